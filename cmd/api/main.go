@@ -7,6 +7,7 @@ import (
 	"github.com/godiidev/appsynex/config"
 	"github.com/godiidev/appsynex/internal/api/router"
 	"github.com/godiidev/appsynex/internal/repository/mysql"
+	"github.com/godiidev/appsynex/pkg/storage"
 )
 
 // @title           AppSynex API
@@ -26,6 +27,11 @@ import (
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
+
+// @securityDefinitions.apikey PortalAuth
+// @in header
+// @name Authorization
+// @description Customer portal token from /portal/v1/auth/login; staff tokens are not accepted
 func main() {
 	// Load configuration
 	cfg, err := config.LoadConfig()
@@ -39,8 +45,21 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	// Index the samples that have no normalized search text yet, e.g. right after the search migration
+	if count, err := mysql.NewSampleRepository(db).FillMissingSearchText(500); err != nil {
+		log.Printf("Failed to index sample search text: %v", err)
+	} else if count > 0 {
+		log.Printf("Indexed search text of %d samples", count)
+	}
+
+	// Initialize blob storage for uploaded files
+	blobStore, err := storage.New(&cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+
 	// Setup router
-	r := router.SetupRouter(db, cfg)
+	r := router.SetupRouter(db, cfg, blobStore)
 
 	// Start server
 	port := cfg.Server.Port
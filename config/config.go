@@ -9,15 +9,19 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	JWT       JWTConfig
+	Storage   StorageConfig
+	Inventory InventoryConfig
+	Finance   FinanceConfig
 }
 
 type ServerConfig struct {
-	Port     string
-	Env      string
-	LogLevel string
+	Port            string
+	Env             string
+	LogLevel        string
+	DefaultLanguage string // vi, en; used when the request has no Accept-Language
 }
 
 type DatabaseConfig struct {
@@ -33,8 +37,32 @@ type DatabaseConfig struct {
 }
 
 type JWTConfig struct {
-	Secret    string
-	ExpiresIn string
+	Secret          string
+	ExpiresIn       string
+	PortalSecret    string // Signs customer portal tokens; required and separate from Secret
+	PortalExpiresIn string
+}
+
+type StorageConfig struct {
+	Driver         string // local, s3
+	LocalPath      string
+	BaseURL        string
+	MaxUploadSize  int64
+	ThumbnailWidth int
+	S3Endpoint     string
+	S3Region       string
+	S3Bucket       string
+	S3AccessKey    string
+	S3SecretKey    string
+	S3UseSSL       bool
+}
+
+type InventoryConfig struct {
+	ReservationTTL time.Duration // How long stock reserved for an order is held unless the request sets an expiry
+}
+
+type FinanceConfig struct {
+	BaseCurrency string // ISO 4217 code reports are converted to unless another one is asked for
 }
 
 func LoadConfig() (*Config, error) {
@@ -47,9 +75,10 @@ func LoadConfig() (*Config, error) {
 
 	config := &Config{
 		Server: ServerConfig{
-			Port:     viper.GetString("PORT"),
-			Env:      viper.GetString("ENV"),
-			LogLevel: viper.GetString("LOG_LEVEL"),
+			Port:            viper.GetString("PORT"),
+			Env:             viper.GetString("ENV"),
+			LogLevel:        viper.GetString("LOG_LEVEL"),
+			DefaultLanguage: viper.GetString("DEFAULT_LANGUAGE"),
 		},
 		Database: DatabaseConfig{
 			Host:            viper.GetString("DB_HOST"),
@@ -63,8 +92,29 @@ func LoadConfig() (*Config, error) {
 			ConnMaxLifetime: viper.GetDuration("DB_CONN_MAX_LIFETIME"),
 		},
 		JWT: JWTConfig{
-			Secret:    viper.GetString("JWT_SECRET"),
-			ExpiresIn: viper.GetString("JWT_EXPIRES_IN"),
+			Secret:          viper.GetString("JWT_SECRET"),
+			ExpiresIn:       viper.GetString("JWT_EXPIRES_IN"),
+			PortalSecret:    viper.GetString("PORTAL_JWT_SECRET"),
+			PortalExpiresIn: viper.GetString("PORTAL_JWT_EXPIRES_IN"),
+		},
+		Storage: StorageConfig{
+			Driver:         viper.GetString("STORAGE_DRIVER"),
+			LocalPath:      viper.GetString("STORAGE_LOCAL_PATH"),
+			BaseURL:        viper.GetString("STORAGE_BASE_URL"),
+			MaxUploadSize:  viper.GetInt64("STORAGE_MAX_UPLOAD_SIZE"),
+			ThumbnailWidth: viper.GetInt("STORAGE_THUMBNAIL_WIDTH"),
+			S3Endpoint:     viper.GetString("S3_ENDPOINT"),
+			S3Region:       viper.GetString("S3_REGION"),
+			S3Bucket:       viper.GetString("S3_BUCKET"),
+			S3AccessKey:    viper.GetString("S3_ACCESS_KEY"),
+			S3SecretKey:    viper.GetString("S3_SECRET_KEY"),
+			S3UseSSL:       viper.GetBool("S3_USE_SSL"),
+		},
+		Inventory: InventoryConfig{
+			ReservationTTL: viper.GetDuration("RESERVATION_TTL"),
+		},
+		Finance: FinanceConfig{
+			BaseCurrency: viper.GetString("BASE_CURRENCY"),
 		},
 	}
 
@@ -78,6 +128,9 @@ func LoadConfig() (*Config, error) {
 	if config.Server.LogLevel == "" {
 		config.Server.LogLevel = "debug"
 	}
+	if config.Server.DefaultLanguage == "" {
+		config.Server.DefaultLanguage = "en"
+	}
 	if config.Database.Charset == "" {
 		config.Database.Charset = "utf8mb4"
 	}
@@ -93,6 +146,36 @@ func LoadConfig() (*Config, error) {
 	if config.JWT.ExpiresIn == "" {
 		config.JWT.ExpiresIn = "24h"
 	}
+	if config.JWT.PortalSecret == "" {
+		return nil, fmt.Errorf("PORTAL_JWT_SECRET is required for customer portal tokens")
+	}
+	if config.JWT.PortalSecret == config.JWT.Secret {
+		log.Println("Warning: PORTAL_JWT_SECRET equals JWT_SECRET; only the token audience keeps portal and staff tokens apart")
+	}
+	if config.JWT.PortalExpiresIn == "" {
+		config.JWT.PortalExpiresIn = "12h"
+	}
+	if config.Storage.Driver == "" {
+		config.Storage.Driver = "local"
+	}
+	if config.Storage.LocalPath == "" {
+		config.Storage.LocalPath = "./uploads"
+	}
+	if config.Storage.BaseURL == "" {
+		config.Storage.BaseURL = "/uploads"
+	}
+	if config.Storage.MaxUploadSize == 0 {
+		config.Storage.MaxUploadSize = 10 << 20 // 10MB
+	}
+	if config.Storage.ThumbnailWidth == 0 {
+		config.Storage.ThumbnailWidth = 320
+	}
+	if config.Inventory.ReservationTTL == 0 {
+		config.Inventory.ReservationTTL = 72 * time.Hour
+	}
+	if config.Finance.BaseCurrency == "" {
+		config.Finance.BaseCurrency = "VND"
+	}
 
 	return config, nil
 }
@@ -5,6 +5,7 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -42,9 +43,73 @@ func (h *CategoryHandler) GetAll(c *gin.Context) {
 		return
 	}
 
+	localize(c, categories)
+
 	c.JSON(http.StatusOK, categories)
 }
 
+// GetTree godoc
+// @Summary     Get category tree
+// @Description Get the nested category hierarchy with breadcrumb paths and product/sample counts per node
+// @Tags        categories
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} response.CategoryTreeResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /categories/tree [get]
+func (h *CategoryHandler) GetTree(c *gin.Context) {
+	tree, err := h.categoryService.GetCategoryTree()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	localize(c, tree)
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// Move godoc
+// @Summary     Move category
+// @Description Move a category under another parent (or to the root with a null parent). Moves that would create a cycle are rejected.
+// @Tags        categories
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Category ID"
+// @Param       request body request.MoveCategoryRequest true "New parent"
+// @Security    BearerAuth
+// @Success     200 {object} response.CategoryDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /categories/{id}/move [put]
+func (h *CategoryHandler) Move(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.MoveCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.categoryService.MoveCategory(uint(id), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	localize(c, category)
+
+	c.JSON(http.StatusOK, category)
+}
+
 // GetByID godoc
 // @Summary     Get category by ID
 // @Description Get a product category by ID
@@ -73,6 +138,8 @@ func (h *CategoryHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	localize(c, category)
+
 	c.JSON(http.StatusOK, category)
 }
 
@@ -103,6 +170,8 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 		return
 	}
 
+	localize(c, category)
+
 	c.JSON(http.StatusCreated, category)
 }
 
@@ -141,22 +210,30 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 		return
 	}
 
+	localize(c, category)
+
 	c.JSON(http.StatusOK, category)
 }
 
 // Delete godoc
 // @Summary     Delete a category
-// @Description Delete a product category by ID
+// @Description Delete a product category by ID. strategy=block (default) refuses while sub-categories, products or samples reference it;
+// @Description strategy=reassign moves them to target_category_id; strategy=cascade deletes the whole subtree with its products and samples.
+// @Description dry_run=true only reports the impact of each strategy.
 // @Tags        categories
 // @Accept      json
 // @Produce     json
 // @Param       id path int true "Category ID"
+// @Param       strategy query string false "Delete strategy" Enums(block, reassign, cascade)
+// @Param       target_category_id query int false "Category receiving sub-categories, products and samples (reassign)"
+// @Param       dry_run query bool false "Preview without deleting"
 // @Security    BearerAuth
-// @Success     204 {object} nil
+// @Success     200 {object} response.CategoryDeleteResponse
 // @Failure     400 {object} response.ErrorResponse
 // @Failure     401 {object} response.ErrorResponse
 // @Failure     403 {object} response.ErrorResponse
 // @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
 // @Failure     500 {object} response.ErrorResponse
 // @Router      /categories/{id} [delete]
 func (h *CategoryHandler) Delete(c *gin.Context) {
@@ -166,10 +243,21 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.categoryService.DeleteCategory(uint(id)); err != nil {
+	var req request.DeleteCategoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.categoryService.DeleteCategory(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrCategoryInUse) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "details": result})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, result)
 }
\ No newline at end of file
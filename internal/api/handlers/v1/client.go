@@ -0,0 +1,31 @@
+// File: internal/api/handlers/v1/client.go
+// Tạo tại: internal/api/handlers/v1/client.go
+// Mục đích: Lấy thông tin client (IP, thiết bị) và người dùng hiện tại của request để ghi nhật ký hoạt động
+
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/pkg/auth"
+)
+
+// clientInfo returns the caller's IP address and user agent
+func clientInfo(c *gin.Context) request.ClientInfo {
+	return request.ClientInfo{
+		IPAddress:  c.ClientIP(),
+		DeviceInfo: c.Request.UserAgent(),
+	}
+}
+
+// currentUserID returns the ID of the authenticated staff user, writing a 401 when it is missing
+func currentUserID(c *gin.Context) (uint, bool) {
+	userClaims, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return 0, false
+	}
+	return userClaims.(*auth.JWTClaims).ID, true
+}
@@ -0,0 +1,265 @@
+// File: internal/api/handlers/v1/customer.go
+// Tạo tại: internal/api/handlers/v1/customer.go
+// Mục đích: Handler xử lý các API quản lý khách hàng (CRUD customers, tìm kiếm, phân trang)
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type CustomerHandler struct {
+	customerService services.CustomerService
+	activityService services.CustomerActivityService
+}
+
+func NewCustomerHandler(customerService services.CustomerService, activityService services.CustomerActivityService) *CustomerHandler {
+	return &CustomerHandler{
+		customerService: customerService,
+		activityService: activityService,
+	}
+}
+
+// GetAll godoc
+// @Summary     Get all customers
+// @Description Get a list of customers with search, status filter, sorting and pagination.
+// @Description With pagination=cursor the response is a response.CursorPaginatedResponse with next_cursor instead of page counts.
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       search query string false "Search code, name, company, email, phone or tax ID"
+// @Param       account_status query string false "Filter by status" Enums(active, inactive, blocked)
+// @Param       sort_by query string false "Sort fields, comma-separated, '-' prefix for descending"
+// @Param       sort_order query string false "Direction of unprefixed sort fields" Enums(asc, desc)
+// @Param       pagination query string false "offset (default) or cursor"
+// @Param       cursor query string false "next_cursor from the previous page"
+// @Param       total query string false "Cursor mode total: none (default), estimate or exact"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers [get]
+func (h *CustomerHandler) GetAll(c *gin.Context) {
+	var req request.CustomerFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var res interface{}
+	var err error
+	if req.UsesCursor() {
+		res, err = h.customerService.GetCustomersByCursor(req)
+	} else {
+		res, err = h.customerService.GetCustomers(req)
+	}
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetByID godoc
+// @Summary     Get customer by ID
+// @Description Get a customer with order, sample dispatch and lap dip counts and the latest of each
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.CustomerDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/{id} [get]
+func (h *CustomerHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	customer, err := h.customerService.GetCustomerByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, customer)
+}
+
+// Create godoc
+// @Summary     Create a new customer
+// @Description Create a customer. customer_code is generated (KH00001, KH00002, ...) when omitted.
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       customer body request.CreateCustomerRequest true "Customer to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.CustomerDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers [post]
+func (h *CustomerHandler) Create(c *gin.Context) {
+	var req request.CreateCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer, err := h.customerService.CreateCustomer(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, customer)
+}
+
+// Update godoc
+// @Summary     Update a customer
+// @Description Update a customer by ID
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Param       customer body request.UpdateCustomerRequest true "Customer data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.CustomerDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/{id} [put]
+func (h *CustomerHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer, err := h.customerService.UpdateCustomer(uint(id), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, customer)
+}
+
+// Delete godoc
+// @Summary     Delete a customer
+// @Description Delete a customer by ID. Customers with orders cannot be deleted; set them inactive instead.
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/{id} [delete]
+func (h *CustomerHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.customerService.DeleteCustomer(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Activity godoc
+// @Summary     Get customer activity timeline
+// @Description Get the customer's activity (samples received, lap dips approved, orders placed or cancelled, merges), newest first.
+// @Description With pagination=cursor the response is a response.CursorPaginatedResponse with next_cursor instead of page counts.
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       activity_type query []string false "Filter by activity types" Enums(SAMPLE_RECEIVED, LAP_DIP_APPROVED, ORDER_PLACED, ORDER_CANCELLED, CUSTOMER_MERGED)
+// @Param       date_from query string false "On or after (YYYY-MM-DD)"
+// @Param       date_to query string false "On or before (YYYY-MM-DD)"
+// @Param       sort_order query string false "Order by activity date" Enums(asc, desc)
+// @Param       pagination query string false "offset (default) or cursor"
+// @Param       cursor query string false "next_cursor from the previous page"
+// @Param       total query string false "Cursor mode total: none (default), estimate or exact"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/{id}/activity [get]
+func (h *CustomerHandler) Activity(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.CustomerActivityFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var res interface{}
+	if req.UsesCursor() {
+		res, err = h.activityService.GetActivityByCursor(uint(id), req)
+	} else {
+		res, err = h.activityService.GetActivity(uint(id), req)
+	}
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
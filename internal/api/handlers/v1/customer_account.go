@@ -0,0 +1,188 @@
+// File: internal/api/handlers/v1/customer_account.go
+// Tạo tại: internal/api/handlers/v1/customer_account.go
+// Mục đích: Handler cho nhân viên quản lý tài khoản đăng nhập portal của khách hàng
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type CustomerAccountHandler struct {
+	accountService services.CustomerAccountService
+}
+
+func NewCustomerAccountHandler(accountService services.CustomerAccountService) *CustomerAccountHandler {
+	return &CustomerAccountHandler{
+		accountService: accountService,
+	}
+}
+
+// customerAccountIDs parses the customer and portal account IDs from the path
+func customerAccountIDs(c *gin.Context) (uint, uint, bool) {
+	customerID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return 0, 0, false
+	}
+	accountID, err := strconv.ParseUint(c.Param("accountId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID format"})
+		return 0, 0, false
+	}
+	return uint(customerID), uint(accountID), true
+}
+
+// GetAll godoc
+// @Summary     List portal accounts
+// @Description List the customer portal accounts of a customer
+// @Tags        customers
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Security    BearerAuth
+// @Success     200 {array} response.CustomerAccountResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/{id}/portal-accounts [get]
+func (h *CustomerAccountHandler) GetAll(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	accounts, err := h.accountService.GetAccounts(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
+
+// Create godoc
+// @Summary     Create a portal account
+// @Description Create a customer portal login for a buyer of the customer
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Param       account body request.CreateCustomerAccountRequest true "Portal account to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.CustomerAccountResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /customers/{id}/portal-accounts [post]
+func (h *CustomerAccountHandler) Create(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.CreateCustomerAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.accountService.CreateAccount(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, account)
+}
+
+// Update godoc
+// @Summary     Update a portal account
+// @Description Rename, disable or re-enable a portal account, or reset its password
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Param       accountId path int true "Portal account ID"
+// @Param       account body request.UpdateCustomerAccountRequest true "Portal account data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.CustomerAccountResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /customers/{id}/portal-accounts/{accountId} [put]
+func (h *CustomerAccountHandler) Update(c *gin.Context) {
+	customerID, accountID, ok := customerAccountIDs(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateCustomerAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.accountService.UpdateAccount(customerID, accountID, req)
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+// Delete godoc
+// @Summary     Delete a portal account
+// @Description Delete a customer portal account
+// @Tags        customers
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Param       accountId path int true "Portal account ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /customers/{id}/portal-accounts/{accountId} [delete]
+func (h *CustomerAccountHandler) Delete(c *gin.Context) {
+	customerID, accountID, ok := customerAccountIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.accountService.DeleteAccount(customerID, accountID); err != nil {
+		if errors.Is(err, services.ErrCustomerAccountNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
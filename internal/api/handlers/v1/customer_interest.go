@@ -0,0 +1,314 @@
+// File: internal/api/handlers/v1/customer_interest.go
+// Tạo tại: internal/api/handlers/v1/customer_interest.go
+// Mục đích: Handler xử lý API danh sách lưu, lịch sử tìm kiếm của khách hàng và báo cáo quan tâm
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type CustomerInterestHandler struct {
+	interestService services.CustomerInterestService
+}
+
+func NewCustomerInterestHandler(interestService services.CustomerInterestService) *CustomerInterestHandler {
+	return &CustomerInterestHandler{
+		interestService: interestService,
+	}
+}
+
+// GetSavedItems godoc
+// @Summary     Get customer saved items
+// @Description Get the products and samples a customer saved, newest first
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       item_type query string false "Filter by item type" Enums(product, sample)
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/{id}/saved-items [get]
+func (h *CustomerInterestHandler) GetSavedItems(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.SavedItemFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items, err := h.interestService.GetSavedItems(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	localize(c, items)
+	c.JSON(http.StatusOK, items)
+}
+
+// SaveItem godoc
+// @Summary     Save an item for a customer
+// @Description Add a product or a sample to the customer's saved items, with optional notes
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Param       item body request.CreateSavedItemRequest true "Product or sample to save"
+// @Security    BearerAuth
+// @Success     201 {object} response.SavedItemResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/{id}/saved-items [post]
+func (h *CustomerInterestHandler) SaveItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.CreateSavedItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.interestService.SaveItem(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	localize(c, item)
+	c.JSON(http.StatusCreated, item)
+}
+
+// UpdateSavedItem godoc
+// @Summary     Update a saved item
+// @Description Update the notes of a customer's saved item
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Param       itemId path int true "Saved item ID"
+// @Param       item body request.UpdateSavedItemRequest true "Notes"
+// @Security    BearerAuth
+// @Success     200 {object} response.SavedItemResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/{id}/saved-items/{itemId} [put]
+func (h *CustomerInterestHandler) UpdateSavedItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	itemID, err := strconv.ParseUint(c.Param("itemId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID format"})
+		return
+	}
+
+	var req request.UpdateSavedItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.interestService.UpdateSavedItem(uint(id), uint(itemID), req)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	localize(c, item)
+	c.JSON(http.StatusOK, item)
+}
+
+// RemoveSavedItem godoc
+// @Summary     Remove a saved item
+// @Description Remove a product or sample from the customer's saved items
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Param       itemId path int true "Saved item ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/{id}/saved-items/{itemId} [delete]
+func (h *CustomerInterestHandler) RemoveSavedItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	itemID, err := strconv.ParseUint(c.Param("itemId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID format"})
+		return
+	}
+
+	if err := h.interestService.RemoveSavedItem(uint(id), uint(itemID)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSearchHistory godoc
+// @Summary     Get customer search history
+// @Description Get what a customer searched for, newest first
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       date_from query string false "On or after (YYYY-MM-DD)"
+// @Param       date_to query string false "On or before (YYYY-MM-DD)"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/{id}/search-history [get]
+func (h *CustomerInterestHandler) GetSearchHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.SearchHistoryFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	history, err := h.interestService.GetSearchHistory(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// RecordSearch godoc
+// @Summary     Record a customer search
+// @Description Add a search to the customer's history; the query is normalized for the interest report
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Customer ID"
+// @Param       search body request.RecordSearchRequest true "Search to record"
+// @Security    BearerAuth
+// @Success     201 {object} response.SearchHistoryResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/{id}/search-history [post]
+func (h *CustomerInterestHandler) RecordSearch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.RecordSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	search, err := h.interestService.RecordSearch(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, search)
+}
+
+// InterestReport godoc
+// @Summary     Customer interest report
+// @Description Top searched terms and most saved products and samples over a period, for collection planning
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       date_from query string false "Period start (YYYY-MM-DD), default 30 days before date_to"
+// @Param       date_to query string false "Period end, inclusive (YYYY-MM-DD), default today"
+// @Param       limit query int false "Entries per list (1-100, default 10)"
+// @Security    BearerAuth
+// @Success     200 {object} response.CustomerInterestReportResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/reports/interests [get]
+func (h *CustomerInterestHandler) InterestReport(c *gin.Context) {
+	var req request.CustomerInterestReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.interestService.GetInterestReport(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	localize(c, report)
+	c.JSON(http.StatusOK, report)
+}
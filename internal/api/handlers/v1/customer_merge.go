@@ -0,0 +1,116 @@
+// File: internal/api/handlers/v1/customer_merge.go
+// Tạo tại: internal/api/handlers/v1/customer_merge.go
+// Mục đích: Handler xử lý API phát hiện và gộp khách hàng trùng lặp
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/pkg/auth"
+)
+
+type CustomerMergeHandler struct {
+	mergeService services.CustomerMergeService
+}
+
+func NewCustomerMergeHandler(mergeService services.CustomerMergeService) *CustomerMergeHandler {
+	return &CustomerMergeHandler{
+		mergeService: mergeService,
+	}
+}
+
+// FindDuplicates godoc
+// @Summary     Find duplicate customers
+// @Description Find likely duplicate customers by tax ID, normalized email and phone, and fuzzy company name.
+// @Description Each pair has a 0-100 score and the fields that matched.
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       customer_id query int false "Only duplicates of this customer"
+// @Param       min_score query int false "Lowest score returned (1-100, default 35)"
+// @Param       limit query int false "Maximum pairs (1-200, default 50)"
+// @Security    BearerAuth
+// @Success     200 {object} response.DuplicateCustomersResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/duplicates [get]
+func (h *CustomerMergeHandler) FindDuplicates(c *gin.Context) {
+	var req request.DuplicateSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	duplicates, err := h.mergeService.FindDuplicates(req)
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, duplicates)
+}
+
+// Merge godoc
+// @Summary     Merge a duplicate customer
+// @Description Move the duplicate's orders, sample dispatches, lap dip tests, sales, activity and saved items to this customer
+// @Description in one transaction, then delete the duplicate. The merge is recorded for audit. Use dry_run to preview.
+// @Tags        customers
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Surviving customer ID"
+// @Param       merge body request.MergeCustomerRequest true "Duplicate to merge"
+// @Security    BearerAuth
+// @Success     200 {object} response.CustomerMergeResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /customers/{id}/merge [post]
+func (h *CustomerMergeHandler) Merge(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.MergeCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get current user from context
+	userClaims, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	claims := userClaims.(*auth.JWTClaims)
+
+	result, err := h.mergeService.MergeCustomers(uint(id), req, claims.ID, clientInfo(c))
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,620 @@
+// File: internal/api/handlers/v1/dyeing.go
+// Tạo tại: internal/api/handlers/v1/dyeing.go
+// Mục đích: Handler nhà nhuộm, lệnh nhuộm và lô nhuộm trả về: kết quả co rút, độ bền màu, nghiệm thu nhập kho
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type DyeingHandler struct {
+	dyeingService services.DyeingService
+}
+
+func NewDyeingHandler(dyeingService services.DyeingService) *DyeingHandler {
+	return &DyeingHandler{
+		dyeingService: dyeingService,
+	}
+}
+
+// GetSubcontractors godoc
+// @Summary     Get all dye houses
+// @Description Get the dye houses, optionally filtered by name
+// @Tags        dyeing
+// @Produce     json
+// @Param       search query string false "Name"
+// @Security    BearerAuth
+// @Success     200 {array}  response.DyeingSubcontractorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /dyeing/subcontractors [get]
+func (h *DyeingHandler) GetSubcontractors(c *gin.Context) {
+	subcontractors, err := h.dyeingService.GetSubcontractors(c.Query("search"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, subcontractors)
+}
+
+// GetSubcontractorByID godoc
+// @Summary     Get dye house by ID
+// @Description Get a dye house
+// @Tags        dyeing
+// @Produce     json
+// @Param       id path int true "Dye house ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.DyeingSubcontractorResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /dyeing/subcontractors/{id} [get]
+func (h *DyeingHandler) GetSubcontractorByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	subcontractor, err := h.dyeingService.GetSubcontractorByID(uint(id))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subcontractor)
+}
+
+// CreateSubcontractor godoc
+// @Summary     Create a dye house
+// @Description Add a dye house; its currency is the default for its dyeing jobs
+// @Tags        dyeing
+// @Accept      json
+// @Produce     json
+// @Param       subcontractor body request.CreateDyeingSubcontractorRequest true "Dye house to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.DyeingSubcontractorResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /dyeing/subcontractors [post]
+func (h *DyeingHandler) CreateSubcontractor(c *gin.Context) {
+	var req request.CreateDyeingSubcontractorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subcontractor, err := h.dyeingService.CreateSubcontractor(req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, subcontractor)
+}
+
+// UpdateSubcontractor godoc
+// @Summary     Update a dye house
+// @Description Change the name, contact or currency of a dye house
+// @Tags        dyeing
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Dye house ID"
+// @Param       subcontractor body request.UpdateDyeingSubcontractorRequest true "Dye house data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.DyeingSubcontractorResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /dyeing/subcontractors/{id} [put]
+func (h *DyeingHandler) UpdateSubcontractor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateDyeingSubcontractorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subcontractor, err := h.dyeingService.UpdateSubcontractor(uint(id), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subcontractor)
+}
+
+// DeleteSubcontractor godoc
+// @Summary     Delete a dye house
+// @Description Delete a dye house that has no open dyeing jobs
+// @Tags        dyeing
+// @Produce     json
+// @Param       id path int true "Dye house ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /dyeing/subcontractors/{id} [delete]
+func (h *DyeingHandler) DeleteSubcontractor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.dyeingService.DeleteSubcontractor(uint(id)); err != nil {
+		if errors.Is(err, services.ErrDyeingSubcontractorNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetJobs godoc
+// @Summary     Get all dyeing jobs
+// @Description Get dyeing jobs with their returned lots, optionally filtered by status, dye house, sales order,
+// @Description weaving order or expected return date
+// @Tags        dyeing
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       search query string false "Job code, dye house, target color or lap dip reference"
+// @Param       status query []string false "sent, partially_returned, returned, cancelled" collectionFormat(multi)
+// @Param       subcontractor_id query int false "Filter by dye house"
+// @Param       order_id query int false "Filter by sales order"
+// @Param       weaving_order_id query int false "Filter by weaving order"
+// @Param       due_by query string false "Expected back on or before this day (YYYY-MM-DD)"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /dyeing/jobs [get]
+func (h *DyeingHandler) GetJobs(c *gin.Context) {
+	var req request.DyeingJobFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	jobs, err := h.dyeingService.GetJobs(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetJobByID godoc
+// @Summary     Get dyeing job by ID
+// @Description Get a dyeing job with its returned lots and their shrinkage and color fastness results
+// @Tags        dyeing
+// @Produce     json
+// @Param       id path int true "Dyeing job ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.DyeingJobResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /dyeing/jobs/{id} [get]
+func (h *DyeingHandler) GetJobByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	job, err := h.dyeingService.GetJobByID(uint(id))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// CreateJob godoc
+// @Summary     Send greige to a dye house
+// @Description Create a dyeing job for a target color. The weaving order the greige comes from must have no failed
+// @Description inspections without corrective action; a completed one is sent to dyeing with the job. It gives the
+// @Description sales order, product and greige length by default; a lap dip test gives the color and reference.
+// @Tags        dyeing
+// @Accept      json
+// @Produce     json
+// @Param       job body request.CreateDyeingJobRequest true "Dyeing job to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.DyeingJobResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Failure     422 {object} response.ErrorResponse
+// @Router      /dyeing/jobs [post]
+func (h *DyeingHandler) CreateJob(c *gin.Context) {
+	var req request.CreateDyeingJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.dyeingService.CreateJob(req, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, job)
+}
+
+// UpdateJob godoc
+// @Summary     Update a dyeing job
+// @Description Change the color, greige sent, expected return date or rate of a job still at the dye house
+// @Tags        dyeing
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Dyeing job ID"
+// @Param       job body request.UpdateDyeingJobRequest true "Dyeing job data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.DyeingJobResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /dyeing/jobs/{id} [put]
+func (h *DyeingHandler) UpdateJob(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateDyeingJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := h.dyeingService.UpdateJob(uint(id), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// ChangeJobStatus godoc
+// @Summary     Close or cancel a dyeing job
+// @Description Mark a job returned once all its lots are accepted or rejected, or cancel a job with no lots yet
+// @Tags        dyeing
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Dyeing job ID"
+// @Param       status body request.ChangeDyeingJobStatusRequest true "New status"
+// @Security    BearerAuth
+// @Success     200 {object} response.DyeingJobResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /dyeing/jobs/{id}/status [put]
+func (h *DyeingHandler) ChangeJobStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.ChangeDyeingJobStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := h.dyeingService.ChangeJobStatus(uint(id), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// DeleteJob godoc
+// @Summary     Delete a dyeing job
+// @Description Delete a dyeing job that has no returned lots
+// @Tags        dyeing
+// @Produce     json
+// @Param       id path int true "Dyeing job ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /dyeing/jobs/{id} [delete]
+func (h *DyeingHandler) DeleteJob(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.dyeingService.DeleteJob(uint(id)); err != nil {
+		if errors.Is(err, services.ErrDyeingJobNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddLot godoc
+// @Summary     Record a returned dyeing lot
+// @Description Record a lot the dye house sent back, optionally with its shrinkage and color fastness results.
+// @Description The lot code defaults to the job code and the lot's number on the job.
+// @Tags        dyeing
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Dyeing job ID"
+// @Param       lot body request.CreateDyeingLotRequest true "Returned lot"
+// @Security    BearerAuth
+// @Success     201 {object} response.DyeingLotResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /dyeing/jobs/{id}/lots [post]
+func (h *DyeingHandler) AddLot(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.CreateDyeingLotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	lot, err := h.dyeingService.AddLot(uint(id), req, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, lot)
+}
+
+// RecordLotResults godoc
+// @Summary     Record dyeing lot test results
+// @Description Record or correct the shrinkage (%) and color fastness grades of a lot not yet accepted or rejected
+// @Tags        dyeing
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Dyeing lot ID"
+// @Param       results body request.DyeingLotResultsRequest true "Test results"
+// @Security    BearerAuth
+// @Success     200 {object} response.DyeingLotResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /dyeing/lots/{id}/results [put]
+func (h *DyeingHandler) RecordLotResults(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.DyeingLotResultsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	lot, err := h.dyeingService.RecordLotResults(uint(id), req, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lot)
+}
+
+// AcceptLot godoc
+// @Summary     Accept a dyeing lot
+// @Description Accept a tested lot; it is stocked as an available warehouse lot of the job's product and color
+// @Description with the same lot code
+// @Tags        dyeing
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Dyeing lot ID"
+// @Param       acceptance body request.AcceptDyeingLotRequest true "Warehouse location and notes"
+// @Security    BearerAuth
+// @Success     200 {object} response.DyeingLotResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /dyeing/lots/{id}/accept [post]
+func (h *DyeingHandler) AcceptLot(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.AcceptDyeingLotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	lot, err := h.dyeingService.AcceptLot(uint(id), req, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lot)
+}
+
+// RejectLot godoc
+// @Summary     Reject a dyeing lot
+// @Description Reject a lot with the quality issues found; it does not go into stock
+// @Tags        dyeing
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Dyeing lot ID"
+// @Param       rejection body request.RejectDyeingLotRequest true "Quality issues"
+// @Security    BearerAuth
+// @Success     200 {object} response.DyeingLotResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /dyeing/lots/{id}/reject [post]
+func (h *DyeingHandler) RejectLot(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.RejectDyeingLotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	lot, err := h.dyeingService.RejectLot(uint(id), req, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lot)
+}
+
+// DeleteLot godoc
+// @Summary     Delete a dyeing lot
+// @Description Delete a returned lot recorded by mistake, while it is not yet accepted or rejected
+// @Tags        dyeing
+// @Produce     json
+// @Param       id path int true "Dyeing lot ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /dyeing/lots/{id} [delete]
+func (h *DyeingHandler) DeleteLot(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.dyeingService.DeleteLot(uint(id)); err != nil {
+		if errors.Is(err, services.ErrDyeingLotNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *DyeingHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrDyeingSubcontractorNotFound),
+		errors.Is(err, services.ErrDyeingJobNotFound),
+		errors.Is(err, services.ErrDyeingLotNotFound),
+		errors.Is(err, services.ErrWeavingOrderNotFound),
+		errors.Is(err, services.ErrLapDipNotFound),
+		errors.Is(err, services.ErrOrderNotFound),
+		errors.Is(err, services.ErrProductNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrDyeingJobStatusChanged),
+		errors.Is(err, services.ErrDyeingLotStatusChanged),
+		errors.Is(err, services.ErrWeavingOrderStatusChanged):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrWeavingOrderGuardFailed):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,234 @@
+// File: internal/api/handlers/v1/exchange_rate.go
+// Tạo tại: internal/api/handlers/v1/exchange_rate.go
+// Mục đích: Handler quản lý tỷ giá theo ngày hiệu lực và quy đổi số tiền giữa các loại tiền
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type ExchangeRateHandler struct {
+	exchangeRateService services.ExchangeRateService
+}
+
+func NewExchangeRateHandler(exchangeRateService services.ExchangeRateService) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		exchangeRateService: exchangeRateService,
+	}
+}
+
+// GetAll godoc
+// @Summary     Get all exchange rates
+// @Description Get exchange rates, newest effective date first, optionally of one currency pair
+// @Tags        exchange-rates
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       from_currency query string false "Filter by source currency"
+// @Param       to_currency query string false "Filter by target currency"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /exchange-rates [get]
+func (h *ExchangeRateHandler) GetAll(c *gin.Context) {
+	var req request.ExchangeRateFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rates, err := h.exchangeRateService.GetRates(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+// GetByID godoc
+// @Summary     Get exchange rate by ID
+// @Description Get an exchange rate
+// @Tags        exchange-rates
+// @Produce     json
+// @Param       id path int true "Exchange rate ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.ExchangeRateResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /exchange-rates/{id} [get]
+func (h *ExchangeRateHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	rate, err := h.exchangeRateService.GetRateByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rate)
+}
+
+// Create godoc
+// @Summary     Create an exchange rate
+// @Description Set the value of one unit of from_currency in to_currency from effective_date on.
+// @Description The rate applies until a later rate of the pair takes effect; the opposite pair uses its inverse.
+// @Tags        exchange-rates
+// @Accept      json
+// @Produce     json
+// @Param       rate body request.CreateExchangeRateRequest true "Exchange rate to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.ExchangeRateResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /exchange-rates [post]
+func (h *ExchangeRateHandler) Create(c *gin.Context) {
+	var req request.CreateExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	rate, err := h.exchangeRateService.CreateRate(req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rate)
+}
+
+// Update godoc
+// @Summary     Update an exchange rate
+// @Description Correct the rate, effective date, source or notes of an exchange rate
+// @Tags        exchange-rates
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Exchange rate ID"
+// @Param       rate body request.UpdateExchangeRateRequest true "Exchange rate data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.ExchangeRateResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /exchange-rates/{id} [put]
+func (h *ExchangeRateHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate, err := h.exchangeRateService.UpdateRate(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrExchangeRateNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rate)
+}
+
+// Delete godoc
+// @Summary     Delete an exchange rate
+// @Description Delete an exchange rate. Amounts already converted keep their values.
+// @Tags        exchange-rates
+// @Produce     json
+// @Param       id path int true "Exchange rate ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /exchange-rates/{id} [delete]
+func (h *ExchangeRateHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.exchangeRateService.DeleteRate(uint(id)); err != nil {
+		if errors.Is(err, services.ErrExchangeRateNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Convert godoc
+// @Summary     Convert an amount
+// @Description Convert an amount between currencies at the rate effective on the date
+// @Tags        exchange-rates
+// @Produce     json
+// @Param       amount query string true "Amount, e.g. 1250.50"
+// @Param       from query string true "Source currency code"
+// @Param       to query string false "Target currency code, defaults to the base currency"
+// @Param       date query string false "Rate date (YYYY-MM-DD), defaults to today"
+// @Security    BearerAuth
+// @Success     200 {object} response.ConversionResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     422 {object} response.ErrorResponse
+// @Router      /exchange-rates/convert [get]
+func (h *ExchangeRateHandler) Convert(c *gin.Context) {
+	var req request.ConvertAmountRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conversion, err := h.exchangeRateService.ConvertAmount(req)
+	if err != nil {
+		if errors.Is(err, services.ErrNoExchangeRate) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, conversion)
+}
@@ -0,0 +1,61 @@
+// File: internal/api/handlers/v1/finance.go
+// Tạo tại: internal/api/handlers/v1/finance.go
+// Mục đích: Handler báo cáo tài chính, tổng doanh số đơn hàng quy về một loại tiền
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type FinanceHandler struct {
+	exchangeRateService services.ExchangeRateService
+}
+
+func NewFinanceHandler(exchangeRateService services.ExchangeRateService) *FinanceHandler {
+	return &FinanceHandler{
+		exchangeRateService: exchangeRateService,
+	}
+}
+
+// SalesSummary godoc
+// @Summary     Sales summary in one currency
+// @Description Total the non-cancelled orders created in a period, per order currency and converted into
+// @Description one currency at the exchange rate of each order day
+// @Tags        finance
+// @Produce     json
+// @Param       from query string true "First day (YYYY-MM-DD)"
+// @Param       to query string true "Last day, inclusive (YYYY-MM-DD)"
+// @Param       currency query string false "Report currency, defaults to the base currency"
+// @Security    BearerAuth
+// @Success     200 {object} response.SalesSummaryResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     422 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /finance/sales-summary [get]
+func (h *FinanceHandler) SalesSummary(c *gin.Context) {
+	var req request.SalesSummaryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	summary, err := h.exchangeRateService.SalesSummary(req)
+	if err != nil {
+		if errors.Is(err, services.ErrNoExchangeRate) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,381 @@
+// File: internal/api/handlers/v1/image.go
+// Tạo tại: internal/api/handlers/v1/image.go
+// Mục đích: Handler upload và quản lý hình ảnh cho sample/product (multipart upload, ảnh chính, thứ tự)
+
+package v1
+
+import (
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type ImageHandler struct {
+	imageService  services.ImageService
+	maxUploadSize int64
+}
+
+func NewImageHandler(imageService services.ImageService, maxUploadSize int64) *ImageHandler {
+	return &ImageHandler{
+		imageService:  imageService,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+// GetSampleImages godoc
+// @Summary     Get sample images
+// @Description Get all images of a sample ordered by display order
+// @Tags        samples
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Sample ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.ImagesResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /samples/{id}/images [get]
+func (h *ImageHandler) GetSampleImages(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	images, err := h.imageService.GetSampleImages(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, images)
+}
+
+// UploadSampleImages godoc
+// @Summary     Upload sample images
+// @Description Upload one or more images for a sample (multipart field "images"). Thumbnails are generated automatically.
+// @Tags        samples
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       id path int true "Sample ID"
+// @Param       images formData file true "Image files (jpeg, png, gif, webp)"
+// @Security    BearerAuth
+// @Success     201 {object} response.ImagesResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /samples/{id}/images [post]
+func (h *ImageHandler) UploadSampleImages(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	files, err := h.readImageFiles(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	images, err := h.imageService.UploadSampleImages(uint(id), files)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, images)
+}
+
+// SetPrimarySampleImage godoc
+// @Summary     Set primary sample image
+// @Description Mark an image as the primary image of a sample
+// @Tags        samples
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Sample ID"
+// @Param       imageId path int true "Image ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.ImagesResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /samples/{id}/images/{imageId}/primary [put]
+func (h *ImageHandler) SetPrimarySampleImage(c *gin.Context) {
+	id, imageID, ok := parseImagePath(c)
+	if !ok {
+		return
+	}
+
+	images, err := h.imageService.SetPrimarySampleImage(id, imageID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, images)
+}
+
+// ReorderSampleImages godoc
+// @Summary     Reorder sample images
+// @Description Set the display order of all images of a sample
+// @Tags        samples
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Sample ID"
+// @Param       order body request.ReorderImagesRequest true "Image IDs in display order"
+// @Security    BearerAuth
+// @Success     200 {object} response.ImagesResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /samples/{id}/images/reorder [put]
+func (h *ImageHandler) ReorderSampleImages(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.ReorderImagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	images, err := h.imageService.ReorderSampleImages(uint(id), req.ImageIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, images)
+}
+
+// DeleteSampleImage godoc
+// @Summary     Delete sample image
+// @Description Delete an image (and its thumbnail) from a sample
+// @Tags        samples
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Sample ID"
+// @Param       imageId path int true "Image ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /samples/{id}/images/{imageId} [delete]
+func (h *ImageHandler) DeleteSampleImage(c *gin.Context) {
+	id, imageID, ok := parseImagePath(c)
+	if !ok {
+		return
+	}
+
+	if err := h.imageService.DeleteSampleImage(id, imageID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetProductImages godoc
+// @Summary     Get product images
+// @Description Get all images of a product ordered by display order
+// @Tags        products
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Product ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.ImagesResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /products/{id}/images [get]
+func (h *ImageHandler) GetProductImages(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	images, err := h.imageService.GetProductImages(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, images)
+}
+
+// UploadProductImages godoc
+// @Summary     Upload product images
+// @Description Upload one or more images for a product (multipart field "images"). Thumbnails are generated automatically.
+// @Tags        products
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       id path int true "Product ID"
+// @Param       images formData file true "Image files (jpeg, png, gif, webp)"
+// @Security    BearerAuth
+// @Success     201 {object} response.ImagesResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /products/{id}/images [post]
+func (h *ImageHandler) UploadProductImages(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	files, err := h.readImageFiles(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	images, err := h.imageService.UploadProductImages(uint(id), files)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, images)
+}
+
+// SetPrimaryProductImage godoc
+// @Summary     Set primary product image
+// @Description Mark an image as the primary image of a product
+// @Tags        products
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Product ID"
+// @Param       imageId path int true "Image ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.ImagesResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /products/{id}/images/{imageId}/primary [put]
+func (h *ImageHandler) SetPrimaryProductImage(c *gin.Context) {
+	id, imageID, ok := parseImagePath(c)
+	if !ok {
+		return
+	}
+
+	images, err := h.imageService.SetPrimaryProductImage(id, imageID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, images)
+}
+
+// ReorderProductImages godoc
+// @Summary     Reorder product images
+// @Description Set the display order of all images of a product
+// @Tags        products
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Product ID"
+// @Param       order body request.ReorderImagesRequest true "Image IDs in display order"
+// @Security    BearerAuth
+// @Success     200 {object} response.ImagesResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /products/{id}/images/reorder [put]
+func (h *ImageHandler) ReorderProductImages(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.ReorderImagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	images, err := h.imageService.ReorderProductImages(uint(id), req.ImageIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, images)
+}
+
+// DeleteProductImage godoc
+// @Summary     Delete product image
+// @Description Delete an image (and its thumbnail) from a product
+// @Tags        products
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Product ID"
+// @Param       imageId path int true "Image ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /products/{id}/images/{imageId} [delete]
+func (h *ImageHandler) DeleteProductImage(c *gin.Context) {
+	id, imageID, ok := parseImagePath(c)
+	if !ok {
+		return
+	}
+
+	if err := h.imageService.DeleteProductImage(id, imageID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// readImageFiles collects uploaded files from the "images" (multiple) or "image" (single) form fields
+func (h *ImageHandler) readImageFiles(c *gin.Context) ([]*multipart.FileHeader, error) {
+	if h.maxUploadSize > 0 {
+		// Allow a little headroom for multipart boundaries and multiple files
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize*10)
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, err
+	}
+
+	files := form.File["images"]
+	files = append(files, form.File["image"]...)
+	return files, nil
+}
+
+// parseImagePath parses the owner ":id" and ":imageId" path params, writing a 400 on failure
+func parseImagePath(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return 0, 0, false
+	}
+
+	imageID, err := strconv.ParseUint(c.Param("imageId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid image ID format"})
+		return 0, 0, false
+	}
+
+	return uint(id), uint(imageID), true
+}
@@ -0,0 +1,55 @@
+// File: internal/api/handlers/v1/inventory.go
+// Tạo tại: internal/api/handlers/v1/inventory.go
+// Mục đích: Handler tra cứu khả năng đáp ứng (ATP) theo mã hàng, màu và ngày
+
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type InventoryHandler struct {
+	inventoryService services.InventoryService
+}
+
+func NewInventoryHandler(inventoryService services.InventoryService) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryService: inventoryService,
+	}
+}
+
+// AvailableToPromise godoc
+// @Summary     Get available-to-promise quantity
+// @Description How many meters of a SKU in a color can be promised by a date: free stock of available, good-quality lots
+// @Description plus weaving orders and dyeing lots due by the date, minus confirmed, in-production and held order lines not covered by reservations.
+// @Tags        inventory
+// @Produce     json
+// @Param       sku query string true "Product SKU"
+// @Param       color_code query string false "Color code; empty counts every color"
+// @Param       date query string false "Promise date (YYYY-MM-DD), default today"
+// @Security    BearerAuth
+// @Success     200 {object} response.AvailableToPromiseResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /warehouse/atp [get]
+func (h *InventoryHandler) AvailableToPromise(c *gin.Context) {
+	var req request.AvailableToPromiseRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	atp, err := h.inventoryService.AvailableToPromise(req)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, atp)
+}
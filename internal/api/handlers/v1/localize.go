@@ -0,0 +1,18 @@
+// File: internal/api/handlers/v1/localize.go
+// Tạo tại: internal/api/handlers/v1/localize.go
+// Mục đích: Điền nội dung song ngữ của response theo ngôn ngữ do middleware Locale chọn
+
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/api/middleware"
+	"github.com/godiidev/appsynex/internal/dto/response"
+)
+
+// localize fills the display fields of res for the request language
+func localize(c *gin.Context, res response.Localizable) {
+	if res != nil {
+		res.Localize(middleware.GetLang(c))
+	}
+}
@@ -0,0 +1,355 @@
+// File: internal/api/handlers/v1/order.go
+// Tạo tại: internal/api/handlers/v1/order.go
+// Mục đích: Handler xử lý các API quản lý đơn hàng (CRUD orders, dòng hàng, lịch sử trạng thái, file đính kèm)
+
+package v1
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type OrderHandler struct {
+	orderService  services.OrderService
+	maxUploadSize int64
+}
+
+func NewOrderHandler(orderService services.OrderService, maxUploadSize int64) *OrderHandler {
+	return &OrderHandler{
+		orderService:  orderService,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+// GetAll godoc
+// @Summary     Get all orders
+// @Description Get a list of orders with search, customer, status and due date filters, sorting and pagination.
+// @Description With pagination=cursor the response is a response.CursorPaginatedResponse with next_cursor instead of page counts.
+// @Tags        orders
+// @Accept      json
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       search query string false "Search order code, customer code, name or company"
+// @Param       customer_id query int false "Filter by customer"
+// @Param       status query []string false "Filter by status names"
+// @Param       due_from query string false "Due on or after (YYYY-MM-DD)"
+// @Param       due_to query string false "Due on or before (YYYY-MM-DD)"
+// @Param       sort_by query string false "Sort fields, comma-separated, '-' prefix for descending"
+// @Param       sort_order query string false "Direction of unprefixed sort fields" Enums(asc, desc)
+// @Param       pagination query string false "offset (default) or cursor"
+// @Param       cursor query string false "next_cursor from the previous page"
+// @Param       total query string false "Cursor mode total: none (default), estimate or exact"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /orders [get]
+func (h *OrderHandler) GetAll(c *gin.Context) {
+	var req request.OrderFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var res interface{}
+	var err error
+	if req.UsesCursor() {
+		res, err = h.orderService.GetOrdersByCursor(req)
+	} else {
+		res, err = h.orderService.GetOrders(req)
+	}
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Statuses godoc
+// @Summary     Get order statuses
+// @Description Get the statuses an order can have
+// @Tags        orders
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {array} response.OrderStatusResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /orders/statuses [get]
+func (h *OrderHandler) Statuses(c *gin.Context) {
+	statuses, err := h.orderService.GetStatuses()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, statuses)
+}
+
+// GetByID godoc
+// @Summary     Get order by ID
+// @Description Get an order with its items, attachments and full status history
+// @Tags        orders
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.OrderDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /orders/{id} [get]
+func (h *OrderHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	order, err := h.orderService.GetOrderByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// Create godoc
+// @Summary     Create a new order
+// @Description Create a PENDING order with line items. Subtotals and total_amount are computed from quantity and price.
+// @Description order_code is generated (DH000001, DH000002, ...) when omitted.
+// @Tags        orders
+// @Accept      json
+// @Produce     json
+// @Param       order body request.CreateOrderRequest true "Order to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.OrderDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /orders [post]
+func (h *OrderHandler) Create(c *gin.Context) {
+	var req request.CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.orderService.CreateOrder(req, userID, clientInfo(c))
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// Update godoc
+// @Summary     Update an order
+// @Description Update the due date, notes or items (only while PENDING) of an order. Status changes go through the order actions.
+// @Tags        orders
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Param       order body request.UpdateOrderRequest true "Order data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.OrderDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /orders/{id} [put]
+func (h *OrderHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.orderService.UpdateOrder(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrOrderStatusChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// Delete godoc
+// @Summary     Delete an order
+// @Description Delete a PENDING or CANCELLED order
+// @Tags        orders
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /orders/{id} [delete]
+func (h *OrderHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.orderService.DeleteOrder(uint(id)); err != nil {
+		if errors.Is(err, services.ErrOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UploadAttachments godoc
+// @Summary     Upload order attachments
+// @Description Attach files (PDF, images, office documents, CSV, TXT or ZIP) to an order
+// @Tags        orders
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Param       files formData file true "Files to attach (repeat the field for several files)"
+// @Security    BearerAuth
+// @Success     201 {object} response.OrderDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /orders/{id}/attachments [post]
+func (h *OrderHandler) UploadAttachments(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	files, err := h.readAttachmentFiles(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.orderService.AddAttachments(uint(id), files, userID)
+	if err != nil {
+		if errors.Is(err, services.ErrOrderStatusChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// DeleteAttachment godoc
+// @Summary     Delete an order attachment
+// @Description Remove a file from an order
+// @Tags        orders
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Param       name path string true "Attachment name"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /orders/{id}/attachments/{name} [delete]
+func (h *OrderHandler) DeleteAttachment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.orderService.DeleteAttachment(uint(id), c.Param("name")); err != nil {
+		if errors.Is(err, services.ErrOrderStatusChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrOrderNotFound) || errors.Is(err, services.ErrOrderAttachmentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *OrderHandler) readAttachmentFiles(c *gin.Context) ([]*multipart.FileHeader, error) {
+	if h.maxUploadSize > 0 {
+		// Allow a little headroom for multipart boundaries and multiple files
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize*10)
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, err
+	}
+
+	files := form.File["files"]
+	files = append(files, form.File["file"]...)
+	return files, nil
+}
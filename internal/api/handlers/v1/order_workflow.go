@@ -0,0 +1,190 @@
+// File: internal/api/handlers/v1/order_workflow.go
+// Tạo tại: internal/api/handlers/v1/order_workflow.go
+// Mục đích: Handler chuyển trạng thái đơn hàng (duyệt, hủy, giao hàng và các thao tác khác của máy trạng thái)
+
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type OrderWorkflowHandler struct {
+	workflowService services.OrderWorkflowService
+}
+
+func NewOrderWorkflowHandler(workflowService services.OrderWorkflowService) *OrderWorkflowHandler {
+	return &OrderWorkflowHandler{
+		workflowService: workflowService,
+	}
+}
+
+// Transitions godoc
+// @Summary     Get available order actions
+// @Description List the actions the order's current status allows, whether the current user may run each one and why not
+// @Tags        orders
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Security    BearerAuth
+// @Success     200 {array} response.OrderTransitionResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /orders/{id}/transitions [get]
+func (h *OrderWorkflowHandler) Transitions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	transitions, err := h.workflowService.Transitions(uint(id), userID)
+	if err != nil {
+		if errors.Is(err, services.ErrOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transitions)
+}
+
+// Apply godoc
+// @Summary     Run an order action
+// @Description Move the order to another status. Each action is allowed from certain statuses only and requires its own permission:
+// @Description approve (ORDER_APPROVE), start_production, hold, resume, complete (ORDER_UPDATE), ship (ORDER_SHIP, needs a packing list), cancel (ORDER_CANCEL).
+// @Description hold and cancel require a note.
+// @Tags        orders
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Param       action path string true "Action" Enums(approve, start_production, hold, resume, ship, complete, cancel)
+// @Param       transition body request.OrderTransitionRequest false "Note kept in the order history"
+// @Security    BearerAuth
+// @Success     200 {object} response.OrderDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Failure     422 {object} response.ErrorResponse
+// @Router      /orders/{id}/transitions/{action} [post]
+func (h *OrderWorkflowHandler) Apply(c *gin.Context) {
+	h.apply(c, c.Param("action"))
+}
+
+// Approve godoc
+// @Summary     Approve an order
+// @Description Confirm a PENDING order. The order needs items and a customer that is not blocked.
+// @Tags        orders
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Param       transition body request.OrderTransitionRequest false "Note kept in the order history"
+// @Security    BearerAuth
+// @Success     200 {object} response.OrderDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Failure     422 {object} response.ErrorResponse
+// @Router      /orders/{id}/approve [post]
+func (h *OrderWorkflowHandler) Approve(c *gin.Context) {
+	h.apply(c, services.OrderActionApprove)
+}
+
+// Cancel godoc
+// @Summary     Cancel an order
+// @Description Cancel an order that has not shipped. The note (cancel reason) is required.
+// @Tags        orders
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Param       transition body request.OrderTransitionRequest true "Cancel reason"
+// @Security    BearerAuth
+// @Success     200 {object} response.OrderDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /orders/{id}/cancel [post]
+func (h *OrderWorkflowHandler) Cancel(c *gin.Context) {
+	h.apply(c, services.OrderActionCancel)
+}
+
+// Ship godoc
+// @Summary     Ship an order
+// @Description Mark a CONFIRMED or IN_PRODUCTION order as SHIPPED. The order needs a packing list.
+// @Tags        orders
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Param       transition body request.OrderTransitionRequest false "Note kept in the order history"
+// @Security    BearerAuth
+// @Success     200 {object} response.OrderDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Failure     422 {object} response.ErrorResponse
+// @Router      /orders/{id}/ship [post]
+func (h *OrderWorkflowHandler) Ship(c *gin.Context) {
+	h.apply(c, services.OrderActionShip)
+}
+
+func (h *OrderWorkflowHandler) apply(c *gin.Context, action string) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	// The body is optional for actions without a required note
+	var req request.OrderTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.workflowService.Apply(uint(id), action, req, userID, clientInfo(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound), errors.Is(err, services.ErrUnknownOrderAction):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrOrderActionForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrIllegalOrderTransition), errors.Is(err, services.ErrOrderStatusChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrOrderGuardFailed):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
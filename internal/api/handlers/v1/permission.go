@@ -43,6 +43,8 @@ func (h *PermissionHandler) GetAllPermissions(c *gin.Context) {
 		return
 	}
 
+	localize(c, permissions)
+
 	c.JSON(http.StatusOK, permissions)
 }
 
@@ -73,6 +75,8 @@ func (h *PermissionHandler) GetPermissionsByModule(c *gin.Context) {
 		return
 	}
 
+	localize(c, permissions)
+
 	c.JSON(http.StatusOK, permissions)
 }
 
@@ -95,6 +99,8 @@ func (h *PermissionHandler) GetPermissionGroups(c *gin.Context) {
 		return
 	}
 
+	localize(c, groups)
+
 	c.JSON(http.StatusOK, groups)
 }
 
@@ -125,6 +131,8 @@ func (h *PermissionHandler) CreatePermission(c *gin.Context) {
 		return
 	}
 
+	localize(c, permission)
+
 	c.JSON(http.StatusCreated, permission)
 }
 
@@ -163,6 +171,8 @@ func (h *PermissionHandler) UpdatePermission(c *gin.Context) {
 		return
 	}
 
+	localize(c, permission)
+
 	c.JSON(http.StatusOK, permission)
 }
 
@@ -307,6 +317,8 @@ func (h *PermissionHandler) GetRolePermissions(c *gin.Context) {
 		return
 	}
 
+	localize(c, permissions)
+
 	c.JSON(http.StatusOK, permissions)
 }
 
@@ -422,6 +434,8 @@ func (h *PermissionHandler) GetUserPermissions(c *gin.Context) {
 		return
 	}
 
+	localize(c, permissions)
+
 	c.JSON(http.StatusOK, permissions)
 }
 
@@ -453,6 +467,8 @@ func (h *PermissionHandler) GetUserEffectivePermissions(c *gin.Context) {
 		return
 	}
 
+	localize(c, permissions)
+
 	c.JSON(http.StatusOK, permissions)
 }
 
@@ -0,0 +1,390 @@
+// File: internal/api/handlers/v1/portal.go
+// Tạo tại: internal/api/handlers/v1/portal.go
+// Mục đích: Handler cổng portal khách hàng (/portal/v1): đăng nhập, đơn hàng, giao hàng, mẫu gửi và duyệt lap dip
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/pkg/auth"
+)
+
+type PortalHandler struct {
+	portalService services.PortalService
+}
+
+func NewPortalHandler(portalService services.PortalService) *PortalHandler {
+	return &PortalHandler{
+		portalService: portalService,
+	}
+}
+
+// portalCustomer returns the portal claims set by middleware.PortalAuth
+func portalCustomer(c *gin.Context) (*auth.CustomerClaims, bool) {
+	customerClaims, exists := c.Get("customer")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Customer not found in context"})
+		return nil, false
+	}
+	return customerClaims.(*auth.CustomerClaims), true
+}
+
+// Login godoc
+// @Summary     Portal login
+// @Description Login a customer portal account with email and password. The token only works on /portal/v1.
+// @Tags        portal
+// @Accept      json
+// @Produce     json
+// @Param       request body request.PortalLoginRequest true "Login credentials"
+// @Success     200 {object} response.PortalLoginResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Router      /portal/v1/auth/login [post]
+func (h *PortalHandler) Login(c *gin.Context) {
+	var req request.PortalLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.portalService.Login(req)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Me godoc
+// @Summary     Current portal account
+// @Description Get the signed-in portal account and its customer
+// @Tags        portal
+// @Produce     json
+// @Security    PortalAuth
+// @Success     200 {object} response.PortalAccountResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Router      /portal/v1/me [get]
+func (h *PortalHandler) Me(c *gin.Context) {
+	claims, ok := portalCustomer(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.portalService.GetProfile(claims.AccountID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetOrders godoc
+// @Summary     List my orders
+// @Description List the customer's orders, newest first
+// @Tags        portal
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page (max 100)"
+// @Param       status query string false "Filter by order status name"
+// @Security    PortalAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /portal/v1/orders [get]
+func (h *PortalHandler) GetOrders(c *gin.Context) {
+	claims, ok := portalCustomer(c)
+	if !ok {
+		return
+	}
+
+	var req request.PortalOrderFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.portalService.GetOrders(claims.CustomerID, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetOrder godoc
+// @Summary     Get my order
+// @Description Get one of the customer's orders with its items
+// @Tags        portal
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Security    PortalAuth
+// @Success     200 {object} response.PortalOrderDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /portal/v1/orders/{id} [get]
+func (h *PortalHandler) GetOrder(c *gin.Context) {
+	claims, ok := portalCustomer(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	res, err := h.portalService.GetOrder(claims.CustomerID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetShipments godoc
+// @Summary     List my shipments
+// @Description List deliveries of the customer's orders with delivery status and tracking info
+// @Tags        portal
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page (max 100)"
+// @Param       order_id query int false "Only shipments of this order"
+// @Security    PortalAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /portal/v1/shipments [get]
+func (h *PortalHandler) GetShipments(c *gin.Context) {
+	claims, ok := portalCustomer(c)
+	if !ok {
+		return
+	}
+
+	var req request.PortalShipmentFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.portalService.GetShipments(claims.CustomerID, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetSampleDispatches godoc
+// @Summary     List my sample dispatches
+// @Description List samples sent to the customer with lot and tracking numbers, newest first
+// @Tags        portal
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page (max 100)"
+// @Security    PortalAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /portal/v1/sample-dispatches [get]
+func (h *PortalHandler) GetSampleDispatches(c *gin.Context) {
+	claims, ok := portalCustomer(c)
+	if !ok {
+		return
+	}
+
+	var req request.PortalListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.portalService.GetSampleDispatches(claims.CustomerID, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetLapDips godoc
+// @Summary     List my lap dips
+// @Description List lap dip dispatches sent to the customer, newest first
+// @Tags        portal
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page (max 100)"
+// @Param       status query string false "Filter by status" Enums(dispatched, approved, rejected)
+// @Security    PortalAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /portal/v1/lap-dips [get]
+func (h *PortalHandler) GetLapDips(c *gin.Context) {
+	claims, ok := portalCustomer(c)
+	if !ok {
+		return
+	}
+
+	var req request.PortalLapDipFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.portalService.GetLapDips(claims.CustomerID, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetLapDip godoc
+// @Summary     Get my lap dip
+// @Description Get a lap dip dispatch with the codes that can be approved
+// @Tags        portal
+// @Produce     json
+// @Param       id path int true "Lap dip dispatch ID"
+// @Security    PortalAuth
+// @Success     200 {object} response.PortalLapDipResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /portal/v1/lap-dips/{id} [get]
+func (h *PortalHandler) GetLapDip(c *gin.Context) {
+	claims, ok := portalCustomer(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	res, err := h.portalService.GetLapDip(claims.CustomerID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// ApproveLapDip godoc
+// @Summary     Approve a lap dip
+// @Description Approve a dispatched lap dip by choosing one of its result codes. The choice becomes the final selection of the test.
+// @Tags        portal
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Lap dip dispatch ID"
+// @Param       request body request.ApproveLapDipRequest true "Chosen lap dip code"
+// @Security    PortalAuth
+// @Success     200 {object} response.PortalLapDipResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /portal/v1/lap-dips/{id}/approve [post]
+func (h *PortalHandler) ApproveLapDip(c *gin.Context) {
+	claims, ok := portalCustomer(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.ApproveLapDipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.portalService.ApproveLapDip(claims.CustomerID, uint(id), req, clientInfo(c))
+	if err != nil {
+		lapDipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// RejectLapDip godoc
+// @Summary     Reject a lap dip
+// @Description Reject a dispatched lap dip with a reason so the color can be redone
+// @Tags        portal
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Lap dip dispatch ID"
+// @Param       request body request.RejectLapDipRequest true "Rejection reason"
+// @Security    PortalAuth
+// @Success     200 {object} response.PortalLapDipResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /portal/v1/lap-dips/{id}/reject [post]
+func (h *PortalHandler) RejectLapDip(c *gin.Context) {
+	claims, ok := portalCustomer(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.RejectLapDipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.portalService.RejectLapDip(claims.CustomerID, uint(id), req)
+	if err != nil {
+		lapDipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// lapDipError maps lap dip answer errors to status codes
+func lapDipError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrLapDipNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrLapDipAnswered):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
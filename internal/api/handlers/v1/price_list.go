@@ -0,0 +1,254 @@
+// File: internal/api/handlers/v1/price_list.go
+// Tạo tại: internal/api/handlers/v1/price_list.go
+// Mục đích: Handler quản lý bảng giá theo khách hàng/nhóm giá và tra cứu giá áp dụng
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type PriceListHandler struct {
+	priceListService services.PriceListService
+}
+
+func NewPriceListHandler(priceListService services.PriceListService) *PriceListHandler {
+	return &PriceListHandler{
+		priceListService: priceListService,
+	}
+}
+
+// GetAll godoc
+// @Summary     Get all price lists
+// @Description Get price lists, optionally only those of a customer, a price tier or valid on a date
+// @Tags        price-lists
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       customer_id query int false "Filter by customer"
+// @Param       price_tier query string false "Filter by price tier"
+// @Param       currency query string false "Filter by currency code"
+// @Param       active_on query string false "Only active lists valid on this date (YYYY-MM-DD)"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /price-lists [get]
+func (h *PriceListHandler) GetAll(c *gin.Context) {
+	var req request.PriceListFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	lists, err := h.priceListService.GetPriceLists(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, lists)
+}
+
+// GetByID godoc
+// @Summary     Get price list by ID
+// @Description Get a price list with its quantity breaks
+// @Tags        price-lists
+// @Produce     json
+// @Param       id path int true "Price list ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.PriceListResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /price-lists/{id} [get]
+func (h *PriceListHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	list, err := h.priceListService.GetPriceListByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// Create godoc
+// @Summary     Create a price list
+// @Description Create a price list for a customer, a price tier, or every customer when neither is set.
+// @Description Each item prices a product from min_quantity up; the highest break not above the quantity applies.
+// @Tags        price-lists
+// @Accept      json
+// @Produce     json
+// @Param       priceList body request.CreatePriceListRequest true "Price list to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.PriceListResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /price-lists [post]
+func (h *PriceListHandler) Create(c *gin.Context) {
+	var req request.CreatePriceListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.priceListService.CreatePriceList(req, userID)
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+// Update godoc
+// @Summary     Update a price list
+// @Description Update a price list. items, when given, replace every quantity break.
+// @Tags        price-lists
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Price list ID"
+// @Param       priceList body request.UpdatePriceListRequest true "Price list data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.PriceListResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /price-lists/{id} [put]
+func (h *PriceListHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdatePriceListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	list, err := h.priceListService.UpdatePriceList(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrPriceListNotFound) || errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// Delete godoc
+// @Summary     Delete a price list
+// @Description Delete a price list. Quotations keep the prices they were made with.
+// @Tags        price-lists
+// @Produce     json
+// @Param       id path int true "Price list ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /price-lists/{id} [delete]
+func (h *PriceListHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.priceListService.DeletePriceList(uint(id)); err != nil {
+		if errors.Is(err, services.ErrPriceListNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Resolve godoc
+// @Summary     Look up a customer's price
+// @Description Get the unit price a customer gets for a product and quantity: from the customer's own price lists,
+// @Description then the lists of the customer's price tier, then general lists, else the product's sales price
+// @Description converted at the exchange rate of the date. Lists only apply in their own currency.
+// @Tags        price-lists
+// @Produce     json
+// @Param       customer_id query int true "Customer ID"
+// @Param       product_id query int true "Product ID"
+// @Param       currency query string false "Currency code, defaults to the customer's currency"
+// @Param       quantity query number false "Quantity"
+// @Param       date query string false "Price date (YYYY-MM-DD), defaults to today"
+// @Security    BearerAuth
+// @Success     200 {object} response.ResolvedPriceResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     422 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /price-lists/resolve [get]
+func (h *PriceListHandler) Resolve(c *gin.Context) {
+	var req request.ResolvePriceRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	price, err := h.priceListService.ResolvePrice(req)
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerNotFound) || errors.Is(err, services.ErrProductNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrNoExchangeRate) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, price)
+}
@@ -0,0 +1,90 @@
+// File: internal/api/handlers/v1/product.go
+// Tạo tại: internal/api/handlers/v1/product.go
+// Mục đích: Handler xử lý các API đọc danh sách và chi tiết sản phẩm
+
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type ProductHandler struct {
+	productService services.ProductService
+}
+
+func NewProductHandler(productService services.ProductService) *ProductHandler {
+	return &ProductHandler{
+		productService: productService,
+	}
+}
+
+// GetAll godoc
+// @Summary     Get all products
+// @Description Get a list of products with pagination and filtering
+// @Tags        products
+// @Accept      json
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       search query string false "Search term for SKU or product name"
+// @Param       category query string false "Filter by category ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /products [get]
+func (h *ProductHandler) GetAll(c *gin.Context) {
+	var req request.ProductFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.productService.GetProducts(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	localize(c, res)
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetByID godoc
+// @Summary     Get product by ID
+// @Description Get a product by ID including its images
+// @Tags        products
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Product ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.ProductResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /products/{id} [get]
+func (h *ProductHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	product, err := h.productService.GetProductByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	localize(c, product)
+
+	c.JSON(http.StatusOK, product)
+}
@@ -0,0 +1,350 @@
+// File: internal/api/handlers/v1/quotation.go
+// Tạo tại: internal/api/handlers/v1/quotation.go
+// Mục đích: Handler báo giá (CRUD, gửi, từ chối, in) và chuyển báo giá được chấp nhận thành đơn hàng
+
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type QuotationHandler struct {
+	quotationService services.QuotationService
+}
+
+func NewQuotationHandler(quotationService services.QuotationService) *QuotationHandler {
+	return &QuotationHandler{
+		quotationService: quotationService,
+	}
+}
+
+// GetAll godoc
+// @Summary     Get all quotations
+// @Description Get quotations, newest first, with search, customer and status filters.
+// @Description A draft or sent quotation past valid_until has status expired.
+// @Tags        quotations
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       search query string false "Search quotation code, customer code, name or company"
+// @Param       customer_id query int false "Filter by customer"
+// @Param       status query []string false "Filter by status: draft, sent, accepted, rejected, expired"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /quotations [get]
+func (h *QuotationHandler) GetAll(c *gin.Context) {
+	var req request.QuotationFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	quotations, err := h.quotationService.GetQuotations(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quotations)
+}
+
+// GetByID godoc
+// @Summary     Get quotation by ID
+// @Description Get a quotation with its items
+// @Tags        quotations
+// @Produce     json
+// @Param       id path int true "Quotation ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.QuotationDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /quotations/{id} [get]
+func (h *QuotationHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	quotation, err := h.quotationService.GetQuotationByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quotation)
+}
+
+// Print godoc
+// @Summary     Print a quotation
+// @Description Get the quotation as a printable HTML page
+// @Tags        quotations
+// @Produce     html
+// @Param       id path int true "Quotation ID"
+// @Security    BearerAuth
+// @Success     200 {string} string "HTML page"
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /quotations/{id}/print [get]
+func (h *QuotationHandler) Print(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	page, err := h.quotationService.Print(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrQuotationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
+
+// Create godoc
+// @Summary     Create a quotation
+// @Description Create a draft quotation. Items without unit_price are priced from the customer's price lists on the issue date.
+// @Description net_price applies the line and quotation discounts; quotation_code is generated (BG000001, ...) when omitted.
+// @Tags        quotations
+// @Accept      json
+// @Produce     json
+// @Param       quotation body request.CreateQuotationRequest true "Quotation to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.QuotationDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /quotations [post]
+func (h *QuotationHandler) Create(c *gin.Context) {
+	var req request.CreateQuotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	quotation, err := h.quotationService.CreateQuotation(req, userID)
+	if err != nil {
+		if errors.Is(err, services.ErrCustomerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, quotation)
+}
+
+// Update godoc
+// @Summary     Update a quotation
+// @Description Update a draft or sent quotation that has not expired. items, when given, replace every item.
+// @Tags        quotations
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Quotation ID"
+// @Param       quotation body request.UpdateQuotationRequest true "Quotation data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.QuotationDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /quotations/{id} [put]
+func (h *QuotationHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateQuotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	quotation, err := h.quotationService.UpdateQuotation(uint(id), req)
+	if err != nil {
+		writeQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quotation)
+}
+
+// Delete godoc
+// @Summary     Delete a quotation
+// @Description Delete a quotation that was not accepted
+// @Tags        quotations
+// @Produce     json
+// @Param       id path int true "Quotation ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /quotations/{id} [delete]
+func (h *QuotationHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.quotationService.DeleteQuotation(uint(id)); err != nil {
+		writeQuotationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Send godoc
+// @Summary     Mark a quotation as sent
+// @Description Move a draft quotation to sent once it has been given to the customer
+// @Tags        quotations
+// @Produce     json
+// @Param       id path int true "Quotation ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.QuotationDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /quotations/{id}/send [post]
+func (h *QuotationHandler) Send(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	quotation, err := h.quotationService.Send(uint(id))
+	if err != nil {
+		writeQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quotation)
+}
+
+// Reject godoc
+// @Summary     Reject a quotation
+// @Description Record that the customer declined a draft or sent quotation
+// @Tags        quotations
+// @Produce     json
+// @Param       id path int true "Quotation ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.QuotationDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /quotations/{id}/reject [post]
+func (h *QuotationHandler) Reject(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	quotation, err := h.quotationService.Reject(uint(id))
+	if err != nil {
+		writeQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quotation)
+}
+
+// Accept godoc
+// @Summary     Accept a quotation
+// @Description Accept a draft or sent quotation that has not expired and convert it into a PENDING order
+// @Description at the quoted net prices. The quotation keeps the ID of the order.
+// @Tags        quotations
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Quotation ID"
+// @Param       accept body request.AcceptQuotationRequest false "Order due date and notes"
+// @Security    BearerAuth
+// @Success     201 {object} response.OrderDetailResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /quotations/{id}/accept [post]
+func (h *QuotationHandler) Accept(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	// The body is optional
+	var req request.AcceptQuotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.quotationService.Accept(uint(id), req, userID, clientInfo(c))
+	if err != nil {
+		writeQuotationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+func writeQuotationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrQuotationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrQuotationClosed), errors.Is(err, services.ErrQuotationStatusChanged):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
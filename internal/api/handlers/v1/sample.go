@@ -1,12 +1,14 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/godiidev/appsynex/internal/domain/services"
 	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
 )
 
 type SampleHandler struct {
@@ -21,22 +23,40 @@ func NewSampleHandler(sampleService services.SampleService) *SampleHandler {
 
 // GetAll godoc
 // @Summary     Get all sample products
-// @Description Get a list of sample products with pagination and filtering
+// @Description Get a list of sample products with pagination and filtering.
+// @Description With pagination=cursor the response is a response.SampleCursorPageResponse with next_cursor instead of page counts.
 // @Tags        samples
 // @Accept      json
 // @Produce     json
 // @Param       page query int false "Page number"
 // @Param       limit query int false "Items per page"
-// @Param       search query string false "Search term for SKU or product name"
-// @Param       category query string false "Filter by category ID"
-// @Param       sample_type query string false "Filter by sample type"
+// @Param       search query string false "Search SKU, product name, color, fiber content, description and source (diacritics optional)"
+// @Param       category query string false "Filter by category ID (comma-separated for several)"
+// @Param       category_ids query []int false "Filter by category IDs"
+// @Param       include_subcategories query bool false "Include sub-categories of the selected categories (default true)"
+// @Param       sample_type query []string false "Filter by sample types"
+// @Param       location query []string false "Filter by sample locations"
 // @Param       weight_min query number false "Minimum weight"
 // @Param       weight_max query number false "Maximum weight"
 // @Param       width_min query number false "Minimum width"
 // @Param       width_max query number false "Maximum width"
-// @Param       color query string false "Filter by color"
+// @Param       remaining_min query int false "Minimum remaining quantity"
+// @Param       remaining_max query int false "Maximum remaining quantity"
+// @Param       color query string false "Filter by color (contains)"
+// @Param       fiber_content query string false "Filter by fiber content (contains)"
+// @Param       created_from query string false "Created on or after (YYYY-MM-DD)"
+// @Param       created_to query string false "Created on or before (YYYY-MM-DD)"
+// @Param       updated_from query string false "Updated on or after (YYYY-MM-DD)"
+// @Param       updated_to query string false "Updated on or before (YYYY-MM-DD)"
+// @Param       sort_by query string false "Sort fields, comma-separated, '-' prefix for descending (id, sku, category_id, sample_type, weight, width, color, remaining_quantity, sample_location, created_at, updated_at)"
+// @Param       sort_order query string false "Default direction (asc, desc)"
+// @Param       facets query []string false "Facet counts to return (sample_type, category, color, fiber_content, location)"
+// @Param       pagination query string false "offset (default) or cursor"
+// @Param       cursor query string false "next_cursor from the previous page (cursor mode, single sort field)"
+// @Param       total query string false "Cursor mode total: none (default), estimate or exact"
 // @Security    BearerAuth
-// @Success     200 {object} response.PaginatedResponse
+// @Success     200 {object} response.SamplePageResponse
+// @Failure     400 {object} response.ErrorResponse
 // @Failure     401 {object} response.ErrorResponse
 // @Failure     403 {object} response.ErrorResponse
 // @Failure     500 {object} response.ErrorResponse
@@ -48,7 +68,77 @@ func (h *SampleHandler) GetAll(c *gin.Context) {
 		return
 	}
 
-	res, err := h.sampleService.GetSamples(req)
+	var res response.Localizable
+	var err error
+	if req.UsesCursor() {
+		res, err = h.sampleService.GetSamplesByCursor(req)
+	} else {
+		res, err = h.sampleService.GetSamples(req)
+	}
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	localize(c, res)
+	c.JSON(http.StatusOK, res)
+}
+
+// Search godoc
+// @Summary     Search sample products
+// @Description Ranked full-text search over SKU, product name, color, fiber content, description and source.
+// @Description Vietnamese diacritics are optional ("vai cotton" matches "vải cotton"); typos are tolerated when nothing matches exactly.
+// @Tags        samples
+// @Accept      json
+// @Produce     json
+// @Param       q query string true "Search query"
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       category query string false "Filter by category ID"
+// @Param       sample_type query string false "Filter by sample type"
+// @Param       fuzzy query bool false "Allow typo-tolerant matching (default true)"
+// @Security    BearerAuth
+// @Success     200 {object} response.SampleSearchResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /samples/search [get]
+func (h *SampleHandler) Search(c *gin.Context) {
+	var req request.SampleSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.sampleService.SearchSamples(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	localize(c, res)
+
+	c.JSON(http.StatusOK, res)
+}
+
+// RebuildSearchIndex godoc
+// @Summary     Rebuild sample search index
+// @Description Recompute the normalized search text of every sample (after migrations or product name changes)
+// @Tags        samples
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} response.SearchReindexResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /samples/search/reindex [post]
+func (h *SampleHandler) RebuildSearchIndex(c *gin.Context) {
+	res, err := h.sampleService.RebuildSearchIndex()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -85,6 +175,8 @@ func (h *SampleHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	localize(c, sample)
+
 	c.JSON(http.StatusOK, sample)
 }
 
@@ -115,6 +207,8 @@ func (h *SampleHandler) Create(c *gin.Context) {
 		return
 	}
 
+	localize(c, sample)
+
 	c.JSON(http.StatusCreated, sample)
 }
 
@@ -153,6 +247,8 @@ func (h *SampleHandler) Update(c *gin.Context) {
 		return
 	}
 
+	localize(c, sample)
+
 	c.JSON(http.StatusOK, sample)
 }
 
@@ -0,0 +1,61 @@
+// File: internal/api/handlers/v1/sample_dispatch.go
+// Tạo tại: internal/api/handlers/v1/sample_dispatch.go
+// Mục đích: Handler xử lý API gửi mẫu cho khách hàng
+
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type SampleDispatchHandler struct {
+	dispatchService services.SampleDispatchService
+}
+
+func NewSampleDispatchHandler(dispatchService services.SampleDispatchService) *SampleDispatchHandler {
+	return &SampleDispatchHandler{
+		dispatchService: dispatchService,
+	}
+}
+
+// Dispatch godoc
+// @Summary     Dispatch a sample to a customer
+// @Description Record a sample sent to a customer. The dispatch is added to the customer's activity timeline.
+// @Tags        samples
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Sample ID"
+// @Param       dispatch body request.DispatchSampleRequest true "Dispatch details"
+// @Security    BearerAuth
+// @Success     201 {object} response.SampleDispatchResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /samples/{id}/dispatch [post]
+func (h *SampleDispatchHandler) Dispatch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.DispatchSampleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dispatch, err := h.dispatchService.DispatchSample(uint(id), req, clientInfo(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dispatch)
+}
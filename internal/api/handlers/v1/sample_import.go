@@ -0,0 +1,150 @@
+// File: internal/api/handlers/v1/sample_import.go
+// Tạo tại: internal/api/handlers/v1/sample_import.go
+// Mục đích: Handler import/export mẫu vải bằng file CSV/XLSX
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/pkg/spreadsheet"
+)
+
+type SampleImportExportHandler struct {
+	importExportService services.SampleImportExportService
+	maxUploadSize       int64
+}
+
+func NewSampleImportExportHandler(importExportService services.SampleImportExportService, maxUploadSize int64) *SampleImportExportHandler {
+	return &SampleImportExportHandler{
+		importExportService: importExportService,
+		maxUploadSize:       maxUploadSize,
+	}
+}
+
+// Import godoc
+// @Summary     Import samples
+// @Description Import samples from a CSV or XLSX file. Columns are matched to fields by header name unless a mapping is given.
+// @Description Rows are validated against existing product names and categories; invalid rows are reported and skipped.
+// @Tags        samples
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       file formData file true "CSV or XLSX file"
+// @Param       mapping formData string false "JSON object mapping column headers to sample fields"
+// @Param       dry_run formData bool false "Validate only, do not save"
+// @Param       update_existing formData bool false "Update samples whose SKU already exists"
+// @Security    BearerAuth
+// @Success     200 {object} response.SampleImportResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /samples/import [post]
+func (h *SampleImportExportHandler) Import(c *gin.Context) {
+	if h.maxUploadSize > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize)
+	}
+
+	var req request.SampleImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+
+	format, err := spreadsheet.FormatFromFileName(fileHeader.Filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	result, err := h.importExportService.ImportSamples(file, format, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Export godoc
+// @Summary     Export samples
+// @Description Export all samples matching the list filters as a CSV or XLSX file
+// @Tags        samples
+// @Produce     text/csv
+// @Produce     application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param       format query string false "File format (csv, xlsx)" default(csv)
+// @Param       search query string false "Search term"
+// @Param       category query string false "Category ID"
+// @Param       category_ids query []int false "Category IDs (sub-categories included)"
+// @Param       sample_type query []string false "Sample types"
+// @Param       location query []string false "Sample locations"
+// @Param       weight_min query number false "Minimum weight"
+// @Param       weight_max query number false "Maximum weight"
+// @Param       width_min query number false "Minimum width"
+// @Param       width_max query number false "Maximum width"
+// @Param       remaining_min query int false "Minimum remaining quantity"
+// @Param       remaining_max query int false "Maximum remaining quantity"
+// @Param       color query string false "Color contains"
+// @Param       fiber_content query string false "Fiber content contains"
+// @Param       created_from query string false "Created on or after (YYYY-MM-DD)"
+// @Param       created_to query string false "Created on or before (YYYY-MM-DD)"
+// @Security    BearerAuth
+// @Success     200 {file} file
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /samples/export [get]
+func (h *SampleImportExportHandler) Export(c *gin.Context) {
+	var req request.SampleExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Format == "" {
+		req.Format = spreadsheet.FormatCSV
+	}
+	format, err := spreadsheet.ParseFormat(req.Format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Format = format
+
+	fileName := fmt.Sprintf("samples_%s.%s", time.Now().Format("20060102_150405"), req.Format)
+	c.Header("Content-Type", spreadsheet.ContentType(req.Format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Status(http.StatusOK)
+
+	if err := h.importExportService.ExportSamples(c.Writer, req); err != nil {
+		// Headers may already be sent while streaming, so only log
+		log.Printf("Warning: sample export failed: %v", err)
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Type")
+			status := http.StatusInternalServerError
+			if errors.Is(err, services.ErrInvalidQuery) {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// File: internal/api/handlers/v1/stock_reservation.go
+// Tạo tại: internal/api/handlers/v1/stock_reservation.go
+// Mục đích: Handler giữ hàng cho đơn (giữ cuộn vải theo lô, xem và nhả hàng đã giữ)
+
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type StockReservationHandler struct {
+	reservationService services.StockReservationService
+}
+
+func NewStockReservationHandler(reservationService services.StockReservationService) *StockReservationHandler {
+	return &StockReservationHandler{
+		reservationService: reservationService,
+	}
+}
+
+// GetAll godoc
+// @Summary     Get order stock reservations
+// @Description Get the rolls reserved for an order and how much of each line they cover
+// @Tags        orders
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.OrderReservationsResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /orders/{id}/reservations [get]
+func (h *StockReservationHandler) GetAll(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	reservations, err := h.reservationService.GetReservations(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservations)
+}
+
+// Reserve godoc
+// @Summary     Reserve stock for an order
+// @Description Reserve whole fabric rolls of available, good-quality lots for order lines, oldest lot first, matching the line's product and color.
+// @Description Without items every line's outstanding length is reserved. Reservations expire (RESERVATION_TTL, default 72h) unless expires_at is set,
+// @Description and are released when the order is cancelled. Without allow_partial nothing is reserved when a line cannot be covered.
+// @Tags        orders
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Param       reservation body request.ReserveStockRequest false "Lines to reserve"
+// @Security    BearerAuth
+// @Success     201 {object} response.OrderReservationsResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /orders/{id}/reservations [post]
+func (h *StockReservationHandler) Reserve(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	// The body is optional; without it every line is reserved
+	var req request.ReserveStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	reservations, err := h.reservationService.Reserve(uint(id), req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInsufficientStock):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, reservations)
+}
+
+// ReleaseAll godoc
+// @Summary     Release order stock reservations
+// @Description Release every active reservation of an order
+// @Tags        orders
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /orders/{id}/reservations [delete]
+func (h *StockReservationHandler) ReleaseAll(c *gin.Context) {
+	h.release(c, 0)
+}
+
+// Release godoc
+// @Summary     Release a stock reservation
+// @Description Release one active reservation of an order
+// @Tags        orders
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Param       reservationId path int true "Reservation ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /orders/{id}/reservations/{reservationId} [delete]
+func (h *StockReservationHandler) Release(c *gin.Context) {
+	reservationID, err := strconv.ParseUint(c.Param("reservationId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	h.release(c, uint(reservationID))
+}
+
+func (h *StockReservationHandler) release(c *gin.Context, reservationID uint) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.reservationService.Release(uint(id), reservationID); err != nil {
+		if errors.Is(err, services.ErrOrderNotFound) || errors.Is(err, services.ErrReservationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,225 @@
+// File: internal/api/handlers/v1/subcontracting.go
+// Tạo tại: internal/api/handlers/v1/subcontracting.go
+// Mục đích: Handler công nợ nhà gia công dệt/nhuộm: bảng kê, hóa đơn, thanh toán và báo cáo tuổi nợ
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type SubcontractHandler struct {
+	subcontractService services.SubcontractService
+}
+
+func NewSubcontractHandler(subcontractService services.SubcontractService) *SubcontractHandler {
+	return &SubcontractHandler{
+		subcontractService: subcontractService,
+	}
+}
+
+// GetStatements godoc
+// @Summary     Subcontractor statements
+// @Description List the weaving orders at outside mills and the dyeing jobs per subcontractor, with the quantity
+// @Description sent and returned, the invoice and the balance owed. Jobs not yet invoiced carry an estimate at the
+// @Description agreed rate for the quantity returned. Statements are per currency and the grand totals are
+// @Description converted at today's rate.
+// @Tags        finance
+// @Produce     json
+// @Param       type query string false "weaving or dyeing"
+// @Param       search query string false "Subcontractor name or job code"
+// @Param       from query string false "Jobs sent out from this day (YYYY-MM-DD)"
+// @Param       to query string false "Jobs sent out up to this day, inclusive (YYYY-MM-DD)"
+// @Param       unpaid_only query bool false "Only jobs with an invoice that is not fully paid"
+// @Param       currency query string false "Currency of the grand totals, defaults to the base currency"
+// @Security    BearerAuth
+// @Success     200 {object} response.SubcontractorStatementsResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     422 {object} response.ErrorResponse
+// @Router      /finance/subcontractors/statements [get]
+func (h *SubcontractHandler) GetStatements(c *gin.Context) {
+	var req request.SubcontractorStatementRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	statements, err := h.subcontractService.GetStatements(req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statements)
+}
+
+// GetAging godoc
+// @Summary     Subcontractor payables aging
+// @Description Bucket the unpaid subcontractor invoice balances by days past their due date (the invoice date when
+// @Description there is none): current, 1-30, 31-60, 61-90 and over 90 days
+// @Tags        finance
+// @Produce     json
+// @Param       type query string false "weaving or dyeing"
+// @Param       as_of query string false "Report date (YYYY-MM-DD), defaults to today"
+// @Param       search query string false "Subcontractor name"
+// @Param       currency query string false "Currency of the totals, defaults to the base currency"
+// @Security    BearerAuth
+// @Success     200 {object} response.PayablesAgingResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     422 {object} response.ErrorResponse
+// @Router      /finance/subcontractors/aging [get]
+func (h *SubcontractHandler) GetAging(c *gin.Context) {
+	var req request.PayablesAgingRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	aging, err := h.subcontractService.GetAging(req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, aging)
+}
+
+// SaveInvoice godoc
+// @Summary     Record a subcontractor invoice
+// @Description Record or correct the invoice for a weaving order at an outside mill or a dyeing job. The total
+// @Description defaults to the unit cost times the quantity, and cannot go below the amount already paid. The
+// @Description currency defaults to the job's and is fixed once payments are recorded.
+// @Tags        finance
+// @Accept      json
+// @Produce     json
+// @Param       type path string true "weaving or dyeing"
+// @Param       id path int true "Weaving order or dyeing job ID"
+// @Param       invoice body request.SaveSubcontractInvoiceRequest true "Invoice"
+// @Security    BearerAuth
+// @Success     200 {object} response.SubcontractJobResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     422 {object} response.ErrorResponse
+// @Router      /finance/subcontractors/{type}/{id}/invoice [put]
+func (h *SubcontractHandler) SaveInvoice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.SaveSubcontractInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := h.subcontractService.SaveInvoice(c.Param("type"), uint(id), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// RecordPayment godoc
+// @Summary     Record a subcontractor payment
+// @Description Pay part or all of a job's invoice; the invoice becomes partial or paid
+// @Tags        finance
+// @Accept      json
+// @Produce     json
+// @Param       type path string true "weaving or dyeing"
+// @Param       id path int true "Weaving order or dyeing job ID"
+// @Param       payment body request.RecordSubcontractorPaymentRequest true "Payment"
+// @Security    BearerAuth
+// @Success     201 {object} response.SubcontractorPaymentResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     422 {object} response.ErrorResponse
+// @Router      /finance/subcontractors/{type}/{id}/payments [post]
+func (h *SubcontractHandler) RecordPayment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.RecordSubcontractorPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	payment, err := h.subcontractService.RecordPayment(c.Param("type"), uint(id), req, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, payment)
+}
+
+// GetPayments godoc
+// @Summary     Get a job's subcontractor payments
+// @Description List the payments made against a job's invoice, oldest first
+// @Tags        finance
+// @Produce     json
+// @Param       type path string true "weaving or dyeing"
+// @Param       id path int true "Weaving order or dyeing job ID"
+// @Security    BearerAuth
+// @Success     200 {array} response.SubcontractorPaymentResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /finance/subcontractors/{type}/{id}/payments [get]
+func (h *SubcontractHandler) GetPayments(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	payments, err := h.subcontractService.GetPayments(c.Param("type"), uint(id))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payments)
+}
+
+func (h *SubcontractHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrSubcontractJobNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrSubcontractNotInvoiced),
+		errors.Is(err, services.ErrPaymentExceedsBalance),
+		errors.Is(err, services.ErrInvoiceBelowPaid),
+		errors.Is(err, services.ErrInvoiceCurrencyLocked),
+		errors.Is(err, services.ErrNoExchangeRate):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -5,6 +5,7 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -25,13 +26,18 @@ func NewUserHandler(userService services.UserService) *UserHandler {
 
 // GetAll godoc
 // @Summary     Get all users
-// @Description Get a list of users with pagination and filtering
+// @Description Get a list of users with pagination and filtering.
+// @Description With pagination=cursor the response is a response.CursorPaginatedResponse with next_cursor instead of page counts.
 // @Tags        users
 // @Accept      json
 // @Produce     json
 // @Param       page query int false "Page number"
 // @Param       limit query int false "Items per page"
 // @Param       search query string false "Search term for username or email"
+// @Param       pagination query string false "offset (default) or cursor"
+// @Param       cursor query string false "next_cursor from the previous page"
+// @Param       sort_by query string false "Cursor mode sort field (id, username, email, account_status, created_at), '-' prefix for descending"
+// @Param       total query string false "Cursor mode total: none (default), estimate or exact"
 // @Security    BearerAuth
 // @Success     200 {object} response.PaginatedResponse
 // @Failure     401 {object} response.ErrorResponse
@@ -45,8 +51,18 @@ func (h *UserHandler) GetAll(c *gin.Context) {
 		return
 	}
 
-	res, err := h.userService.GetUsers(req)
+	var res interface{}
+	var err error
+	if req.UsesCursor() {
+		res, err = h.userService.GetUsersByCursor(req)
+	} else {
+		res, err = h.userService.GetUsers(req)
+	}
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
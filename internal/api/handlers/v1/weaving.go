@@ -0,0 +1,505 @@
+// File: internal/api/handlers/v1/weaving.go
+// Tạo tại: internal/api/handlers/v1/weaving.go
+// Mục đích: Handler lệnh dệt, công đoạn dệt và tiến độ dệt của đơn hàng
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type WeavingHandler struct {
+	weavingService services.WeavingService
+}
+
+func NewWeavingHandler(weavingService services.WeavingService) *WeavingHandler {
+	return &WeavingHandler{
+		weavingService: weavingService,
+	}
+}
+
+// GetAll godoc
+// @Summary     Get all weaving orders
+// @Description Get weaving orders, optionally filtered by status, facility, sales order or product
+// @Tags        weaving
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       search query string false "Weaving order code or sales order code"
+// @Param       status query []string false "pending, in_progress, completed, sent_to_dyeing, cancelled" collectionFormat(multi)
+// @Param       facility_id query int false "Filter by facility"
+// @Param       linked_order_id query int false "Filter by sales order"
+// @Param       product_id query int false "Filter by product"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /weaving/orders [get]
+func (h *WeavingHandler) GetAll(c *gin.Context) {
+	var req request.WeavingOrderFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orders, err := h.weavingService.GetWeavingOrders(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// GetByID godoc
+// @Summary     Get weaving order by ID
+// @Description Get a weaving order with its planned and produced quantity
+// @Tags        weaving
+// @Produce     json
+// @Param       id path int true "Weaving order ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingOrderResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/orders/{id} [get]
+func (h *WeavingHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	order, err := h.weavingService.GetWeavingOrderByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// Create godoc
+// @Summary     Create a weaving order
+// @Description Weave a product of a confirmed or in-production sales order from a yarn box at a facility.
+// @Description Without planned_quantity the order plans what the sales order still needs of the product. The
+// @Description color defaults to the sales order line's; until end_date the meters not yet woven count as incoming
+// @Description supply for available-to-promise.
+// @Tags        weaving
+// @Accept      json
+// @Produce     json
+// @Param       order body request.CreateWeavingOrderRequest true "Weaving order to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.WeavingOrderResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /weaving/orders [post]
+func (h *WeavingHandler) Create(c *gin.Context) {
+	var req request.CreateWeavingOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.weavingService.CreateWeavingOrder(req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// Update godoc
+// @Summary     Update a weaving order
+// @Description Change the yarn box, planned quantity or notes of an open weaving order; the facility only while it is pending
+// @Tags        weaving
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Weaving order ID"
+// @Param       order body request.UpdateWeavingOrderRequest true "Weaving order data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingOrderResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/orders/{id} [put]
+func (h *WeavingHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateWeavingOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.weavingService.UpdateWeavingOrder(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrWeavingOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// Delete godoc
+// @Summary     Delete a weaving order
+// @Description Delete a pending weaving order; started orders are cancelled instead
+// @Tags        weaving
+// @Produce     json
+// @Param       id path int true "Weaving order ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /weaving/orders/{id} [delete]
+func (h *WeavingHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.weavingService.DeleteWeavingOrder(uint(id)); err != nil {
+		if errors.Is(err, services.ErrWeavingOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ChangeStatus godoc
+// @Summary     Change a weaving order status
+// @Description Start a pending order, complete an in-progress one, send a completed one to dyeing or cancel
+// @Description an open one. Starting moves a confirmed sales order into production; an order with failed
+// @Description inspections that lack corrective action cannot go to dyeing.
+// @Tags        weaving
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Weaving order ID"
+// @Param       status body request.ChangeWeavingOrderStatusRequest true "New status"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingOrderResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Failure     422 {object} response.ErrorResponse
+// @Router      /weaving/orders/{id}/status [put]
+func (h *WeavingHandler) ChangeStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.ChangeWeavingOrderStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.weavingService.ChangeStatus(uint(id), req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeavingOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrWeavingOrderStatusChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrWeavingOrderGuardFailed):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// GetOperations godoc
+// @Summary     Get weaving operations
+// @Description Get logged weaving operations, optionally filtered by facility, staff, shift, machine or start date
+// @Tags        weaving
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       weaving_order_id query int false "Filter by weaving order"
+// @Param       facility_id query int false "Filter by facility"
+// @Param       staff_id query int false "Filter by staff"
+// @Param       shift_id query int false "Filter by shift"
+// @Param       machine_id query int false "Filter by machine"
+// @Param       from query string false "From date (YYYY-MM-DD)"
+// @Param       to query string false "To date, inclusive (YYYY-MM-DD)"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /weaving/operations [get]
+func (h *WeavingHandler) GetOperations(c *gin.Context) {
+	var req request.WeavingOperationFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	operations, err := h.weavingService.GetOperations(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, operations)
+}
+
+// GetOrderOperations godoc
+// @Summary     Get the operations of a weaving order
+// @Description Get the operations logged on a weaving order, latest first
+// @Tags        weaving
+// @Produce     json
+// @Param       id path int true "Weaving order ID"
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/orders/{id}/operations [get]
+func (h *WeavingHandler) GetOrderOperations(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.WeavingOperationFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := h.weavingService.GetWeavingOrderByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	req.WeavingOrderID = uint(id)
+
+	operations, err := h.weavingService.GetOperations(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, operations)
+}
+
+// LogOperation godoc
+// @Summary     Log a weaving operation
+// @Description Record a run of a weaving order on a machine by a worker during a shift. The quantity adds to
+// @Description the order's progress; the first operation starts a pending order and its sales order.
+// @Tags        weaving
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Weaving order ID"
+// @Param       operation body request.CreateWeavingOperationRequest true "Operation to log"
+// @Security    BearerAuth
+// @Success     201 {object} response.WeavingOperationResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /weaving/orders/{id}/operations [post]
+func (h *WeavingHandler) LogOperation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.CreateWeavingOperationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	operation, err := h.weavingService.LogOperation(uint(id), req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeavingOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrWeavingOrderStatusChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, operation)
+}
+
+// UpdateOperation godoc
+// @Summary     Update a weaving operation
+// @Description Correct a logged operation of an open weaving order; the order's progress follows the quantity
+// @Tags        weaving
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Operation ID"
+// @Param       operation body request.UpdateWeavingOperationRequest true "Operation data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingOperationResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /weaving/operations/{id} [put]
+func (h *WeavingHandler) UpdateOperation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateWeavingOperationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	operation, err := h.weavingService.UpdateOperation(uint(id), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeavingOperationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrWeavingOrderStatusChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, operation)
+}
+
+// DeleteOperation godoc
+// @Summary     Delete a weaving operation
+// @Description Delete a logged operation of an open weaving order; its quantity leaves the order's progress
+// @Tags        weaving
+// @Produce     json
+// @Param       id path int true "Operation ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /weaving/operations/{id} [delete]
+func (h *WeavingHandler) DeleteOperation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.weavingService.DeleteOperation(uint(id)); err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeavingOperationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrWeavingOrderStatusChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetOrderWeaving godoc
+// @Summary     Get the weaving progress of an order
+// @Description Roll the order's weaving orders up per product and for the whole order: meters ordered,
+// @Description planned in weaving orders that are not cancelled and woven so far
+// @Tags        orders
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.OrderWeavingResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /orders/{id}/weaving [get]
+func (h *WeavingHandler) GetOrderWeaving(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	progress, err := h.weavingService.GetOrderWeaving(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
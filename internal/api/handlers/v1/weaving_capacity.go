@@ -0,0 +1,367 @@
+// File: internal/api/handlers/v1/weaving_capacity.go
+// Tạo tại: internal/api/handlers/v1/weaving_capacity.go
+// Mục đích: Handler máy dệt, lịch phân ca công nhân và kế hoạch công suất dệt theo xưởng
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type WeavingCapacityHandler struct {
+	capacityService services.WeavingCapacityService
+}
+
+func NewWeavingCapacityHandler(capacityService services.WeavingCapacityService) *WeavingCapacityHandler {
+	return &WeavingCapacityHandler{
+		capacityService: capacityService,
+	}
+}
+
+// GetMachines godoc
+// @Summary     Get weaving machines
+// @Description Get the machine registry, optionally filtered by facility, loom type or status
+// @Tags        weaving-capacity
+// @Produce     json
+// @Param       facility_id query int false "Filter by facility"
+// @Param       loom_type query string false "air_jet, rapier, water_jet, projectile or shuttle"
+// @Param       status query []string false "active, maintenance, retired" collectionFormat(multi)
+// @Param       search query string false "Machine code or notes"
+// @Security    BearerAuth
+// @Success     200 {array} response.WeavingMachineResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /weaving/machines [get]
+func (h *WeavingCapacityHandler) GetMachines(c *gin.Context) {
+	var req request.WeavingMachineFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	machines, err := h.capacityService.GetMachines(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, machines)
+}
+
+// GetMachineByID godoc
+// @Summary     Get a weaving machine
+// @Description Get a weaving machine by ID
+// @Tags        weaving-capacity
+// @Produce     json
+// @Param       id path int true "Machine ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingMachineResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/machines/{id} [get]
+func (h *WeavingCapacityHandler) GetMachineByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	machine, err := h.capacityService.GetMachineByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, machine)
+}
+
+// CreateMachine godoc
+// @Summary     Register a weaving machine
+// @Description Add a loom to a facility with its type, widest fabric (cm), speed (picks per minute) and
+// @Description output per running hour (m), which the capacity planner uses
+// @Tags        weaving-capacity
+// @Accept      json
+// @Produce     json
+// @Param       machine body request.CreateWeavingMachineRequest true "Machine to register"
+// @Security    BearerAuth
+// @Success     201 {object} response.WeavingMachineResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/machines [post]
+func (h *WeavingCapacityHandler) CreateMachine(c *gin.Context) {
+	var req request.CreateWeavingMachineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	machine, err := h.capacityService.CreateMachine(req)
+	if err != nil {
+		if errors.Is(err, services.ErrWeavingFacilityNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, machine)
+}
+
+// UpdateMachine godoc
+// @Summary     Update a weaving machine
+// @Description Update a machine; machines in maintenance or retired are left out of capacity planning
+// @Tags        weaving-capacity
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Machine ID"
+// @Param       machine body request.UpdateWeavingMachineRequest true "Machine data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingMachineResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/machines/{id} [put]
+func (h *WeavingCapacityHandler) UpdateMachine(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateWeavingMachineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	machine, err := h.capacityService.UpdateMachine(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrWeavingMachineNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, machine)
+}
+
+// DeleteMachine godoc
+// @Summary     Delete a weaving machine
+// @Description Remove a machine from the registry; logged operations keep it
+// @Tags        weaving-capacity
+// @Produce     json
+// @Param       id path int true "Machine ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/machines/{id} [delete]
+func (h *WeavingCapacityHandler) DeleteMachine(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.capacityService.DeleteMachine(uint(id)); err != nil {
+		if errors.Is(err, services.ErrWeavingMachineNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetRosters godoc
+// @Summary     Get the shift roster of a facility
+// @Description Get who works which shift and machine per day; defaults to the week from today
+// @Tags        weaving-capacity
+// @Produce     json
+// @Param       id path int true "Facility ID"
+// @Param       staff_id query int false "Filter by staff"
+// @Param       shift_id query int false "Filter by shift"
+// @Param       machine_id query int false "Filter by machine"
+// @Param       from query string false "First day (YYYY-MM-DD)"
+// @Param       to query string false "Last day (YYYY-MM-DD)"
+// @Security    BearerAuth
+// @Success     200 {array} response.WeavingRosterResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/facilities/{id}/rosters [get]
+func (h *WeavingCapacityHandler) GetRosters(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.WeavingRosterFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rosters, err := h.capacityService.GetRosters(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrWeavingFacilityNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rosters)
+}
+
+// SaveRosters godoc
+// @Summary     Roster staff on a shift
+// @Description Put staff on a shift of the facility, optionally each on a machine, for every day of a range
+// @Description of up to 31 days. Staff already rostered on one of those days get the new shift instead.
+// @Tags        weaving-capacity
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Facility ID"
+// @Param       roster body request.CreateWeavingRostersRequest true "Shift, days and staff"
+// @Security    BearerAuth
+// @Success     201 {array} response.WeavingRosterResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/facilities/{id}/rosters [post]
+func (h *WeavingCapacityHandler) SaveRosters(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.CreateWeavingRostersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	rosters, err := h.capacityService.SaveRosters(uint(id), req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeavingFacilityNotFound), errors.Is(err, services.ErrWeavingShiftNotFound),
+			errors.Is(err, services.ErrWeavingStaffNotFound), errors.Is(err, services.ErrWeavingMachineNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, rosters)
+}
+
+// DeleteRoster godoc
+// @Summary     Delete a roster entry
+// @Description Take a staff member off the shift they were rostered on for a day
+// @Tags        weaving-capacity
+// @Produce     json
+// @Param       id path int true "Roster entry ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/rosters/{id} [delete]
+func (h *WeavingCapacityHandler) DeleteRoster(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.capacityService.DeleteRoster(uint(id)); err != nil {
+		if errors.Is(err, services.ErrWeavingRosterNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PlanCapacity godoc
+// @Summary     Plan weaving capacity
+// @Description Propose a machine and shifts for each pending or in-progress weaving order of the facility,
+// @Description earliest due date first, with projected start and completion. Machines run when the roster
+// @Description (or, on days without one, the staff's usual shift) has someone for them. Orders that finish
+// @Description after their due date or do not fit in the window are flagged as overloaded.
+// @Tags        weaving-capacity
+// @Produce     json
+// @Param       id path int true "Facility ID"
+// @Param       from query string false "First day (YYYY-MM-DD), defaults to today"
+// @Param       days query int false "Days to plan, 1 to 90 (default 14)"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingCapacityPlanResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/facilities/{id}/capacity-plan [get]
+func (h *WeavingCapacityHandler) PlanCapacity(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.WeavingCapacityPlanRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan, err := h.capacityService.PlanCapacity(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrWeavingFacilityNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
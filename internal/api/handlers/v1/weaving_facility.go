@@ -0,0 +1,502 @@
+// File: internal/api/handlers/v1/weaving_facility.go
+// Tạo tại: internal/api/handlers/v1/weaving_facility.go
+// Mục đích: Handler xưởng dệt, ca làm việc và công nhân dệt
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type WeavingFacilityHandler struct {
+	facilityService services.WeavingFacilityService
+}
+
+func NewWeavingFacilityHandler(facilityService services.WeavingFacilityService) *WeavingFacilityHandler {
+	return &WeavingFacilityHandler{
+		facilityService: facilityService,
+	}
+}
+
+// GetAll godoc
+// @Summary     Get all weaving facilities
+// @Description Get the weaving facilities, optionally filtered by name, location or type
+// @Tags        weaving
+// @Produce     json
+// @Param       search query string false "Name or location"
+// @Param       facility_type query string false "internal or external"
+// @Security    BearerAuth
+// @Success     200 {array}  response.WeavingFacilityResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /weaving/facilities [get]
+func (h *WeavingFacilityHandler) GetAll(c *gin.Context) {
+	var req request.WeavingFacilityFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	facilities, err := h.facilityService.GetFacilities(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, facilities)
+}
+
+// GetByID godoc
+// @Summary     Get weaving facility by ID
+// @Description Get a weaving facility with its shifts
+// @Tags        weaving
+// @Produce     json
+// @Param       id path int true "Facility ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingFacilityResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/facilities/{id} [get]
+func (h *WeavingFacilityHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	facility, err := h.facilityService.GetFacilityByID(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrWeavingFacilityNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, facility)
+}
+
+// Create godoc
+// @Summary     Create a weaving facility
+// @Description Register an internal weaving mill or an external subcontractor
+// @Tags        weaving
+// @Accept      json
+// @Produce     json
+// @Param       facility body request.CreateWeavingFacilityRequest true "Facility to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.WeavingFacilityResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /weaving/facilities [post]
+func (h *WeavingFacilityHandler) Create(c *gin.Context) {
+	var req request.CreateWeavingFacilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	facility, err := h.facilityService.CreateFacility(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, facility)
+}
+
+// Update godoc
+// @Summary     Update a weaving facility
+// @Description Change the name, location, contact or type of a facility
+// @Tags        weaving
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Facility ID"
+// @Param       facility body request.UpdateWeavingFacilityRequest true "Facility data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingFacilityResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/facilities/{id} [put]
+func (h *WeavingFacilityHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateWeavingFacilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	facility, err := h.facilityService.UpdateFacility(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrWeavingFacilityNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, facility)
+}
+
+// Delete godoc
+// @Summary     Delete a weaving facility
+// @Description Delete a facility that has no pending or in-progress weaving orders
+// @Tags        weaving
+// @Produce     json
+// @Param       id path int true "Facility ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /weaving/facilities/{id} [delete]
+func (h *WeavingFacilityHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.facilityService.DeleteFacility(uint(id)); err != nil {
+		if errors.Is(err, services.ErrWeavingFacilityNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetShifts godoc
+// @Summary     Get the shifts of a weaving facility
+// @Description Get the daily shifts of a facility by start time
+// @Tags        weaving
+// @Produce     json
+// @Param       id path int true "Facility ID"
+// @Security    BearerAuth
+// @Success     200 {array}  response.WeavingShiftResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/facilities/{id}/shifts [get]
+func (h *WeavingFacilityHandler) GetShifts(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	shifts, err := h.facilityService.GetShifts(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrWeavingFacilityNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, shifts)
+}
+
+// CreateShift godoc
+// @Summary     Create a weaving shift
+// @Description Add a daily shift to a facility. Times are HH:MM; a shift ending before it starts runs past midnight.
+// @Tags        weaving
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Facility ID"
+// @Param       shift body request.CreateWeavingShiftRequest true "Shift to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.WeavingShiftResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/facilities/{id}/shifts [post]
+func (h *WeavingFacilityHandler) CreateShift(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.CreateWeavingShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shift, err := h.facilityService.CreateShift(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrWeavingFacilityNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, shift)
+}
+
+// UpdateShift godoc
+// @Summary     Update a weaving shift
+// @Description Change the name, times or leader of a shift
+// @Tags        weaving
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Shift ID"
+// @Param       shift body request.UpdateWeavingShiftRequest true "Shift data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingShiftResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/shifts/{id} [put]
+func (h *WeavingFacilityHandler) UpdateShift(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateWeavingShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shift, err := h.facilityService.UpdateShift(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrWeavingShiftNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, shift)
+}
+
+// DeleteShift godoc
+// @Summary     Delete a weaving shift
+// @Description Delete a shift. Its staff and logged operations keep no shift.
+// @Tags        weaving
+// @Produce     json
+// @Param       id path int true "Shift ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/shifts/{id} [delete]
+func (h *WeavingFacilityHandler) DeleteShift(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.facilityService.DeleteShift(uint(id)); err != nil {
+		if errors.Is(err, services.ErrWeavingShiftNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetStaff godoc
+// @Summary     Get weaving staff
+// @Description Get weaving staff, optionally filtered by facility, shift, name or position
+// @Tags        weaving
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       facility_id query int false "Filter by facility"
+// @Param       shift_id query int false "Filter by shift"
+// @Param       search query string false "Name or position"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /weaving/staff [get]
+func (h *WeavingFacilityHandler) GetStaff(c *gin.Context) {
+	var req request.WeavingStaffFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	staff, err := h.facilityService.GetStaff(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, staff)
+}
+
+// GetStaffByID godoc
+// @Summary     Get weaving staff by ID
+// @Description Get a weaving worker with their facility and shift
+// @Tags        weaving
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingStaffResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/staff/{id} [get]
+func (h *WeavingFacilityHandler) GetStaffByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	staff, err := h.facilityService.GetStaffByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, staff)
+}
+
+// CreateStaff godoc
+// @Summary     Create weaving staff
+// @Description Register a worker at a facility, optionally with the shift they usually work
+// @Tags        weaving
+// @Accept      json
+// @Produce     json
+// @Param       staff body request.CreateWeavingStaffRequest true "Staff to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.WeavingStaffResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /weaving/staff [post]
+func (h *WeavingFacilityHandler) CreateStaff(c *gin.Context) {
+	var req request.CreateWeavingStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	staff, err := h.facilityService.CreateStaff(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, staff)
+}
+
+// UpdateStaff godoc
+// @Summary     Update weaving staff
+// @Description Change a worker's details, facility or shift; shift_id 0 clears the shift
+// @Tags        weaving
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Param       staff body request.UpdateWeavingStaffRequest true "Staff data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingStaffResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/staff/{id} [put]
+func (h *WeavingFacilityHandler) UpdateStaff(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateWeavingStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	staff, err := h.facilityService.UpdateStaff(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrWeavingStaffNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, staff)
+}
+
+// DeleteStaff godoc
+// @Summary     Delete weaving staff
+// @Description Delete a worker. Their logged operations are kept.
+// @Tags        weaving
+// @Produce     json
+// @Param       id path int true "Staff ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/staff/{id} [delete]
+func (h *WeavingFacilityHandler) DeleteStaff(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.facilityService.DeleteStaff(uint(id)); err != nil {
+		if errors.Is(err, services.ErrWeavingStaffNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,388 @@
+// File: internal/api/handlers/v1/weaving_quality.go
+// Tạo tại: internal/api/handlers/v1/weaving_quality.go
+// Mục đích: Handler danh mục lỗi vải và phiếu kiểm tra chất lượng công đoạn dệt
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type WeavingQualityHandler struct {
+	qualityService services.WeavingQualityService
+}
+
+func NewWeavingQualityHandler(qualityService services.WeavingQualityService) *WeavingQualityHandler {
+	return &WeavingQualityHandler{
+		qualityService: qualityService,
+	}
+}
+
+// GetDefectTypes godoc
+// @Summary     Get the defect catalog
+// @Description Get fabric defect types, optionally filtered by category
+// @Tags        weaving-quality
+// @Produce     json
+// @Param       search query string false "Code or name"
+// @Param       category query string false "yarn, warp, weft, fabric or other"
+// @Param       active_only query bool false "Only active defect types"
+// @Security    BearerAuth
+// @Success     200 {array} response.DefectTypeResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /weaving/defect-types [get]
+func (h *WeavingQualityHandler) GetDefectTypes(c *gin.Context) {
+	var req request.DefectTypeFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	defectTypes, err := h.qualityService.GetDefectTypes(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, defectTypes)
+}
+
+// GetDefectTypeByID godoc
+// @Summary     Get a defect type
+// @Description Get a fabric defect type by ID
+// @Tags        weaving-quality
+// @Produce     json
+// @Param       id path int true "Defect type ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.DefectTypeResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/defect-types/{id} [get]
+func (h *WeavingQualityHandler) GetDefectTypeByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	defectType, err := h.qualityService.GetDefectTypeByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, defectType)
+}
+
+// CreateDefectType godoc
+// @Summary     Create a defect type
+// @Description Add a fabric defect type to the catalog; fixed points score it regardless of length
+// @Tags        weaving-quality
+// @Accept      json
+// @Produce     json
+// @Param       defect_type body request.CreateDefectTypeRequest true "Defect type to create"
+// @Security    BearerAuth
+// @Success     201 {object} response.DefectTypeResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /weaving/defect-types [post]
+func (h *WeavingQualityHandler) CreateDefectType(c *gin.Context) {
+	var req request.CreateDefectTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	defectType, err := h.qualityService.CreateDefectType(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, defectType)
+}
+
+// UpdateDefectType godoc
+// @Summary     Update a defect type
+// @Description Update a fabric defect type; inactive types cannot be recorded on new inspections
+// @Tags        weaving-quality
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Defect type ID"
+// @Param       defect_type body request.UpdateDefectTypeRequest true "Defect type data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.DefectTypeResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/defect-types/{id} [put]
+func (h *WeavingQualityHandler) UpdateDefectType(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateDefectTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	defectType, err := h.qualityService.UpdateDefectType(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrDefectTypeNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, defectType)
+}
+
+// DeleteDefectType godoc
+// @Summary     Delete a defect type
+// @Description Remove a fabric defect type from the catalog; past inspections keep it
+// @Tags        weaving-quality
+// @Produce     json
+// @Param       id path int true "Defect type ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/defect-types/{id} [delete]
+func (h *WeavingQualityHandler) DeleteDefectType(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.qualityService.DeleteDefectType(uint(id)); err != nil {
+		if errors.Is(err, services.ErrDefectTypeNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetInspections godoc
+// @Summary     Get quality inspections
+// @Description Get weaving quality inspections, latest first, optionally only failed ones without corrective action
+// @Tags        weaving-quality
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       weaving_order_id query int false "Filter by weaving order"
+// @Param       operation_id query int false "Filter by operation"
+// @Param       status query string false "passed or failed"
+// @Param       uncorrected query bool false "Only failed inspections without corrective action"
+// @Param       from query string false "Inspected on or after (YYYY-MM-DD)"
+// @Param       to query string false "Inspected on or before (YYYY-MM-DD)"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /weaving/inspections [get]
+func (h *WeavingQualityHandler) GetInspections(c *gin.Context) {
+	var req request.WeavingInspectionFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	inspections, err := h.qualityService.GetInspections(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, inspections)
+}
+
+// GetInspectionByID godoc
+// @Summary     Get a quality inspection
+// @Description Get a weaving quality inspection with its defects
+// @Tags        weaving-quality
+// @Produce     json
+// @Param       id path int true "Inspection ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingInspectionResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/inspections/{id} [get]
+func (h *WeavingQualityHandler) GetInspectionByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	inspection, err := h.qualityService.GetInspectionByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, inspection)
+}
+
+// Inspect godoc
+// @Summary     Inspect a weaving operation
+// @Description Score the fabric of an operation with the 4-point system. Each defect takes 1 to 4 points by
+// @Description length (up to 3, 6, 9 inches and longer), at most 4 per linear yard; the operation passes when
+// @Description the points per 100 square yards stay within max_points (default 40).
+// @Tags        weaving-quality
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Operation ID"
+// @Param       inspection body request.CreateWeavingInspectionRequest true "Inspection result"
+// @Security    BearerAuth
+// @Success     201 {object} response.WeavingInspectionResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /weaving/operations/{id}/inspections [post]
+func (h *WeavingQualityHandler) Inspect(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.CreateWeavingInspectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	inspection, err := h.qualityService.Inspect(uint(id), req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeavingOperationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrWeavingOrderStatusChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, inspection)
+}
+
+// RecordCorrectiveAction godoc
+// @Summary     Record corrective action
+// @Description Record the corrective action of a failed inspection; the weaving order can go to dyeing once
+// @Description all its failed inspections have one
+// @Tags        weaving-quality
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Inspection ID"
+// @Param       action body request.RecordCorrectiveActionRequest true "Corrective action"
+// @Security    BearerAuth
+// @Success     200 {object} response.WeavingInspectionResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /weaving/inspections/{id}/corrective-action [put]
+func (h *WeavingQualityHandler) RecordCorrectiveAction(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.RecordCorrectiveActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	inspection, err := h.qualityService.RecordCorrectiveAction(uint(id), req, userID)
+	if err != nil {
+		if errors.Is(err, services.ErrWeavingInspectionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, inspection)
+}
+
+// DeleteInspection godoc
+// @Summary     Delete a quality inspection
+// @Description Delete an inspection; its operation takes the result of its latest remaining inspection.
+// @Description A failed inspection needs its corrective action recorded first.
+// @Tags        weaving-quality
+// @Produce     json
+// @Param       id path int true "Inspection ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /weaving/inspections/{id} [delete]
+func (h *WeavingQualityHandler) DeleteInspection(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.qualityService.DeleteInspection(uint(id)); err != nil {
+		switch {
+		case errors.Is(err, services.ErrWeavingInspectionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrWeavingInspectionUncorrected):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
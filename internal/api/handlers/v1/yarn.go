@@ -0,0 +1,426 @@
+// File: internal/api/handlers/v1/yarn.go
+// Tạo tại: internal/api/handlers/v1/yarn.go
+// Mục đích: Handler kho sợi (đăng ký thùng sợi, nhập/xuất, sổ giao dịch và tồn theo loại sợi)
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+)
+
+type YarnHandler struct {
+	yarnService services.YarnService
+}
+
+func NewYarnHandler(yarnService services.YarnService) *YarnHandler {
+	return &YarnHandler{
+		yarnService: yarnService,
+	}
+}
+
+// GetAll godoc
+// @Summary     Get all yarn boxes
+// @Description Get yarn boxes, optionally filtered by yarn type, warehouse location or status
+// @Tags        yarn
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       search query string false "Box code or yarn type"
+// @Param       yarn_type query string false "Filter by yarn type"
+// @Param       location query string false "Filter by warehouse location prefix"
+// @Param       status query []string false "available, allocated, depleted" collectionFormat(multi)
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /warehouse/yarn-boxes [get]
+func (h *YarnHandler) GetAll(c *gin.Context) {
+	var req request.YarnBoxFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	boxes, err := h.yarnService.GetYarnBoxes(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, boxes)
+}
+
+// GetByID godoc
+// @Summary     Get yarn box by ID
+// @Description Get a yarn box with its current cones, weight and location
+// @Tags        yarn
+// @Produce     json
+// @Param       id path int true "Yarn box ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.YarnBoxResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /warehouse/yarn-boxes/{id} [get]
+func (h *YarnHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	box, err := h.yarnService.GetYarnBoxByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, box)
+}
+
+// Create godoc
+// @Summary     Register a yarn box
+// @Description Register a yarn box. cone_quantity and total_weight are received as its first IN transaction;
+// @Description a box registered empty is depleted until yarn is received into it.
+// @Tags        yarn
+// @Accept      json
+// @Produce     json
+// @Param       box body request.CreateYarnBoxRequest true "Yarn box to register"
+// @Security    BearerAuth
+// @Success     201 {object} response.YarnBoxResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Router      /warehouse/yarn-boxes [post]
+func (h *YarnHandler) Create(c *gin.Context) {
+	var req request.CreateYarnBoxRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	box, err := h.yarnService.CreateYarnBox(req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, box)
+}
+
+// Update godoc
+// @Summary     Update a yarn box
+// @Description Change the yarn type or warehouse location of a box. Cones and weight change through receive and issue.
+// @Tags        yarn
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Yarn box ID"
+// @Param       box body request.UpdateYarnBoxRequest true "Yarn box data to update"
+// @Security    BearerAuth
+// @Success     200 {object} response.YarnBoxResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /warehouse/yarn-boxes/{id} [put]
+func (h *YarnHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.UpdateYarnBoxRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	box, err := h.yarnService.UpdateYarnBox(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrYarnBoxNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, box)
+}
+
+// Delete godoc
+// @Summary     Delete a yarn box
+// @Description Delete an empty yarn box. Its transactions are kept.
+// @Tags        yarn
+// @Produce     json
+// @Param       id path int true "Yarn box ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /warehouse/yarn-boxes/{id} [delete]
+func (h *YarnHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.yarnService.DeleteYarnBox(uint(id)); err != nil {
+		if errors.Is(err, services.ErrYarnBoxNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ChangeStatus godoc
+// @Summary     Change a yarn box status
+// @Description Allocate an available box or release an allocated one. Boxes become depleted when emptied
+// @Description and available again when restocked.
+// @Tags        yarn
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Yarn box ID"
+// @Param       status body request.ChangeYarnBoxStatusRequest true "New status"
+// @Security    BearerAuth
+// @Success     200 {object} response.YarnBoxResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /warehouse/yarn-boxes/{id}/status [put]
+func (h *YarnHandler) ChangeStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.ChangeYarnBoxStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	box, err := h.yarnService.ChangeStatus(uint(id), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrYarnBoxNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrYarnBoxStatusChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, box)
+}
+
+// Receive godoc
+// @Summary     Receive yarn into a box
+// @Description Add cones and weight to a box (IN). The transaction keeps the box balance after it;
+// @Description warehouse_location, when set, moves the box.
+// @Tags        yarn
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Yarn box ID"
+// @Param       movement body request.YarnMovementRequest true "Cones and weight received"
+// @Security    BearerAuth
+// @Success     201 {object} response.YarnMovementResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /warehouse/yarn-boxes/{id}/receive [post]
+func (h *YarnHandler) Receive(c *gin.Context) {
+	h.record(c, h.yarnService.Receive)
+}
+
+// Issue godoc
+// @Summary     Issue yarn from a box
+// @Description Take cones and weight out of a box (OUT). Issuing more than the box holds, less what is
+// @Description allocated to orders and not yet issued, is refused; a box emptied by the issue becomes depleted.
+// @Tags        yarn
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Yarn box ID"
+// @Param       movement body request.YarnMovementRequest true "Cones and weight issued"
+// @Security    BearerAuth
+// @Success     201 {object} response.YarnMovementResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /warehouse/yarn-boxes/{id}/issue [post]
+func (h *YarnHandler) Issue(c *gin.Context) {
+	h.record(c, h.yarnService.Issue)
+}
+
+func (h *YarnHandler) record(c *gin.Context, move func(uint, request.YarnMovementRequest, uint) (*response.YarnMovementResponse, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.YarnMovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	movement, err := move(uint(id), req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrYarnBoxNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInsufficientYarn):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, movement)
+}
+
+// GetTransactions godoc
+// @Summary     Get yarn transactions
+// @Description Get the IN/OUT ledger of yarn boxes, newest first, with the box balance after each movement
+// @Tags        yarn
+// @Produce     json
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       yarn_box_id query int false "Filter by yarn box"
+// @Param       transaction_type query string false "IN or OUT"
+// @Param       from query string false "First day (YYYY-MM-DD)"
+// @Param       to query string false "Last day, inclusive (YYYY-MM-DD)"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /warehouse/yarn-transactions [get]
+func (h *YarnHandler) GetTransactions(c *gin.Context) {
+	var req request.YarnTransactionFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transactions, err := h.yarnService.GetTransactions(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transactions)
+}
+
+// GetBoxTransactions godoc
+// @Summary     Get the transactions of a yarn box
+// @Description Get the IN/OUT ledger of one yarn box, newest first
+// @Tags        yarn
+// @Produce     json
+// @Param       id path int true "Yarn box ID"
+// @Param       page query int false "Page number"
+// @Param       limit query int false "Items per page"
+// @Param       transaction_type query string false "IN or OUT"
+// @Param       from query string false "First day (YYYY-MM-DD)"
+// @Param       to query string false "Last day, inclusive (YYYY-MM-DD)"
+// @Security    BearerAuth
+// @Success     200 {object} response.PaginatedResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /warehouse/yarn-boxes/{id}/transactions [get]
+func (h *YarnHandler) GetBoxTransactions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.YarnTransactionFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := h.yarnService.GetYarnBoxByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	req.YarnBoxID = uint(id)
+
+	transactions, err := h.yarnService.GetTransactions(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transactions)
+}
+
+// GetStock godoc
+// @Summary     Get yarn stock by type
+// @Description Sum the cones and weight of the boxes that are not depleted per yarn type, with the allocated and free part
+// @Tags        yarn
+// @Produce     json
+// @Param       yarn_type query string false "Only this yarn type"
+// @Security    BearerAuth
+// @Success     200 {array}  response.YarnStockResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /warehouse/yarn-stock [get]
+func (h *YarnHandler) GetStock(c *gin.Context) {
+	stock, err := h.yarnService.GetStock(c.Query("yarn_type"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stock)
+}
@@ -0,0 +1,325 @@
+// File: internal/api/handlers/v1/yarn_allocation.go
+// Tạo tại: internal/api/handlers/v1/yarn_allocation.go
+// Mục đích: Handler định mức sợi theo sản phẩm, nhu cầu và phân bổ sợi cho đơn, xuất sợi cho dệt và báo cáo thiếu sợi
+
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/services"
+	"github.com/godiidev/appsynex/internal/dto/request"
+)
+
+type YarnAllocationHandler struct {
+	allocationService services.YarnAllocationService
+}
+
+func NewYarnAllocationHandler(allocationService services.YarnAllocationService) *YarnAllocationHandler {
+	return &YarnAllocationHandler{
+		allocationService: allocationService,
+	}
+}
+
+// GetComponents godoc
+// @Summary     Get a product's yarn bill of materials
+// @Description Get the yarn types woven into a product and the grams of each in one meter
+// @Tags        products
+// @Produce     json
+// @Param       id path int true "Product ID"
+// @Security    BearerAuth
+// @Success     200 {array}  response.YarnComponentResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /products/{id}/yarn-components [get]
+func (h *YarnAllocationHandler) GetComponents(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	components, err := h.allocationService.GetComponents(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrProductNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, components)
+}
+
+// SetComponents godoc
+// @Summary     Set a product's yarn bill of materials
+// @Description Replace the yarn types of a product and their grams per meter; an empty list clears them
+// @Tags        products
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Product ID"
+// @Param       components body request.SetYarnComponentsRequest true "Bill of materials"
+// @Security    BearerAuth
+// @Success     200 {array}  response.YarnComponentResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /products/{id}/yarn-components [put]
+func (h *YarnAllocationHandler) SetComponents(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.SetYarnComponentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	components, err := h.allocationService.SetComponents(uint(id), req)
+	if err != nil {
+		if errors.Is(err, services.ErrProductNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, components)
+}
+
+// GetOrderYarn godoc
+// @Summary     Get order yarn requirements
+// @Description Get the yarn an order needs per type (line meters × grams per meter of the product's bill of materials),
+// @Description what is allocated or issued, and the shortage against free stock, in kilograms
+// @Tags        orders
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Security    BearerAuth
+// @Success     200 {object} response.OrderYarnResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /orders/{id}/yarn [get]
+func (h *YarnAllocationHandler) GetOrderYarn(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	yarn, err := h.allocationService.GetOrderYarn(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, yarn)
+}
+
+// Allocate godoc
+// @Summary     Allocate yarn to an order
+// @Description Allocate free yarn of available boxes to the order's outstanding requirement, oldest receipt first.
+// @Description Without yarn_types every outstanding type is allocated. Without allow_partial nothing is allocated
+// @Description when a yarn type cannot be covered. Allocations are released when the order is cancelled or ships.
+// @Tags        orders
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Param       allocation body request.AllocateYarnRequest false "Yarn types to allocate"
+// @Security    BearerAuth
+// @Success     201 {object} response.OrderYarnResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /orders/{id}/yarn-allocations [post]
+func (h *YarnAllocationHandler) Allocate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	// The body is optional; without it every yarn type is allocated
+	var req request.AllocateYarnRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	yarn, err := h.allocationService.Allocate(uint(id), req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInsufficientYarn):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, yarn)
+}
+
+// ReleaseAll godoc
+// @Summary     Release order yarn allocations
+// @Description Release every allocated box of an order. Issued yarn stays issued.
+// @Tags        orders
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /orders/{id}/yarn-allocations [delete]
+func (h *YarnAllocationHandler) ReleaseAll(c *gin.Context) {
+	h.release(c, 0)
+}
+
+// Release godoc
+// @Summary     Release a yarn allocation
+// @Description Release one allocated box of an order
+// @Tags        orders
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Param       allocationId path int true "Yarn allocation ID"
+// @Security    BearerAuth
+// @Success     204 {object} nil
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Router      /orders/{id}/yarn-allocations/{allocationId} [delete]
+func (h *YarnAllocationHandler) Release(c *gin.Context) {
+	allocationID, err := strconv.ParseUint(c.Param("allocationId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	h.release(c, uint(allocationID))
+}
+
+func (h *YarnAllocationHandler) release(c *gin.Context, allocationID uint) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.allocationService.Release(uint(id), allocationID); err != nil {
+		if errors.Is(err, services.ErrOrderNotFound) || errors.Is(err, services.ErrYarnAllocationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Issue godoc
+// @Summary     Issue allocated yarn for weaving
+// @Description Take the cones and weight of an allocation out of its box as an OUT transaction
+// @Tags        orders
+// @Accept      json
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Param       allocationId path int true "Yarn allocation ID"
+// @Param       issue body request.IssueYarnAllocationRequest false "Notes"
+// @Security    BearerAuth
+// @Success     201 {object} response.YarnMovementResponse
+// @Failure     400 {object} response.ErrorResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     404 {object} response.ErrorResponse
+// @Failure     409 {object} response.ErrorResponse
+// @Router      /orders/{id}/yarn-allocations/{allocationId}/issue [post]
+func (h *YarnAllocationHandler) Issue(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	allocationID, err := strconv.ParseUint(c.Param("allocationId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req request.IssueYarnAllocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	movement, err := h.allocationService.Issue(uint(id), uint(allocationID), req, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrOrderNotFound), errors.Is(err, services.ErrYarnAllocationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInsufficientYarn), errors.Is(err, services.ErrYarnAllocationChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, movement)
+}
+
+// Shortage godoc
+// @Summary     Yarn shortage report
+// @Description List the yarn types to purchase: what open orders still need beyond their allocated and issued yarn,
+// @Description less the free stock, in kilograms
+// @Tags        yarn
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {array}  response.YarnShortageResponse
+// @Failure     401 {object} response.ErrorResponse
+// @Failure     403 {object} response.ErrorResponse
+// @Failure     500 {object} response.ErrorResponse
+// @Router      /warehouse/yarn-shortage [get]
+func (h *YarnAllocationHandler) Shortage(c *gin.Context) {
+	shortage, err := h.allocationService.Shortage()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, shortage)
+}
@@ -0,0 +1,116 @@
+// File: internal/api/middleware/locale.go
+// Tạo tại: internal/api/middleware/locale.go
+// Mục đích: Chọn ngôn ngữ phản hồi theo ?lang / Accept-Language và dịch thông báo lỗi JSON
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/pkg/i18n"
+)
+
+// LangKey is the gin context key holding the request i18n.Lang
+const LangKey = "lang"
+
+// Locale resolves the response language from the lang query parameter, then Accept-Language,
+// then defaultLang, and translates the "error" field of JSON error responses into it
+func Locale(defaultLang i18n.Lang) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := i18n.Parse(c.Query("lang"))
+		if !ok {
+			lang = i18n.Match(c.GetHeader("Accept-Language"), defaultLang)
+		}
+		c.Set(LangKey, lang)
+		c.Header("Content-Language", string(lang))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		writer := &localizedWriter{ResponseWriter: c.Writer, lang: lang}
+		c.Writer = writer
+		c.Next()
+		writer.flush()
+	}
+}
+
+// GetLang returns the language chosen by Locale, or English outside it
+func GetLang(c *gin.Context) i18n.Lang {
+	if lang, ok := c.Get(LangKey); ok {
+		if lang, ok := lang.(i18n.Lang); ok {
+			return lang
+		}
+	}
+	return i18n.EN
+}
+
+// localizedWriter buffers JSON error bodies so their messages can be translated before sending
+type localizedWriter struct {
+	gin.ResponseWriter
+	lang     i18n.Lang
+	buffer   bytes.Buffer
+	buffered bool
+}
+
+func (w *localizedWriter) capture() bool {
+	if w.buffered {
+		return true
+	}
+	if w.ResponseWriter.Written() || w.Status() < http.StatusBadRequest {
+		return false
+	}
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		return false
+	}
+	w.buffered = true
+	return true
+}
+
+func (w *localizedWriter) Write(data []byte) (int, error) {
+	if w.capture() {
+		return w.buffer.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *localizedWriter) WriteString(s string) (int, error) {
+	if w.capture() {
+		return w.buffer.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *localizedWriter) Written() bool {
+	return w.buffered || w.ResponseWriter.Written()
+}
+
+func (w *localizedWriter) Size() int {
+	if w.buffered {
+		return w.buffer.Len()
+	}
+	return w.ResponseWriter.Size()
+}
+
+// flush writes the buffered body with its "error" message translated
+func (w *localizedWriter) flush() {
+	if !w.buffered {
+		return
+	}
+	w.buffered = false
+
+	body := w.buffer.Bytes()
+	var payload map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err == nil {
+		if message, ok := payload["error"].(string); ok {
+			payload["error"] = i18n.TranslateError(w.lang, message)
+			if translated, err := json.Marshal(payload); err == nil {
+				body = translated
+			}
+		}
+	}
+	w.ResponseWriter.Write(body)
+}
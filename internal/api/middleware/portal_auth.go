@@ -0,0 +1,51 @@
+// File: internal/api/middleware/portal_auth.go
+// Tạo tại: internal/api/middleware/portal_auth.go
+// Mục đích: Middleware xác thực token khách hàng cho API portal
+
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/auth"
+)
+
+// PortalAuth authenticates customer portal requests. Only portal tokens are accepted, and the
+// account must still be active and belong to the customer in the token.
+func PortalAuth(jwtService auth.CustomerJWTService, accountRepo interfaces.CustomerAccountRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+			return
+		}
+
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
+			return
+		}
+
+		claims, err := jwtService.ValidateToken(parts[1])
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		// Disabled accounts and blocked customers lose access before their token expires
+		account, err := accountRepo.FindByID(claims.AccountID)
+		if err != nil || account.CustomerID != claims.CustomerID || account.Status != models.CustomerAccountActive ||
+			account.Customer.ID == 0 || account.Customer.AccountStatus == models.CustomerStatusBlocked {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Account is disabled"})
+			return
+		}
+
+		// Set customer info to context
+		c.Set("customer", claims)
+		c.Next()
+	}
+}
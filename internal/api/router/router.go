@@ -12,10 +12,12 @@ import (
 	"github.com/godiidev/appsynex/internal/domain/services"
 	"github.com/godiidev/appsynex/internal/repository/mysql"
 	"github.com/godiidev/appsynex/pkg/auth"
+	"github.com/godiidev/appsynex/pkg/i18n"
+	"github.com/godiidev/appsynex/pkg/storage"
 	"gorm.io/gorm"
 )
 
-func SetupRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
+func SetupRouter(db *gorm.DB, cfg *config.Config, blobStore storage.BlobStore) *gin.Engine {
 	// Set Gin mode
 	if cfg.Server.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -25,6 +27,16 @@ func SetupRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
 
 	// Global middlewares
 	r.Use(middleware.CORS())
+	defaultLang, ok := i18n.Parse(cfg.Server.DefaultLanguage)
+	if !ok {
+		defaultLang = i18n.EN
+	}
+	r.Use(middleware.Locale(defaultLang))
+
+	// Serve uploaded files when using local storage
+	if cfg.Storage.Driver == "local" {
+		r.Static(cfg.Storage.BaseURL, cfg.Storage.LocalPath)
+	}
 
 	// Health check
 	r.GET("/health", func(c *gin.Context) {
@@ -41,14 +53,69 @@ func SetupRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	productNameRepo := mysql.NewProductNameRepository(db)
 	productCategoryRepo := mysql.NewProductCategoryRepository(db)
 	sampleRepo := mysql.NewSampleRepository(db)
+	productRepo := mysql.NewProductRepository(db)
+	sampleImageRepo := mysql.NewSampleImageRepository(db)
+	productImageRepo := mysql.NewProductImageRepository(db)
+	customerRepo := mysql.NewCustomerRepository(db)
+	customerActivityRepo := mysql.NewCustomerActivityRepository(db)
+	sampleDispatchRepo := mysql.NewSampleDispatchRepository(db)
+	customerSavedItemRepo := mysql.NewCustomerSavedItemRepository(db)
+	customerSearchHistoryRepo := mysql.NewCustomerSearchHistoryRepository(db)
+	customerAccountRepo := mysql.NewCustomerAccountRepository(db)
+	portalRepo := mysql.NewPortalRepository(db)
+	orderRepo := mysql.NewOrderRepository(db)
+	stockReservationRepo := mysql.NewStockReservationRepository(db)
+	inventoryRepo := mysql.NewInventoryRepository(db)
+	priceListRepo := mysql.NewPriceListRepository(db)
+	quotationRepo := mysql.NewQuotationRepository(db)
+	exchangeRateRepo := mysql.NewExchangeRateRepository(db)
+	yarnBoxRepo := mysql.NewYarnBoxRepository(db)
+	yarnComponentRepo := mysql.NewYarnComponentRepository(db)
+	yarnAllocationRepo := mysql.NewYarnAllocationRepository(db)
+	weavingFacilityRepo := mysql.NewWeavingFacilityRepository(db)
+	weavingOrderRepo := mysql.NewWeavingOrderRepository(db)
+	weavingQualityRepo := mysql.NewWeavingQualityRepository(db)
+	weavingMachineRepo := mysql.NewWeavingMachineRepository(db)
+	subcontractRepo := mysql.NewSubcontractRepository(db)
+	dyeingRepo := mysql.NewDyeingRepository(db)
 
 	// Initialize services
 	jwtService := auth.NewJWTService(cfg.JWT.Secret, cfg.JWT.ExpiresIn)
+	customerJWTService := auth.NewCustomerJWTService(cfg.JWT.PortalSecret, cfg.JWT.PortalExpiresIn)
 	authService := services.NewAuthService(userRepo, roleRepo, permissionRepo, jwtService)
 	userService := services.NewUserService(userRepo, roleRepo)
 	permissionService := services.NewPermissionService(permissionRepo, roleRepo, userRepo)
 	categoryService := services.NewCategoryService(productCategoryRepo)
 	sampleService := services.NewSampleService(sampleRepo, productNameRepo, productCategoryRepo)
+	productService := services.NewProductService(productRepo)
+	sampleImportExportService := services.NewSampleImportExportService(sampleRepo, productNameRepo, productCategoryRepo)
+	customerService := services.NewCustomerService(customerRepo)
+	customerActivityService := services.NewCustomerActivityService(customerActivityRepo, customerRepo)
+	customerInterestService := services.NewCustomerInterestService(customerSavedItemRepo, customerSearchHistoryRepo, customerRepo, productRepo, sampleRepo)
+	customerMergeService := services.NewCustomerMergeService(customerRepo, customerActivityService)
+	customerAccountService := services.NewCustomerAccountService(customerAccountRepo, customerRepo)
+	portalService := services.NewPortalService(customerAccountRepo, portalRepo, customerJWTService, customerActivityService)
+	orderService := services.NewOrderService(orderRepo, customerRepo, productRepo, customerActivityService, blobStore, cfg.Storage.MaxUploadSize)
+	orderWorkflowService := services.NewOrderWorkflowService(orderRepo, customerRepo, permissionRepo, orderService, customerActivityService)
+	stockReservationService := services.NewStockReservationService(stockReservationRepo, orderRepo, cfg.Inventory.ReservationTTL)
+	stockReservationService.RegisterOrderEffects(orderWorkflowService)
+	inventoryService := services.NewInventoryService(inventoryRepo, productRepo)
+	yarnService := services.NewYarnService(yarnBoxRepo)
+	yarnAllocationService := services.NewYarnAllocationService(yarnComponentRepo, yarnAllocationRepo, yarnBoxRepo, orderRepo, productRepo)
+	yarnAllocationService.RegisterOrderEffects(orderWorkflowService)
+	weavingFacilityService := services.NewWeavingFacilityService(weavingFacilityRepo)
+	weavingService := services.NewWeavingService(weavingOrderRepo, weavingFacilityRepo, orderRepo, yarnBoxRepo, weavingMachineRepo)
+	weavingService.RegisterOrderEffects(orderWorkflowService)
+	weavingQualityService := services.NewWeavingQualityService(weavingQualityRepo, weavingOrderRepo)
+	weavingQualityService.RegisterWeavingGuards(weavingService)
+	dyeingService := services.NewDyeingService(dyeingRepo, weavingOrderRepo, orderRepo, productRepo, weavingService)
+	weavingCapacityService := services.NewWeavingCapacityService(weavingMachineRepo, weavingFacilityRepo, weavingOrderRepo)
+	exchangeRateService := services.NewExchangeRateService(exchangeRateRepo, orderRepo, cfg.Finance.BaseCurrency)
+	subcontractService := services.NewSubcontractService(subcontractRepo, exchangeRateService)
+	priceListService := services.NewPriceListService(priceListRepo, customerRepo, productRepo, exchangeRateService)
+	quotationService := services.NewQuotationService(quotationRepo, priceListRepo, customerRepo, productRepo, orderService, exchangeRateService)
+	sampleDispatchService := services.NewSampleDispatchService(sampleDispatchRepo, sampleRepo, customerRepo, customerActivityService)
+	imageService := services.NewImageService(sampleRepo, productRepo, sampleImageRepo, productImageRepo, blobStore, cfg.Storage.MaxUploadSize, cfg.Storage.ThumbnailWidth)
 
 	// Initialize handlers
 	authHandler := v1.NewAuthHandler(authService)
@@ -56,6 +123,31 @@ func SetupRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	permissionHandler := v1.NewPermissionHandler(permissionService)
 	categoryHandler := v1.NewCategoryHandler(categoryService)
 	sampleHandler := v1.NewSampleHandler(sampleService)
+	productHandler := v1.NewProductHandler(productService)
+	imageHandler := v1.NewImageHandler(imageService, cfg.Storage.MaxUploadSize)
+	sampleImportExportHandler := v1.NewSampleImportExportHandler(sampleImportExportService, cfg.Storage.MaxUploadSize)
+	customerHandler := v1.NewCustomerHandler(customerService, customerActivityService)
+	customerInterestHandler := v1.NewCustomerInterestHandler(customerInterestService)
+	customerMergeHandler := v1.NewCustomerMergeHandler(customerMergeService)
+	sampleDispatchHandler := v1.NewSampleDispatchHandler(sampleDispatchService)
+	customerAccountHandler := v1.NewCustomerAccountHandler(customerAccountService)
+	portalHandler := v1.NewPortalHandler(portalService)
+	orderHandler := v1.NewOrderHandler(orderService, cfg.Storage.MaxUploadSize)
+	orderWorkflowHandler := v1.NewOrderWorkflowHandler(orderWorkflowService)
+	stockReservationHandler := v1.NewStockReservationHandler(stockReservationService)
+	inventoryHandler := v1.NewInventoryHandler(inventoryService)
+	yarnHandler := v1.NewYarnHandler(yarnService)
+	yarnAllocationHandler := v1.NewYarnAllocationHandler(yarnAllocationService)
+	weavingFacilityHandler := v1.NewWeavingFacilityHandler(weavingFacilityService)
+	weavingHandler := v1.NewWeavingHandler(weavingService)
+	weavingQualityHandler := v1.NewWeavingQualityHandler(weavingQualityService)
+	weavingCapacityHandler := v1.NewWeavingCapacityHandler(weavingCapacityService)
+	dyeingHandler := v1.NewDyeingHandler(dyeingService)
+	priceListHandler := v1.NewPriceListHandler(priceListService)
+	quotationHandler := v1.NewQuotationHandler(quotationService)
+	exchangeRateHandler := v1.NewExchangeRateHandler(exchangeRateService)
+	financeHandler := v1.NewFinanceHandler(exchangeRateService)
+	subcontractHandler := v1.NewSubcontractHandler(subcontractService)
 
 	// Initialize permission middleware
 	permMiddleware := middleware.NewPermissionMiddleware(permissionRepo)
@@ -145,27 +237,23 @@ func SetupRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
 			categories := protected.Group("/categories")
 			{
 				categories.GET("", permMiddleware.RequirePermission("PRODUCT_CATEGORY", "VIEW"), categoryHandler.GetAll)
+				categories.GET("/tree", permMiddleware.RequirePermission("PRODUCT_CATEGORY", "VIEW"), categoryHandler.GetTree)
 				categories.POST("", permMiddleware.RequirePermission("PRODUCT_CATEGORY", "CREATE"), categoryHandler.Create)
 				categories.GET("/:id", permMiddleware.RequirePermission("PRODUCT_CATEGORY", "VIEW"), categoryHandler.GetByID)
 				categories.PUT("/:id", permMiddleware.RequirePermission("PRODUCT_CATEGORY", "UPDATE"), categoryHandler.Update)
+				categories.PUT("/:id/move", permMiddleware.RequirePermission("PRODUCT_CATEGORY", "UPDATE"), categoryHandler.Move)
 				categories.DELETE("/:id", permMiddleware.RequirePermission("PRODUCT_CATEGORY", "DELETE"), categoryHandler.Delete)
 			}
 
 			// Product Management Routes
 			products := protected.Group("/products")
 			{
-				products.GET("", permMiddleware.RequirePermission("PRODUCT", "VIEW"), func(c *gin.Context) {
-					// TODO: Implement product handler
-					c.JSON(200, gin.H{"message": "Product list endpoint"})
-				})
+				products.GET("", permMiddleware.RequirePermission("PRODUCT", "VIEW"), productHandler.GetAll)
 				products.POST("", permMiddleware.RequirePermission("PRODUCT", "CREATE"), func(c *gin.Context) {
 					// TODO: Implement product creation
 					c.JSON(200, gin.H{"message": "Product creation endpoint"})
 				})
-				products.GET("/:id", permMiddleware.RequirePermission("PRODUCT", "VIEW"), func(c *gin.Context) {
-					// TODO: Implement product details
-					c.JSON(200, gin.H{"message": "Product details endpoint"})
-				})
+				products.GET("/:id", permMiddleware.RequirePermission("PRODUCT", "VIEW"), productHandler.GetByID)
 				products.PUT("/:id", permMiddleware.RequirePermission("PRODUCT", "UPDATE"), func(c *gin.Context) {
 					// TODO: Implement product update
 					c.JSON(200, gin.H{"message": "Product update endpoint"})
@@ -174,6 +262,15 @@ func SetupRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
 					// TODO: Implement product deletion
 					c.JSON(200, gin.H{"message": "Product deletion endpoint"})
 				})
+
+				// Product images
+				products.GET("/:id/images", permMiddleware.RequirePermission("PRODUCT", "VIEW"), imageHandler.GetProductImages)
+				products.POST("/:id/images", permMiddleware.RequirePermission("PRODUCT", "UPDATE"), imageHandler.UploadProductImages)
+				products.PUT("/:id/images/reorder", permMiddleware.RequirePermission("PRODUCT", "UPDATE"), imageHandler.ReorderProductImages)
+				products.PUT("/:id/images/:imageId/primary", permMiddleware.RequirePermission("PRODUCT", "UPDATE"), imageHandler.SetPrimaryProductImage)
+				products.GET("/:id/yarn-components", permMiddleware.RequirePermission("PRODUCT", "VIEW"), yarnAllocationHandler.GetComponents)
+				products.PUT("/:id/yarn-components", permMiddleware.RequirePermission("PRODUCT", "UPDATE"), yarnAllocationHandler.SetComponents)
+				products.DELETE("/:id/images/:imageId", permMiddleware.RequirePermission("PRODUCT", "UPDATE"), imageHandler.DeleteProductImage)
 			}
 
 			// Sample Product Management Routes
@@ -181,15 +278,23 @@ func SetupRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
 			{
 				samples.GET("", permMiddleware.RequirePermission("SAMPLE", "VIEW"), sampleHandler.GetAll)
 				samples.POST("", permMiddleware.RequirePermission("SAMPLE", "CREATE"), sampleHandler.Create)
+				samples.GET("/search", permMiddleware.RequirePermission("SAMPLE", "VIEW"), sampleHandler.Search)
+				samples.POST("/search/reindex", permMiddleware.RequirePermission("SAMPLE", "UPDATE"), sampleHandler.RebuildSearchIndex)
+				samples.POST("/import", permMiddleware.RequirePermission("SAMPLE", "IMPORT"), sampleImportExportHandler.Import)
+				samples.GET("/export", permMiddleware.RequirePermission("SAMPLE", "EXPORT"), sampleImportExportHandler.Export)
 				samples.GET("/:id", permMiddleware.RequirePermission("SAMPLE", "VIEW"), sampleHandler.GetByID)
 				samples.PUT("/:id", permMiddleware.RequirePermission("SAMPLE", "UPDATE"), sampleHandler.Update)
 				samples.DELETE("/:id", permMiddleware.RequirePermission("SAMPLE", "DELETE"), sampleHandler.Delete)
 
+				// Sample images
+				samples.GET("/:id/images", permMiddleware.RequirePermission("SAMPLE", "VIEW"), imageHandler.GetSampleImages)
+				samples.POST("/:id/images", permMiddleware.RequirePermission("SAMPLE", "UPDATE"), imageHandler.UploadSampleImages)
+				samples.PUT("/:id/images/reorder", permMiddleware.RequirePermission("SAMPLE", "UPDATE"), imageHandler.ReorderSampleImages)
+				samples.PUT("/:id/images/:imageId/primary", permMiddleware.RequirePermission("SAMPLE", "UPDATE"), imageHandler.SetPrimarySampleImage)
+				samples.DELETE("/:id/images/:imageId", permMiddleware.RequirePermission("SAMPLE", "UPDATE"), imageHandler.DeleteSampleImage)
+
 				// Additional sample operations
-				samples.POST("/:id/dispatch", permMiddleware.RequirePermission("SAMPLE", "DISPATCH"), func(c *gin.Context) {
-					// TODO: Implement sample dispatch
-					c.JSON(200, gin.H{"message": "Sample dispatch endpoint"})
-				})
+				samples.POST("/:id/dispatch", permMiddleware.RequirePermission("SAMPLE", "DISPATCH"), sampleDispatchHandler.Dispatch)
 				samples.GET("/:id/tracking", permMiddleware.RequirePermission("SAMPLE", "TRACK"), func(c *gin.Context) {
 					// TODO: Implement sample tracking
 					c.JSON(200, gin.H{"message": "Sample tracking endpoint"})
@@ -199,67 +304,109 @@ func SetupRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
 			// Customer Management Routes
 			customers := protected.Group("/customers")
 			{
-				customers.GET("", permMiddleware.RequirePermission("CUSTOMER", "VIEW"), func(c *gin.Context) {
-					// TODO: Implement customer handler
-					c.JSON(200, gin.H{"message": "Customer list endpoint"})
-				})
-				customers.POST("", permMiddleware.RequirePermission("CUSTOMER", "CREATE"), func(c *gin.Context) {
-					// TODO: Implement customer creation
-					c.JSON(200, gin.H{"message": "Customer creation endpoint"})
-				})
-				customers.GET("/:id", permMiddleware.RequirePermission("CUSTOMER", "VIEW"), func(c *gin.Context) {
-					// TODO: Implement customer details
-					c.JSON(200, gin.H{"message": "Customer details endpoint"})
-				})
-				customers.PUT("/:id", permMiddleware.RequirePermission("CUSTOMER", "UPDATE"), func(c *gin.Context) {
-					// TODO: Implement customer update
-					c.JSON(200, gin.H{"message": "Customer update endpoint"})
-				})
-				customers.DELETE("/:id", permMiddleware.RequirePermission("CUSTOMER", "DELETE"), func(c *gin.Context) {
-					// TODO: Implement customer deletion
-					c.JSON(200, gin.H{"message": "Customer deletion endpoint"})
-				})
-				customers.GET("/:id/activity", permMiddleware.RequirePermission("CUSTOMER", "VIEW_ACTIVITY"), func(c *gin.Context) {
-					// TODO: Implement customer activity logs
-					c.JSON(200, gin.H{"message": "Customer activity endpoint"})
-				})
+				customers.GET("", permMiddleware.RequirePermission("CUSTOMER", "VIEW"), customerHandler.GetAll)
+				customers.POST("", permMiddleware.RequirePermission("CUSTOMER", "CREATE"), customerHandler.Create)
+				customers.GET("/:id", permMiddleware.RequirePermission("CUSTOMER", "VIEW"), customerHandler.GetByID)
+				customers.PUT("/:id", permMiddleware.RequirePermission("CUSTOMER", "UPDATE"), customerHandler.Update)
+				customers.DELETE("/:id", permMiddleware.RequirePermission("CUSTOMER", "DELETE"), customerHandler.Delete)
+				customers.GET("/:id/activity", permMiddleware.RequirePermission("CUSTOMER", "VIEW_ACTIVITY"), customerHandler.Activity)
+
+				// Saved items and search history
+				customers.GET("/:id/saved-items", permMiddleware.RequirePermission("CUSTOMER", "VIEW"), customerInterestHandler.GetSavedItems)
+				customers.POST("/:id/saved-items", permMiddleware.RequirePermission("CUSTOMER", "UPDATE"), customerInterestHandler.SaveItem)
+				customers.PUT("/:id/saved-items/:itemId", permMiddleware.RequirePermission("CUSTOMER", "UPDATE"), customerInterestHandler.UpdateSavedItem)
+				customers.DELETE("/:id/saved-items/:itemId", permMiddleware.RequirePermission("CUSTOMER", "UPDATE"), customerInterestHandler.RemoveSavedItem)
+				customers.GET("/:id/search-history", permMiddleware.RequirePermission("CUSTOMER", "VIEW_ACTIVITY"), customerInterestHandler.GetSearchHistory)
+				customers.POST("/:id/search-history", permMiddleware.RequirePermission("CUSTOMER", "UPDATE"), customerInterestHandler.RecordSearch)
+				customers.GET("/reports/interests", permMiddleware.RequirePermission("REPORT", "VIEW"), customerInterestHandler.InterestReport)
+
+				// Deduplication
+				customers.GET("/duplicates", permMiddleware.RequirePermission("CUSTOMER", "VIEW"), customerMergeHandler.FindDuplicates)
+				customers.POST("/:id/merge", permMiddleware.RequirePermission("CUSTOMER", "MERGE"), customerMergeHandler.Merge)
+
+				// Customer portal accounts
+				customers.GET("/:id/portal-accounts", permMiddleware.RequirePermission("CUSTOMER", "VIEW"), customerAccountHandler.GetAll)
+				customers.POST("/:id/portal-accounts", permMiddleware.RequirePermission("CUSTOMER", "UPDATE"), customerAccountHandler.Create)
+				customers.PUT("/:id/portal-accounts/:accountId", permMiddleware.RequirePermission("CUSTOMER", "UPDATE"), customerAccountHandler.Update)
+				customers.DELETE("/:id/portal-accounts/:accountId", permMiddleware.RequirePermission("CUSTOMER", "UPDATE"), customerAccountHandler.Delete)
 			}
 
 			// Order Management Routes
 			orders := protected.Group("/orders")
 			{
-				orders.GET("", permMiddleware.RequirePermission("ORDER", "VIEW"), func(c *gin.Context) {
-					// TODO: Implement order handler
-					c.JSON(200, gin.H{"message": "Order list endpoint"})
-				})
-				orders.POST("", permMiddleware.RequirePermission("ORDER", "CREATE"), func(c *gin.Context) {
-					// TODO: Implement order creation
-					c.JSON(200, gin.H{"message": "Order creation endpoint"})
-				})
-				orders.GET("/:id", permMiddleware.RequirePermission("ORDER", "VIEW"), func(c *gin.Context) {
-					// TODO: Implement order details
-					c.JSON(200, gin.H{"message": "Order details endpoint"})
-				})
-				orders.PUT("/:id", permMiddleware.RequirePermission("ORDER", "UPDATE"), func(c *gin.Context) {
-					// TODO: Implement order update
-					c.JSON(200, gin.H{"message": "Order update endpoint"})
-				})
-				orders.DELETE("/:id", permMiddleware.RequirePermission("ORDER", "DELETE"), func(c *gin.Context) {
-					// TODO: Implement order deletion
-					c.JSON(200, gin.H{"message": "Order deletion endpoint"})
-				})
-				orders.POST("/:id/approve", permMiddleware.RequirePermission("ORDER", "APPROVE"), func(c *gin.Context) {
-					// TODO: Implement order approval
-					c.JSON(200, gin.H{"message": "Order approval endpoint"})
-				})
-				orders.POST("/:id/cancel", permMiddleware.RequirePermission("ORDER", "CANCEL"), func(c *gin.Context) {
-					// TODO: Implement order cancellation
-					c.JSON(200, gin.H{"message": "Order cancellation endpoint"})
-				})
-				orders.POST("/:id/ship", permMiddleware.RequirePermission("ORDER", "SHIP"), func(c *gin.Context) {
-					// TODO: Implement order shipping
-					c.JSON(200, gin.H{"message": "Order shipping endpoint"})
-				})
+				orders.GET("", permMiddleware.RequirePermission("ORDER", "VIEW"), orderHandler.GetAll)
+				orders.POST("", permMiddleware.RequirePermission("ORDER", "CREATE"), orderHandler.Create)
+				orders.GET("/statuses", permMiddleware.RequirePermission("ORDER", "VIEW"), orderHandler.Statuses)
+				orders.GET("/:id", permMiddleware.RequirePermission("ORDER", "VIEW"), orderHandler.GetByID)
+				orders.PUT("/:id", permMiddleware.RequirePermission("ORDER", "UPDATE"), orderHandler.Update)
+				orders.DELETE("/:id", permMiddleware.RequirePermission("ORDER", "DELETE"), orderHandler.Delete)
+				orders.POST("/:id/attachments", permMiddleware.RequirePermission("ORDER", "UPDATE"), orderHandler.UploadAttachments)
+				orders.DELETE("/:id/attachments/:name", permMiddleware.RequirePermission("ORDER", "UPDATE"), orderHandler.DeleteAttachment)
+				orders.POST("/:id/approve", permMiddleware.RequirePermission("ORDER", "APPROVE"), orderWorkflowHandler.Approve)
+				orders.POST("/:id/cancel", permMiddleware.RequirePermission("ORDER", "CANCEL"), orderWorkflowHandler.Cancel)
+				orders.POST("/:id/ship", permMiddleware.RequirePermission("ORDER", "SHIP"), orderWorkflowHandler.Ship)
+				// Every other action checks its own permission in the order state machine
+				orders.GET("/:id/transitions", permMiddleware.RequirePermission("ORDER", "VIEW"), orderWorkflowHandler.Transitions)
+				orders.POST("/:id/transitions/:action", permMiddleware.RequirePermission("ORDER", "VIEW"), orderWorkflowHandler.Apply)
+				orders.GET("/:id/reservations", permMiddleware.RequirePermission("ORDER", "VIEW"), stockReservationHandler.GetAll)
+				orders.POST("/:id/reservations", permMiddleware.RequirePermission("ORDER", "UPDATE"), stockReservationHandler.Reserve)
+				orders.DELETE("/:id/reservations", permMiddleware.RequirePermission("ORDER", "UPDATE"), stockReservationHandler.ReleaseAll)
+				orders.DELETE("/:id/reservations/:reservationId", permMiddleware.RequirePermission("ORDER", "UPDATE"), stockReservationHandler.Release)
+				orders.GET("/:id/yarn", permMiddleware.RequireAnyPermission(
+					middleware.PermissionCheck{Module: "ORDER", Action: "VIEW"},
+					middleware.PermissionCheck{Module: "WAREHOUSE", Action: "VIEW"},
+				), yarnAllocationHandler.GetOrderYarn)
+				orders.POST("/:id/yarn-allocations", permMiddleware.RequirePermission("WAREHOUSE", "UPDATE"), yarnAllocationHandler.Allocate)
+				orders.DELETE("/:id/yarn-allocations", permMiddleware.RequirePermission("WAREHOUSE", "UPDATE"), yarnAllocationHandler.ReleaseAll)
+				orders.DELETE("/:id/yarn-allocations/:allocationId", permMiddleware.RequirePermission("WAREHOUSE", "UPDATE"), yarnAllocationHandler.Release)
+				orders.POST("/:id/yarn-allocations/:allocationId/issue", permMiddleware.RequirePermission("WAREHOUSE", "UPDATE"), yarnAllocationHandler.Issue)
+				orders.GET("/:id/weaving", permMiddleware.RequireAnyPermission(
+					middleware.PermissionCheck{Module: "ORDER", Action: "VIEW"},
+					middleware.PermissionCheck{Module: "PRODUCTION", Action: "VIEW"},
+				), weavingHandler.GetOrderWeaving)
+			}
+
+			// Quotation Routes
+			quotations := protected.Group("/quotations")
+			{
+				quotations.GET("", permMiddleware.RequirePermission("ORDER", "VIEW"), quotationHandler.GetAll)
+				quotations.POST("", permMiddleware.RequirePermission("ORDER", "QUOTE"), quotationHandler.Create)
+				quotations.GET("/:id", permMiddleware.RequirePermission("ORDER", "VIEW"), quotationHandler.GetByID)
+				quotations.GET("/:id/print", permMiddleware.RequirePermission("ORDER", "VIEW"), quotationHandler.Print)
+				quotations.PUT("/:id", permMiddleware.RequirePermission("ORDER", "QUOTE"), quotationHandler.Update)
+				quotations.DELETE("/:id", permMiddleware.RequirePermission("ORDER", "QUOTE"), quotationHandler.Delete)
+				quotations.POST("/:id/send", permMiddleware.RequirePermission("ORDER", "QUOTE"), quotationHandler.Send)
+				quotations.POST("/:id/reject", permMiddleware.RequirePermission("ORDER", "QUOTE"), quotationHandler.Reject)
+				// Accepting creates an order
+				quotations.POST("/:id/accept", permMiddleware.RequirePermission("ORDER", "CREATE"), quotationHandler.Accept)
+			}
+
+			// Price List Routes
+			priceLists := protected.Group("/price-lists")
+			{
+				priceLists.GET("", permMiddleware.RequirePermission("PRODUCT", "VIEW"), priceListHandler.GetAll)
+				priceLists.POST("", permMiddleware.RequirePermission("PRODUCT", "PRICING"), priceListHandler.Create)
+				priceLists.GET("/resolve", permMiddleware.RequireAnyPermission(
+					middleware.PermissionCheck{Module: "PRODUCT", Action: "VIEW"},
+					middleware.PermissionCheck{Module: "ORDER", Action: "QUOTE"},
+				), priceListHandler.Resolve)
+				priceLists.GET("/:id", permMiddleware.RequirePermission("PRODUCT", "VIEW"), priceListHandler.GetByID)
+				priceLists.PUT("/:id", permMiddleware.RequirePermission("PRODUCT", "PRICING"), priceListHandler.Update)
+				priceLists.DELETE("/:id", permMiddleware.RequirePermission("PRODUCT", "PRICING"), priceListHandler.Delete)
+			}
+
+			// Exchange Rate Routes
+			exchangeRates := protected.Group("/exchange-rates")
+			{
+				exchangeRates.GET("", permMiddleware.RequirePermission("FINANCE", "VIEW"), exchangeRateHandler.GetAll)
+				exchangeRates.POST("", permMiddleware.RequirePermission("FINANCE", "RATES"), exchangeRateHandler.Create)
+				exchangeRates.GET("/convert", permMiddleware.RequireAnyPermission(
+					middleware.PermissionCheck{Module: "FINANCE", Action: "VIEW"},
+					middleware.PermissionCheck{Module: "ORDER", Action: "VIEW"},
+				), exchangeRateHandler.Convert)
+				exchangeRates.GET("/:id", permMiddleware.RequirePermission("FINANCE", "VIEW"), exchangeRateHandler.GetByID)
+				exchangeRates.PUT("/:id", permMiddleware.RequirePermission("FINANCE", "RATES"), exchangeRateHandler.Update)
+				exchangeRates.DELETE("/:id", permMiddleware.RequirePermission("FINANCE", "RATES"), exchangeRateHandler.Delete)
 			}
 
 			// Warehouse Management Routes
@@ -269,10 +416,139 @@ func SetupRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
 					// TODO: Implement warehouse handler
 					c.JSON(200, gin.H{"message": "Warehouse data endpoint"})
 				})
+				warehouse.GET("/atp", permMiddleware.RequireAnyPermission(
+					middleware.PermissionCheck{Module: "WAREHOUSE", Action: "VIEW"},
+					middleware.PermissionCheck{Module: "ORDER", Action: "VIEW"},
+				), inventoryHandler.AvailableToPromise)
 				warehouse.POST("/transfer", permMiddleware.RequirePermission("WAREHOUSE", "TRANSFER"), func(c *gin.Context) {
 					// TODO: Implement inventory transfer
 					c.JSON(200, gin.H{"message": "Inventory transfer endpoint"})
 				})
+
+				// Yarn inventory
+				warehouse.GET("/yarn-stock", permMiddleware.RequirePermission("WAREHOUSE", "VIEW"), yarnHandler.GetStock)
+				warehouse.GET("/yarn-transactions", permMiddleware.RequirePermission("WAREHOUSE", "VIEW"), yarnHandler.GetTransactions)
+				warehouse.GET("/yarn-shortage", permMiddleware.RequirePermission("WAREHOUSE", "VIEW"), yarnAllocationHandler.Shortage)
+				yarnBoxes := warehouse.Group("/yarn-boxes")
+				{
+					yarnBoxes.GET("", permMiddleware.RequirePermission("WAREHOUSE", "VIEW"), yarnHandler.GetAll)
+					yarnBoxes.POST("", permMiddleware.RequirePermission("WAREHOUSE", "CREATE"), yarnHandler.Create)
+					yarnBoxes.GET("/:id", permMiddleware.RequirePermission("WAREHOUSE", "VIEW"), yarnHandler.GetByID)
+					yarnBoxes.PUT("/:id", permMiddleware.RequirePermission("WAREHOUSE", "UPDATE"), yarnHandler.Update)
+					yarnBoxes.DELETE("/:id", permMiddleware.RequirePermission("WAREHOUSE", "DELETE"), yarnHandler.Delete)
+					yarnBoxes.PUT("/:id/status", permMiddleware.RequirePermission("WAREHOUSE", "UPDATE"), yarnHandler.ChangeStatus)
+					yarnBoxes.GET("/:id/transactions", permMiddleware.RequirePermission("WAREHOUSE", "VIEW"), yarnHandler.GetBoxTransactions)
+					yarnBoxes.POST("/:id/receive", permMiddleware.RequirePermission("WAREHOUSE", "CREATE"), yarnHandler.Receive)
+					yarnBoxes.POST("/:id/issue", permMiddleware.RequirePermission("WAREHOUSE", "UPDATE"), yarnHandler.Issue)
+				}
+			}
+
+			// Weaving Production Routes
+			weaving := protected.Group("/weaving")
+			{
+				facilities := weaving.Group("/facilities")
+				{
+					facilities.GET("", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingFacilityHandler.GetAll)
+					facilities.POST("", permMiddleware.RequirePermission("PRODUCTION", "CREATE"), weavingFacilityHandler.Create)
+					facilities.GET("/:id", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingFacilityHandler.GetByID)
+					facilities.PUT("/:id", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), weavingFacilityHandler.Update)
+					facilities.DELETE("/:id", permMiddleware.RequirePermission("PRODUCTION", "DELETE"), weavingFacilityHandler.Delete)
+					facilities.GET("/:id/shifts", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingFacilityHandler.GetShifts)
+					facilities.POST("/:id/shifts", permMiddleware.RequirePermission("PRODUCTION", "CREATE"), weavingFacilityHandler.CreateShift)
+					facilities.GET("/:id/rosters", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingCapacityHandler.GetRosters)
+					facilities.POST("/:id/rosters", permMiddleware.RequirePermission("PRODUCTION", "CREATE"), weavingCapacityHandler.SaveRosters)
+					facilities.GET("/:id/capacity-plan", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingCapacityHandler.PlanCapacity)
+				}
+				weaving.PUT("/shifts/:id", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), weavingFacilityHandler.UpdateShift)
+				weaving.DELETE("/shifts/:id", permMiddleware.RequirePermission("PRODUCTION", "DELETE"), weavingFacilityHandler.DeleteShift)
+				weaving.DELETE("/rosters/:id", permMiddleware.RequirePermission("PRODUCTION", "DELETE"), weavingCapacityHandler.DeleteRoster)
+
+				machines := weaving.Group("/machines")
+				{
+					machines.GET("", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingCapacityHandler.GetMachines)
+					machines.POST("", permMiddleware.RequirePermission("PRODUCTION", "CREATE"), weavingCapacityHandler.CreateMachine)
+					machines.GET("/:id", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingCapacityHandler.GetMachineByID)
+					machines.PUT("/:id", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), weavingCapacityHandler.UpdateMachine)
+					machines.DELETE("/:id", permMiddleware.RequirePermission("PRODUCTION", "DELETE"), weavingCapacityHandler.DeleteMachine)
+				}
+
+				staff := weaving.Group("/staff")
+				{
+					staff.GET("", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingFacilityHandler.GetStaff)
+					staff.POST("", permMiddleware.RequirePermission("PRODUCTION", "CREATE"), weavingFacilityHandler.CreateStaff)
+					staff.GET("/:id", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingFacilityHandler.GetStaffByID)
+					staff.PUT("/:id", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), weavingFacilityHandler.UpdateStaff)
+					staff.DELETE("/:id", permMiddleware.RequirePermission("PRODUCTION", "DELETE"), weavingFacilityHandler.DeleteStaff)
+				}
+
+				weavingOrders := weaving.Group("/orders")
+				{
+					weavingOrders.GET("", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingHandler.GetAll)
+					weavingOrders.POST("", permMiddleware.RequirePermission("PRODUCTION", "CREATE"), weavingHandler.Create)
+					weavingOrders.GET("/:id", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingHandler.GetByID)
+					weavingOrders.PUT("/:id", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), weavingHandler.Update)
+					weavingOrders.DELETE("/:id", permMiddleware.RequirePermission("PRODUCTION", "DELETE"), weavingHandler.Delete)
+					weavingOrders.PUT("/:id/status", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), weavingHandler.ChangeStatus)
+					weavingOrders.GET("/:id/operations", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingHandler.GetOrderOperations)
+					weavingOrders.POST("/:id/operations", permMiddleware.RequirePermission("PRODUCTION", "CREATE"), weavingHandler.LogOperation)
+				}
+
+				operations := weaving.Group("/operations")
+				{
+					operations.GET("", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingHandler.GetOperations)
+					operations.PUT("/:id", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), weavingHandler.UpdateOperation)
+					operations.DELETE("/:id", permMiddleware.RequirePermission("PRODUCTION", "DELETE"), weavingHandler.DeleteOperation)
+					operations.POST("/:id/inspections", permMiddleware.RequirePermission("PRODUCTION", "CREATE"), weavingQualityHandler.Inspect)
+				}
+
+				defectTypes := weaving.Group("/defect-types")
+				{
+					defectTypes.GET("", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingQualityHandler.GetDefectTypes)
+					defectTypes.POST("", permMiddleware.RequirePermission("PRODUCTION", "CREATE"), weavingQualityHandler.CreateDefectType)
+					defectTypes.GET("/:id", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingQualityHandler.GetDefectTypeByID)
+					defectTypes.PUT("/:id", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), weavingQualityHandler.UpdateDefectType)
+					defectTypes.DELETE("/:id", permMiddleware.RequirePermission("PRODUCTION", "DELETE"), weavingQualityHandler.DeleteDefectType)
+				}
+
+				inspections := weaving.Group("/inspections")
+				{
+					inspections.GET("", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingQualityHandler.GetInspections)
+					inspections.GET("/:id", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), weavingQualityHandler.GetInspectionByID)
+					inspections.PUT("/:id/corrective-action", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), weavingQualityHandler.RecordCorrectiveAction)
+					inspections.DELETE("/:id", permMiddleware.RequirePermission("PRODUCTION", "DELETE"), weavingQualityHandler.DeleteInspection)
+				}
+			}
+
+			// Dyeing Routes
+			dyeing := protected.Group("/dyeing")
+			{
+				dyeHouses := dyeing.Group("/subcontractors")
+				{
+					dyeHouses.GET("", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), dyeingHandler.GetSubcontractors)
+					dyeHouses.POST("", permMiddleware.RequirePermission("PRODUCTION", "CREATE"), dyeingHandler.CreateSubcontractor)
+					dyeHouses.GET("/:id", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), dyeingHandler.GetSubcontractorByID)
+					dyeHouses.PUT("/:id", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), dyeingHandler.UpdateSubcontractor)
+					dyeHouses.DELETE("/:id", permMiddleware.RequirePermission("PRODUCTION", "DELETE"), dyeingHandler.DeleteSubcontractor)
+				}
+
+				dyeingJobs := dyeing.Group("/jobs")
+				{
+					dyeingJobs.GET("", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), dyeingHandler.GetJobs)
+					dyeingJobs.POST("", permMiddleware.RequirePermission("PRODUCTION", "CREATE"), dyeingHandler.CreateJob)
+					dyeingJobs.GET("/:id", permMiddleware.RequirePermission("PRODUCTION", "VIEW"), dyeingHandler.GetJobByID)
+					dyeingJobs.PUT("/:id", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), dyeingHandler.UpdateJob)
+					dyeingJobs.DELETE("/:id", permMiddleware.RequirePermission("PRODUCTION", "DELETE"), dyeingHandler.DeleteJob)
+					dyeingJobs.PUT("/:id/status", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), dyeingHandler.ChangeJobStatus)
+					dyeingJobs.POST("/:id/lots", permMiddleware.RequirePermission("PRODUCTION", "CREATE"), dyeingHandler.AddLot)
+				}
+
+				dyeingLots := dyeing.Group("/lots")
+				{
+					dyeingLots.PUT("/:id/results", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), dyeingHandler.RecordLotResults)
+					dyeingLots.POST("/:id/accept", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), dyeingHandler.AcceptLot)
+					dyeingLots.POST("/:id/reject", permMiddleware.RequirePermission("PRODUCTION", "UPDATE"), dyeingHandler.RejectLot)
+					dyeingLots.DELETE("/:id", permMiddleware.RequirePermission("PRODUCTION", "DELETE"), dyeingHandler.DeleteLot)
+				}
 			}
 
 			// Financial Management Routes
@@ -286,6 +562,20 @@ func SetupRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
 					// TODO: Implement financial approval
 					c.JSON(200, gin.H{"message": "Financial approval endpoint"})
 				})
+				finance.GET("/sales-summary", permMiddleware.RequireAnyPermission(
+					middleware.PermissionCheck{Module: "FINANCE", Action: "VIEW"},
+					middleware.PermissionCheck{Module: "REPORT", Action: "VIEW"},
+				), financeHandler.SalesSummary)
+
+				// Outside weaving mills and dye houses: statements, invoices, payments and aging
+				subcontractors := finance.Group("/subcontractors")
+				{
+					subcontractors.GET("/statements", permMiddleware.RequirePermission("FINANCE", "VIEW"), subcontractHandler.GetStatements)
+					subcontractors.GET("/aging", permMiddleware.RequirePermission("FINANCE", "VIEW"), subcontractHandler.GetAging)
+					subcontractors.PUT("/:type/:id/invoice", permMiddleware.RequirePermission("FINANCE", "UPDATE"), subcontractHandler.SaveInvoice)
+					subcontractors.GET("/:type/:id/payments", permMiddleware.RequirePermission("FINANCE", "VIEW"), subcontractHandler.GetPayments)
+					subcontractors.POST("/:type/:id/payments", permMiddleware.RequirePermission("FINANCE", "CREATE"), subcontractHandler.RecordPayment)
+				}
 			}
 
 			// Reporting Routes
@@ -329,5 +619,25 @@ func SetupRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
 		}
 	}
 
+	// Customer portal routes, authenticated with portal tokens only
+	portal := r.Group("/portal/v1")
+	{
+		portal.POST("/auth/login", portalHandler.Login)
+
+		account := portal.Group("")
+		account.Use(middleware.PortalAuth(customerJWTService, customerAccountRepo))
+		{
+			account.GET("/me", portalHandler.Me)
+			account.GET("/orders", portalHandler.GetOrders)
+			account.GET("/orders/:id", portalHandler.GetOrder)
+			account.GET("/shipments", portalHandler.GetShipments)
+			account.GET("/sample-dispatches", portalHandler.GetSampleDispatches)
+			account.GET("/lap-dips", portalHandler.GetLapDips)
+			account.GET("/lap-dips/:id", portalHandler.GetLapDip)
+			account.POST("/lap-dips/:id/approve", portalHandler.ApproveLapDip)
+			account.POST("/lap-dips/:id/reject", portalHandler.RejectLapDip)
+		}
+	}
+
 	return r
 }
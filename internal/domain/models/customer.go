@@ -0,0 +1,72 @@
+// File: internal/domain/models/customer.go
+// Tạo tại: internal/domain/models/customer.go
+// Mục đích: Model khách hàng (bảng customers) cùng các bản ghi liên kết: gửi mẫu và lap dip
+
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Customer account statuses
+const (
+	CustomerStatusActive   = "active"
+	CustomerStatusInactive = "inactive"
+	CustomerStatusBlocked  = "blocked"
+)
+
+type Customer struct {
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	CustomerCode  string         `gorm:"size:100;uniqueIndex" json:"customer_code"`
+	Name          string         `gorm:"size:255;not null" json:"name"`
+	Email         string         `gorm:"size:255" json:"email"`
+	Phone         string         `gorm:"size:50" json:"phone"`
+	Address       string         `gorm:"type:text" json:"address"`
+	CompanyName   string         `gorm:"size:255" json:"company_name"`
+	TaxID         string         `gorm:"size:100" json:"tax_id"`
+	AccountStatus string         `gorm:"size:50;default:active" json:"account_status"`
+	PriceTier     string         `gorm:"size:50" json:"price_tier"`          // Picks the tier price lists; empty uses the general ones
+	Currency      string         `gorm:"size:3;default:VND" json:"currency"` // Default for new orders and quotations
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// SampleDispatch is a sample sent to a customer
+type SampleDispatch struct {
+	ID               uint          `gorm:"primaryKey" json:"id"`
+	SampleProductID  uint          `json:"sample_product_id"`
+	CustomerID       uint          `json:"customer_id"`
+	DispatchDate     time.Time     `json:"dispatch_date"`
+	DispatchQuantity float64       `json:"dispatch_quantity"`
+	DispatchWeight   float64       `json:"dispatch_weight"`
+	DispatchColor    string        `gorm:"size:255" json:"dispatch_color"`
+	LotNumber        string        `gorm:"size:100" json:"lot_number"`
+	TrackingNumber   string        `gorm:"size:100" json:"tracking_number"`
+	DispatchNotes    string        `gorm:"type:text" json:"dispatch_notes"`
+	SampleProduct    SampleProduct `gorm:"foreignKey:SampleProductID" json:"sample_product,omitempty"`
+}
+
+// LapDipTest is a color matching test run by a dyeing subcontractor for a customer
+type LapDipTest struct {
+	ID                    uint       `gorm:"primaryKey" json:"id"`
+	LDCode                string     `gorm:"column:ld_code;size:100;uniqueIndex" json:"ld_code"`
+	CustomerID            uint       `json:"customer_id"`
+	ColorName             string     `gorm:"size:255" json:"color_name"`
+	ColorCode             string     `gorm:"size:100" json:"color_code"`
+	GreigeFabricID        uint       `json:"greige_fabric_id"`
+	DyeingSubcontractorID uint       `json:"dyeing_subcontractor_id"`
+	TestStartDate         time.Time  `json:"test_start_date"`
+	TestEndDate           *time.Time `json:"test_end_date"`
+	LDResults             string     `gorm:"column:ld_results;type:json" json:"ld_results"` // Lap dip codes returned by the dyehouse
+	SelectedLDCode        string     `gorm:"column:selected_ld_code;size:100" json:"selected_ld_code"`
+	Status                string     `gorm:"size:50;default:testing" json:"status"`
+	InitialSampleImage    string     `gorm:"type:text" json:"initial_sample_image"`
+	ResultImage           string     `gorm:"type:text" json:"result_image"`
+	CustomerApprovalImage string     `gorm:"type:text" json:"customer_approval_image"`
+	Notes                 string     `gorm:"type:text" json:"notes"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,26 @@
+// File: internal/domain/models/customer_account.go
+// Tạo tại: internal/domain/models/customer_account.go
+// Mục đích: Model tài khoản đăng nhập cổng portal của khách hàng (bảng customer_accounts)
+
+package models
+
+import "time"
+
+const (
+	CustomerAccountActive   = "active"
+	CustomerAccountDisabled = "disabled"
+)
+
+// CustomerAccount is a buyer login for the customer portal, tied to one customer
+type CustomerAccount struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	CustomerID   uint       `json:"customer_id"`
+	Email        string     `gorm:"size:255;uniqueIndex" json:"email"`
+	PasswordHash string     `gorm:"size:255" json:"-"`
+	FullName     string     `gorm:"size:255" json:"full_name"`
+	Status       string     `gorm:"size:50;default:active" json:"status"`
+	LastLogin    *time.Time `json:"last_login"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	Customer     Customer   `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+}
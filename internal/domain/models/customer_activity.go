@@ -0,0 +1,40 @@
+// File: internal/domain/models/customer_activity.go
+// Tạo tại: internal/domain/models/customer_activity.go
+// Mục đích: Model nhật ký hoạt động khách hàng (bảng customer_activity_log)
+
+package models
+
+import "time"
+
+// Customer activity types written to customer_activity_log
+const (
+	CustomerActivitySampleReceived = "SAMPLE_RECEIVED"
+	CustomerActivityLapDipApproved = "LAP_DIP_APPROVED"
+	CustomerActivityOrderPlaced    = "ORDER_PLACED"
+	CustomerActivityOrderCancelled = "ORDER_CANCELLED"
+	CustomerActivityMerged         = "CUSTOMER_MERGED"
+)
+
+// CustomerActivityTypes lists the activity types in timeline order of the business flow
+var CustomerActivityTypes = []string{
+	CustomerActivitySampleReceived,
+	CustomerActivityLapDipApproved,
+	CustomerActivityOrderPlaced,
+	CustomerActivityOrderCancelled,
+	CustomerActivityMerged,
+}
+
+type CustomerActivity struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	CustomerID      uint      `json:"customer_id"`
+	ActivityType    string    `gorm:"size:100;not null" json:"activity_type"`
+	ActivityDetails string    `gorm:"type:text" json:"activity_details"` // JSON object describing the event
+	ActivityDate    time.Time `json:"activity_date"`
+	IPAddress       string    `gorm:"size:50" json:"ip_address"`
+	DeviceInfo      string    `gorm:"size:255" json:"device_info"`
+}
+
+// TableName maps CustomerActivity to the singular table created by migration 000004
+func (CustomerActivity) TableName() string {
+	return "customer_activity_log"
+}
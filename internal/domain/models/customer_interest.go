@@ -0,0 +1,34 @@
+// File: internal/domain/models/customer_interest.go
+// Tạo tại: internal/domain/models/customer_interest.go
+// Mục đích: Model danh sách sản phẩm/mẫu khách hàng lưu lại và lịch sử tìm kiếm của khách hàng
+
+package models
+
+import "time"
+
+// CustomerSavedItem is a product or a sample on a customer's wishlist; exactly one of the IDs is set
+type CustomerSavedItem struct {
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	CustomerID      uint           `json:"customer_id"`
+	ProductID       *uint          `json:"product_id"`
+	SampleProductID *uint          `json:"sample_product_id"`
+	SavedAt         time.Time      `json:"saved_at"`
+	Notes           string         `gorm:"type:text" json:"notes"`
+	Product         *Product       `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	SampleProduct   *SampleProduct `gorm:"foreignKey:SampleProductID" json:"sample_product,omitempty"`
+}
+
+type CustomerSearchHistory struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	CustomerID      uint      `json:"customer_id"`
+	SearchQuery     string    `gorm:"type:text;not null" json:"search_query"`
+	NormalizedQuery string    `gorm:"size:255" json:"normalized_query"` // textsearch.Normalize of the query, used to group equal searches
+	SearchDate      time.Time `json:"search_date"`
+	ResultCount     *int      `json:"result_count"`
+	SearchResults   *string   `gorm:"type:json" json:"search_results"`
+}
+
+// TableName maps CustomerSearchHistory to the singular table created by migration 000004
+func (CustomerSearchHistory) TableName() string {
+	return "customer_search_history"
+}
@@ -0,0 +1,20 @@
+// File: internal/domain/models/customer_merge.go
+// Tạo tại: internal/domain/models/customer_merge.go
+// Mục đích: Model lưu vết gộp khách hàng trùng lặp (bảng customer_merges)
+
+package models
+
+import "time"
+
+// CustomerMerge records a duplicate customer merged into a survivor
+type CustomerMerge struct {
+	ID                 uint      `gorm:"primaryKey" json:"id"`
+	SurvivorID         uint      `json:"survivor_id"`
+	MergedCustomerID   uint      `json:"merged_customer_id"`
+	MergedCustomerCode string    `gorm:"size:100" json:"merged_customer_code"`
+	MergedSnapshot     string    `gorm:"type:json" json:"merged_snapshot"` // Duplicate customer row before the merge
+	MovedRecords       string    `gorm:"type:json" json:"moved_records"`   // Rows re-pointed per table
+	Reason             string    `gorm:"type:text" json:"reason"`
+	MergedBy           *uint     `json:"merged_by"`
+	MergedAt           time.Time `json:"merged_at"`
+}
@@ -0,0 +1,108 @@
+// File: internal/domain/models/dyeing.go
+// Tạo tại: internal/domain/models/dyeing.go
+// Mục đích: Model nhà nhuộm, lệnh nhuộm và lô nhuộm trả về (dyeing_subcontractors, dyeing_jobs, dyeing_lots) theo
+// migration 000007, 000027, 000029 và 000035
+
+package models
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+	"gorm.io/gorm"
+)
+
+// Dyeing job statuses. A job is partially returned from its first lot and returned once closed.
+const (
+	DyeingJobSent              = "sent"
+	DyeingJobPartiallyReturned = "partially_returned"
+	DyeingJobReturned          = "returned"
+	DyeingJobCancelled         = "cancelled"
+)
+
+// Dyeing lot statuses. A returned lot waits for its shrinkage and color fastness results, then is
+// accepted into the warehouse or rejected; rejected lots are not counted as returned.
+const (
+	DyeingLotPending  = "pending"
+	DyeingLotAccepted = "accepted"
+	DyeingLotRejected = "rejected"
+)
+
+// LotOriginDyeing is the origin of warehouse lots made from accepted dyeing lots
+const LotOriginDyeing = "Dyeing"
+
+// DyeingSubcontractor is an outside dye house. Its old job columns are left in the table for history;
+// dyeing jobs carry them now.
+type DyeingSubcontractor struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Name        string         `gorm:"size:255" json:"name"`
+	ContactInfo string         `gorm:"type:text" json:"contact_info"`
+	Currency    string         `gorm:"size:3;default:VND" json:"currency"` // Default for its jobs
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// DyeingJob sends greige to a dye house to be dyed to a target color matching an approved lap dip
+type DyeingJob struct {
+	ID                    uint                `gorm:"primaryKey" json:"id"`
+	JobCode               string              `gorm:"size:100;uniqueIndex" json:"job_code"`
+	DyeingSubcontractorID uint                `json:"dyeing_subcontractor_id"`
+	OrderID               *uint               `json:"order_id"`
+	WeavingOrderID        *uint               `json:"weaving_order_id"`
+	ProductID             *uint               `json:"product_id"` // Product the dyed lots are stocked as
+	TargetColor           string              `gorm:"size:255" json:"target_color"`
+	ColorCode             string              `gorm:"size:100" json:"color_code"`
+	LDTestID              *uint               `gorm:"column:ld_test_id" json:"ld_test_id"`
+	LapDipReference       string              `gorm:"size:100" json:"lap_dip_reference"`
+	GreigeLength          float64             `json:"greige_length"` // Meters sent
+	GreigeWeight          float64             `json:"greige_weight"` // Kilograms sent
+	GreigeRolls           int                 `json:"greige_rolls"`
+	SentDate              time.Time           `gorm:"type:date" json:"sent_date"`
+	ExpectedReturnDate    *time.Time          `gorm:"type:date" json:"expected_return_date"`
+	ReturnedDate          *time.Time          `gorm:"type:date" json:"returned_date"`
+	CostPerKg             money.Amount        `json:"cost_per_kg"`
+	Currency              string              `gorm:"size:3;default:VND" json:"currency"`
+	Status                string              `gorm:"size:50;default:sent" json:"status"`
+	SpecialRequirements   string              `gorm:"type:text" json:"special_requirements"`
+	Notes                 string              `gorm:"type:text" json:"notes"`
+	CreatedBy             *uint               `json:"created_by"`
+	CreatedAt             time.Time           `json:"created_at"`
+	UpdatedAt             time.Time           `json:"updated_at"`
+	DeletedAt             gorm.DeletedAt      `gorm:"index" json:"-"`
+	DyeingSubcontractor   DyeingSubcontractor `gorm:"foreignKey:DyeingSubcontractorID" json:"dyeing_subcontractor,omitempty"`
+	Order                 *Order              `gorm:"foreignKey:OrderID" json:"order,omitempty"`
+	WeavingOrder          *WeavingOrder       `gorm:"foreignKey:WeavingOrderID" json:"weaving_order,omitempty"`
+	Product               *Product            `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Lots                  []DyeingLot         `gorm:"foreignKey:DyeingJobID" json:"lots,omitempty"`
+}
+
+// DyeingLot is a lot of dyed fabric returned by a dye house, with its shrinkage and color fastness results
+type DyeingLot struct {
+	ID                    uint       `gorm:"primaryKey" json:"id"`
+	DyeingJobID           *uint      `json:"dyeing_job_id"`
+	DyeingSubcontractorID uint       `json:"dyeing_subcontractor_id"`
+	ProductID             *uint      `json:"product_id"`
+	ColorCode             string     `gorm:"size:100" json:"color_code"`
+	LotCode               string     `gorm:"size:100;uniqueIndex" json:"lot_code"`
+	Quantity              int        `json:"quantity"` // Rolls
+	Weight                float64    `json:"weight"`   // Kilograms
+	PlannedLength         float64    `json:"planned_length"`
+	ExpectedDate          *time.Time `json:"expected_date"`
+	ReturnedDate          *time.Time `gorm:"type:date" json:"returned_date"`
+	Status                string     `gorm:"size:50;default:pending" json:"status"`
+	ShrinkageLength       *float64   `json:"shrinkage_length"`       // Percent along the warp
+	ShrinkageWidth        *float64   `json:"shrinkage_width"`        // Percent along the weft
+	ColorFastnessWashing  *float64   `json:"color_fastness_washing"` // Grey scale 1-5
+	ColorFastnessRubbing  *float64   `json:"color_fastness_rubbing"` // Grey scale 1-5
+	ColorFastnessLight    *float64   `json:"color_fastness_light"`   // Blue wool scale 1-8
+	TestedBy              *uint      `json:"tested_by"`
+	TestedAt              *time.Time `json:"tested_at"`
+	DecidedBy             *uint      `json:"decided_by"`
+	DecidedAt             *time.Time `json:"decided_at"`
+	LotID                 *uint      `json:"lot_id"` // Warehouse lot made on acceptance
+	QualityIssues         string     `gorm:"type:text" json:"quality_issues"`
+	Notes                 string     `gorm:"type:text" json:"notes"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
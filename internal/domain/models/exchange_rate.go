@@ -0,0 +1,29 @@
+// File: internal/domain/models/exchange_rate.go
+// Tạo tại: internal/domain/models/exchange_rate.go
+// Mục đích: Model tỷ giá hối đoái theo ngày hiệu lực (bảng exchange_rates) theo migration 000029
+
+package models
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+// DefaultCurrency is what currency columns default to, as in migration 000029
+const DefaultCurrency = money.VND
+
+// ExchangeRate says one unit of FromCurrency is worth Rate units of ToCurrency from EffectiveDate
+// until the next rate of the same pair
+type ExchangeRate struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	FromCurrency  string    `gorm:"size:3" json:"from_currency"`
+	ToCurrency    string    `gorm:"size:3" json:"to_currency"`
+	Rate          float64   `gorm:"type:decimal(20,8)" json:"rate"`
+	EffectiveDate time.Time `gorm:"type:date" json:"effective_date"`
+	Source        string    `gorm:"size:100" json:"source"`
+	Notes         string    `gorm:"type:text" json:"notes"`
+	CreatedBy     *uint     `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,43 @@
+// File: internal/domain/models/image.go
+// Tạo tại: internal/domain/models/image.go
+// Mục đích: Model hình ảnh cho sample_products và products (sample_images, product_images)
+
+package models
+
+import "time"
+
+type SampleImage struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	SampleProductID uint      `gorm:"not null" json:"sample_product_id"`
+	ImageURL        string    `gorm:"type:text" json:"image_url"`
+	StorageKey      string    `gorm:"size:500" json:"-"`
+	ThumbnailURL    string    `gorm:"type:text" json:"thumbnail_url"`
+	ThumbnailKey    string    `gorm:"size:500" json:"-"`
+	FileName        string    `gorm:"size:255" json:"file_name"`
+	ContentType     string    `gorm:"size:100" json:"content_type"`
+	FileSize        int64     `json:"file_size"`
+	Width           int       `json:"width"`
+	Height          int       `json:"height"`
+	IsPrimary       bool      `gorm:"default:false" json:"is_primary"`
+	SortOrder       int       `gorm:"default:0" json:"sort_order"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type ProductImage struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ProductID    uint      `gorm:"not null" json:"product_id"`
+	ImageURL     string    `gorm:"type:text" json:"image_url"`
+	StorageKey   string    `gorm:"size:500" json:"-"`
+	ThumbnailURL string    `gorm:"type:text" json:"thumbnail_url"`
+	ThumbnailKey string    `gorm:"size:500" json:"-"`
+	FileName     string    `gorm:"size:255" json:"file_name"`
+	ContentType  string    `gorm:"size:100" json:"content_type"`
+	FileSize     int64     `json:"file_size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	IsPrimary    bool      `gorm:"default:false" json:"is_primary"`
+	SortOrder    int       `gorm:"default:0" json:"sort_order"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,77 @@
+// File: internal/domain/models/inventory.go
+// Tạo tại: internal/domain/models/inventory.go
+// Mục đích: Model lô hàng, cuộn vải (lots, fabric_rolls) theo migration 000008 và giữ hàng cho đơn (stock_reservations) theo migration 000027
+
+package models
+
+import "time"
+
+// Lot and roll statuses that can be sold
+const (
+	LotStatusAvailable  = "available"
+	LotQualityGood      = "good"
+	RollStatusAvailable = "available"
+)
+
+const (
+	ReservationActive   = "active"
+	ReservationReleased = "released"
+	ReservationExpired  = "expired"
+	ReservationConsumed = "consumed" // The order shipped
+)
+
+// Lot is a batch of finished fabric of one product and color
+type Lot struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	LotCode       string    `gorm:"size:100;uniqueIndex" json:"lot_code"`
+	Origin        string    `gorm:"size:255" json:"origin"` // Dyeing, Purchase
+	ProductID     uint      `json:"product_id"`
+	ColorCode     string    `gorm:"size:100" json:"color_code"`
+	FabricType    string    `gorm:"size:255" json:"fabric_type"`
+	Quantity      int       `json:"quantity"`
+	TotalWeight   float64   `json:"total_weight"`
+	Location      string    `gorm:"size:255" json:"location"`
+	Status        string    `gorm:"size:50;default:available" json:"status"`
+	QualityStatus string    `gorm:"size:50;default:good" json:"quality_status"`
+	OriginOrderID *uint     `json:"origin_order_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Notes         string    `gorm:"type:text" json:"notes"`
+}
+
+// FabricRoll is one roll of a lot kept in a warehouse
+type FabricRoll struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	LotID       uint      `json:"lot_id"`
+	WarehouseID uint      `json:"warehouse_id"`
+	Barcode     string    `gorm:"size:100;uniqueIndex" json:"barcode"`
+	Weight      float64   `json:"weight"`
+	Length      float64   `json:"length"` // Meters
+	Location    string    `gorm:"size:255" json:"location"`
+	Status      string    `gorm:"size:50;default:available" json:"status"`
+	CreatedBy   uint      `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Notes       string    `gorm:"type:text" json:"notes"`
+	Lot         Lot       `gorm:"foreignKey:LotID" json:"lot,omitempty"`
+}
+
+// StockReservation holds a whole fabric roll for an order line
+type StockReservation struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	OrderID       uint       `json:"order_id"`
+	OrderItemID   uint       `json:"order_item_id"`
+	LotID         uint       `json:"lot_id"`
+	FabricRollID  uint       `json:"fabric_roll_id"`
+	Length        float64    `json:"length"`
+	Weight        float64    `json:"weight"`
+	Status        string     `gorm:"size:20;default:active" json:"status"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	ReservedBy    *uint      `json:"reserved_by"`
+	ReleasedAt    *time.Time `json:"released_at"`
+	ReleaseReason string     `gorm:"size:255" json:"release_reason"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	Lot           Lot        `gorm:"foreignKey:LotID" json:"lot,omitempty"`
+	FabricRoll    FabricRoll `gorm:"foreignKey:FabricRollID" json:"fabric_roll,omitempty"`
+}
@@ -0,0 +1,42 @@
+// File: internal/domain/models/lap_dip.go
+// Tạo tại: internal/domain/models/lap_dip.go
+// Mục đích: Model gửi lap dip cho khách và lựa chọn cuối cùng (lap_dip_dispatches, lap_dip_final_selection) theo migration 000011
+
+package models
+
+import "time"
+
+const (
+	LapDipDispatched = "dispatched"
+	LapDipApproved   = "approved"
+	LapDipRejected   = "rejected"
+)
+
+// LapDipDispatch is a set of lap dips sent to the customer for a decision
+type LapDipDispatch struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	LDTestID         uint       `gorm:"column:ld_test_id" json:"ld_test_id"`
+	DispatchDate     time.Time  `json:"dispatch_date"`
+	DispatchCode     string     `gorm:"size:100" json:"dispatch_code"` // Combined code from the dyehouse and the company
+	CustomerResponse string     `gorm:"type:text" json:"customer_response"`
+	ResponseDate     *time.Time `json:"response_date"`
+	FinalLDCode      string     `gorm:"column:final_ld_code;size:100" json:"final_ld_code"`
+	Status           string     `gorm:"size:50;default:dispatched" json:"status"`
+	Notes            string     `gorm:"type:text" json:"notes"`
+	LapDipTest       LapDipTest `gorm:"foreignKey:LDTestID" json:"lap_dip_test,omitempty"`
+}
+
+// LapDipFinalSelection is the lap dip code a customer settled on
+type LapDipFinalSelection struct {
+	ID                   uint      `gorm:"primaryKey" json:"id"`
+	LDTestID             uint      `gorm:"column:ld_test_id" json:"ld_test_id"`
+	FinalLDCode          string    `gorm:"column:final_ld_code;size:100" json:"final_ld_code"`
+	SelectedByCustomerID uint      `json:"selected_by_customer_id"`
+	OrderID              *uint     `json:"order_id"`
+	FinalSelectionDate   time.Time `json:"final_selection_date"`
+	Notes                string    `gorm:"type:text" json:"notes"`
+}
+
+func (LapDipFinalSelection) TableName() string {
+	return "lap_dip_final_selection"
+}
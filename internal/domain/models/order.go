@@ -0,0 +1,86 @@
+// File: internal/domain/models/order.go
+// Tạo tại: internal/domain/models/order.go
+// Mục đích: Model đơn hàng (orders, order_statuses, order_items, order_histories) theo migration 000004
+
+package models
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+	"gorm.io/gorm"
+)
+
+// Order status names seeded by migrations 000004 and 000026
+const (
+	OrderStatusPending      = "PENDING"
+	OrderStatusConfirmed    = "CONFIRMED"
+	OrderStatusInProduction = "IN_PRODUCTION"
+	OrderStatusShipped      = "SHIPPED"
+	OrderStatusCompleted    = "COMPLETED"
+	OrderStatusCancelled    = "CANCELLED"
+	OrderStatusOnHold       = "ON_HOLD"
+)
+
+type OrderStatus struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	StatusName  string         `gorm:"size:100;uniqueIndex" json:"status_name"`
+	Description string         `gorm:"type:text" json:"description"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+type Order struct {
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	OrderCode      string         `gorm:"size:100;uniqueIndex" json:"order_code"`
+	CustomerID     uint           `json:"customer_id"`
+	OrderStatusID  uint           `json:"order_status_id"`
+	TotalAmount    money.Amount   `json:"total_amount"`
+	Currency       string         `gorm:"size:3;default:VND" json:"currency"` // Of the total and every item price
+	DueDate        *time.Time     `json:"due_date"`
+	Notes          string         `gorm:"type:text" json:"notes"`
+	FileAttachment string         `gorm:"type:text" json:"file_attachment"` // JSON list of OrderAttachment
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+	Customer       Customer       `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	OrderStatus    OrderStatus    `gorm:"foreignKey:OrderStatusID" json:"order_status,omitempty"`
+	Items          []OrderItem    `gorm:"foreignKey:OrderID" json:"items,omitempty"`
+}
+
+// OrderAttachment is a file attached to an order
+type OrderAttachment struct {
+	Name        string    `json:"name"` // Object name, unique within the order
+	Key         string    `json:"key"`
+	URL         string    `json:"url"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	UploadedBy  uint      `json:"uploaded_by"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+type OrderItem struct {
+	ID        uint         `gorm:"primaryKey" json:"id"`
+	OrderID   uint         `json:"order_id"`
+	ProductID uint         `json:"product_id"`
+	ColorCode string       `gorm:"size:100" json:"color_code"` // Empty means the product color
+	Quantity  float64      `json:"quantity"`
+	Price     money.Amount `json:"price"`
+	Subtotal  money.Amount `json:"subtotal"`
+	Notes     string       `gorm:"type:text" json:"notes"`
+	Product   Product      `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+}
+
+// OrderHistory records a status an order moved to and who moved it
+type OrderHistory struct {
+	ID        uint        `gorm:"primaryKey" json:"id"`
+	OrderID   uint        `json:"order_id"`
+	StatusID  uint        `json:"status_id"`
+	ChangedAt time.Time   `json:"changed_at"`
+	ChangedBy uint        `json:"changed_by"`
+	Notes     string      `gorm:"type:text" json:"notes"`
+	Status    OrderStatus `gorm:"foreignKey:StatusID" json:"status,omitempty"`
+	User      User        `gorm:"foreignKey:ChangedBy" json:"user,omitempty"`
+}
@@ -99,6 +99,7 @@ var PreDefinedPermissions = []Permission{
 	{Module: "PRODUCT", Action: "DELETE", PermissionName: "PRODUCT_DELETE", Description: "Delete products"},
 	{Module: "PRODUCT", Action: "EXPORT", PermissionName: "PRODUCT_EXPORT", Description: "Export product data"},
 	{Module: "PRODUCT", Action: "IMPORT", PermissionName: "PRODUCT_IMPORT", Description: "Import product data"},
+	{Module: "PRODUCT", Action: "PRICING", PermissionName: "PRODUCT_PRICING", Description: "Manage price lists"},
 	
 	// Product Category Management
 	{Module: "PRODUCT_CATEGORY", Action: "VIEW", PermissionName: "PRODUCT_CATEGORY_VIEW", Description: "View product categories"},
@@ -113,6 +114,8 @@ var PreDefinedPermissions = []Permission{
 	{Module: "SAMPLE", Action: "DELETE", PermissionName: "SAMPLE_DELETE", Description: "Delete samples"},
 	{Module: "SAMPLE", Action: "DISPATCH", PermissionName: "SAMPLE_DISPATCH", Description: "Dispatch samples to customers"},
 	{Module: "SAMPLE", Action: "TRACK", PermissionName: "SAMPLE_TRACK", Description: "Track sample status"},
+	{Module: "SAMPLE", Action: "IMPORT", PermissionName: "SAMPLE_IMPORT", Description: "Import sample data"},
+	{Module: "SAMPLE", Action: "EXPORT", PermissionName: "SAMPLE_EXPORT", Description: "Export sample data"},
 	
 	// Customer Management
 	{Module: "CUSTOMER", Action: "VIEW", PermissionName: "CUSTOMER_VIEW", Description: "View customers"},
@@ -120,6 +123,7 @@ var PreDefinedPermissions = []Permission{
 	{Module: "CUSTOMER", Action: "UPDATE", PermissionName: "CUSTOMER_UPDATE", Description: "Update customer information"},
 	{Module: "CUSTOMER", Action: "DELETE", PermissionName: "CUSTOMER_DELETE", Description: "Delete customers"},
 	{Module: "CUSTOMER", Action: "VIEW_ACTIVITY", PermissionName: "CUSTOMER_VIEW_ACTIVITY", Description: "View customer activity logs"},
+	{Module: "CUSTOMER", Action: "MERGE", PermissionName: "CUSTOMER_MERGE", Description: "Merge duplicate customers"},
 	
 	// Order Management
 	{Module: "ORDER", Action: "VIEW", PermissionName: "ORDER_VIEW", Description: "View orders"},
@@ -129,6 +133,7 @@ var PreDefinedPermissions = []Permission{
 	{Module: "ORDER", Action: "APPROVE", PermissionName: "ORDER_APPROVE", Description: "Approve orders"},
 	{Module: "ORDER", Action: "CANCEL", PermissionName: "ORDER_CANCEL", Description: "Cancel orders"},
 	{Module: "ORDER", Action: "SHIP", PermissionName: "ORDER_SHIP", Description: "Ship orders"},
+	{Module: "ORDER", Action: "QUOTE", PermissionName: "ORDER_QUOTE", Description: "Create and manage quotations"},
 	
 	// Warehouse Management
 	{Module: "WAREHOUSE", Action: "VIEW", PermissionName: "WAREHOUSE_VIEW", Description: "View warehouse data"},
@@ -137,12 +142,19 @@ var PreDefinedPermissions = []Permission{
 	{Module: "WAREHOUSE", Action: "DELETE", PermissionName: "WAREHOUSE_DELETE", Description: "Delete warehouse entries"},
 	{Module: "WAREHOUSE", Action: "TRANSFER", PermissionName: "WAREHOUSE_TRANSFER", Description: "Transfer inventory"},
 	
+	// Production Management
+	{Module: "PRODUCTION", Action: "VIEW", PermissionName: "PRODUCTION_VIEW", Description: "View weaving facilities, orders and operations"},
+	{Module: "PRODUCTION", Action: "CREATE", PermissionName: "PRODUCTION_CREATE", Description: "Create weaving orders and log operations"},
+	{Module: "PRODUCTION", Action: "UPDATE", PermissionName: "PRODUCTION_UPDATE", Description: "Update weaving data and order status"},
+	{Module: "PRODUCTION", Action: "DELETE", PermissionName: "PRODUCTION_DELETE", Description: "Delete weaving data"},
+	
 	// Financial Management
 	{Module: "FINANCE", Action: "VIEW", PermissionName: "FINANCE_VIEW", Description: "View financial data"},
 	{Module: "FINANCE", Action: "CREATE", PermissionName: "FINANCE_CREATE", Description: "Create financial records"},
 	{Module: "FINANCE", Action: "UPDATE", PermissionName: "FINANCE_UPDATE", Description: "Update financial data"},
 	{Module: "FINANCE", Action: "DELETE", PermissionName: "FINANCE_DELETE", Description: "Delete financial records"},
 	{Module: "FINANCE", Action: "APPROVE", PermissionName: "FINANCE_APPROVE", Description: "Approve financial transactions"},
+	{Module: "FINANCE", Action: "RATES", PermissionName: "FINANCE_RATES", Description: "Manage exchange rates"},
 	
 	// Reporting
 	{Module: "REPORT", Action: "VIEW", PermissionName: "REPORT_VIEW", Description: "View reports"},
@@ -168,4 +180,5 @@ var PreDefinedPermissionGroups = []PermissionGroup{
 	{GroupName: "FINANCIAL_MANAGEMENT", DisplayName: "Financial Management", Module: "FINANCE", SortOrder: 9},
 	{GroupName: "REPORTING", DisplayName: "Reports & Analytics", Module: "REPORT", SortOrder: 10},
 	{GroupName: "SYSTEM_ADMINISTRATION", DisplayName: "System Administration", Module: "SYSTEM", SortOrder: 11},
+	{GroupName: "PRODUCTION_MANAGEMENT", DisplayName: "Production Management", Module: "PRODUCTION", SortOrder: 12},
 }
\ No newline at end of file
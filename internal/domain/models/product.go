@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/godiidev/appsynex/pkg/money"
 	"gorm.io/gorm"
 )
 
@@ -21,8 +22,10 @@ type ProductName struct {
 type ProductCategory struct {
 	ID               uint              `gorm:"primaryKey" json:"id"`
 	CategoryName     string            `gorm:"size:255" json:"category_name"`
+	CategoryNameEN   string            `gorm:"size:255" json:"category_name_en"`
 	ParentCategoryID *uint             `json:"parent_category_id"`
 	Description      string            `gorm:"type:text" json:"description"`
+	DescriptionEN    string            `gorm:"type:text" json:"description_en"`
 	CreatedAt        time.Time         `json:"created_at"`
 	UpdatedAt        time.Time         `json:"updated_at"`
 	DeletedAt        gorm.DeletedAt    `gorm:"index" json:"-"`
@@ -39,6 +42,7 @@ type Product struct {
 	SKU            string          `gorm:"size:100;uniqueIndex" json:"sku"`
 	SKUVariant     string          `gorm:"size:100" json:"sku_variant"`
 	Description    string          `gorm:"type:text" json:"description"`
+	DescriptionEN  string          `gorm:"type:text" json:"description_en"`
 	FabricType     string          `gorm:"size:255" json:"fabric_type"`
 	Weight         float64         `json:"weight"`
 	Width          float64         `json:"width"`
@@ -46,12 +50,14 @@ type Product struct {
 	Quality        string          `gorm:"size:50" json:"quality"`
 	FiberContent   string          `gorm:"size:255" json:"fiber_content"`
 	AdditionalInfo string          `gorm:"type:json" json:"additional_info"`
-	Price          float64         `json:"price"`
-	SalesPrice     float64         `json:"sales_price"`
+	Price          money.Amount    `json:"price"`
+	SalesPrice     money.Amount    `json:"sales_price"`
+	Currency       string          `gorm:"size:3;default:VND" json:"currency"` // Of Price and SalesPrice
 	StockQuantity  float64         `json:"stock_quantity"`
 	CreatedAt      time.Time       `json:"created_at"`
 	UpdatedAt      time.Time       `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt  `gorm:"index" json:"-"`
 	ProductName    ProductName     `gorm:"foreignKey:ProductNameID" json:"product_name,omitempty"`
 	Category       ProductCategory `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Images         []ProductImage  `gorm:"foreignKey:ProductID" json:"images,omitempty"`
 }
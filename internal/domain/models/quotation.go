@@ -0,0 +1,90 @@
+// File: internal/domain/models/quotation.go
+// Tạo tại: internal/domain/models/quotation.go
+// Mục đích: Model bảng giá (price_lists, price_list_items) và báo giá (quotations, quotation_items) theo migration 000028
+
+package models
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+	"gorm.io/gorm"
+)
+
+// Quotation statuses. A sent or draft quotation past valid_until is reported as expired.
+const (
+	QuotationDraft    = "draft"
+	QuotationSent     = "sent"
+	QuotationAccepted = "accepted"
+	QuotationRejected = "rejected"
+	QuotationExpired  = "expired"
+)
+
+// PriceList holds prices for one customer, one price tier, or everyone when both are empty
+type PriceList struct {
+	ID         uint            `gorm:"primaryKey" json:"id"`
+	Name       string          `gorm:"size:255" json:"name"`
+	CustomerID *uint           `json:"customer_id"`
+	PriceTier  string          `gorm:"size:50" json:"price_tier"`
+	Currency   string          `gorm:"size:3;default:VND" json:"currency"` // Of every item price
+	ValidFrom  *time.Time      `gorm:"type:date" json:"valid_from"`
+	ValidTo    *time.Time      `gorm:"type:date" json:"valid_to"` // Inclusive
+	IsActive   bool            `gorm:"default:true" json:"is_active"`
+	Notes      string          `gorm:"type:text" json:"notes"`
+	CreatedBy  *uint           `json:"created_by"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt  `gorm:"index" json:"-"`
+	Customer   *Customer       `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	Items      []PriceListItem `gorm:"foreignKey:PriceListID" json:"items,omitempty"`
+}
+
+// PriceListItem is the unit price of a product from a minimum quantity up
+type PriceListItem struct {
+	ID          uint         `gorm:"primaryKey" json:"id"`
+	PriceListID uint         `json:"price_list_id"`
+	ProductID   uint         `json:"product_id"`
+	MinQuantity float64      `json:"min_quantity"`
+	UnitPrice   money.Amount `json:"unit_price"`
+	Product     Product      `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+}
+
+type Quotation struct {
+	ID              uint            `gorm:"primaryKey" json:"id"`
+	QuotationCode   string          `gorm:"size:100;uniqueIndex" json:"quotation_code"`
+	CustomerID      uint            `json:"customer_id"`
+	Status          string          `gorm:"size:20;default:draft" json:"status"`
+	IssueDate       time.Time       `gorm:"type:date" json:"issue_date"`
+	ValidUntil      time.Time       `gorm:"type:date" json:"valid_until"` // Inclusive
+	Currency        string          `gorm:"size:3;default:VND" json:"currency"`
+	DiscountPercent float64         `json:"discount_percent"`
+	Subtotal        money.Amount    `json:"subtotal"` // Before discounts
+	DiscountAmount  money.Amount    `json:"discount_amount"`
+	TotalAmount     money.Amount    `json:"total_amount"`
+	Notes           string          `gorm:"type:text" json:"notes"`
+	Terms           string          `gorm:"type:text" json:"terms"`
+	OrderID         *uint           `json:"order_id"`
+	CreatedBy       *uint           `json:"created_by"`
+	RespondedAt     *time.Time      `json:"responded_at"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt  `gorm:"index" json:"-"`
+	Customer        Customer        `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	Items           []QuotationItem `gorm:"foreignKey:QuotationID" json:"items,omitempty"`
+}
+
+// QuotationItem is a quoted line. NetPrice is the unit price after the line and quotation discounts.
+type QuotationItem struct {
+	ID              uint         `gorm:"primaryKey" json:"id"`
+	QuotationID     uint         `json:"quotation_id"`
+	ProductID       uint         `json:"product_id"`
+	ColorCode       string       `gorm:"size:100" json:"color_code"`
+	Quantity        float64      `json:"quantity"`
+	UnitPrice       money.Amount `json:"unit_price"`
+	DiscountPercent float64      `json:"discount_percent"`
+	NetPrice        money.Amount `json:"net_price"`
+	Subtotal        money.Amount `json:"subtotal"`
+	PriceListID     *uint        `json:"price_list_id"`
+	Notes           string       `gorm:"type:text" json:"notes"`
+	Product         Product      `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+}
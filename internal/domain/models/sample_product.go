@@ -12,6 +12,7 @@ type SampleProduct struct {
 	ProductNameID     uint            `json:"product_name_id"`
 	CategoryID        uint            `json:"category_id"`
 	Description       string          `gorm:"type:text" json:"description"`
+	DescriptionEN     string          `gorm:"type:text" json:"description_en"`
 	SampleType        string          `gorm:"size:255" json:"sample_type"`
 	Weight            float64         `json:"weight"`
 	Width             float64         `json:"width"`
@@ -23,9 +24,11 @@ type SampleProduct struct {
 	Source            string          `gorm:"size:255" json:"source"`
 	SampleLocation    string          `gorm:"size:255" json:"sample_location"`
 	Barcode           string          `gorm:"size:255" json:"barcode"`
+	SearchText        string          `gorm:"type:text" json:"-"` // Normalized text for FULLTEXT search
 	CreatedAt         time.Time       `json:"created_at"`
 	UpdatedAt         time.Time       `json:"updated_at"`
 	DeletedAt         gorm.DeletedAt  `gorm:"index" json:"-"`
 	ProductName       ProductName     `gorm:"foreignKey:ProductNameID" json:"product_name,omitempty"`
 	Category          ProductCategory `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Images            []SampleImage   `gorm:"foreignKey:SampleProductID" json:"images,omitempty"`
 }
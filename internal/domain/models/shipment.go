@@ -0,0 +1,39 @@
+// File: internal/domain/models/shipment.go
+// Tạo tại: internal/domain/models/shipment.go
+// Mục đích: Model giao hàng (bảng shipping) theo migration 000009
+
+package models
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+// Shipment is the delivery of a fabric roll of an order
+type Shipment struct {
+	ID                   uint         `gorm:"primaryKey" json:"id"`
+	OrderID              uint         `json:"order_id"`
+	PackingListID        uint         `json:"packing_list_id"`
+	FabricRollID         uint         `json:"fabric_roll_id"`
+	DeliveryStatus       string       `gorm:"size:50;default:pending" json:"delivery_status"`
+	TrackingInfo         string       `gorm:"type:json" json:"tracking_info"`
+	ReceivedBy           string       `gorm:"size:255" json:"received_by"`
+	ReturnedQuantity     int          `json:"returned_quantity"`
+	ReturnedWeight       float64      `json:"returned_weight"`
+	ReturnReason         string       `gorm:"type:text" json:"return_reason"`
+	ReturnAction         string       `gorm:"type:text" json:"return_action"`
+	TransportationMethod string       `gorm:"size:100" json:"transportation_method"`
+	VehicleNumber        string       `gorm:"size:100" json:"vehicle_number"`
+	ShippingDate         *time.Time   `json:"shipping_date"`
+	ShippingCost         money.Amount `json:"shipping_cost"`
+	Currency             string       `gorm:"size:3;default:VND" json:"currency"`
+	Notes                string       `gorm:"type:text" json:"notes"`
+	CreatedAt            time.Time    `json:"created_at"`
+	UpdatedAt            time.Time    `json:"updated_at"`
+	Order                Order        `gorm:"foreignKey:OrderID" json:"order,omitempty"`
+}
+
+func (Shipment) TableName() string {
+	return "shipping"
+}
@@ -0,0 +1,84 @@
+// File: internal/domain/models/subcontracting.go
+// Tạo tại: internal/domain/models/subcontracting.go
+// Mục đích: Model hóa đơn gia công dệt/nhuộm và thanh toán cho nhà gia công (weaving_financials, dyeing_financials,
+// subcontractor_payments) theo migration 000006, 000034, 000035 và 000036
+
+package models
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+// Subcontracted job types
+const (
+	SubcontractWeaving = "weaving"
+	SubcontractDyeing  = "dyeing"
+)
+
+// Invoice payment statuses, kept in step with the amount paid
+const (
+	PaymentStatusPending = "pending"
+	PaymentStatusPartial = "partial"
+	PaymentStatusPaid    = "paid"
+)
+
+// Payment methods
+const (
+	PaymentMethodBankTransfer = "bank_transfer"
+	PaymentMethodCash         = "cash"
+	PaymentMethodOther        = "other"
+)
+
+// WeavingFinancial is an outside weaving mill's invoice for a weaving order, one per order; amounts are in Currency
+type WeavingFinancial struct {
+	ID             uint         `gorm:"primaryKey" json:"id"`
+	WeavingOrderID uint         `json:"weaving_order_id"`
+	CustomerID     *uint        `json:"customer_id"`
+	UnitCost       money.Amount `json:"unit_cost"` // Per meter
+	Quantity       float64      `json:"quantity"`  // Meters billed
+	TotalCost      money.Amount `json:"total_cost"`
+	Currency       string       `gorm:"size:3;default:VND" json:"currency"`
+	AmountPaid     money.Amount `json:"amount_paid"`
+	PaymentStatus  string       `gorm:"size:50;default:pending" json:"payment_status"`
+	DueDate        *time.Time   `json:"due_date"`
+	InvoiceID      *uint        `json:"invoice_id"`
+	InvoiceNumber  string       `gorm:"size:100" json:"invoice_number"`
+	InvoiceDate    *time.Time   `gorm:"type:date" json:"invoice_date"`
+	Notes          string       `gorm:"type:text" json:"notes"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// DyeingFinancial is a dye house's invoice for a dyeing job; amounts are in Currency
+type DyeingFinancial struct {
+	ID            uint         `gorm:"primaryKey" json:"id"`
+	DyeingJobID   uint         `json:"dyeing_job_id"`
+	UnitCost      money.Amount `json:"unit_cost"`
+	Quantity      float64      `json:"quantity"` // Units billed
+	TotalCost     money.Amount `json:"total_cost"`
+	Currency      string       `gorm:"size:3;default:VND" json:"currency"`
+	AmountPaid    money.Amount `json:"amount_paid"`
+	PaymentStatus string       `gorm:"size:50;default:pending" json:"payment_status"`
+	DueDate       *time.Time   `gorm:"type:date" json:"due_date"`
+	InvoiceNumber string       `gorm:"size:100" json:"invoice_number"`
+	InvoiceDate   *time.Time   `gorm:"type:date" json:"invoice_date"`
+	Notes         string       `gorm:"type:text" json:"notes"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+// SubcontractorPayment settles part of one weaving or dyeing invoice, in the invoice currency
+type SubcontractorPayment struct {
+	ID                 uint         `gorm:"primaryKey" json:"id"`
+	WeavingFinancialID *uint        `json:"weaving_financial_id"`
+	DyeingFinancialID  *uint        `json:"dyeing_financial_id"`
+	Amount             money.Amount `json:"amount"`
+	PaidAt             time.Time    `gorm:"type:date" json:"paid_at"`
+	Method             string       `gorm:"size:50;default:bank_transfer" json:"method"`
+	Reference          string       `gorm:"size:100" json:"reference"`
+	Notes              string       `gorm:"type:text" json:"notes"`
+	CreatedBy          *uint        `json:"created_by"`
+	CreatedAt          time.Time    `json:"created_at"`
+}
@@ -0,0 +1,121 @@
+// File: internal/domain/models/weaving.go
+// Tạo tại: internal/domain/models/weaving.go
+// Mục đích: Model xưởng dệt, ca, công nhân, lệnh dệt và công đoạn dệt (weaving_facilities, weaving_shifts, weaving_staff,
+// weaving_orders, weaving_operations) theo migration 000006, 000027, 000031, 000032 và 000033
+
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Weaving facility types
+const (
+	WeavingFacilityInternal = "internal"
+	WeavingFacilityExternal = "external"
+)
+
+// Weaving order statuses. An order starts with its first operation, takes no more operations once
+// completed or cancelled, and a completed order's fabric is then sent to dyeing.
+const (
+	WeavingOrderPending      = "pending"
+	WeavingOrderInProgress   = "in_progress"
+	WeavingOrderCompleted    = "completed"
+	WeavingOrderSentToDyeing = "sent_to_dyeing"
+	WeavingOrderCancelled    = "cancelled"
+)
+
+// WeavingFacility is a weaving mill of the company or of a subcontractor
+type WeavingFacility struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	FacilityName string         `gorm:"size:255" json:"facility_name"`
+	Location     string         `gorm:"size:255" json:"location"`
+	ContactInfo  string         `gorm:"type:text" json:"contact_info"`
+	FacilityType string         `gorm:"size:50;default:internal" json:"facility_type"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// WeavingShift is a daily shift of a facility; StartTime and EndTime are HH:MM:SS and a shift
+// ending before it starts runs past midnight
+type WeavingShift struct {
+	ID                uint      `gorm:"primaryKey" json:"id"`
+	WeavingFacilityID uint      `json:"weaving_facility_id"`
+	ShiftName         string    `gorm:"size:100" json:"shift_name"`
+	StartTime         string    `gorm:"type:time" json:"start_time"`
+	EndTime           string    `gorm:"type:time" json:"end_time"`
+	ShiftLeader       string    `gorm:"size:255" json:"shift_leader"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// WeavingStaff is a worker of a facility, with the shift they usually work
+type WeavingStaff struct {
+	ID                uint            `gorm:"primaryKey" json:"id"`
+	WeavingFacilityID uint            `json:"weaving_facility_id"`
+	StaffName         string          `gorm:"size:255" json:"staff_name"`
+	Position          string          `gorm:"size:100" json:"position"`
+	ContactInfo       string          `gorm:"type:text" json:"contact_info"`
+	ShiftID           *uint           `json:"shift_id"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt  `gorm:"index" json:"-"`
+	WeavingFacility   WeavingFacility `gorm:"foreignKey:WeavingFacilityID" json:"weaving_facility,omitempty"`
+	Shift             *WeavingShift   `gorm:"foreignKey:ShiftID" json:"shift,omitempty"`
+}
+
+func (WeavingStaff) TableName() string {
+	return "weaving_staff"
+}
+
+// WeavingOrder weaves a product of a sales order from a yarn box at a facility
+type WeavingOrder struct {
+	ID                uint            `gorm:"primaryKey" json:"id"`
+	OrderCode         string          `gorm:"size:100;uniqueIndex" json:"order_code"`
+	WeavingFacilityID uint            `json:"weaving_facility_id"`
+	LinkedOrderID     uint            `json:"linked_order_id"`
+	YarnBoxID         uint            `json:"yarn_box_id"`
+	ProductID         uint            `json:"product_id"`
+	ColorCode         string          `gorm:"size:100" json:"color_code"`                    // Color the greige will be dyed to
+	PlannedQuantity   float64         `gorm:"column:planned_length" json:"planned_quantity"` // Meters
+	ProducedQuantity  float64         `json:"produced_quantity"`                             // Sum of the operations
+	StartDate         *time.Time      `json:"start_date"`
+	EndDate           *time.Time      `json:"end_date"` // Planned finish while open, actual once completed or cancelled
+	Status            string          `gorm:"size:50;default:pending" json:"status"`
+	Notes             string          `gorm:"type:text" json:"notes"`
+	CreatedBy         *uint           `json:"created_by"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	WeavingFacility   WeavingFacility `gorm:"foreignKey:WeavingFacilityID" json:"weaving_facility,omitempty"`
+	LinkedOrder       Order           `gorm:"foreignKey:LinkedOrderID" json:"linked_order,omitempty"`
+	YarnBox           YarnBox         `gorm:"foreignKey:YarnBoxID" json:"yarn_box,omitempty"`
+	Product           Product         `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+}
+
+// WeavingOperation is a run of a weaving order on a machine by a worker during a shift
+type WeavingOperation struct {
+	ID                 uint            `gorm:"primaryKey" json:"id"`
+	WeavingOrderID     uint            `json:"weaving_order_id"`
+	WeavingFacilityID  uint            `json:"weaving_facility_id"`
+	WeavingStaffID     uint            `json:"weaving_staff_id"`
+	WeavingShiftID     *uint           `json:"weaving_shift_id"`
+	YarnBoxID          uint            `json:"yarn_box_id"`
+	WeavingMachineID   *uint           `json:"weaving_machine_id"`
+	CamLayout          string          `gorm:"type:text" json:"cam_layout"`
+	StartTime          *time.Time      `json:"start_time"`
+	EndTime            *time.Time      `json:"end_time"`
+	Quantity           float64         `json:"quantity"` // Meters woven
+	QualityCheckStatus string          `gorm:"size:50;default:pending" json:"quality_check_status"`
+	Notes              string          `gorm:"type:text" json:"notes"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+	WeavingOrder       WeavingOrder    `gorm:"foreignKey:WeavingOrderID" json:"weaving_order,omitempty"`
+	WeavingStaff       WeavingStaff    `gorm:"foreignKey:WeavingStaffID" json:"weaving_staff,omitempty"`
+	WeavingShift       *WeavingShift   `gorm:"foreignKey:WeavingShiftID" json:"weaving_shift,omitempty"`
+	YarnBox            YarnBox         `gorm:"foreignKey:YarnBoxID" json:"yarn_box,omitempty"`
+	WeavingFacility    WeavingFacility `gorm:"foreignKey:WeavingFacilityID" json:"weaving_facility,omitempty"`
+	WeavingMachine     *WeavingMachine `gorm:"foreignKey:WeavingMachineID" json:"weaving_machine,omitempty"`
+}
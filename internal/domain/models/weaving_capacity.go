@@ -0,0 +1,60 @@
+// File: internal/domain/models/weaving_capacity.go
+// Tạo tại: internal/domain/models/weaving_capacity.go
+// Mục đích: Model máy dệt và lịch phân ca công nhân dệt (weaving_machines, weaving_rosters) theo migration 000033
+
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Loom types
+const (
+	LoomTypeAirJet     = "air_jet"
+	LoomTypeRapier     = "rapier"
+	LoomTypeWaterJet   = "water_jet"
+	LoomTypeProjectile = "projectile"
+	LoomTypeShuttle    = "shuttle"
+)
+
+// Weaving machine statuses. Only active machines are planned.
+const (
+	WeavingMachineActive      = "active"
+	WeavingMachineMaintenance = "maintenance"
+	WeavingMachineRetired     = "retired"
+)
+
+// WeavingMachine is a loom of a facility
+type WeavingMachine struct {
+	ID                uint            `gorm:"primaryKey" json:"id"`
+	WeavingFacilityID uint            `json:"weaving_facility_id"`
+	MachineCode       string          `gorm:"size:50;uniqueIndex" json:"machine_code"`
+	LoomType          string          `gorm:"size:50;default:rapier" json:"loom_type"`
+	MaxWidth          float64         `json:"max_width"`                         // Centimeters
+	SpeedRPM          int             `gorm:"column:speed_rpm" json:"speed_rpm"` // Picks per minute
+	OutputPerHour     float64         `json:"output_per_hour"`                   // Meters per running hour
+	Status            string          `gorm:"size:50;default:active" json:"status"`
+	Notes             string          `gorm:"type:text" json:"notes"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt  `gorm:"index" json:"-"`
+	WeavingFacility   WeavingFacility `gorm:"foreignKey:WeavingFacilityID" json:"weaving_facility,omitempty"`
+}
+
+// WeavingRoster puts a staff member on a shift for a day, optionally on a machine
+type WeavingRoster struct {
+	ID               uint            `gorm:"primaryKey" json:"id"`
+	WeavingStaffID   uint            `json:"weaving_staff_id"`
+	WeavingShiftID   uint            `json:"weaving_shift_id"`
+	WeavingMachineID *uint           `json:"weaving_machine_id"`
+	WorkDate         time.Time       `gorm:"type:date" json:"work_date"`
+	Notes            string          `gorm:"type:text" json:"notes"`
+	CreatedBy        *uint           `json:"created_by"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+	WeavingStaff     WeavingStaff    `gorm:"foreignKey:WeavingStaffID" json:"weaving_staff,omitempty"`
+	WeavingShift     WeavingShift    `gorm:"foreignKey:WeavingShiftID" json:"weaving_shift,omitempty"`
+	WeavingMachine   *WeavingMachine `gorm:"foreignKey:WeavingMachineID" json:"weaving_machine,omitempty"`
+}
@@ -0,0 +1,91 @@
+// File: internal/domain/models/weaving_quality.go
+// Tạo tại: internal/domain/models/weaving_quality.go
+// Mục đích: Model danh mục lỗi vải và phiếu kiểm tra chất lượng dệt theo hệ thống 4 điểm (defect_types,
+// weaving_quality_control, weaving_qc_defects) theo migration 000006 và 000032
+
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Defect type categories
+const (
+	DefectCategoryYarn   = "yarn"
+	DefectCategoryWarp   = "warp"
+	DefectCategoryWeft   = "weft"
+	DefectCategoryFabric = "fabric"
+	DefectCategoryOther  = "other"
+)
+
+// Quality check results. An operation is pending until its first inspection.
+const (
+	WeavingQCPending = "pending"
+	WeavingQCPassed  = "passed"
+	WeavingQCFailed  = "failed"
+)
+
+// DefectType is an entry of the fabric defect catalog
+type DefectType struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Code        string         `gorm:"size:50;uniqueIndex" json:"code"`
+	Name        string         `gorm:"size:255" json:"name"`
+	Category    string         `gorm:"size:50;default:other" json:"category"`
+	Description string         `gorm:"type:text" json:"description"`
+	FixedPoints *int           `json:"fixed_points"` // Points regardless of length, e.g. 4 for holes
+	IsActive    bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// WeavingQualityControl is an inspection of the fabric of a weaving operation. DefectFound and
+// DefectType summarize Defects for readers of the original free-text columns.
+type WeavingQualityControl struct {
+	ID                 uint              `gorm:"primaryKey" json:"id"`
+	WeavingOrderID     uint              `json:"weaving_order_id"`
+	WeavingOperationID *uint             `json:"weaving_operation_id"`
+	QualityInspector   string            `gorm:"size:255" json:"quality_inspector"`
+	InspectedBy        *uint             `json:"inspected_by"`
+	QualityCheckTime   time.Time         `json:"quality_check_time"`
+	InspectedLength    float64           `json:"inspected_length"` // Meters
+	FabricWidth        float64           `json:"fabric_width"`     // Centimeters
+	TotalPoints        int               `json:"total_points"`
+	PointsPer100SqYd   float64           `gorm:"column:points_per_100_sq_yd" json:"points_per_100_sq_yd"`
+	MaxPoints          float64           `json:"max_points"` // Highest points per 100 square yards that pass
+	DefectFound        bool              `json:"defect_found"`
+	DefectType         string            `gorm:"size:255" json:"defect_type"`
+	CorrectiveAction   string            `gorm:"type:text" json:"corrective_action"`
+	CorrectedAt        *time.Time        `json:"corrected_at"`
+	CorrectedBy        *uint             `json:"corrected_by"`
+	Status             string            `gorm:"size:50;default:passed" json:"status"`
+	Notes              string            `gorm:"type:text" json:"notes"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+	WeavingOrder       WeavingOrder      `gorm:"foreignKey:WeavingOrderID" json:"weaving_order,omitempty"`
+	WeavingOperation   *WeavingOperation `gorm:"foreignKey:WeavingOperationID" json:"weaving_operation,omitempty"`
+	Defects            []WeavingQCDefect `gorm:"foreignKey:WeavingQualityControlID" json:"defects,omitempty"`
+}
+
+func (WeavingQualityControl) TableName() string {
+	return "weaving_quality_control"
+}
+
+// WeavingQCDefect is a defect found by an inspection, scored 1 to 4 points
+type WeavingQCDefect struct {
+	ID                      uint       `gorm:"primaryKey" json:"id"`
+	WeavingQualityControlID uint       `json:"weaving_quality_control_id"`
+	DefectTypeID            uint       `json:"defect_type_id"`
+	Position                *float64   `json:"position"` // Meter mark from the start of the inspected length
+	Length                  float64    `json:"length"`   // Inches
+	Points                  int        `json:"points"`
+	Notes                   string     `gorm:"type:text" json:"notes"`
+	CreatedAt               time.Time  `json:"created_at"`
+	DefectType              DefectType `gorm:"foreignKey:DefectTypeID" json:"defect_type,omitempty"`
+}
+
+func (WeavingQCDefect) TableName() string {
+	return "weaving_qc_defects"
+}
@@ -0,0 +1,93 @@
+// File: internal/domain/models/yarn.go
+// Tạo tại: internal/domain/models/yarn.go
+// Mục đích: Model thùng sợi, sổ nhập/xuất sợi và phân bổ sợi cho đơn (yarn_boxes, yarn_inventory_transactions, yarn_orders)
+// theo migration 000005 và định mức sợi theo sản phẩm (product_yarn_components) theo migration 000030
+
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Yarn box statuses. A box becomes depleted when its last cone and weight are issued,
+// and available again when yarn is received into it.
+const (
+	YarnBoxAvailable = "available"
+	YarnBoxAllocated = "allocated"
+	YarnBoxDepleted  = "depleted"
+)
+
+// Yarn allocation statuses
+const (
+	YarnAllocationAllocated = "allocated"
+	YarnAllocationIssued    = "issued" // Taken out of the box for weaving
+	YarnAllocationReleased  = "released"
+)
+
+// Yarn inventory transaction types
+const (
+	YarnTransactionIn  = "IN"
+	YarnTransactionOut = "OUT"
+)
+
+// YarnBox is a box of yarn cones kept in the warehouse
+type YarnBox struct {
+	ID                uint           `gorm:"primaryKey" json:"id"`
+	BoxCode           string         `gorm:"size:100;uniqueIndex" json:"box_code"`
+	YarnType          string         `gorm:"size:255" json:"yarn_type"`
+	ConeQuantity      int            `json:"cone_quantity"`
+	TotalWeight       float64        `json:"total_weight"` // Kilograms
+	WarehouseLocation string         `gorm:"size:255" json:"warehouse_location"`
+	Status            string         `gorm:"size:50;default:available" json:"status"`
+	ReceivedAt        *time.Time     `json:"received_at"` // Last receipt into an empty box; allocation takes the oldest first
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// YarnInventoryTransaction receives (IN) or issues (OUT) cones and weight of a box, keeping the
+// box balance after the movement
+type YarnInventoryTransaction struct {
+	ID                uint      `gorm:"primaryKey" json:"id"`
+	YarnBoxID         uint      `json:"yarn_box_id"`
+	TransactionType   string    `gorm:"size:50" json:"transaction_type"`
+	TransactionDate   time.Time `json:"transaction_date"`
+	Quantity          int       `json:"quantity"` // Cones
+	Weight            float64   `json:"weight"`
+	RemainingQuantity int       `json:"remaining_quantity"`
+	RemainingWeight   float64   `json:"remaining_weight"`
+	HandledBy         uint      `json:"handled_by"`
+	Notes             string    `gorm:"type:text" json:"notes"`
+	YarnBox           YarnBox   `gorm:"foreignKey:YarnBoxID" json:"yarn_box,omitempty"`
+	User              User      `gorm:"foreignKey:HandledBy" json:"user,omitempty"`
+}
+
+// ProductYarnComponent is a line of a product's bill of materials: the grams of a yarn type in one meter
+type ProductYarnComponent struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	ProductID     uint      `json:"product_id"`
+	YarnType      string    `gorm:"size:255" json:"yarn_type"`
+	GramsPerMeter float64   `json:"grams_per_meter"`
+	Notes         string    `gorm:"type:text" json:"notes"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// YarnOrder allocates cones and weight of a yarn box to an order
+type YarnOrder struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	OrderID       uint       `json:"order_id"`
+	YarnBoxID     uint       `json:"yarn_box_id"`
+	YarnType      string     `gorm:"size:255" json:"yarn_type"`
+	ConeQuantity  int        `json:"cone_quantity"`
+	TotalWeight   float64    `json:"total_weight"`
+	Status        string     `gorm:"size:50;default:allocated" json:"status"`
+	AllocatedBy   *uint      `json:"allocated_by"`
+	ReleasedAt    *time.Time `json:"released_at"`
+	ReleaseReason string     `gorm:"size:255" json:"release_reason"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	YarnBox       YarnBox    `gorm:"foreignKey:YarnBoxID" json:"yarn_box,omitempty"`
+}
@@ -18,7 +18,9 @@ type CategoryService interface {
 	GetCategoryByID(id uint) (*response.CategoryDetailResponse, error)
 	CreateCategory(req request.CreateCategoryRequest) (*response.CategoryDetailResponse, error)
 	UpdateCategory(id uint, req request.UpdateCategoryRequest) (*response.CategoryDetailResponse, error)
-	DeleteCategory(id uint) error
+	DeleteCategory(id uint, req request.DeleteCategoryRequest) (*response.CategoryDeleteResponse, error)
+	GetCategoryTree() (*response.CategoryTreeResponse, error)
+	MoveCategory(id uint, req request.MoveCategoryRequest) (*response.CategoryDetailResponse, error)
 }
 
 type categoryService struct {
@@ -43,8 +45,10 @@ func (s *categoryService) GetAllCategories() (*response.CategoriesResponse, erro
 		items[i] = response.CategoryResponse{
 			ID:               category.ID,
 			CategoryName:     category.CategoryName,
+			CategoryNameEN:   category.CategoryNameEN,
 			ParentCategoryID: category.ParentCategoryID,
 			Description:      category.Description,
+			DescriptionEN:    category.DescriptionEN,
 			CreatedAt:        category.CreatedAt,
 			UpdatedAt:        category.UpdatedAt,
 		}
@@ -62,7 +66,9 @@ func (s *categoryService) GetCategoryByID(id uint) (*response.CategoryDetailResp
 		return nil, errors.New("category not found")
 	}
 
-	return convertCategoryToDetailResponse(category), nil
+	detail := convertCategoryToDetailResponse(category)
+	detail.Breadcrumb = s.categoryBreadcrumb(id)
+	return detail, nil
 }
 
 func (s *categoryService) CreateCategory(req request.CreateCategoryRequest) (*response.CategoryDetailResponse, error) {
@@ -77,8 +83,10 @@ func (s *categoryService) CreateCategory(req request.CreateCategoryRequest) (*re
 	// Create category
 	category := &models.ProductCategory{
 		CategoryName:     req.CategoryName,
+		CategoryNameEN:   req.CategoryNameEN,
 		ParentCategoryID: req.ParentCategoryID,
 		Description:      req.Description,
+		DescriptionEN:    req.DescriptionEN,
 	}
 
 	if err := s.categoryRepo.Create(category); err != nil {
@@ -103,14 +111,13 @@ func (s *categoryService) UpdateCategory(id uint, req request.UpdateCategoryRequ
 
 	// Validate parent category if provided
 	if req.ParentCategoryID != nil {
-		// Check for circular reference
-		if *req.ParentCategoryID == id {
-			return nil, errors.New("category cannot be its own parent")
-		}
-
-		_, err := s.categoryRepo.FindByID(*req.ParentCategoryID)
+		// Check for circular reference at any depth
+		categories, err := s.categoryRepo.FindAll()
 		if err != nil {
-			return nil, errors.New("parent category not found")
+			return nil, err
+		}
+		if err := newCategoryIndex(categories).checkParent(id, req.ParentCategoryID); err != nil {
+			return nil, err
 		}
 	}
 
@@ -118,12 +125,18 @@ func (s *categoryService) UpdateCategory(id uint, req request.UpdateCategoryRequ
 	if req.CategoryName != "" {
 		category.CategoryName = req.CategoryName
 	}
+	if req.CategoryNameEN != "" {
+		category.CategoryNameEN = req.CategoryNameEN
+	}
 	if req.ParentCategoryID != nil {
 		category.ParentCategoryID = req.ParentCategoryID
 	}
 	if req.Description != "" {
 		category.Description = req.Description
 	}
+	if req.DescriptionEN != "" {
+		category.DescriptionEN = req.DescriptionEN
+	}
 
 	// Update category
 	if err := s.categoryRepo.Update(category); err != nil {
@@ -139,26 +152,15 @@ func (s *categoryService) UpdateCategory(id uint, req request.UpdateCategoryRequ
 	return convertCategoryToDetailResponse(updatedCategory), nil
 }
 
-func (s *categoryService) DeleteCategory(id uint) error {
-	// Check if category exists
-	_, err := s.categoryRepo.FindByID(id)
-	if err != nil {
-		return errors.New("category not found")
-	}
-
-	// TODO: Check if category has products or samples before deleting
-	// This would require additional repository methods
-
-	return s.categoryRepo.Delete(id)
-}
-
 // Helper function to convert model to response DTO
 func convertCategoryToDetailResponse(category *models.ProductCategory) *response.CategoryDetailResponse {
 	return &response.CategoryDetailResponse{
 		ID:               category.ID,
 		CategoryName:     category.CategoryName,
+		CategoryNameEN:   category.CategoryNameEN,
 		ParentCategoryID: category.ParentCategoryID,
 		Description:      category.Description,
+		DescriptionEN:    category.DescriptionEN,
 		CreatedAt:        category.CreatedAt,
 		UpdatedAt:        category.UpdatedAt,
 	}
@@ -0,0 +1,126 @@
+// File: internal/domain/services/category_delete.go
+// Tạo tại: internal/domain/services/category_delete.go
+// Mục đích: Xóa danh mục an toàn với các chiến lược block/reassign/cascade và báo cáo ảnh hưởng (dry run)
+
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+// ErrCategoryInUse is returned when a blocked delete finds sub-categories, products or samples
+var ErrCategoryInUse = errors.New("category is in use")
+
+const (
+	deleteStrategyBlock    = "block"
+	deleteStrategyReassign = "reassign"
+	deleteStrategyCascade  = "cascade"
+)
+
+// DeleteCategory deletes a category according to req.Strategy. The response always carries the
+// impact of every strategy so clients can preview them with dry_run before choosing one.
+func (s *categoryService) DeleteCategory(id uint, req request.DeleteCategoryRequest) (*response.CategoryDeleteResponse, error) {
+	strategy := req.Strategy
+	if strategy == "" {
+		strategy = deleteStrategyBlock
+	}
+
+	categories, err := s.categoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	index := newCategoryIndex(categories)
+	if index.byID[id] == nil {
+		return nil, errors.New("category not found")
+	}
+
+	productCounts, err := s.categoryRepo.CountProductsByCategory()
+	if err != nil {
+		return nil, err
+	}
+	sampleCounts, err := s.categoryRepo.CountSamplesByCategory()
+	if err != nil {
+		return nil, err
+	}
+
+	// Impact of each strategy
+	subtree := categoryDescendantIDs(categories, []uint{id})
+	cascade := response.CategoryDeleteImpact{Categories: int64(len(subtree))}
+	for _, categoryID := range subtree {
+		cascade.Products += productCounts[categoryID]
+		cascade.Samples += sampleCounts[categoryID]
+	}
+	reassign := response.CategoryDeleteImpact{
+		Categories:      1,
+		ChildCategories: int64(len(index.children[id])),
+		Products:        productCounts[id],
+		Samples:         sampleCounts[id],
+	}
+
+	resp := &response.CategoryDeleteResponse{
+		CategoryID:       id,
+		Strategy:         strategy,
+		TargetCategoryID: req.TargetCategoryID,
+		DryRun:           req.DryRun,
+		Strategies: map[string]response.CategoryDeleteImpact{
+			deleteStrategyBlock:    {Categories: 1},
+			deleteStrategyReassign: reassign,
+			deleteStrategyCascade:  cascade,
+		},
+	}
+
+	switch strategy {
+	case deleteStrategyBlock:
+		if reassign.ChildCategories > 0 || reassign.Products > 0 || reassign.Samples > 0 {
+			return resp, fmt.Errorf("%w: it has %d sub-categories, %d products and %d samples; use strategy=reassign or strategy=cascade",
+				ErrCategoryInUse, reassign.ChildCategories, reassign.Products, reassign.Samples)
+		}
+	case deleteStrategyReassign:
+		if req.TargetCategoryID == nil {
+			return nil, errors.New("target_category_id is required for strategy=reassign")
+		}
+		if index.byID[*req.TargetCategoryID] == nil {
+			return nil, errors.New("target category not found")
+		}
+		for _, categoryID := range subtree {
+			if categoryID == *req.TargetCategoryID {
+				return nil, errors.New("target category cannot be the deleted category or one of its sub-categories")
+			}
+		}
+	case deleteStrategyCascade:
+	default:
+		return nil, fmt.Errorf("unsupported delete strategy %q", strategy)
+	}
+
+	resp.Affected = resp.Strategies[strategy]
+	if req.DryRun {
+		return resp, nil
+	}
+
+	var result *interfaces.CategoryDeleteResult
+	switch strategy {
+	case deleteStrategyReassign:
+		result, err = s.categoryRepo.DeleteAndReassign(id, *req.TargetCategoryID)
+	case deleteStrategyCascade:
+		result, err = s.categoryRepo.DeleteCascade(subtree)
+	default:
+		result, err = &interfaces.CategoryDeleteResult{Categories: 1}, s.categoryRepo.Delete(id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Deleted = true
+	resp.Affected = response.CategoryDeleteImpact{
+		Categories:      result.Categories,
+		ChildCategories: result.ChildCategories,
+		Products:        result.Products,
+		Samples:         result.Samples,
+	}
+	return resp, nil
+}
@@ -0,0 +1,220 @@
+// File: internal/domain/services/category_tree.go
+// Tạo tại: internal/domain/services/category_tree.go
+// Mục đích: Cây danh mục lồng nhau (số lượng sản phẩm/mẫu, breadcrumb) và di chuyển danh mục có kiểm tra vòng lặp
+
+package services
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+)
+
+const categoryBreadcrumbSeparator = " > "
+
+// categoryIndex looks up categories by ID and parent
+type categoryIndex struct {
+	byID     map[uint]*models.ProductCategory
+	children map[uint][]*models.ProductCategory
+	roots    []*models.ProductCategory
+}
+
+func newCategoryIndex(categories []models.ProductCategory) *categoryIndex {
+	index := &categoryIndex{
+		byID:     make(map[uint]*models.ProductCategory, len(categories)),
+		children: make(map[uint][]*models.ProductCategory),
+	}
+	for i := range categories {
+		index.byID[categories[i].ID] = &categories[i]
+	}
+	for i := range categories {
+		category := &categories[i]
+		if category.ParentCategoryID != nil && index.byID[*category.ParentCategoryID] != nil {
+			index.children[*category.ParentCategoryID] = append(index.children[*category.ParentCategoryID], category)
+		} else {
+			index.roots = append(index.roots, category)
+		}
+	}
+
+	byName := func(list []*models.ProductCategory) {
+		sort.Slice(list, func(a, b int) bool {
+			if list[a].CategoryName != list[b].CategoryName {
+				return list[a].CategoryName < list[b].CategoryName
+			}
+			return list[a].ID < list[b].ID
+		})
+	}
+	byName(index.roots)
+	for _, list := range index.children {
+		byName(list)
+	}
+	return index
+}
+
+// path returns the categories from the root down to id. A cycle in stored data stops the walk.
+func (idx *categoryIndex) path(id uint) []*models.ProductCategory {
+	var path []*models.ProductCategory
+	seen := make(map[uint]bool)
+	for category := idx.byID[id]; category != nil && !seen[category.ID]; {
+		seen[category.ID] = true
+		path = append([]*models.ProductCategory{category}, path...)
+		if category.ParentCategoryID == nil {
+			break
+		}
+		category = idx.byID[*category.ParentCategoryID]
+	}
+	return path
+}
+
+func (idx *categoryIndex) breadcrumb(id uint) string {
+	path := idx.path(id)
+	names := make([]string, len(path))
+	for i, category := range path {
+		names[i] = category.CategoryName
+	}
+	return strings.Join(names, categoryBreadcrumbSeparator)
+}
+
+// checkParent verifies that making parentID the parent of id keeps the hierarchy acyclic,
+// walking up from the new parent through every ancestor
+func (idx *categoryIndex) checkParent(id uint, parentID *uint) error {
+	if parentID == nil {
+		return nil
+	}
+	if *parentID == id {
+		return errors.New("category cannot be its own parent")
+	}
+	if idx.byID[*parentID] == nil {
+		return errors.New("parent category not found")
+	}
+
+	seen := make(map[uint]bool)
+	for ancestor := idx.byID[*parentID]; ancestor != nil; {
+		if ancestor.ID == id {
+			return errors.New("cannot move a category under one of its own sub-categories")
+		}
+		if seen[ancestor.ID] {
+			return errors.New("category hierarchy already contains a cycle")
+		}
+		seen[ancestor.ID] = true
+		if ancestor.ParentCategoryID == nil {
+			break
+		}
+		ancestor = idx.byID[*ancestor.ParentCategoryID]
+	}
+	return nil
+}
+
+func (s *categoryService) GetCategoryTree() (*response.CategoryTreeResponse, error) {
+	categories, err := s.categoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	productCounts, err := s.categoryRepo.CountProductsByCategory()
+	if err != nil {
+		return nil, err
+	}
+	sampleCounts, err := s.categoryRepo.CountSamplesByCategory()
+	if err != nil {
+		return nil, err
+	}
+
+	index := newCategoryIndex(categories)
+	visited := make(map[uint]bool, len(categories))
+
+	var build func(category *models.ProductCategory, parentPath []response.CategoryPathItem) response.CategoryTreeNode
+	build = func(category *models.ProductCategory, parentPath []response.CategoryPathItem) response.CategoryTreeNode {
+		visited[category.ID] = true
+
+		path := append(append([]response.CategoryPathItem{}, parentPath...), response.CategoryPathItem{
+			ID:             category.ID,
+			CategoryName:   category.CategoryName,
+			CategoryNameEN: category.CategoryNameEN,
+		})
+		names := make([]string, len(path))
+		for i, item := range path {
+			names[i] = item.CategoryName
+		}
+
+		node := response.CategoryTreeNode{
+			ID:               category.ID,
+			CategoryName:     category.CategoryName,
+			CategoryNameEN:   category.CategoryNameEN,
+			ParentCategoryID: category.ParentCategoryID,
+			Description:      category.Description,
+			DescriptionEN:    category.DescriptionEN,
+			Depth:            len(path) - 1,
+			Path:             path,
+			Breadcrumb:       strings.Join(names, categoryBreadcrumbSeparator),
+			ProductCount:     productCounts[category.ID],
+			SampleCount:      sampleCounts[category.ID],
+			Children:         []response.CategoryTreeNode{},
+		}
+		node.TotalProductCount = node.ProductCount
+		node.TotalSampleCount = node.SampleCount
+
+		for _, child := range index.children[category.ID] {
+			if visited[child.ID] {
+				continue
+			}
+			childNode := build(child, path)
+			node.TotalProductCount += childNode.TotalProductCount
+			node.TotalSampleCount += childNode.TotalSampleCount
+			node.Children = append(node.Children, childNode)
+		}
+		return node
+	}
+
+	tree := []response.CategoryTreeNode{}
+	for _, root := range index.roots {
+		tree = append(tree, build(root, nil))
+	}
+
+	// Categories caught in a stored cycle are unreachable from any root; surface them as roots
+	for i := range categories {
+		if !visited[categories[i].ID] {
+			log.Printf("Warning: category %d is part of a parent cycle", categories[i].ID)
+			tree = append(tree, build(&categories[i], nil))
+		}
+	}
+
+	return &response.CategoryTreeResponse{
+		Tree:  tree,
+		Total: len(categories),
+	}, nil
+}
+
+func (s *categoryService) MoveCategory(id uint, req request.MoveCategoryRequest) (*response.CategoryDetailResponse, error) {
+	categories, err := s.categoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	index := newCategoryIndex(categories)
+
+	if index.byID[id] == nil {
+		return nil, errors.New("category not found")
+	}
+	if err := index.checkParent(id, req.ParentCategoryID); err != nil {
+		return nil, err
+	}
+
+	if err := s.categoryRepo.UpdateParent(id, req.ParentCategoryID); err != nil {
+		return nil, err
+	}
+
+	return s.GetCategoryByID(id)
+}
+
+// categoryBreadcrumb builds the breadcrumb of a single category
+func (s *categoryService) categoryBreadcrumb(id uint) string {
+	categories, err := s.categoryRepo.FindAll()
+	if err != nil {
+		return ""
+	}
+	return newCategoryIndex(categories).breadcrumb(id)
+}
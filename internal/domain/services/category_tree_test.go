@@ -0,0 +1,77 @@
+// File: internal/domain/services/category_tree_test.go
+// Tạo tại: internal/domain/services/category_tree_test.go
+// Mục đích: Kiểm thử phát hiện vòng lặp khi đổi danh mục cha và breadcrumb của cây danh mục
+
+package services
+
+import (
+	"testing"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+// testCategories: Vải > Dệt kim > Thun, a separate root Phụ liệu, and 5 and 6 stored as each other's parent
+func testCategories() []models.ProductCategory {
+	return []models.ProductCategory{
+		{ID: 1, CategoryName: "Vải"},
+		{ID: 2, CategoryName: "Dệt kim", ParentCategoryID: uintPtr(1)},
+		{ID: 3, CategoryName: "Thun", ParentCategoryID: uintPtr(2)},
+		{ID: 4, CategoryName: "Phụ liệu"},
+		{ID: 5, CategoryName: "Lỗi A", ParentCategoryID: uintPtr(6)},
+		{ID: 6, CategoryName: "Lỗi B", ParentCategoryID: uintPtr(5)},
+	}
+}
+
+func TestCategoryIndexCheckParent(t *testing.T) {
+	index := newCategoryIndex(testCategories())
+	tests := []struct {
+		name     string
+		id       uint
+		parentID *uint
+		wantErr  string
+	}{
+		{name: "move to the root", id: 3},
+		{name: "move under another branch", id: 2, parentID: uintPtr(4)},
+		{name: "move a leaf up", id: 3, parentID: uintPtr(1)},
+		{name: "own parent", id: 2, parentID: uintPtr(2), wantErr: "category cannot be its own parent"},
+		{name: "unknown parent", id: 2, parentID: uintPtr(99), wantErr: "parent category not found"},
+		{name: "under a child", id: 1, parentID: uintPtr(2),
+			wantErr: "cannot move a category under one of its own sub-categories"},
+		{name: "under a grandchild", id: 1, parentID: uintPtr(3),
+			wantErr: "cannot move a category under one of its own sub-categories"},
+		{name: "stored cycle above the parent", id: 4, parentID: uintPtr(5),
+			wantErr: "category hierarchy already contains a cycle"},
+	}
+	for _, tt := range tests {
+		err := index.checkParent(tt.id, tt.parentID)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCategoryIndexBreadcrumb(t *testing.T) {
+	index := newCategoryIndex(testCategories())
+	tests := map[uint]string{
+		3:  "Vải > Dệt kim > Thun",
+		4:  "Phụ liệu",
+		99: "",
+	}
+	for id, want := range tests {
+		if got := index.breadcrumb(id); got != want {
+			t.Errorf("breadcrumb(%d) = %q, want %q", id, got, want)
+		}
+	}
+
+	// A stored cycle stops the walk instead of looping
+	if got := index.breadcrumb(5); got != "Lỗi B > Lỗi A" {
+		t.Errorf("breadcrumb(5) = %q, want %q", got, "Lỗi B > Lỗi A")
+	}
+}
@@ -0,0 +1,407 @@
+// File: internal/domain/services/customer.go
+// Tạo tại: internal/domain/services/customer.go
+// Mục đích: Service quản lý khách hàng (CRUD, tìm kiếm, phân trang, sinh mã khách hàng duy nhất)
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+const (
+	customerCodePrefix = "KH"
+	customerCodeDigits = 5
+
+	// customerCodeAttempts bounds retries when a concurrent create takes the generated code
+	customerCodeAttempts = 5
+
+	// customerRecentLimit is the number of linked records shown on the customer detail
+	customerRecentLimit = 10
+)
+
+// ErrCustomerNotFound is returned when a customer ID does not exist
+var ErrCustomerNotFound = errors.New("customer not found")
+
+type CustomerService interface {
+	GetCustomers(req request.CustomerFilterRequest) (*response.PaginatedResponse, error)
+	GetCustomersByCursor(req request.CustomerFilterRequest) (*response.CursorPaginatedResponse, error)
+	GetCustomerByID(id uint) (*response.CustomerDetailResponse, error)
+	CreateCustomer(req request.CreateCustomerRequest) (*response.CustomerDetailResponse, error)
+	UpdateCustomer(id uint, req request.UpdateCustomerRequest) (*response.CustomerDetailResponse, error)
+	DeleteCustomer(id uint) error
+}
+
+type customerService struct {
+	customerRepo interfaces.CustomerRepository
+}
+
+func NewCustomerService(customerRepo interfaces.CustomerRepository) CustomerService {
+	return &customerService{
+		customerRepo: customerRepo,
+	}
+}
+
+// customerSortFields lists the fields customers can be sorted by in offset mode
+var customerSortFields = map[string]bool{
+	"id":             true,
+	"customer_code":  true,
+	"name":           true,
+	"company_name":   true,
+	"account_status": true,
+	"created_at":     true,
+}
+
+// customerCursorSortFields lists the indexed fields customers can be paged by with a cursor
+var customerCursorSortFields = map[string]bool{
+	"id":            true,
+	"customer_code": true,
+	"name":          true,
+	"created_at":    true,
+}
+
+func buildCustomerQuery(req request.CustomerFilterRequest, sortFields map[string]bool) (interfaces.QuerySpec, error) {
+	spec := interfaces.QuerySpec{Search: strings.TrimSpace(req.Search)}
+	if req.AccountStatus != "" {
+		spec.Filters = append(spec.Filters, interfaces.Filter{Field: "account_status", Op: interfaces.FilterEq, Value: req.AccountStatus})
+	}
+
+	sort, err := parseSort(req.SortBy, req.SortOrder, sortFields)
+	if err != nil {
+		return spec, err
+	}
+	spec.Sort = sort
+	return spec, nil
+}
+
+func (s *customerService) GetCustomers(req request.CustomerFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	spec, err := buildCustomerQuery(req, customerSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	customers, total, err := s.customerRepo.FindAll(req.Page, req.Limit, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(customers))
+	for i := range customers {
+		items[i] = convertCustomerToResponse(&customers[i])
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(req.Limit)))
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *customerService) GetCustomersByCursor(req request.CustomerFilterRequest) (*response.CursorPaginatedResponse, error) {
+	limit := cursorPageLimit(req.Limit)
+
+	spec, err := buildCustomerQuery(req, customerCursorSortFields)
+	if err != nil {
+		return nil, err
+	}
+	after, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := cursorSort(spec.Sort, interfaces.SortField{Field: "created_at", Desc: true}, after)
+	if err != nil {
+		return nil, err
+	}
+	spec.Sort = []interfaces.SortField{sort}
+
+	customers, next, err := s.customerRepo.FindPage(spec, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, estimated, err := pageTotal(s.customerRepo, spec, req.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(customers))
+	for i := range customers {
+		items[i] = convertCustomerToResponse(&customers[i])
+	}
+
+	return &response.CursorPaginatedResponse{
+		Items:          items,
+		Limit:          limit,
+		NextCursor:     encodeCursor(next),
+		HasMore:        next != nil,
+		TotalItems:     total,
+		TotalEstimated: estimated,
+	}, nil
+}
+
+func (s *customerService) GetCustomerByID(id uint) (*response.CustomerDetailResponse, error) {
+	customer, err := s.customerRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrCustomerNotFound
+	}
+
+	return s.customerDetail(customer)
+}
+
+func (s *customerService) CreateCustomer(req request.CreateCustomerRequest) (*response.CustomerDetailResponse, error) {
+	customer := &models.Customer{
+		CustomerCode:  strings.TrimSpace(req.CustomerCode),
+		Name:          req.Name,
+		Email:         req.Email,
+		Phone:         req.Phone,
+		Address:       req.Address,
+		CompanyName:   req.CompanyName,
+		TaxID:         req.TaxID,
+		AccountStatus: req.AccountStatus,
+		PriceTier:     strings.TrimSpace(req.PriceTier),
+	}
+	if customer.AccountStatus == "" {
+		customer.AccountStatus = models.CustomerStatusActive
+	}
+	customer.Currency = models.DefaultCurrency
+	if req.Currency != "" {
+		currency, err := money.NormalizeCurrency(req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		customer.Currency = currency
+	}
+
+	// Explicit code: must be unused
+	if customer.CustomerCode != "" {
+		if existing, _ := s.customerRepo.FindByCode(customer.CustomerCode); existing != nil {
+			return nil, errors.New("customer code already exists")
+		}
+		if err := s.customerRepo.Create(customer); err != nil {
+			return nil, err
+		}
+		return s.customerDetail(customer)
+	}
+
+	// Generated code: retry with the next number when a concurrent create took it
+	for attempt := 0; attempt < customerCodeAttempts; attempt++ {
+		code, err := s.nextCustomerCode()
+		if err != nil {
+			return nil, err
+		}
+		customer.ID = 0
+		customer.CustomerCode = code
+
+		err = s.customerRepo.Create(customer)
+		if err == nil {
+			return s.customerDetail(customer)
+		}
+		if existing, _ := s.customerRepo.FindByCode(code); existing == nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("could not generate a unique customer code")
+}
+
+func (s *customerService) UpdateCustomer(id uint, req request.UpdateCustomerRequest) (*response.CustomerDetailResponse, error) {
+	customer, err := s.customerRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrCustomerNotFound
+	}
+
+	// Check if code is being changed and already exists
+	code := strings.TrimSpace(req.CustomerCode)
+	if code != "" && code != customer.CustomerCode {
+		if existing, _ := s.customerRepo.FindByCode(code); existing != nil {
+			return nil, errors.New("customer code already exists")
+		}
+		customer.CustomerCode = code
+	}
+
+	// Update fields if provided
+	if req.Name != "" {
+		customer.Name = req.Name
+	}
+	if req.Email != nil {
+		customer.Email = *req.Email
+	}
+	if req.Phone != nil {
+		customer.Phone = *req.Phone
+	}
+	if req.Address != nil {
+		customer.Address = *req.Address
+	}
+	if req.CompanyName != nil {
+		customer.CompanyName = *req.CompanyName
+	}
+	if req.TaxID != nil {
+		customer.TaxID = *req.TaxID
+	}
+	if req.AccountStatus != "" {
+		customer.AccountStatus = req.AccountStatus
+	}
+	if req.PriceTier != nil {
+		customer.PriceTier = strings.TrimSpace(*req.PriceTier)
+	}
+	if req.Currency != "" {
+		currency, err := money.NormalizeCurrency(req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		customer.Currency = currency
+	}
+
+	if err := s.customerRepo.Update(customer); err != nil {
+		return nil, err
+	}
+
+	return s.customerDetail(customer)
+}
+
+func (s *customerService) DeleteCustomer(id uint) error {
+	// Check if customer exists
+	if _, err := s.customerRepo.FindByID(id); err != nil {
+		return ErrCustomerNotFound
+	}
+
+	// Customers with orders stay for the sales history; deactivate them instead
+	counts, err := s.customerRepo.CountLinks(id)
+	if err != nil {
+		return err
+	}
+	if counts.Orders > 0 {
+		return errors.New("customer has orders; set account_status to inactive instead")
+	}
+
+	return s.customerRepo.Delete(id)
+}
+
+// nextCustomerCode returns the code after the highest generated one, e.g. KH00042 → KH00043
+func (s *customerService) nextCustomerCode() (string, error) {
+	last, err := s.customerRepo.LastCodeWithPrefix(customerCodePrefix)
+	if err != nil {
+		return "", err
+	}
+
+	next := 1
+	if last != "" {
+		number, err := strconv.Atoi(strings.TrimPrefix(last, customerCodePrefix))
+		if err != nil {
+			return "", err
+		}
+		next = number + 1
+	}
+	return fmt.Sprintf("%s%0*d", customerCodePrefix, customerCodeDigits, next), nil
+}
+
+// customerDetail adds the link summary and the latest orders, sample dispatches and lap dip tests
+func (s *customerService) customerDetail(customer *models.Customer) (*response.CustomerDetailResponse, error) {
+	counts, err := s.customerRepo.CountLinks(customer.ID)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := s.customerRepo.FindRecentOrders(customer.ID, customerRecentLimit)
+	if err != nil {
+		return nil, err
+	}
+	dispatches, err := s.customerRepo.FindRecentSampleDispatches(customer.ID, customerRecentLimit)
+	if err != nil {
+		return nil, err
+	}
+	lapDips, err := s.customerRepo.FindRecentLapDipTests(customer.ID, customerRecentLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &response.CustomerDetailResponse{
+		CustomerResponse: *convertCustomerToResponse(customer),
+		Summary: response.CustomerSummary{
+			OrderCount:          counts.Orders,
+			OrderTotals:         counts.OrderTotals,
+			SampleDispatchCount: counts.SampleDispatches,
+			LapDipTestCount:     counts.LapDipTests,
+		},
+		RecentOrders:           make([]response.CustomerOrderSummary, len(orders)),
+		RecentSampleDispatches: make([]response.CustomerSampleDispatchSummary, len(dispatches)),
+		RecentLapDipTests:      make([]response.CustomerLapDipSummary, len(lapDips)),
+	}
+
+	for i, order := range orders {
+		detail.RecentOrders[i] = response.CustomerOrderSummary{
+			ID:          order.ID,
+			OrderCode:   order.OrderCode,
+			Status:      order.OrderStatus.StatusName,
+			TotalAmount: order.TotalAmount,
+			Currency:    order.Currency,
+			DueDate:     order.DueDate,
+			CreatedAt:   order.CreatedAt,
+		}
+	}
+	for i, dispatch := range dispatches {
+		detail.RecentSampleDispatches[i] = response.CustomerSampleDispatchSummary{
+			ID:               dispatch.ID,
+			SampleProductID:  dispatch.SampleProductID,
+			SampleSKU:        dispatch.SampleProduct.SKU,
+			DispatchDate:     dispatch.DispatchDate,
+			DispatchQuantity: dispatch.DispatchQuantity,
+			DispatchWeight:   dispatch.DispatchWeight,
+			DispatchColor:    dispatch.DispatchColor,
+			LotNumber:        dispatch.LotNumber,
+			TrackingNumber:   dispatch.TrackingNumber,
+		}
+	}
+	for i, test := range lapDips {
+		detail.RecentLapDipTests[i] = response.CustomerLapDipSummary{
+			ID:             test.ID,
+			LDCode:         test.LDCode,
+			ColorName:      test.ColorName,
+			ColorCode:      test.ColorCode,
+			Status:         test.Status,
+			SelectedLDCode: test.SelectedLDCode,
+			TestStartDate:  test.TestStartDate,
+			TestEndDate:    test.TestEndDate,
+		}
+	}
+
+	return detail, nil
+}
+
+// Helper function to convert model to response DTO
+func convertCustomerToResponse(customer *models.Customer) *response.CustomerResponse {
+	return &response.CustomerResponse{
+		ID:            customer.ID,
+		CustomerCode:  customer.CustomerCode,
+		Name:          customer.Name,
+		Email:         customer.Email,
+		Phone:         customer.Phone,
+		Address:       customer.Address,
+		CompanyName:   customer.CompanyName,
+		TaxID:         customer.TaxID,
+		AccountStatus: customer.AccountStatus,
+		PriceTier:     customer.PriceTier,
+		Currency:      customer.Currency,
+		CreatedAt:     customer.CreatedAt,
+		UpdatedAt:     customer.UpdatedAt,
+	}
+}
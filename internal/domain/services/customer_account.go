@@ -0,0 +1,139 @@
+// File: internal/domain/services/customer_account.go
+// Tạo tại: internal/domain/services/customer_account.go
+// Mục đích: Service cho nhân viên quản lý tài khoản đăng nhập portal của khách hàng
+
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrCustomerAccountNotFound = errors.New("portal account not found")
+
+type CustomerAccountService interface {
+	GetAccounts(customerID uint) ([]response.CustomerAccountResponse, error)
+	CreateAccount(customerID uint, req request.CreateCustomerAccountRequest) (*response.CustomerAccountResponse, error)
+	UpdateAccount(customerID, accountID uint, req request.UpdateCustomerAccountRequest) (*response.CustomerAccountResponse, error)
+	DeleteAccount(customerID, accountID uint) error
+}
+
+type customerAccountService struct {
+	accountRepo  interfaces.CustomerAccountRepository
+	customerRepo interfaces.CustomerRepository
+}
+
+func NewCustomerAccountService(accountRepo interfaces.CustomerAccountRepository, customerRepo interfaces.CustomerRepository) CustomerAccountService {
+	return &customerAccountService{
+		accountRepo:  accountRepo,
+		customerRepo: customerRepo,
+	}
+}
+
+func (s *customerAccountService) GetAccounts(customerID uint) ([]response.CustomerAccountResponse, error) {
+	if _, err := s.customerRepo.FindByID(customerID); err != nil {
+		return nil, ErrCustomerNotFound
+	}
+
+	accounts, err := s.accountRepo.FindByCustomer(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.CustomerAccountResponse, len(accounts))
+	for i := range accounts {
+		res[i] = *convertCustomerAccountToResponse(&accounts[i])
+	}
+	return res, nil
+}
+
+func (s *customerAccountService) CreateAccount(customerID uint, req request.CreateCustomerAccountRequest) (*response.CustomerAccountResponse, error) {
+	if _, err := s.customerRepo.FindByID(customerID); err != nil {
+		return nil, ErrCustomerNotFound
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if _, err := s.accountRepo.FindByEmail(email); err == nil {
+		return nil, errors.New("email already in use")
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &models.CustomerAccount{
+		CustomerID:   customerID,
+		Email:        email,
+		PasswordHash: string(passwordHash),
+		FullName:     strings.TrimSpace(req.FullName),
+		Status:       models.CustomerAccountActive,
+	}
+	if err := s.accountRepo.Create(account); err != nil {
+		return nil, err
+	}
+
+	return convertCustomerAccountToResponse(account), nil
+}
+
+func (s *customerAccountService) UpdateAccount(customerID, accountID uint, req request.UpdateCustomerAccountRequest) (*response.CustomerAccountResponse, error) {
+	account, err := s.customerAccount(customerID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FullName != nil {
+		account.FullName = strings.TrimSpace(*req.FullName)
+	}
+	if req.Status != "" {
+		account.Status = req.Status
+	}
+	if req.Password != "" {
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		account.PasswordHash = string(passwordHash)
+	}
+
+	if err := s.accountRepo.Update(account); err != nil {
+		return nil, err
+	}
+
+	return convertCustomerAccountToResponse(account), nil
+}
+
+func (s *customerAccountService) DeleteAccount(customerID, accountID uint) error {
+	if _, err := s.customerAccount(customerID, accountID); err != nil {
+		return err
+	}
+	return s.accountRepo.Delete(accountID)
+}
+
+// customerAccount loads an account and checks it belongs to the customer in the URL
+func (s *customerAccountService) customerAccount(customerID, accountID uint) (*models.CustomerAccount, error) {
+	account, err := s.accountRepo.FindByID(accountID)
+	if err != nil || account.CustomerID != customerID {
+		return nil, ErrCustomerAccountNotFound
+	}
+	return account, nil
+}
+
+func convertCustomerAccountToResponse(account *models.CustomerAccount) *response.CustomerAccountResponse {
+	return &response.CustomerAccountResponse{
+		ID:         account.ID,
+		CustomerID: account.CustomerID,
+		Email:      account.Email,
+		FullName:   account.FullName,
+		Status:     account.Status,
+		LastLogin:  account.LastLogin,
+		CreatedAt:  account.CreatedAt,
+		UpdatedAt:  account.UpdatedAt,
+	}
+}
@@ -0,0 +1,213 @@
+// File: internal/domain/services/customer_activity.go
+// Tạo tại: internal/domain/services/customer_activity.go
+// Mục đích: Ghi nhật ký hoạt động khách hàng (nhận mẫu, duyệt lap dip, đặt/hủy đơn, trả hàng) và trả về timeline
+
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+// maxDeviceInfoLength is the size of customer_activity_log.device_info
+const maxDeviceInfoLength = 255
+
+// CustomerActivityService records customer activity and serves the customer timeline.
+// Services that dispatch samples, approve lap dips and place or cancel orders call Record after
+// their change is saved.
+type CustomerActivityService interface {
+	Record(customerID uint, activityType string, details map[string]interface{}, client request.ClientInfo)
+	GetActivity(customerID uint, req request.CustomerActivityFilterRequest) (*response.PaginatedResponse, error)
+	GetActivityByCursor(customerID uint, req request.CustomerActivityFilterRequest) (*response.CursorPaginatedResponse, error)
+}
+
+type customerActivityService struct {
+	activityRepo interfaces.CustomerActivityRepository
+	customerRepo interfaces.CustomerRepository
+}
+
+func NewCustomerActivityService(
+	activityRepo interfaces.CustomerActivityRepository,
+	customerRepo interfaces.CustomerRepository,
+) CustomerActivityService {
+	return &customerActivityService{
+		activityRepo: activityRepo,
+		customerRepo: customerRepo,
+	}
+}
+
+// Record writes an activity entry. The activity log is secondary to the change being
+// recorded, so a failed write is logged rather than returned to the caller.
+func (s *customerActivityService) Record(customerID uint, activityType string, details map[string]interface{}, client request.ClientInfo) {
+	activity := &models.CustomerActivity{
+		CustomerID:   customerID,
+		ActivityType: activityType,
+		ActivityDate: time.Now(),
+		IPAddress:    client.IPAddress,
+		DeviceInfo:   truncate(client.DeviceInfo, maxDeviceInfoLength),
+	}
+	if len(details) > 0 {
+		encoded, err := json.Marshal(details)
+		if err != nil {
+			log.Printf("Error encoding %s activity for customer %d: %v", activityType, customerID, err)
+		} else {
+			activity.ActivityDetails = string(encoded)
+		}
+	}
+
+	if err := s.activityRepo.Create(activity); err != nil {
+		log.Printf("Error recording %s activity for customer %d: %v", activityType, customerID, err)
+	}
+}
+
+func (s *customerActivityService) GetActivity(customerID uint, req request.CustomerActivityFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	spec, err := s.buildActivityQuery(customerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	activities, total, err := s.activityRepo.FindAll(req.Page, req.Limit, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(activities))
+	for i := range activities {
+		items[i] = convertActivityToResponse(&activities[i])
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(req.Limit)))
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *customerActivityService) GetActivityByCursor(customerID uint, req request.CustomerActivityFilterRequest) (*response.CursorPaginatedResponse, error) {
+	limit := cursorPageLimit(req.Limit)
+
+	spec, err := s.buildActivityQuery(customerID, req)
+	if err != nil {
+		return nil, err
+	}
+	after, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := cursorSort(spec.Sort, interfaces.SortField{Field: "activity_date", Desc: true}, after)
+	if err != nil {
+		return nil, err
+	}
+	spec.Sort = []interfaces.SortField{sort}
+
+	activities, next, err := s.activityRepo.FindPage(spec, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, estimated, err := pageTotal(s.activityRepo, spec, req.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(activities))
+	for i := range activities {
+		items[i] = convertActivityToResponse(&activities[i])
+	}
+
+	return &response.CursorPaginatedResponse{
+		Items:          items,
+		Limit:          limit,
+		NextCursor:     encodeCursor(next),
+		HasMore:        next != nil,
+		TotalItems:     total,
+		TotalEstimated: estimated,
+	}, nil
+}
+
+// buildActivityQuery checks the customer exists and turns the filter request into a query spec
+func (s *customerActivityService) buildActivityQuery(customerID uint, req request.CustomerActivityFilterRequest) (interfaces.QuerySpec, error) {
+	spec := interfaces.QuerySpec{}
+	if _, err := s.customerRepo.FindByID(customerID); err != nil {
+		return spec, ErrCustomerNotFound
+	}
+
+	add := func(field string, op interfaces.FilterOp, value interface{}) {
+		spec.Filters = append(spec.Filters, interfaces.Filter{Field: field, Op: op, Value: value})
+	}
+	add("customer_id", interfaces.FilterEq, customerID)
+
+	if types := splitValues(req.ActivityType); len(types) > 0 {
+		for _, activityType := range types {
+			if !isCustomerActivityType(activityType) {
+				return spec, fmt.Errorf("%w: unsupported activity type %q", ErrInvalidQuery, activityType)
+			}
+		}
+		add("activity_type", interfaces.FilterIn, types)
+	}
+	addDateRange(add, "activity_date", req.DateFrom, req.DateTo)
+
+	spec.Sort = []interfaces.SortField{{Field: "activity_date", Desc: req.SortOrder != "asc"}}
+	return spec, nil
+}
+
+func isCustomerActivityType(activityType string) bool {
+	for _, known := range models.CustomerActivityTypes {
+		if activityType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && s[n]&0xC0 == 0x80 {
+		n--
+	}
+	return s[:n]
+}
+
+// Helper function to convert model to response DTO
+func convertActivityToResponse(activity *models.CustomerActivity) *response.CustomerActivityResponse {
+	res := &response.CustomerActivityResponse{
+		ID:           activity.ID,
+		CustomerID:   activity.CustomerID,
+		ActivityType: activity.ActivityType,
+		ActivityDate: activity.ActivityDate,
+		IPAddress:    activity.IPAddress,
+		DeviceInfo:   activity.DeviceInfo,
+	}
+	if activity.ActivityDetails != "" {
+		var details map[string]interface{}
+		if err := json.Unmarshal([]byte(activity.ActivityDetails), &details); err == nil {
+			res.Details = details
+		} else {
+			res.Details = activity.ActivityDetails
+		}
+	}
+	return res
+}
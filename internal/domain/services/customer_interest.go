@@ -0,0 +1,347 @@
+// File: internal/domain/services/customer_interest.go
+// Tạo tại: internal/domain/services/customer_interest.go
+// Mục đích: Service danh sách sản phẩm/mẫu khách hàng lưu, lịch sử tìm kiếm và báo cáo từ khóa/sản phẩm được quan tâm
+
+package services
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/textsearch"
+)
+
+const (
+	// maxNormalizedQueryLength is the size of customer_search_history.normalized_query
+	maxNormalizedQueryLength = 255
+
+	// interestReportDays is the default report period ending today
+	interestReportDays  = 30
+	interestReportLimit = 10
+)
+
+type CustomerInterestService interface {
+	GetSavedItems(customerID uint, req request.SavedItemFilterRequest) (*response.PaginatedResponse, error)
+	SaveItem(customerID uint, req request.CreateSavedItemRequest) (*response.SavedItemResponse, error)
+	UpdateSavedItem(customerID, itemID uint, req request.UpdateSavedItemRequest) (*response.SavedItemResponse, error)
+	RemoveSavedItem(customerID, itemID uint) error
+	GetSearchHistory(customerID uint, req request.SearchHistoryFilterRequest) (*response.PaginatedResponse, error)
+	RecordSearch(customerID uint, req request.RecordSearchRequest) (*response.SearchHistoryResponse, error)
+	GetInterestReport(req request.CustomerInterestReportRequest) (*response.CustomerInterestReportResponse, error)
+}
+
+type customerInterestService struct {
+	savedItemRepo     interfaces.CustomerSavedItemRepository
+	searchHistoryRepo interfaces.CustomerSearchHistoryRepository
+	customerRepo      interfaces.CustomerRepository
+	productRepo       interfaces.ProductRepository
+	sampleRepo        interfaces.SampleRepository
+}
+
+func NewCustomerInterestService(
+	savedItemRepo interfaces.CustomerSavedItemRepository,
+	searchHistoryRepo interfaces.CustomerSearchHistoryRepository,
+	customerRepo interfaces.CustomerRepository,
+	productRepo interfaces.ProductRepository,
+	sampleRepo interfaces.SampleRepository,
+) CustomerInterestService {
+	return &customerInterestService{
+		savedItemRepo:     savedItemRepo,
+		searchHistoryRepo: searchHistoryRepo,
+		customerRepo:      customerRepo,
+		productRepo:       productRepo,
+		sampleRepo:        sampleRepo,
+	}
+}
+
+func (s *customerInterestService) GetSavedItems(customerID uint, req request.SavedItemFilterRequest) (*response.PaginatedResponse, error) {
+	if _, err := s.customerRepo.FindByID(customerID); err != nil {
+		return nil, ErrCustomerNotFound
+	}
+
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	items, total, err := s.savedItemRepo.FindByCustomer(customerID, req.ItemType, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interface{}, len(items))
+	for i := range items {
+		results[i] = convertSavedItemToResponse(&items[i])
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(req.Limit)))
+
+	return &response.PaginatedResponse{
+		Items:      results,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *customerInterestService) SaveItem(customerID uint, req request.CreateSavedItemRequest) (*response.SavedItemResponse, error) {
+	if _, err := s.customerRepo.FindByID(customerID); err != nil {
+		return nil, ErrCustomerNotFound
+	}
+	if (req.ProductID == nil) == (req.SampleProductID == nil) {
+		return nil, errors.New("set exactly one of product_id and sample_product_id")
+	}
+
+	item := &models.CustomerSavedItem{
+		CustomerID:      customerID,
+		ProductID:       req.ProductID,
+		SampleProductID: req.SampleProductID,
+		SavedAt:         time.Now(),
+		Notes:           req.Notes,
+	}
+
+	itemType, itemID := interfaces.SavedItemProduct, uint(0)
+	if req.ProductID != nil {
+		itemID = *req.ProductID
+		if _, err := s.productRepo.FindByID(itemID); err != nil {
+			return nil, errors.New("product not found")
+		}
+	} else {
+		itemType, itemID = interfaces.SavedItemSample, *req.SampleProductID
+		if _, err := s.sampleRepo.FindByID(itemID); err != nil {
+			return nil, errors.New("sample not found")
+		}
+	}
+
+	if existing, _ := s.savedItemRepo.FindExisting(customerID, itemType, itemID); existing != nil {
+		return nil, errors.New("item is already saved for this customer")
+	}
+
+	if err := s.savedItemRepo.Create(item); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.savedItemRepo.FindByID(item.ID)
+	if err != nil {
+		return nil, err
+	}
+	return convertSavedItemToResponse(saved), nil
+}
+
+func (s *customerInterestService) UpdateSavedItem(customerID, itemID uint, req request.UpdateSavedItemRequest) (*response.SavedItemResponse, error) {
+	item, err := s.findSavedItem(customerID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Notes = req.Notes
+	if err := s.savedItemRepo.Update(item); err != nil {
+		return nil, err
+	}
+
+	return convertSavedItemToResponse(item), nil
+}
+
+func (s *customerInterestService) RemoveSavedItem(customerID, itemID uint) error {
+	if _, err := s.findSavedItem(customerID, itemID); err != nil {
+		return err
+	}
+	return s.savedItemRepo.Delete(itemID)
+}
+
+// findSavedItem loads a saved item, treating items of other customers as missing
+func (s *customerInterestService) findSavedItem(customerID, itemID uint) (*models.CustomerSavedItem, error) {
+	item, err := s.savedItemRepo.FindByID(itemID)
+	if err != nil || item.CustomerID != customerID {
+		return nil, errors.New("saved item not found")
+	}
+	return item, nil
+}
+
+func (s *customerInterestService) GetSearchHistory(customerID uint, req request.SearchHistoryFilterRequest) (*response.PaginatedResponse, error) {
+	if _, err := s.customerRepo.FindByID(customerID); err != nil {
+		return nil, ErrCustomerNotFound
+	}
+
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	// date_to is a whole day
+	to := req.DateTo
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	searches, total, err := s.searchHistoryRepo.FindByCustomer(customerID, req.DateFrom, to, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(searches))
+	for i := range searches {
+		items[i] = convertSearchToResponse(&searches[i])
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(req.Limit)))
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *customerInterestService) RecordSearch(customerID uint, req request.RecordSearchRequest) (*response.SearchHistoryResponse, error) {
+	if _, err := s.customerRepo.FindByID(customerID); err != nil {
+		return nil, ErrCustomerNotFound
+	}
+
+	search := &models.CustomerSearchHistory{
+		CustomerID:      customerID,
+		SearchQuery:     req.SearchQuery,
+		NormalizedQuery: truncate(textsearch.Normalize(req.SearchQuery), maxNormalizedQueryLength),
+		SearchDate:      time.Now(),
+		ResultCount:     req.ResultCount,
+	}
+	if len(req.SearchResults) > 0 && string(req.SearchResults) != "null" {
+		results := string(req.SearchResults)
+		search.SearchResults = &results
+	}
+
+	if err := s.searchHistoryRepo.Create(search); err != nil {
+		return nil, err
+	}
+
+	return convertSearchToResponse(search), nil
+}
+
+func (s *customerInterestService) GetInterestReport(req request.CustomerInterestReportRequest) (*response.CustomerInterestReportResponse, error) {
+	to := req.DateTo
+	if to.IsZero() {
+		now := time.Now()
+		to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	}
+	from := req.DateFrom
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -interestReportDays+1)
+	}
+	if from.After(to) {
+		return nil, errors.New("date_from must not be after date_to")
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = interestReportLimit
+	}
+
+	// date_to is a whole day
+	end := to.AddDate(0, 0, 1)
+
+	terms, err := s.searchHistoryRepo.TopTerms(from, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	products, err := s.savedItemRepo.MostSaved(interfaces.SavedItemProduct, from, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	samples, err := s.savedItemRepo.MostSaved(interfaces.SavedItemSample, from, end, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &response.CustomerInterestReportResponse{
+		DateFrom:          from,
+		DateTo:            to,
+		TopSearchTerms:    make([]response.SearchTermStat, len(terms)),
+		MostSavedProducts: convertSavedItemStats(products),
+		MostSavedSamples:  convertSavedItemStats(samples),
+	}
+	for i, term := range terms {
+		report.TopSearchTerms[i] = response.SearchTermStat{
+			Term:            term.Term,
+			SearchCount:     term.SearchCount,
+			CustomerCount:   term.CustomerCount,
+			ZeroResultCount: term.ZeroResultCount,
+		}
+	}
+	return report, nil
+}
+
+func convertSavedItemStats(counts []interfaces.SavedItemCount) []response.SavedItemStat {
+	stats := make([]response.SavedItemStat, len(counts))
+	for i, count := range counts {
+		stats[i] = response.SavedItemStat{
+			ItemID:        count.ItemID,
+			SKU:           count.SKU,
+			ProductNameVI: count.ProductNameVI,
+			ProductNameEN: count.ProductNameEN,
+			SaveCount:     count.SaveCount,
+			CustomerCount: count.CustomerCount,
+		}
+	}
+	return stats
+}
+
+// Helper function to convert model to response DTO
+func convertSavedItemToResponse(item *models.CustomerSavedItem) *response.SavedItemResponse {
+	res := &response.SavedItemResponse{
+		ID:              item.ID,
+		CustomerID:      item.CustomerID,
+		ProductID:       item.ProductID,
+		SampleProductID: item.SampleProductID,
+		Notes:           item.Notes,
+		SavedAt:         item.SavedAt,
+	}
+
+	switch {
+	case item.Product != nil:
+		res.ItemType = interfaces.SavedItemProduct
+		res.SKU = item.Product.SKU
+		res.ProductNameVI = item.Product.ProductName.ProductNameVI
+		res.ProductNameEN = item.Product.ProductName.ProductNameEN
+		res.Color = item.Product.Color
+	case item.SampleProduct != nil:
+		res.ItemType = interfaces.SavedItemSample
+		res.SKU = item.SampleProduct.SKU
+		res.ProductNameVI = item.SampleProduct.ProductName.ProductNameVI
+		res.ProductNameEN = item.SampleProduct.ProductName.ProductNameEN
+		res.Color = item.SampleProduct.Color
+	case item.ProductID != nil:
+		res.ItemType = interfaces.SavedItemProduct
+	default:
+		res.ItemType = interfaces.SavedItemSample
+	}
+	return res
+}
+
+// Helper function to convert model to response DTO
+func convertSearchToResponse(search *models.CustomerSearchHistory) *response.SearchHistoryResponse {
+	res := &response.SearchHistoryResponse{
+		ID:              search.ID,
+		CustomerID:      search.CustomerID,
+		SearchQuery:     search.SearchQuery,
+		NormalizedQuery: search.NormalizedQuery,
+		SearchDate:      search.SearchDate,
+		ResultCount:     search.ResultCount,
+	}
+	if search.SearchResults != nil {
+		res.SearchResults = []byte(*search.SearchResults)
+	}
+	return res
+}
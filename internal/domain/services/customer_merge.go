@@ -0,0 +1,406 @@
+// File: internal/domain/services/customer_merge.go
+// Tạo tại: internal/domain/services/customer_merge.go
+// Mục đích: Phát hiện khách hàng trùng lặp (mã số thuế, điện thoại/email chuẩn hóa, tên công ty gần đúng) và gộp khách hàng
+
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/textsearch"
+)
+
+// Duplicate score weights; a pair's score is their sum, capped at 100
+const (
+	duplicateTaxIDScore   = 60
+	duplicateEmailScore   = 40
+	duplicatePhoneScore   = 35
+	duplicateCompanyScore = 50 // Scaled by the name similarity
+
+	// duplicateCompanySimilarity is the lowest company name similarity counted as a match
+	duplicateCompanySimilarity = 0.85
+
+	// duplicateTokenBlockSize skips name words shared by so many customers that they say nothing ("textile")
+	duplicateTokenBlockSize = 100
+
+	defaultDuplicateMinScore = 35
+	defaultDuplicateLimit    = 50
+)
+
+// companyLegalPhrases are legal-form and business-type phrases dropped before comparing company names,
+// in normalized form and longest first so "co phan" is removed before "co"
+var companyLegalPhrases = [][]string{
+	{"trach", "nhiem", "huu", "han"},
+	{"xuat", "nhap", "khau"},
+	{"mot", "thanh", "vien"},
+	{"cong", "ty"},
+	{"co", "phan"},
+	{"thuong", "mai"},
+	{"dich", "vu"},
+	{"san", "xuat"},
+	{"co", "ltd"},
+	{"cty"}, {"tnhh"}, {"mtv"}, {"cp"}, {"tm"}, {"dv"}, {"sx"}, {"xnk"},
+	{"ltd"}, {"limited"}, {"company"}, {"jsc"}, {"inc"}, {"corp"}, {"corporation"}, {"llc"},
+}
+
+type CustomerMergeService interface {
+	FindDuplicates(req request.DuplicateSearchRequest) (*response.DuplicateCustomersResponse, error)
+	MergeCustomers(survivorID uint, req request.MergeCustomerRequest, mergedBy uint, client request.ClientInfo) (*response.CustomerMergeResponse, error)
+}
+
+type customerMergeService struct {
+	customerRepo    interfaces.CustomerRepository
+	activityService CustomerActivityService
+}
+
+func NewCustomerMergeService(customerRepo interfaces.CustomerRepository, activityService CustomerActivityService) CustomerMergeService {
+	return &customerMergeService{
+		customerRepo:    customerRepo,
+		activityService: activityService,
+	}
+}
+
+// dedupKeys are the normalized values two customers are compared on
+type dedupKeys struct {
+	taxID   string
+	email   string
+	phone   string
+	company string
+}
+
+func (s *customerMergeService) FindDuplicates(req request.DuplicateSearchRequest) (*response.DuplicateCustomersResponse, error) {
+	minScore := req.MinScore
+	if minScore <= 0 {
+		minScore = defaultDuplicateMinScore
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultDuplicateLimit
+	}
+
+	customers, err := s.customerRepo.FindDedupCandidates()
+	if err != nil {
+		return nil, err
+	}
+
+	target := -1
+	if req.CustomerID != 0 {
+		for i := range customers {
+			if customers[i].ID == req.CustomerID {
+				target = i
+				break
+			}
+		}
+		if target < 0 {
+			return nil, ErrCustomerNotFound
+		}
+	}
+
+	keys := make([]dedupKeys, len(customers))
+	for i := range customers {
+		keys[i] = customerDedupKeys(&customers[i])
+	}
+
+	var pairs []response.DuplicatePair
+	for _, pair := range candidatePairs(keys, target) {
+		i, j := pair[0], pair[1]
+		score, reasons := duplicateScore(keys[i], keys[j])
+		if score < minScore {
+			continue
+		}
+		pairs = append(pairs, response.DuplicatePair{
+			Customer:  *convertCustomerToResponse(&customers[i]),
+			Duplicate: *convertCustomerToResponse(&customers[j]),
+			Score:     score,
+			Reasons:   reasons,
+		})
+	}
+
+	sort.SliceStable(pairs, func(a, b int) bool {
+		if pairs[a].Score != pairs[b].Score {
+			return pairs[a].Score > pairs[b].Score
+		}
+		if pairs[a].Customer.ID != pairs[b].Customer.ID {
+			return pairs[a].Customer.ID < pairs[b].Customer.ID
+		}
+		return pairs[a].Duplicate.ID < pairs[b].Duplicate.ID
+	})
+	if len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+	if pairs == nil {
+		pairs = []response.DuplicatePair{}
+	}
+
+	return &response.DuplicateCustomersResponse{Pairs: pairs}, nil
+}
+
+func (s *customerMergeService) MergeCustomers(survivorID uint, req request.MergeCustomerRequest, mergedBy uint, client request.ClientInfo) (*response.CustomerMergeResponse, error) {
+	if survivorID == req.DuplicateID {
+		return nil, errors.New("cannot merge a customer into itself")
+	}
+	survivor, err := s.customerRepo.FindByID(survivorID)
+	if err != nil {
+		return nil, ErrCustomerNotFound
+	}
+	duplicate, err := s.customerRepo.FindByID(req.DuplicateID)
+	if err != nil {
+		return nil, errors.New("duplicate customer not found")
+	}
+
+	var filled []string
+	if req.FillMissing == nil || *req.FillMissing {
+		filled = fillMissingCustomerFields(survivor, duplicate)
+	}
+	if filled == nil {
+		filled = []string{}
+	}
+
+	res := &response.CustomerMergeResponse{
+		DryRun:             req.DryRun,
+		MergedCustomerID:   duplicate.ID,
+		MergedCustomerCode: duplicate.CustomerCode,
+		FilledFields:       filled,
+	}
+
+	if req.DryRun {
+		moved, err := s.customerRepo.CountReferences(duplicate.ID)
+		if err != nil {
+			return nil, err
+		}
+		res.Survivor = *convertCustomerToResponse(survivor)
+		res.MovedRecords = moved
+		return res, nil
+	}
+
+	snapshot, err := json.Marshal(duplicate)
+	if err != nil {
+		return nil, err
+	}
+	audit := &models.CustomerMerge{
+		SurvivorID:         survivor.ID,
+		MergedCustomerID:   duplicate.ID,
+		MergedCustomerCode: duplicate.CustomerCode,
+		MergedSnapshot:     string(snapshot),
+		Reason:             req.Reason,
+		MergedAt:           time.Now(),
+	}
+	if mergedBy != 0 {
+		audit.MergedBy = &mergedBy
+	}
+
+	moved, err := s.customerRepo.Merge(survivor, duplicate.ID, audit)
+	if err != nil {
+		return nil, err
+	}
+
+	s.activityService.Record(survivor.ID, models.CustomerActivityMerged, map[string]interface{}{
+		"merge_id":             audit.ID,
+		"merged_customer_id":   duplicate.ID,
+		"merged_customer_code": duplicate.CustomerCode,
+		"moved_records":        moved,
+	}, client)
+
+	res.MergeID = audit.ID
+	res.Survivor = *convertCustomerToResponse(survivor)
+	res.MovedRecords = moved
+	return res, nil
+}
+
+// fillMissingCustomerFields copies contact fields the survivor lacks from the duplicate and returns their names
+func fillMissingCustomerFields(survivor, duplicate *models.Customer) []string {
+	var filled []string
+	fill := func(name string, field *string, value string) {
+		if strings.TrimSpace(*field) == "" && strings.TrimSpace(value) != "" {
+			*field = value
+			filled = append(filled, name)
+		}
+	}
+	fill("email", &survivor.Email, duplicate.Email)
+	fill("phone", &survivor.Phone, duplicate.Phone)
+	fill("address", &survivor.Address, duplicate.Address)
+	fill("company_name", &survivor.CompanyName, duplicate.CompanyName)
+	fill("tax_id", &survivor.TaxID, duplicate.TaxID)
+	return filled
+}
+
+// candidatePairs returns the index pairs sharing a tax ID, email, phone or company name word.
+// With target >= 0 only pairs including it are returned, target first.
+func candidatePairs(keys []dedupKeys, target int) [][2]int {
+	blocks := make(map[string][]int)
+	for i, key := range keys {
+		if key.taxID != "" {
+			blocks["tax:"+key.taxID] = append(blocks["tax:"+key.taxID], i)
+		}
+		if key.email != "" {
+			blocks["email:"+key.email] = append(blocks["email:"+key.email], i)
+		}
+		if key.phone != "" {
+			blocks["phone:"+key.phone] = append(blocks["phone:"+key.phone], i)
+		}
+		for _, word := range strings.Fields(key.company) {
+			if len(word) >= 3 {
+				blocks["word:"+word] = append(blocks["word:"+word], i)
+			}
+		}
+	}
+
+	seen := make(map[[2]int]bool)
+	var pairs [][2]int
+	add := func(i, j int) {
+		if target < 0 && i > j {
+			i, j = j, i
+		}
+		if pair := [2]int{i, j}; !seen[pair] {
+			seen[pair] = true
+			pairs = append(pairs, pair)
+		}
+	}
+
+	for name, members := range blocks {
+		if strings.HasPrefix(name, "word:") && len(members) > duplicateTokenBlockSize {
+			continue
+		}
+		for a := 0; a < len(members); a++ {
+			for b := a + 1; b < len(members); b++ {
+				i, j := members[a], members[b]
+				switch {
+				case target < 0:
+					add(i, j)
+				case i == target:
+					add(i, j)
+				case j == target:
+					add(j, i)
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+// duplicateScore scores how likely two customers are the same company
+func duplicateScore(a, b dedupKeys) (int, []response.DuplicateReason) {
+	score := 0
+	reasons := []response.DuplicateReason{}
+	if a.taxID != "" && a.taxID == b.taxID {
+		score += duplicateTaxIDScore
+		reasons = append(reasons, response.DuplicateReason{Field: "tax_id", Similarity: 1})
+	}
+	if a.email != "" && a.email == b.email {
+		score += duplicateEmailScore
+		reasons = append(reasons, response.DuplicateReason{Field: "email", Similarity: 1})
+	}
+	if a.phone != "" && a.phone == b.phone {
+		score += duplicatePhoneScore
+		reasons = append(reasons, response.DuplicateReason{Field: "phone", Similarity: 1})
+	}
+	if a.company != "" && b.company != "" {
+		if similarity := textsearch.Similarity(a.company, b.company); similarity >= duplicateCompanySimilarity {
+			score += int(similarity*duplicateCompanyScore + 0.5)
+			reasons = append(reasons, response.DuplicateReason{Field: "company_name", Similarity: float64(int(similarity*100+0.5)) / 100})
+		}
+	}
+	return min(score, 100), reasons
+}
+
+func customerDedupKeys(customer *models.Customer) dedupKeys {
+	company := customer.CompanyName
+	if strings.TrimSpace(company) == "" {
+		company = customer.Name
+	}
+	return dedupKeys{
+		taxID:   normalizeTaxID(customer.TaxID),
+		email:   normalizeEmail(customer.Email),
+		phone:   normalizePhone(customer.Phone),
+		company: normalizeCompanyName(company),
+	}
+}
+
+// normalizeTaxID keeps letters and digits; IDs too short to be real are ignored
+func normalizeTaxID(taxID string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(taxID) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() < 6 {
+		return ""
+	}
+	return b.String()
+}
+
+// normalizeEmail lowercases the address and drops +tags; Gmail also ignores dots in the local part
+func normalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" || domain == "" {
+		return ""
+	}
+	if i := strings.Index(local, "+"); i > 0 {
+		local = local[:i]
+	}
+	if domain == "gmail.com" || domain == "googlemail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+		domain = "gmail.com"
+	}
+	return local + "@" + domain
+}
+
+// normalizePhone keeps the last 9 digits so "+84 912 345 678", "0912.345.678" and "84912345678" match
+func normalizePhone(phone string) string {
+	var digits []rune
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+		}
+	}
+	if len(digits) < 9 {
+		return ""
+	}
+	return string(digits[len(digits)-9:])
+}
+
+// normalizeCompanyName strips diacritics, punctuation and legal-form phrases ("Công ty TNHH", "Co., Ltd")
+func normalizeCompanyName(name string) string {
+	words := strings.Fields(textsearch.Normalize(name))
+	var kept []string
+	for i := 0; i < len(words); {
+		if n := legalPhraseAt(words, i); n > 0 {
+			i += n
+			continue
+		}
+		kept = append(kept, words[i])
+		i++
+	}
+	return strings.Join(kept, " ")
+}
+
+// legalPhraseAt returns the length of the legal-form phrase starting at words[i], or 0
+func legalPhraseAt(words []string, i int) int {
+	for _, phrase := range companyLegalPhrases {
+		if i+len(phrase) > len(words) {
+			continue
+		}
+		match := true
+		for k, word := range phrase {
+			if words[i+k] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			return len(phrase)
+		}
+	}
+	return 0
+}
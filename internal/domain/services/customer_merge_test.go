@@ -0,0 +1,52 @@
+// File: internal/domain/services/customer_merge_test.go
+// Tạo tại: internal/domain/services/customer_merge_test.go
+// Mục đích: Kiểm thử chuẩn hóa khóa so trùng khách hàng và chép thông tin còn thiếu khi gộp
+
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+)
+
+func TestNormalizeDedupKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		normalize func(string) string
+		in, want  string
+	}{
+		{"tax id keeps letters and digits", normalizeTaxID, " 0312-345 678 ", "0312345678"},
+		{"short tax id is ignored", normalizeTaxID, "12-34", ""},
+		{"email lowercased without tag", normalizeEmail, " Sales+VN@Example.com ", "sales@example.com"},
+		{"gmail ignores dots", normalizeEmail, "minh.anh@googlemail.com", "minhanh@gmail.com"},
+		{"not an email", normalizeEmail, "sales@", ""},
+		{"international phone", normalizePhone, "+84 912 345 678", "912345678"},
+		{"local phone", normalizePhone, "0912.345.678", "912345678"},
+		{"short phone is ignored", normalizePhone, "12345", ""},
+		{"legal form dropped", normalizeCompanyName, "Công ty TNHH Dệt May Minh Anh", "det may minh anh"},
+		{"english legal form dropped", normalizeCompanyName, "Minh Anh Textile Co., Ltd", "minh anh textile"},
+		{"joint-stock forms dropped", normalizeCompanyName, "Co Phan Minh Anh JSC", "minh anh"},
+	}
+	for _, tt := range tests {
+		if got := tt.normalize(tt.in); got != tt.want {
+			t.Errorf("%s: normalize(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFillMissingCustomerFields(t *testing.T) {
+	survivor := &models.Customer{Email: "sales@minhanh.vn", Phone: " ", CompanyName: "Minh Anh"}
+	duplicate := &models.Customer{Email: "old@minhanh.vn", Phone: "0912345678", Address: "12 Lê Lợi", TaxID: ""}
+
+	filled := fillMissingCustomerFields(survivor, duplicate)
+
+	if want := []string{"phone", "address"}; !reflect.DeepEqual(filled, want) {
+		t.Errorf("filled = %v, want %v", filled, want)
+	}
+	if survivor.Email != "sales@minhanh.vn" || survivor.Phone != "0912345678" || survivor.Address != "12 Lê Lợi" ||
+		survivor.CompanyName != "Minh Anh" || survivor.TaxID != "" {
+		t.Errorf("survivor = %+v", survivor)
+	}
+}
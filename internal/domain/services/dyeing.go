@@ -0,0 +1,797 @@
+// File: internal/domain/services/dyeing.go
+// Tạo tại: internal/domain/services/dyeing.go
+// Mục đích: Service nhà nhuộm và lệnh nhuộm: gửi vải mộc theo màu mục tiêu và lap dip, nhận lô nhuộm trả về, ghi kết
+// quả co rút và độ bền màu theo lô; lô được nghiệm thu tự tạo lô kho nguồn Dyeing
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/money"
+	"gorm.io/gorm"
+)
+
+const (
+	dyeingJobCodePrefix = "DJ"
+	dyeingJobCodeDigits = 6
+)
+
+var (
+	// ErrDyeingSubcontractorNotFound is returned when a dye house ID does not exist
+	ErrDyeingSubcontractorNotFound = errors.New("dye house not found")
+	// ErrDyeingJobNotFound is returned when a dyeing job ID does not exist
+	ErrDyeingJobNotFound = errors.New("dyeing job not found")
+	// ErrDyeingLotNotFound is returned when a dyeing lot ID does not exist
+	ErrDyeingLotNotFound = errors.New("dyeing lot not found")
+	// ErrDyeingJobStatusChanged is returned when someone else changed the dyeing job status meanwhile
+	ErrDyeingJobStatusChanged = interfaces.ErrDyeingJobStatusChanged
+	// ErrDyeingLotStatusChanged is returned when the dyeing lot was accepted or rejected meanwhile
+	ErrDyeingLotStatusChanged = interfaces.ErrDyeingLotStatusChanged
+)
+
+type DyeingService interface {
+	GetSubcontractors(search string) ([]response.DyeingSubcontractorResponse, error)
+	GetSubcontractorByID(id uint) (*response.DyeingSubcontractorResponse, error)
+	CreateSubcontractor(req request.CreateDyeingSubcontractorRequest) (*response.DyeingSubcontractorResponse, error)
+	UpdateSubcontractor(id uint, req request.UpdateDyeingSubcontractorRequest) (*response.DyeingSubcontractorResponse, error)
+	DeleteSubcontractor(id uint) error
+
+	GetJobs(req request.DyeingJobFilterRequest) (*response.PaginatedResponse, error)
+	GetJobByID(id uint) (*response.DyeingJobResponse, error)
+	// CreateJob sends greige to a dye house. The weaving order the greige comes from must pass its guards
+	// for dyeing; a completed one is sent to dyeing together with the job.
+	CreateJob(req request.CreateDyeingJobRequest, userID uint) (*response.DyeingJobResponse, error)
+	UpdateJob(id uint, req request.UpdateDyeingJobRequest) (*response.DyeingJobResponse, error)
+	// ChangeJobStatus closes a job once its returned lots are all judged, or cancels a job with no lots
+	ChangeJobStatus(id uint, req request.ChangeDyeingJobStatusRequest) (*response.DyeingJobResponse, error)
+	DeleteJob(id uint) error
+
+	// AddLot records a lot the dye house returned, optionally with its results
+	AddLot(jobID uint, req request.CreateDyeingLotRequest, userID uint) (*response.DyeingLotResponse, error)
+	RecordLotResults(id uint, req request.DyeingLotResultsRequest, userID uint) (*response.DyeingLotResponse, error)
+	// AcceptLot stocks a tested lot as a warehouse lot of the job's product and color
+	AcceptLot(id uint, req request.AcceptDyeingLotRequest, userID uint) (*response.DyeingLotResponse, error)
+	RejectLot(id uint, req request.RejectDyeingLotRequest, userID uint) (*response.DyeingLotResponse, error)
+	DeleteLot(id uint) error
+}
+
+type dyeingService struct {
+	dyeingRepo     interfaces.DyeingRepository
+	weavingRepo    interfaces.WeavingOrderRepository
+	orderRepo      interfaces.OrderRepository
+	productRepo    interfaces.ProductRepository
+	weavingService WeavingService
+}
+
+func NewDyeingService(
+	dyeingRepo interfaces.DyeingRepository,
+	weavingRepo interfaces.WeavingOrderRepository,
+	orderRepo interfaces.OrderRepository,
+	productRepo interfaces.ProductRepository,
+	weavingService WeavingService,
+) DyeingService {
+	return &dyeingService{
+		dyeingRepo:     dyeingRepo,
+		weavingRepo:    weavingRepo,
+		orderRepo:      orderRepo,
+		productRepo:    productRepo,
+		weavingService: weavingService,
+	}
+}
+
+func (s *dyeingService) GetSubcontractors(search string) ([]response.DyeingSubcontractorResponse, error) {
+	subcontractors, err := s.dyeingRepo.FindSubcontractors(strings.TrimSpace(search))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.DyeingSubcontractorResponse, len(subcontractors))
+	for i := range subcontractors {
+		result[i] = *convertDyeingSubcontractorToResponse(&subcontractors[i])
+	}
+	return result, nil
+}
+
+func (s *dyeingService) GetSubcontractorByID(id uint) (*response.DyeingSubcontractorResponse, error) {
+	subcontractor, err := s.dyeingRepo.FindSubcontractorByID(id)
+	if err != nil {
+		return nil, ErrDyeingSubcontractorNotFound
+	}
+	return convertDyeingSubcontractorToResponse(subcontractor), nil
+}
+
+func (s *dyeingService) CreateSubcontractor(req request.CreateDyeingSubcontractorRequest) (*response.DyeingSubcontractorResponse, error) {
+	subcontractor := &models.DyeingSubcontractor{
+		Name:        strings.TrimSpace(req.Name),
+		ContactInfo: req.ContactInfo,
+		Currency:    models.DefaultCurrency,
+	}
+	if subcontractor.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if req.Currency != "" {
+		currency, err := money.NormalizeCurrency(req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		subcontractor.Currency = currency
+	}
+
+	if err := s.dyeingRepo.CreateSubcontractor(subcontractor); err != nil {
+		return nil, err
+	}
+	return convertDyeingSubcontractorToResponse(subcontractor), nil
+}
+
+func (s *dyeingService) UpdateSubcontractor(id uint, req request.UpdateDyeingSubcontractorRequest) (*response.DyeingSubcontractorResponse, error) {
+	subcontractor, err := s.dyeingRepo.FindSubcontractorByID(id)
+	if err != nil {
+		return nil, ErrDyeingSubcontractorNotFound
+	}
+
+	if req.Name != nil {
+		subcontractor.Name = strings.TrimSpace(*req.Name)
+		if subcontractor.Name == "" {
+			return nil, errors.New("name is required")
+		}
+	}
+	if req.ContactInfo != nil {
+		subcontractor.ContactInfo = *req.ContactInfo
+	}
+	if req.Currency != nil {
+		if subcontractor.Currency, err = money.NormalizeCurrency(*req.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.dyeingRepo.UpdateSubcontractor(subcontractor); err != nil {
+		return nil, err
+	}
+	return s.GetSubcontractorByID(id)
+}
+
+func (s *dyeingService) DeleteSubcontractor(id uint) error {
+	if _, err := s.dyeingRepo.FindSubcontractorByID(id); err != nil {
+		return ErrDyeingSubcontractorNotFound
+	}
+
+	open, err := s.dyeingRepo.CountOpenJobs(id)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return fmt.Errorf("dye house has %d open dyeing jobs; close or cancel them first", open)
+	}
+
+	return s.dyeingRepo.DeleteSubcontractor(id)
+}
+
+func (s *dyeingService) GetJobs(req request.DyeingJobFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	filter := interfaces.DyeingJobFilter{
+		Search:          strings.TrimSpace(req.Search),
+		SubcontractorID: req.SubcontractorID,
+		OrderID:         req.OrderID,
+		WeavingOrderID:  req.WeavingOrderID,
+	}
+	for _, status := range splitValues(req.Status) {
+		switch status = strings.ToLower(status); status {
+		case models.DyeingJobSent, models.DyeingJobPartiallyReturned, models.DyeingJobReturned, models.DyeingJobCancelled:
+			filter.Statuses = append(filter.Statuses, status)
+		default:
+			return nil, fmt.Errorf("%w: unknown dyeing job status %q", ErrInvalidQuery, status)
+		}
+	}
+	if !req.DueBy.IsZero() {
+		dueBy := dateOnly(req.DueBy)
+		filter.DueBy = &dueBy
+	}
+
+	jobs, total, err := s.dyeingRepo.FindJobs(filter, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(jobs))
+	for i := range jobs {
+		items[i] = convertDyeingJobToResponse(&jobs[i])
+	}
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.Limit))),
+	}, nil
+}
+
+func (s *dyeingService) GetJobByID(id uint) (*response.DyeingJobResponse, error) {
+	job, err := s.dyeingRepo.FindJobByID(id)
+	if err != nil {
+		return nil, ErrDyeingJobNotFound
+	}
+	return convertDyeingJobToResponse(job), nil
+}
+
+func (s *dyeingService) CreateJob(req request.CreateDyeingJobRequest, userID uint) (*response.DyeingJobResponse, error) {
+	subcontractor, err := s.dyeingRepo.FindSubcontractorByID(req.DyeingSubcontractorID)
+	if err != nil {
+		return nil, ErrDyeingSubcontractorNotFound
+	}
+
+	job := &models.DyeingJob{
+		JobCode:               strings.TrimSpace(req.JobCode),
+		DyeingSubcontractorID: subcontractor.ID,
+		OrderID:               req.OrderID,
+		ProductID:             req.ProductID,
+		TargetColor:           strings.TrimSpace(req.TargetColor),
+		ColorCode:             strings.TrimSpace(req.ColorCode),
+		LapDipReference:       strings.TrimSpace(req.LapDipReference),
+		GreigeLength:          roundQuantity(req.GreigeLength),
+		GreigeWeight:          roundQuantity(req.GreigeWeight),
+		GreigeRolls:           req.GreigeRolls,
+		SentDate:              today(),
+		CostPerKg:             req.CostPerKg,
+		Currency:              subcontractor.Currency,
+		Status:                models.DyeingJobSent,
+		SpecialRequirements:   req.SpecialRequirements,
+		Notes:                 req.Notes,
+		CreatedBy:             &userID,
+	}
+	if req.SentDate != nil {
+		job.SentDate = dateOnly(*req.SentDate)
+	}
+	if req.ExpectedReturnDate != nil {
+		expected := dateOnly(*req.ExpectedReturnDate)
+		job.ExpectedReturnDate = &expected
+	}
+	if req.Currency != "" {
+		if job.Currency, err = money.NormalizeCurrency(req.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	// The weaving order gives the sales order, product and greige woven unless they are given
+	var weavingOrder *models.WeavingOrder
+	if req.WeavingOrderID != nil {
+		if weavingOrder, err = s.weavingRepo.FindByID(*req.WeavingOrderID); err != nil {
+			return nil, ErrWeavingOrderNotFound
+		}
+		if weavingOrder.Status != models.WeavingOrderCompleted && weavingOrder.Status != models.WeavingOrderSentToDyeing {
+			return nil, fmt.Errorf("weaving order %s is %s; only completed weaving can go to dyeing", weavingOrder.OrderCode, weavingOrder.Status)
+		}
+		job.WeavingOrderID = &weavingOrder.ID
+		if job.OrderID == nil {
+			job.OrderID = &weavingOrder.LinkedOrderID
+		}
+		if job.ProductID == nil {
+			job.ProductID = &weavingOrder.ProductID
+		}
+		if job.GreigeLength == 0 {
+			job.GreigeLength = weavingOrder.ProducedQuantity
+		}
+	}
+
+	// The lap dip gives the color and the code the customer approved
+	if req.LDTestID != nil {
+		test, err := s.dyeingRepo.FindLapDipTestByID(*req.LDTestID)
+		if err != nil {
+			return nil, ErrLapDipNotFound
+		}
+		job.LDTestID = &test.ID
+		if job.TargetColor == "" {
+			job.TargetColor = test.ColorName
+		}
+		if job.ColorCode == "" {
+			job.ColorCode = test.ColorCode
+		}
+		if job.LapDipReference == "" {
+			job.LapDipReference = test.SelectedLDCode
+		}
+		if job.LapDipReference == "" {
+			job.LapDipReference = test.LDCode
+		}
+	}
+
+	if err := s.validateJob(job); err != nil {
+		return nil, err
+	}
+
+	// Every job of the greige passes the guards of sending it to dyeing, e.g. the QC hold on failed
+	// inspections, also when an earlier job already sent the weaving order. They run in the transaction
+	// that saves the job.
+	sendWeavingOrder := false
+	var guards func(tx *gorm.DB) error
+	if weavingOrder != nil {
+		sendWeavingOrder = weavingOrder.Status == models.WeavingOrderCompleted
+		guards = func(tx *gorm.DB) error {
+			return s.weavingService.CheckStatusGuards(weavingOrder, models.WeavingOrderSentToDyeing, tx)
+		}
+	}
+
+	// Explicit code: must be unused
+	if job.JobCode != "" {
+		if existing, _ := s.dyeingRepo.FindJobByCode(job.JobCode); existing != nil {
+			return nil, errors.New("dyeing job code already exists")
+		}
+		if err := s.dyeingRepo.CreateJob(job, sendWeavingOrder, guards); err != nil {
+			return nil, err
+		}
+		return s.GetJobByID(job.ID)
+	}
+
+	// Generated code: retry with the next number when a concurrent create took it
+	for attempt := 0; attempt < orderCodeAttempts; attempt++ {
+		code, err := s.nextJobCode()
+		if err != nil {
+			return nil, err
+		}
+		job.ID = 0
+		job.JobCode = code
+
+		err = s.dyeingRepo.CreateJob(job, sendWeavingOrder, guards)
+		if err == nil {
+			return s.GetJobByID(job.ID)
+		}
+		if existing, _ := s.dyeingRepo.FindJobByCode(code); existing == nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("could not generate a unique dyeing job code")
+}
+
+func (s *dyeingService) UpdateJob(id uint, req request.UpdateDyeingJobRequest) (*response.DyeingJobResponse, error) {
+	job, err := s.dyeingRepo.FindJobByID(id)
+	if err != nil {
+		return nil, ErrDyeingJobNotFound
+	}
+	if job.Status != models.DyeingJobSent && job.Status != models.DyeingJobPartiallyReturned {
+		return nil, fmt.Errorf("cannot change a %s dyeing job", job.Status)
+	}
+
+	if req.ProductID != nil {
+		job.ProductID = req.ProductID
+	}
+	if req.TargetColor != nil {
+		job.TargetColor = strings.TrimSpace(*req.TargetColor)
+	}
+	if req.ColorCode != nil {
+		job.ColorCode = strings.TrimSpace(*req.ColorCode)
+	}
+	if req.LapDipReference != nil {
+		job.LapDipReference = strings.TrimSpace(*req.LapDipReference)
+	}
+	if req.GreigeLength != nil {
+		job.GreigeLength = roundQuantity(*req.GreigeLength)
+	}
+	if req.GreigeWeight != nil {
+		job.GreigeWeight = roundQuantity(*req.GreigeWeight)
+	}
+	if req.GreigeRolls != nil {
+		job.GreigeRolls = *req.GreigeRolls
+	}
+	if req.ExpectedReturnDate != nil {
+		expected := dateOnly(*req.ExpectedReturnDate)
+		job.ExpectedReturnDate = &expected
+	}
+	if req.CostPerKg != nil {
+		job.CostPerKg = *req.CostPerKg
+	}
+	if req.SpecialRequirements != nil {
+		job.SpecialRequirements = *req.SpecialRequirements
+	}
+	if req.Notes != nil {
+		job.Notes = *req.Notes
+	}
+
+	if err := s.validateJob(job); err != nil {
+		return nil, err
+	}
+	if err := s.dyeingRepo.UpdateJob(job); err != nil {
+		return nil, err
+	}
+	return s.GetJobByID(id)
+}
+
+func (s *dyeingService) ChangeJobStatus(id uint, req request.ChangeDyeingJobStatusRequest) (*response.DyeingJobResponse, error) {
+	job, err := s.dyeingRepo.FindJobByID(id)
+	if err != nil {
+		return nil, ErrDyeingJobNotFound
+	}
+	if job.Status == req.Status {
+		return nil, fmt.Errorf("dyeing job is already %s", job.Status)
+	}
+
+	var from []string
+	switch req.Status {
+	case models.DyeingJobReturned:
+		from = []string{models.DyeingJobSent, models.DyeingJobPartiallyReturned}
+		if len(job.Lots) == 0 {
+			return nil, errors.New("record the returned lots before closing the dyeing job")
+		}
+		for _, lot := range job.Lots {
+			if lot.Status == models.DyeingLotPending {
+				return nil, fmt.Errorf("lot %s is not yet accepted or rejected", lot.LotCode)
+			}
+		}
+		returned := today()
+		job.ReturnedDate = &returned
+	case models.DyeingJobCancelled:
+		from = []string{models.DyeingJobSent}
+	default:
+		return nil, fmt.Errorf("cannot set a dyeing job to %s", req.Status)
+	}
+	if !slices.Contains(from, job.Status) {
+		return nil, fmt.Errorf("a %s dyeing job cannot become %s", job.Status, req.Status)
+	}
+
+	job.Status = req.Status
+	if err := s.dyeingRepo.ChangeJobStatus(job, from); err != nil {
+		return nil, err
+	}
+	return s.GetJobByID(id)
+}
+
+func (s *dyeingService) DeleteJob(id uint) error {
+	job, err := s.dyeingRepo.FindJobByID(id)
+	if err != nil {
+		return ErrDyeingJobNotFound
+	}
+	if len(job.Lots) > 0 {
+		return errors.New("cannot delete a dyeing job with returned lots")
+	}
+	return s.dyeingRepo.DeleteJob(id)
+}
+
+func (s *dyeingService) AddLot(jobID uint, req request.CreateDyeingLotRequest, userID uint) (*response.DyeingLotResponse, error) {
+	job, err := s.dyeingRepo.FindJobByID(jobID)
+	if err != nil {
+		return nil, ErrDyeingJobNotFound
+	}
+	if job.Status != models.DyeingJobSent && job.Status != models.DyeingJobPartiallyReturned {
+		return nil, fmt.Errorf("a %s dyeing job takes no more lots", job.Status)
+	}
+
+	returned := today()
+	if req.ReturnedDate != nil {
+		returned = dateOnly(*req.ReturnedDate)
+	}
+	if returned.Before(job.SentDate) {
+		return nil, errors.New("a lot cannot come back before the job was sent")
+	}
+
+	lot := &models.DyeingLot{
+		DyeingJobID:           &job.ID,
+		DyeingSubcontractorID: job.DyeingSubcontractorID,
+		ProductID:             job.ProductID,
+		ColorCode:             job.ColorCode,
+		LotCode:               strings.TrimSpace(req.LotCode),
+		Quantity:              req.Quantity,
+		Weight:                roundQuantity(req.Weight),
+		ReturnedDate:          &returned,
+		Status:                models.DyeingLotPending,
+	}
+	applyDyeingLotResults(lot, req.DyeingLotResultsRequest, userID)
+
+	// Explicit code: must be unused
+	if lot.LotCode != "" {
+		if existing, _ := s.dyeingRepo.FindLotByCode(lot.LotCode); existing != nil {
+			return nil, errors.New("dyeing lot code already exists")
+		}
+		if err := s.dyeingRepo.CreateLot(lot); err != nil {
+			return nil, err
+		}
+		return convertDyeingLotToResponse(lot), nil
+	}
+
+	// Generated code: the job code and the lot's number on the job, skipping numbers taken meanwhile
+	for attempt := 0; attempt < orderCodeAttempts; attempt++ {
+		lot.ID = 0
+		lot.LotCode = fmt.Sprintf("%s-%02d", job.JobCode, len(job.Lots)+attempt+1)
+		if existing, _ := s.dyeingRepo.FindLotByCode(lot.LotCode); existing != nil {
+			continue
+		}
+
+		err := s.dyeingRepo.CreateLot(lot)
+		if err == nil {
+			return convertDyeingLotToResponse(lot), nil
+		}
+		if existing, _ := s.dyeingRepo.FindLotByCode(lot.LotCode); existing == nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("could not generate a unique dyeing lot code")
+}
+
+func (s *dyeingService) RecordLotResults(id uint, req request.DyeingLotResultsRequest, userID uint) (*response.DyeingLotResponse, error) {
+	lot, err := s.dyeingRepo.FindLotByID(id)
+	if err != nil {
+		return nil, ErrDyeingLotNotFound
+	}
+	if lot.Status != models.DyeingLotPending {
+		return nil, ErrDyeingLotStatusChanged
+	}
+
+	applyDyeingLotResults(lot, req, userID)
+	if err := s.dyeingRepo.SaveLotResults(lot); err != nil {
+		return nil, err
+	}
+	return convertDyeingLotToResponse(lot), nil
+}
+
+func (s *dyeingService) AcceptLot(id uint, req request.AcceptDyeingLotRequest, userID uint) (*response.DyeingLotResponse, error) {
+	lot, err := s.dyeingRepo.FindLotByID(id)
+	if err != nil {
+		return nil, ErrDyeingLotNotFound
+	}
+	if lot.Status != models.DyeingLotPending {
+		return nil, ErrDyeingLotStatusChanged
+	}
+	if lot.DyeingJobID == nil {
+		return nil, errors.New("only lots of a dyeing job can be accepted")
+	}
+	job, err := s.dyeingRepo.FindJobByID(*lot.DyeingJobID)
+	if err != nil {
+		return nil, ErrDyeingJobNotFound
+	}
+	if job.ProductID == nil {
+		return nil, fmt.Errorf("set the product of dyeing job %s before accepting its lots", job.JobCode)
+	}
+	if lot.ShrinkageLength == nil || lot.ShrinkageWidth == nil ||
+		lot.ColorFastnessWashing == nil || lot.ColorFastnessRubbing == nil {
+		return nil, errors.New("record the shrinkage and color fastness results before accepting the lot")
+	}
+	taken, err := s.dyeingRepo.WarehouseLotExists(lot.LotCode)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, fmt.Errorf("warehouse lot %s already exists", lot.LotCode)
+	}
+
+	notes := fmt.Sprintf("Dyeing job %s", job.JobCode)
+	if req.Notes != "" {
+		notes += "; " + req.Notes
+	}
+	stock := &models.Lot{
+		LotCode:       lot.LotCode,
+		Origin:        models.LotOriginDyeing,
+		ProductID:     *job.ProductID,
+		ColorCode:     job.ColorCode,
+		Quantity:      lot.Quantity,
+		TotalWeight:   lot.Weight,
+		Location:      strings.TrimSpace(req.Location),
+		Status:        models.LotStatusAvailable,
+		QualityStatus: models.LotQualityGood,
+		OriginOrderID: job.OrderID,
+		Notes:         notes,
+	}
+	if job.Product != nil {
+		stock.FabricType = job.Product.FabricType
+	}
+
+	now := time.Now()
+	lot.DecidedBy = &userID
+	lot.DecidedAt = &now
+	if err := s.dyeingRepo.AcceptLot(lot, stock); err != nil {
+		return nil, err
+	}
+	return convertDyeingLotToResponse(lot), nil
+}
+
+func (s *dyeingService) RejectLot(id uint, req request.RejectDyeingLotRequest, userID uint) (*response.DyeingLotResponse, error) {
+	lot, err := s.dyeingRepo.FindLotByID(id)
+	if err != nil {
+		return nil, ErrDyeingLotNotFound
+	}
+	if lot.Status != models.DyeingLotPending {
+		return nil, ErrDyeingLotStatusChanged
+	}
+
+	now := time.Now()
+	lot.QualityIssues = strings.TrimSpace(req.QualityIssues)
+	lot.DecidedBy = &userID
+	lot.DecidedAt = &now
+	if err := s.dyeingRepo.RejectLot(lot); err != nil {
+		return nil, err
+	}
+	return convertDyeingLotToResponse(lot), nil
+}
+
+func (s *dyeingService) DeleteLot(id uint) error {
+	lot, err := s.dyeingRepo.FindLotByID(id)
+	if err != nil {
+		return ErrDyeingLotNotFound
+	}
+	if lot.Status != models.DyeingLotPending {
+		return fmt.Errorf("cannot delete a %s dyeing lot", lot.Status)
+	}
+	return s.dyeingRepo.DeleteLot(id)
+}
+
+func (s *dyeingService) validateJob(job *models.DyeingJob) error {
+	if job.TargetColor == "" {
+		return errors.New("target_color is required; give it or a lap dip test")
+	}
+	if job.ExpectedReturnDate != nil && job.ExpectedReturnDate.Before(job.SentDate) {
+		return errors.New("expected return date is before the sent date")
+	}
+	if job.OrderID != nil {
+		if _, err := s.orderRepo.FindByID(*job.OrderID); err != nil {
+			return ErrOrderNotFound
+		}
+	}
+	if job.ProductID != nil {
+		if _, err := s.productRepo.FindByID(*job.ProductID); err != nil {
+			return ErrProductNotFound
+		}
+	}
+	return nil
+}
+
+func (s *dyeingService) nextJobCode() (string, error) {
+	last, err := s.dyeingRepo.LastCodeWithPrefix(dyeingJobCodePrefix)
+	if err != nil {
+		return "", err
+	}
+
+	next := 1
+	if last != "" {
+		number, err := strconv.Atoi(strings.TrimPrefix(last, dyeingJobCodePrefix))
+		if err != nil {
+			return "", err
+		}
+		next = number + 1
+	}
+	return fmt.Sprintf("%s%0*d", dyeingJobCodePrefix, dyeingJobCodeDigits, next), nil
+}
+
+// applyDyeingLotResults copies the results given; any result marks the lot tested by the user
+func applyDyeingLotResults(lot *models.DyeingLot, req request.DyeingLotResultsRequest, userID uint) {
+	tested := false
+	for _, result := range []struct {
+		value  *float64
+		target **float64
+	}{
+		{req.ShrinkageLength, &lot.ShrinkageLength},
+		{req.ShrinkageWidth, &lot.ShrinkageWidth},
+		{req.ColorFastnessWashing, &lot.ColorFastnessWashing},
+		{req.ColorFastnessRubbing, &lot.ColorFastnessRubbing},
+		{req.ColorFastnessLight, &lot.ColorFastnessLight},
+	} {
+		if result.value != nil {
+			value := math.Round(*result.value*100) / 100
+			*result.target = &value
+			tested = true
+		}
+	}
+	if req.QualityIssues != nil {
+		lot.QualityIssues = *req.QualityIssues
+	}
+	if req.Notes != nil {
+		lot.Notes = *req.Notes
+	}
+	if tested {
+		now := time.Now()
+		lot.TestedBy = &userID
+		lot.TestedAt = &now
+	}
+}
+
+func convertDyeingSubcontractorToResponse(subcontractor *models.DyeingSubcontractor) *response.DyeingSubcontractorResponse {
+	return &response.DyeingSubcontractorResponse{
+		ID:          subcontractor.ID,
+		Name:        subcontractor.Name,
+		ContactInfo: subcontractor.ContactInfo,
+		Currency:    subcontractor.Currency,
+		CreatedAt:   subcontractor.CreatedAt,
+		UpdatedAt:   subcontractor.UpdatedAt,
+	}
+}
+
+func convertDyeingJobToResponse(job *models.DyeingJob) *response.DyeingJobResponse {
+	result := &response.DyeingJobResponse{
+		ID:                    job.ID,
+		JobCode:               job.JobCode,
+		DyeingSubcontractorID: job.DyeingSubcontractorID,
+		SubcontractorName:     job.DyeingSubcontractor.Name,
+		OrderID:               job.OrderID,
+		WeavingOrderID:        job.WeavingOrderID,
+		ProductID:             job.ProductID,
+		TargetColor:           job.TargetColor,
+		ColorCode:             job.ColorCode,
+		LDTestID:              job.LDTestID,
+		LapDipReference:       job.LapDipReference,
+		GreigeLength:          job.GreigeLength,
+		GreigeWeight:          job.GreigeWeight,
+		GreigeRolls:           job.GreigeRolls,
+		SentDate:              job.SentDate,
+		ExpectedReturnDate:    job.ExpectedReturnDate,
+		ReturnedDate:          job.ReturnedDate,
+		CostPerKg:             job.CostPerKg,
+		Currency:              job.Currency,
+		Status:                job.Status,
+		SpecialRequirements:   job.SpecialRequirements,
+		Notes:                 job.Notes,
+		CreatedBy:             job.CreatedBy,
+		CreatedAt:             job.CreatedAt,
+		UpdatedAt:             job.UpdatedAt,
+		Lots:                  make([]response.DyeingLotResponse, len(job.Lots)),
+	}
+	if job.Order != nil {
+		result.OrderCode = job.Order.OrderCode
+	}
+	if job.WeavingOrder != nil {
+		result.WeavingOrderCode = job.WeavingOrder.OrderCode
+	}
+	if job.Product != nil {
+		result.SKU = job.Product.SKU
+	}
+	if (job.Status == models.DyeingJobSent || job.Status == models.DyeingJobPartiallyReturned) &&
+		job.ExpectedReturnDate != nil && job.ExpectedReturnDate.Before(today()) {
+		result.Overdue = true
+	}
+
+	for i := range job.Lots {
+		lot := &job.Lots[i]
+		result.Lots[i] = *convertDyeingLotToResponse(lot)
+		switch lot.Status {
+		case models.DyeingLotRejected:
+			continue
+		case models.DyeingLotAccepted:
+			result.AcceptedWeight += lot.Weight
+		case models.DyeingLotPending:
+			result.PendingLots++
+		}
+		result.ReturnedRolls += lot.Quantity
+		result.ReturnedWeight += lot.Weight
+	}
+	result.ReturnedWeight = roundQuantity(result.ReturnedWeight)
+	result.AcceptedWeight = roundQuantity(result.AcceptedWeight)
+	return result
+}
+
+func convertDyeingLotToResponse(lot *models.DyeingLot) *response.DyeingLotResponse {
+	return &response.DyeingLotResponse{
+		ID:                   lot.ID,
+		DyeingJobID:          lot.DyeingJobID,
+		LotCode:              lot.LotCode,
+		ColorCode:            lot.ColorCode,
+		Quantity:             lot.Quantity,
+		Weight:               lot.Weight,
+		ReturnedDate:         lot.ReturnedDate,
+		Status:               lot.Status,
+		ShrinkageLength:      lot.ShrinkageLength,
+		ShrinkageWidth:       lot.ShrinkageWidth,
+		ColorFastnessWashing: lot.ColorFastnessWashing,
+		ColorFastnessRubbing: lot.ColorFastnessRubbing,
+		ColorFastnessLight:   lot.ColorFastnessLight,
+		TestedBy:             lot.TestedBy,
+		TestedAt:             lot.TestedAt,
+		DecidedBy:            lot.DecidedBy,
+		DecidedAt:            lot.DecidedAt,
+		LotID:                lot.LotID,
+		QualityIssues:        lot.QualityIssues,
+		Notes:                lot.Notes,
+		CreatedAt:            lot.CreatedAt,
+	}
+}
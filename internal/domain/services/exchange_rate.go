@@ -0,0 +1,341 @@
+// File: internal/domain/services/exchange_rate.go
+// Tạo tại: internal/domain/services/exchange_rate.go
+// Mục đích: Service tỷ giá theo ngày hiệu lực, quy đổi tiền tệ và báo cáo doanh số quy về một loại tiền
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+var (
+	// ErrExchangeRateNotFound is returned when an exchange rate ID does not exist
+	ErrExchangeRateNotFound = errors.New("exchange rate not found")
+	// ErrNoExchangeRate is returned when no rate converts between two currencies on a date
+	ErrNoExchangeRate = errors.New("no exchange rate")
+)
+
+type ExchangeRateService interface {
+	// BaseCurrency is the currency reports and new price lists default to
+	BaseCurrency() string
+
+	GetRates(req request.ExchangeRateFilterRequest) (*response.PaginatedResponse, error)
+	GetRateByID(id uint) (*response.ExchangeRateResponse, error)
+	CreateRate(req request.CreateExchangeRateRequest, userID uint) (*response.ExchangeRateResponse, error)
+	UpdateRate(id uint, req request.UpdateExchangeRateRequest) (*response.ExchangeRateResponse, error)
+	DeleteRate(id uint) error
+
+	// Rate is the value of one unit of from in to on the date. It uses the pair's rate, the inverse
+	// of the opposite pair, or a cross rate through the base currency, in that order.
+	Rate(from, to string, date time.Time) (float64, error)
+	// Convert changes an amount from one currency into another at the rate of the date
+	Convert(amount money.Amount, from, to string, date time.Time) (money.Amount, error)
+	ConvertAmount(req request.ConvertAmountRequest) (*response.ConversionResponse, error)
+
+	// SalesSummary totals the orders of a period in one currency, converting each day at that day's rate
+	SalesSummary(req request.SalesSummaryRequest) (*response.SalesSummaryResponse, error)
+}
+
+type exchangeRateService struct {
+	rateRepo     interfaces.ExchangeRateRepository
+	orderRepo    interfaces.OrderRepository
+	baseCurrency string
+}
+
+func NewExchangeRateService(
+	rateRepo interfaces.ExchangeRateRepository,
+	orderRepo interfaces.OrderRepository,
+	baseCurrency string,
+) ExchangeRateService {
+	return &exchangeRateService{
+		rateRepo:     rateRepo,
+		orderRepo:    orderRepo,
+		baseCurrency: baseCurrency,
+	}
+}
+
+func (s *exchangeRateService) BaseCurrency() string {
+	return s.baseCurrency
+}
+
+func (s *exchangeRateService) GetRates(req request.ExchangeRateFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	filter := interfaces.ExchangeRateFilter{}
+	var err error
+	if req.FromCurrency != "" {
+		if filter.FromCurrency, err = money.NormalizeCurrency(req.FromCurrency); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+	}
+	if req.ToCurrency != "" {
+		if filter.ToCurrency, err = money.NormalizeCurrency(req.ToCurrency); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+	}
+
+	rates, total, err := s.rateRepo.FindAll(filter, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(rates))
+	for i := range rates {
+		items[i] = convertExchangeRateToResponse(&rates[i])
+	}
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.Limit))),
+	}, nil
+}
+
+func (s *exchangeRateService) GetRateByID(id uint) (*response.ExchangeRateResponse, error) {
+	rate, err := s.rateRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrExchangeRateNotFound
+	}
+	return convertExchangeRateToResponse(rate), nil
+}
+
+func (s *exchangeRateService) CreateRate(req request.CreateExchangeRateRequest, userID uint) (*response.ExchangeRateResponse, error) {
+	from, err := money.NormalizeCurrency(req.FromCurrency)
+	if err != nil {
+		return nil, err
+	}
+	to, err := money.NormalizeCurrency(req.ToCurrency)
+	if err != nil {
+		return nil, err
+	}
+	if from == to {
+		return nil, errors.New("from_currency and to_currency must differ")
+	}
+
+	rate := &models.ExchangeRate{
+		FromCurrency:  from,
+		ToCurrency:    to,
+		Rate:          req.Rate,
+		EffectiveDate: today(),
+		Source:        req.Source,
+		Notes:         req.Notes,
+		CreatedBy:     &userID,
+	}
+	if req.EffectiveDate != nil {
+		rate.EffectiveDate = dateOnly(*req.EffectiveDate)
+	}
+	if existing, _ := s.rateRepo.FindByPairDate(from, to, rate.EffectiveDate); existing != nil {
+		return nil, fmt.Errorf("a %s to %s rate is already set for %s", from, to, rate.EffectiveDate.Format("2006-01-02"))
+	}
+
+	if err := s.rateRepo.Create(rate); err != nil {
+		return nil, err
+	}
+	return convertExchangeRateToResponse(rate), nil
+}
+
+func (s *exchangeRateService) UpdateRate(id uint, req request.UpdateExchangeRateRequest) (*response.ExchangeRateResponse, error) {
+	rate, err := s.rateRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrExchangeRateNotFound
+	}
+
+	if req.Rate != nil {
+		rate.Rate = *req.Rate
+	}
+	if req.EffectiveDate != nil {
+		date := dateOnly(*req.EffectiveDate)
+		if existing, _ := s.rateRepo.FindByPairDate(rate.FromCurrency, rate.ToCurrency, date); existing != nil && existing.ID != rate.ID {
+			return nil, fmt.Errorf("a %s to %s rate is already set for %s", rate.FromCurrency, rate.ToCurrency, date.Format("2006-01-02"))
+		}
+		rate.EffectiveDate = date
+	}
+	if req.Source != nil {
+		rate.Source = *req.Source
+	}
+	if req.Notes != nil {
+		rate.Notes = *req.Notes
+	}
+
+	if err := s.rateRepo.Update(rate); err != nil {
+		return nil, err
+	}
+	return convertExchangeRateToResponse(rate), nil
+}
+
+func (s *exchangeRateService) DeleteRate(id uint) error {
+	if _, err := s.rateRepo.FindByID(id); err != nil {
+		return ErrExchangeRateNotFound
+	}
+	return s.rateRepo.Delete(id)
+}
+
+func (s *exchangeRateService) Rate(from, to string, date time.Time) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	rate, err := s.pairRate(from, to, date)
+	if err != nil || rate != 0 {
+		return rate, err
+	}
+
+	// Cross rate through the base currency, e.g. EUR → VND → USD
+	if from != s.baseCurrency && to != s.baseCurrency {
+		toBase, err := s.pairRate(from, s.baseCurrency, date)
+		if err != nil {
+			return 0, err
+		}
+		fromBase, err := s.pairRate(s.baseCurrency, to, date)
+		if err != nil {
+			return 0, err
+		}
+		if toBase != 0 && fromBase != 0 {
+			return toBase * fromBase, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w from %s to %s on %s", ErrNoExchangeRate, from, to, date.Format("2006-01-02"))
+}
+
+// pairRate is the rate of the pair or the inverse of the opposite pair, or 0 when neither is set
+func (s *exchangeRateService) pairRate(from, to string, date time.Time) (float64, error) {
+	rate, err := s.rateRepo.FindEffective(from, to, date)
+	if err != nil {
+		return 0, err
+	}
+	if rate != nil {
+		return rate.Rate, nil
+	}
+
+	inverse, err := s.rateRepo.FindEffective(to, from, date)
+	if err != nil {
+		return 0, err
+	}
+	if inverse != nil && inverse.Rate != 0 {
+		return 1 / inverse.Rate, nil
+	}
+	return 0, nil
+}
+
+func (s *exchangeRateService) Convert(amount money.Amount, from, to string, date time.Time) (money.Amount, error) {
+	rate, err := s.Rate(from, to, date)
+	if err != nil {
+		return 0, err
+	}
+	return money.Convert(amount, rate), nil
+}
+
+func (s *exchangeRateService) ConvertAmount(req request.ConvertAmountRequest) (*response.ConversionResponse, error) {
+	amount, err := money.Parse(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	from, err := money.NormalizeCurrency(req.From)
+	if err != nil {
+		return nil, err
+	}
+	to := s.baseCurrency
+	if req.To != "" {
+		if to, err = money.NormalizeCurrency(req.To); err != nil {
+			return nil, err
+		}
+	}
+	date := today()
+	if !req.Date.IsZero() {
+		date = dateOnly(req.Date)
+	}
+
+	rate, err := s.Rate(from, to, date)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ConversionResponse{
+		Amount:    amount,
+		From:      from,
+		To:        to,
+		Rate:      rate,
+		Converted: money.Convert(amount, rate),
+		Date:      date,
+	}, nil
+}
+
+func (s *exchangeRateService) SalesSummary(req request.SalesSummaryRequest) (*response.SalesSummaryResponse, error) {
+	currency := s.baseCurrency
+	if req.Currency != "" {
+		var err error
+		if currency, err = money.NormalizeCurrency(req.Currency); err != nil {
+			return nil, err
+		}
+	}
+	from, to := dateOnly(req.From), dateOnly(req.To)
+	if to.Before(from) {
+		return nil, errors.New("to must not be before from")
+	}
+
+	totals, err := s.orderRepo.TotalsByCurrency(from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.SalesSummaryResponse{
+		Currency:   currency,
+		From:       from,
+		To:         to,
+		ByCurrency: []response.SalesCurrencyTotal{},
+	}
+	lines := make(map[string]int) // Index in ByCurrency
+	for _, day := range totals {
+		converted, err := s.Convert(day.Total, day.Currency, currency, day.Day)
+		if err != nil {
+			return nil, err
+		}
+
+		i, ok := lines[day.Currency]
+		if !ok {
+			i = len(result.ByCurrency)
+			lines[day.Currency] = i
+			result.ByCurrency = append(result.ByCurrency, response.SalesCurrencyTotal{Currency: day.Currency})
+		}
+		line := &result.ByCurrency[i]
+		line.OrderCount += day.Orders
+		line.Total += day.Total
+		line.Converted += converted
+
+		result.OrderCount += day.Orders
+		result.Total += converted
+	}
+
+	return result, nil
+}
+
+func convertExchangeRateToResponse(rate *models.ExchangeRate) *response.ExchangeRateResponse {
+	return &response.ExchangeRateResponse{
+		ID:            rate.ID,
+		FromCurrency:  rate.FromCurrency,
+		ToCurrency:    rate.ToCurrency,
+		Rate:          rate.Rate,
+		EffectiveDate: rate.EffectiveDate,
+		Source:        rate.Source,
+		Notes:         rate.Notes,
+		CreatedAt:     rate.CreatedAt,
+		UpdatedAt:     rate.UpdatedAt,
+	}
+}
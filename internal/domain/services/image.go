@@ -0,0 +1,531 @@
+// File: internal/domain/services/image.go
+// Tạo tại: internal/domain/services/image.go
+// Mục đích: Service upload/quản lý hình ảnh cho sample và product (thumbnail, ảnh chính, thứ tự)
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/imaging"
+	"github.com/godiidev/appsynex/pkg/storage"
+)
+
+type ImageService interface {
+	// Sample images
+	GetSampleImages(sampleID uint) (*response.ImagesResponse, error)
+	UploadSampleImages(sampleID uint, files []*multipart.FileHeader) (*response.ImagesResponse, error)
+	SetPrimarySampleImage(sampleID uint, imageID uint) (*response.ImagesResponse, error)
+	ReorderSampleImages(sampleID uint, imageIDs []uint) (*response.ImagesResponse, error)
+	DeleteSampleImage(sampleID uint, imageID uint) error
+
+	// Product images
+	GetProductImages(productID uint) (*response.ImagesResponse, error)
+	UploadProductImages(productID uint, files []*multipart.FileHeader) (*response.ImagesResponse, error)
+	SetPrimaryProductImage(productID uint, imageID uint) (*response.ImagesResponse, error)
+	ReorderProductImages(productID uint, imageIDs []uint) (*response.ImagesResponse, error)
+	DeleteProductImage(productID uint, imageID uint) error
+}
+
+type imageService struct {
+	sampleRepo       interfaces.SampleRepository
+	productRepo      interfaces.ProductRepository
+	sampleImageRepo  interfaces.SampleImageRepository
+	productImageRepo interfaces.ProductImageRepository
+	blobStore        storage.BlobStore
+	maxUploadSize    int64
+	thumbnailWidth   int
+}
+
+func NewImageService(
+	sampleRepo interfaces.SampleRepository,
+	productRepo interfaces.ProductRepository,
+	sampleImageRepo interfaces.SampleImageRepository,
+	productImageRepo interfaces.ProductImageRepository,
+	blobStore storage.BlobStore,
+	maxUploadSize int64,
+	thumbnailWidth int,
+) ImageService {
+	return &imageService{
+		sampleRepo:       sampleRepo,
+		productRepo:      productRepo,
+		sampleImageRepo:  sampleImageRepo,
+		productImageRepo: productImageRepo,
+		blobStore:        blobStore,
+		maxUploadSize:    maxUploadSize,
+		thumbnailWidth:   thumbnailWidth,
+	}
+}
+
+// storedImage holds the result of writing an upload and its thumbnail to the blob store
+type storedImage struct {
+	ImageURL     string
+	StorageKey   string
+	ThumbnailURL string
+	ThumbnailKey string
+	FileName     string
+	ContentType  string
+	FileSize     int64
+	Width        int
+	Height       int
+}
+
+// ===== Sample images =====
+
+func (s *imageService) GetSampleImages(sampleID uint) (*response.ImagesResponse, error) {
+	if _, err := s.sampleRepo.FindByID(sampleID); err != nil {
+		return nil, errors.New("sample not found")
+	}
+	return s.sampleImagesResponse(sampleID)
+}
+
+func (s *imageService) UploadSampleImages(sampleID uint, files []*multipart.FileHeader) (*response.ImagesResponse, error) {
+	if _, err := s.sampleRepo.FindByID(sampleID); err != nil {
+		return nil, errors.New("sample not found")
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no image files provided")
+	}
+
+	existing, err := s.sampleImageRepo.FindBySampleID(sampleID)
+	if err != nil {
+		return nil, err
+	}
+	hasPrimary := false
+	for _, img := range existing {
+		if img.IsPrimary {
+			hasPrimary = true
+			break
+		}
+	}
+
+	stored, err := s.storeUploads(files, storage.Key("samples", strconv.FormatUint(uint64(sampleID), 10)))
+	if err != nil {
+		return nil, err
+	}
+
+	sortOrder, err := s.sampleImageRepo.NextSortOrder(sampleID)
+	if err != nil {
+		s.removeUploads(stored)
+		return nil, err
+	}
+
+	images := make([]models.SampleImage, len(stored))
+	for i, upload := range stored {
+		images[i] = models.SampleImage{
+			SampleProductID: sampleID,
+			ImageURL:        upload.ImageURL,
+			StorageKey:      upload.StorageKey,
+			ThumbnailURL:    upload.ThumbnailURL,
+			ThumbnailKey:    upload.ThumbnailKey,
+			FileName:        upload.FileName,
+			ContentType:     upload.ContentType,
+			FileSize:        upload.FileSize,
+			Width:           upload.Width,
+			Height:          upload.Height,
+			IsPrimary:       !hasPrimary && i == 0,
+			SortOrder:       sortOrder + i,
+		}
+	}
+	if err := s.sampleImageRepo.Create(images); err != nil {
+		s.removeUploads(stored)
+		return nil, err
+	}
+
+	return s.sampleImagesResponse(sampleID)
+}
+
+func (s *imageService) SetPrimarySampleImage(sampleID uint, imageID uint) (*response.ImagesResponse, error) {
+	image, err := s.sampleImageRepo.FindByID(imageID)
+	if err != nil || image.SampleProductID != sampleID {
+		return nil, errors.New("image not found")
+	}
+
+	if err := s.sampleImageRepo.SetPrimary(sampleID, imageID); err != nil {
+		return nil, err
+	}
+
+	return s.sampleImagesResponse(sampleID)
+}
+
+func (s *imageService) ReorderSampleImages(sampleID uint, imageIDs []uint) (*response.ImagesResponse, error) {
+	images, err := s.sampleImageRepo.FindBySampleID(sampleID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(images))
+	for i, img := range images {
+		ids[i] = img.ID
+	}
+	if err := validateImageOrder(ids, imageIDs); err != nil {
+		return nil, err
+	}
+
+	if err := s.sampleImageRepo.Reorder(sampleID, imageIDs); err != nil {
+		return nil, err
+	}
+
+	return s.sampleImagesResponse(sampleID)
+}
+
+func (s *imageService) DeleteSampleImage(sampleID uint, imageID uint) error {
+	image, err := s.sampleImageRepo.FindByID(imageID)
+	if err != nil || image.SampleProductID != sampleID {
+		return errors.New("image not found")
+	}
+
+	if err := s.sampleImageRepo.Delete(imageID); err != nil {
+		return err
+	}
+	s.removeBlobs(image.StorageKey, image.ThumbnailKey)
+
+	// Promote the next image so the sample keeps a primary image
+	if image.IsPrimary {
+		remaining, err := s.sampleImageRepo.FindBySampleID(sampleID)
+		if err == nil && len(remaining) > 0 {
+			return s.sampleImageRepo.SetPrimary(sampleID, remaining[0].ID)
+		}
+	}
+
+	return nil
+}
+
+func (s *imageService) sampleImagesResponse(sampleID uint) (*response.ImagesResponse, error) {
+	images, err := s.sampleImageRepo.FindBySampleID(sampleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.ImagesResponse{
+		Images: convertSampleImagesToResponse(images),
+		Total:  len(images),
+	}, nil
+}
+
+// ===== Product images =====
+
+func (s *imageService) GetProductImages(productID uint) (*response.ImagesResponse, error) {
+	if _, err := s.productRepo.FindByID(productID); err != nil {
+		return nil, errors.New("product not found")
+	}
+	return s.productImagesResponse(productID)
+}
+
+func (s *imageService) UploadProductImages(productID uint, files []*multipart.FileHeader) (*response.ImagesResponse, error) {
+	if _, err := s.productRepo.FindByID(productID); err != nil {
+		return nil, errors.New("product not found")
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no image files provided")
+	}
+
+	existing, err := s.productImageRepo.FindByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+	hasPrimary := false
+	for _, img := range existing {
+		if img.IsPrimary {
+			hasPrimary = true
+			break
+		}
+	}
+
+	stored, err := s.storeUploads(files, storage.Key("products", strconv.FormatUint(uint64(productID), 10)))
+	if err != nil {
+		return nil, err
+	}
+
+	sortOrder, err := s.productImageRepo.NextSortOrder(productID)
+	if err != nil {
+		s.removeUploads(stored)
+		return nil, err
+	}
+
+	images := make([]models.ProductImage, len(stored))
+	for i, upload := range stored {
+		images[i] = models.ProductImage{
+			ProductID:    productID,
+			ImageURL:     upload.ImageURL,
+			StorageKey:   upload.StorageKey,
+			ThumbnailURL: upload.ThumbnailURL,
+			ThumbnailKey: upload.ThumbnailKey,
+			FileName:     upload.FileName,
+			ContentType:  upload.ContentType,
+			FileSize:     upload.FileSize,
+			Width:        upload.Width,
+			Height:       upload.Height,
+			IsPrimary:    !hasPrimary && i == 0,
+			SortOrder:    sortOrder + i,
+		}
+	}
+	if err := s.productImageRepo.Create(images); err != nil {
+		s.removeUploads(stored)
+		return nil, err
+	}
+
+	return s.productImagesResponse(productID)
+}
+
+func (s *imageService) SetPrimaryProductImage(productID uint, imageID uint) (*response.ImagesResponse, error) {
+	image, err := s.productImageRepo.FindByID(imageID)
+	if err != nil || image.ProductID != productID {
+		return nil, errors.New("image not found")
+	}
+
+	if err := s.productImageRepo.SetPrimary(productID, imageID); err != nil {
+		return nil, err
+	}
+
+	return s.productImagesResponse(productID)
+}
+
+func (s *imageService) ReorderProductImages(productID uint, imageIDs []uint) (*response.ImagesResponse, error) {
+	images, err := s.productImageRepo.FindByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(images))
+	for i, img := range images {
+		ids[i] = img.ID
+	}
+	if err := validateImageOrder(ids, imageIDs); err != nil {
+		return nil, err
+	}
+
+	if err := s.productImageRepo.Reorder(productID, imageIDs); err != nil {
+		return nil, err
+	}
+
+	return s.productImagesResponse(productID)
+}
+
+func (s *imageService) DeleteProductImage(productID uint, imageID uint) error {
+	image, err := s.productImageRepo.FindByID(imageID)
+	if err != nil || image.ProductID != productID {
+		return errors.New("image not found")
+	}
+
+	if err := s.productImageRepo.Delete(imageID); err != nil {
+		return err
+	}
+	s.removeBlobs(image.StorageKey, image.ThumbnailKey)
+
+	// Promote the next image so the product keeps a primary image
+	if image.IsPrimary {
+		remaining, err := s.productImageRepo.FindByProductID(productID)
+		if err == nil && len(remaining) > 0 {
+			return s.productImageRepo.SetPrimary(productID, remaining[0].ID)
+		}
+	}
+
+	return nil
+}
+
+func (s *imageService) productImagesResponse(productID uint) (*response.ImagesResponse, error) {
+	images, err := s.productImageRepo.FindByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.ImagesResponse{
+		Images: convertProductImagesToResponse(images),
+		Total:  len(images),
+	}, nil
+}
+
+// ===== Helpers =====
+
+// preparedUpload is an upload that passed validation, with its thumbnail, before anything is written
+type preparedUpload struct {
+	FileName    string
+	ContentType string
+	Ext         string
+	Data        []byte
+	Thumbnail   *imaging.Thumbnail
+}
+
+// storeUploads validates every file and makes its thumbnail before writing any of them, so one bad file
+// rejects the whole batch. When a write fails, the objects already written are removed.
+func (s *imageService) storeUploads(files []*multipart.FileHeader, prefix string) ([]storedImage, error) {
+	prepared := make([]*preparedUpload, len(files))
+	for i, file := range files {
+		upload, err := s.prepareUpload(file)
+		if err != nil {
+			return nil, err
+		}
+		prepared[i] = upload
+	}
+
+	stored := make([]storedImage, 0, len(prepared))
+	for _, upload := range prepared {
+		image, err := s.storeUpload(upload, prefix)
+		if err != nil {
+			s.removeUploads(stored)
+			return nil, err
+		}
+		stored = append(stored, *image)
+	}
+	return stored, nil
+}
+
+// prepareUpload checks the size and format of an uploaded file and generates its thumbnail
+func (s *imageService) prepareUpload(file *multipart.FileHeader) (*preparedUpload, error) {
+	if s.maxUploadSize > 0 && file.Size > s.maxUploadSize {
+		return nil, fmt.Errorf("file %s exceeds maximum size of %d bytes", file.Filename, s.maxUploadSize)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := imaging.AllowedContentTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("file %s: %s", file.Filename, imaging.ErrUnsupportedFormat.Error())
+	}
+
+	thumb, err := imaging.MakeThumbnail(bytes.NewReader(data), s.thumbnailWidth)
+	if err != nil {
+		return nil, fmt.Errorf("file %s: %s", file.Filename, err.Error())
+	}
+
+	return &preparedUpload{
+		FileName:    file.Filename,
+		ContentType: contentType,
+		Ext:         ext,
+		Data:        data,
+		Thumbnail:   thumb,
+	}, nil
+}
+
+// storeUpload writes a prepared upload and its thumbnail to the blob store
+func (s *imageService) storeUpload(upload *preparedUpload, prefix string) (*storedImage, error) {
+	name, err := storage.NewObjectName()
+	if err != nil {
+		return nil, err
+	}
+	imageKey := storage.Key(prefix, name+upload.Ext)
+	thumbKey := storage.Key(prefix, "thumbs", name+".jpg")
+
+	ctx := context.Background()
+	if err := s.blobStore.Put(ctx, imageKey, bytes.NewReader(upload.Data), int64(len(upload.Data)), upload.ContentType); err != nil {
+		return nil, err
+	}
+	thumb := upload.Thumbnail
+	if err := s.blobStore.Put(ctx, thumbKey, bytes.NewReader(thumb.Data), int64(len(thumb.Data)), "image/jpeg"); err != nil {
+		s.removeBlobs(imageKey)
+		return nil, err
+	}
+
+	return &storedImage{
+		ImageURL:     s.blobStore.URL(imageKey),
+		StorageKey:   imageKey,
+		ThumbnailURL: s.blobStore.URL(thumbKey),
+		ThumbnailKey: thumbKey,
+		FileName:     upload.FileName,
+		ContentType:  upload.ContentType,
+		FileSize:     int64(len(upload.Data)),
+		Width:        thumb.SourceWidth,
+		Height:       thumb.SourceHeight,
+	}, nil
+}
+
+// removeUploads deletes the objects of uploads whose rows were not saved
+func (s *imageService) removeUploads(stored []storedImage) {
+	for _, upload := range stored {
+		s.removeBlobs(upload.StorageKey, upload.ThumbnailKey)
+	}
+}
+
+// removeBlobs deletes stored objects, logging failures instead of returning them
+func (s *imageService) removeBlobs(keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := s.blobStore.Delete(context.Background(), key); err != nil {
+			log.Printf("Warning: failed to delete blob %s: %v", key, err)
+		}
+	}
+}
+
+// validateImageOrder ensures the requested order lists every existing image exactly once
+func validateImageOrder(existingIDs []uint, requested []uint) error {
+	if len(existingIDs) != len(requested) {
+		return errors.New("image_ids must include every image exactly once")
+	}
+
+	existing := make(map[uint]bool, len(existingIDs))
+	for _, id := range existingIDs {
+		existing[id] = true
+	}
+	for _, id := range requested {
+		if !existing[id] {
+			return fmt.Errorf("image %d not found", id)
+		}
+		delete(existing, id)
+	}
+
+	return nil
+}
+
+func convertSampleImagesToResponse(images []models.SampleImage) []response.ImageResponse {
+	result := make([]response.ImageResponse, len(images))
+	for i, img := range images {
+		result[i] = response.ImageResponse{
+			ID:           img.ID,
+			ImageURL:     img.ImageURL,
+			ThumbnailURL: img.ThumbnailURL,
+			FileName:     img.FileName,
+			ContentType:  img.ContentType,
+			FileSize:     img.FileSize,
+			Width:        img.Width,
+			Height:       img.Height,
+			IsPrimary:    img.IsPrimary,
+			SortOrder:    img.SortOrder,
+			CreatedAt:    img.CreatedAt,
+		}
+	}
+	return result
+}
+
+func convertProductImagesToResponse(images []models.ProductImage) []response.ImageResponse {
+	result := make([]response.ImageResponse, len(images))
+	for i, img := range images {
+		result[i] = response.ImageResponse{
+			ID:           img.ID,
+			ImageURL:     img.ImageURL,
+			ThumbnailURL: img.ThumbnailURL,
+			FileName:     img.FileName,
+			ContentType:  img.ContentType,
+			FileSize:     img.FileSize,
+			Width:        img.Width,
+			Height:       img.Height,
+			IsPrimary:    img.IsPrimary,
+			SortOrder:    img.SortOrder,
+			CreatedAt:    img.CreatedAt,
+		}
+	}
+	return result
+}
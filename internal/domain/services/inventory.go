@@ -0,0 +1,123 @@
+// File: internal/domain/services/inventory.go
+// Tạo tại: internal/domain/services/inventory.go
+// Mục đích: Service tính khả năng đáp ứng (ATP) theo mã hàng, màu và ngày từ tồn kho, hàng đang dệt và đang nhuộm
+
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+type InventoryService interface {
+	// AvailableToPromise answers how many meters of the SKU in the color can be promised by the date
+	AvailableToPromise(req request.AvailableToPromiseRequest) (*response.AvailableToPromiseResponse, error)
+}
+
+type inventoryService struct {
+	inventoryRepo interfaces.InventoryRepository
+	productRepo   interfaces.ProductRepository
+}
+
+func NewInventoryService(inventoryRepo interfaces.InventoryRepository, productRepo interfaces.ProductRepository) InventoryService {
+	return &inventoryService{
+		inventoryRepo: inventoryRepo,
+		productRepo:   productRepo,
+	}
+}
+
+func (s *inventoryService) AvailableToPromise(req request.AvailableToPromiseRequest) (*response.AvailableToPromiseResponse, error) {
+	sku := strings.TrimSpace(req.SKU)
+	product, err := s.productRepo.FindBySKU(sku)
+	if err != nil {
+		return nil, fmt.Errorf("product %s not found", sku)
+	}
+
+	now := time.Now()
+	date := req.Date
+	if date.IsZero() {
+		date = now
+	}
+	// Production finishing any time on the promise date counts
+	until := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, now.Location())
+
+	criteria := interfaces.StockCriteria{
+		ProductID:    product.ID,
+		ColorCode:    strings.TrimSpace(req.ColorCode),
+		ProductColor: product.Color,
+		Until:        until,
+		Now:          now,
+	}
+
+	lots, err := s.inventoryRepo.StockByLot(criteria)
+	if err != nil {
+		return nil, err
+	}
+	weaving, err := s.inventoryRepo.IncomingWeaving(criteria)
+	if err != nil {
+		return nil, err
+	}
+	dyeing, err := s.inventoryRepo.IncomingDyeing(criteria)
+	if err != nil {
+		return nil, err
+	}
+	demand, err := s.inventoryRepo.OpenDemand(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.AvailableToPromiseResponse{
+		ProductID:  product.ID,
+		SKU:        product.SKU,
+		ColorCode:  criteria.ColorCode,
+		Date:       until.Format("2006-01-02"),
+		Unit:       "m",
+		OpenDemand: roundQuantity(demand),
+		Lots:       make([]response.LotAvailabilityResponse, len(lots)),
+		Incoming:   []response.IncomingSupplyResponse{},
+	}
+	for i, lot := range lots {
+		result.OnHand += lot.OnHand
+		result.Reserved += lot.Reserved
+		result.Lots[i] = response.LotAvailabilityResponse{
+			LotID:     lot.LotID,
+			LotCode:   lot.LotCode,
+			ColorCode: lot.ColorCode,
+			Rolls:     lot.Rolls,
+			OnHand:    roundQuantity(lot.OnHand),
+			Reserved:  roundQuantity(lot.Reserved),
+			Available: roundQuantity(lot.OnHand - lot.Reserved),
+		}
+	}
+	for _, supply := range append(weaving, dyeing...) {
+		if supply.Source == "weaving" {
+			result.IncomingWeaving += supply.Length
+		} else {
+			result.IncomingDyeing += supply.Length
+		}
+		result.Incoming = append(result.Incoming, response.IncomingSupplyResponse{
+			Source:       supply.Source,
+			ID:           supply.ID,
+			Code:         supply.Code,
+			ColorCode:    supply.ColorCode,
+			Status:       supply.Status,
+			ExpectedDate: supply.ExpectedDate,
+			Length:       supply.Length,
+		})
+	}
+
+	result.OnHand = roundQuantity(result.OnHand)
+	result.Reserved = roundQuantity(result.Reserved)
+	result.IncomingWeaving = roundQuantity(result.IncomingWeaving)
+	result.IncomingDyeing = roundQuantity(result.IncomingDyeing)
+	atp := result.OnHand - result.Reserved + result.IncomingWeaving + result.IncomingDyeing - result.OpenDemand
+	result.AvailableToPromise = math.Max(roundQuantity(atp), 0)
+
+	return result, nil
+}
@@ -0,0 +1,646 @@
+// File: internal/domain/services/order.go
+// Tạo tại: internal/domain/services/order.go
+// Mục đích: Service quản lý đơn hàng (dòng hàng, tính tổng tiền, lịch sử trạng thái, file đính kèm, sinh mã đơn)
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/money"
+	"github.com/godiidev/appsynex/pkg/storage"
+)
+
+const (
+	orderCodePrefix = "DH"
+	orderCodeDigits = 6
+
+	// orderCodeAttempts bounds retries when a concurrent create takes the generated code
+	orderCodeAttempts = 5
+)
+
+// orderAttachmentTypes lists the file extensions accepted as order attachments
+var orderAttachmentTypes = map[string]bool{
+	".pdf": true, ".jpg": true, ".jpeg": true, ".png": true, ".webp": true,
+	".doc": true, ".docx": true, ".xls": true, ".xlsx": true, ".csv": true, ".txt": true, ".zip": true,
+}
+
+var (
+	// ErrOrderNotFound is returned when an order ID does not exist
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrOrderAttachmentNotFound = errors.New("attachment not found")
+)
+
+type OrderService interface {
+	GetOrders(req request.OrderFilterRequest) (*response.PaginatedResponse, error)
+	GetOrdersByCursor(req request.OrderFilterRequest) (*response.CursorPaginatedResponse, error)
+	GetOrderByID(id uint) (*response.OrderDetailResponse, error)
+	GetStatuses() ([]response.OrderStatusResponse, error)
+	CreateOrder(req request.CreateOrderRequest, userID uint, client request.ClientInfo) (*response.OrderDetailResponse, error)
+	UpdateOrder(id uint, req request.UpdateOrderRequest) (*response.OrderDetailResponse, error)
+	DeleteOrder(id uint) error
+
+	// Attachments
+	AddAttachments(id uint, files []*multipart.FileHeader, userID uint) (*response.OrderDetailResponse, error)
+	DeleteAttachment(id uint, name string) error
+}
+
+type orderService struct {
+	orderRepo       interfaces.OrderRepository
+	customerRepo    interfaces.CustomerRepository
+	productRepo     interfaces.ProductRepository
+	activityService CustomerActivityService
+	blobStore       storage.BlobStore
+	maxUploadSize   int64
+}
+
+func NewOrderService(
+	orderRepo interfaces.OrderRepository,
+	customerRepo interfaces.CustomerRepository,
+	productRepo interfaces.ProductRepository,
+	activityService CustomerActivityService,
+	blobStore storage.BlobStore,
+	maxUploadSize int64,
+) OrderService {
+	return &orderService{
+		orderRepo:       orderRepo,
+		customerRepo:    customerRepo,
+		productRepo:     productRepo,
+		activityService: activityService,
+		blobStore:       blobStore,
+		maxUploadSize:   maxUploadSize,
+	}
+}
+
+// orderSortFields lists the fields orders can be sorted by in offset mode
+var orderSortFields = map[string]bool{
+	"id":           true,
+	"order_code":   true,
+	"total_amount": true,
+	"due_date":     true,
+	"created_at":   true,
+}
+
+// orderCursorSortFields lists the indexed fields orders can be paged by with a cursor
+var orderCursorSortFields = map[string]bool{
+	"id":         true,
+	"order_code": true,
+	"created_at": true,
+}
+
+func (s *orderService) buildOrderQuery(req request.OrderFilterRequest, sortFields map[string]bool) (interfaces.QuerySpec, error) {
+	spec := interfaces.QuerySpec{Search: strings.TrimSpace(req.Search)}
+	add := func(field string, op interfaces.FilterOp, value interface{}) {
+		spec.Filters = append(spec.Filters, interfaces.Filter{Field: field, Op: op, Value: value})
+	}
+
+	if req.CustomerID != 0 {
+		add("customer_id", interfaces.FilterEq, req.CustomerID)
+	}
+	if names := splitValues(req.Status); len(names) > 0 {
+		statuses, err := s.orderRepo.FindStatuses()
+		if err != nil {
+			return spec, err
+		}
+		ids := make([]uint, 0, len(names))
+		for _, name := range names {
+			status := findOrderStatus(statuses, name)
+			if status == nil {
+				return spec, fmt.Errorf("%w: unknown order status %q", ErrInvalidQuery, name)
+			}
+			ids = append(ids, status.ID)
+		}
+		add("order_status_id", interfaces.FilterIn, ids)
+	}
+	addDateRange(add, "due_date", req.DueFrom, req.DueTo)
+
+	sort, err := parseSort(req.SortBy, req.SortOrder, sortFields)
+	if err != nil {
+		return spec, err
+	}
+	spec.Sort = sort
+	return spec, nil
+}
+
+func (s *orderService) GetOrders(req request.OrderFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	spec, err := s.buildOrderQuery(req, orderSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, total, err := s.orderRepo.FindAll(req.Page, req.Limit, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(orders))
+	for i := range orders {
+		items[i] = convertOrderToResponse(&orders[i])
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(req.Limit)))
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *orderService) GetOrdersByCursor(req request.OrderFilterRequest) (*response.CursorPaginatedResponse, error) {
+	limit := cursorPageLimit(req.Limit)
+
+	spec, err := s.buildOrderQuery(req, orderCursorSortFields)
+	if err != nil {
+		return nil, err
+	}
+	after, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := cursorSort(spec.Sort, interfaces.SortField{Field: "created_at", Desc: true}, after)
+	if err != nil {
+		return nil, err
+	}
+	spec.Sort = []interfaces.SortField{sort}
+
+	orders, next, err := s.orderRepo.FindPage(spec, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, estimated, err := pageTotal(s.orderRepo, spec, req.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(orders))
+	for i := range orders {
+		items[i] = convertOrderToResponse(&orders[i])
+	}
+
+	return &response.CursorPaginatedResponse{
+		Items:          items,
+		Limit:          limit,
+		NextCursor:     encodeCursor(next),
+		HasMore:        next != nil,
+		TotalItems:     total,
+		TotalEstimated: estimated,
+	}, nil
+}
+
+func (s *orderService) GetOrderByID(id uint) (*response.OrderDetailResponse, error) {
+	order, err := s.orderRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+	return s.orderDetail(order)
+}
+
+func (s *orderService) GetStatuses() ([]response.OrderStatusResponse, error) {
+	statuses, err := s.orderRepo.FindStatuses()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.OrderStatusResponse, len(statuses))
+	for i, status := range statuses {
+		res[i] = response.OrderStatusResponse{
+			ID:          status.ID,
+			StatusName:  status.StatusName,
+			Description: status.Description,
+		}
+	}
+	return res, nil
+}
+
+func (s *orderService) CreateOrder(req request.CreateOrderRequest, userID uint, client request.ClientInfo) (*response.OrderDetailResponse, error) {
+	customer, err := s.customerRepo.FindByID(req.CustomerID)
+	if err != nil {
+		return nil, ErrCustomerNotFound
+	}
+	if customer.AccountStatus == models.CustomerStatusBlocked {
+		return nil, errors.New("customer account is blocked")
+	}
+
+	currency := customer.Currency
+	if req.Currency != "" {
+		if currency, err = money.NormalizeCurrency(req.Currency); err != nil {
+			return nil, err
+		}
+	}
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+
+	items, total, err := s.buildOrderItems(req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := s.orderRepo.FindStatusByName(models.OrderStatusPending)
+	if err != nil {
+		return nil, errors.New("order status PENDING is missing")
+	}
+
+	order := &models.Order{
+		OrderCode:     strings.TrimSpace(req.OrderCode),
+		CustomerID:    customer.ID,
+		OrderStatusID: status.ID,
+		TotalAmount:   total,
+		Currency:      currency,
+		DueDate:       req.DueDate,
+		Notes:         req.Notes,
+		Items:         items,
+	}
+	history := func() *models.OrderHistory {
+		return &models.OrderHistory{StatusID: status.ID, ChangedAt: time.Now(), ChangedBy: userID, Notes: "Order created"}
+	}
+
+	// Explicit code: must be unused
+	if order.OrderCode != "" {
+		if existing, _ := s.orderRepo.FindByCode(order.OrderCode); existing != nil {
+			return nil, errors.New("order code already exists")
+		}
+		if err := s.orderRepo.Create(order, history()); err != nil {
+			return nil, err
+		}
+		return s.orderPlaced(order, client)
+	}
+
+	// Generated code: retry with the next number when a concurrent create took it
+	for attempt := 0; attempt < orderCodeAttempts; attempt++ {
+		code, err := s.nextOrderCode()
+		if err != nil {
+			return nil, err
+		}
+		order.ID = 0
+		order.OrderCode = code
+		for i := range order.Items {
+			order.Items[i].ID = 0
+		}
+
+		err = s.orderRepo.Create(order, history())
+		if err == nil {
+			return s.orderPlaced(order, client)
+		}
+		if existing, _ := s.orderRepo.FindByCode(code); existing == nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("could not generate a unique order code")
+}
+
+// orderPlaced records the ORDER_PLACED activity and returns the saved order
+func (s *orderService) orderPlaced(order *models.Order, client request.ClientInfo) (*response.OrderDetailResponse, error) {
+	s.activityService.Record(order.CustomerID, models.CustomerActivityOrderPlaced, map[string]interface{}{
+		"order_id":     order.ID,
+		"order_code":   order.OrderCode,
+		"total_amount": order.TotalAmount,
+		"item_count":   len(order.Items),
+	}, client)
+
+	return s.GetOrderByID(order.ID)
+}
+
+// UpdateOrder changes the order details. Status changes go through OrderWorkflowService.
+func (s *orderService) UpdateOrder(id uint, req request.UpdateOrderRequest) (*response.OrderDetailResponse, error) {
+	order, err := s.orderRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	if req.DueDate != nil {
+		order.DueDate = req.DueDate
+	}
+	if req.Notes != nil {
+		order.Notes = *req.Notes
+	}
+
+	var items []models.OrderItem
+	if req.Items != nil {
+		if order.OrderStatus.StatusName != models.OrderStatusPending {
+			return nil, errors.New("order items can only be changed while the order is PENDING")
+		}
+		items, order.TotalAmount, err = s.buildOrderItems(req.Items)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.orderRepo.Update(order, items, nil); err != nil {
+		return nil, err
+	}
+
+	return s.GetOrderByID(order.ID)
+}
+
+func (s *orderService) DeleteOrder(id uint) error {
+	order, err := s.orderRepo.FindByID(id)
+	if err != nil {
+		return ErrOrderNotFound
+	}
+
+	// Orders past PENDING are kept for the record; cancel them instead
+	switch order.OrderStatus.StatusName {
+	case models.OrderStatusPending, models.OrderStatusCancelled:
+	default:
+		return errors.New("only PENDING or CANCELLED orders can be deleted")
+	}
+
+	return s.orderRepo.Delete(id)
+}
+
+func (s *orderService) AddAttachments(id uint, files []*multipart.FileHeader, userID uint) (*response.OrderDetailResponse, error) {
+	if len(files) == 0 {
+		return nil, errors.New("no files uploaded")
+	}
+
+	order, err := s.orderRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+	attachments := orderAttachments(order)
+
+	var stored []string
+	for _, file := range files {
+		attachment, err := s.storeAttachment(order.ID, file, userID)
+		if err != nil {
+			s.removeBlobs(stored...)
+			return nil, err
+		}
+		stored = append(stored, attachment.Key)
+		attachments = append(attachments, *attachment)
+	}
+
+	if err := setOrderAttachments(order, attachments); err != nil {
+		s.removeBlobs(stored...)
+		return nil, err
+	}
+	if err := s.orderRepo.Update(order, nil, nil); err != nil {
+		s.removeBlobs(stored...)
+		return nil, err
+	}
+
+	return s.orderDetail(order)
+}
+
+func (s *orderService) DeleteAttachment(id uint, name string) error {
+	order, err := s.orderRepo.FindByID(id)
+	if err != nil {
+		return ErrOrderNotFound
+	}
+
+	attachments := orderAttachments(order)
+	for i, attachment := range attachments {
+		if attachment.Name != name {
+			continue
+		}
+		if err := setOrderAttachments(order, append(attachments[:i], attachments[i+1:]...)); err != nil {
+			return err
+		}
+		if err := s.orderRepo.Update(order, nil, nil); err != nil {
+			return err
+		}
+		s.removeBlobs(attachment.Key)
+		return nil
+	}
+	return ErrOrderAttachmentNotFound
+}
+
+// buildOrderItems checks the products and computes each subtotal and the order total
+func (s *orderService) buildOrderItems(reqItems []request.OrderItemRequest) ([]models.OrderItem, money.Amount, error) {
+	items := make([]models.OrderItem, len(reqItems))
+	var total money.Amount
+	for i, item := range reqItems {
+		product, err := s.productRepo.FindByID(item.ProductID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("product %d not found", item.ProductID)
+		}
+		subtotal := item.Price.Mul(item.Quantity)
+		items[i] = models.OrderItem{
+			ProductID: product.ID,
+			ColorCode: strings.TrimSpace(item.ColorCode),
+			Quantity:  item.Quantity,
+			Price:     *item.Price,
+			Subtotal:  subtotal,
+			Notes:     item.Notes,
+			Product:   *product,
+		}
+		total += subtotal
+	}
+	return items, total, nil
+}
+
+// nextOrderCode returns the code after the highest generated one, e.g. DH000042 → DH000043
+func (s *orderService) nextOrderCode() (string, error) {
+	last, err := s.orderRepo.LastCodeWithPrefix(orderCodePrefix)
+	if err != nil {
+		return "", err
+	}
+
+	next := 1
+	if last != "" {
+		number, err := strconv.Atoi(strings.TrimPrefix(last, orderCodePrefix))
+		if err != nil {
+			return "", err
+		}
+		next = number + 1
+	}
+	return fmt.Sprintf("%s%0*d", orderCodePrefix, orderCodeDigits, next), nil
+}
+
+// storeAttachment validates an uploaded file and writes it to the blob store under the order
+func (s *orderService) storeAttachment(orderID uint, file *multipart.FileHeader, userID uint) (*models.OrderAttachment, error) {
+	if s.maxUploadSize > 0 && file.Size > s.maxUploadSize {
+		return nil, fmt.Errorf("file %s exceeds maximum size of %d bytes", file.Filename, s.maxUploadSize)
+	}
+	ext := strings.ToLower(path.Ext(file.Filename))
+	if !orderAttachmentTypes[ext] {
+		return nil, fmt.Errorf("file %s: unsupported attachment type", file.Filename)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := storage.NewObjectName()
+	if err != nil {
+		return nil, err
+	}
+	name += ext
+	key := storage.Key("orders", strconv.FormatUint(uint64(orderID), 10), name)
+	contentType := http.DetectContentType(data)
+
+	if err := s.blobStore.Put(context.Background(), key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return nil, err
+	}
+
+	return &models.OrderAttachment{
+		Name:        name,
+		Key:         key,
+		URL:         s.blobStore.URL(key),
+		FileName:    file.Filename,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		UploadedBy:  userID,
+		UploadedAt:  time.Now(),
+	}, nil
+}
+
+// removeBlobs deletes stored objects, logging failures instead of returning them
+func (s *orderService) removeBlobs(keys ...string) {
+	for _, key := range keys {
+		if err := s.blobStore.Delete(context.Background(), key); err != nil {
+			log.Printf("Warning: failed to delete blob %s: %v", key, err)
+		}
+	}
+}
+
+// orderDetail adds the items, attachments and status history
+func (s *orderService) orderDetail(order *models.Order) (*response.OrderDetailResponse, error) {
+	history, err := s.orderRepo.FindHistory(order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &response.OrderDetailResponse{
+		OrderResponse: *convertOrderToResponse(order),
+		Items:         make([]response.OrderItemResponse, len(order.Items)),
+		Attachments:   []response.OrderAttachmentResponse{},
+		History:       make([]response.OrderHistoryResponse, len(history)),
+	}
+	for i, item := range order.Items {
+		detail.Items[i] = response.OrderItemResponse{
+			ID:        item.ID,
+			ProductID: item.ProductID,
+			SKU:       item.Product.SKU,
+			Color:     item.Product.Color,
+			ColorCode: item.ColorCode,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+			Subtotal:  item.Subtotal,
+			Notes:     item.Notes,
+		}
+	}
+	for _, attachment := range orderAttachments(order) {
+		detail.Attachments = append(detail.Attachments, response.OrderAttachmentResponse{
+			Name:        attachment.Name,
+			URL:         attachment.URL,
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+			UploadedBy:  attachment.UploadedBy,
+			UploadedAt:  attachment.UploadedAt,
+		})
+	}
+	for i, entry := range history {
+		detail.History[i] = response.OrderHistoryResponse{
+			ID:            entry.ID,
+			Status:        entry.Status.StatusName,
+			ChangedAt:     entry.ChangedAt,
+			ChangedBy:     entry.ChangedBy,
+			ChangedByName: entry.User.Username,
+			Notes:         entry.Notes,
+		}
+	}
+
+	return detail, nil
+}
+
+// orderAttachments decodes the attachment list kept in orders.file_attachment
+func orderAttachments(order *models.Order) []models.OrderAttachment {
+	var attachments []models.OrderAttachment
+	if order.FileAttachment == "" {
+		return attachments
+	}
+	if err := json.Unmarshal([]byte(order.FileAttachment), &attachments); err != nil {
+		log.Printf("Warning: invalid attachments on order %d: %v", order.ID, err)
+		return nil
+	}
+	return attachments
+}
+
+func setOrderAttachments(order *models.Order, attachments []models.OrderAttachment) error {
+	if len(attachments) == 0 {
+		order.FileAttachment = ""
+		return nil
+	}
+	encoded, err := json.Marshal(attachments)
+	if err != nil {
+		return err
+	}
+	order.FileAttachment = string(encoded)
+	return nil
+}
+
+func findOrderStatus(statuses []models.OrderStatus, name string) *models.OrderStatus {
+	for i := range statuses {
+		if strings.EqualFold(statuses[i].StatusName, name) {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// roundMoney rounds an amount to the two decimals stored in DECIMAL(15,2) columns
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// roundQuantity rounds meters, kilograms, hours or a percentage to the two decimals of the quantity columns
+func roundQuantity(quantity float64) float64 {
+	return math.Round(quantity*100) / 100
+}
+
+// Helper function to convert model to response DTO
+func convertOrderToResponse(order *models.Order) *response.OrderResponse {
+	return &response.OrderResponse{
+		ID:           order.ID,
+		OrderCode:    order.OrderCode,
+		CustomerID:   order.CustomerID,
+		CustomerCode: order.Customer.CustomerCode,
+		CustomerName: order.Customer.Name,
+		Status:       order.OrderStatus.StatusName,
+		TotalAmount:  order.TotalAmount,
+		Currency:     order.Currency,
+		DueDate:      order.DueDate,
+		Notes:        order.Notes,
+		CreatedAt:    order.CreatedAt,
+		UpdatedAt:    order.UpdatedAt,
+	}
+}
@@ -0,0 +1,356 @@
+// File: internal/domain/services/order_workflow.go
+// Tạo tại: internal/domain/services/order_workflow.go
+// Mục đích: Máy trạng thái đơn hàng (duyệt, sản xuất, tạm dừng, hủy, giao hàng) với quyền, điều kiện và tác vụ đi kèm
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+// Order actions
+const (
+	OrderActionApprove         = "approve"
+	OrderActionStartProduction = "start_production"
+	OrderActionHold            = "hold"
+	OrderActionResume          = "resume"
+	OrderActionShip            = "ship"
+	OrderActionComplete        = "complete"
+	OrderActionCancel          = "cancel"
+)
+
+var (
+	// ErrUnknownOrderAction is returned for an action missing from the state machine
+	ErrUnknownOrderAction = errors.New("unknown order action")
+	// ErrIllegalOrderTransition is returned when the action is not allowed from the current status
+	ErrIllegalOrderTransition = errors.New("illegal order transition")
+	// ErrOrderActionForbidden is returned when the user lacks the permission the action requires
+	ErrOrderActionForbidden = errors.New("insufficient permissions")
+	// ErrOrderGuardFailed wraps the reason a guard condition rejected the action
+	ErrOrderGuardFailed = errors.New("order action not possible")
+	// ErrOrderStatusChanged is returned when someone else changed the order status meanwhile
+	ErrOrderStatusChanged = interfaces.ErrOrderStatusChanged
+)
+
+// OrderTransitionContext is what guards and effects see of a transition
+type OrderTransitionContext struct {
+	Order  *models.Order
+	Action string
+	From   string
+	To     string
+	UserID uint
+	Note   string
+	Client request.ClientInfo
+	// Tx is the transaction that saves the status change; set only while effects run
+	Tx *gorm.DB
+}
+
+// OrderGuard rejects a transition by returning an error; the message is shown to the user
+type OrderGuard func(t *OrderTransitionContext) error
+
+// OrderEffect runs in the transaction that saves the status change and writes through t.Tx.
+// A failing effect rolls the change back.
+type OrderEffect func(t *OrderTransitionContext) error
+
+// OrderNotice runs once the status change is committed, e.g. to record a customer activity
+type OrderNotice func(t *OrderTransitionContext)
+
+// OrderTransition declares one action of the order state machine
+type OrderTransition struct {
+	Action string
+	From   []string
+	// To is the status the order ends in; empty means the status it had before it was put on hold
+	To string
+
+	// Permission required besides ORDER VIEW
+	Module     string
+	Permission string
+
+	NoteRequired bool
+	Guards       []OrderGuard
+	Effects      []OrderEffect
+	Notices      []OrderNotice
+}
+
+func (t *OrderTransition) allowedFrom(status string) bool {
+	for _, from := range t.From {
+		if from == status {
+			return true
+		}
+	}
+	return false
+}
+
+type OrderWorkflowService interface {
+	// Transitions lists the actions available on the order for the user, with the reason when one is blocked
+	Transitions(orderID uint, userID uint) ([]response.OrderTransitionResponse, error)
+	Apply(orderID uint, action string, req request.OrderTransitionRequest, userID uint, client request.ClientInfo) (*response.OrderDetailResponse, error)
+
+	// AddGuard and AddEffect extend an action, e.g. to release reserved stock when an order is cancelled
+	AddGuard(action string, guard OrderGuard)
+	AddEffect(action string, effect OrderEffect)
+}
+
+type orderWorkflowService struct {
+	orderRepo       interfaces.OrderRepository
+	customerRepo    interfaces.CustomerRepository
+	permissionRepo  interfaces.PermissionRepository
+	orderService    OrderService
+	activityService CustomerActivityService
+	transitions     map[string]*OrderTransition
+	actions         []string
+}
+
+func NewOrderWorkflowService(
+	orderRepo interfaces.OrderRepository,
+	customerRepo interfaces.CustomerRepository,
+	permissionRepo interfaces.PermissionRepository,
+	orderService OrderService,
+	activityService CustomerActivityService,
+) OrderWorkflowService {
+	s := &orderWorkflowService{
+		orderRepo:       orderRepo,
+		customerRepo:    customerRepo,
+		permissionRepo:  permissionRepo,
+		orderService:    orderService,
+		activityService: activityService,
+		transitions:     make(map[string]*OrderTransition),
+	}
+
+	active := []string{models.OrderStatusPending, models.OrderStatusConfirmed, models.OrderStatusInProduction}
+	for _, t := range []*OrderTransition{
+		{
+			Action: OrderActionApprove, From: []string{models.OrderStatusPending}, To: models.OrderStatusConfirmed,
+			Module: "ORDER", Permission: "APPROVE",
+			Guards: []OrderGuard{orderHasItems, s.customerNotBlocked},
+		},
+		{
+			Action: OrderActionStartProduction, From: []string{models.OrderStatusConfirmed}, To: models.OrderStatusInProduction,
+			Module: "ORDER", Permission: "UPDATE",
+		},
+		{
+			Action: OrderActionHold, From: active, To: models.OrderStatusOnHold,
+			Module: "ORDER", Permission: "UPDATE", NoteRequired: true,
+		},
+		{
+			Action: OrderActionResume, From: []string{models.OrderStatusOnHold},
+			Module: "ORDER", Permission: "UPDATE",
+		},
+		{
+			Action: OrderActionShip, From: []string{models.OrderStatusConfirmed, models.OrderStatusInProduction}, To: models.OrderStatusShipped,
+			Module: "ORDER", Permission: "SHIP",
+			Guards: []OrderGuard{s.hasPackingList},
+		},
+		{
+			Action: OrderActionComplete, From: []string{models.OrderStatusShipped}, To: models.OrderStatusCompleted,
+			Module: "ORDER", Permission: "UPDATE",
+		},
+		{
+			Action: OrderActionCancel, From: []string{models.OrderStatusPending, models.OrderStatusConfirmed, models.OrderStatusInProduction, models.OrderStatusOnHold}, To: models.OrderStatusCancelled,
+			Module: "ORDER", Permission: "CANCEL", NoteRequired: true,
+			Notices: []OrderNotice{s.recordCancelled},
+		},
+	} {
+		s.transitions[t.Action] = t
+		s.actions = append(s.actions, t.Action)
+	}
+
+	return s
+}
+
+func (s *orderWorkflowService) AddGuard(action string, guard OrderGuard) {
+	if t, ok := s.transitions[action]; ok {
+		t.Guards = append(t.Guards, guard)
+	}
+}
+
+func (s *orderWorkflowService) AddEffect(action string, effect OrderEffect) {
+	if t, ok := s.transitions[action]; ok {
+		t.Effects = append(t.Effects, effect)
+	}
+}
+
+func (s *orderWorkflowService) Transitions(orderID uint, userID uint) ([]response.OrderTransitionResponse, error) {
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	from := order.OrderStatus.StatusName
+	result := []response.OrderTransitionResponse{}
+	for _, action := range s.actions {
+		t := s.transitions[action]
+		if !t.allowedFrom(from) {
+			continue
+		}
+
+		to, err := s.targetStatus(t, order)
+		if err != nil {
+			return nil, err
+		}
+		item := response.OrderTransitionResponse{
+			Action:       t.Action,
+			To:           to,
+			Permission:   t.Module + "_" + t.Permission,
+			NoteRequired: t.NoteRequired,
+			Allowed:      true,
+		}
+
+		if ok, err := s.permissionRepo.UserHasPermission(userID, t.Module, t.Permission, ""); err != nil {
+			return nil, err
+		} else if !ok {
+			item.Allowed = false
+			item.Reason = fmt.Sprintf("requires permission %s", item.Permission)
+		} else if err := runGuards(t, &OrderTransitionContext{Order: order, Action: t.Action, From: from, To: to, UserID: userID}); err != nil {
+			item.Allowed = false
+			item.Reason = err.Error()
+		}
+
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+func (s *orderWorkflowService) Apply(orderID uint, action string, req request.OrderTransitionRequest, userID uint, client request.ClientInfo) (*response.OrderDetailResponse, error) {
+	t, ok := s.transitions[action]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownOrderAction, action)
+	}
+
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	from := order.OrderStatus.StatusName
+	if !t.allowedFrom(from) {
+		return nil, fmt.Errorf("%w: cannot %s an order that is %s (allowed from %s)",
+			ErrIllegalOrderTransition, t.Action, from, strings.Join(t.From, ", "))
+	}
+
+	if ok, err := s.permissionRepo.UserHasPermission(userID, t.Module, t.Permission, ""); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, fmt.Errorf("%w: %s requires permission %s_%s", ErrOrderActionForbidden, t.Action, t.Module, t.Permission)
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if t.NoteRequired && note == "" {
+		return nil, fmt.Errorf("a note is required to %s an order", t.Action)
+	}
+
+	to, err := s.targetStatus(t, order)
+	if err != nil {
+		return nil, err
+	}
+	status, err := s.orderRepo.FindStatusByName(to)
+	if err != nil {
+		return nil, fmt.Errorf("order status %s is not configured", to)
+	}
+
+	ctx := &OrderTransitionContext{Order: order, Action: t.Action, From: from, To: to, UserID: userID, Note: note, Client: client}
+	if err := runGuards(t, ctx); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOrderGuardFailed, err)
+	}
+
+	fromStatusID := order.OrderStatusID
+	order.OrderStatusID = status.ID
+	order.OrderStatus = *status
+	history := &models.OrderHistory{StatusID: status.ID, ChangedAt: time.Now(), ChangedBy: userID, Notes: note}
+	err = s.orderRepo.ChangeStatus(order, fromStatusID, history, func(tx *gorm.DB) error {
+		ctx.Tx = tx
+		defer func() { ctx.Tx = nil }()
+		for _, effect := range t.Effects {
+			if err := effect(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, notice := range t.Notices {
+		notice(ctx)
+	}
+
+	return s.orderService.GetOrderByID(order.ID)
+}
+
+// targetStatus resolves the status the transition leads to. Resume goes back to the status recorded
+// before the latest move to ON_HOLD, or PENDING when the history has none.
+func (s *orderWorkflowService) targetStatus(t *OrderTransition, order *models.Order) (string, error) {
+	if t.To != "" {
+		return t.To, nil
+	}
+
+	history, err := s.orderRepo.FindHistory(order.ID)
+	if err != nil {
+		return "", err
+	}
+	// History is oldest first
+	for i := len(history) - 1; i > 0; i-- {
+		if history[i].Status.StatusName == models.OrderStatusOnHold {
+			return history[i-1].Status.StatusName, nil
+		}
+	}
+	return models.OrderStatusPending, nil
+}
+
+func runGuards(t *OrderTransition, ctx *OrderTransitionContext) error {
+	for _, guard := range t.Guards {
+		if err := guard(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func orderHasItems(t *OrderTransitionContext) error {
+	if len(t.Order.Items) == 0 {
+		return errors.New("order has no items")
+	}
+	return nil
+}
+
+func (s *orderWorkflowService) customerNotBlocked(t *OrderTransitionContext) error {
+	customer, err := s.customerRepo.FindByID(t.Order.CustomerID)
+	if err != nil {
+		return ErrCustomerNotFound
+	}
+	if customer.AccountStatus == models.CustomerStatusBlocked {
+		return fmt.Errorf("customer %s is blocked", customer.CustomerCode)
+	}
+	return nil
+}
+
+func (s *orderWorkflowService) hasPackingList(t *OrderTransitionContext) error {
+	count, err := s.orderRepo.CountPackingLists(t.Order.ID)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("order has no packing list; create one before shipping")
+	}
+	return nil
+}
+
+func (s *orderWorkflowService) recordCancelled(t *OrderTransitionContext) {
+	s.activityService.Record(t.Order.CustomerID, models.CustomerActivityOrderCancelled, map[string]interface{}{
+		"order_id":     t.Order.ID,
+		"order_code":   t.Order.OrderCode,
+		"total_amount": t.Order.TotalAmount,
+		"reason":       t.Note,
+	}, t.Client)
+}
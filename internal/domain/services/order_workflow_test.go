@@ -0,0 +1,117 @@
+// File: internal/domain/services/order_workflow_test.go
+// Tạo tại: internal/domain/services/order_workflow_test.go
+// Mục đích: Kiểm thử bảng chuyển trạng thái đơn hàng và trạng thái đích khi tiếp tục đơn tạm dừng
+
+package services
+
+import (
+	"testing"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+// historyOrderRepo answers FindHistory from memory; other methods are not used by these tests
+type historyOrderRepo struct {
+	interfaces.OrderRepository
+	history []models.OrderHistory
+}
+
+func (r *historyOrderRepo) FindHistory(orderID uint) ([]models.OrderHistory, error) {
+	return r.history, nil
+}
+
+func orderHistory(statuses ...string) []models.OrderHistory {
+	history := make([]models.OrderHistory, len(statuses))
+	for i, status := range statuses {
+		history[i].Status.StatusName = status
+	}
+	return history
+}
+
+func TestOrderTransitionTable(t *testing.T) {
+	s := NewOrderWorkflowService(&historyOrderRepo{}, nil, nil, nil, nil).(*orderWorkflowService)
+	statuses := []string{
+		models.OrderStatusPending, models.OrderStatusConfirmed, models.OrderStatusInProduction,
+		models.OrderStatusOnHold, models.OrderStatusShipped, models.OrderStatusCompleted, models.OrderStatusCancelled,
+	}
+	allowed := map[string][]string{
+		OrderActionApprove:         {models.OrderStatusPending},
+		OrderActionStartProduction: {models.OrderStatusConfirmed},
+		OrderActionHold:            {models.OrderStatusPending, models.OrderStatusConfirmed, models.OrderStatusInProduction},
+		OrderActionResume:          {models.OrderStatusOnHold},
+		OrderActionShip:            {models.OrderStatusConfirmed, models.OrderStatusInProduction},
+		OrderActionComplete:        {models.OrderStatusShipped},
+		OrderActionCancel: {models.OrderStatusPending, models.OrderStatusConfirmed, models.OrderStatusInProduction,
+			models.OrderStatusOnHold},
+	}
+	targets := map[string]string{
+		OrderActionApprove:         models.OrderStatusConfirmed,
+		OrderActionStartProduction: models.OrderStatusInProduction,
+		OrderActionHold:            models.OrderStatusOnHold,
+		OrderActionShip:            models.OrderStatusShipped,
+		OrderActionComplete:        models.OrderStatusCompleted,
+		OrderActionCancel:          models.OrderStatusCancelled,
+	}
+
+	if len(s.transitions) != len(allowed) {
+		t.Fatalf("state machine has %d actions, want %d", len(s.transitions), len(allowed))
+	}
+	for action, from := range allowed {
+		transition, ok := s.transitions[action]
+		if !ok {
+			t.Errorf("action %s is missing", action)
+			continue
+		}
+		for _, status := range statuses {
+			want := false
+			for _, f := range from {
+				want = want || f == status
+			}
+			if got := transition.allowedFrom(status); got != want {
+				t.Errorf("%s from %s: allowed = %v, want %v", action, status, got, want)
+			}
+		}
+		if transition.To != targets[action] {
+			t.Errorf("%s: to = %q, want %q", action, transition.To, targets[action])
+		}
+	}
+}
+
+func TestOrderTargetStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		history []models.OrderHistory
+		want    string
+	}{
+		{name: "fixed target", action: OrderActionApprove, want: models.OrderStatusConfirmed},
+		{
+			name:    "resume to the status before the hold",
+			action:  OrderActionResume,
+			history: orderHistory(models.OrderStatusPending, models.OrderStatusConfirmed, models.OrderStatusInProduction, models.OrderStatusOnHold),
+			want:    models.OrderStatusInProduction,
+		},
+		{
+			name:   "latest hold wins",
+			action: OrderActionResume,
+			history: orderHistory(models.OrderStatusPending, models.OrderStatusOnHold, models.OrderStatusPending,
+				models.OrderStatusConfirmed, models.OrderStatusOnHold),
+			want: models.OrderStatusConfirmed,
+		},
+		{
+			name:    "hold without an earlier status",
+			action:  OrderActionResume,
+			history: orderHistory(models.OrderStatusOnHold),
+			want:    models.OrderStatusPending,
+		},
+		{name: "no history", action: OrderActionResume, want: models.OrderStatusPending},
+	}
+	for _, tt := range tests {
+		s := NewOrderWorkflowService(&historyOrderRepo{history: tt.history}, nil, nil, nil, nil).(*orderWorkflowService)
+		got, err := s.targetStatus(s.transitions[tt.action], &models.Order{})
+		if err != nil || got != tt.want {
+			t.Errorf("%s: targetStatus = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
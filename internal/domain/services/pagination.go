@@ -0,0 +1,126 @@
+// File: internal/domain/services/pagination.go
+// Tạo tại: internal/domain/services/pagination.go
+// Mục đích: Mã hóa/giải mã cursor và tính tổng (chính xác/ước lượng) cho phân trang keyset
+
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+const maxCursorPageLimit = 100
+
+const (
+	totalExact    = "exact"
+	totalEstimate = "estimate"
+	totalNone     = "none"
+)
+
+// pageCounter is implemented by repositories that support cursor pagination
+type pageCounter interface {
+	Count(spec interfaces.QuerySpec) (int64, error)
+	EstimateCount(spec interfaces.QuerySpec) (int64, error)
+}
+
+// encodeCursor turns a keyset position into an opaque token
+func encodeCursor(cursor *interfaces.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a token from encodeCursor; an empty token means the first page
+func decodeCursor(token string) (*interfaces.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	var cursor interfaces.Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Field == "" {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	return &cursor, nil
+}
+
+// cursorSort resolves the single keyset sort field and checks the cursor was issued for it
+func cursorSort(sorts []interfaces.SortField, defaultSort interfaces.SortField, cursor *interfaces.Cursor) (interfaces.SortField, error) {
+	if len(sorts) > 1 {
+		return defaultSort, fmt.Errorf("%w: cursor pagination supports a single sort field", ErrInvalidQuery)
+	}
+	sort := defaultSort
+	if len(sorts) == 1 {
+		sort = sorts[0]
+	}
+	if cursor != nil && (cursor.Field != sort.Field || cursor.Desc != sort.Desc) {
+		return sort, fmt.Errorf("%w: cursor does not match the requested sort order", ErrInvalidQuery)
+	}
+	return sort, nil
+}
+
+// parseSort parses "field,-field" into sort fields limited to allowed; sortOrder is the direction of unprefixed fields
+func parseSort(sortBy, sortOrder string, allowed map[string]bool) ([]interfaces.SortField, error) {
+	var sort []interfaces.SortField
+	for _, value := range strings.Split(sortBy, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		field := interfaces.SortField{Field: value, Desc: sortOrder == "desc"}
+		switch value[0] {
+		case '-':
+			field = interfaces.SortField{Field: value[1:], Desc: true}
+		case '+':
+			field = interfaces.SortField{Field: value[1:], Desc: false}
+		}
+		if !allowed[field.Field] {
+			return nil, fmt.Errorf("%w: unsupported sort field %q", ErrInvalidQuery, field.Field)
+		}
+		sort = append(sort, field)
+	}
+	return sort, nil
+}
+
+// cursorPageLimit applies the default and maximum page size
+func cursorPageLimit(limit int) int {
+	if limit <= 0 {
+		return 10
+	}
+	if limit > maxCursorPageLimit {
+		return maxCursorPageLimit
+	}
+	return limit
+}
+
+// pageTotal returns the total for a cursor page according to mode; nil when not requested
+func pageTotal(counter pageCounter, spec interfaces.QuerySpec, mode string) (*int64, bool, error) {
+	switch mode {
+	case totalExact:
+		count, err := counter.Count(spec)
+		if err != nil {
+			return nil, false, err
+		}
+		return &count, false, nil
+	case totalEstimate:
+		count, err := counter.EstimateCount(spec)
+		if err != nil {
+			return nil, false, err
+		}
+		return &count, true, nil
+	default:
+		return nil, false, nil
+	}
+}
@@ -0,0 +1,115 @@
+// File: internal/domain/services/pagination_test.go
+// Tạo tại: internal/domain/services/pagination_test.go
+// Mục đích: Kiểm thử phân tích tham số sắp xếp, mã hóa/giải mã cursor và giới hạn trang
+
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+func TestParseSort(t *testing.T) {
+	allowed := map[string]bool{"sku": true, "created_at": true}
+	tests := []struct {
+		name      string
+		sortBy    string
+		sortOrder string
+		want      []interfaces.SortField
+		wantErr   bool
+	}{
+		{name: "empty", sortBy: ""},
+		{name: "ascending by default", sortBy: "sku", want: []interfaces.SortField{{Field: "sku"}}},
+		{name: "sort order applies to unprefixed fields", sortBy: "sku", sortOrder: "desc",
+			want: []interfaces.SortField{{Field: "sku", Desc: true}}},
+		{name: "prefixes override the sort order", sortBy: "-created_at, +sku", sortOrder: "desc",
+			want: []interfaces.SortField{{Field: "created_at", Desc: true}, {Field: "sku"}}},
+		{name: "blank entries are skipped", sortBy: ",sku,,", want: []interfaces.SortField{{Field: "sku"}}},
+		{name: "unknown field", sortBy: "sku,-password", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseSort(tt.sortBy, tt.sortOrder, allowed)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Errorf("%s: error = %v, want ErrInvalidQuery", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parseSort = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	created := time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)
+	cursor := &interfaces.Cursor{Field: "created_at", Desc: true, Time: &created, ID: 42}
+
+	got, err := decodeCursor(encodeCursor(cursor))
+	if err != nil {
+		t.Fatalf("round trip: unexpected error %v", err)
+	}
+	if got.Field != cursor.Field || got.Desc != cursor.Desc || got.ID != cursor.ID || !got.Time.Equal(created) {
+		t.Errorf("round trip = %+v, want %+v", got, cursor)
+	}
+
+	if got, err := decodeCursor(""); got != nil || err != nil {
+		t.Errorf(`decodeCursor("") = %v, %v, want nil, nil`, got, err)
+	}
+
+	for name, token := range map[string]string{
+		"not base64":    "%%%",
+		"not json":      base64.RawURLEncoding.EncodeToString([]byte("sku")),
+		"missing field": base64.RawURLEncoding.EncodeToString([]byte(`{"id":1}`)),
+	} {
+		if _, err := decodeCursor(token); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("%s: error = %v, want ErrInvalidQuery", name, err)
+		}
+	}
+}
+
+func TestCursorSort(t *testing.T) {
+	defaultSort := interfaces.SortField{Field: "id", Desc: true}
+	tests := []struct {
+		name    string
+		sorts   []interfaces.SortField
+		cursor  *interfaces.Cursor
+		want    interfaces.SortField
+		wantErr bool
+	}{
+		{name: "default", want: defaultSort},
+		{name: "requested field", sorts: []interfaces.SortField{{Field: "sku"}}, want: interfaces.SortField{Field: "sku"}},
+		{name: "single field only", sorts: []interfaces.SortField{{Field: "sku"}, {Field: "id"}}, wantErr: true},
+		{name: "matching cursor", cursor: &interfaces.Cursor{Field: "id", Desc: true}, want: defaultSort},
+		{name: "cursor of another order", cursor: &interfaces.Cursor{Field: "id"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := cursorSort(tt.sorts, defaultSort, tt.cursor)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Errorf("%s: error = %v, want ErrInvalidQuery", tt.name, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s: cursorSort = %+v, %v, want %+v", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestCursorPageLimit(t *testing.T) {
+	for limit, want := range map[int]int{-1: 10, 0: 10, 25: 25, maxCursorPageLimit + 1: maxCursorPageLimit} {
+		if got := cursorPageLimit(limit); got != want {
+			t.Errorf("cursorPageLimit(%d) = %d, want %d", limit, got, want)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+// File: internal/domain/services/portal.go
+// Tạo tại: internal/domain/services/portal.go
+// Mục đích: Service cổng portal khách hàng: đăng nhập, xem đơn hàng, giao hàng, mẫu gửi, lap dip và duyệt/từ chối lap dip
+
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/auth"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrLapDipNotFound = errors.New("lap dip not found")
+
+	// ErrLapDipAnswered is returned when a lap dip was already approved or rejected
+	ErrLapDipAnswered = interfaces.ErrLapDipAnswered
+)
+
+// PortalService serves the customer portal. Every read takes the customer ID from the
+// portal token, so a buyer only ever sees their own company's records.
+type PortalService interface {
+	Login(req request.PortalLoginRequest) (*response.PortalLoginResponse, error)
+	GetProfile(accountID uint) (*response.PortalAccountResponse, error)
+	GetOrders(customerID uint, req request.PortalOrderFilterRequest) (*response.PaginatedResponse, error)
+	GetOrder(customerID, orderID uint) (*response.PortalOrderDetailResponse, error)
+	GetShipments(customerID uint, req request.PortalShipmentFilterRequest) (*response.PaginatedResponse, error)
+	GetSampleDispatches(customerID uint, req request.PortalListRequest) (*response.PaginatedResponse, error)
+	GetLapDips(customerID uint, req request.PortalLapDipFilterRequest) (*response.PaginatedResponse, error)
+	GetLapDip(customerID, dispatchID uint) (*response.PortalLapDipResponse, error)
+	ApproveLapDip(customerID, dispatchID uint, req request.ApproveLapDipRequest, client request.ClientInfo) (*response.PortalLapDipResponse, error)
+	RejectLapDip(customerID, dispatchID uint, req request.RejectLapDipRequest) (*response.PortalLapDipResponse, error)
+}
+
+type portalService struct {
+	accountRepo     interfaces.CustomerAccountRepository
+	portalRepo      interfaces.PortalRepository
+	jwtService      auth.CustomerJWTService
+	activityService CustomerActivityService
+}
+
+func NewPortalService(
+	accountRepo interfaces.CustomerAccountRepository,
+	portalRepo interfaces.PortalRepository,
+	jwtService auth.CustomerJWTService,
+	activityService CustomerActivityService,
+) PortalService {
+	return &portalService{
+		accountRepo:     accountRepo,
+		portalRepo:      portalRepo,
+		jwtService:      jwtService,
+		activityService: activityService,
+	}
+}
+
+func (s *portalService) Login(req request.PortalLoginRequest) (*response.PortalLoginResponse, error) {
+	account, err := s.accountRepo.FindByEmail(strings.ToLower(strings.TrimSpace(req.Email)))
+	if err != nil {
+		return nil, errors.New("invalid credentials")
+	}
+
+	// Check password
+	if err := bcrypt.CompareHashAndPassword([]byte(account.PasswordHash), []byte(req.Password)); err != nil {
+		return nil, errors.New("invalid credentials")
+	}
+
+	// Deleted or blocked customers lose portal access along with the account
+	if account.Status != models.CustomerAccountActive || account.Customer.ID == 0 ||
+		account.Customer.AccountStatus == models.CustomerStatusBlocked {
+		return nil, errors.New("account is disabled")
+	}
+
+	token, expiresAt, err := s.jwtService.GenerateToken(account.ID, account.CustomerID, account.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	account.LastLogin = &now
+	if err := s.accountRepo.Update(account); err != nil {
+		return nil, err
+	}
+
+	return &response.PortalLoginResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		Account:   *convertPortalAccount(account),
+	}, nil
+}
+
+func (s *portalService) GetProfile(accountID uint) (*response.PortalAccountResponse, error) {
+	account, err := s.accountRepo.FindByID(accountID)
+	if err != nil {
+		return nil, errors.New("account not found")
+	}
+	return convertPortalAccount(account), nil
+}
+
+func (s *portalService) GetOrders(customerID uint, req request.PortalOrderFilterRequest) (*response.PaginatedResponse, error) {
+	page, limit := portalPage(req.Page, req.Limit)
+
+	orders, total, err := s.portalRepo.FindOrders(customerID, strings.TrimSpace(req.Status), page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(orders))
+	for i := range orders {
+		items[i] = convertPortalOrder(&orders[i])
+	}
+	return portalPaginated(items, total, page, limit), nil
+}
+
+func (s *portalService) GetOrder(customerID, orderID uint) (*response.PortalOrderDetailResponse, error) {
+	order, err := s.portalRepo.FindOrder(customerID, orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	detail := &response.PortalOrderDetailResponse{
+		PortalOrderResponse: *convertPortalOrder(order),
+		Items:               make([]response.PortalOrderItemResponse, len(order.Items)),
+	}
+	for i, item := range order.Items {
+		detail.Items[i] = response.PortalOrderItemResponse{
+			ID:        item.ID,
+			ProductID: item.ProductID,
+			SKU:       item.Product.SKU,
+			Color:     item.Product.Color,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+			Subtotal:  item.Subtotal,
+		}
+	}
+	return detail, nil
+}
+
+func (s *portalService) GetShipments(customerID uint, req request.PortalShipmentFilterRequest) (*response.PaginatedResponse, error) {
+	page, limit := portalPage(req.Page, req.Limit)
+
+	shipments, total, err := s.portalRepo.FindShipments(customerID, req.OrderID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(shipments))
+	for i, shipment := range shipments {
+		var tracking interface{}
+		if shipment.TrackingInfo != "" {
+			if err := json.Unmarshal([]byte(shipment.TrackingInfo), &tracking); err != nil {
+				tracking = shipment.TrackingInfo
+			}
+		}
+		items[i] = response.PortalShipmentResponse{
+			ID:                   shipment.ID,
+			OrderID:              shipment.OrderID,
+			OrderCode:            shipment.Order.OrderCode,
+			DeliveryStatus:       shipment.DeliveryStatus,
+			TrackingInfo:         tracking,
+			TransportationMethod: shipment.TransportationMethod,
+			VehicleNumber:        shipment.VehicleNumber,
+			ShippingDate:         shipment.ShippingDate,
+			ReceivedBy:           shipment.ReceivedBy,
+			ReturnedQuantity:     shipment.ReturnedQuantity,
+			ReturnedWeight:       shipment.ReturnedWeight,
+		}
+	}
+	return portalPaginated(items, total, page, limit), nil
+}
+
+func (s *portalService) GetSampleDispatches(customerID uint, req request.PortalListRequest) (*response.PaginatedResponse, error) {
+	page, limit := portalPage(req.Page, req.Limit)
+
+	dispatches, total, err := s.portalRepo.FindSampleDispatches(customerID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(dispatches))
+	for i, dispatch := range dispatches {
+		items[i] = response.CustomerSampleDispatchSummary{
+			ID:               dispatch.ID,
+			SampleProductID:  dispatch.SampleProductID,
+			SampleSKU:        dispatch.SampleProduct.SKU,
+			DispatchDate:     dispatch.DispatchDate,
+			DispatchQuantity: dispatch.DispatchQuantity,
+			DispatchWeight:   dispatch.DispatchWeight,
+			DispatchColor:    dispatch.DispatchColor,
+			LotNumber:        dispatch.LotNumber,
+			TrackingNumber:   dispatch.TrackingNumber,
+		}
+	}
+	return portalPaginated(items, total, page, limit), nil
+}
+
+func (s *portalService) GetLapDips(customerID uint, req request.PortalLapDipFilterRequest) (*response.PaginatedResponse, error) {
+	page, limit := portalPage(req.Page, req.Limit)
+
+	dispatches, total, err := s.portalRepo.FindLapDipDispatches(customerID, req.Status, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(dispatches))
+	for i := range dispatches {
+		items[i] = convertPortalLapDip(&dispatches[i])
+	}
+	return portalPaginated(items, total, page, limit), nil
+}
+
+func (s *portalService) GetLapDip(customerID, dispatchID uint) (*response.PortalLapDipResponse, error) {
+	dispatch, err := s.portalRepo.FindLapDipDispatch(customerID, dispatchID)
+	if err != nil {
+		return nil, ErrLapDipNotFound
+	}
+	return convertPortalLapDip(dispatch), nil
+}
+
+func (s *portalService) ApproveLapDip(customerID, dispatchID uint, req request.ApproveLapDipRequest, client request.ClientInfo) (*response.PortalLapDipResponse, error) {
+	dispatch, err := s.pendingLapDip(customerID, dispatchID)
+	if err != nil {
+		return nil, err
+	}
+
+	code := strings.TrimSpace(req.FinalLDCode)
+	if results := lapDipResults(dispatch.LapDipTest.LDResults); len(results) > 0 && !slices.Contains(results, code) {
+		return nil, errors.New("final_ld_code is not one of the lap dip results")
+	}
+
+	now := time.Now()
+	dispatch.Status = models.LapDipApproved
+	dispatch.CustomerResponse = strings.TrimSpace(req.Comment)
+	dispatch.ResponseDate = &now
+	dispatch.FinalLDCode = code
+
+	selection := &models.LapDipFinalSelection{
+		LDTestID:             dispatch.LDTestID,
+		FinalLDCode:          code,
+		SelectedByCustomerID: customerID,
+		FinalSelectionDate:   now,
+		Notes:                dispatch.CustomerResponse,
+	}
+	if err := s.portalRepo.RespondLapDip(dispatch, selection); err != nil {
+		return nil, err
+	}
+
+	dispatch.LapDipTest.Status = models.LapDipApproved
+	dispatch.LapDipTest.SelectedLDCode = code
+
+	s.activityService.Record(customerID, models.CustomerActivityLapDipApproved, map[string]interface{}{
+		"lap_dip_dispatch_id": dispatch.ID,
+		"ld_test_id":          dispatch.LDTestID,
+		"ld_code":             dispatch.LapDipTest.LDCode,
+		"dispatch_code":       dispatch.DispatchCode,
+		"final_ld_code":       code,
+		"source":              "portal",
+	}, client)
+
+	return convertPortalLapDip(dispatch), nil
+}
+
+func (s *portalService) RejectLapDip(customerID, dispatchID uint, req request.RejectLapDipRequest) (*response.PortalLapDipResponse, error) {
+	dispatch, err := s.pendingLapDip(customerID, dispatchID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	dispatch.Status = models.LapDipRejected
+	dispatch.CustomerResponse = strings.TrimSpace(req.Reason)
+	dispatch.ResponseDate = &now
+
+	if err := s.portalRepo.RespondLapDip(dispatch, nil); err != nil {
+		return nil, err
+	}
+	dispatch.LapDipTest.Status = models.LapDipRejected
+
+	return convertPortalLapDip(dispatch), nil
+}
+
+// pendingLapDip loads a dispatch of the customer that still awaits an answer
+func (s *portalService) pendingLapDip(customerID, dispatchID uint) (*models.LapDipDispatch, error) {
+	dispatch, err := s.portalRepo.FindLapDipDispatch(customerID, dispatchID)
+	if err != nil {
+		return nil, ErrLapDipNotFound
+	}
+	if dispatch.Status != models.LapDipDispatched {
+		return nil, ErrLapDipAnswered
+	}
+	return dispatch, nil
+}
+
+// portalPage applies the default page and caps the page size
+func portalPage(page, limit int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return page, limit
+}
+
+func portalPaginated(items []interface{}, total int64, page, limit int) *response.PaginatedResponse {
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+}
+
+// lapDipResults decodes the JSON array of lap dip codes returned by the dyehouse
+func lapDipResults(raw string) []string {
+	var codes []string
+	if raw == "" || json.Unmarshal([]byte(raw), &codes) != nil {
+		return nil
+	}
+	return codes
+}
+
+func convertPortalAccount(account *models.CustomerAccount) *response.PortalAccountResponse {
+	return &response.PortalAccountResponse{
+		ID:        account.ID,
+		Email:     account.Email,
+		FullName:  account.FullName,
+		LastLogin: account.LastLogin,
+		Customer: response.PortalCustomerResponse{
+			ID:           account.Customer.ID,
+			CustomerCode: account.Customer.CustomerCode,
+			Name:         account.Customer.Name,
+			CompanyName:  account.Customer.CompanyName,
+		},
+	}
+}
+
+func convertPortalOrder(order *models.Order) *response.PortalOrderResponse {
+	return &response.PortalOrderResponse{
+		ID:          order.ID,
+		OrderCode:   order.OrderCode,
+		Status:      order.OrderStatus.StatusName,
+		TotalAmount: order.TotalAmount,
+		Currency:    order.Currency,
+		DueDate:     order.DueDate,
+		CreatedAt:   order.CreatedAt,
+		UpdatedAt:   order.UpdatedAt,
+	}
+}
+
+func convertPortalLapDip(dispatch *models.LapDipDispatch) *response.PortalLapDipResponse {
+	return &response.PortalLapDipResponse{
+		ID:               dispatch.ID,
+		DispatchCode:     dispatch.DispatchCode,
+		DispatchDate:     dispatch.DispatchDate,
+		Status:           dispatch.Status,
+		CustomerResponse: dispatch.CustomerResponse,
+		ResponseDate:     dispatch.ResponseDate,
+		FinalLDCode:      dispatch.FinalLDCode,
+		LDCode:           dispatch.LapDipTest.LDCode,
+		ColorName:        dispatch.LapDipTest.ColorName,
+		ColorCode:        dispatch.LapDipTest.ColorCode,
+		LDResults:        lapDipResults(dispatch.LapDipTest.LDResults),
+		ResultImage:      dispatch.LapDipTest.ResultImage,
+	}
+}
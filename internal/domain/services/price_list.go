@@ -0,0 +1,370 @@
+// File: internal/domain/services/price_list.go
+// Tạo tại: internal/domain/services/price_list.go
+// Mục đích: Service bảng giá theo khách hàng/nhóm giá với bậc số lượng, thời hạn và tra cứu giá áp dụng
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+// Price sources reported by a price lookup
+const (
+	PriceSourceList    = "price_list"
+	PriceSourceProduct = "product"
+)
+
+var (
+	// ErrPriceListNotFound is returned when a price list ID does not exist
+	ErrPriceListNotFound = errors.New("price list not found")
+	// ErrProductNotFound is returned when a priced product ID does not exist
+	ErrProductNotFound = errors.New("product not found")
+)
+
+type PriceListService interface {
+	GetPriceLists(req request.PriceListFilterRequest) (*response.PaginatedResponse, error)
+	GetPriceListByID(id uint) (*response.PriceListResponse, error)
+	CreatePriceList(req request.CreatePriceListRequest, userID uint) (*response.PriceListResponse, error)
+	UpdatePriceList(id uint, req request.UpdatePriceListRequest) (*response.PriceListResponse, error)
+	DeletePriceList(id uint) error
+
+	// ResolvePrice returns the unit price the customer gets for the product and quantity on the date
+	ResolvePrice(req request.ResolvePriceRequest) (*response.ResolvedPriceResponse, error)
+}
+
+type priceListService struct {
+	priceListRepo interfaces.PriceListRepository
+	customerRepo  interfaces.CustomerRepository
+	productRepo   interfaces.ProductRepository
+	rateService   ExchangeRateService
+}
+
+func NewPriceListService(
+	priceListRepo interfaces.PriceListRepository,
+	customerRepo interfaces.CustomerRepository,
+	productRepo interfaces.ProductRepository,
+	rateService ExchangeRateService,
+) PriceListService {
+	return &priceListService{
+		priceListRepo: priceListRepo,
+		customerRepo:  customerRepo,
+		productRepo:   productRepo,
+		rateService:   rateService,
+	}
+}
+
+func (s *priceListService) GetPriceLists(req request.PriceListFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	filter := interfaces.PriceListFilter{
+		CustomerID: req.CustomerID,
+		PriceTier:  strings.TrimSpace(req.PriceTier),
+	}
+	if req.Currency != "" {
+		currency, err := money.NormalizeCurrency(req.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+		filter.Currency = currency
+	}
+	if !req.ActiveOn.IsZero() {
+		filter.ActiveOn = &req.ActiveOn
+	}
+
+	lists, total, err := s.priceListRepo.FindAll(filter, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(lists))
+	for i := range lists {
+		items[i] = convertPriceListToResponse(&lists[i])
+	}
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.Limit))),
+	}, nil
+}
+
+func (s *priceListService) GetPriceListByID(id uint) (*response.PriceListResponse, error) {
+	list, err := s.priceListRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrPriceListNotFound
+	}
+	return convertPriceListToResponse(list), nil
+}
+
+func (s *priceListService) CreatePriceList(req request.CreatePriceListRequest, userID uint) (*response.PriceListResponse, error) {
+	list := &models.PriceList{
+		Name:       strings.TrimSpace(req.Name),
+		CustomerID: req.CustomerID,
+		PriceTier:  strings.TrimSpace(req.PriceTier),
+		Currency:   s.rateService.BaseCurrency(),
+		ValidFrom:  req.ValidFrom,
+		ValidTo:    req.ValidTo,
+		IsActive:   true,
+		Notes:      req.Notes,
+		CreatedBy:  &userID,
+	}
+	if req.IsActive != nil {
+		list.IsActive = *req.IsActive
+	}
+	if req.Currency != "" {
+		currency, err := money.NormalizeCurrency(req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		list.Currency = currency
+	}
+	if err := s.checkPriceList(list); err != nil {
+		return nil, err
+	}
+
+	items, err := s.buildPriceListItems(req.Items)
+	if err != nil {
+		return nil, err
+	}
+	list.Items = items
+
+	if err := s.priceListRepo.Create(list); err != nil {
+		return nil, err
+	}
+	return s.GetPriceListByID(list.ID)
+}
+
+func (s *priceListService) UpdatePriceList(id uint, req request.UpdatePriceListRequest) (*response.PriceListResponse, error) {
+	list, err := s.priceListRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrPriceListNotFound
+	}
+
+	if req.Name != nil {
+		list.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.CustomerID != nil {
+		list.CustomerID = req.CustomerID
+		if *req.CustomerID == 0 {
+			list.CustomerID = nil
+		}
+		list.Customer = nil
+	}
+	if req.PriceTier != nil {
+		list.PriceTier = strings.TrimSpace(*req.PriceTier)
+	}
+	if req.Currency != nil {
+		currency, err := money.NormalizeCurrency(*req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		list.Currency = currency
+	}
+	if req.ValidFrom != nil {
+		list.ValidFrom = req.ValidFrom
+	}
+	if req.ValidTo != nil {
+		list.ValidTo = req.ValidTo
+	}
+	if req.IsActive != nil {
+		list.IsActive = *req.IsActive
+	}
+	if req.Notes != nil {
+		list.Notes = *req.Notes
+	}
+	if list.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if err := s.checkPriceList(list); err != nil {
+		return nil, err
+	}
+
+	var items []models.PriceListItem
+	if req.Items != nil {
+		if items, err = s.buildPriceListItems(req.Items); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.priceListRepo.Update(list, items); err != nil {
+		return nil, err
+	}
+	return s.GetPriceListByID(list.ID)
+}
+
+func (s *priceListService) DeletePriceList(id uint) error {
+	if _, err := s.priceListRepo.FindByID(id); err != nil {
+		return ErrPriceListNotFound
+	}
+	return s.priceListRepo.Delete(id)
+}
+
+func (s *priceListService) ResolvePrice(req request.ResolvePriceRequest) (*response.ResolvedPriceResponse, error) {
+	customer, err := s.customerRepo.FindByID(req.CustomerID)
+	if err != nil {
+		return nil, ErrCustomerNotFound
+	}
+	product, err := s.productRepo.FindByID(req.ProductID)
+	if err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	currency := customerCurrency(customer)
+	if req.Currency != "" {
+		if currency, err = money.NormalizeCurrency(req.Currency); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+	}
+
+	date := req.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	return lookupPrice(s.priceListRepo, s.rateService, customer, product, currency, req.Quantity, date)
+}
+
+// checkPriceList rejects a list aimed at both a customer and a tier, or with an empty validity
+func (s *priceListService) checkPriceList(list *models.PriceList) error {
+	if list.CustomerID != nil && list.PriceTier != "" {
+		return errors.New("a price list is for a customer or a price tier, not both")
+	}
+	if list.CustomerID != nil {
+		if _, err := s.customerRepo.FindByID(*list.CustomerID); err != nil {
+			return ErrCustomerNotFound
+		}
+	}
+	if list.ValidFrom != nil && list.ValidTo != nil && list.ValidTo.Before(*list.ValidFrom) {
+		return errors.New("valid_to must not be before valid_from")
+	}
+	return nil
+}
+
+// buildPriceListItems checks the products and that each quantity break appears once
+func (s *priceListService) buildPriceListItems(reqItems []request.PriceListItemRequest) ([]models.PriceListItem, error) {
+	type priceBreak struct {
+		productID   uint
+		minQuantity float64
+	}
+	seen := make(map[priceBreak]bool, len(reqItems))
+
+	items := make([]models.PriceListItem, len(reqItems))
+	for i, item := range reqItems {
+		if _, err := s.productRepo.FindByID(item.ProductID); err != nil {
+			return nil, fmt.Errorf("product %d not found", item.ProductID)
+		}
+		key := priceBreak{item.ProductID, roundQuantity(item.MinQuantity)}
+		if seen[key] {
+			return nil, fmt.Errorf("product %d has two prices from quantity %v", item.ProductID, key.minQuantity)
+		}
+		seen[key] = true
+
+		items[i] = models.PriceListItem{
+			ProductID:   item.ProductID,
+			MinQuantity: key.minQuantity,
+			UnitPrice:   *item.UnitPrice,
+		}
+	}
+	return items, nil
+}
+
+// lookupPrice picks the price list price of the product in the currency for the customer, falling
+// back to the product's sales price, or its list price when no sales price is set, converted from
+// the product's currency at the rate of the date
+func lookupPrice(
+	repo interfaces.PriceListRepository,
+	rates ExchangeRateService,
+	customer *models.Customer,
+	product *models.Product,
+	currency string,
+	quantity float64,
+	date time.Time,
+) (*response.ResolvedPriceResponse, error) {
+	result := &response.ResolvedPriceResponse{
+		ProductID: product.ID,
+		Quantity:  quantity,
+		Currency:  currency,
+	}
+
+	applicable, err := repo.FindApplicable(customer.ID, customer.PriceTier, currency, product.ID, quantity, date)
+	if err != nil {
+		return nil, err
+	}
+	if applicable != nil {
+		result.UnitPrice = applicable.Item.UnitPrice
+		result.Source = PriceSourceList
+		result.PriceListID = &applicable.PriceList.ID
+		result.PriceListName = applicable.PriceList.Name
+		result.MinQuantity = applicable.Item.MinQuantity
+		return result, nil
+	}
+
+	price := product.SalesPrice
+	if price == 0 {
+		price = product.Price
+	}
+	productCurrency := product.Currency
+	if productCurrency == "" {
+		productCurrency = models.DefaultCurrency
+	}
+	if result.UnitPrice, err = rates.Convert(price, productCurrency, currency, date); err != nil {
+		return nil, err
+	}
+	result.Source = PriceSourceProduct
+	return result, nil
+}
+
+func convertPriceListToResponse(list *models.PriceList) *response.PriceListResponse {
+	res := &response.PriceListResponse{
+		ID:         list.ID,
+		Name:       list.Name,
+		CustomerID: list.CustomerID,
+		PriceTier:  list.PriceTier,
+		Currency:   list.Currency,
+		ValidFrom:  list.ValidFrom,
+		ValidTo:    list.ValidTo,
+		IsActive:   list.IsActive,
+		Notes:      list.Notes,
+		CreatedAt:  list.CreatedAt,
+		UpdatedAt:  list.UpdatedAt,
+	}
+	if list.Customer != nil {
+		res.CustomerCode = list.Customer.CustomerCode
+		res.CustomerName = list.Customer.Name
+	}
+	for _, item := range list.Items {
+		res.Items = append(res.Items, response.PriceListItemResponse{
+			ID:          item.ID,
+			ProductID:   item.ProductID,
+			SKU:         item.Product.SKU,
+			MinQuantity: item.MinQuantity,
+			UnitPrice:   item.UnitPrice,
+		})
+	}
+	return res
+}
+
+// customerCurrency is the currency the customer is invoiced in
+func customerCurrency(customer *models.Customer) string {
+	if customer.Currency == "" {
+		return models.DefaultCurrency
+	}
+	return customer.Currency
+}
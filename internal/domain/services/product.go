@@ -0,0 +1,120 @@
+// File: internal/domain/services/product.go
+// Tạo tại: internal/domain/services/product.go
+// Mục đích: Service xử lý logic nghiệp vụ cho Product (danh sách, chi tiết kèm hình ảnh)
+
+package services
+
+import (
+	"errors"
+	"math"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+type ProductService interface {
+	GetProducts(req request.ProductFilterRequest) (*response.PaginatedResponse, error)
+	GetProductByID(id uint) (*response.ProductResponse, error)
+}
+
+type productService struct {
+	productRepo interfaces.ProductRepository
+}
+
+func NewProductService(productRepo interfaces.ProductRepository) ProductService {
+	return &productService{
+		productRepo: productRepo,
+	}
+}
+
+func (s *productService) GetProducts(req request.ProductFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	products, total, err := s.productRepo.FindAll(req.Page, req.Limit, req.Search, req.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(products))
+	for i, product := range products {
+		items[i] = convertProductToResponse(&product)
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(req.Limit)))
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *productService) GetProductByID(id uint) (*response.ProductResponse, error) {
+	product, err := s.productRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("product not found")
+	}
+
+	return convertProductToResponse(product), nil
+}
+
+// Helper function to convert model to response DTO
+func convertProductToResponse(product *models.Product) *response.ProductResponse {
+	productResponse := &response.ProductResponse{
+		ID:             product.ID,
+		ProductNameID:  product.ProductNameID,
+		CategoryID:     product.CategoryID,
+		SKU:            product.SKU,
+		SKUVariant:     product.SKUVariant,
+		Description:    product.Description,
+		DescriptionEN:  product.DescriptionEN,
+		FabricType:     product.FabricType,
+		Weight:         product.Weight,
+		Width:          product.Width,
+		Color:          product.Color,
+		Quality:        product.Quality,
+		FiberContent:   product.FiberContent,
+		AdditionalInfo: product.AdditionalInfo,
+		Price:          product.Price,
+		SalesPrice:     product.SalesPrice,
+		Currency:       product.Currency,
+		StockQuantity:  product.StockQuantity,
+		CreatedAt:      product.CreatedAt,
+		UpdatedAt:      product.UpdatedAt,
+		Images:         convertProductImagesToResponse(product.Images),
+	}
+
+	if product.ProductName.ID != 0 {
+		productResponse.ProductName = &response.ProductNameResponse{
+			ID:            product.ProductName.ID,
+			ProductNameVI: product.ProductName.ProductNameVI,
+			ProductNameEN: product.ProductName.ProductNameEN,
+			SKUParent:     product.ProductName.SKUParent,
+		}
+	}
+
+	if product.Category.ID != 0 {
+		productResponse.Category = &response.CategoryResponse{
+			ID:               product.Category.ID,
+			CategoryName:     product.Category.CategoryName,
+			CategoryNameEN:   product.Category.CategoryNameEN,
+			ParentCategoryID: product.Category.ParentCategoryID,
+			Description:      product.Category.Description,
+			DescriptionEN:    product.Category.DescriptionEN,
+			CreatedAt:        product.Category.CreatedAt,
+			UpdatedAt:        product.Category.UpdatedAt,
+		}
+	}
+
+	return productResponse
+}
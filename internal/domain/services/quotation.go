@@ -0,0 +1,596 @@
+// File: internal/domain/services/quotation.go
+// Tạo tại: internal/domain/services/quotation.go
+// Mục đích: Service báo giá (dòng hàng, chiết khấu, hiệu lực, gửi/từ chối, in) và chuyển báo giá được chấp nhận thành đơn hàng
+
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+const (
+	quotationCodePrefix = "BG"
+	quotationCodeDigits = 6
+
+	// quotationValidDays is how long a quotation stays valid when no valid_until is given
+	quotationValidDays = 30
+)
+
+var (
+	// ErrQuotationNotFound is returned when a quotation ID does not exist
+	ErrQuotationNotFound = errors.New("quotation not found")
+	// ErrQuotationClosed is returned when a change needs a draft or sent quotation that is still valid
+	ErrQuotationClosed = errors.New("quotation is closed")
+	// ErrQuotationStatusChanged is returned when someone else changed the quotation status meanwhile
+	ErrQuotationStatusChanged = interfaces.ErrQuotationStatusChanged
+)
+
+// openQuotationStatuses are the stored statuses a quotation can still be edited and answered in
+var openQuotationStatuses = []string{models.QuotationDraft, models.QuotationSent}
+
+type QuotationService interface {
+	GetQuotations(req request.QuotationFilterRequest) (*response.PaginatedResponse, error)
+	GetQuotationByID(id uint) (*response.QuotationDetailResponse, error)
+	CreateQuotation(req request.CreateQuotationRequest, userID uint) (*response.QuotationDetailResponse, error)
+	UpdateQuotation(id uint, req request.UpdateQuotationRequest) (*response.QuotationDetailResponse, error)
+	DeleteQuotation(id uint) error
+
+	Send(id uint) (*response.QuotationDetailResponse, error)
+	Reject(id uint) (*response.QuotationDetailResponse, error)
+	// Accept converts the quotation into a PENDING order at the quoted net prices
+	Accept(id uint, req request.AcceptQuotationRequest, userID uint, client request.ClientInfo) (*response.OrderDetailResponse, error)
+
+	// Print renders the quotation as a printable HTML page
+	Print(id uint) ([]byte, error)
+}
+
+type quotationService struct {
+	quotationRepo interfaces.QuotationRepository
+	priceListRepo interfaces.PriceListRepository
+	customerRepo  interfaces.CustomerRepository
+	productRepo   interfaces.ProductRepository
+	orderService  OrderService
+	rateService   ExchangeRateService
+}
+
+func NewQuotationService(
+	quotationRepo interfaces.QuotationRepository,
+	priceListRepo interfaces.PriceListRepository,
+	customerRepo interfaces.CustomerRepository,
+	productRepo interfaces.ProductRepository,
+	orderService OrderService,
+	rateService ExchangeRateService,
+) QuotationService {
+	return &quotationService{
+		quotationRepo: quotationRepo,
+		priceListRepo: priceListRepo,
+		customerRepo:  customerRepo,
+		productRepo:   productRepo,
+		orderService:  orderService,
+		rateService:   rateService,
+	}
+}
+
+func (s *quotationService) GetQuotations(req request.QuotationFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	filter := interfaces.QuotationFilter{
+		Search:     strings.TrimSpace(req.Search),
+		CustomerID: req.CustomerID,
+		Today:      today(),
+	}
+	for _, status := range splitValues(req.Status) {
+		switch status = strings.ToLower(status); status {
+		case models.QuotationDraft, models.QuotationSent, models.QuotationAccepted, models.QuotationRejected, models.QuotationExpired:
+			filter.Statuses = append(filter.Statuses, status)
+		default:
+			return nil, fmt.Errorf("%w: unknown quotation status %q", ErrInvalidQuery, status)
+		}
+	}
+
+	quotations, total, err := s.quotationRepo.FindAll(filter, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(quotations))
+	for i := range quotations {
+		items[i] = convertQuotationToResponse(&quotations[i])
+	}
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.Limit))),
+	}, nil
+}
+
+func (s *quotationService) GetQuotationByID(id uint) (*response.QuotationDetailResponse, error) {
+	quotation, err := s.quotationRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrQuotationNotFound
+	}
+	return convertQuotationToDetail(quotation), nil
+}
+
+func (s *quotationService) CreateQuotation(req request.CreateQuotationRequest, userID uint) (*response.QuotationDetailResponse, error) {
+	customer, err := s.customerRepo.FindByID(req.CustomerID)
+	if err != nil {
+		return nil, ErrCustomerNotFound
+	}
+	if customer.AccountStatus == models.CustomerStatusBlocked {
+		return nil, errors.New("customer account is blocked")
+	}
+
+	quotation := &models.Quotation{
+		QuotationCode:   strings.TrimSpace(req.QuotationCode),
+		CustomerID:      customer.ID,
+		Currency:        customerCurrency(customer),
+		Status:          models.QuotationDraft,
+		IssueDate:       today(),
+		DiscountPercent: req.DiscountPercent,
+		Notes:           req.Notes,
+		Terms:           req.Terms,
+		CreatedBy:       &userID,
+	}
+	if req.Currency != "" {
+		if quotation.Currency, err = money.NormalizeCurrency(req.Currency); err != nil {
+			return nil, err
+		}
+	}
+	if req.IssueDate != nil {
+		quotation.IssueDate = dateOnly(*req.IssueDate)
+	}
+	quotation.ValidUntil = quotation.IssueDate.AddDate(0, 0, quotationValidDays)
+	if req.ValidUntil != nil {
+		quotation.ValidUntil = dateOnly(*req.ValidUntil)
+	}
+	if quotation.ValidUntil.Before(quotation.IssueDate) {
+		return nil, errors.New("valid_until must not be before issue_date")
+	}
+
+	if quotation.Items, err = s.buildQuotationItems(customer, quotation.Currency, req.Items, quotation.IssueDate); err != nil {
+		return nil, err
+	}
+	applyQuotationTotals(quotation, quotation.Items)
+
+	// Explicit code: must be unused
+	if quotation.QuotationCode != "" {
+		if existing, _ := s.quotationRepo.FindByCode(quotation.QuotationCode); existing != nil {
+			return nil, errors.New("quotation code already exists")
+		}
+		if err := s.quotationRepo.Create(quotation); err != nil {
+			return nil, err
+		}
+		return s.GetQuotationByID(quotation.ID)
+	}
+
+	// Generated code: retry with the next number when a concurrent create took it
+	for attempt := 0; attempt < orderCodeAttempts; attempt++ {
+		code, err := s.nextQuotationCode()
+		if err != nil {
+			return nil, err
+		}
+		quotation.ID = 0
+		quotation.QuotationCode = code
+		for i := range quotation.Items {
+			quotation.Items[i].ID = 0
+		}
+
+		err = s.quotationRepo.Create(quotation)
+		if err == nil {
+			return s.GetQuotationByID(quotation.ID)
+		}
+		if existing, _ := s.quotationRepo.FindByCode(code); existing == nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("could not generate a unique quotation code")
+}
+
+// UpdateQuotation changes a draft or sent quotation. New items are priced on the issue date.
+func (s *quotationService) UpdateQuotation(id uint, req request.UpdateQuotationRequest) (*response.QuotationDetailResponse, error) {
+	quotation, err := s.quotationRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrQuotationNotFound
+	}
+	if !isOpenQuotation(quotation) {
+		return nil, fmt.Errorf("%w: a %s quotation cannot be changed", ErrQuotationClosed, quotation.Status)
+	}
+
+	if req.IssueDate != nil {
+		quotation.IssueDate = dateOnly(*req.IssueDate)
+	}
+	if req.ValidUntil != nil {
+		quotation.ValidUntil = dateOnly(*req.ValidUntil)
+	}
+	if quotation.ValidUntil.Before(quotation.IssueDate) {
+		return nil, errors.New("valid_until must not be before issue_date")
+	}
+	if req.DiscountPercent != nil {
+		quotation.DiscountPercent = *req.DiscountPercent
+	}
+	if req.Notes != nil {
+		quotation.Notes = *req.Notes
+	}
+	if req.Terms != nil {
+		quotation.Terms = *req.Terms
+	}
+
+	items := quotation.Items
+	var replaced []models.QuotationItem
+	if req.Items != nil {
+		if replaced, err = s.buildQuotationItems(&quotation.Customer, quotation.Currency, req.Items, quotation.IssueDate); err != nil {
+			return nil, err
+		}
+		items = replaced
+	}
+	// The quotation discount changes every net price, so totals are always recomputed
+	applyQuotationTotals(quotation, items)
+	if replaced == nil {
+		replaced = items
+	}
+
+	if err := s.quotationRepo.Update(quotation, replaced); err != nil {
+		return nil, err
+	}
+	return s.GetQuotationByID(quotation.ID)
+}
+
+func (s *quotationService) DeleteQuotation(id uint) error {
+	quotation, err := s.quotationRepo.FindByID(id)
+	if err != nil {
+		return ErrQuotationNotFound
+	}
+	if quotation.Status == models.QuotationAccepted {
+		return fmt.Errorf("%w: an accepted quotation is kept with its order", ErrQuotationClosed)
+	}
+	return s.quotationRepo.Delete(id)
+}
+
+func (s *quotationService) Send(id uint) (*response.QuotationDetailResponse, error) {
+	quotation, err := s.quotationRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrQuotationNotFound
+	}
+	if quotation.Status != models.QuotationDraft || isExpired(quotation) {
+		return nil, fmt.Errorf("%w: only a valid draft quotation can be sent (quotation is %s)", ErrQuotationClosed, quotationStatus(quotation))
+	}
+
+	quotation.Status = models.QuotationSent
+	if err := s.quotationRepo.ChangeStatus(quotation, []string{models.QuotationDraft}); err != nil {
+		return nil, err
+	}
+	return s.GetQuotationByID(quotation.ID)
+}
+
+func (s *quotationService) Reject(id uint) (*response.QuotationDetailResponse, error) {
+	quotation, err := s.quotationRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrQuotationNotFound
+	}
+	if !isOpenQuotation(quotation) {
+		return nil, fmt.Errorf("%w: a %s quotation cannot be rejected", ErrQuotationClosed, quotationStatus(quotation))
+	}
+
+	now := time.Now()
+	quotation.Status = models.QuotationRejected
+	quotation.RespondedAt = &now
+	if err := s.quotationRepo.ChangeStatus(quotation, openQuotationStatuses); err != nil {
+		return nil, err
+	}
+	return s.GetQuotationByID(quotation.ID)
+}
+
+func (s *quotationService) Accept(id uint, req request.AcceptQuotationRequest, userID uint, client request.ClientInfo) (*response.OrderDetailResponse, error) {
+	quotation, err := s.quotationRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrQuotationNotFound
+	}
+	if !isOpenQuotation(quotation) {
+		return nil, fmt.Errorf("%w: a %s quotation cannot be accepted", ErrQuotationClosed, quotationStatus(quotation))
+	}
+
+	// Claim the quotation first so two accepts cannot both create an order
+	previous := quotation.Status
+	now := time.Now()
+	quotation.Status = models.QuotationAccepted
+	quotation.RespondedAt = &now
+	if err := s.quotationRepo.ChangeStatus(quotation, openQuotationStatuses); err != nil {
+		return nil, err
+	}
+
+	orderReq := request.CreateOrderRequest{
+		CustomerID: quotation.CustomerID,
+		Currency:   quotation.Currency,
+		DueDate:    req.DueDate,
+		Notes:      strings.TrimSpace(fmt.Sprintf("Quotation %s\n%s", quotation.QuotationCode, req.Notes)),
+		Items:      make([]request.OrderItemRequest, len(quotation.Items)),
+	}
+	for i, item := range quotation.Items {
+		price := item.NetPrice
+		orderReq.Items[i] = request.OrderItemRequest{
+			ProductID: item.ProductID,
+			ColorCode: item.ColorCode,
+			Quantity:  item.Quantity,
+			Price:     &price,
+			Notes:     item.Notes,
+		}
+	}
+
+	order, err := s.orderService.CreateOrder(orderReq, userID, client)
+	if err != nil {
+		// Reopen the quotation so it can be accepted once the problem is fixed
+		quotation.Status = previous
+		quotation.RespondedAt = nil
+		if revertErr := s.quotationRepo.ChangeStatus(quotation, []string{models.QuotationAccepted}); revertErr != nil {
+			log.Printf("Warning: failed to reopen quotation %d: %v", quotation.ID, revertErr)
+		}
+		return nil, err
+	}
+
+	quotation.OrderID = &order.ID
+	if err := s.quotationRepo.ChangeStatus(quotation, []string{models.QuotationAccepted}); err != nil {
+		log.Printf("Warning: failed to link quotation %d to order %d: %v", quotation.ID, order.ID, err)
+	}
+	return order, nil
+}
+
+func (s *quotationService) Print(id uint) ([]byte, error) {
+	quotation, err := s.quotationRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrQuotationNotFound
+	}
+
+	var buf bytes.Buffer
+	if err := quotationPrintTemplate.Execute(&buf, convertQuotationToDetail(quotation)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// buildQuotationItems checks the products and takes missing unit prices from the customer's price lists
+// in the quotation currency
+func (s *quotationService) buildQuotationItems(customer *models.Customer, currency string, reqItems []request.QuotationItemRequest, date time.Time) ([]models.QuotationItem, error) {
+	items := make([]models.QuotationItem, len(reqItems))
+	for i, item := range reqItems {
+		product, err := s.productRepo.FindByID(item.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("product %d not found", item.ProductID)
+		}
+
+		items[i] = models.QuotationItem{
+			ProductID:       product.ID,
+			ColorCode:       strings.TrimSpace(item.ColorCode),
+			Quantity:        item.Quantity,
+			DiscountPercent: item.DiscountPercent,
+			Notes:           item.Notes,
+			Product:         *product,
+		}
+		if items[i].ColorCode == "" {
+			items[i].ColorCode = product.Color
+		}
+
+		if item.UnitPrice != nil {
+			items[i].UnitPrice = *item.UnitPrice
+			continue
+		}
+		price, err := lookupPrice(s.priceListRepo, s.rateService, customer, product, currency, item.Quantity, date)
+		if err != nil {
+			return nil, err
+		}
+		items[i].UnitPrice = price.UnitPrice
+		items[i].PriceListID = price.PriceListID
+	}
+	return items, nil
+}
+
+// nextQuotationCode returns the code after the highest generated one, e.g. BG000042 → BG000043
+func (s *quotationService) nextQuotationCode() (string, error) {
+	last, err := s.quotationRepo.LastCodeWithPrefix(quotationCodePrefix)
+	if err != nil {
+		return "", err
+	}
+
+	next := 1
+	if last != "" {
+		number, err := strconv.Atoi(strings.TrimPrefix(last, quotationCodePrefix))
+		if err != nil {
+			return "", err
+		}
+		next = number + 1
+	}
+	return fmt.Sprintf("%s%0*d", quotationCodePrefix, quotationCodeDigits, next), nil
+}
+
+// applyQuotationTotals prices each line after its own and the quotation discount. Subtotal is the
+// amount before discounts and TotalAmount the sum of the discounted lines, which is what the order totals.
+func applyQuotationTotals(quotation *models.Quotation, items []models.QuotationItem) {
+	var gross, total money.Amount
+	for i := range items {
+		item := &items[i]
+		item.NetPrice = item.UnitPrice.Discount(item.DiscountPercent).Discount(quotation.DiscountPercent)
+		item.Subtotal = item.NetPrice.Mul(item.Quantity)
+		gross += item.UnitPrice.Mul(item.Quantity)
+		total += item.Subtotal
+	}
+	quotation.Subtotal = gross
+	quotation.TotalAmount = total
+	quotation.DiscountAmount = gross - total
+}
+
+func isExpired(quotation *models.Quotation) bool {
+	return quotation.ValidUntil.Before(today())
+}
+
+// isOpenQuotation reports whether the quotation is a draft or sent one that is still valid
+func isOpenQuotation(quotation *models.Quotation) bool {
+	return (quotation.Status == models.QuotationDraft || quotation.Status == models.QuotationSent) && !isExpired(quotation)
+}
+
+// quotationStatus is the stored status, or expired for a draft or sent quotation past valid_until
+func quotationStatus(quotation *models.Quotation) string {
+	if (quotation.Status == models.QuotationDraft || quotation.Status == models.QuotationSent) && isExpired(quotation) {
+		return models.QuotationExpired
+	}
+	return quotation.Status
+}
+
+// today is the current date at midnight local time
+func today() time.Time {
+	return dateOnly(time.Now())
+}
+
+func dateOnly(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func convertQuotationToResponse(quotation *models.Quotation) *response.QuotationResponse {
+	return &response.QuotationResponse{
+		ID:              quotation.ID,
+		QuotationCode:   quotation.QuotationCode,
+		CustomerID:      quotation.CustomerID,
+		CustomerCode:    quotation.Customer.CustomerCode,
+		CustomerName:    quotation.Customer.Name,
+		Status:          quotationStatus(quotation),
+		Currency:        quotation.Currency,
+		IssueDate:       quotation.IssueDate,
+		ValidUntil:      quotation.ValidUntil,
+		DiscountPercent: quotation.DiscountPercent,
+		Subtotal:        quotation.Subtotal,
+		DiscountAmount:  quotation.DiscountAmount,
+		TotalAmount:     quotation.TotalAmount,
+		OrderID:         quotation.OrderID,
+		CreatedAt:       quotation.CreatedAt,
+		UpdatedAt:       quotation.UpdatedAt,
+	}
+}
+
+func convertQuotationToDetail(quotation *models.Quotation) *response.QuotationDetailResponse {
+	res := &response.QuotationDetailResponse{
+		QuotationResponse: *convertQuotationToResponse(quotation),
+		Notes:             quotation.Notes,
+		Terms:             quotation.Terms,
+		RespondedAt:       quotation.RespondedAt,
+		Items:             make([]response.QuotationItemResponse, len(quotation.Items)),
+	}
+	for i, item := range quotation.Items {
+		res.Items[i] = response.QuotationItemResponse{
+			ID:              item.ID,
+			ProductID:       item.ProductID,
+			SKU:             item.Product.SKU,
+			ProductName:     item.Product.ProductName.ProductNameVI,
+			ColorCode:       item.ColorCode,
+			Quantity:        item.Quantity,
+			UnitPrice:       item.UnitPrice,
+			DiscountPercent: item.DiscountPercent,
+			NetPrice:        item.NetPrice,
+			Subtotal:        item.Subtotal,
+			PriceListID:     item.PriceListID,
+			Notes:           item.Notes,
+		}
+	}
+	return res
+}
+
+// formatNumber writes a number with dot thousands separators and a comma before decimals, e.g. 1.234.567,5
+func formatNumber(number float64) string {
+	text := strconv.FormatFloat(roundMoney(number), 'f', -1, 64)
+	whole, decimals, _ := strings.Cut(text, ".")
+	negative := strings.HasPrefix(whole, "-")
+	whole = strings.TrimPrefix(whole, "-")
+
+	var b strings.Builder
+	if negative {
+		b.WriteByte('-')
+	}
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(digit)
+	}
+	if decimals != "" {
+		b.WriteString("," + decimals)
+	}
+	return b.String()
+}
+
+var quotationPrintTemplate = template.Must(template.New("quotation").Funcs(template.FuncMap{
+	"number": formatNumber,
+	"amount": func(amount money.Amount) string { return formatNumber(amount.Float64()) },
+	"date":   func(t time.Time) string { return t.Format("02/01/2006") },
+	"inc":    func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="vi">
+<head>
+<meta charset="utf-8">
+<title>Báo giá {{.QuotationCode}}</title>
+<style>
+body { font-family: Arial, sans-serif; font-size: 13px; margin: 24px; color: #222; }
+h1 { font-size: 20px; margin-bottom: 4px; }
+table { width: 100%; border-collapse: collapse; margin-top: 16px; }
+th, td { border: 1px solid #999; padding: 6px; }
+th { background: #eee; }
+td.num { text-align: right; }
+.meta td { border: none; padding: 2px 8px 2px 0; }
+.totals td { border: none; }
+.pre { white-space: pre-wrap; }
+@media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>BÁO GIÁ / QUOTATION</h1>
+<table class="meta">
+<tr><td>Số báo giá:</td><td><strong>{{.QuotationCode}}</strong></td><td>Ngày:</td><td>{{date .IssueDate}}</td></tr>
+<tr><td>Khách hàng:</td><td>{{.CustomerCode}} - {{.CustomerName}}</td><td>Hiệu lực đến:</td><td>{{date .ValidUntil}}</td></tr>
+<tr><td>Loại tiền:</td><td>{{.Currency}}</td><td></td><td></td></tr>
+</table>
+<table>
+<thead>
+<tr><th>#</th><th>Mã hàng</th><th>Tên hàng</th><th>Màu</th><th>Số lượng</th><th>Đơn giá</th><th>CK (%)</th><th>Đơn giá sau CK</th><th>Thành tiền</th></tr>
+</thead>
+<tbody>
+{{range $i, $item := .Items}}<tr>
+<td>{{inc $i}}</td><td>{{$item.SKU}}</td><td>{{$item.ProductName}}</td><td>{{$item.ColorCode}}</td>
+<td class="num">{{number $item.Quantity}}</td><td class="num">{{amount $item.UnitPrice}}</td>
+<td class="num">{{number $item.DiscountPercent}}</td><td class="num">{{amount $item.NetPrice}}</td><td class="num">{{amount $item.Subtotal}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+<table class="totals">
+<tr><td class="num">Cộng tiền hàng:</td><td class="num">{{amount .Subtotal}}</td></tr>
+{{if .DiscountPercent}}<tr><td class="num">Chiết khấu báo giá ({{number .DiscountPercent}}%):</td><td></td></tr>
+{{end}}<tr><td class="num">Tổng chiết khấu:</td><td class="num">{{amount .DiscountAmount}}</td></tr>
+<tr><td class="num"><strong>Tổng cộng:</strong></td><td class="num"><strong>{{amount .TotalAmount}}</strong></td></tr>
+</table>
+{{if .Terms}}<h3>Điều khoản</h3>
+<div class="pre">{{.Terms}}</div>
+{{end}}{{if .Notes}}<h3>Ghi chú</h3>
+<div class="pre">{{.Notes}}</div>
+{{end}}</body>
+</html>
+`))
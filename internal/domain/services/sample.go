@@ -6,19 +6,31 @@ package services
 import (
 	"errors"
 	"math"
+	"sort"
 
 	"github.com/godiidev/appsynex/internal/domain/models"
 	"github.com/godiidev/appsynex/internal/dto/request"
 	"github.com/godiidev/appsynex/internal/dto/response"
 	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/textsearch"
+)
+
+const (
+	// maxSearchCandidates caps the FULLTEXT candidates re-ranked in memory
+	maxSearchCandidates = 1000
+	searchSnippetLength = 160
+	searchReindexBatch  = 500
 )
 
 type SampleService interface {
-	GetSamples(req request.SampleFilterRequest) (*response.PaginatedResponse, error)
+	GetSamples(req request.SampleFilterRequest) (*response.SamplePageResponse, error)
+	GetSamplesByCursor(req request.SampleFilterRequest) (*response.SampleCursorPageResponse, error)
 	GetSampleByID(id uint) (*response.SampleResponse, error)
 	CreateSample(req request.CreateSampleRequest) (*response.SampleResponse, error)
 	UpdateSample(id uint, req request.UpdateSampleRequest) (*response.SampleResponse, error)
 	DeleteSample(id uint) error
+	SearchSamples(req request.SampleSearchRequest) (*response.SampleSearchResponse, error)
+	RebuildSearchIndex() (*response.SearchReindexResponse, error)
 }
 
 type sampleService struct {
@@ -39,7 +51,7 @@ func NewSampleService(
 	}
 }
 
-func (s *sampleService) GetSamples(req request.SampleFilterRequest) (*response.PaginatedResponse, error) {
+func (s *sampleService) GetSamples(req request.SampleFilterRequest) (*response.SamplePageResponse, error) {
 	// Set defaults for pagination
 	if req.Page <= 0 {
 		req.Page = 1
@@ -48,11 +60,19 @@ func (s *sampleService) GetSamples(req request.SampleFilterRequest) (*response.P
 		req.Limit = 10
 	}
 
-	// Build filters map
-	filters := s.buildFilters(req)
+	// Build filter and sort spec
+	spec, err := buildSampleQuery(req, s.categoryRepo)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get samples from repository (với Preload relationships)
-	samples, total, err := s.sampleRepo.FindAll(req.Page, req.Limit, req.Search, req.Category, filters)
+	samples, total, err := s.sampleRepo.FindAll(req.Page, req.Limit, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	facets, err := sampleFacets(s.sampleRepo, s.categoryRepo, spec, req.Facets)
 	if err != nil {
 		return nil, err
 	}
@@ -65,12 +85,66 @@ func (s *sampleService) GetSamples(req request.SampleFilterRequest) (*response.P
 
 	totalPages := int(math.Ceil(float64(total) / float64(req.Limit)))
 
-	return &response.PaginatedResponse{
-		Items:      items,
-		TotalItems: total,
-		Page:       req.Page,
-		Limit:      req.Limit,
-		TotalPages: totalPages,
+	return &response.SamplePageResponse{
+		PaginatedResponse: response.PaginatedResponse{
+			Items:      items,
+			TotalItems: total,
+			Page:       req.Page,
+			Limit:      req.Limit,
+			TotalPages: totalPages,
+		},
+		Facets: facets,
+	}, nil
+}
+
+func (s *sampleService) GetSamplesByCursor(req request.SampleFilterRequest) (*response.SampleCursorPageResponse, error) {
+	limit := cursorPageLimit(req.Limit)
+
+	spec, err := buildSampleQuery(req, s.categoryRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := cursorSort(spec.Sort, interfaces.SortField{Field: "created_at", Desc: true}, after)
+	if err != nil {
+		return nil, err
+	}
+	spec.Sort = []interfaces.SortField{sort}
+
+	samples, next, err := s.sampleRepo.FindPage(spec, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, estimated, err := pageTotal(s.sampleRepo, spec, req.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	facets, err := sampleFacets(s.sampleRepo, s.categoryRepo, spec, req.Facets)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(samples))
+	for i := range samples {
+		items[i] = s.convertSampleToResponse(&samples[i])
+	}
+
+	return &response.SampleCursorPageResponse{
+		CursorPaginatedResponse: response.CursorPaginatedResponse{
+			Items:          items,
+			Limit:          limit,
+			NextCursor:     encodeCursor(next),
+			HasMore:        next != nil,
+			TotalItems:     total,
+			TotalEstimated: estimated,
+		},
+		Facets: facets,
 	}, nil
 }
 
@@ -108,6 +182,7 @@ func (s *sampleService) CreateSample(req request.CreateSampleRequest) (*response
 		ProductNameID:     req.ProductNameID,
 		CategoryID:        req.CategoryID,
 		Description:       req.Description,
+		DescriptionEN:     req.DescriptionEN,
 		SampleType:        req.SampleType,
 		Weight:            req.Weight,
 		Width:             req.Width,
@@ -172,6 +247,9 @@ func (s *sampleService) UpdateSample(id uint, req request.UpdateSampleRequest) (
 	if req.Description != nil {
 		sample.Description = *req.Description
 	}
+	if req.DescriptionEN != nil {
+		sample.DescriptionEN = *req.DescriptionEN
+	}
 	if req.SampleType != nil {
 		sample.SampleType = *req.SampleType
 	}
@@ -227,28 +305,131 @@ func (s *sampleService) DeleteSample(id uint) error {
 	return s.sampleRepo.Delete(id)
 }
 
-// Helper function to build filters map
-func (s *sampleService) buildFilters(req request.SampleFilterRequest) map[string]interface{} {
-	filters := make(map[string]interface{})
-	if req.WeightMin > 0 {
-		filters["weight_min"] = req.WeightMin
+func (s *sampleService) SearchSamples(req request.SampleSearchRequest) (*response.SampleSearchResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
 	}
-	if req.WeightMax > 0 {
-		filters["weight_max"] = req.WeightMax
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	tokens := textsearch.Tokenize(req.Query)
+	if textsearch.BooleanQuery(tokens, true) == "" {
+		return nil, errors.New("search query must contain at least one word of 2 or more characters")
 	}
-	if req.WidthMin > 0 {
-		filters["width_min"] = req.WidthMin
+
+	spec, err := buildSampleQuery(request.SampleFilterRequest{
+		Category:   req.Category,
+		SampleType: []string{req.SampleType},
+	}, s.categoryRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.sampleRepo.Search(textsearch.BooleanQuery(tokens, true), spec, maxSearchCandidates)
+	if err != nil {
+		return nil, err
 	}
-	if req.WidthMax > 0 {
-		filters["width_max"] = req.WidthMax
+	results := rankSamples(candidates, tokens)
+
+	// Nothing matched every word exactly: retry with typo-tolerant matching
+	fuzzy := false
+	if len(results) == 0 && (req.Fuzzy == nil || *req.Fuzzy) {
+		candidates, err = s.sampleRepo.Search(textsearch.BooleanQuery(tokens, false), spec, maxSearchCandidates)
+		if err != nil {
+			return nil, err
+		}
+		results = rankSamples(candidates, tokens)
+		fuzzy = true
+	}
+
+	total := len(results)
+	start := (req.Page - 1) * req.Limit
+	if start > total {
+		start = total
+	}
+	end := start + req.Limit
+	if end > total {
+		end = total
+	}
+
+	items := make([]response.SampleSearchResult, 0, end-start)
+	for _, result := range results[start:end] {
+		items = append(items, response.SampleSearchResult{
+			Sample:     s.convertSampleToResponse(result.sample),
+			Relevance:  result.relevance,
+			Highlights: result.highlights,
+		})
+	}
+
+	return &response.SampleSearchResponse{
+		Query:      req.Query,
+		Tokens:     tokens,
+		Fuzzy:      fuzzy,
+		Items:      items,
+		TotalItems: int64(total),
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.Limit))),
+	}, nil
+}
+
+func (s *sampleService) RebuildSearchIndex() (*response.SearchReindexResponse, error) {
+	count, err := s.sampleRepo.RebuildSearchText(searchReindexBatch)
+	if err != nil {
+		return nil, err
 	}
-	if req.SampleType != "" {
-		filters["sample_type"] = req.SampleType
+	return &response.SearchReindexResponse{Reindexed: count}, nil
+}
+
+type rankedSample struct {
+	sample     *models.SampleProduct
+	relevance  float64
+	highlights map[string]string
+}
+
+// rankSamples scores candidates against the query tokens, drops non-matches and sorts by relevance
+func rankSamples(samples []models.SampleProduct, tokens []string) []rankedSample {
+	results := make([]rankedSample, 0, len(samples))
+	for i := range samples {
+		sample := &samples[i]
+		fields := sampleSearchFields(sample)
+
+		score := textsearch.Score(tokens, fields)
+		if score.Relevance == 0 {
+			continue
+		}
+
+		highlights := make(map[string]string)
+		for _, field := range fields {
+			if snippet, ok := textsearch.Highlight(field.Text, tokens, searchSnippetLength); ok {
+				highlights[field.Name] = snippet
+			}
+		}
+
+		results = append(results, rankedSample{sample: sample, relevance: score.Relevance, highlights: highlights})
 	}
-	if req.Color != "" {
-		filters["color"] = req.Color
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].relevance > results[j].relevance
+	})
+	return results
+}
+
+// sampleSearchFields lists the searchable fields of a sample; identifiers and names weigh most
+func sampleSearchFields(sample *models.SampleProduct) []textsearch.Field {
+	return []textsearch.Field{
+		{Name: "sku", Text: sample.SKU, Weight: 3},
+		{Name: "product_name_vi", Text: sample.ProductName.ProductNameVI, Weight: 3},
+		{Name: "product_name_en", Text: sample.ProductName.ProductNameEN, Weight: 3},
+		{Name: "sku_parent", Text: sample.ProductName.SKUParent, Weight: 2},
+		{Name: "color", Text: sample.Color, Weight: 2},
+		{Name: "color_code", Text: sample.ColorCode, Weight: 2},
+		{Name: "fiber_content", Text: sample.FiberContent, Weight: 1.5},
+		{Name: "description", Text: sample.Description, Weight: 1},
+		{Name: "description_en", Text: sample.DescriptionEN, Weight: 1},
+		{Name: "source", Text: sample.Source, Weight: 1},
 	}
-	return filters
 }
 
 // Enhanced helper function to convert model to response DTO
@@ -259,6 +440,7 @@ func (s *sampleService) convertSampleToResponse(sample *models.SampleProduct) *r
 		ProductNameID:     sample.ProductNameID,
 		CategoryID:        sample.CategoryID,
 		Description:       sample.Description,
+		DescriptionEN:     sample.DescriptionEN,
 		SampleType:        sample.SampleType,
 		Weight:            sample.Weight,
 		Width:             sample.Width,
@@ -272,6 +454,7 @@ func (s *sampleService) convertSampleToResponse(sample *models.SampleProduct) *r
 		Barcode:           sample.Barcode,
 		CreatedAt:         sample.CreatedAt,
 		UpdatedAt:         sample.UpdatedAt,
+		Images:            convertSampleImagesToResponse(sample.Images),
 	}
 
 	// Auto-populate ProductName if loaded (check if relationship exists)
@@ -299,8 +482,10 @@ func (s *sampleService) convertSampleToResponse(sample *models.SampleProduct) *r
 		sampleResponse.Category = &response.CategoryResponse{
 			ID:               sample.Category.ID,
 			CategoryName:     sample.Category.CategoryName,
+			CategoryNameEN:   sample.Category.CategoryNameEN,
 			ParentCategoryID: sample.Category.ParentCategoryID,
 			Description:      sample.Category.Description,
+			DescriptionEN:    sample.Category.DescriptionEN,
 			CreatedAt:        sample.Category.CreatedAt,
 			UpdatedAt:        sample.Category.UpdatedAt,
 		}
@@ -310,8 +495,10 @@ func (s *sampleService) convertSampleToResponse(sample *models.SampleProduct) *r
 			sampleResponse.Category = &response.CategoryResponse{
 				ID:               category.ID,
 				CategoryName:     category.CategoryName,
+				CategoryNameEN:   category.CategoryNameEN,
 				ParentCategoryID: category.ParentCategoryID,
 				Description:      category.Description,
+				DescriptionEN:    category.DescriptionEN,
 				CreatedAt:        category.CreatedAt,
 				UpdatedAt:        category.UpdatedAt,
 			}
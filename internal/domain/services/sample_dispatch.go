@@ -0,0 +1,101 @@
+// File: internal/domain/services/sample_dispatch.go
+// Tạo tại: internal/domain/services/sample_dispatch.go
+// Mục đích: Service gửi mẫu cho khách hàng và ghi nhận hoạt động nhận mẫu của khách hàng
+
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+type SampleDispatchService interface {
+	DispatchSample(sampleID uint, req request.DispatchSampleRequest, client request.ClientInfo) (*response.SampleDispatchResponse, error)
+}
+
+type sampleDispatchService struct {
+	dispatchRepo    interfaces.SampleDispatchRepository
+	sampleRepo      interfaces.SampleRepository
+	customerRepo    interfaces.CustomerRepository
+	activityService CustomerActivityService
+}
+
+func NewSampleDispatchService(
+	dispatchRepo interfaces.SampleDispatchRepository,
+	sampleRepo interfaces.SampleRepository,
+	customerRepo interfaces.CustomerRepository,
+	activityService CustomerActivityService,
+) SampleDispatchService {
+	return &sampleDispatchService{
+		dispatchRepo:    dispatchRepo,
+		sampleRepo:      sampleRepo,
+		customerRepo:    customerRepo,
+		activityService: activityService,
+	}
+}
+
+func (s *sampleDispatchService) DispatchSample(sampleID uint, req request.DispatchSampleRequest, client request.ClientInfo) (*response.SampleDispatchResponse, error) {
+	sample, err := s.sampleRepo.FindByID(sampleID)
+	if err != nil {
+		return nil, errors.New("sample not found")
+	}
+	customer, err := s.customerRepo.FindByID(req.CustomerID)
+	if err != nil {
+		return nil, ErrCustomerNotFound
+	}
+	if customer.AccountStatus == models.CustomerStatusBlocked {
+		return nil, errors.New("customer account is blocked")
+	}
+
+	dispatch := &models.SampleDispatch{
+		SampleProductID:  sample.ID,
+		CustomerID:       customer.ID,
+		DispatchDate:     time.Now(),
+		DispatchQuantity: req.DispatchQuantity,
+		DispatchWeight:   req.DispatchWeight,
+		DispatchColor:    req.DispatchColor,
+		LotNumber:        req.LotNumber,
+		TrackingNumber:   req.TrackingNumber,
+		DispatchNotes:    req.DispatchNotes,
+	}
+	if req.DispatchDate != nil {
+		dispatch.DispatchDate = *req.DispatchDate
+	}
+	if dispatch.DispatchColor == "" {
+		dispatch.DispatchColor = sample.Color
+	}
+
+	if err := s.dispatchRepo.Create(dispatch); err != nil {
+		return nil, err
+	}
+
+	s.activityService.Record(customer.ID, models.CustomerActivitySampleReceived, map[string]interface{}{
+		"sample_dispatch_id": dispatch.ID,
+		"sample_product_id":  sample.ID,
+		"sku":                sample.SKU,
+		"quantity":           dispatch.DispatchQuantity,
+		"weight":             dispatch.DispatchWeight,
+		"color":              dispatch.DispatchColor,
+		"tracking_number":    dispatch.TrackingNumber,
+	}, client)
+
+	return &response.SampleDispatchResponse{
+		ID:               dispatch.ID,
+		SampleProductID:  sample.ID,
+		SampleSKU:        sample.SKU,
+		CustomerID:       customer.ID,
+		CustomerCode:     customer.CustomerCode,
+		DispatchDate:     dispatch.DispatchDate,
+		DispatchQuantity: dispatch.DispatchQuantity,
+		DispatchWeight:   dispatch.DispatchWeight,
+		DispatchColor:    dispatch.DispatchColor,
+		LotNumber:        dispatch.LotNumber,
+		TrackingNumber:   dispatch.TrackingNumber,
+		DispatchNotes:    dispatch.DispatchNotes,
+	}, nil
+}
@@ -0,0 +1,476 @@
+// File: internal/domain/services/sample_import.go
+// Tạo tại: internal/domain/services/sample_import.go
+// Mục đích: Import/export mẫu vải hàng loạt từ file CSV/XLSX (ánh xạ cột, kiểm tra dữ liệu, dry-run)
+
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/spreadsheet"
+)
+
+// MaxSampleImportRows limits the number of data rows accepted in one import file
+const MaxSampleImportRows = 10000
+
+const sampleExportBatchSize = 500
+
+// sampleImportFields lists the importable fields in export column order
+var sampleImportFields = []string{
+	"sku",
+	"product_name_id",
+	"sku_parent",
+	"product_name",
+	"category_id",
+	"category",
+	"description",
+	"description_en",
+	"sample_type",
+	"weight",
+	"width",
+	"color",
+	"color_code",
+	"quality",
+	"remaining_quantity",
+	"fiber_content",
+	"source",
+	"sample_location",
+	"barcode",
+}
+
+type SampleImportExportService interface {
+	ImportSamples(r io.Reader, format string, req request.SampleImportRequest) (*response.SampleImportResponse, error)
+	ExportSamples(w io.Writer, req request.SampleExportRequest) error
+}
+
+type sampleImportExportService struct {
+	sampleRepo      interfaces.SampleRepository
+	productNameRepo interfaces.ProductNameRepository
+	categoryRepo    interfaces.ProductCategoryRepository
+}
+
+func NewSampleImportExportService(
+	sampleRepo interfaces.SampleRepository,
+	productNameRepo interfaces.ProductNameRepository,
+	categoryRepo interfaces.ProductCategoryRepository,
+) SampleImportExportService {
+	return &sampleImportExportService{
+		sampleRepo:      sampleRepo,
+		productNameRepo: productNameRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+// sampleLookups indexes product names and categories for row validation
+type sampleLookups struct {
+	productNamesByID   map[uint]*models.ProductName
+	productNamesBySKU  map[string]*models.ProductName
+	productNamesByName map[string]*models.ProductName
+	categoriesByID     map[uint]*models.ProductCategory
+	categoriesByName   map[string]*models.ProductCategory
+}
+
+func (s *sampleImportExportService) ImportSamples(r io.Reader, format string, req request.SampleImportRequest) (*response.SampleImportResponse, error) {
+	rows, err := spreadsheet.ReadAll(r, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("import file is empty")
+	}
+	if len(rows)-1 > MaxSampleImportRows {
+		return nil, fmt.Errorf("import file exceeds %d rows", MaxSampleImportRows)
+	}
+
+	header := rows[0]
+	columns, mapping, err := resolveSampleColumns(header, req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := columns["sku"]; !ok {
+		return nil, errors.New("no column mapped to sku")
+	}
+
+	lookups, err := s.loadLookups()
+	if err != nil {
+		return nil, err
+	}
+
+	// Load existing samples for every SKU in the file
+	var skus []string
+	for _, row := range rows[1:] {
+		if sku := cellValue(row, columns, "sku"); sku != "" {
+			skus = append(skus, sku)
+		}
+	}
+	existing, err := s.sampleRepo.FindBySKUs(skus)
+	if err != nil {
+		return nil, err
+	}
+	existingBySKU := make(map[string]*models.SampleProduct, len(existing))
+	for i := range existing {
+		existingBySKU[strings.ToLower(existing[i].SKU)] = &existing[i]
+	}
+
+	result := &response.SampleImportResponse{
+		DryRun:  req.DryRun,
+		Mapping: mapping,
+		Errors:  []response.SampleImportError{},
+	}
+
+	var creates, updates []*models.SampleProduct
+	seen := make(map[string]int)
+
+	for i, row := range rows[1:] {
+		rowNumber := i + 2 // 1-based, header is row 1
+		if isBlankRow(row) {
+			continue
+		}
+		result.TotalRows++
+
+		rowErrors := []response.SampleImportError{}
+		addError := func(field, value, message string) {
+			rowErrors = append(rowErrors, response.SampleImportError{
+				Row:     rowNumber,
+				Column:  headerFor(header, columns, field),
+				Field:   field,
+				Value:   value,
+				Message: message,
+			})
+		}
+
+		sku := cellValue(row, columns, "sku")
+		if sku == "" {
+			addError("sku", "", "sku is required")
+			result.Failed++
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+		if firstRow, ok := seen[strings.ToLower(sku)]; ok {
+			addError("sku", sku, fmt.Sprintf("duplicate sku, already used on row %d", firstRow))
+			result.Failed++
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+		seen[strings.ToLower(sku)] = rowNumber
+
+		sample, isUpdate := existingBySKU[strings.ToLower(sku)]
+		if isUpdate && !req.UpdateExisting {
+			addError("sku", sku, "SKU already exists")
+			result.Failed++
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+		if !isUpdate {
+			sample = &models.SampleProduct{SKU: sku}
+		}
+
+		s.applyRow(sample, row, columns, lookups, !isUpdate, addError)
+
+		if len(rowErrors) > 0 {
+			result.Failed++
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+
+		result.ValidRows++
+		if isUpdate {
+			updates = append(updates, sample)
+		} else {
+			creates = append(creates, sample)
+		}
+	}
+
+	result.Created = len(creates)
+	result.Updated = len(updates)
+
+	if req.DryRun || result.ValidRows == 0 {
+		return result, nil
+	}
+
+	if err := s.sampleRepo.SaveBatch(creates, updates); err != nil {
+		return nil, fmt.Errorf("failed to import samples: %w", err)
+	}
+
+	return result, nil
+}
+
+// applyRow copies the mapped cell values onto the sample, reporting validation errors through addError
+func (s *sampleImportExportService) applyRow(
+	sample *models.SampleProduct,
+	row []string,
+	columns map[string]int,
+	lookups *sampleLookups,
+	isNew bool,
+	addError func(field, value, message string),
+) {
+	// Resolve product name: by ID, then parent SKU, then VI/EN name
+	if value := cellValue(row, columns, "product_name_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			addError("product_name_id", value, "product_name_id must be a number")
+		} else if productName, ok := lookups.productNamesByID[uint(id)]; ok {
+			sample.ProductNameID = productName.ID
+		} else {
+			addError("product_name_id", value, "product name not found")
+		}
+	} else if value := cellValue(row, columns, "sku_parent"); value != "" {
+		if productName, ok := lookups.productNamesBySKU[strings.ToLower(value)]; ok {
+			sample.ProductNameID = productName.ID
+		} else {
+			addError("sku_parent", value, "product name not found")
+		}
+	} else if value := cellValue(row, columns, "product_name"); value != "" {
+		if productName, ok := lookups.productNamesByName[strings.ToLower(value)]; ok {
+			sample.ProductNameID = productName.ID
+		} else {
+			addError("product_name", value, "product name not found")
+		}
+	} else if isNew {
+		addError("product_name", "", "product name is required")
+	}
+
+	// Resolve category: by ID, then name
+	if value := cellValue(row, columns, "category_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			addError("category_id", value, "category_id must be a number")
+		} else if category, ok := lookups.categoriesByID[uint(id)]; ok {
+			sample.CategoryID = category.ID
+		} else {
+			addError("category_id", value, "category not found")
+		}
+	} else if value := cellValue(row, columns, "category"); value != "" {
+		if category, ok := lookups.categoriesByName[strings.ToLower(value)]; ok {
+			sample.CategoryID = category.ID
+		} else {
+			addError("category", value, "category not found")
+		}
+	} else if isNew {
+		addError("category", "", "category is required")
+	}
+
+	if value := cellValue(row, columns, "weight"); value != "" {
+		if weight, err := parseDecimal(value); err != nil || weight < 0 {
+			addError("weight", value, "weight must be a non-negative number")
+		} else {
+			sample.Weight = weight
+		}
+	}
+	if value := cellValue(row, columns, "width"); value != "" {
+		if width, err := parseDecimal(value); err != nil || width < 0 {
+			addError("width", value, "width must be a non-negative number")
+		} else {
+			sample.Width = width
+		}
+	}
+	if value := cellValue(row, columns, "remaining_quantity"); value != "" {
+		if quantity, err := strconv.Atoi(value); err != nil || quantity < 0 {
+			addError("remaining_quantity", value, "remaining_quantity must be a non-negative integer")
+		} else {
+			sample.RemainingQuantity = quantity
+		}
+	}
+
+	textFields := map[string]*string{
+		"description":     &sample.Description,
+		"description_en":  &sample.DescriptionEN,
+		"sample_type":     &sample.SampleType,
+		"color":           &sample.Color,
+		"color_code":      &sample.ColorCode,
+		"quality":         &sample.Quality,
+		"fiber_content":   &sample.FiberContent,
+		"source":          &sample.Source,
+		"sample_location": &sample.SampleLocation,
+		"barcode":         &sample.Barcode,
+	}
+	for field, target := range textFields {
+		if value := cellValue(row, columns, field); value != "" {
+			*target = value
+		}
+	}
+}
+
+func (s *sampleImportExportService) ExportSamples(w io.Writer, req request.SampleExportRequest) error {
+	format := req.Format
+	if format == "" {
+		format = spreadsheet.FormatCSV
+	}
+
+	spec, err := buildSampleQuery(req.SampleFilterRequest, s.categoryRepo)
+	if err != nil {
+		return err
+	}
+
+	writer, err := spreadsheet.NewWriter(w, format, "Samples")
+	if err != nil {
+		return err
+	}
+
+	if err := writer.WriteRow(sampleImportFields); err != nil {
+		return err
+	}
+
+	err = s.sampleRepo.FindAllInBatches(spec, sampleExportBatchSize, func(samples []models.SampleProduct) error {
+		for i := range samples {
+			if err := writer.WriteRow(sampleExportRow(&samples[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (s *sampleImportExportService) loadLookups() (*sampleLookups, error) {
+	productNames, err := s.productNameRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	lookups := &sampleLookups{
+		productNamesByID:   make(map[uint]*models.ProductName, len(productNames)),
+		productNamesBySKU:  make(map[string]*models.ProductName, len(productNames)),
+		productNamesByName: make(map[string]*models.ProductName, len(productNames)*2),
+		categoriesByID:     make(map[uint]*models.ProductCategory, len(categories)),
+		categoriesByName:   make(map[string]*models.ProductCategory, len(categories)),
+	}
+	for i := range productNames {
+		productName := &productNames[i]
+		lookups.productNamesByID[productName.ID] = productName
+		if productName.SKUParent != "" {
+			lookups.productNamesBySKU[strings.ToLower(productName.SKUParent)] = productName
+		}
+		if productName.ProductNameVI != "" {
+			lookups.productNamesByName[strings.ToLower(productName.ProductNameVI)] = productName
+		}
+		if productName.ProductNameEN != "" {
+			lookups.productNamesByName[strings.ToLower(productName.ProductNameEN)] = productName
+		}
+	}
+	for i := range categories {
+		category := &categories[i]
+		lookups.categoriesByID[category.ID] = category
+		lookups.categoriesByName[strings.ToLower(category.CategoryName)] = category
+	}
+
+	return lookups, nil
+}
+
+// resolveSampleColumns maps sample fields to column indexes.
+// Without an explicit mapping, headers are matched to field names case-insensitively.
+func resolveSampleColumns(header []string, rawMapping string) (map[string]int, map[string]string, error) {
+	known := make(map[string]bool, len(sampleImportFields))
+	for _, field := range sampleImportFields {
+		known[field] = true
+	}
+
+	explicit := map[string]string{}
+	if strings.TrimSpace(rawMapping) != "" {
+		if err := json.Unmarshal([]byte(rawMapping), &explicit); err != nil {
+			return nil, nil, errors.New("invalid column mapping, expected JSON object of column header to field")
+		}
+		for column, field := range explicit {
+			if !known[field] {
+				return nil, nil, fmt.Errorf("unknown field %q in mapping for column %q", field, column)
+			}
+		}
+	}
+
+	columns := make(map[string]int)
+	mapping := make(map[string]string)
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		field, ok := explicit[name]
+		if !ok && len(explicit) == 0 {
+			normalized := strings.ReplaceAll(strings.ToLower(name), " ", "_")
+			if known[normalized] {
+				field, ok = normalized, true
+			}
+		}
+		if !ok {
+			continue
+		}
+		if _, dup := columns[field]; dup {
+			return nil, nil, fmt.Errorf("field %q is mapped to more than one column", field)
+		}
+		columns[field] = i
+		mapping[name] = field
+	}
+
+	return columns, mapping, nil
+}
+
+func sampleExportRow(sample *models.SampleProduct) []string {
+	return []string{
+		sample.SKU,
+		strconv.FormatUint(uint64(sample.ProductNameID), 10),
+		sample.ProductName.SKUParent,
+		sample.ProductName.ProductNameVI,
+		strconv.FormatUint(uint64(sample.CategoryID), 10),
+		sample.Category.CategoryName,
+		sample.Description,
+		sample.DescriptionEN,
+		sample.SampleType,
+		strconv.FormatFloat(sample.Weight, 'f', -1, 64),
+		strconv.FormatFloat(sample.Width, 'f', -1, 64),
+		sample.Color,
+		sample.ColorCode,
+		sample.Quality,
+		strconv.Itoa(sample.RemainingQuantity),
+		sample.FiberContent,
+		sample.Source,
+		sample.SampleLocation,
+		sample.Barcode,
+	}
+}
+
+func cellValue(row []string, columns map[string]int, field string) string {
+	index, ok := columns[field]
+	if !ok || index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+func headerFor(header []string, columns map[string]int, field string) string {
+	if index, ok := columns[field]; ok && index < len(header) {
+		return header[index]
+	}
+	return ""
+}
+
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDecimal accepts both "1.5" and "1,5" decimal separators
+func parseDecimal(value string) (float64, error) {
+	return strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+}
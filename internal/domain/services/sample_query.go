@@ -0,0 +1,218 @@
+// File: internal/domain/services/sample_query.go
+// Tạo tại: internal/domain/services/sample_query.go
+// Mục đích: Chuyển bộ lọc/sắp xếp mẫu vải từ request sang QuerySpec và tính facet counts
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+// ErrInvalidQuery marks filter, sort or facet parameters the client must fix
+var ErrInvalidQuery = errors.New("invalid query")
+
+const sampleFacetLimit = 50
+
+// sampleSortFields lists the indexed columns samples can be sorted by
+var sampleSortFields = map[string]bool{
+	"id":                 true,
+	"sku":                true,
+	"category_id":        true,
+	"sample_type":        true,
+	"weight":             true,
+	"width":              true,
+	"color":              true,
+	"remaining_quantity": true,
+	"sample_location":    true,
+	"created_at":         true,
+	"updated_at":         true,
+}
+
+// sampleFacetFields maps facet names to repository fields
+var sampleFacetFields = map[string]string{
+	"sample_type":   "sample_type",
+	"category":      "category_id",
+	"color":         "color",
+	"fiber_content": "fiber_content",
+	"location":      "sample_location",
+}
+
+// buildSampleQuery converts list filters into a repository QuerySpec.
+// Selected categories are expanded to all their sub-categories unless include_subcategories=false.
+func buildSampleQuery(req request.SampleFilterRequest, categoryRepo interfaces.ProductCategoryRepository) (interfaces.QuerySpec, error) {
+	spec := interfaces.QuerySpec{Search: req.Search}
+	add := func(field string, op interfaces.FilterOp, value interface{}) {
+		spec.Filters = append(spec.Filters, interfaces.Filter{Field: field, Op: op, Value: value})
+	}
+
+	// Categories
+	categoryIDs := append([]uint{}, req.CategoryIDs...)
+	for _, value := range splitValues([]string{req.Category}) {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return spec, fmt.Errorf("%w: category must be a category ID", ErrInvalidQuery)
+		}
+		categoryIDs = append(categoryIDs, uint(id))
+	}
+	if len(categoryIDs) > 0 {
+		if req.IncludeSubcategories == nil || *req.IncludeSubcategories {
+			categories, err := categoryRepo.FindAll()
+			if err != nil {
+				return spec, err
+			}
+			categoryIDs = categoryDescendantIDs(categories, categoryIDs)
+		}
+		add("category_id", interfaces.FilterIn, categoryIDs)
+	}
+
+	if sampleTypes := splitValues(req.SampleType); len(sampleTypes) > 0 {
+		add("sample_type", interfaces.FilterIn, sampleTypes)
+	}
+	if locations := splitValues(req.Location); len(locations) > 0 {
+		add("sample_location", interfaces.FilterIn, locations)
+	}
+
+	// Ranges
+	if req.WeightMin > 0 {
+		add("weight", interfaces.FilterGte, req.WeightMin)
+	}
+	if req.WeightMax > 0 {
+		add("weight", interfaces.FilterLte, req.WeightMax)
+	}
+	if req.WidthMin > 0 {
+		add("width", interfaces.FilterGte, req.WidthMin)
+	}
+	if req.WidthMax > 0 {
+		add("width", interfaces.FilterLte, req.WidthMax)
+	}
+	if req.RemainingMin != nil {
+		add("remaining_quantity", interfaces.FilterGte, *req.RemainingMin)
+	}
+	if req.RemainingMax != nil {
+		add("remaining_quantity", interfaces.FilterLte, *req.RemainingMax)
+	}
+	addDateRange(add, "created_at", req.CreatedFrom, req.CreatedTo)
+	addDateRange(add, "updated_at", req.UpdatedFrom, req.UpdatedTo)
+
+	// Contains
+	if req.Color != "" {
+		add("color", interfaces.FilterContains, req.Color)
+	}
+	if req.FiberContent != "" {
+		add("fiber_content", interfaces.FilterContains, req.FiberContent)
+	}
+
+	sort, err := parseSort(req.SortBy, req.SortOrder, sampleSortFields)
+	if err != nil {
+		return spec, err
+	}
+	spec.Sort = sort
+
+	return spec, nil
+}
+
+// sampleFacets counts samples per value of each requested facet
+func sampleFacets(
+	sampleRepo interfaces.SampleRepository,
+	categoryRepo interfaces.ProductCategoryRepository,
+	spec interfaces.QuerySpec,
+	names []string,
+) (map[string][]response.FacetCount, error) {
+	names = splitValues(names)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	facets := make(map[string][]response.FacetCount, len(names))
+	for _, name := range names {
+		field, ok := sampleFacetFields[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported facet %q", ErrInvalidQuery, name)
+		}
+
+		counts, err := sampleRepo.FacetCounts(spec, field, sampleFacetLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]response.FacetCount, len(counts))
+		for i, count := range counts {
+			items[i] = response.FacetCount{Value: count.Value, Count: count.Count}
+		}
+		facets[name] = items
+	}
+
+	// Category facets carry IDs; add the category names as labels
+	if items, ok := facets["category"]; ok && len(items) > 0 {
+		categories, err := categoryRepo.FindAll()
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[string]string, len(categories))
+		for _, category := range categories {
+			names[strconv.FormatUint(uint64(category.ID), 10)] = category.CategoryName
+		}
+		for i := range items {
+			items[i].Label = names[items[i].Value]
+		}
+	}
+
+	return facets, nil
+}
+
+// categoryDescendantIDs returns the given category IDs plus every sub-category below them
+func categoryDescendantIDs(categories []models.ProductCategory, rootIDs []uint) []uint {
+	children := make(map[uint][]uint)
+	for _, category := range categories {
+		if category.ParentCategoryID != nil {
+			children[*category.ParentCategoryID] = append(children[*category.ParentCategoryID], category.ID)
+		}
+	}
+
+	seen := make(map[uint]bool)
+	var ids []uint
+	queue := append([]uint{}, rootIDs...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+		queue = append(queue, children[id]...)
+	}
+	return ids
+}
+
+// addDateRange filters field on [from, to] where to is a whole day
+func addDateRange(add func(string, interfaces.FilterOp, interface{}), field string, from, to time.Time) {
+	if !from.IsZero() {
+		add(field, interfaces.FilterGte, from)
+	}
+	if !to.IsZero() {
+		add(field, interfaces.FilterLt, to.AddDate(0, 0, 1))
+	}
+}
+
+// splitValues flattens repeated and comma-separated query values, dropping blanks
+func splitValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
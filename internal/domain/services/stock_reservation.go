@@ -0,0 +1,251 @@
+// File: internal/domain/services/stock_reservation.go
+// Tạo tại: internal/domain/services/stock_reservation.go
+// Mục đích: Service giữ cuộn vải theo lô cho dòng đơn hàng, nhả hàng khi hủy, hết hạn hoặc giao hàng
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+var (
+	// ErrReservationNotFound is returned when a reservation does not belong to the order
+	ErrReservationNotFound = errors.New("reservation not found")
+	// ErrInsufficientStock is returned when the free rolls do not cover a line
+	ErrInsufficientStock = interfaces.ErrInsufficientStock
+)
+
+// reservableOrderStatuses are the statuses an order can hold stock in
+var reservableOrderStatuses = map[string]bool{
+	models.OrderStatusPending:      true,
+	models.OrderStatusConfirmed:    true,
+	models.OrderStatusInProduction: true,
+	models.OrderStatusOnHold:       true,
+}
+
+type StockReservationService interface {
+	GetReservations(orderID uint) (*response.OrderReservationsResponse, error)
+	Reserve(orderID uint, req request.ReserveStockRequest, userID uint) (*response.OrderReservationsResponse, error)
+	// Release frees one reservation, or every active one of the order when reservationID is 0
+	Release(orderID, reservationID uint) error
+
+	// RegisterOrderEffects releases the order's stock when it is cancelled and consumes it when it ships
+	RegisterOrderEffects(workflow OrderWorkflowService)
+}
+
+type stockReservationService struct {
+	reservationRepo interfaces.StockReservationRepository
+	orderRepo       interfaces.OrderRepository
+	ttl             time.Duration
+}
+
+func NewStockReservationService(
+	reservationRepo interfaces.StockReservationRepository,
+	orderRepo interfaces.OrderRepository,
+	ttl time.Duration,
+) StockReservationService {
+	return &stockReservationService{
+		reservationRepo: reservationRepo,
+		orderRepo:       orderRepo,
+		ttl:             ttl,
+	}
+}
+
+func (s *stockReservationService) GetReservations(orderID uint) (*response.OrderReservationsResponse, error) {
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+	s.expireDue()
+	return s.orderReservations(order)
+}
+
+func (s *stockReservationService) Reserve(orderID uint, req request.ReserveStockRequest, userID uint) (*response.OrderReservationsResponse, error) {
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+	if !reservableOrderStatuses[order.OrderStatus.StatusName] {
+		return nil, fmt.Errorf("cannot reserve stock for an order that is %s", order.OrderStatus.StatusName)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(now) {
+			return nil, errors.New("expires_at must be in the future")
+		}
+		expiresAt = *req.ExpiresAt
+	}
+
+	s.expireDue()
+	reserved, err := s.reservationRepo.ReservedLength(order.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(map[uint]*models.OrderItem, len(order.Items))
+	for i := range order.Items {
+		items[order.Items[i].ID] = &order.Items[i]
+	}
+
+	// Without items, reserve the outstanding length of every line
+	reqItems := req.Items
+	if len(reqItems) == 0 {
+		for _, item := range order.Items {
+			reqItems = append(reqItems, request.ReserveStockItemRequest{OrderItemID: item.ID})
+		}
+	}
+
+	var lines []interfaces.ReservationLine
+	for _, reqItem := range reqItems {
+		item, ok := items[reqItem.OrderItemID]
+		if !ok {
+			return nil, fmt.Errorf("order item %d is not part of the order", reqItem.OrderItemID)
+		}
+
+		length := reqItem.Length
+		if length == 0 {
+			length = roundQuantity(item.Quantity - reserved[item.ID])
+		}
+		if length <= 0 && len(reqItem.FabricRollIDs) == 0 {
+			// Already covered; only an error when the caller asked for this line
+			if len(req.Items) > 0 {
+				return nil, fmt.Errorf("order item %d is already fully reserved", item.ID)
+			}
+			continue
+		}
+
+		lines = append(lines, interfaces.ReservationLine{
+			Template: models.StockReservation{
+				OrderID:     order.ID,
+				OrderItemID: item.ID,
+				ExpiresAt:   &expiresAt,
+				ReservedBy:  &userID,
+			},
+			Criteria: interfaces.RollCriteria{
+				ProductID: item.ProductID,
+				ColorCode: orderItemColor(item),
+				LotID:     reqItem.LotID,
+				RollIDs:   reqItem.FabricRollIDs,
+			},
+			Length: length,
+		})
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("every order line is already fully reserved")
+	}
+
+	if _, err := s.reservationRepo.Reserve(lines, req.AllowPartial, now); err != nil {
+		return nil, err
+	}
+
+	return s.orderReservations(order)
+}
+
+func (s *stockReservationService) Release(orderID, reservationID uint) error {
+	if _, err := s.orderRepo.FindByID(orderID); err != nil {
+		return ErrOrderNotFound
+	}
+
+	var ids []uint
+	if reservationID != 0 {
+		reservation, err := s.reservationRepo.FindByID(reservationID)
+		if err != nil || reservation.OrderID != orderID {
+			return ErrReservationNotFound
+		}
+		if reservation.Status != models.ReservationActive {
+			return fmt.Errorf("reservation is already %s", reservation.Status)
+		}
+		ids = []uint{reservationID}
+	}
+
+	_, err := s.reservationRepo.Release(orderID, ids, models.ReservationReleased, "released manually", time.Now())
+	return err
+}
+
+func (s *stockReservationService) RegisterOrderEffects(workflow OrderWorkflowService) {
+	workflow.AddEffect(OrderActionCancel, func(t *OrderTransitionContext) error {
+		_, err := s.reservationRepo.WithTx(t.Tx).Release(t.Order.ID, nil, models.ReservationReleased, "order cancelled", time.Now())
+		return err
+	})
+	workflow.AddEffect(OrderActionShip, func(t *OrderTransitionContext) error {
+		_, err := s.reservationRepo.WithTx(t.Tx).Release(t.Order.ID, nil, models.ReservationConsumed, "order shipped", time.Now())
+		return err
+	})
+}
+
+// expireDue ends reservations past their expiry. Active checks also compare expires_at,
+// so a failure here only leaves stale statuses behind.
+func (s *stockReservationService) expireDue() {
+	if _, err := s.reservationRepo.ExpireDue(time.Now()); err != nil {
+		log.Printf("Warning: failed to expire stock reservations: %v", err)
+	}
+}
+
+func (s *stockReservationService) orderReservations(order *models.Order) (*response.OrderReservationsResponse, error) {
+	reservations, err := s.reservationRepo.FindByOrder(order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reserved := make(map[uint]float64)
+	result := &response.OrderReservationsResponse{
+		OrderID:      order.ID,
+		OrderCode:    order.OrderCode,
+		Lines:        make([]response.ReservationLineResponse, len(order.Items)),
+		Reservations: make([]response.StockReservationResponse, len(reservations)),
+	}
+	for i, r := range reservations {
+		if r.Status == models.ReservationActive && (r.ExpiresAt == nil || r.ExpiresAt.After(now)) {
+			reserved[r.OrderItemID] += r.Length
+		}
+		result.Reservations[i] = response.StockReservationResponse{
+			ID:            r.ID,
+			OrderItemID:   r.OrderItemID,
+			LotID:         r.LotID,
+			LotCode:       r.Lot.LotCode,
+			ColorCode:     r.Lot.ColorCode,
+			FabricRollID:  r.FabricRollID,
+			Barcode:       r.FabricRoll.Barcode,
+			Length:        r.Length,
+			Weight:        r.Weight,
+			Status:        r.Status,
+			ExpiresAt:     r.ExpiresAt,
+			ReleasedAt:    r.ReleasedAt,
+			ReleaseReason: r.ReleaseReason,
+			CreatedAt:     r.CreatedAt,
+		}
+	}
+	for i, item := range order.Items {
+		result.Lines[i] = response.ReservationLineResponse{
+			OrderItemID: item.ID,
+			ProductID:   item.ProductID,
+			SKU:         item.Product.SKU,
+			ColorCode:   orderItemColor(&item),
+			Ordered:     item.Quantity,
+			Reserved:    roundQuantity(reserved[item.ID]),
+			Outstanding: math.Max(roundQuantity(item.Quantity-reserved[item.ID]), 0),
+		}
+	}
+
+	return result, nil
+}
+
+// orderItemColor is the color the line is delivered in
+func orderItemColor(item *models.OrderItem) string {
+	if item.ColorCode != "" {
+		return item.ColorCode
+	}
+	return item.Product.Color
+}
@@ -0,0 +1,422 @@
+// File: internal/domain/services/subcontracting.go
+// Tạo tại: internal/domain/services/subcontracting.go
+// Mục đích: Service công nợ nhà gia công dệt/nhuộm: bảng kê công việc gửi đi và số phải trả, ghi hóa đơn, ghi nhận
+// thanh toán và báo cáo tuổi nợ các hóa đơn chưa trả
+
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+var (
+	// ErrSubcontractJobNotFound is returned when a job is not an outside weaving order or a dyeing job
+	ErrSubcontractJobNotFound = errors.New("subcontract job not found")
+	// ErrInvalidSubcontractType is returned when a job type is neither weaving nor dyeing
+	ErrInvalidSubcontractType = errors.New("job type must be weaving or dyeing")
+	// ErrSubcontractNotInvoiced is returned when paying a job that has no invoice yet
+	ErrSubcontractNotInvoiced = errors.New("job has no invoice to pay")
+	// ErrPaymentExceedsBalance is returned when a payment is more than the invoice still owes
+	ErrPaymentExceedsBalance = interfaces.ErrPaymentExceedsBalance
+	// ErrInvoiceBelowPaid is returned when an invoice total is lowered below what was already paid
+	ErrInvoiceBelowPaid = interfaces.ErrInvoiceBelowPaid
+	// ErrInvoiceCurrencyLocked is returned when changing the currency of an invoice that has payments
+	ErrInvoiceCurrencyLocked = interfaces.ErrInvoiceCurrencyLocked
+)
+
+type SubcontractService interface {
+	// GetStatements lists the jobs sent to each subcontractor with what was returned and what is owed.
+	// Statements are per currency; the grand totals are converted at today's rate.
+	GetStatements(req request.SubcontractorStatementRequest) (*response.SubcontractorStatementsResponse, error)
+	// SaveInvoice records or corrects the subcontractor's invoice for a job
+	SaveInvoice(jobType string, jobID uint, req request.SaveSubcontractInvoiceRequest) (*response.SubcontractJobResponse, error)
+	RecordPayment(jobType string, jobID uint, req request.RecordSubcontractorPaymentRequest, userID uint) (*response.SubcontractorPaymentResponse, error)
+	GetPayments(jobType string, jobID uint) ([]response.SubcontractorPaymentResponse, error)
+	// GetAging buckets the unpaid invoice balances by days past their due date, converting the totals
+	// at the rate of the report date
+	GetAging(req request.PayablesAgingRequest) (*response.PayablesAgingResponse, error)
+}
+
+type subcontractService struct {
+	subcontractRepo     interfaces.SubcontractRepository
+	exchangeRateService ExchangeRateService
+}
+
+func NewSubcontractService(
+	subcontractRepo interfaces.SubcontractRepository,
+	exchangeRateService ExchangeRateService,
+) SubcontractService {
+	return &subcontractService{
+		subcontractRepo:     subcontractRepo,
+		exchangeRateService: exchangeRateService,
+	}
+}
+
+func (s *subcontractService) GetStatements(req request.SubcontractorStatementRequest) (*response.SubcontractorStatementsResponse, error) {
+	currency, err := s.reportCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := interfaces.SubcontractJobFilter{
+		Type:       req.Type,
+		Search:     req.Search,
+		UnpaidOnly: req.UnpaidOnly,
+	}
+	if !req.From.IsZero() {
+		filter.From = &req.From
+	}
+	if !req.To.IsZero() {
+		filter.To = &req.To
+	}
+
+	jobs, err := s.subcontractRepo.FindJobs(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.SubcontractorStatementsResponse{
+		Currency:   currency,
+		Statements: []response.SubcontractorStatementResponse{},
+	}
+	statements := make(map[string]int) // Index in Statements
+	for i := range jobs {
+		job := convertSubcontractJobToResponse(&jobs[i])
+
+		key := job.Type + "|" + jobs[i].SubcontractorName + "|" + job.Currency
+		n, ok := statements[key]
+		if !ok {
+			n = len(result.Statements)
+			statements[key] = n
+			result.Statements = append(result.Statements, response.SubcontractorStatementResponse{
+				Type:              job.Type,
+				SubcontractorName: jobs[i].SubcontractorName,
+				Currency:          job.Currency,
+			})
+		}
+		statement := &result.Statements[n]
+		statement.Jobs = append(statement.Jobs, *job)
+		if job.Invoiced {
+			statement.TotalInvoiced += job.AmountDue
+			statement.TotalPaid += job.AmountPaid
+			statement.TotalBalance += job.Balance
+		} else {
+			statement.TotalUninvoiced += job.AmountDue
+		}
+	}
+
+	day := today()
+	for _, statement := range result.Statements {
+		totals := []money.Amount{statement.TotalInvoiced, statement.TotalPaid, statement.TotalBalance, statement.TotalUninvoiced}
+		for j := range totals {
+			if totals[j], err = s.exchangeRateService.Convert(totals[j], statement.Currency, currency, day); err != nil {
+				return nil, err
+			}
+		}
+		result.TotalInvoiced += totals[0]
+		result.TotalPaid += totals[1]
+		result.TotalBalance += totals[2]
+		result.TotalUninvoiced += totals[3]
+	}
+	return result, nil
+}
+
+func (s *subcontractService) SaveInvoice(jobType string, jobID uint, req request.SaveSubcontractInvoiceRequest) (*response.SubcontractJobResponse, error) {
+	job, err := s.findJob(jobType, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	unitCost := job.UnitCost
+	if req.UnitCost != nil {
+		unitCost = *req.UnitCost
+	}
+	quantity := job.ReturnedQuantity
+	if req.Quantity != nil {
+		quantity = roundQuantity(*req.Quantity)
+	}
+	totalCost := unitCost.Mul(quantity)
+	if req.TotalCost != nil {
+		totalCost = *req.TotalCost
+	}
+	currency := job.Currency
+	if req.Currency != "" {
+		if currency, err = money.NormalizeCurrency(req.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	invoiceDate := today()
+	if req.InvoiceDate != nil {
+		invoiceDate = dateOnly(*req.InvoiceDate)
+	}
+	var dueDate *time.Time
+	if req.DueDate != nil {
+		due := dateOnly(*req.DueDate)
+		if due.Before(invoiceDate) {
+			return nil, errors.New("due date is before the invoice date")
+		}
+		dueDate = &due
+	}
+
+	var financialID uint
+	if job.FinancialID != nil {
+		financialID = *job.FinancialID
+	}
+	switch jobType {
+	case models.SubcontractWeaving:
+		err = s.subcontractRepo.SaveWeavingInvoice(&models.WeavingFinancial{
+			ID:             financialID,
+			WeavingOrderID: jobID,
+			UnitCost:       unitCost,
+			Quantity:       quantity,
+			TotalCost:      totalCost,
+			Currency:       currency,
+			DueDate:        dueDate,
+			InvoiceNumber:  req.InvoiceNumber,
+			InvoiceDate:    &invoiceDate,
+			Notes:          req.Notes,
+		})
+	case models.SubcontractDyeing:
+		err = s.subcontractRepo.SaveDyeingInvoice(&models.DyeingFinancial{
+			ID:            financialID,
+			DyeingJobID:   jobID,
+			UnitCost:      unitCost,
+			Quantity:      quantity,
+			TotalCost:     totalCost,
+			Currency:      currency,
+			DueDate:       dueDate,
+			InvoiceNumber: req.InvoiceNumber,
+			InvoiceDate:   &invoiceDate,
+			Notes:         req.Notes,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	job, err = s.findJob(jobType, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return convertSubcontractJobToResponse(job), nil
+}
+
+func (s *subcontractService) RecordPayment(jobType string, jobID uint, req request.RecordSubcontractorPaymentRequest, userID uint) (*response.SubcontractorPaymentResponse, error) {
+	job, err := s.findJob(jobType, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.FinancialID == nil {
+		return nil, ErrSubcontractNotInvoiced
+	}
+
+	payment := &models.SubcontractorPayment{
+		Amount:    req.Amount,
+		PaidAt:    today(),
+		Method:    req.Method,
+		Reference: req.Reference,
+		Notes:     req.Notes,
+	}
+	if jobType == models.SubcontractWeaving {
+		payment.WeavingFinancialID = job.FinancialID
+	} else {
+		payment.DyeingFinancialID = job.FinancialID
+	}
+	if req.PaidAt != nil {
+		payment.PaidAt = dateOnly(*req.PaidAt)
+	}
+	if payment.Method == "" {
+		payment.Method = models.PaymentMethodBankTransfer
+	}
+	if userID != 0 {
+		payment.CreatedBy = &userID
+	}
+
+	if err := s.subcontractRepo.RecordPayment(payment); err != nil {
+		return nil, err
+	}
+	return convertSubcontractorPaymentToResponse(payment, job), nil
+}
+
+func (s *subcontractService) GetPayments(jobType string, jobID uint) ([]response.SubcontractorPaymentResponse, error) {
+	job, err := s.findJob(jobType, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []response.SubcontractorPaymentResponse{}
+	if job.FinancialID == nil {
+		return result, nil
+	}
+
+	payments, err := s.subcontractRepo.FindPayments(jobType, *job.FinancialID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range payments {
+		result = append(result, *convertSubcontractorPaymentToResponse(&payments[i], job))
+	}
+	return result, nil
+}
+
+func (s *subcontractService) GetAging(req request.PayablesAgingRequest) (*response.PayablesAgingResponse, error) {
+	currency, err := s.reportCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	asOf := today()
+	if !req.AsOf.IsZero() {
+		asOf = dateOnly(req.AsOf)
+	}
+
+	jobs, err := s.subcontractRepo.FindJobs(interfaces.SubcontractJobFilter{
+		Type:       req.Type,
+		Search:     req.Search,
+		UnpaidOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.PayablesAgingResponse{
+		AsOf:           asOf,
+		Currency:       currency,
+		Subcontractors: []response.PayablesAgingSubcontractorResponse{},
+	}
+	subcontractors := make(map[string]int) // Index in Subcontractors
+	for i := range jobs {
+		job := &jobs[i]
+		balance := job.TotalCost - job.AmountPaid
+		if balance <= 0 {
+			continue
+		}
+
+		invoice := response.PayablesAgingInvoiceResponse{
+			Type:          job.Type,
+			JobID:         job.JobID,
+			JobCode:       job.JobCode,
+			InvoiceNumber: job.InvoiceNumber,
+			InvoiceDate:   job.InvoiceDate,
+			DueDate:       job.DueDate,
+			Balance:       balance,
+		}
+		if invoice.DueDate == nil {
+			invoice.DueDate = job.InvoiceDate
+		}
+		if invoice.DueDate != nil {
+			invoice.DaysOverdue = max(int(asOf.Sub(dateOnly(*invoice.DueDate)).Hours()/24), 0)
+		}
+
+		key := job.Type + "|" + job.SubcontractorName + "|" + job.Currency
+		n, ok := subcontractors[key]
+		if !ok {
+			n = len(result.Subcontractors)
+			subcontractors[key] = n
+			result.Subcontractors = append(result.Subcontractors, response.PayablesAgingSubcontractorResponse{
+				Type:              job.Type,
+				SubcontractorName: job.SubcontractorName,
+				Currency:          job.Currency,
+			})
+		}
+		subcontractor := &result.Subcontractors[n]
+		subcontractor.Invoices = append(subcontractor.Invoices, invoice)
+
+		converted, err := s.exchangeRateService.Convert(balance, job.Currency, currency, asOf)
+		if err != nil {
+			return nil, err
+		}
+		addToAgingBucket(&subcontractor.Buckets, invoice.DaysOverdue, balance)
+		addToAgingBucket(&result.Totals, invoice.DaysOverdue, converted)
+	}
+	return result, nil
+}
+
+// reportCurrency is the requested currency, or the base currency when none is given
+func (s *subcontractService) reportCurrency(code string) (string, error) {
+	if code == "" {
+		return s.exchangeRateService.BaseCurrency(), nil
+	}
+	return money.NormalizeCurrency(code)
+}
+
+func (s *subcontractService) findJob(jobType string, jobID uint) (*interfaces.SubcontractJob, error) {
+	if jobType != models.SubcontractWeaving && jobType != models.SubcontractDyeing {
+		return nil, ErrInvalidSubcontractType
+	}
+	job, err := s.subcontractRepo.FindJob(jobType, jobID)
+	if err != nil {
+		return nil, ErrSubcontractJobNotFound
+	}
+	return job, nil
+}
+
+// addToAgingBucket adds a balance to the bucket of its days past due; invoices not yet due are current
+func addToAgingBucket(buckets *response.AgingBuckets, daysOverdue int, balance money.Amount) {
+	switch {
+	case daysOverdue <= 0:
+		buckets.Current += balance
+	case daysOverdue <= 30:
+		buckets.Days1To30 += balance
+	case daysOverdue <= 60:
+		buckets.Days31To60 += balance
+	case daysOverdue <= 90:
+		buckets.Days61To90 += balance
+	default:
+		buckets.Over90 += balance
+	}
+	buckets.Total += balance
+}
+
+// convertSubcontractJobToResponse shows what a job owes: the invoice balance, or an estimate at the agreed
+// rate for the quantity returned when there is no invoice yet
+func convertSubcontractJobToResponse(job *interfaces.SubcontractJob) *response.SubcontractJobResponse {
+	result := &response.SubcontractJobResponse{
+		Type:             job.Type,
+		JobID:            job.JobID,
+		JobCode:          job.JobCode,
+		Description:      job.Description,
+		SentAt:           job.SentAt,
+		SentQuantity:     job.SentQuantity,
+		ReturnedQuantity: job.ReturnedQuantity,
+		UnitCost:         job.UnitCost,
+		Currency:         job.Currency,
+		Invoiced:         job.FinancialID != nil,
+		InvoiceNumber:    job.InvoiceNumber,
+		InvoiceDate:      job.InvoiceDate,
+		DueDate:          job.DueDate,
+		BilledQuantity:   job.BilledQuantity,
+		AmountPaid:       job.AmountPaid,
+		PaymentStatus:    job.PaymentStatus,
+	}
+	if result.Invoiced {
+		result.AmountDue = job.TotalCost
+	} else {
+		result.AmountDue = job.UnitCost.Mul(job.ReturnedQuantity)
+	}
+	result.Balance = result.AmountDue - result.AmountPaid
+	return result
+}
+
+func convertSubcontractorPaymentToResponse(payment *models.SubcontractorPayment, job *interfaces.SubcontractJob) *response.SubcontractorPaymentResponse {
+	return &response.SubcontractorPaymentResponse{
+		ID:        payment.ID,
+		Type:      job.Type,
+		JobID:     job.JobID,
+		Amount:    payment.Amount,
+		Currency:  job.Currency,
+		PaidAt:    payment.PaidAt,
+		Method:    payment.Method,
+		Reference: payment.Reference,
+		Notes:     payment.Notes,
+		CreatedBy: payment.CreatedBy,
+		CreatedAt: payment.CreatedAt,
+	}
+}
@@ -17,6 +17,7 @@ import (
 
 type UserService interface {
 	GetUsers(req request.UserFilterRequest) (*response.PaginatedResponse, error)
+	GetUsersByCursor(req request.UserFilterRequest) (*response.CursorPaginatedResponse, error)
 	GetUserByID(id uint) (*response.UserDetailResponse, error)
 	CreateUser(req request.CreateUserRequest) (*response.UserDetailResponse, error)
 	UpdateUser(id uint, req request.UpdateUserRequest) (*response.UserDetailResponse, error)
@@ -68,6 +69,57 @@ func (s *userService) GetUsers(req request.UserFilterRequest) (*response.Paginat
 	}, nil
 }
 
+// userSortFields lists the indexed columns users can be paged by with a cursor
+var userSortFields = map[string]bool{
+	"id":             true,
+	"username":       true,
+	"email":          true,
+	"account_status": true,
+	"created_at":     true,
+}
+
+func (s *userService) GetUsersByCursor(req request.UserFilterRequest) (*response.CursorPaginatedResponse, error) {
+	limit := cursorPageLimit(req.Limit)
+
+	sorts, err := parseSort(req.SortBy, "", userSortFields)
+	if err != nil {
+		return nil, err
+	}
+	after, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	sort, err := cursorSort(sorts, interfaces.SortField{Field: "id"}, after)
+	if err != nil {
+		return nil, err
+	}
+	spec := interfaces.QuerySpec{Search: req.Search, Sort: []interfaces.SortField{sort}}
+
+	users, next, err := s.userRepo.FindPage(spec, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, estimated, err := pageTotal(s.userRepo, spec, req.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(users))
+	for i := range users {
+		items[i] = convertUserToResponse(&users[i])
+	}
+
+	return &response.CursorPaginatedResponse{
+		Items:          items,
+		Limit:          limit,
+		NextCursor:     encodeCursor(next),
+		HasMore:        next != nil,
+		TotalItems:     total,
+		TotalEstimated: estimated,
+	}, nil
+}
+
 func (s *userService) GetUserByID(id uint) (*response.UserDetailResponse, error) {
 	user, err := s.userRepo.FindByIDWithRoles(id)
 	if err != nil {
@@ -0,0 +1,745 @@
+// File: internal/domain/services/weaving.go
+// Tạo tại: internal/domain/services/weaving.go
+// Mục đích: Service lệnh dệt theo đơn hàng, ghi nhận công đoạn dệt theo máy/công nhân/ca và tổng hợp tiến độ lên lệnh dệt và đơn hàng
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+const (
+	weavingOrderCodePrefix = "WO"
+	weavingOrderCodeDigits = 6
+)
+
+var (
+	// ErrWeavingOrderNotFound is returned when a weaving order ID does not exist
+	ErrWeavingOrderNotFound = errors.New("weaving order not found")
+	// ErrWeavingOperationNotFound is returned when a weaving operation ID does not exist
+	ErrWeavingOperationNotFound = errors.New("weaving operation not found")
+	// ErrWeavingOrderStatusChanged is returned when someone else changed the weaving order status meanwhile
+	ErrWeavingOrderStatusChanged = interfaces.ErrWeavingOrderStatusChanged
+	// ErrWeavingOrderGuardFailed wraps the reason a status guard rejected the change
+	ErrWeavingOrderGuardFailed = errors.New("weaving order status change not possible")
+)
+
+// WeavingOrderGuard rejects a weaving order status change by returning an error; the message is shown to the user.
+// It runs in tx, the transaction that saves the change, with the weaving order row locked.
+type WeavingOrderGuard func(order *models.WeavingOrder, tx *gorm.DB) error
+
+// weavableOrderStatuses are the sales order statuses weaving orders can be created for
+var weavableOrderStatuses = map[string]bool{
+	models.OrderStatusConfirmed:    true,
+	models.OrderStatusInProduction: true,
+}
+
+type WeavingService interface {
+	GetWeavingOrders(req request.WeavingOrderFilterRequest) (*response.PaginatedResponse, error)
+	GetWeavingOrderByID(id uint) (*response.WeavingOrderResponse, error)
+	CreateWeavingOrder(req request.CreateWeavingOrderRequest, userID uint) (*response.WeavingOrderResponse, error)
+	UpdateWeavingOrder(id uint, req request.UpdateWeavingOrderRequest) (*response.WeavingOrderResponse, error)
+	// DeleteWeavingOrder removes a pending weaving order; started ones are cancelled instead
+	DeleteWeavingOrder(id uint) error
+	// ChangeStatus starts, completes, sends to dyeing or cancels a weaving order. Starting moves a
+	// confirmed sales order into production.
+	ChangeStatus(id uint, req request.ChangeWeavingOrderStatusRequest, userID uint) (*response.WeavingOrderResponse, error)
+
+	GetOperations(req request.WeavingOperationFilterRequest) (*response.PaginatedResponse, error)
+	// LogOperation records a run of the weaving order and adds its quantity to the order's progress.
+	// The first operation starts a pending order.
+	LogOperation(weavingOrderID uint, req request.CreateWeavingOperationRequest, userID uint) (*response.WeavingOperationResponse, error)
+	UpdateOperation(id uint, req request.UpdateWeavingOperationRequest) (*response.WeavingOperationResponse, error)
+	DeleteOperation(id uint) error
+
+	// GetOrderWeaving rolls the sales order's weaving orders up per product and for the whole order
+	GetOrderWeaving(orderID uint) (*response.OrderWeavingResponse, error)
+
+	// RegisterOrderEffects cancels the open weaving orders of a cancelled sales order
+	RegisterOrderEffects(workflow OrderWorkflowService)
+	// AddStatusGuard checks a weaving order before it changes to status, e.g. to hold failed fabric back from dyeing
+	AddStatusGuard(status string, guard WeavingOrderGuard)
+	// CheckStatusGuards runs the guards of status on the order in tx; the error wraps ErrWeavingOrderGuardFailed
+	CheckStatusGuards(order *models.WeavingOrder, status string, tx *gorm.DB) error
+}
+
+type weavingService struct {
+	weavingRepo  interfaces.WeavingOrderRepository
+	facilityRepo interfaces.WeavingFacilityRepository
+	orderRepo    interfaces.OrderRepository
+	yarnBoxRepo  interfaces.YarnBoxRepository
+	machineRepo  interfaces.WeavingMachineRepository
+	guards       map[string][]WeavingOrderGuard
+}
+
+func NewWeavingService(
+	weavingRepo interfaces.WeavingOrderRepository,
+	facilityRepo interfaces.WeavingFacilityRepository,
+	orderRepo interfaces.OrderRepository,
+	yarnBoxRepo interfaces.YarnBoxRepository,
+	machineRepo interfaces.WeavingMachineRepository,
+) WeavingService {
+	return &weavingService{
+		weavingRepo:  weavingRepo,
+		facilityRepo: facilityRepo,
+		orderRepo:    orderRepo,
+		yarnBoxRepo:  yarnBoxRepo,
+		machineRepo:  machineRepo,
+		guards:       make(map[string][]WeavingOrderGuard),
+	}
+}
+
+func (s *weavingService) GetWeavingOrders(req request.WeavingOrderFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	filter := interfaces.WeavingOrderFilter{
+		Search:        strings.TrimSpace(req.Search),
+		FacilityID:    req.FacilityID,
+		LinkedOrderID: req.LinkedOrderID,
+		ProductID:     req.ProductID,
+	}
+	for _, status := range splitValues(req.Status) {
+		switch status = strings.ToLower(status); status {
+		case models.WeavingOrderPending, models.WeavingOrderInProgress, models.WeavingOrderCompleted,
+			models.WeavingOrderSentToDyeing, models.WeavingOrderCancelled:
+			filter.Statuses = append(filter.Statuses, status)
+		default:
+			return nil, fmt.Errorf("%w: unknown weaving order status %q", ErrInvalidQuery, status)
+		}
+	}
+
+	orders, total, err := s.weavingRepo.FindAll(filter, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(orders))
+	for i := range orders {
+		items[i] = convertWeavingOrderToResponse(&orders[i])
+	}
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.Limit))),
+	}, nil
+}
+
+func (s *weavingService) GetWeavingOrderByID(id uint) (*response.WeavingOrderResponse, error) {
+	order, err := s.weavingRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrWeavingOrderNotFound
+	}
+	return convertWeavingOrderToResponse(order), nil
+}
+
+func (s *weavingService) CreateWeavingOrder(req request.CreateWeavingOrderRequest, userID uint) (*response.WeavingOrderResponse, error) {
+	salesOrder, err := s.orderRepo.FindByID(req.LinkedOrderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+	if !weavableOrderStatuses[salesOrder.OrderStatus.StatusName] {
+		return nil, fmt.Errorf("cannot weave for an order that is %s", salesOrder.OrderStatus.StatusName)
+	}
+
+	var sku, colorCode string
+	onOrder := false
+	for _, item := range salesOrder.Items {
+		if item.ProductID == req.ProductID {
+			sku, onOrder = item.Product.SKU, true
+			// A line naming no color is in the product color
+			colorCode = item.ColorCode
+			if colorCode == "" {
+				colorCode = item.Product.Color
+			}
+			break
+		}
+	}
+	if !onOrder {
+		return nil, fmt.Errorf("product %d is not on order %s", req.ProductID, salesOrder.OrderCode)
+	}
+
+	order := &models.WeavingOrder{
+		OrderCode:         strings.TrimSpace(req.OrderCode),
+		WeavingFacilityID: req.WeavingFacilityID,
+		LinkedOrderID:     salesOrder.ID,
+		YarnBoxID:         req.YarnBoxID,
+		ProductID:         req.ProductID,
+		ColorCode:         colorCode,
+		PlannedQuantity:   roundQuantity(req.PlannedQuantity),
+		Status:            models.WeavingOrderPending,
+		Notes:             req.Notes,
+		CreatedBy:         &userID,
+	}
+	if color := strings.TrimSpace(req.ColorCode); color != "" {
+		order.ColorCode = color
+	}
+	if req.EndDate != nil {
+		end := dateOnly(*req.EndDate)
+		order.EndDate = &end
+	}
+	if err := s.validateWeavingOrder(order); err != nil {
+		return nil, err
+	}
+
+	// Without a planned quantity, weave what the sales order still needs planned of the product
+	if order.PlannedQuantity == 0 {
+		progress, err := s.weavingRepo.Progress(salesOrder.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range progress {
+			if line.ProductID == order.ProductID {
+				order.PlannedQuantity = math.Max(roundQuantity(line.Ordered-line.Planned), 0)
+			}
+		}
+		if order.PlannedQuantity == 0 {
+			return nil, fmt.Errorf("%s of order %s is already fully planned for weaving", sku, salesOrder.OrderCode)
+		}
+	}
+
+	// Explicit code: must be unused
+	if order.OrderCode != "" {
+		if existing, _ := s.weavingRepo.FindByCode(order.OrderCode); existing != nil {
+			return nil, errors.New("weaving order code already exists")
+		}
+		if err := s.weavingRepo.Create(order); err != nil {
+			return nil, err
+		}
+		return s.GetWeavingOrderByID(order.ID)
+	}
+
+	// Generated code: retry with the next number when a concurrent create took it
+	for attempt := 0; attempt < orderCodeAttempts; attempt++ {
+		code, err := s.nextWeavingOrderCode()
+		if err != nil {
+			return nil, err
+		}
+		order.ID = 0
+		order.OrderCode = code
+
+		err = s.weavingRepo.Create(order)
+		if err == nil {
+			return s.GetWeavingOrderByID(order.ID)
+		}
+		if existing, _ := s.weavingRepo.FindByCode(code); existing == nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("could not generate a unique weaving order code")
+}
+
+func (s *weavingService) UpdateWeavingOrder(id uint, req request.UpdateWeavingOrderRequest) (*response.WeavingOrderResponse, error) {
+	order, err := s.weavingRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrWeavingOrderNotFound
+	}
+	if order.Status != models.WeavingOrderPending && order.Status != models.WeavingOrderInProgress {
+		return nil, fmt.Errorf("cannot change a %s weaving order", order.Status)
+	}
+
+	if req.WeavingFacilityID != nil && *req.WeavingFacilityID != order.WeavingFacilityID {
+		if order.Status != models.WeavingOrderPending {
+			return nil, errors.New("cannot move a started weaving order to another facility")
+		}
+		order.WeavingFacilityID = *req.WeavingFacilityID
+	}
+	if req.YarnBoxID != nil {
+		order.YarnBoxID = *req.YarnBoxID
+	}
+	if req.PlannedQuantity != nil {
+		order.PlannedQuantity = roundQuantity(*req.PlannedQuantity)
+	}
+	if req.ColorCode != nil {
+		order.ColorCode = strings.TrimSpace(*req.ColorCode)
+	}
+	if req.EndDate != nil {
+		end := dateOnly(*req.EndDate)
+		order.EndDate = &end
+	}
+	if req.Notes != nil {
+		order.Notes = *req.Notes
+	}
+	if err := s.validateWeavingOrder(order); err != nil {
+		return nil, err
+	}
+
+	if err := s.weavingRepo.Update(order); err != nil {
+		return nil, err
+	}
+	return s.GetWeavingOrderByID(order.ID)
+}
+
+func (s *weavingService) DeleteWeavingOrder(id uint) error {
+	order, err := s.weavingRepo.FindByID(id)
+	if err != nil {
+		return ErrWeavingOrderNotFound
+	}
+	if order.Status != models.WeavingOrderPending {
+		return fmt.Errorf("weaving order is %s; cancel it instead", order.Status)
+	}
+	return s.weavingRepo.Delete(id)
+}
+
+func (s *weavingService) ChangeStatus(id uint, req request.ChangeWeavingOrderStatusRequest, userID uint) (*response.WeavingOrderResponse, error) {
+	order, err := s.weavingRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrWeavingOrderNotFound
+	}
+	if order.Status == req.Status {
+		return nil, fmt.Errorf("weaving order is already %s", order.Status)
+	}
+
+	now := time.Now()
+	var from []string
+	switch req.Status {
+	case models.WeavingOrderInProgress:
+		from = []string{models.WeavingOrderPending}
+		if order.StartDate == nil {
+			order.StartDate = &now
+		}
+	case models.WeavingOrderCompleted:
+		from = []string{models.WeavingOrderInProgress}
+		if order.ProducedQuantity <= 0 {
+			return nil, errors.New("cannot complete a weaving order with nothing woven")
+		}
+		order.EndDate = &now
+	case models.WeavingOrderSentToDyeing:
+		from = []string{models.WeavingOrderCompleted}
+	case models.WeavingOrderCancelled:
+		from = []string{models.WeavingOrderPending, models.WeavingOrderInProgress}
+		order.EndDate = &now
+	default:
+		return nil, fmt.Errorf("cannot set a weaving order to %s", req.Status)
+	}
+	if !slices.Contains(from, order.Status) {
+		return nil, fmt.Errorf("a %s weaving order cannot become %s", order.Status, req.Status)
+	}
+	status := req.Status
+	order.Status = status
+	err = s.weavingRepo.ChangeStatus(order, from, func(tx *gorm.DB) error {
+		return s.CheckStatusGuards(order, status, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == models.WeavingOrderInProgress {
+		s.startSalesOrder(order, userID)
+	}
+	return s.GetWeavingOrderByID(order.ID)
+}
+
+func (s *weavingService) GetOperations(req request.WeavingOperationFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	filter := interfaces.WeavingOperationFilter{
+		WeavingOrderID: req.WeavingOrderID,
+		FacilityID:     req.FacilityID,
+		StaffID:        req.StaffID,
+		ShiftID:        req.ShiftID,
+		MachineID:      req.MachineID,
+	}
+	if !req.From.IsZero() {
+		from := dateOnly(req.From)
+		filter.From = &from
+	}
+	if !req.To.IsZero() {
+		to := dateOnly(req.To).AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	operations, total, err := s.weavingRepo.FindOperations(filter, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(operations))
+	for i := range operations {
+		items[i] = convertWeavingOperationToResponse(&operations[i])
+	}
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.Limit))),
+	}, nil
+}
+
+func (s *weavingService) LogOperation(weavingOrderID uint, req request.CreateWeavingOperationRequest, userID uint) (*response.WeavingOperationResponse, error) {
+	order, err := s.weavingRepo.FindByID(weavingOrderID)
+	if err != nil {
+		return nil, ErrWeavingOrderNotFound
+	}
+	if order.Status != models.WeavingOrderPending && order.Status != models.WeavingOrderInProgress {
+		return nil, fmt.Errorf("cannot log operations on a %s weaving order", order.Status)
+	}
+
+	operation := &models.WeavingOperation{
+		WeavingOrderID:    order.ID,
+		WeavingFacilityID: order.WeavingFacilityID,
+		WeavingStaffID:    req.WeavingStaffID,
+		WeavingShiftID:    req.WeavingShiftID,
+		YarnBoxID:         req.YarnBoxID,
+		WeavingMachineID:  req.WeavingMachineID,
+		CamLayout:         req.CamLayout,
+		StartTime:         req.StartTime,
+		EndTime:           req.EndTime,
+		Quantity:          roundQuantity(req.Quantity),
+		Notes:             req.Notes,
+	}
+	if operation.YarnBoxID == 0 {
+		operation.YarnBoxID = order.YarnBoxID
+	}
+	if operation.WeavingShiftID == nil {
+		if staff, err := s.facilityRepo.FindStaffByID(operation.WeavingStaffID); err == nil {
+			operation.WeavingShiftID = staff.ShiftID
+		}
+	}
+	if err := s.validateOperation(operation); err != nil {
+		return nil, err
+	}
+
+	if err := s.weavingRepo.CreateOperation(operation); err != nil {
+		return nil, err
+	}
+	if order.Status == models.WeavingOrderPending {
+		s.startSalesOrder(order, userID)
+	}
+
+	created, err := s.weavingRepo.FindOperationByID(operation.ID)
+	if err != nil {
+		return nil, err
+	}
+	return convertWeavingOperationToResponse(created), nil
+}
+
+func (s *weavingService) UpdateOperation(id uint, req request.UpdateWeavingOperationRequest) (*response.WeavingOperationResponse, error) {
+	operation, err := s.weavingRepo.FindOperationByID(id)
+	if err != nil {
+		return nil, ErrWeavingOperationNotFound
+	}
+
+	if req.WeavingStaffID != nil {
+		operation.WeavingStaffID = *req.WeavingStaffID
+	}
+	if req.WeavingShiftID != nil {
+		operation.WeavingShiftID = req.WeavingShiftID
+		if *req.WeavingShiftID == 0 {
+			operation.WeavingShiftID = nil
+		}
+	}
+	if req.YarnBoxID != nil {
+		operation.YarnBoxID = *req.YarnBoxID
+	}
+	if req.WeavingMachineID != nil {
+		operation.WeavingMachineID = req.WeavingMachineID
+		if *req.WeavingMachineID == 0 {
+			operation.WeavingMachineID = nil
+		}
+	}
+	if req.CamLayout != nil {
+		operation.CamLayout = *req.CamLayout
+	}
+	if req.StartTime != nil {
+		operation.StartTime = req.StartTime
+	}
+	if req.EndTime != nil {
+		operation.EndTime = req.EndTime
+	}
+	if req.Quantity != nil {
+		operation.Quantity = roundQuantity(*req.Quantity)
+	}
+	if req.Notes != nil {
+		operation.Notes = *req.Notes
+	}
+	if err := s.validateOperation(operation); err != nil {
+		return nil, err
+	}
+
+	if err := s.weavingRepo.UpdateOperation(operation); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.weavingRepo.FindOperationByID(operation.ID)
+	if err != nil {
+		return nil, err
+	}
+	return convertWeavingOperationToResponse(updated), nil
+}
+
+func (s *weavingService) DeleteOperation(id uint) error {
+	operation, err := s.weavingRepo.FindOperationByID(id)
+	if err != nil {
+		return ErrWeavingOperationNotFound
+	}
+	return s.weavingRepo.DeleteOperation(operation)
+}
+
+func (s *weavingService) GetOrderWeaving(orderID uint) (*response.OrderWeavingResponse, error) {
+	salesOrder, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+	progress, err := s.weavingRepo.Progress(salesOrder.ID)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := s.weavingRepo.FindByLinkedOrder(salesOrder.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	skus := make(map[uint]string)
+	for _, item := range salesOrder.Items {
+		skus[item.ProductID] = item.Product.SKU
+	}
+	for _, order := range orders {
+		if _, ok := skus[order.ProductID]; !ok {
+			skus[order.ProductID] = order.Product.SKU
+		}
+	}
+
+	result := &response.OrderWeavingResponse{
+		OrderID:       salesOrder.ID,
+		OrderCode:     salesOrder.OrderCode,
+		OrderStatus:   salesOrder.OrderStatus.StatusName,
+		Products:      make([]response.WeavingProductProgressResponse, len(progress)),
+		WeavingOrders: make([]response.WeavingOrderResponse, len(orders)),
+	}
+	for i, line := range progress {
+		result.Products[i] = response.WeavingProductProgressResponse{
+			ProductID: line.ProductID,
+			SKU:       skus[line.ProductID],
+			Ordered:   line.Ordered,
+			Planned:   line.Planned,
+			Produced:  line.Produced,
+			Progress:  weavingProgress(line.Produced, line.Ordered),
+		}
+		result.Ordered = roundQuantity(result.Ordered + line.Ordered)
+		result.Planned = roundQuantity(result.Planned + line.Planned)
+		result.Produced = roundQuantity(result.Produced + line.Produced)
+	}
+	result.Progress = weavingProgress(result.Produced, result.Ordered)
+	for i := range orders {
+		result.WeavingOrders[i] = *convertWeavingOrderToResponse(&orders[i])
+	}
+	return result, nil
+}
+
+func (s *weavingService) RegisterOrderEffects(workflow OrderWorkflowService) {
+	workflow.AddEffect(OrderActionCancel, func(t *OrderTransitionContext) error {
+		_, err := s.weavingRepo.WithTx(t.Tx).CancelByLinkedOrder(t.Order.ID, time.Now())
+		return err
+	})
+}
+
+func (s *weavingService) AddStatusGuard(status string, guard WeavingOrderGuard) {
+	s.guards[status] = append(s.guards[status], guard)
+}
+
+func (s *weavingService) CheckStatusGuards(order *models.WeavingOrder, status string, tx *gorm.DB) error {
+	for _, guard := range s.guards[status] {
+		if err := guard(order, tx); err != nil {
+			return fmt.Errorf("%w: %v", ErrWeavingOrderGuardFailed, err)
+		}
+	}
+	return nil
+}
+
+// validateWeavingOrder checks the facility and yarn box of the order exist. A box the order did not
+// have before must not be depleted.
+func (s *weavingService) validateWeavingOrder(order *models.WeavingOrder) error {
+	if _, err := s.facilityRepo.FindByID(order.WeavingFacilityID); err != nil {
+		return ErrWeavingFacilityNotFound
+	}
+	box, err := s.yarnBoxRepo.FindByID(order.YarnBoxID)
+	if err != nil {
+		return ErrYarnBoxNotFound
+	}
+	if box.Status == models.YarnBoxDepleted && box.ID != order.YarnBox.ID {
+		return fmt.Errorf("yarn box %s is depleted", box.BoxCode)
+	}
+	if order.PlannedQuantity < 0 {
+		return errors.New("planned_quantity cannot be negative")
+	}
+	if order.StartDate != nil && order.EndDate != nil && order.EndDate.Before(dateOnly(*order.StartDate)) {
+		return errors.New("planned end date is before the start date")
+	}
+	return nil
+}
+
+// validateOperation checks the staff, shift and machine belong to the operation's facility
+func (s *weavingService) validateOperation(operation *models.WeavingOperation) error {
+	staff, err := s.facilityRepo.FindStaffByID(operation.WeavingStaffID)
+	if err != nil {
+		return ErrWeavingStaffNotFound
+	}
+	if staff.WeavingFacilityID != operation.WeavingFacilityID {
+		return fmt.Errorf("%s works at another facility", staff.StaffName)
+	}
+	if operation.WeavingShiftID != nil {
+		shift, err := s.facilityRepo.FindShiftByID(*operation.WeavingShiftID)
+		if err != nil {
+			return ErrWeavingShiftNotFound
+		}
+		if shift.WeavingFacilityID != operation.WeavingFacilityID {
+			return fmt.Errorf("shift %s belongs to another facility", shift.ShiftName)
+		}
+	}
+	if operation.WeavingMachineID != nil && (operation.WeavingMachine == nil || operation.WeavingMachine.ID != *operation.WeavingMachineID) {
+		machine, err := s.machineRepo.FindMachineByID(*operation.WeavingMachineID)
+		if err != nil {
+			return ErrWeavingMachineNotFound
+		}
+		if machine.WeavingFacilityID != operation.WeavingFacilityID {
+			return fmt.Errorf("machine %s belongs to another facility", machine.MachineCode)
+		}
+		if machine.Status == models.WeavingMachineRetired {
+			return fmt.Errorf("machine %s is retired", machine.MachineCode)
+		}
+	}
+	if _, err := s.yarnBoxRepo.FindByID(operation.YarnBoxID); err != nil {
+		return ErrYarnBoxNotFound
+	}
+	if operation.StartTime != nil && operation.EndTime != nil && operation.EndTime.Before(*operation.StartTime) {
+		return errors.New("end_time is before start_time")
+	}
+	return nil
+}
+
+// startSalesOrder moves the weaving order's sales order into production when it is still confirmed.
+// The weaving change is already saved, so a failure is only logged.
+func (s *weavingService) startSalesOrder(order *models.WeavingOrder, userID uint) {
+	salesOrder, err := s.orderRepo.FindByID(order.LinkedOrderID)
+	if err != nil || salesOrder.OrderStatus.StatusName != models.OrderStatusConfirmed {
+		return
+	}
+	status, err := s.orderRepo.FindStatusByName(models.OrderStatusInProduction)
+	if err != nil {
+		log.Printf("Warning: order status %s is not configured: %v", models.OrderStatusInProduction, err)
+		return
+	}
+
+	fromStatusID := salesOrder.OrderStatusID
+	salesOrder.OrderStatusID = status.ID
+	salesOrder.OrderStatus = *status
+	history := &models.OrderHistory{
+		StatusID:  status.ID,
+		ChangedAt: time.Now(),
+		ChangedBy: userID,
+		Notes:     fmt.Sprintf("Weaving order %s started", order.OrderCode),
+	}
+	if err := s.orderRepo.ChangeStatus(salesOrder, fromStatusID, history, nil); err != nil && !errors.Is(err, ErrOrderStatusChanged) {
+		log.Printf("Warning: failed to start production of order %d: %v", salesOrder.ID, err)
+	}
+}
+
+func (s *weavingService) nextWeavingOrderCode() (string, error) {
+	last, err := s.weavingRepo.LastCodeWithPrefix(weavingOrderCodePrefix)
+	if err != nil {
+		return "", err
+	}
+
+	next := 1
+	if last != "" {
+		number, err := strconv.Atoi(strings.TrimPrefix(last, weavingOrderCodePrefix))
+		if err != nil {
+			return "", err
+		}
+		next = number + 1
+	}
+	return fmt.Sprintf("%s%0*d", weavingOrderCodePrefix, weavingOrderCodeDigits, next), nil
+}
+
+// weavingProgress is done as a percent of target, 0 when there is no target
+func weavingProgress(done, target float64) float64 {
+	if target <= 0 {
+		return 0
+	}
+	return roundQuantity(done / target * 100)
+}
+
+func convertWeavingOrderToResponse(order *models.WeavingOrder) *response.WeavingOrderResponse {
+	return &response.WeavingOrderResponse{
+		ID:                order.ID,
+		OrderCode:         order.OrderCode,
+		WeavingFacilityID: order.WeavingFacilityID,
+		FacilityName:      order.WeavingFacility.FacilityName,
+		LinkedOrderID:     order.LinkedOrderID,
+		LinkedOrderCode:   order.LinkedOrder.OrderCode,
+		YarnBoxID:         order.YarnBoxID,
+		BoxCode:           order.YarnBox.BoxCode,
+		YarnType:          order.YarnBox.YarnType,
+		ProductID:         order.ProductID,
+		SKU:               order.Product.SKU,
+		ColorCode:         order.ColorCode,
+		PlannedQuantity:   order.PlannedQuantity,
+		ProducedQuantity:  order.ProducedQuantity,
+		Progress:          weavingProgress(order.ProducedQuantity, order.PlannedQuantity),
+		StartDate:         order.StartDate,
+		EndDate:           order.EndDate,
+		Status:            order.Status,
+		Notes:             order.Notes,
+		CreatedBy:         order.CreatedBy,
+		CreatedAt:         order.CreatedAt,
+		UpdatedAt:         order.UpdatedAt,
+	}
+}
+
+func convertWeavingOperationToResponse(operation *models.WeavingOperation) *response.WeavingOperationResponse {
+	result := &response.WeavingOperationResponse{
+		ID:                 operation.ID,
+		WeavingOrderID:     operation.WeavingOrderID,
+		WeavingOrderCode:   operation.WeavingOrder.OrderCode,
+		WeavingFacilityID:  operation.WeavingFacilityID,
+		WeavingStaffID:     operation.WeavingStaffID,
+		StaffName:          operation.WeavingStaff.StaffName,
+		WeavingShiftID:     operation.WeavingShiftID,
+		YarnBoxID:          operation.YarnBoxID,
+		BoxCode:            operation.YarnBox.BoxCode,
+		WeavingMachineID:   operation.WeavingMachineID,
+		CamLayout:          operation.CamLayout,
+		StartTime:          operation.StartTime,
+		EndTime:            operation.EndTime,
+		Quantity:           operation.Quantity,
+		QualityCheckStatus: operation.QualityCheckStatus,
+		Notes:              operation.Notes,
+		CreatedAt:          operation.CreatedAt,
+	}
+	if operation.WeavingShift != nil {
+		result.ShiftName = operation.WeavingShift.ShiftName
+	}
+	if operation.WeavingMachine != nil {
+		result.MachineCode = operation.WeavingMachine.MachineCode
+	}
+	return result
+}
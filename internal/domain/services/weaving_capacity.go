@@ -0,0 +1,688 @@
+// File: internal/domain/services/weaving_capacity.go
+// Tạo tại: internal/domain/services/weaving_capacity.go
+// Mục đích: Service danh mục máy dệt, lịch phân ca công nhân theo ngày và kế hoạch công suất: đề xuất máy, ca và ngày
+// hoàn thành dự kiến cho các lệnh dệt đang mở, cảnh báo quá tải
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+const (
+	defaultPlanDays = 14
+	maxRosterDays   = 31
+)
+
+var (
+	// ErrWeavingMachineNotFound is returned when a weaving machine ID does not exist
+	ErrWeavingMachineNotFound = errors.New("weaving machine not found")
+	// ErrWeavingRosterNotFound is returned when a roster entry ID does not exist
+	ErrWeavingRosterNotFound = errors.New("weaving roster not found")
+)
+
+type WeavingCapacityService interface {
+	GetMachines(req request.WeavingMachineFilterRequest) ([]response.WeavingMachineResponse, error)
+	GetMachineByID(id uint) (*response.WeavingMachineResponse, error)
+	CreateMachine(req request.CreateWeavingMachineRequest) (*response.WeavingMachineResponse, error)
+	UpdateMachine(id uint, req request.UpdateWeavingMachineRequest) (*response.WeavingMachineResponse, error)
+	// DeleteMachine removes the machine from the registry; logged operations keep it
+	DeleteMachine(id uint) error
+
+	GetRosters(facilityID uint, req request.WeavingRosterFilterRequest) ([]response.WeavingRosterResponse, error)
+	// SaveRosters puts staff on a shift of the facility for a range of days, replacing what they were
+	// rostered for on those days
+	SaveRosters(facilityID uint, req request.CreateWeavingRostersRequest, userID uint) ([]response.WeavingRosterResponse, error)
+	DeleteRoster(id uint) error
+
+	// PlanCapacity proposes a machine and shifts for each pending or in-progress weaving order of the
+	// facility, earliest due date first, and flags orders that finish late or do not fit in the window
+	PlanCapacity(facilityID uint, req request.WeavingCapacityPlanRequest) (*response.WeavingCapacityPlanResponse, error)
+}
+
+type weavingCapacityService struct {
+	machineRepo  interfaces.WeavingMachineRepository
+	facilityRepo interfaces.WeavingFacilityRepository
+	weavingRepo  interfaces.WeavingOrderRepository
+}
+
+func NewWeavingCapacityService(
+	machineRepo interfaces.WeavingMachineRepository,
+	facilityRepo interfaces.WeavingFacilityRepository,
+	weavingRepo interfaces.WeavingOrderRepository,
+) WeavingCapacityService {
+	return &weavingCapacityService{
+		machineRepo:  machineRepo,
+		facilityRepo: facilityRepo,
+		weavingRepo:  weavingRepo,
+	}
+}
+
+func (s *weavingCapacityService) GetMachines(req request.WeavingMachineFilterRequest) ([]response.WeavingMachineResponse, error) {
+	filter := interfaces.WeavingMachineFilter{
+		FacilityID: req.FacilityID,
+		LoomType:   req.LoomType,
+		Search:     strings.TrimSpace(req.Search),
+	}
+	for _, status := range splitValues(req.Status) {
+		switch status = strings.ToLower(status); status {
+		case models.WeavingMachineActive, models.WeavingMachineMaintenance, models.WeavingMachineRetired:
+			filter.Statuses = append(filter.Statuses, status)
+		default:
+			return nil, fmt.Errorf("%w: unknown machine status %q", ErrInvalidQuery, status)
+		}
+	}
+
+	machines, err := s.machineRepo.FindMachines(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.WeavingMachineResponse, len(machines))
+	for i := range machines {
+		result[i] = *convertWeavingMachineToResponse(&machines[i])
+	}
+	return result, nil
+}
+
+func (s *weavingCapacityService) GetMachineByID(id uint) (*response.WeavingMachineResponse, error) {
+	machine, err := s.machineRepo.FindMachineByID(id)
+	if err != nil {
+		return nil, ErrWeavingMachineNotFound
+	}
+	return convertWeavingMachineToResponse(machine), nil
+}
+
+func (s *weavingCapacityService) CreateMachine(req request.CreateWeavingMachineRequest) (*response.WeavingMachineResponse, error) {
+	if _, err := s.facilityRepo.FindByID(req.WeavingFacilityID); err != nil {
+		return nil, ErrWeavingFacilityNotFound
+	}
+
+	machine := &models.WeavingMachine{
+		WeavingFacilityID: req.WeavingFacilityID,
+		MachineCode:       strings.ToUpper(strings.TrimSpace(req.MachineCode)),
+		LoomType:          req.LoomType,
+		MaxWidth:          roundQuantity(req.MaxWidth),
+		SpeedRPM:          req.SpeedRPM,
+		OutputPerHour:     roundQuantity(req.OutputPerHour),
+		Status:            models.WeavingMachineActive,
+		Notes:             req.Notes,
+	}
+	if machine.LoomType == "" {
+		machine.LoomType = models.LoomTypeRapier
+	}
+	if machine.MachineCode == "" {
+		return nil, errors.New("machine_code is required")
+	}
+	if existing, _ := s.machineRepo.FindMachineByCode(machine.MachineCode); existing != nil {
+		return nil, errors.New("machine code already exists")
+	}
+
+	if err := s.machineRepo.CreateMachine(machine); err != nil {
+		return nil, err
+	}
+	return s.GetMachineByID(machine.ID)
+}
+
+func (s *weavingCapacityService) UpdateMachine(id uint, req request.UpdateWeavingMachineRequest) (*response.WeavingMachineResponse, error) {
+	machine, err := s.machineRepo.FindMachineByID(id)
+	if err != nil {
+		return nil, ErrWeavingMachineNotFound
+	}
+
+	if req.MachineCode != nil {
+		code := strings.ToUpper(strings.TrimSpace(*req.MachineCode))
+		if code == "" {
+			return nil, errors.New("machine_code is required")
+		}
+		if code != machine.MachineCode {
+			if existing, _ := s.machineRepo.FindMachineByCode(code); existing != nil {
+				return nil, errors.New("machine code already exists")
+			}
+			machine.MachineCode = code
+		}
+	}
+	if req.LoomType != nil {
+		machine.LoomType = *req.LoomType
+	}
+	if req.MaxWidth != nil {
+		machine.MaxWidth = roundQuantity(*req.MaxWidth)
+	}
+	if req.SpeedRPM != nil {
+		machine.SpeedRPM = *req.SpeedRPM
+	}
+	if req.OutputPerHour != nil {
+		machine.OutputPerHour = roundQuantity(*req.OutputPerHour)
+	}
+	if req.Status != nil {
+		machine.Status = *req.Status
+	}
+	if req.Notes != nil {
+		machine.Notes = *req.Notes
+	}
+
+	if err := s.machineRepo.UpdateMachine(machine); err != nil {
+		return nil, err
+	}
+	return s.GetMachineByID(machine.ID)
+}
+
+func (s *weavingCapacityService) DeleteMachine(id uint) error {
+	if _, err := s.machineRepo.FindMachineByID(id); err != nil {
+		return ErrWeavingMachineNotFound
+	}
+	return s.machineRepo.DeleteMachine(id)
+}
+
+func (s *weavingCapacityService) GetRosters(facilityID uint, req request.WeavingRosterFilterRequest) ([]response.WeavingRosterResponse, error) {
+	if _, err := s.facilityRepo.FindByID(facilityID); err != nil {
+		return nil, ErrWeavingFacilityNotFound
+	}
+
+	from := today()
+	if !req.From.IsZero() {
+		from = dateOnly(req.From)
+	}
+	to := from.AddDate(0, 0, 6)
+	if !req.To.IsZero() {
+		to = dateOnly(req.To)
+	}
+
+	return s.findRosters(interfaces.WeavingRosterFilter{
+		FacilityID: facilityID,
+		StaffID:    req.StaffID,
+		ShiftID:    req.ShiftID,
+		MachineID:  req.MachineID,
+		From:       &from,
+		To:         &to,
+	})
+}
+
+func (s *weavingCapacityService) SaveRosters(facilityID uint, req request.CreateWeavingRostersRequest, userID uint) ([]response.WeavingRosterResponse, error) {
+	if _, err := s.facilityRepo.FindByID(facilityID); err != nil {
+		return nil, ErrWeavingFacilityNotFound
+	}
+	shift, err := s.facilityRepo.FindShiftByID(req.WeavingShiftID)
+	if err != nil {
+		return nil, ErrWeavingShiftNotFound
+	}
+	if shift.WeavingFacilityID != facilityID {
+		return nil, fmt.Errorf("shift %s belongs to another facility", shift.ShiftName)
+	}
+
+	from := dateOnly(*req.From)
+	to := from
+	if req.To != nil {
+		to = dateOnly(*req.To)
+	}
+	if to.Before(from) {
+		return nil, errors.New("to is before from")
+	}
+	if to.After(from.AddDate(0, 0, maxRosterDays-1)) {
+		return nil, fmt.Errorf("rosters cover at most %d days at a time", maxRosterDays)
+	}
+
+	staffNames := make(map[uint]string)
+	machineCodes := make(map[uint]string)
+	for _, entry := range req.Staff {
+		staff, err := s.facilityRepo.FindStaffByID(entry.WeavingStaffID)
+		if err != nil {
+			return nil, ErrWeavingStaffNotFound
+		}
+		if staff.WeavingFacilityID != facilityID {
+			return nil, fmt.Errorf("%s works at another facility", staff.StaffName)
+		}
+		if _, ok := staffNames[staff.ID]; ok {
+			return nil, fmt.Errorf("%s is listed twice", staff.StaffName)
+		}
+		staffNames[staff.ID] = staff.StaffName
+
+		if entry.WeavingMachineID == nil {
+			continue
+		}
+		machine, err := s.machineRepo.FindMachineByID(*entry.WeavingMachineID)
+		if err != nil {
+			return nil, ErrWeavingMachineNotFound
+		}
+		if machine.WeavingFacilityID != facilityID {
+			return nil, fmt.Errorf("machine %s belongs to another facility", machine.MachineCode)
+		}
+		if machine.Status != models.WeavingMachineActive {
+			return nil, fmt.Errorf("machine %s is not active", machine.MachineCode)
+		}
+		if _, ok := machineCodes[machine.ID]; ok {
+			return nil, fmt.Errorf("machine %s is given to more than one staff member", machine.MachineCode)
+		}
+		machineCodes[machine.ID] = machine.MachineCode
+	}
+
+	// A machine runs with one staff member per shift
+	existing, err := s.machineRepo.FindRosters(interfaces.WeavingRosterFilter{ShiftID: shift.ID, From: &from, To: &to})
+	if err != nil {
+		return nil, err
+	}
+	for _, roster := range existing {
+		if roster.WeavingMachineID == nil {
+			continue
+		}
+		code, ok := machineCodes[*roster.WeavingMachineID]
+		if _, replaced := staffNames[roster.WeavingStaffID]; ok && !replaced {
+			return nil, fmt.Errorf("machine %s is already rostered to %s on %s", code, roster.WeavingStaff.StaffName, roster.WorkDate.Format("2006-01-02"))
+		}
+	}
+
+	var rosters []models.WeavingRoster
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for _, entry := range req.Staff {
+			rosters = append(rosters, models.WeavingRoster{
+				WeavingStaffID:   entry.WeavingStaffID,
+				WeavingShiftID:   shift.ID,
+				WeavingMachineID: entry.WeavingMachineID,
+				WorkDate:         day,
+				Notes:            req.Notes,
+				CreatedBy:        &userID,
+			})
+		}
+	}
+	if err := s.machineRepo.SaveRosters(rosters); err != nil {
+		return nil, err
+	}
+
+	return s.findRosters(interfaces.WeavingRosterFilter{ShiftID: shift.ID, From: &from, To: &to})
+}
+
+func (s *weavingCapacityService) DeleteRoster(id uint) error {
+	if _, err := s.machineRepo.FindRosterByID(id); err != nil {
+		return ErrWeavingRosterNotFound
+	}
+	return s.machineRepo.DeleteRoster(id)
+}
+
+func (s *weavingCapacityService) findRosters(filter interfaces.WeavingRosterFilter) ([]response.WeavingRosterResponse, error) {
+	rosters, err := s.machineRepo.FindRosters(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.WeavingRosterResponse, len(rosters))
+	for i := range rosters {
+		result[i] = *convertWeavingRosterToResponse(&rosters[i])
+	}
+	return result, nil
+}
+
+// planSlot is one shift on one day of the planning window
+type planSlot struct {
+	shift     *models.WeavingShift
+	date      time.Time
+	start     time.Time
+	hours     float64
+	dedicated map[uint]bool    // Machines with a rostered staff member
+	crew      int              // Staff who can run any machine
+	running   map[uint]bool    // Machines planned in the slot
+	used      map[uint]float64 // Hours planned per machine
+}
+
+// canRun tells whether the machine has someone to run it in the slot
+func (p *planSlot) canRun(machineID uint) bool {
+	return p.running[machineID] || p.dedicated[machineID] || p.crew > 0
+}
+
+// slotPlan is the part of an order a machine weaves in a slot
+type slotPlan struct {
+	slot  *planSlot
+	hours float64
+}
+
+// machinePlan is how a machine would weave an order: the slots it takes, the meters that do not fit
+// in the window and when it would start and finish
+type machinePlan struct {
+	machine *models.WeavingMachine
+	slots   []slotPlan
+	left    float64
+	start   time.Time
+	end     time.Time
+}
+
+func (s *weavingCapacityService) PlanCapacity(facilityID uint, req request.WeavingCapacityPlanRequest) (*response.WeavingCapacityPlanResponse, error) {
+	facility, err := s.facilityRepo.FindByID(facilityID)
+	if err != nil {
+		return nil, ErrWeavingFacilityNotFound
+	}
+
+	from := today()
+	if !req.From.IsZero() {
+		from = dateOnly(req.From)
+	}
+	days := req.Days
+	if days <= 0 {
+		days = defaultPlanDays
+	}
+	to := from.AddDate(0, 0, days)
+
+	shifts, err := s.facilityRepo.FindShifts(facilityID)
+	if err != nil {
+		return nil, err
+	}
+	machines, err := s.machineRepo.FindMachines(interfaces.WeavingMachineFilter{
+		FacilityID: facilityID,
+		Statuses:   []string{models.WeavingMachineActive},
+	})
+	if err != nil {
+		return nil, err
+	}
+	orders, err := s.weavingRepo.FindOpen(facilityID)
+	if err != nil {
+		return nil, err
+	}
+	slots, err := s.planSlots(facilityID, shifts, machines, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.WeavingCapacityPlanResponse{
+		FacilityID:   facility.ID,
+		FacilityName: facility.FacilityName,
+		From:         from,
+		To:           to,
+		Issues:       []string{},
+		Orders:       []response.WeavingOrderPlanResponse{},
+		Machines:     make([]response.WeavingMachineLoadResponse, len(machines)),
+	}
+	if len(shifts) == 0 {
+		result.Issues = append(result.Issues, "the facility has no shifts")
+	}
+	if len(machines) == 0 {
+		result.Issues = append(result.Issues, "the facility has no active machines")
+	}
+	unstaffed := 0
+	for _, slot := range slots {
+		if slot.crew == 0 && len(slot.dedicated) == 0 {
+			unstaffed++
+		}
+	}
+	if unstaffed > 0 {
+		result.Issues = append(result.Issues, fmt.Sprintf("%d shifts in the window have no staff", unstaffed))
+	}
+
+	// Earliest due date first; started orders before pending ones
+	sort.SliceStable(orders, func(a, b int) bool {
+		dueA, dueB := orders[a].LinkedOrder.DueDate, orders[b].LinkedOrder.DueDate
+		if (dueA == nil) != (dueB == nil) {
+			return dueA != nil
+		}
+		if dueA != nil && !dueA.Equal(*dueB) {
+			return dueA.Before(*dueB)
+		}
+		return orders[a].Status == models.WeavingOrderInProgress && orders[b].Status != models.WeavingOrderInProgress
+	})
+
+	for i := range orders {
+		order := &orders[i]
+		remaining := roundQuantity(order.PlannedQuantity - order.ProducedQuantity)
+		if remaining <= 0 {
+			continue
+		}
+
+		plan := response.WeavingOrderPlanResponse{
+			WeavingOrderID:    order.ID,
+			OrderCode:         order.OrderCode,
+			Status:            order.Status,
+			LinkedOrderCode:   order.LinkedOrder.OrderCode,
+			SKU:               order.Product.SKU,
+			DueDate:           order.LinkedOrder.DueDate,
+			RemainingQuantity: remaining,
+			Issues:            []string{},
+			Assignments:       []response.WeavingPlanAssignmentResponse{},
+		}
+
+		var best *machinePlan
+		for j := range machines {
+			machine := &machines[j]
+			if machine.OutputPerHour <= 0 {
+				continue
+			}
+			if width := order.Product.Width; width > 0 && machine.MaxWidth > 0 && machine.MaxWidth < width {
+				continue
+			}
+			candidate := planOnMachine(slots, machine, remaining)
+			if best == nil || candidate.left < best.left || (candidate.left == best.left && candidate.end.Before(best.end)) {
+				best = candidate
+			}
+		}
+
+		switch {
+		case best == nil:
+			plan.Issues = append(plan.Issues, fmt.Sprintf("no active machine can weave %s at %vcm", order.Product.SKU, order.Product.Width))
+		case len(best.slots) == 0:
+			plan.MachineID, plan.MachineCode = &best.machine.ID, best.machine.MachineCode
+			plan.Issues = append(plan.Issues, "no free machine time in the window")
+		default:
+			commitMachinePlan(best)
+			plan.MachineID, plan.MachineCode = &best.machine.ID, best.machine.MachineCode
+			plan.ScheduledQuantity = roundQuantity(remaining - best.left)
+			plan.ProjectedStart = &best.start
+			for _, step := range best.slots {
+				plan.Assignments = append(plan.Assignments, response.WeavingPlanAssignmentResponse{
+					Date:      step.slot.date,
+					ShiftID:   step.slot.shift.ID,
+					ShiftName: step.slot.shift.ShiftName,
+					Hours:     roundQuantity(step.hours),
+					Quantity:  roundQuantity(step.hours * best.machine.OutputPerHour),
+				})
+			}
+			if best.left > 0 {
+				plan.Issues = append(plan.Issues, fmt.Sprintf("%v m do not fit before %s", roundQuantity(best.left), to.Format("2006-01-02")))
+			} else {
+				plan.ProjectedCompletion = &best.end
+			}
+		}
+
+		if due := order.LinkedOrder.DueDate; due != nil {
+			deadline := dateOnly(*due).AddDate(0, 0, 1)
+			if plan.ProjectedCompletion == nil || plan.ProjectedCompletion.After(deadline) {
+				plan.Late = true
+				plan.Issues = append(plan.Issues, fmt.Sprintf("projected to finish after the due date %s", due.Format("2006-01-02")))
+			}
+		}
+		plan.Overloaded = len(plan.Issues) > 0
+		result.Overloaded = result.Overloaded || plan.Overloaded
+		result.Orders = append(result.Orders, plan)
+	}
+
+	var window float64
+	for _, slot := range slots {
+		window += slot.hours
+	}
+	for i := range machines {
+		var planned float64
+		for _, slot := range slots {
+			planned += slot.used[machines[i].ID]
+		}
+		result.Machines[i] = response.WeavingMachineLoadResponse{
+			MachineID:      machines[i].ID,
+			MachineCode:    machines[i].MachineCode,
+			LoomType:       machines[i].LoomType,
+			AvailableHours: roundQuantity(window),
+			PlannedHours:   roundQuantity(planned),
+			Utilization:    weavingProgress(planned, window),
+		}
+	}
+	return result, nil
+}
+
+// planSlots lays the facility's shifts over the window, in time order and skipping time already past.
+// Days with a roster take their crew from it; other days from the staff's usual shifts.
+func (s *weavingCapacityService) planSlots(facilityID uint, shifts []models.WeavingShift, machines []models.WeavingMachine, from, to time.Time) ([]*planSlot, error) {
+	last := to.AddDate(0, 0, -1)
+	rosters, err := s.machineRepo.FindRosters(interfaces.WeavingRosterFilter{FacilityID: facilityID, From: &from, To: &last})
+	if err != nil {
+		return nil, err
+	}
+	usualCrew, err := s.machineRepo.StaffPerShift(facilityID)
+	if err != nil {
+		return nil, err
+	}
+
+	planned := make(map[uint]bool, len(machines))
+	for _, machine := range machines {
+		planned[machine.ID] = true
+	}
+	rostered := make(map[string]bool)
+	byShift := make(map[string][]models.WeavingRoster)
+	for _, roster := range rosters {
+		day := roster.WorkDate.Format("2006-01-02")
+		rostered[day] = true
+		key := fmt.Sprintf("%s/%d", day, roster.WeavingShiftID)
+		byShift[key] = append(byShift[key], roster)
+	}
+
+	now := time.Now()
+	var slots []*planSlot
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		for i := range shifts {
+			shift := &shifts[i]
+			start, end, err := shiftWindow(day, shift)
+			if err != nil {
+				return nil, err
+			}
+			if !end.After(now) {
+				continue
+			}
+			if start.Before(now) {
+				start = now
+			}
+
+			slot := &planSlot{
+				shift:     shift,
+				date:      day,
+				start:     start,
+				hours:     end.Sub(start).Hours(),
+				dedicated: make(map[uint]bool),
+				running:   make(map[uint]bool),
+				used:      make(map[uint]float64),
+			}
+			if rostered[day.Format("2006-01-02")] {
+				for _, roster := range byShift[fmt.Sprintf("%s/%d", day.Format("2006-01-02"), shift.ID)] {
+					if roster.WeavingMachineID != nil && planned[*roster.WeavingMachineID] && !slot.dedicated[*roster.WeavingMachineID] {
+						slot.dedicated[*roster.WeavingMachineID] = true
+					} else {
+						slot.crew++
+					}
+				}
+			} else {
+				slot.crew = usualCrew[shift.ID]
+			}
+			slots = append(slots, slot)
+		}
+	}
+
+	sort.SliceStable(slots, func(a, b int) bool { return slots[a].start.Before(slots[b].start) })
+	return slots, nil
+}
+
+// planOnMachine fits the meters into the machine's earliest free hours without booking them
+func planOnMachine(slots []*planSlot, machine *models.WeavingMachine, meters float64) *machinePlan {
+	plan := &machinePlan{machine: machine}
+	for _, slot := range slots {
+		if meters < 0.01 {
+			break
+		}
+		used := slot.used[machine.ID]
+		free := slot.hours - used
+		if free <= 0 || !slot.canRun(machine.ID) {
+			continue
+		}
+
+		hours := math.Min(free, meters/machine.OutputPerHour)
+		if len(plan.slots) == 0 {
+			plan.start = slot.start.Add(time.Duration(used * float64(time.Hour)))
+		}
+		plan.end = slot.start.Add(time.Duration((used + hours) * float64(time.Hour)))
+		plan.slots = append(plan.slots, slotPlan{slot: slot, hours: hours})
+		meters -= hours * machine.OutputPerHour
+	}
+	if meters >= 0.01 {
+		plan.left = meters
+	}
+	return plan
+}
+
+// commitMachinePlan books the plan's hours, taking a free crew member in each slot the machine did not run in yet
+func commitMachinePlan(plan *machinePlan) {
+	id := plan.machine.ID
+	for _, step := range plan.slots {
+		step.slot.used[id] += step.hours
+		if !step.slot.running[id] {
+			step.slot.running[id] = true
+			if !step.slot.dedicated[id] {
+				step.slot.crew--
+			}
+		}
+	}
+}
+
+// shiftWindow is when the shift runs on day; a shift ending before it starts ends the next day
+func shiftWindow(day time.Time, shift *models.WeavingShift) (time.Time, time.Time, error) {
+	startClock, err := time.Parse("15:04:05", shift.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid shift time %q; use HH:MM", shift.StartTime)
+	}
+	endClock, err := time.Parse("15:04:05", shift.EndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid shift time %q; use HH:MM", shift.EndTime)
+	}
+
+	start := time.Date(day.Year(), day.Month(), day.Day(), startClock.Hour(), startClock.Minute(), startClock.Second(), 0, day.Location())
+	end := time.Date(day.Year(), day.Month(), day.Day(), endClock.Hour(), endClock.Minute(), endClock.Second(), 0, day.Location())
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end, nil
+}
+
+func convertWeavingMachineToResponse(machine *models.WeavingMachine) *response.WeavingMachineResponse {
+	return &response.WeavingMachineResponse{
+		ID:                machine.ID,
+		WeavingFacilityID: machine.WeavingFacilityID,
+		FacilityName:      machine.WeavingFacility.FacilityName,
+		MachineCode:       machine.MachineCode,
+		LoomType:          machine.LoomType,
+		MaxWidth:          machine.MaxWidth,
+		SpeedRPM:          machine.SpeedRPM,
+		OutputPerHour:     machine.OutputPerHour,
+		Status:            machine.Status,
+		Notes:             machine.Notes,
+		CreatedAt:         machine.CreatedAt,
+		UpdatedAt:         machine.UpdatedAt,
+	}
+}
+
+func convertWeavingRosterToResponse(roster *models.WeavingRoster) *response.WeavingRosterResponse {
+	result := &response.WeavingRosterResponse{
+		ID:               roster.ID,
+		WorkDate:         roster.WorkDate,
+		WeavingStaffID:   roster.WeavingStaffID,
+		StaffName:        roster.WeavingStaff.StaffName,
+		WeavingShiftID:   roster.WeavingShiftID,
+		ShiftName:        roster.WeavingShift.ShiftName,
+		StartTime:        roster.WeavingShift.StartTime,
+		EndTime:          roster.WeavingShift.EndTime,
+		WeavingMachineID: roster.WeavingMachineID,
+		Notes:            roster.Notes,
+	}
+	if roster.WeavingMachine != nil {
+		result.MachineCode = roster.WeavingMachine.MachineCode
+	}
+	return result
+}
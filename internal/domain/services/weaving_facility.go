@@ -0,0 +1,411 @@
+// File: internal/domain/services/weaving_facility.go
+// Tạo tại: internal/domain/services/weaving_facility.go
+// Mục đích: Service xưởng dệt (nội bộ/gia công), ca làm việc theo xưởng và công nhân dệt
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+var (
+	// ErrWeavingFacilityNotFound is returned when a weaving facility ID does not exist
+	ErrWeavingFacilityNotFound = errors.New("weaving facility not found")
+	// ErrWeavingShiftNotFound is returned when a shift ID does not exist
+	ErrWeavingShiftNotFound = errors.New("weaving shift not found")
+	// ErrWeavingStaffNotFound is returned when a weaving staff ID does not exist
+	ErrWeavingStaffNotFound = errors.New("weaving staff not found")
+)
+
+type WeavingFacilityService interface {
+	GetFacilities(req request.WeavingFacilityFilterRequest) ([]response.WeavingFacilityResponse, error)
+	// GetFacilityByID returns the facility with its shifts
+	GetFacilityByID(id uint) (*response.WeavingFacilityResponse, error)
+	CreateFacility(req request.CreateWeavingFacilityRequest) (*response.WeavingFacilityResponse, error)
+	UpdateFacility(id uint, req request.UpdateWeavingFacilityRequest) (*response.WeavingFacilityResponse, error)
+	// DeleteFacility removes a facility that has no pending or in-progress weaving orders
+	DeleteFacility(id uint) error
+
+	GetShifts(facilityID uint) ([]response.WeavingShiftResponse, error)
+	CreateShift(facilityID uint, req request.CreateWeavingShiftRequest) (*response.WeavingShiftResponse, error)
+	UpdateShift(id uint, req request.UpdateWeavingShiftRequest) (*response.WeavingShiftResponse, error)
+	DeleteShift(id uint) error
+
+	GetStaff(req request.WeavingStaffFilterRequest) (*response.PaginatedResponse, error)
+	GetStaffByID(id uint) (*response.WeavingStaffResponse, error)
+	CreateStaff(req request.CreateWeavingStaffRequest) (*response.WeavingStaffResponse, error)
+	UpdateStaff(id uint, req request.UpdateWeavingStaffRequest) (*response.WeavingStaffResponse, error)
+	DeleteStaff(id uint) error
+}
+
+type weavingFacilityService struct {
+	facilityRepo interfaces.WeavingFacilityRepository
+}
+
+func NewWeavingFacilityService(facilityRepo interfaces.WeavingFacilityRepository) WeavingFacilityService {
+	return &weavingFacilityService{
+		facilityRepo: facilityRepo,
+	}
+}
+
+func (s *weavingFacilityService) GetFacilities(req request.WeavingFacilityFilterRequest) ([]response.WeavingFacilityResponse, error) {
+	facilities, err := s.facilityRepo.FindAll(strings.TrimSpace(req.Search), req.FacilityType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.WeavingFacilityResponse, len(facilities))
+	for i := range facilities {
+		result[i] = *convertWeavingFacilityToResponse(&facilities[i])
+	}
+	return result, nil
+}
+
+func (s *weavingFacilityService) GetFacilityByID(id uint) (*response.WeavingFacilityResponse, error) {
+	facility, err := s.facilityRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrWeavingFacilityNotFound
+	}
+	shifts, err := s.GetShifts(id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := convertWeavingFacilityToResponse(facility)
+	result.Shifts = shifts
+	return result, nil
+}
+
+func (s *weavingFacilityService) CreateFacility(req request.CreateWeavingFacilityRequest) (*response.WeavingFacilityResponse, error) {
+	facility := &models.WeavingFacility{
+		FacilityName: strings.TrimSpace(req.FacilityName),
+		Location:     strings.TrimSpace(req.Location),
+		ContactInfo:  req.ContactInfo,
+		FacilityType: req.FacilityType,
+	}
+	if facility.FacilityType == "" {
+		facility.FacilityType = models.WeavingFacilityInternal
+	}
+	if facility.FacilityName == "" {
+		return nil, errors.New("facility_name is required")
+	}
+
+	if err := s.facilityRepo.Create(facility); err != nil {
+		return nil, err
+	}
+	return convertWeavingFacilityToResponse(facility), nil
+}
+
+func (s *weavingFacilityService) UpdateFacility(id uint, req request.UpdateWeavingFacilityRequest) (*response.WeavingFacilityResponse, error) {
+	facility, err := s.facilityRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrWeavingFacilityNotFound
+	}
+
+	if req.FacilityName != nil {
+		facility.FacilityName = strings.TrimSpace(*req.FacilityName)
+		if facility.FacilityName == "" {
+			return nil, errors.New("facility_name is required")
+		}
+	}
+	if req.Location != nil {
+		facility.Location = strings.TrimSpace(*req.Location)
+	}
+	if req.ContactInfo != nil {
+		facility.ContactInfo = *req.ContactInfo
+	}
+	if req.FacilityType != nil {
+		facility.FacilityType = *req.FacilityType
+	}
+
+	if err := s.facilityRepo.Update(facility); err != nil {
+		return nil, err
+	}
+	return s.GetFacilityByID(facility.ID)
+}
+
+func (s *weavingFacilityService) DeleteFacility(id uint) error {
+	if _, err := s.facilityRepo.FindByID(id); err != nil {
+		return ErrWeavingFacilityNotFound
+	}
+
+	open, err := s.facilityRepo.CountOpenOrders(id)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return fmt.Errorf("facility has %d open weaving orders; complete or cancel them first", open)
+	}
+
+	return s.facilityRepo.Delete(id)
+}
+
+func (s *weavingFacilityService) GetShifts(facilityID uint) ([]response.WeavingShiftResponse, error) {
+	if _, err := s.facilityRepo.FindByID(facilityID); err != nil {
+		return nil, ErrWeavingFacilityNotFound
+	}
+	shifts, err := s.facilityRepo.FindShifts(facilityID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.WeavingShiftResponse, len(shifts))
+	for i := range shifts {
+		result[i] = *convertWeavingShiftToResponse(&shifts[i])
+	}
+	return result, nil
+}
+
+func (s *weavingFacilityService) CreateShift(facilityID uint, req request.CreateWeavingShiftRequest) (*response.WeavingShiftResponse, error) {
+	if _, err := s.facilityRepo.FindByID(facilityID); err != nil {
+		return nil, ErrWeavingFacilityNotFound
+	}
+
+	shift := &models.WeavingShift{
+		WeavingFacilityID: facilityID,
+		ShiftName:         strings.TrimSpace(req.ShiftName),
+		StartTime:         req.StartTime,
+		EndTime:           req.EndTime,
+		ShiftLeader:       strings.TrimSpace(req.ShiftLeader),
+	}
+	if err := validateWeavingShift(shift); err != nil {
+		return nil, err
+	}
+
+	if err := s.facilityRepo.CreateShift(shift); err != nil {
+		return nil, err
+	}
+	return convertWeavingShiftToResponse(shift), nil
+}
+
+func (s *weavingFacilityService) UpdateShift(id uint, req request.UpdateWeavingShiftRequest) (*response.WeavingShiftResponse, error) {
+	shift, err := s.facilityRepo.FindShiftByID(id)
+	if err != nil {
+		return nil, ErrWeavingShiftNotFound
+	}
+
+	if req.ShiftName != nil {
+		shift.ShiftName = strings.TrimSpace(*req.ShiftName)
+	}
+	if req.StartTime != nil {
+		shift.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		shift.EndTime = *req.EndTime
+	}
+	if req.ShiftLeader != nil {
+		shift.ShiftLeader = strings.TrimSpace(*req.ShiftLeader)
+	}
+	if err := validateWeavingShift(shift); err != nil {
+		return nil, err
+	}
+
+	if err := s.facilityRepo.UpdateShift(shift); err != nil {
+		return nil, err
+	}
+	return convertWeavingShiftToResponse(shift), nil
+}
+
+func (s *weavingFacilityService) DeleteShift(id uint) error {
+	if _, err := s.facilityRepo.FindShiftByID(id); err != nil {
+		return ErrWeavingShiftNotFound
+	}
+	return s.facilityRepo.DeleteShift(id)
+}
+
+func (s *weavingFacilityService) GetStaff(req request.WeavingStaffFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	staff, total, err := s.facilityRepo.FindStaff(interfaces.WeavingStaffFilter{
+		FacilityID: req.FacilityID,
+		ShiftID:    req.ShiftID,
+		Search:     strings.TrimSpace(req.Search),
+	}, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(staff))
+	for i := range staff {
+		items[i] = convertWeavingStaffToResponse(&staff[i])
+	}
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.Limit))),
+	}, nil
+}
+
+func (s *weavingFacilityService) GetStaffByID(id uint) (*response.WeavingStaffResponse, error) {
+	staff, err := s.facilityRepo.FindStaffByID(id)
+	if err != nil {
+		return nil, ErrWeavingStaffNotFound
+	}
+	return convertWeavingStaffToResponse(staff), nil
+}
+
+func (s *weavingFacilityService) CreateStaff(req request.CreateWeavingStaffRequest) (*response.WeavingStaffResponse, error) {
+	staff := &models.WeavingStaff{
+		WeavingFacilityID: req.WeavingFacilityID,
+		StaffName:         strings.TrimSpace(req.StaffName),
+		Position:          strings.TrimSpace(req.Position),
+		ContactInfo:       req.ContactInfo,
+		ShiftID:           req.ShiftID,
+	}
+	if err := s.validateStaff(staff); err != nil {
+		return nil, err
+	}
+
+	if err := s.facilityRepo.CreateStaff(staff); err != nil {
+		return nil, err
+	}
+	return s.GetStaffByID(staff.ID)
+}
+
+func (s *weavingFacilityService) UpdateStaff(id uint, req request.UpdateWeavingStaffRequest) (*response.WeavingStaffResponse, error) {
+	staff, err := s.facilityRepo.FindStaffByID(id)
+	if err != nil {
+		return nil, ErrWeavingStaffNotFound
+	}
+
+	if req.WeavingFacilityID != nil {
+		staff.WeavingFacilityID = *req.WeavingFacilityID
+	}
+	if req.StaffName != nil {
+		staff.StaffName = strings.TrimSpace(*req.StaffName)
+	}
+	if req.Position != nil {
+		staff.Position = strings.TrimSpace(*req.Position)
+	}
+	if req.ContactInfo != nil {
+		staff.ContactInfo = *req.ContactInfo
+	}
+	if req.ShiftID != nil {
+		staff.ShiftID = req.ShiftID
+		if *req.ShiftID == 0 {
+			staff.ShiftID = nil
+		}
+	}
+	if err := s.validateStaff(staff); err != nil {
+		return nil, err
+	}
+
+	if err := s.facilityRepo.UpdateStaff(staff); err != nil {
+		return nil, err
+	}
+	return s.GetStaffByID(staff.ID)
+}
+
+func (s *weavingFacilityService) DeleteStaff(id uint) error {
+	if _, err := s.facilityRepo.FindStaffByID(id); err != nil {
+		return ErrWeavingStaffNotFound
+	}
+	return s.facilityRepo.DeleteStaff(id)
+}
+
+// validateStaff checks the staff's facility exists and their shift belongs to it
+func (s *weavingFacilityService) validateStaff(staff *models.WeavingStaff) error {
+	if staff.StaffName == "" {
+		return errors.New("staff_name is required")
+	}
+	if _, err := s.facilityRepo.FindByID(staff.WeavingFacilityID); err != nil {
+		return ErrWeavingFacilityNotFound
+	}
+	if staff.ShiftID != nil {
+		shift, err := s.facilityRepo.FindShiftByID(*staff.ShiftID)
+		if err != nil {
+			return ErrWeavingShiftNotFound
+		}
+		if shift.WeavingFacilityID != staff.WeavingFacilityID {
+			return fmt.Errorf("shift %s belongs to another facility", shift.ShiftName)
+		}
+	}
+	return nil
+}
+
+// validateWeavingShift normalizes the shift times to HH:MM:SS
+func validateWeavingShift(shift *models.WeavingShift) error {
+	if shift.ShiftName == "" {
+		return errors.New("shift_name is required")
+	}
+	var err error
+	if shift.StartTime, err = parseShiftTime(shift.StartTime); err != nil {
+		return err
+	}
+	if shift.EndTime, err = parseShiftTime(shift.EndTime); err != nil {
+		return err
+	}
+	if shift.StartTime == shift.EndTime {
+		return errors.New("a shift cannot start and end at the same time")
+	}
+	return nil
+}
+
+func parseShiftTime(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("15:04:05"), nil
+		}
+	}
+	return "", fmt.Errorf("invalid shift time %q; use HH:MM", value)
+}
+
+func convertWeavingFacilityToResponse(facility *models.WeavingFacility) *response.WeavingFacilityResponse {
+	return &response.WeavingFacilityResponse{
+		ID:           facility.ID,
+		FacilityName: facility.FacilityName,
+		Location:     facility.Location,
+		ContactInfo:  facility.ContactInfo,
+		FacilityType: facility.FacilityType,
+		CreatedAt:    facility.CreatedAt,
+		UpdatedAt:    facility.UpdatedAt,
+	}
+}
+
+func convertWeavingShiftToResponse(shift *models.WeavingShift) *response.WeavingShiftResponse {
+	return &response.WeavingShiftResponse{
+		ID:                shift.ID,
+		WeavingFacilityID: shift.WeavingFacilityID,
+		ShiftName:         shift.ShiftName,
+		StartTime:         shift.StartTime,
+		EndTime:           shift.EndTime,
+		ShiftLeader:       shift.ShiftLeader,
+	}
+}
+
+func convertWeavingStaffToResponse(staff *models.WeavingStaff) *response.WeavingStaffResponse {
+	result := &response.WeavingStaffResponse{
+		ID:                staff.ID,
+		WeavingFacilityID: staff.WeavingFacilityID,
+		FacilityName:      staff.WeavingFacility.FacilityName,
+		StaffName:         staff.StaffName,
+		Position:          staff.Position,
+		ContactInfo:       staff.ContactInfo,
+		ShiftID:           staff.ShiftID,
+		CreatedAt:         staff.CreatedAt,
+		UpdatedAt:         staff.UpdatedAt,
+	}
+	if staff.Shift != nil {
+		result.ShiftName = staff.Shift.ShiftName
+	}
+	return result
+}
@@ -0,0 +1,450 @@
+// File: internal/domain/services/weaving_quality.go
+// Tạo tại: internal/domain/services/weaving_quality.go
+// Mục đích: Service danh mục lỗi vải và kiểm tra chất lượng công đoạn dệt theo hệ thống 4 điểm; lệnh dệt có phiếu
+// không đạt chưa khắc phục không được chuyển sang nhuộm
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultMaxQCPoints is the usual acceptance limit of the 4-point system
+	defaultMaxQCPoints = 40
+	// maxDefectPointsPerYard caps the points counted in one linear yard
+	maxDefectPointsPerYard = 4
+	metersPerYard          = 0.9144
+	centimetersPerInch     = 2.54
+)
+
+var (
+	// ErrDefectTypeNotFound is returned when a defect type ID does not exist
+	ErrDefectTypeNotFound = errors.New("defect type not found")
+	// ErrWeavingInspectionNotFound is returned when a quality inspection ID does not exist
+	ErrWeavingInspectionNotFound = errors.New("weaving inspection not found")
+	// ErrWeavingInspectionUncorrected is returned when deleting a failed inspection without corrective action
+	ErrWeavingInspectionUncorrected = errors.New("record corrective action before deleting a failed inspection")
+)
+
+type WeavingQualityService interface {
+	GetDefectTypes(req request.DefectTypeFilterRequest) ([]response.DefectTypeResponse, error)
+	GetDefectTypeByID(id uint) (*response.DefectTypeResponse, error)
+	CreateDefectType(req request.CreateDefectTypeRequest) (*response.DefectTypeResponse, error)
+	UpdateDefectType(id uint, req request.UpdateDefectTypeRequest) (*response.DefectTypeResponse, error)
+	DeleteDefectType(id uint) error
+
+	GetInspections(req request.WeavingInspectionFilterRequest) (*response.PaginatedResponse, error)
+	GetInspectionByID(id uint) (*response.WeavingInspectionResponse, error)
+	// Inspect scores the fabric of a weaving operation with the 4-point system. The operation passes when
+	// its points per 100 square yards stay within the limit.
+	Inspect(operationID uint, req request.CreateWeavingInspectionRequest, userID uint) (*response.WeavingInspectionResponse, error)
+	// RecordCorrectiveAction closes a failed inspection so the weaving order can go on to dyeing
+	RecordCorrectiveAction(id uint, req request.RecordCorrectiveActionRequest, userID uint) (*response.WeavingInspectionResponse, error)
+	DeleteInspection(id uint) error
+
+	// RegisterWeavingGuards holds a weaving order back from dyeing while a failed inspection has no corrective action
+	RegisterWeavingGuards(weaving WeavingService)
+}
+
+type weavingQualityService struct {
+	qualityRepo interfaces.WeavingQualityRepository
+	weavingRepo interfaces.WeavingOrderRepository
+}
+
+func NewWeavingQualityService(
+	qualityRepo interfaces.WeavingQualityRepository,
+	weavingRepo interfaces.WeavingOrderRepository,
+) WeavingQualityService {
+	return &weavingQualityService{
+		qualityRepo: qualityRepo,
+		weavingRepo: weavingRepo,
+	}
+}
+
+func (s *weavingQualityService) GetDefectTypes(req request.DefectTypeFilterRequest) ([]response.DefectTypeResponse, error) {
+	defectTypes, err := s.qualityRepo.FindDefectTypes(interfaces.DefectTypeFilter{
+		Search:     strings.TrimSpace(req.Search),
+		Category:   req.Category,
+		ActiveOnly: req.ActiveOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.DefectTypeResponse, len(defectTypes))
+	for i := range defectTypes {
+		result[i] = *convertDefectTypeToResponse(&defectTypes[i])
+	}
+	return result, nil
+}
+
+func (s *weavingQualityService) GetDefectTypeByID(id uint) (*response.DefectTypeResponse, error) {
+	defectType, err := s.qualityRepo.FindDefectTypeByID(id)
+	if err != nil {
+		return nil, ErrDefectTypeNotFound
+	}
+	return convertDefectTypeToResponse(defectType), nil
+}
+
+func (s *weavingQualityService) CreateDefectType(req request.CreateDefectTypeRequest) (*response.DefectTypeResponse, error) {
+	defectType := &models.DefectType{
+		Code:        strings.ToUpper(strings.TrimSpace(req.Code)),
+		Name:        strings.TrimSpace(req.Name),
+		Category:    req.Category,
+		Description: req.Description,
+		FixedPoints: req.FixedPoints,
+		IsActive:    true,
+	}
+	if defectType.Category == "" {
+		defectType.Category = models.DefectCategoryOther
+	}
+	if defectType.Code == "" {
+		return nil, errors.New("code is required")
+	}
+	if defectType.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if existing, _ := s.qualityRepo.FindDefectTypeByCode(defectType.Code); existing != nil {
+		return nil, errors.New("defect type code already exists")
+	}
+
+	if err := s.qualityRepo.CreateDefectType(defectType); err != nil {
+		return nil, err
+	}
+	return convertDefectTypeToResponse(defectType), nil
+}
+
+func (s *weavingQualityService) UpdateDefectType(id uint, req request.UpdateDefectTypeRequest) (*response.DefectTypeResponse, error) {
+	defectType, err := s.qualityRepo.FindDefectTypeByID(id)
+	if err != nil {
+		return nil, ErrDefectTypeNotFound
+	}
+
+	if req.Name != nil {
+		defectType.Name = strings.TrimSpace(*req.Name)
+		if defectType.Name == "" {
+			return nil, errors.New("name is required")
+		}
+	}
+	if req.Category != nil {
+		defectType.Category = *req.Category
+	}
+	if req.Description != nil {
+		defectType.Description = *req.Description
+	}
+	if req.FixedPoints != nil {
+		defectType.FixedPoints = req.FixedPoints
+		if *req.FixedPoints == 0 {
+			defectType.FixedPoints = nil
+		}
+	}
+	if req.IsActive != nil {
+		defectType.IsActive = *req.IsActive
+	}
+
+	if err := s.qualityRepo.UpdateDefectType(defectType); err != nil {
+		return nil, err
+	}
+	return convertDefectTypeToResponse(defectType), nil
+}
+
+func (s *weavingQualityService) DeleteDefectType(id uint) error {
+	if _, err := s.qualityRepo.FindDefectTypeByID(id); err != nil {
+		return ErrDefectTypeNotFound
+	}
+	return s.qualityRepo.DeleteDefectType(id)
+}
+
+func (s *weavingQualityService) GetInspections(req request.WeavingInspectionFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	filter := interfaces.WeavingInspectionFilter{
+		WeavingOrderID: req.WeavingOrderID,
+		OperationID:    req.OperationID,
+		Status:         req.Status,
+		Uncorrected:    req.Uncorrected,
+	}
+	if !req.From.IsZero() {
+		from := dateOnly(req.From)
+		filter.From = &from
+	}
+	if !req.To.IsZero() {
+		to := dateOnly(req.To).AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	inspections, total, err := s.qualityRepo.FindInspections(filter, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(inspections))
+	for i := range inspections {
+		items[i] = convertWeavingInspectionToResponse(&inspections[i])
+	}
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.Limit))),
+	}, nil
+}
+
+func (s *weavingQualityService) GetInspectionByID(id uint) (*response.WeavingInspectionResponse, error) {
+	qc, err := s.qualityRepo.FindInspectionByID(id)
+	if err != nil {
+		return nil, ErrWeavingInspectionNotFound
+	}
+	return convertWeavingInspectionToResponse(qc), nil
+}
+
+func (s *weavingQualityService) Inspect(operationID uint, req request.CreateWeavingInspectionRequest, userID uint) (*response.WeavingInspectionResponse, error) {
+	operation, err := s.weavingRepo.FindOperationByID(operationID)
+	if err != nil {
+		return nil, ErrWeavingOperationNotFound
+	}
+	order, err := s.weavingRepo.FindByID(operation.WeavingOrderID)
+	if err != nil {
+		return nil, ErrWeavingOrderNotFound
+	}
+	inspectable := []string{models.WeavingOrderInProgress, models.WeavingOrderCompleted}
+	if !slices.Contains(inspectable, order.Status) {
+		return nil, fmt.Errorf("cannot inspect a %s weaving order", order.Status)
+	}
+
+	qc := &models.WeavingQualityControl{
+		WeavingOrderID:     order.ID,
+		WeavingOperationID: &operation.ID,
+		QualityInspector:   strings.TrimSpace(req.QualityInspector),
+		InspectedBy:        &userID,
+		QualityCheckTime:   time.Now(),
+		InspectedLength:    roundQuantity(req.InspectedLength),
+		FabricWidth:        roundQuantity(req.FabricWidth),
+		MaxPoints:          req.MaxPoints,
+		Notes:              req.Notes,
+	}
+	if req.QualityCheckTime != nil {
+		qc.QualityCheckTime = *req.QualityCheckTime
+	}
+	if qc.QualityInspector == "" {
+		return nil, errors.New("quality_inspector is required")
+	}
+	if qc.InspectedLength == 0 {
+		qc.InspectedLength = operation.Quantity
+	}
+	if qc.InspectedLength <= 0 {
+		return nil, errors.New("inspected_length is required when the operation has no quantity")
+	}
+	if qc.FabricWidth == 0 {
+		qc.FabricWidth = order.Product.Width
+	}
+	if qc.FabricWidth <= 0 {
+		return nil, fmt.Errorf("fabric_width is required; product %s has no width", order.Product.SKU)
+	}
+	if qc.MaxPoints == 0 {
+		qc.MaxPoints = defaultMaxQCPoints
+	}
+
+	var names []string
+	for _, item := range req.Defects {
+		defectType, err := s.qualityRepo.FindDefectTypeByID(item.DefectTypeID)
+		if err != nil {
+			return nil, ErrDefectTypeNotFound
+		}
+		if !defectType.IsActive {
+			return nil, fmt.Errorf("defect type %s is inactive", defectType.Code)
+		}
+		if item.Position != nil && *item.Position > qc.InspectedLength {
+			return nil, fmt.Errorf("defect at %vm is beyond the inspected length", *item.Position)
+		}
+
+		defect := models.WeavingQCDefect{
+			DefectTypeID: defectType.ID,
+			Position:     item.Position,
+			Length:       roundQuantity(item.Length),
+			Points:       item.Points,
+			Notes:        item.Notes,
+			DefectType:   *defectType,
+		}
+		if defect.Points == 0 && defectType.FixedPoints != nil {
+			defect.Points = *defectType.FixedPoints
+		}
+		if defect.Points == 0 {
+			defect.Points = defectPoints(defect.Length)
+		}
+		qc.Defects = append(qc.Defects, defect)
+
+		if !slices.Contains(names, defectType.Name) {
+			names = append(names, defectType.Name)
+		}
+	}
+
+	qc.TotalPoints, qc.PointsPer100SqYd = scoreInspection(qc.Defects, qc.InspectedLength, qc.FabricWidth)
+	qc.DefectFound = len(qc.Defects) > 0
+	qc.DefectType = truncate(strings.Join(names, ", "), 255)
+	qc.Status = models.WeavingQCPassed
+	if qc.PointsPer100SqYd > qc.MaxPoints {
+		qc.Status = models.WeavingQCFailed
+	}
+
+	if err := s.qualityRepo.CreateInspection(qc, inspectable); err != nil {
+		return nil, err
+	}
+	return s.GetInspectionByID(qc.ID)
+}
+
+func (s *weavingQualityService) RecordCorrectiveAction(id uint, req request.RecordCorrectiveActionRequest, userID uint) (*response.WeavingInspectionResponse, error) {
+	qc, err := s.qualityRepo.FindInspectionByID(id)
+	if err != nil {
+		return nil, ErrWeavingInspectionNotFound
+	}
+	if qc.Status != models.WeavingQCFailed {
+		return nil, errors.New("only failed inspections need corrective action")
+	}
+
+	now := time.Now()
+	qc.CorrectiveAction = strings.TrimSpace(req.CorrectiveAction)
+	if qc.CorrectiveAction == "" {
+		return nil, errors.New("corrective_action is required")
+	}
+	qc.CorrectedAt = &now
+	qc.CorrectedBy = &userID
+
+	if err := s.qualityRepo.RecordCorrectiveAction(qc); err != nil {
+		return nil, err
+	}
+	return s.GetInspectionByID(qc.ID)
+}
+
+func (s *weavingQualityService) DeleteInspection(id uint) error {
+	qc, err := s.qualityRepo.FindInspectionByID(id)
+	if err != nil {
+		return ErrWeavingInspectionNotFound
+	}
+	// The failure would vanish from the hold on sending the fabric to dyeing
+	if qc.Status == models.WeavingQCFailed && qc.CorrectedAt == nil {
+		return ErrWeavingInspectionUncorrected
+	}
+	return s.qualityRepo.DeleteInspection(qc)
+}
+
+func (s *weavingQualityService) RegisterWeavingGuards(weaving WeavingService) {
+	weaving.AddStatusGuard(models.WeavingOrderSentToDyeing, func(order *models.WeavingOrder, tx *gorm.DB) error {
+		count, err := s.qualityRepo.WithTx(tx).CountUncorrectedFailures(order.ID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%d failed inspections need corrective action first", count)
+		}
+		return nil
+	})
+}
+
+// defectPoints scores a defect by its length in inches: up to 3 is 1 point, up to 6 is 2, up to 9 is 3
+// and anything longer is 4
+func defectPoints(length float64) int {
+	switch {
+	case length <= 3:
+		return 1
+	case length <= 6:
+		return 2
+	case length <= 9:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// scoreInspection totals the defect points, counting at most 4 per linear yard for defects with a
+// position, and scales them to 100 square yards of fabric of the given length (meters) and width (cm)
+func scoreInspection(defects []models.WeavingQCDefect, length, width float64) (int, float64) {
+	total := 0
+	perYard := make(map[int]int)
+	for _, defect := range defects {
+		points := defect.Points
+		if defect.Position != nil {
+			yard := int(*defect.Position / metersPerYard)
+			points = min(points, maxDefectPointsPerYard-perYard[yard])
+			perYard[yard] += points
+		}
+		total += points
+	}
+
+	yards := length / metersPerYard
+	inches := width / centimetersPerInch
+	return total, roundQuantity(float64(total) * 3600 / (yards * inches))
+}
+
+func convertDefectTypeToResponse(defectType *models.DefectType) *response.DefectTypeResponse {
+	return &response.DefectTypeResponse{
+		ID:          defectType.ID,
+		Code:        defectType.Code,
+		Name:        defectType.Name,
+		Category:    defectType.Category,
+		Description: defectType.Description,
+		FixedPoints: defectType.FixedPoints,
+		IsActive:    defectType.IsActive,
+		CreatedAt:   defectType.CreatedAt,
+		UpdatedAt:   defectType.UpdatedAt,
+	}
+}
+
+func convertWeavingInspectionToResponse(qc *models.WeavingQualityControl) *response.WeavingInspectionResponse {
+	result := &response.WeavingInspectionResponse{
+		ID:                 qc.ID,
+		WeavingOrderID:     qc.WeavingOrderID,
+		WeavingOrderCode:   qc.WeavingOrder.OrderCode,
+		WeavingOperationID: qc.WeavingOperationID,
+		QualityInspector:   qc.QualityInspector,
+		InspectedBy:        qc.InspectedBy,
+		QualityCheckTime:   qc.QualityCheckTime,
+		InspectedLength:    qc.InspectedLength,
+		FabricWidth:        qc.FabricWidth,
+		TotalPoints:        qc.TotalPoints,
+		PointsPer100SqYd:   qc.PointsPer100SqYd,
+		MaxPoints:          qc.MaxPoints,
+		Status:             qc.Status,
+		DefectFound:        qc.DefectFound,
+		DefectType:         qc.DefectType,
+		Defects:            make([]response.WeavingInspectionDefectResponse, len(qc.Defects)),
+		CorrectiveAction:   qc.CorrectiveAction,
+		CorrectedAt:        qc.CorrectedAt,
+		CorrectedBy:        qc.CorrectedBy,
+		Notes:              qc.Notes,
+		CreatedAt:          qc.CreatedAt,
+	}
+	for i, defect := range qc.Defects {
+		result.Defects[i] = response.WeavingInspectionDefectResponse{
+			ID:           defect.ID,
+			DefectTypeID: defect.DefectTypeID,
+			DefectCode:   defect.DefectType.Code,
+			DefectName:   defect.DefectType.Name,
+			Position:     defect.Position,
+			Length:       defect.Length,
+			Points:       defect.Points,
+			Notes:        defect.Notes,
+		}
+	}
+	return result
+}
@@ -0,0 +1,100 @@
+// File: internal/domain/services/weaving_quality_test.go
+// Tạo tại: internal/domain/services/weaving_quality_test.go
+// Mục đích: Kiểm thử chấm điểm lỗi theo hệ 4 điểm, giới hạn 4 điểm mỗi yard và quy đổi ra 100 yard vuông
+
+package services
+
+import (
+	"testing"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+)
+
+func TestDefectPoints(t *testing.T) {
+	tests := []struct {
+		length float64
+		want   int
+	}{
+		{0, 1},
+		{3, 1},
+		{3.1, 2},
+		{6, 2},
+		{6.5, 3},
+		{9, 3},
+		{9.01, 4},
+		{36, 4},
+	}
+	for _, tt := range tests {
+		if got := defectPoints(tt.length); got != tt.want {
+			t.Errorf("defectPoints(%v) = %d, want %d", tt.length, got, tt.want)
+		}
+	}
+}
+
+func qcDefect(points int, position ...float64) models.WeavingQCDefect {
+	defect := models.WeavingQCDefect{Points: points}
+	if len(position) > 0 {
+		defect.Position = &position[0]
+	}
+	return defect
+}
+
+func TestScoreInspection(t *testing.T) {
+	tests := []struct {
+		name          string
+		defects       []models.WeavingQCDefect
+		length, width float64 // meters, centimeters
+		total         int
+		per100SqYd    float64
+	}{
+		{
+			name:   "no defects",
+			length: 91.44, width: 152.4,
+		},
+		{
+			// 100 yards of 36 inch fabric is exactly 100 square yards
+			name:    "100 square yards",
+			defects: []models.WeavingQCDefect{qcDefect(4), qcDefect(2), qcDefect(1)},
+			length:  91.44, width: 91.44,
+			total: 7, per100SqYd: 7,
+		},
+		{
+			// 100 yards of 60 inch fabric is 166.67 square yards
+			name:    "wider fabric scales down",
+			defects: []models.WeavingQCDefect{qcDefect(4), qcDefect(4), qcDefect(2)},
+			length:  91.44, width: 152.4,
+			total: 10, per100SqYd: 6,
+		},
+		{
+			// 50 yards of 36 inch fabric is 50 square yards
+			name:    "shorter roll scales up",
+			defects: []models.WeavingQCDefect{qcDefect(3)},
+			length:  45.72, width: 91.44,
+			total: 3, per100SqYd: 6,
+		},
+		{
+			name:    "at most 4 points in one yard",
+			defects: []models.WeavingQCDefect{qcDefect(4, 0.1), qcDefect(3, 0.5), qcDefect(3, 1.0), qcDefect(2, 1.2)},
+			length:  91.44, width: 91.44,
+			total: 8, per100SqYd: 8,
+		},
+		{
+			name:    "yards are counted from the meter mark",
+			defects: []models.WeavingQCDefect{qcDefect(3, 0.9), qcDefect(3, 0.92)},
+			length:  91.44, width: 91.44,
+			total: 6, per100SqYd: 6,
+		},
+		{
+			name:    "defects without a position are not capped",
+			defects: []models.WeavingQCDefect{qcDefect(4, 2), qcDefect(4), qcDefect(4)},
+			length:  91.44, width: 91.44,
+			total: 12, per100SqYd: 12,
+		},
+	}
+	for _, tt := range tests {
+		total, per100SqYd := scoreInspection(tt.defects, tt.length, tt.width)
+		if total != tt.total || per100SqYd != tt.per100SqYd {
+			t.Errorf("%s: scoreInspection = %d, %v, want %d, %v", tt.name, total, per100SqYd, tt.total, tt.per100SqYd)
+		}
+	}
+}
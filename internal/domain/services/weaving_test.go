@@ -0,0 +1,29 @@
+// File: internal/domain/services/weaving_test.go
+// Tạo tại: internal/domain/services/weaving_test.go
+// Mục đích: Kiểm thử tỷ lệ hoàn thành của lệnh dệt
+
+package services
+
+import "testing"
+
+func TestWeavingProgress(t *testing.T) {
+	tests := []struct {
+		done, target float64
+		want         float64
+	}{
+		{0, 0, 0},
+		{50, 0, 0},
+		{50, -10, 0},
+		{0, 1200, 0},
+		{300, 1200, 25},
+		{1, 3, 33.33},
+		{2, 3, 66.67},
+		{1200, 1200, 100},
+		{1300, 1200, 108.33},
+	}
+	for _, tt := range tests {
+		if got := weavingProgress(tt.done, tt.target); got != tt.want {
+			t.Errorf("weavingProgress(%v, %v) = %v, want %v", tt.done, tt.target, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,389 @@
+// File: internal/domain/services/yarn.go
+// Tạo tại: internal/domain/services/yarn.go
+// Mục đích: Service thùng sợi: đăng ký thùng, nhập/xuất cuộn và khối lượng với số dư còn lại, vị trí kho và trạng thái
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+const (
+	yarnBoxCodePrefix = "YB"
+	yarnBoxCodeDigits = 6
+)
+
+var (
+	// ErrYarnBoxNotFound is returned when a yarn box ID does not exist
+	ErrYarnBoxNotFound = errors.New("yarn box not found")
+	// ErrInsufficientYarn is returned when an issue takes more than the box holds
+	ErrInsufficientYarn = interfaces.ErrInsufficientYarn
+	// ErrYarnBoxStatusChanged is returned when someone else changed the box status meanwhile
+	ErrYarnBoxStatusChanged = interfaces.ErrYarnBoxStatusChanged
+)
+
+type YarnService interface {
+	GetYarnBoxes(req request.YarnBoxFilterRequest) (*response.PaginatedResponse, error)
+	GetYarnBoxByID(id uint) (*response.YarnBoxResponse, error)
+	CreateYarnBox(req request.CreateYarnBoxRequest, userID uint) (*response.YarnBoxResponse, error)
+	UpdateYarnBox(id uint, req request.UpdateYarnBoxRequest) (*response.YarnBoxResponse, error)
+	DeleteYarnBox(id uint) error
+	// ChangeStatus moves a box between available and allocated; depleted follows the balance
+	ChangeStatus(id uint, req request.ChangeYarnBoxStatusRequest) (*response.YarnBoxResponse, error)
+
+	// Receive adds cones and weight to a box (IN), Issue takes them out (OUT)
+	Receive(id uint, req request.YarnMovementRequest, userID uint) (*response.YarnMovementResponse, error)
+	Issue(id uint, req request.YarnMovementRequest, userID uint) (*response.YarnMovementResponse, error)
+	GetTransactions(req request.YarnTransactionFilterRequest) (*response.PaginatedResponse, error)
+
+	// GetStock sums the yarn in boxes that are not depleted per yarn type
+	GetStock(yarnType string) ([]response.YarnStockResponse, error)
+}
+
+type yarnService struct {
+	yarnBoxRepo interfaces.YarnBoxRepository
+}
+
+func NewYarnService(yarnBoxRepo interfaces.YarnBoxRepository) YarnService {
+	return &yarnService{
+		yarnBoxRepo: yarnBoxRepo,
+	}
+}
+
+func (s *yarnService) GetYarnBoxes(req request.YarnBoxFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	filter := interfaces.YarnBoxFilter{
+		Search:   strings.TrimSpace(req.Search),
+		YarnType: strings.TrimSpace(req.YarnType),
+		Location: strings.TrimSpace(req.Location),
+	}
+	for _, status := range splitValues(req.Status) {
+		switch status = strings.ToLower(status); status {
+		case models.YarnBoxAvailable, models.YarnBoxAllocated, models.YarnBoxDepleted:
+			filter.Statuses = append(filter.Statuses, status)
+		default:
+			return nil, fmt.Errorf("%w: unknown yarn box status %q", ErrInvalidQuery, status)
+		}
+	}
+
+	boxes, total, err := s.yarnBoxRepo.FindAll(filter, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(boxes))
+	for i := range boxes {
+		items[i] = convertYarnBoxToResponse(&boxes[i])
+	}
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.Limit))),
+	}, nil
+}
+
+func (s *yarnService) GetYarnBoxByID(id uint) (*response.YarnBoxResponse, error) {
+	box, err := s.yarnBoxRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrYarnBoxNotFound
+	}
+	return convertYarnBoxToResponse(box), nil
+}
+
+func (s *yarnService) CreateYarnBox(req request.CreateYarnBoxRequest, userID uint) (*response.YarnBoxResponse, error) {
+	box := &models.YarnBox{
+		BoxCode:           strings.TrimSpace(req.BoxCode),
+		YarnType:          strings.TrimSpace(req.YarnType),
+		ConeQuantity:      req.ConeQuantity,
+		TotalWeight:       roundQuantity(req.TotalWeight),
+		WarehouseLocation: strings.TrimSpace(req.WarehouseLocation),
+		Status:            models.YarnBoxAvailable,
+	}
+	now := time.Now()
+	if box.YarnType == "" {
+		return nil, errors.New("yarn_type is required")
+	}
+
+	// Opening stock goes through the ledger so the balance can be traced from the first movement
+	var opening *models.YarnInventoryTransaction
+	if box.ConeQuantity > 0 || box.TotalWeight > 0 {
+		opening = &models.YarnInventoryTransaction{
+			TransactionType:   models.YarnTransactionIn,
+			TransactionDate:   now,
+			Quantity:          box.ConeQuantity,
+			Weight:            box.TotalWeight,
+			RemainingQuantity: box.ConeQuantity,
+			RemainingWeight:   box.TotalWeight,
+			HandledBy:         userID,
+			Notes:             req.Notes,
+		}
+		box.ReceivedAt = &now
+	} else {
+		box.Status = models.YarnBoxDepleted
+	}
+
+	// Explicit code: must be unused
+	if box.BoxCode != "" {
+		if existing, _ := s.yarnBoxRepo.FindByCode(box.BoxCode); existing != nil {
+			return nil, errors.New("box code already exists")
+		}
+		if err := s.yarnBoxRepo.Create(box, opening); err != nil {
+			return nil, err
+		}
+		return convertYarnBoxToResponse(box), nil
+	}
+
+	// Generated code: retry with the next number when a concurrent create took it
+	for attempt := 0; attempt < orderCodeAttempts; attempt++ {
+		code, err := s.nextBoxCode()
+		if err != nil {
+			return nil, err
+		}
+		box.ID = 0
+		box.BoxCode = code
+
+		err = s.yarnBoxRepo.Create(box, opening)
+		if err == nil {
+			return convertYarnBoxToResponse(box), nil
+		}
+		if existing, _ := s.yarnBoxRepo.FindByCode(code); existing == nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("could not generate a unique box code")
+}
+
+func (s *yarnService) UpdateYarnBox(id uint, req request.UpdateYarnBoxRequest) (*response.YarnBoxResponse, error) {
+	box, err := s.yarnBoxRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrYarnBoxNotFound
+	}
+
+	if req.YarnType != nil {
+		box.YarnType = strings.TrimSpace(*req.YarnType)
+		if box.YarnType == "" {
+			return nil, errors.New("yarn_type is required")
+		}
+	}
+	if req.WarehouseLocation != nil {
+		box.WarehouseLocation = strings.TrimSpace(*req.WarehouseLocation)
+	}
+
+	if err := s.yarnBoxRepo.Update(box); err != nil {
+		return nil, err
+	}
+	return s.GetYarnBoxByID(box.ID)
+}
+
+// DeleteYarnBox removes an empty box; yarn still in a box has to be issued first so the ledger balances
+func (s *yarnService) DeleteYarnBox(id uint) error {
+	box, err := s.yarnBoxRepo.FindByID(id)
+	if err != nil {
+		return ErrYarnBoxNotFound
+	}
+	if box.ConeQuantity > 0 || box.TotalWeight > 0 {
+		return fmt.Errorf("box %s still holds %d cones, %v kg; issue them first", box.BoxCode, box.ConeQuantity, box.TotalWeight)
+	}
+	return s.yarnBoxRepo.Delete(id)
+}
+
+func (s *yarnService) ChangeStatus(id uint, req request.ChangeYarnBoxStatusRequest) (*response.YarnBoxResponse, error) {
+	box, err := s.yarnBoxRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrYarnBoxNotFound
+	}
+
+	var from string
+	switch req.Status {
+	case models.YarnBoxAllocated:
+		from = models.YarnBoxAvailable
+	case models.YarnBoxAvailable:
+		from = models.YarnBoxAllocated
+	default:
+		return nil, fmt.Errorf("cannot set a yarn box to %s", req.Status)
+	}
+	if box.Status == req.Status {
+		return convertYarnBoxToResponse(box), nil
+	}
+	if box.Status != from {
+		return nil, fmt.Errorf("a %s yarn box cannot become %s", box.Status, req.Status)
+	}
+
+	if err := s.yarnBoxRepo.ChangeStatus(box.ID, req.Status, []string{from}); err != nil {
+		return nil, err
+	}
+	return s.GetYarnBoxByID(box.ID)
+}
+
+func (s *yarnService) Receive(id uint, req request.YarnMovementRequest, userID uint) (*response.YarnMovementResponse, error) {
+	return s.record(id, models.YarnTransactionIn, req, userID)
+}
+
+func (s *yarnService) Issue(id uint, req request.YarnMovementRequest, userID uint) (*response.YarnMovementResponse, error) {
+	return s.record(id, models.YarnTransactionOut, req, userID)
+}
+
+func (s *yarnService) record(id uint, transactionType string, req request.YarnMovementRequest, userID uint) (*response.YarnMovementResponse, error) {
+	if _, err := s.yarnBoxRepo.FindByID(id); err != nil {
+		return nil, ErrYarnBoxNotFound
+	}
+	if req.Quantity == 0 && req.Weight == 0 {
+		return nil, errors.New("quantity or weight is required")
+	}
+
+	transaction := &models.YarnInventoryTransaction{
+		YarnBoxID:       id,
+		TransactionType: transactionType,
+		TransactionDate: time.Now(),
+		Quantity:        req.Quantity,
+		Weight:          roundQuantity(req.Weight),
+		HandledBy:       userID,
+		Notes:           req.Notes,
+	}
+	if req.TransactionDate != nil {
+		transaction.TransactionDate = *req.TransactionDate
+	}
+
+	box, err := s.yarnBoxRepo.Record(transaction, strings.TrimSpace(req.WarehouseLocation))
+	if err != nil {
+		return nil, err
+	}
+	transaction.YarnBox = *box
+	return &response.YarnMovementResponse{
+		Transaction: *convertYarnTransactionToResponse(transaction),
+		Box:         *convertYarnBoxToResponse(box),
+	}, nil
+}
+
+func (s *yarnService) GetTransactions(req request.YarnTransactionFilterRequest) (*response.PaginatedResponse, error) {
+	// Set defaults for pagination
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+
+	filter := interfaces.YarnTransactionFilter{
+		YarnBoxID:       req.YarnBoxID,
+		TransactionType: req.TransactionType,
+	}
+	if !req.From.IsZero() {
+		from := dateOnly(req.From)
+		filter.From = &from
+	}
+	if !req.To.IsZero() {
+		to := dateOnly(req.To).AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	transactions, total, err := s.yarnBoxRepo.FindTransactions(filter, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(transactions))
+	for i := range transactions {
+		items[i] = convertYarnTransactionToResponse(&transactions[i])
+	}
+
+	return &response.PaginatedResponse{
+		Items:      items,
+		TotalItems: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(req.Limit))),
+	}, nil
+}
+
+func (s *yarnService) GetStock(yarnType string) ([]response.YarnStockResponse, error) {
+	stock, err := s.yarnBoxRepo.StockByType(strings.TrimSpace(yarnType))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.YarnStockResponse, len(stock))
+	for i, line := range stock {
+		result[i] = response.YarnStockResponse{
+			YarnType:        line.YarnType,
+			Boxes:           line.Boxes,
+			Cones:           line.Cones,
+			Weight:          roundQuantity(line.Weight),
+			AllocatedCones:  line.AllocatedCones,
+			AllocatedWeight: roundQuantity(line.AllocatedWeight),
+			FreeCones:       line.Cones - line.AllocatedCones,
+			FreeWeight:      roundQuantity(line.Weight - line.AllocatedWeight),
+		}
+	}
+	return result, nil
+}
+
+// nextBoxCode returns the code after the highest generated one, e.g. YB000042 → YB000043
+func (s *yarnService) nextBoxCode() (string, error) {
+	last, err := s.yarnBoxRepo.LastCodeWithPrefix(yarnBoxCodePrefix)
+	if err != nil {
+		return "", err
+	}
+
+	next := 1
+	if last != "" {
+		number, err := strconv.Atoi(strings.TrimPrefix(last, yarnBoxCodePrefix))
+		if err != nil {
+			return "", err
+		}
+		next = number + 1
+	}
+	return fmt.Sprintf("%s%0*d", yarnBoxCodePrefix, yarnBoxCodeDigits, next), nil
+}
+
+func convertYarnBoxToResponse(box *models.YarnBox) *response.YarnBoxResponse {
+	return &response.YarnBoxResponse{
+		ID:                box.ID,
+		BoxCode:           box.BoxCode,
+		YarnType:          box.YarnType,
+		ConeQuantity:      box.ConeQuantity,
+		TotalWeight:       box.TotalWeight,
+		WarehouseLocation: box.WarehouseLocation,
+		Status:            box.Status,
+		ReceivedAt:        box.ReceivedAt,
+		CreatedAt:         box.CreatedAt,
+		UpdatedAt:         box.UpdatedAt,
+	}
+}
+
+func convertYarnTransactionToResponse(transaction *models.YarnInventoryTransaction) *response.YarnTransactionResponse {
+	return &response.YarnTransactionResponse{
+		ID:                transaction.ID,
+		YarnBoxID:         transaction.YarnBoxID,
+		BoxCode:           transaction.YarnBox.BoxCode,
+		TransactionType:   transaction.TransactionType,
+		TransactionDate:   transaction.TransactionDate,
+		Quantity:          transaction.Quantity,
+		Weight:            transaction.Weight,
+		RemainingQuantity: transaction.RemainingQuantity,
+		RemainingWeight:   transaction.RemainingWeight,
+		HandledBy:         transaction.HandledBy,
+		HandledByName:     transaction.User.Username,
+		Notes:             transaction.Notes,
+	}
+}
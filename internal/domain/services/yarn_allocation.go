@@ -0,0 +1,374 @@
+// File: internal/domain/services/yarn_allocation.go
+// Tạo tại: internal/domain/services/yarn_allocation.go
+// Mục đích: Service định mức sợi theo sản phẩm, tính nhu cầu sợi của đơn, phân bổ thùng sợi FIFO, xuất sợi cho dệt và báo cáo thiếu sợi
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/dto/request"
+	"github.com/godiidev/appsynex/internal/dto/response"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+)
+
+var (
+	// ErrYarnAllocationNotFound is returned when an allocation does not belong to the order
+	ErrYarnAllocationNotFound = errors.New("yarn allocation not found")
+	// ErrYarnAllocationChanged is returned when someone else released or issued the allocation meanwhile
+	ErrYarnAllocationChanged = interfaces.ErrYarnAllocationChanged
+)
+
+type YarnAllocationService interface {
+	// GetComponents and SetComponents read and replace a product's bill of materials
+	GetComponents(productID uint) ([]response.YarnComponentResponse, error)
+	SetComponents(productID uint, req request.SetYarnComponentsRequest) ([]response.YarnComponentResponse, error)
+
+	// GetOrderYarn computes the yarn the order needs from its lines and the bill of materials
+	GetOrderYarn(orderID uint) (*response.OrderYarnResponse, error)
+	// Allocate holds free yarn of the oldest boxes for the order's outstanding requirement
+	Allocate(orderID uint, req request.AllocateYarnRequest, userID uint) (*response.OrderYarnResponse, error)
+	// Release frees one allocation, or every allocated one of the order when allocationID is 0
+	Release(orderID, allocationID uint) error
+	// Issue takes an allocation's yarn out of its box for weaving
+	Issue(orderID, allocationID uint, req request.IssueYarnAllocationRequest, userID uint) (*response.YarnMovementResponse, error)
+
+	// Shortage lists the yarn types the open orders need more of than free stock holds
+	Shortage() ([]response.YarnShortageResponse, error)
+
+	// RegisterOrderEffects releases the order's allocated yarn when it is cancelled or ships
+	RegisterOrderEffects(workflow OrderWorkflowService)
+}
+
+type yarnAllocationService struct {
+	componentRepo  interfaces.YarnComponentRepository
+	allocationRepo interfaces.YarnAllocationRepository
+	yarnBoxRepo    interfaces.YarnBoxRepository
+	orderRepo      interfaces.OrderRepository
+	productRepo    interfaces.ProductRepository
+}
+
+func NewYarnAllocationService(
+	componentRepo interfaces.YarnComponentRepository,
+	allocationRepo interfaces.YarnAllocationRepository,
+	yarnBoxRepo interfaces.YarnBoxRepository,
+	orderRepo interfaces.OrderRepository,
+	productRepo interfaces.ProductRepository,
+) YarnAllocationService {
+	return &yarnAllocationService{
+		componentRepo:  componentRepo,
+		allocationRepo: allocationRepo,
+		yarnBoxRepo:    yarnBoxRepo,
+		orderRepo:      orderRepo,
+		productRepo:    productRepo,
+	}
+}
+
+func (s *yarnAllocationService) GetComponents(productID uint) ([]response.YarnComponentResponse, error) {
+	if _, err := s.productRepo.FindByID(productID); err != nil {
+		return nil, ErrProductNotFound
+	}
+	components, err := s.componentRepo.FindByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.YarnComponentResponse, len(components))
+	for i, component := range components {
+		result[i] = response.YarnComponentResponse{
+			ID:            component.ID,
+			ProductID:     component.ProductID,
+			YarnType:      component.YarnType,
+			GramsPerMeter: component.GramsPerMeter,
+			Notes:         component.Notes,
+		}
+	}
+	return result, nil
+}
+
+func (s *yarnAllocationService) SetComponents(productID uint, req request.SetYarnComponentsRequest) ([]response.YarnComponentResponse, error) {
+	if _, err := s.productRepo.FindByID(productID); err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	seen := make(map[string]bool, len(req.Components))
+	components := make([]models.ProductYarnComponent, len(req.Components))
+	for i, component := range req.Components {
+		yarnType := strings.TrimSpace(component.YarnType)
+		if yarnType == "" {
+			return nil, errors.New("yarn_type is required")
+		}
+		if seen[yarnType] {
+			return nil, fmt.Errorf("yarn type %s is listed twice", yarnType)
+		}
+		seen[yarnType] = true
+
+		components[i] = models.ProductYarnComponent{
+			YarnType:      yarnType,
+			GramsPerMeter: roundQuantity(component.GramsPerMeter),
+			Notes:         component.Notes,
+		}
+	}
+
+	if err := s.componentRepo.Replace(productID, components); err != nil {
+		return nil, err
+	}
+	return s.GetComponents(productID)
+}
+
+func (s *yarnAllocationService) GetOrderYarn(orderID uint) (*response.OrderYarnResponse, error) {
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+	return s.orderYarn(order)
+}
+
+func (s *yarnAllocationService) Allocate(orderID uint, req request.AllocateYarnRequest, userID uint) (*response.OrderYarnResponse, error) {
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+	if !reservableOrderStatuses[order.OrderStatus.StatusName] {
+		return nil, fmt.Errorf("cannot allocate yarn for an order that is %s", order.OrderStatus.StatusName)
+	}
+
+	demand, err := s.allocationRepo.Demand(order.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(demand) == 0 {
+		return nil, errors.New("the order's products have no yarn bill of materials")
+	}
+
+	wanted := make(map[string]bool, len(req.YarnTypes))
+	for _, yarnType := range req.YarnTypes {
+		wanted[strings.TrimSpace(yarnType)] = true
+	}
+
+	var lines []interfaces.YarnAllocationLine
+	for _, line := range demand {
+		if len(wanted) > 0 && !wanted[line.YarnType] {
+			continue
+		}
+		delete(wanted, line.YarnType)
+
+		outstanding := outstandingYarn(line)
+		if outstanding <= 0 {
+			continue
+		}
+		lines = append(lines, interfaces.YarnAllocationLine{
+			Template: models.YarnOrder{
+				OrderID:     order.ID,
+				AllocatedBy: &userID,
+			},
+			YarnType: line.YarnType,
+			Weight:   outstanding,
+		})
+	}
+	for yarnType := range wanted {
+		return nil, fmt.Errorf("the order does not need yarn type %s", yarnType)
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("the order's yarn is already fully allocated")
+	}
+
+	if _, err := s.allocationRepo.Allocate(lines, req.AllowPartial); err != nil {
+		return nil, err
+	}
+	return s.orderYarn(order)
+}
+
+func (s *yarnAllocationService) Release(orderID, allocationID uint) error {
+	if _, err := s.orderRepo.FindByID(orderID); err != nil {
+		return ErrOrderNotFound
+	}
+
+	var ids []uint
+	if allocationID != 0 {
+		allocation, err := s.allocationRepo.FindByID(allocationID)
+		if err != nil || allocation.OrderID != orderID {
+			return ErrYarnAllocationNotFound
+		}
+		if allocation.Status != models.YarnAllocationAllocated {
+			return fmt.Errorf("yarn allocation is already %s", allocation.Status)
+		}
+		ids = []uint{allocationID}
+	}
+
+	_, err := s.allocationRepo.Release(orderID, ids, "released manually", time.Now())
+	return err
+}
+
+func (s *yarnAllocationService) Issue(orderID, allocationID uint, req request.IssueYarnAllocationRequest, userID uint) (*response.YarnMovementResponse, error) {
+	order, err := s.orderRepo.FindByID(orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+	allocation, err := s.allocationRepo.FindByID(allocationID)
+	if err != nil || allocation.OrderID != orderID {
+		return nil, ErrYarnAllocationNotFound
+	}
+	if allocation.Status != models.YarnAllocationAllocated {
+		return nil, fmt.Errorf("yarn allocation is already %s", allocation.Status)
+	}
+
+	// Claim the allocation first so two issues cannot both take the yarn out
+	if err := s.allocationRepo.ChangeStatus(allocation.ID, models.YarnAllocationIssued, models.YarnAllocationAllocated); err != nil {
+		return nil, err
+	}
+
+	transaction := &models.YarnInventoryTransaction{
+		YarnBoxID:       allocation.YarnBoxID,
+		TransactionType: models.YarnTransactionOut,
+		TransactionDate: time.Now(),
+		Quantity:        allocation.ConeQuantity,
+		Weight:          allocation.TotalWeight,
+		HandledBy:       userID,
+		Notes:           strings.TrimSpace(fmt.Sprintf("Order %s\n%s", order.OrderCode, req.Notes)),
+	}
+	box, err := s.yarnBoxRepo.Record(transaction, "")
+	if err != nil {
+		// Keep the yarn allocated so the issue can be retried
+		if revertErr := s.allocationRepo.ChangeStatus(allocation.ID, models.YarnAllocationAllocated, models.YarnAllocationIssued); revertErr != nil {
+			log.Printf("Warning: failed to reopen yarn allocation %d: %v", allocation.ID, revertErr)
+		}
+		return nil, err
+	}
+
+	transaction.YarnBox = *box
+	return &response.YarnMovementResponse{
+		Transaction: *convertYarnTransactionToResponse(transaction),
+		Box:         *convertYarnBoxToResponse(box),
+	}, nil
+}
+
+func (s *yarnAllocationService) Shortage() ([]response.YarnShortageResponse, error) {
+	statuses := make([]string, 0, len(reservableOrderStatuses))
+	for status := range reservableOrderStatuses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	demand, err := s.allocationRepo.Demand(0, statuses)
+	if err != nil {
+		return nil, err
+	}
+	free, err := s.freeStock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Orders are clamped one by one so yarn over-allocated to one order does not hide another's need
+	index := make(map[string]int)
+	var result []response.YarnShortageResponse
+	for _, line := range demand {
+		outstanding := outstandingYarn(line)
+		if outstanding <= 0 {
+			continue
+		}
+		i, ok := index[line.YarnType]
+		if !ok {
+			i = len(result)
+			index[line.YarnType] = i
+			result = append(result, response.YarnShortageResponse{YarnType: line.YarnType, FreeStock: free[line.YarnType]})
+		}
+		result[i].Orders++
+		result[i].Outstanding = roundQuantity(result[i].Outstanding + outstanding)
+	}
+
+	shortages := make([]response.YarnShortageResponse, 0, len(result))
+	for _, line := range result {
+		line.Shortage = math.Max(roundQuantity(line.Outstanding-line.FreeStock), 0)
+		if line.Shortage > 0 {
+			shortages = append(shortages, line)
+		}
+	}
+	sort.Slice(shortages, func(i, j int) bool { return shortages[i].YarnType < shortages[j].YarnType })
+	return shortages, nil
+}
+
+func (s *yarnAllocationService) RegisterOrderEffects(workflow OrderWorkflowService) {
+	workflow.AddEffect(OrderActionCancel, func(t *OrderTransitionContext) error {
+		_, err := s.allocationRepo.WithTx(t.Tx).Release(t.Order.ID, nil, "order cancelled", time.Now())
+		return err
+	})
+	workflow.AddEffect(OrderActionShip, func(t *OrderTransitionContext) error {
+		_, err := s.allocationRepo.WithTx(t.Tx).Release(t.Order.ID, nil, "order shipped", time.Now())
+		return err
+	})
+}
+
+func (s *yarnAllocationService) orderYarn(order *models.Order) (*response.OrderYarnResponse, error) {
+	demand, err := s.allocationRepo.Demand(order.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	free, err := s.freeStock()
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := s.allocationRepo.FindByOrder(order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.OrderYarnResponse{
+		OrderID:     order.ID,
+		OrderCode:   order.OrderCode,
+		Lines:       make([]response.YarnRequirementLineResponse, len(demand)),
+		Allocations: make([]response.YarnAllocationResponse, len(allocations)),
+	}
+	for i, line := range demand {
+		outstanding := outstandingYarn(line)
+		result.Lines[i] = response.YarnRequirementLineResponse{
+			YarnType:    line.YarnType,
+			Required:    line.Required,
+			Allocated:   line.Allocated,
+			Issued:      line.Issued,
+			Outstanding: outstanding,
+			FreeStock:   free[line.YarnType],
+			Shortage:    math.Max(roundQuantity(outstanding-free[line.YarnType]), 0),
+		}
+	}
+	for i, allocation := range allocations {
+		result.Allocations[i] = response.YarnAllocationResponse{
+			ID:            allocation.ID,
+			YarnBoxID:     allocation.YarnBoxID,
+			BoxCode:       allocation.YarnBox.BoxCode,
+			YarnType:      allocation.YarnType,
+			ConeQuantity:  allocation.ConeQuantity,
+			TotalWeight:   allocation.TotalWeight,
+			Status:        allocation.Status,
+			ReleasedAt:    allocation.ReleasedAt,
+			ReleaseReason: allocation.ReleaseReason,
+			CreatedAt:     allocation.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+// freeStock is the yarn per type in boxes that is neither allocated nor depleted, in kilograms
+func (s *yarnAllocationService) freeStock() (map[string]float64, error) {
+	stock, err := s.yarnBoxRepo.StockByType("")
+	if err != nil {
+		return nil, err
+	}
+	free := make(map[string]float64, len(stock))
+	for _, line := range stock {
+		free[line.YarnType] = math.Max(roundQuantity(line.Weight-line.AllocatedWeight), 0)
+	}
+	return free, nil
+}
+
+// outstandingYarn is the weight of the demand not covered by allocated or issued yarn
+func outstandingYarn(demand interfaces.YarnDemand) float64 {
+	return math.Max(roundQuantity(demand.Required-demand.Allocated-demand.Issued), 0)
+}
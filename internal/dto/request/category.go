@@ -6,12 +6,30 @@ package request
 
 type CreateCategoryRequest struct {
 	CategoryName     string `json:"category_name" binding:"required"`
+	CategoryNameEN   string `json:"category_name_en"`
 	ParentCategoryID *uint  `json:"parent_category_id"`
 	Description      string `json:"description"`
+	DescriptionEN    string `json:"description_en"`
 }
 
 type UpdateCategoryRequest struct {
 	CategoryName     string `json:"category_name"`
+	CategoryNameEN   string `json:"category_name_en"`
 	ParentCategoryID *uint  `json:"parent_category_id"`
 	Description      string `json:"description"`
+	DescriptionEN    string `json:"description_en"`
+}
+
+// MoveCategoryRequest reparents a category; a null parent_category_id moves it to the root
+type MoveCategoryRequest struct {
+	ParentCategoryID *uint `json:"parent_category_id"`
+}
+
+// DeleteCategoryRequest chooses what happens to sub-categories, products and samples of a deleted category:
+// block (default) refuses while anything references it, reassign moves them to target_category_id,
+// cascade deletes the whole subtree with its products and samples
+type DeleteCategoryRequest struct {
+	Strategy         string `form:"strategy" json:"strategy" binding:"omitempty,oneof=block reassign cascade"`
+	TargetCategoryID *uint  `form:"target_category_id" json:"target_category_id"`
+	DryRun           bool   `form:"dry_run" json:"dry_run"`
 }
@@ -0,0 +1,44 @@
+// File: internal/dto/request/customer.go
+// Tạo tại: internal/dto/request/customer.go
+// Mục đích: Định nghĩa các request DTO cho Customer API
+
+package request
+
+type CustomerFilterRequest struct {
+	Page          int    `form:"page" json:"page"`
+	Limit         int    `form:"limit" json:"limit"`
+	Search        string `form:"search" json:"search"` // Code, name, company, email, phone or tax ID
+	AccountStatus string `form:"account_status" json:"account_status" binding:"omitempty,oneof=active inactive blocked"`
+
+	// Offset mode: id, customer_code, name, company_name, account_status, created_at.
+	// Cursor mode: id, customer_code, name, created_at. "-" prefix for descending.
+	SortBy    string `form:"sort_by" json:"sort_by"`
+	SortOrder string `form:"sort_order" json:"sort_order" binding:"omitempty,oneof=asc desc"`
+	CursorParams
+}
+
+type CreateCustomerRequest struct {
+	CustomerCode  string `json:"customer_code"` // Generated when empty
+	Name          string `json:"name" binding:"required"`
+	Email         string `json:"email" binding:"omitempty,email"`
+	Phone         string `json:"phone"`
+	Address       string `json:"address"`
+	CompanyName   string `json:"company_name"`
+	TaxID         string `json:"tax_id"`
+	AccountStatus string `json:"account_status" binding:"omitempty,oneof=active inactive blocked"`
+	PriceTier     string `json:"price_tier"`
+	Currency      string `json:"currency"` // Default currency of new orders and quotations
+}
+
+type UpdateCustomerRequest struct {
+	CustomerCode  string  `json:"customer_code"`
+	Name          string  `json:"name"`
+	Email         *string `json:"email" binding:"omitempty,email"`
+	Phone         *string `json:"phone"`
+	Address       *string `json:"address"`
+	CompanyName   *string `json:"company_name"`
+	TaxID         *string `json:"tax_id"`
+	AccountStatus string  `json:"account_status" binding:"omitempty,oneof=active inactive blocked"`
+	PriceTier     *string `json:"price_tier"`
+	Currency      string  `json:"currency"`
+}
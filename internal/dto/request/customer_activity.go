@@ -0,0 +1,28 @@
+// File: internal/dto/request/customer_activity.go
+// Tạo tại: internal/dto/request/customer_activity.go
+// Mục đích: Định nghĩa các request DTO cho timeline hoạt động khách hàng
+
+package request
+
+import "time"
+
+type CustomerActivityFilterRequest struct {
+	Page  int `form:"page" json:"page"`
+	Limit int `form:"limit" json:"limit"`
+
+	// ActivityType filters by SAMPLE_RECEIVED, LAP_DIP_APPROVED, ORDER_PLACED, ORDER_CANCELLED, CUSTOMER_MERGED
+	// (repeated or comma-separated)
+	ActivityType []string  `form:"activity_type" json:"activity_type"`
+	DateFrom     time.Time `form:"date_from" json:"date_from" time_format:"2006-01-02"`
+	DateTo       time.Time `form:"date_to" json:"date_to" time_format:"2006-01-02"` // Inclusive
+
+	// SortOrder orders the timeline by activity date, newest first by default
+	SortOrder string `form:"sort_order" json:"sort_order" binding:"omitempty,oneof=asc desc"`
+	CursorParams
+}
+
+// ClientInfo identifies where a request came from, recorded with customer activity
+type ClientInfo struct {
+	IPAddress  string
+	DeviceInfo string
+}
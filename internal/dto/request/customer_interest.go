@@ -0,0 +1,46 @@
+// File: internal/dto/request/customer_interest.go
+// Tạo tại: internal/dto/request/customer_interest.go
+// Mục đích: Định nghĩa các request DTO cho danh sách lưu, lịch sử tìm kiếm và báo cáo quan tâm của khách hàng
+
+package request
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type SavedItemFilterRequest struct {
+	Page     int    `form:"page" json:"page"`
+	Limit    int    `form:"limit" json:"limit"`
+	ItemType string `form:"item_type" json:"item_type" binding:"omitempty,oneof=product sample"`
+}
+
+// CreateSavedItemRequest saves a product or a sample; exactly one of the IDs must be set
+type CreateSavedItemRequest struct {
+	ProductID       *uint  `json:"product_id"`
+	SampleProductID *uint  `json:"sample_product_id"`
+	Notes           string `json:"notes"`
+}
+
+type UpdateSavedItemRequest struct {
+	Notes string `json:"notes"`
+}
+
+type SearchHistoryFilterRequest struct {
+	Page     int       `form:"page" json:"page"`
+	Limit    int       `form:"limit" json:"limit"`
+	DateFrom time.Time `form:"date_from" json:"date_from" time_format:"2006-01-02"`
+	DateTo   time.Time `form:"date_to" json:"date_to" time_format:"2006-01-02"` // Inclusive
+}
+
+type RecordSearchRequest struct {
+	SearchQuery   string          `json:"search_query" binding:"required,max=1000"`
+	ResultCount   *int            `json:"result_count" binding:"omitempty,gte=0"`
+	SearchResults json.RawMessage `json:"search_results"` // Optional JSON kept as-is, e.g. the IDs shown
+}
+
+type CustomerInterestReportRequest struct {
+	DateFrom time.Time `form:"date_from" json:"date_from" time_format:"2006-01-02"`  // Default 30 days before date_to
+	DateTo   time.Time `form:"date_to" json:"date_to" time_format:"2006-01-02"`      // Inclusive, default today
+	Limit    int       `form:"limit" json:"limit" binding:"omitempty,gte=1,lte=100"` // Entries per list, default 10
+}
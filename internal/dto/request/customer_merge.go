@@ -0,0 +1,18 @@
+// File: internal/dto/request/customer_merge.go
+// Tạo tại: internal/dto/request/customer_merge.go
+// Mục đích: Định nghĩa các request DTO cho phát hiện và gộp khách hàng trùng lặp
+
+package request
+
+type DuplicateSearchRequest struct {
+	CustomerID uint `form:"customer_id" json:"customer_id"`                               // Only duplicates of this customer
+	MinScore   int  `form:"min_score" json:"min_score" binding:"omitempty,gte=1,lte=100"` // Default 35
+	Limit      int  `form:"limit" json:"limit" binding:"omitempty,gte=1,lte=200"`         // Default 50
+}
+
+type MergeCustomerRequest struct {
+	DuplicateID uint   `json:"duplicate_id" binding:"required"`
+	Reason      string `json:"reason"`
+	DryRun      bool   `json:"dry_run"`      // Report what would move without changing anything
+	FillMissing *bool  `json:"fill_missing"` // Copy contact fields the survivor lacks from the duplicate, default true
+}
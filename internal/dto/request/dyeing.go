@@ -0,0 +1,105 @@
+// File: internal/dto/request/dyeing.go
+// Tạo tại: internal/dto/request/dyeing.go
+// Mục đích: Định nghĩa các request DTO cho nhà nhuộm, lệnh nhuộm, lô nhuộm trả về và kết quả co rút, độ bền màu
+
+package request
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+type CreateDyeingSubcontractorRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	ContactInfo string `json:"contact_info"`
+	Currency    string `json:"currency"` // ISO 4217 code of its jobs; defaults to the base currency
+}
+
+type UpdateDyeingSubcontractorRequest struct {
+	Name        *string `json:"name" binding:"omitempty,max=255"`
+	ContactInfo *string `json:"contact_info"`
+	Currency    *string `json:"currency"`
+}
+
+type DyeingJobFilterRequest struct {
+	Page            int       `form:"page" json:"page"`
+	Limit           int       `form:"limit" json:"limit"`
+	Search          string    `form:"search" json:"search"` // Job code, dye house, target color or lap dip reference
+	Status          []string  `form:"status" json:"status"` // sent, partially_returned, returned, cancelled; repeated or comma-separated
+	SubcontractorID uint      `form:"subcontractor_id" json:"subcontractor_id"`
+	OrderID         uint      `form:"order_id" json:"order_id"`
+	WeavingOrderID  uint      `form:"weaving_order_id" json:"weaving_order_id"`
+	DueBy           time.Time `form:"due_by" json:"due_by" time_format:"2006-01-02"` // Expected back on or before
+}
+
+// CreateDyeingJobRequest sends greige to a dye house. A completed weaving order the greige comes from is
+// sent to dyeing, and gives the sales order, product and greige length by default; a lap dip test gives
+// the target color and reference.
+type CreateDyeingJobRequest struct {
+	JobCode               string       `json:"job_code"` // Generated when empty
+	DyeingSubcontractorID uint         `json:"dyeing_subcontractor_id" binding:"required"`
+	WeavingOrderID        *uint        `json:"weaving_order_id"`
+	OrderID               *uint        `json:"order_id"`
+	ProductID             *uint        `json:"product_id"` // Product the dyed lots are stocked as
+	LDTestID              *uint        `json:"ld_test_id"`
+	TargetColor           string       `json:"target_color" binding:"max=255"`
+	ColorCode             string       `json:"color_code" binding:"max=100"`
+	LapDipReference       string       `json:"lap_dip_reference" binding:"max=100"` // Defaults to the lap dip's selected code
+	GreigeLength          float64      `json:"greige_length" binding:"gte=0"`       // Meters
+	GreigeWeight          float64      `json:"greige_weight" binding:"gte=0"`       // Kilograms
+	GreigeRolls           int          `json:"greige_rolls" binding:"gte=0"`
+	SentDate              *time.Time   `json:"sent_date"` // Defaults to today
+	ExpectedReturnDate    *time.Time   `json:"expected_return_date"`
+	CostPerKg             money.Amount `json:"cost_per_kg" binding:"gte=0"`
+	Currency              string       `json:"currency"` // Defaults to the dye house's
+	SpecialRequirements   string       `json:"special_requirements"`
+	Notes                 string       `json:"notes"`
+}
+
+type UpdateDyeingJobRequest struct {
+	ProductID           *uint         `json:"product_id"`
+	TargetColor         *string       `json:"target_color" binding:"omitempty,min=1,max=255"`
+	ColorCode           *string       `json:"color_code" binding:"omitempty,max=100"`
+	LapDipReference     *string       `json:"lap_dip_reference" binding:"omitempty,max=100"`
+	GreigeLength        *float64      `json:"greige_length" binding:"omitempty,gte=0"`
+	GreigeWeight        *float64      `json:"greige_weight" binding:"omitempty,gte=0"`
+	GreigeRolls         *int          `json:"greige_rolls" binding:"omitempty,gte=0"`
+	ExpectedReturnDate  *time.Time    `json:"expected_return_date"`
+	CostPerKg           *money.Amount `json:"cost_per_kg" binding:"omitempty,gte=0"`
+	SpecialRequirements *string       `json:"special_requirements"`
+	Notes               *string       `json:"notes"`
+}
+
+type ChangeDyeingJobStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=returned cancelled"`
+}
+
+// DyeingLotResultsRequest records the lab results of a returned lot. Shrinkage is a percent, negative when
+// the fabric grew; color fastness uses the grey scale, and the blue wool scale for light.
+type DyeingLotResultsRequest struct {
+	ShrinkageLength      *float64 `json:"shrinkage_length" binding:"omitempty,gte=-30,lte=30"`
+	ShrinkageWidth       *float64 `json:"shrinkage_width" binding:"omitempty,gte=-30,lte=30"`
+	ColorFastnessWashing *float64 `json:"color_fastness_washing" binding:"omitempty,gte=1,lte=5"`
+	ColorFastnessRubbing *float64 `json:"color_fastness_rubbing" binding:"omitempty,gte=1,lte=5"`
+	ColorFastnessLight   *float64 `json:"color_fastness_light" binding:"omitempty,gte=1,lte=8"`
+	QualityIssues        *string  `json:"quality_issues"`
+	Notes                *string  `json:"notes"`
+}
+
+type CreateDyeingLotRequest struct {
+	LotCode      string     `json:"lot_code" binding:"max=100"`       // Generated from the job code when empty
+	Quantity     int        `json:"quantity" binding:"required,gt=0"` // Rolls
+	Weight       float64    `json:"weight" binding:"required,gt=0"`   // Kilograms
+	ReturnedDate *time.Time `json:"returned_date"`                    // Defaults to today
+	DyeingLotResultsRequest
+}
+
+type AcceptDyeingLotRequest struct {
+	Location string `json:"location" binding:"max=255"` // Where the warehouse lot is kept
+	Notes    string `json:"notes"`
+}
+
+type RejectDyeingLotRequest struct {
+	QualityIssues string `json:"quality_issues" binding:"required"`
+}
@@ -0,0 +1,45 @@
+// File: internal/dto/request/exchange_rate.go
+// Tạo tại: internal/dto/request/exchange_rate.go
+// Mục đích: Định nghĩa các request DTO cho tỷ giá, quy đổi tiền tệ và báo cáo doanh số theo tiền tệ gốc
+
+package request
+
+import "time"
+
+type ExchangeRateFilterRequest struct {
+	Page         int    `form:"page" json:"page"`
+	Limit        int    `form:"limit" json:"limit"`
+	FromCurrency string `form:"from_currency" json:"from_currency"`
+	ToCurrency   string `form:"to_currency" json:"to_currency"`
+}
+
+// CreateExchangeRateRequest sets the value of one unit of from_currency in to_currency from effective_date on
+type CreateExchangeRateRequest struct {
+	FromCurrency  string     `json:"from_currency" binding:"required,len=3"`
+	ToCurrency    string     `json:"to_currency" binding:"required,len=3"`
+	Rate          float64    `json:"rate" binding:"required,gt=0"`
+	EffectiveDate *time.Time `json:"effective_date"` // Defaults to today
+	Source        string     `json:"source"`
+	Notes         string     `json:"notes"`
+}
+
+type UpdateExchangeRateRequest struct {
+	Rate          *float64   `json:"rate" binding:"omitempty,gt=0"`
+	EffectiveDate *time.Time `json:"effective_date"`
+	Source        *string    `json:"source"`
+	Notes         *string    `json:"notes"`
+}
+
+type ConvertAmountRequest struct {
+	Amount string    `form:"amount" json:"amount" binding:"required"` // Decimal, e.g. 1250.50
+	From   string    `form:"from" json:"from" binding:"required,len=3"`
+	To     string    `form:"to" json:"to"`                              // Defaults to the base currency
+	Date   time.Time `form:"date" json:"date" time_format:"2006-01-02"` // Defaults to today
+}
+
+// SalesSummaryRequest asks for the order totals of a period in one currency
+type SalesSummaryRequest struct {
+	From     time.Time `form:"from" json:"from" time_format:"2006-01-02" binding:"required"`
+	To       time.Time `form:"to" json:"to" time_format:"2006-01-02" binding:"required"` // Inclusive
+	Currency string    `form:"currency" json:"currency"`                                 // Defaults to the base currency
+}
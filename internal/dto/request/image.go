@@ -0,0 +1,9 @@
+// File: internal/dto/request/image.go
+// Tạo tại: internal/dto/request/image.go
+// Mục đích: Request DTO cho quản lý hình ảnh sample/product
+
+package request
+
+type ReorderImagesRequest struct {
+	ImageIDs []uint `json:"image_ids" binding:"required,min=1"`
+}
@@ -0,0 +1,55 @@
+// File: internal/dto/request/order.go
+// Tạo tại: internal/dto/request/order.go
+// Mục đích: Định nghĩa các request DTO cho Order API
+
+package request
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+type OrderFilterRequest struct {
+	Page       int       `form:"page" json:"page"`
+	Limit      int       `form:"limit" json:"limit"`
+	Search     string    `form:"search" json:"search"` // Order code, customer code, name or company
+	CustomerID uint      `form:"customer_id" json:"customer_id"`
+	Status     []string  `form:"status" json:"status"` // Status names, repeated or comma-separated
+	DueFrom    time.Time `form:"due_from" json:"due_from" time_format:"2006-01-02"`
+	DueTo      time.Time `form:"due_to" json:"due_to" time_format:"2006-01-02"` // Inclusive
+
+	// Offset mode: id, order_code, total_amount, due_date, created_at.
+	// Cursor mode: id, order_code, created_at. "-" prefix for descending.
+	SortBy    string `form:"sort_by" json:"sort_by"`
+	SortOrder string `form:"sort_order" json:"sort_order" binding:"omitempty,oneof=asc desc"`
+	CursorParams
+}
+
+type OrderItemRequest struct {
+	ProductID uint          `json:"product_id" binding:"required"`
+	ColorCode string        `json:"color_code"` // Defaults to the product color
+	Quantity  float64       `json:"quantity" binding:"required,gt=0"`
+	Price     *money.Amount `json:"price" binding:"required,gte=0"` // Unit price in the order currency; subtotal and total are computed
+	Notes     string        `json:"notes"`
+}
+
+type CreateOrderRequest struct {
+	OrderCode  string             `json:"order_code"` // Generated when empty
+	CustomerID uint               `json:"customer_id" binding:"required"`
+	Currency   string             `json:"currency"` // ISO 4217 code; defaults to the customer's currency
+	DueDate    *time.Time         `json:"due_date"`
+	Notes      string             `json:"notes"`
+	Items      []OrderItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type UpdateOrderRequest struct {
+	DueDate *time.Time         `json:"due_date"`
+	Notes   *string            `json:"notes"`
+	Items   []OrderItemRequest `json:"items" binding:"omitempty,min=1,dive"` // Replaces every item; only while PENDING
+}
+
+// OrderTransitionRequest carries the note kept in the order history, e.g. the cancel reason
+type OrderTransitionRequest struct {
+	Note string `json:"note"`
+}
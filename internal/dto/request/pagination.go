@@ -0,0 +1,17 @@
+// File: internal/dto/request/pagination.go
+// Tạo tại: internal/dto/request/pagination.go
+// Mục đích: Tham số phân trang theo cursor dùng chung cho các API danh sách
+
+package request
+
+// CursorParams switches a list endpoint to cursor (keyset) pagination
+type CursorParams struct {
+	Pagination string `form:"pagination" json:"pagination" binding:"omitempty,oneof=offset cursor"`
+	Cursor     string `form:"cursor" json:"cursor"`                                             // Opaque next_cursor from the previous page
+	Total      string `form:"total" json:"total" binding:"omitempty,oneof=exact estimate none"` // Cursor mode only, default none
+}
+
+// UsesCursor reports whether cursor pagination was requested
+func (p CursorParams) UsesCursor() bool {
+	return p.Pagination == "cursor" || p.Cursor != ""
+}
@@ -0,0 +1,54 @@
+// File: internal/dto/request/portal.go
+// Tạo tại: internal/dto/request/portal.go
+// Mục đích: Định nghĩa các request DTO cho cổng portal khách hàng và quản lý tài khoản portal
+
+package request
+
+type PortalLoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+type PortalOrderFilterRequest struct {
+	Page   int    `form:"page" json:"page"`
+	Limit  int    `form:"limit" json:"limit"`
+	Status string `form:"status" json:"status"` // Order status name
+}
+
+type PortalShipmentFilterRequest struct {
+	Page    int  `form:"page" json:"page"`
+	Limit   int  `form:"limit" json:"limit"`
+	OrderID uint `form:"order_id" json:"order_id"`
+}
+
+type PortalListRequest struct {
+	Page  int `form:"page" json:"page"`
+	Limit int `form:"limit" json:"limit"`
+}
+
+type PortalLapDipFilterRequest struct {
+	Page   int    `form:"page" json:"page"`
+	Limit  int    `form:"limit" json:"limit"`
+	Status string `form:"status" json:"status" binding:"omitempty,oneof=dispatched approved rejected"`
+}
+
+type ApproveLapDipRequest struct {
+	FinalLDCode string `json:"final_ld_code" binding:"required"` // One of the lap dip results
+	Comment     string `json:"comment"`
+}
+
+type RejectLapDipRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type CreateCustomerAccountRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+	FullName string `json:"full_name"`
+}
+
+type UpdateCustomerAccountRequest struct {
+	FullName *string `json:"full_name"`
+	Password string  `json:"password" binding:"omitempty,min=8"` // Resets the password when set
+	Status   string  `json:"status" binding:"omitempty,oneof=active disabled"`
+}
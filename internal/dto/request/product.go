@@ -0,0 +1,12 @@
+// File: internal/dto/request/product.go
+// Tạo tại: internal/dto/request/product.go
+// Mục đích: Request DTO cho Product API
+
+package request
+
+type ProductFilterRequest struct {
+	Page     int    `form:"page" json:"page"`
+	Limit    int    `form:"limit" json:"limit"`
+	Search   string `form:"search" json:"search"`
+	Category string `form:"category" json:"category"`
+}
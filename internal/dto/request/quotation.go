@@ -0,0 +1,103 @@
+// File: internal/dto/request/quotation.go
+// Tạo tại: internal/dto/request/quotation.go
+// Mục đích: Định nghĩa các request DTO cho bảng giá và báo giá
+
+package request
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+type PriceListFilterRequest struct {
+	Page       int       `form:"page" json:"page"`
+	Limit      int       `form:"limit" json:"limit"`
+	CustomerID uint      `form:"customer_id" json:"customer_id"`
+	PriceTier  string    `form:"price_tier" json:"price_tier"`
+	Currency   string    `form:"currency" json:"currency"`
+	ActiveOn   time.Time `form:"active_on" json:"active_on" time_format:"2006-01-02"` // Only lists valid on this date
+}
+
+type PriceListItemRequest struct {
+	ProductID   uint          `json:"product_id" binding:"required"`
+	MinQuantity float64       `json:"min_quantity" binding:"gte=0"` // Quantity break; 0 applies from the first meter
+	UnitPrice   *money.Amount `json:"unit_price" binding:"required,gte=0"`
+}
+
+// CreatePriceListRequest sets CustomerID for a customer's own list, PriceTier for a tier, or neither for a general list
+type CreatePriceListRequest struct {
+	Name       string                 `json:"name" binding:"required"`
+	CustomerID *uint                  `json:"customer_id"`
+	PriceTier  string                 `json:"price_tier"`
+	Currency   string                 `json:"currency"` // Defaults to the base currency
+	ValidFrom  *time.Time             `json:"valid_from"`
+	ValidTo    *time.Time             `json:"valid_to"`  // Inclusive
+	IsActive   *bool                  `json:"is_active"` // Defaults to true
+	Notes      string                 `json:"notes"`
+	Items      []PriceListItemRequest `json:"items" binding:"dive"`
+}
+
+type UpdatePriceListRequest struct {
+	Name       *string                `json:"name"`
+	CustomerID *uint                  `json:"customer_id"` // 0 removes the customer
+	PriceTier  *string                `json:"price_tier"`
+	Currency   *string                `json:"currency"`
+	ValidFrom  *time.Time             `json:"valid_from"`
+	ValidTo    *time.Time             `json:"valid_to"`
+	IsActive   *bool                  `json:"is_active"`
+	Notes      *string                `json:"notes"`
+	Items      []PriceListItemRequest `json:"items" binding:"omitempty,dive"` // Replaces every item
+}
+
+type ResolvePriceRequest struct {
+	CustomerID uint      `form:"customer_id" json:"customer_id" binding:"required"`
+	ProductID  uint      `form:"product_id" json:"product_id" binding:"required"`
+	Currency   string    `form:"currency" json:"currency"` // Defaults to the customer's currency
+	Quantity   float64   `form:"quantity" json:"quantity" binding:"gte=0"`
+	Date       time.Time `form:"date" json:"date" time_format:"2006-01-02"` // Defaults to today
+}
+
+type QuotationFilterRequest struct {
+	Page       int      `form:"page" json:"page"`
+	Limit      int      `form:"limit" json:"limit"`
+	Search     string   `form:"search" json:"search"` // Quotation code, customer code, name or company
+	CustomerID uint     `form:"customer_id" json:"customer_id"`
+	Status     []string `form:"status" json:"status"` // draft, sent, accepted, rejected, expired; repeated or comma-separated
+}
+
+type QuotationItemRequest struct {
+	ProductID       uint          `json:"product_id" binding:"required"`
+	ColorCode       string        `json:"color_code"`
+	Quantity        float64       `json:"quantity" binding:"required,gt=0"`
+	UnitPrice       *money.Amount `json:"unit_price" binding:"omitempty,gte=0"` // Looked up from the price lists when empty
+	DiscountPercent float64       `json:"discount_percent" binding:"gte=0,lte=100"`
+	Notes           string        `json:"notes"`
+}
+
+type CreateQuotationRequest struct {
+	QuotationCode   string                 `json:"quotation_code"` // Generated when empty
+	CustomerID      uint                   `json:"customer_id" binding:"required"`
+	Currency        string                 `json:"currency"`    // Defaults to the customer's currency
+	IssueDate       *time.Time             `json:"issue_date"`  // Defaults to today
+	ValidUntil      *time.Time             `json:"valid_until"` // Defaults to 30 days after the issue date
+	DiscountPercent float64                `json:"discount_percent" binding:"gte=0,lte=100"`
+	Notes           string                 `json:"notes"`
+	Terms           string                 `json:"terms"`
+	Items           []QuotationItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type UpdateQuotationRequest struct {
+	IssueDate       *time.Time             `json:"issue_date"`
+	ValidUntil      *time.Time             `json:"valid_until"`
+	DiscountPercent *float64               `json:"discount_percent" binding:"omitempty,gte=0,lte=100"`
+	Notes           *string                `json:"notes"`
+	Terms           *string                `json:"terms"`
+	Items           []QuotationItemRequest `json:"items" binding:"omitempty,min=1,dive"` // Replaces every item
+}
+
+// AcceptQuotationRequest sets the details of the order the quotation is converted into
+type AcceptQuotationRequest struct {
+	DueDate *time.Time `json:"due_date"`
+	Notes   string     `json:"notes"`
+}
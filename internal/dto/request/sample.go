@@ -1,16 +1,41 @@
 package request
 
+import "time"
+
 type SampleFilterRequest struct {
-	Page       int     `form:"page" json:"page"`
-	Limit      int     `form:"limit" json:"limit"`
-	Search     string  `form:"search" json:"search"`
-	Category   string  `form:"category" json:"category"`
-	SampleType string  `form:"sample_type" json:"sample_type"`
-	WeightMin  float64 `form:"weight_min" json:"weight_min"`
-	WeightMax  float64 `form:"weight_max" json:"weight_max"`
-	WidthMin   float64 `form:"width_min" json:"width_min"`
-	WidthMax   float64 `form:"width_max" json:"width_max"`
-	Color      string  `form:"color" json:"color"`
+	Page     int    `form:"page" json:"page"`
+	Limit    int    `form:"limit" json:"limit"`
+	Search   string `form:"search" json:"search"`
+	Category string `form:"category" json:"category"` // Category ID, or comma-separated IDs
+
+	// Multi-select filters accept repeated params or comma-separated values
+	CategoryIDs          []uint   `form:"category_ids" json:"category_ids"`
+	IncludeSubcategories *bool    `form:"include_subcategories" json:"include_subcategories"` // Default true
+	SampleType           []string `form:"sample_type" json:"sample_type"`
+	Location             []string `form:"location" json:"location"`
+
+	WeightMin    float64 `form:"weight_min" json:"weight_min"`
+	WeightMax    float64 `form:"weight_max" json:"weight_max"`
+	WidthMin     float64 `form:"width_min" json:"width_min"`
+	WidthMax     float64 `form:"width_max" json:"width_max"`
+	RemainingMin *int    `form:"remaining_min" json:"remaining_min"`
+	RemainingMax *int    `form:"remaining_max" json:"remaining_max"`
+	Color        string  `form:"color" json:"color"`                 // Contains
+	FiberContent string  `form:"fiber_content" json:"fiber_content"` // Contains
+
+	CreatedFrom time.Time `form:"created_from" json:"created_from" time_format:"2006-01-02"`
+	CreatedTo   time.Time `form:"created_to" json:"created_to" time_format:"2006-01-02"` // Inclusive
+	UpdatedFrom time.Time `form:"updated_from" json:"updated_from" time_format:"2006-01-02"`
+	UpdatedTo   time.Time `form:"updated_to" json:"updated_to" time_format:"2006-01-02"` // Inclusive
+
+	// SortBy is a comma-separated list of fields, "-" prefix for descending (e.g. "-weight,sku")
+	SortBy    string `form:"sort_by" json:"sort_by"`
+	SortOrder string `form:"sort_order" json:"sort_order" binding:"omitempty,oneof=asc desc"`
+
+	// Facets lists the fields to return value counts for: sample_type, category, color, fiber_content, location
+	Facets []string `form:"facets" json:"facets"`
+
+	CursorParams
 }
 
 type CreateSampleRequest struct {
@@ -18,6 +43,7 @@ type CreateSampleRequest struct {
 	ProductNameID     uint    `json:"product_name_id" binding:"required"`
 	CategoryID        uint    `json:"category_id" binding:"required"`
 	Description       string  `json:"description"`
+	DescriptionEN     string  `json:"description_en"`
 	SampleType        string  `json:"sample_type"`
 	Weight            float64 `json:"weight"`
 	Width             float64 `json:"width"`
@@ -36,6 +62,7 @@ type UpdateSampleRequest struct {
 	ProductNameID     uint     `json:"product_name_id"`
 	CategoryID        uint     `json:"category_id"`
 	Description       *string  `json:"description"` // Pointer để phân biệt null với empty string
+	DescriptionEN     *string  `json:"description_en"`
 	SampleType        *string  `json:"sample_type"`
 	Weight            *float64 `json:"weight"`
 	Width             *float64 `json:"width"`
@@ -48,3 +75,26 @@ type UpdateSampleRequest struct {
 	SampleLocation    *string  `json:"sample_location"`
 	Barcode           *string  `json:"barcode"`
 }
+
+// SampleSearchRequest is a ranked full-text search over samples
+type SampleSearchRequest struct {
+	Query      string `form:"q" json:"q" binding:"required"`
+	Page       int    `form:"page" json:"page"`
+	Limit      int    `form:"limit" json:"limit"`
+	Category   string `form:"category" json:"category"`
+	SampleType string `form:"sample_type" json:"sample_type"`
+	Fuzzy      *bool  `form:"fuzzy" json:"fuzzy"` // Fall back to typo-tolerant matching when nothing matches exactly (default true)
+}
+
+// SampleImportRequest holds the multipart form fields sent alongside the import file
+type SampleImportRequest struct {
+	Mapping        string `form:"mapping"`         // JSON object: file column header -> sample field
+	DryRun         bool   `form:"dry_run"`         // Validate only, do not write
+	UpdateExisting bool   `form:"update_existing"` // Update samples whose SKU already exists
+}
+
+// SampleExportRequest exports the result set of the sample list filters
+type SampleExportRequest struct {
+	SampleFilterRequest
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+}
@@ -0,0 +1,18 @@
+// File: internal/dto/request/sample_dispatch.go
+// Tạo tại: internal/dto/request/sample_dispatch.go
+// Mục đích: Định nghĩa request DTO cho việc gửi mẫu cho khách hàng
+
+package request
+
+import "time"
+
+type DispatchSampleRequest struct {
+	CustomerID       uint       `json:"customer_id" binding:"required"`
+	DispatchDate     *time.Time `json:"dispatch_date"` // Defaults to now
+	DispatchQuantity float64    `json:"dispatch_quantity" binding:"required,gt=0"`
+	DispatchWeight   float64    `json:"dispatch_weight" binding:"gte=0"`
+	DispatchColor    string     `json:"dispatch_color"`
+	LotNumber        string     `json:"lot_number"`
+	TrackingNumber   string     `json:"tracking_number"`
+	DispatchNotes    string     `json:"dispatch_notes"`
+}
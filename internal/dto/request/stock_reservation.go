@@ -0,0 +1,26 @@
+// File: internal/dto/request/stock_reservation.go
+// Tạo tại: internal/dto/request/stock_reservation.go
+// Mục đích: Định nghĩa các request DTO cho giữ hàng theo đơn và tra cứu khả năng đáp ứng (ATP)
+
+package request
+
+import "time"
+
+type ReserveStockRequest struct {
+	Items        []ReserveStockItemRequest `json:"items" binding:"omitempty,dive"` // Empty reserves the outstanding length of every line
+	ExpiresAt    *time.Time                `json:"expires_at"`                     // Defaults to now plus the configured reservation TTL
+	AllowPartial bool                      `json:"allow_partial"`                  // Reserve what is free instead of failing when stock is short
+}
+
+type ReserveStockItemRequest struct {
+	OrderItemID   uint    `json:"order_item_id" binding:"required"`
+	Length        float64 `json:"length" binding:"omitempty,gt=0"` // Meters; defaults to the line's outstanding length
+	LotID         uint    `json:"lot_id"`                          // Only take rolls from this lot
+	FabricRollIDs []uint  `json:"fabric_roll_ids"`                 // Reserve exactly these rolls
+}
+
+type AvailableToPromiseRequest struct {
+	SKU       string    `form:"sku" json:"sku" binding:"required"`
+	ColorCode string    `form:"color_code" json:"color_code"`
+	Date      time.Time `form:"date" json:"date" time_format:"2006-01-02"` // Promise date; defaults to today
+}
@@ -0,0 +1,48 @@
+// File: internal/dto/request/subcontracting.go
+// Tạo tại: internal/dto/request/subcontracting.go
+// Mục đích: Định nghĩa các request DTO cho công nợ nhà gia công dệt/nhuộm: bảng kê, hóa đơn, thanh toán và tuổi nợ
+
+package request
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+type SubcontractorStatementRequest struct {
+	Type       string    `form:"type" json:"type" binding:"omitempty,oneof=weaving dyeing"` // Both when empty
+	Search     string    `form:"search" json:"search"`                                      // Subcontractor name or job code
+	From       time.Time `form:"from" json:"from" time_format:"2006-01-02"`                 // Jobs sent out from this day
+	To         time.Time `form:"to" json:"to" time_format:"2006-01-02"`                     // Inclusive
+	UnpaidOnly bool      `form:"unpaid_only" json:"unpaid_only"`
+	Currency   string    `form:"currency" json:"currency"` // Of the grand totals; defaults to the base currency
+}
+
+// SaveSubcontractInvoiceRequest records the subcontractor's invoice for a job. The total defaults to the
+// unit cost times the quantity, which defaults to the quantity returned. Payments are in the invoice currency.
+type SaveSubcontractInvoiceRequest struct {
+	InvoiceNumber string        `json:"invoice_number" binding:"required,max=100"`
+	InvoiceDate   *time.Time    `json:"invoice_date"` // Defaults to today
+	DueDate       *time.Time    `json:"due_date"`
+	UnitCost      *money.Amount `json:"unit_cost" binding:"omitempty,gte=0"` // Defaults to the agreed rate
+	Quantity      *float64      `json:"quantity" binding:"omitempty,gte=0"`
+	TotalCost     *money.Amount `json:"total_cost" binding:"omitempty,gte=0"`
+	Currency      string        `json:"currency"` // ISO 4217 code; defaults to the job's currency
+	Notes         string        `json:"notes"`
+}
+
+type RecordSubcontractorPaymentRequest struct {
+	Amount    money.Amount `json:"amount" binding:"required,gt=0"`
+	PaidAt    *time.Time   `json:"paid_at"` // Defaults to today
+	Method    string       `json:"method" binding:"omitempty,oneof=bank_transfer cash other"`
+	Reference string       `json:"reference" binding:"max=100"`
+	Notes     string       `json:"notes"`
+}
+
+type PayablesAgingRequest struct {
+	Type     string    `form:"type" json:"type" binding:"omitempty,oneof=weaving dyeing"`
+	AsOf     time.Time `form:"as_of" json:"as_of" time_format:"2006-01-02"` // Defaults to today
+	Search   string    `form:"search" json:"search"`                        // Subcontractor name
+	Currency string    `form:"currency" json:"currency"`                    // Of the totals; defaults to the base currency
+}
@@ -8,6 +8,10 @@ type UserFilterRequest struct {
 	Page   int    `form:"page" json:"page"`
 	Limit  int    `form:"limit" json:"limit"`
 	Search string `form:"search" json:"search"`
+
+	// Cursor mode only: id, username, email, account_status, created_at; "-" prefix for descending
+	SortBy string `form:"sort_by" json:"sort_by"`
+	CursorParams
 }
 
 type CreateUserRequest struct {
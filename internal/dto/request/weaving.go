@@ -0,0 +1,136 @@
+// File: internal/dto/request/weaving.go
+// Tạo tại: internal/dto/request/weaving.go
+// Mục đích: Định nghĩa các request DTO cho xưởng dệt, ca, công nhân, lệnh dệt và công đoạn dệt
+
+package request
+
+import "time"
+
+type WeavingFacilityFilterRequest struct {
+	Search       string `form:"search" json:"search"` // Name or location
+	FacilityType string `form:"facility_type" json:"facility_type" binding:"omitempty,oneof=internal external"`
+}
+
+type CreateWeavingFacilityRequest struct {
+	FacilityName string `json:"facility_name" binding:"required"`
+	Location     string `json:"location" binding:"required"`
+	ContactInfo  string `json:"contact_info"`
+	FacilityType string `json:"facility_type" binding:"omitempty,oneof=internal external"` // Defaults to internal
+}
+
+type UpdateWeavingFacilityRequest struct {
+	FacilityName *string `json:"facility_name"`
+	Location     *string `json:"location"`
+	ContactInfo  *string `json:"contact_info"`
+	FacilityType *string `json:"facility_type" binding:"omitempty,oneof=internal external"`
+}
+
+// CreateWeavingShiftRequest takes times as HH:MM or HH:MM:SS; a shift may end after midnight
+type CreateWeavingShiftRequest struct {
+	ShiftName   string `json:"shift_name" binding:"required"`
+	StartTime   string `json:"start_time" binding:"required"`
+	EndTime     string `json:"end_time" binding:"required"`
+	ShiftLeader string `json:"shift_leader"`
+}
+
+type UpdateWeavingShiftRequest struct {
+	ShiftName   *string `json:"shift_name"`
+	StartTime   *string `json:"start_time"`
+	EndTime     *string `json:"end_time"`
+	ShiftLeader *string `json:"shift_leader"`
+}
+
+type WeavingStaffFilterRequest struct {
+	Page       int    `form:"page" json:"page"`
+	Limit      int    `form:"limit" json:"limit"`
+	FacilityID uint   `form:"facility_id" json:"facility_id"`
+	ShiftID    uint   `form:"shift_id" json:"shift_id"`
+	Search     string `form:"search" json:"search"` // Name or position
+}
+
+type CreateWeavingStaffRequest struct {
+	WeavingFacilityID uint   `json:"weaving_facility_id" binding:"required"`
+	StaffName         string `json:"staff_name" binding:"required"`
+	Position          string `json:"position" binding:"required"`
+	ContactInfo       string `json:"contact_info"`
+	ShiftID           *uint  `json:"shift_id"` // A shift of the facility
+}
+
+type UpdateWeavingStaffRequest struct {
+	WeavingFacilityID *uint   `json:"weaving_facility_id"`
+	StaffName         *string `json:"staff_name"`
+	Position          *string `json:"position"`
+	ContactInfo       *string `json:"contact_info"`
+	ShiftID           *uint   `json:"shift_id"` // 0 clears the shift
+}
+
+type WeavingOrderFilterRequest struct {
+	Page          int      `form:"page" json:"page"`
+	Limit         int      `form:"limit" json:"limit"`
+	Search        string   `form:"search" json:"search"` // Weaving order code or sales order code
+	Status        []string `form:"status" json:"status"` // pending, in_progress, completed, sent_to_dyeing, cancelled; repeated or comma-separated
+	FacilityID    uint     `form:"facility_id" json:"facility_id"`
+	LinkedOrderID uint     `form:"linked_order_id" json:"linked_order_id"`
+	ProductID     uint     `form:"product_id" json:"product_id"`
+}
+
+type CreateWeavingOrderRequest struct {
+	OrderCode         string     `json:"order_code"` // Generated when empty
+	WeavingFacilityID uint       `json:"weaving_facility_id" binding:"required"`
+	LinkedOrderID     uint       `json:"linked_order_id" binding:"required"`
+	YarnBoxID         uint       `json:"yarn_box_id" binding:"required"`
+	ProductID         uint       `json:"product_id" binding:"required"`    // A product on the sales order
+	PlannedQuantity   float64    `json:"planned_quantity" binding:"gte=0"` // Meters; defaults to what the sales order still needs planned
+	ColorCode         string     `json:"color_code"`                       // Defaults to the color of the sales order line
+	EndDate           *time.Time `json:"end_date"`                         // Planned finish, counted as incoming supply until then
+	Notes             string     `json:"notes"`
+}
+
+type UpdateWeavingOrderRequest struct {
+	WeavingFacilityID *uint      `json:"weaving_facility_id"`
+	YarnBoxID         *uint      `json:"yarn_box_id"`
+	PlannedQuantity   *float64   `json:"planned_quantity" binding:"omitempty,gt=0"`
+	ColorCode         *string    `json:"color_code"`
+	EndDate           *time.Time `json:"end_date"` // Planned finish
+	Notes             *string    `json:"notes"`
+}
+
+type ChangeWeavingOrderStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=in_progress completed sent_to_dyeing cancelled"`
+}
+
+type WeavingOperationFilterRequest struct {
+	Page           int       `form:"page" json:"page"`
+	Limit          int       `form:"limit" json:"limit"`
+	WeavingOrderID uint      `form:"weaving_order_id" json:"weaving_order_id"`
+	FacilityID     uint      `form:"facility_id" json:"facility_id"`
+	StaffID        uint      `form:"staff_id" json:"staff_id"`
+	ShiftID        uint      `form:"shift_id" json:"shift_id"`
+	MachineID      uint      `form:"machine_id" json:"machine_id"`
+	From           time.Time `form:"from" json:"from" time_format:"2006-01-02"`
+	To             time.Time `form:"to" json:"to" time_format:"2006-01-02"` // Inclusive
+}
+
+type CreateWeavingOperationRequest struct {
+	WeavingStaffID   uint       `json:"weaving_staff_id" binding:"required"`
+	WeavingShiftID   *uint      `json:"weaving_shift_id"` // Defaults to the staff's shift
+	YarnBoxID        uint       `json:"yarn_box_id"`      // Defaults to the weaving order's box
+	WeavingMachineID *uint      `json:"weaving_machine_id"`
+	CamLayout        string     `json:"cam_layout"`
+	StartTime        *time.Time `json:"start_time"`
+	EndTime          *time.Time `json:"end_time"`
+	Quantity         float64    `json:"quantity" binding:"gte=0"` // Meters woven
+	Notes            string     `json:"notes"`
+}
+
+type UpdateWeavingOperationRequest struct {
+	WeavingStaffID   *uint      `json:"weaving_staff_id"`
+	WeavingShiftID   *uint      `json:"weaving_shift_id"` // 0 clears the shift
+	YarnBoxID        *uint      `json:"yarn_box_id"`
+	WeavingMachineID *uint      `json:"weaving_machine_id"` // 0 clears the machine
+	CamLayout        *string    `json:"cam_layout"`
+	StartTime        *time.Time `json:"start_time"`
+	EndTime          *time.Time `json:"end_time"`
+	Quantity         *float64   `json:"quantity" binding:"omitempty,gte=0"`
+	Notes            *string    `json:"notes"`
+}
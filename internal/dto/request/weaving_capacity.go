@@ -0,0 +1,61 @@
+// File: internal/dto/request/weaving_capacity.go
+// Tạo tại: internal/dto/request/weaving_capacity.go
+// Mục đích: Định nghĩa các request DTO cho máy dệt, lịch phân ca công nhân và kế hoạch công suất dệt
+
+package request
+
+import "time"
+
+type WeavingMachineFilterRequest struct {
+	FacilityID uint     `form:"facility_id" json:"facility_id"`
+	LoomType   string   `form:"loom_type" json:"loom_type" binding:"omitempty,oneof=air_jet rapier water_jet projectile shuttle"`
+	Status     []string `form:"status" json:"status"` // active, maintenance, retired; repeated or comma-separated
+	Search     string   `form:"search" json:"search"` // Machine code or notes
+}
+
+type CreateWeavingMachineRequest struct {
+	WeavingFacilityID uint    `json:"weaving_facility_id" binding:"required"`
+	MachineCode       string  `json:"machine_code" binding:"required"`
+	LoomType          string  `json:"loom_type" binding:"omitempty,oneof=air_jet rapier water_jet projectile shuttle"` // Defaults to rapier
+	MaxWidth          float64 `json:"max_width" binding:"gte=0"`                                                       // Centimeters
+	SpeedRPM          int     `json:"speed_rpm" binding:"gte=0"`                                                       // Picks per minute
+	OutputPerHour     float64 `json:"output_per_hour" binding:"gt=0"`                                                  // Meters per running hour
+	Notes             string  `json:"notes"`
+}
+
+type UpdateWeavingMachineRequest struct {
+	MachineCode   *string  `json:"machine_code"`
+	LoomType      *string  `json:"loom_type" binding:"omitempty,oneof=air_jet rapier water_jet projectile shuttle"`
+	MaxWidth      *float64 `json:"max_width" binding:"omitempty,gte=0"`
+	SpeedRPM      *int     `json:"speed_rpm" binding:"omitempty,gte=0"`
+	OutputPerHour *float64 `json:"output_per_hour" binding:"omitempty,gt=0"`
+	Status        *string  `json:"status" binding:"omitempty,oneof=active maintenance retired"`
+	Notes         *string  `json:"notes"`
+}
+
+type WeavingRosterFilterRequest struct {
+	StaffID   uint      `form:"staff_id" json:"staff_id"`
+	ShiftID   uint      `form:"shift_id" json:"shift_id"`
+	MachineID uint      `form:"machine_id" json:"machine_id"`
+	From      time.Time `form:"from" json:"from" time_format:"2006-01-02"` // Defaults to today
+	To        time.Time `form:"to" json:"to" time_format:"2006-01-02"`     // Inclusive; defaults to a week after from
+}
+
+// CreateWeavingRostersRequest puts the staff on a shift for every day from From to To
+type CreateWeavingRostersRequest struct {
+	WeavingShiftID uint                 `json:"weaving_shift_id" binding:"required"`
+	From           *time.Time           `json:"from" binding:"required"`
+	To             *time.Time           `json:"to"` // Inclusive; defaults to from
+	Staff          []WeavingRosterEntry `json:"staff" binding:"required,min=1,dive"`
+	Notes          string               `json:"notes"`
+}
+
+type WeavingRosterEntry struct {
+	WeavingStaffID   uint  `json:"weaving_staff_id" binding:"required"`
+	WeavingMachineID *uint `json:"weaving_machine_id"`
+}
+
+type WeavingCapacityPlanRequest struct {
+	From time.Time `form:"from" json:"from" time_format:"2006-01-02"`         // Defaults to today
+	Days int       `form:"days" json:"days" binding:"omitempty,min=1,max=90"` // Defaults to 14
+}
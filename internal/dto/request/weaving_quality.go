@@ -0,0 +1,64 @@
+// File: internal/dto/request/weaving_quality.go
+// Tạo tại: internal/dto/request/weaving_quality.go
+// Mục đích: Định nghĩa các request DTO cho danh mục lỗi vải và phiếu kiểm tra chất lượng dệt
+
+package request
+
+import "time"
+
+type DefectTypeFilterRequest struct {
+	Search     string `form:"search" json:"search"` // Code or name
+	Category   string `form:"category" json:"category" binding:"omitempty,oneof=yarn warp weft fabric other"`
+	ActiveOnly bool   `form:"active_only" json:"active_only"`
+}
+
+type CreateDefectTypeRequest struct {
+	Code        string `json:"code" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Category    string `json:"category" binding:"omitempty,oneof=yarn warp weft fabric other"` // Defaults to other
+	Description string `json:"description"`
+	FixedPoints *int   `json:"fixed_points" binding:"omitempty,min=1,max=4"` // Points regardless of length, e.g. 4 for holes
+}
+
+type UpdateDefectTypeRequest struct {
+	Name        *string `json:"name"`
+	Category    *string `json:"category" binding:"omitempty,oneof=yarn warp weft fabric other"`
+	Description *string `json:"description"`
+	FixedPoints *int    `json:"fixed_points" binding:"omitempty,min=0,max=4"` // 0 scores by length again
+	IsActive    *bool   `json:"is_active"`
+}
+
+type WeavingInspectionFilterRequest struct {
+	Page           int       `form:"page" json:"page"`
+	Limit          int       `form:"limit" json:"limit"`
+	WeavingOrderID uint      `form:"weaving_order_id" json:"weaving_order_id"`
+	OperationID    uint      `form:"operation_id" json:"operation_id"`
+	Status         string    `form:"status" json:"status" binding:"omitempty,oneof=passed failed"`
+	Uncorrected    bool      `form:"uncorrected" json:"uncorrected"` // Failed inspections without corrective action
+	From           time.Time `form:"from" json:"from" time_format:"2006-01-02"`
+	To             time.Time `form:"to" json:"to" time_format:"2006-01-02"` // Inclusive
+}
+
+// CreateWeavingInspectionRequest scores the operation's fabric with the 4-point system
+type CreateWeavingInspectionRequest struct {
+	QualityInspector string                    `json:"quality_inspector" binding:"required"`
+	QualityCheckTime *time.Time                `json:"quality_check_time"`               // Defaults to now
+	InspectedLength  float64                   `json:"inspected_length" binding:"gte=0"` // Meters; defaults to the operation's quantity
+	FabricWidth      float64                   `json:"fabric_width" binding:"gte=0"`     // Centimeters; defaults to the product's width
+	MaxPoints        float64                   `json:"max_points" binding:"gte=0"`       // Points per 100 square yards that pass; defaults to 40
+	Defects          []WeavingInspectionDefect `json:"defects" binding:"omitempty,dive"`
+	Notes            string                    `json:"notes"`
+}
+
+// WeavingInspectionDefect takes 1 to 4 points by its length unless points are given or its type has fixed points
+type WeavingInspectionDefect struct {
+	DefectTypeID uint     `json:"defect_type_id" binding:"required"`
+	Position     *float64 `json:"position" binding:"omitempty,gte=0"` // Meter mark; at most 4 points count per linear yard
+	Length       float64  `json:"length" binding:"gte=0"`             // Inches
+	Points       int      `json:"points" binding:"omitempty,min=1,max=4"`
+	Notes        string   `json:"notes"`
+}
+
+type RecordCorrectiveActionRequest struct {
+	CorrectiveAction string `json:"corrective_action" binding:"required"`
+}
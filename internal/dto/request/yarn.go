@@ -0,0 +1,72 @@
+// File: internal/dto/request/yarn.go
+// Tạo tại: internal/dto/request/yarn.go
+// Mục đích: Định nghĩa các request DTO cho thùng sợi, nhập/xuất sợi, định mức sợi và phân bổ sợi cho đơn
+
+package request
+
+import "time"
+
+type YarnBoxFilterRequest struct {
+	Page     int      `form:"page" json:"page"`
+	Limit    int      `form:"limit" json:"limit"`
+	Search   string   `form:"search" json:"search"` // Box code or yarn type
+	YarnType string   `form:"yarn_type" json:"yarn_type"`
+	Location string   `form:"location" json:"location"` // Warehouse location prefix
+	Status   []string `form:"status" json:"status"`     // available, allocated, depleted; repeated or comma-separated
+}
+
+type CreateYarnBoxRequest struct {
+	BoxCode           string  `json:"box_code"` // Generated when empty
+	YarnType          string  `json:"yarn_type" binding:"required"`
+	ConeQuantity      int     `json:"cone_quantity" binding:"gte=0"` // Opening stock, received as the first IN
+	TotalWeight       float64 `json:"total_weight" binding:"gte=0"`
+	WarehouseLocation string  `json:"warehouse_location"`
+	Notes             string  `json:"notes"`
+}
+
+type UpdateYarnBoxRequest struct {
+	YarnType          *string `json:"yarn_type"`
+	WarehouseLocation *string `json:"warehouse_location"`
+}
+
+type ChangeYarnBoxStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=available allocated"`
+}
+
+// YarnMovementRequest receives yarn into or issues yarn from a box
+type YarnMovementRequest struct {
+	Quantity          int        `json:"quantity" binding:"gte=0"` // Cones
+	Weight            float64    `json:"weight" binding:"gte=0"`   // Kilograms
+	TransactionDate   *time.Time `json:"transaction_date"`         // Defaults to now
+	WarehouseLocation string     `json:"warehouse_location"`       // Moves the box when set
+	Notes             string     `json:"notes"`
+}
+
+type YarnTransactionFilterRequest struct {
+	Page            int       `form:"page" json:"page"`
+	Limit           int       `form:"limit" json:"limit"`
+	YarnBoxID       uint      `form:"yarn_box_id" json:"yarn_box_id"`
+	TransactionType string    `form:"transaction_type" json:"transaction_type" binding:"omitempty,oneof=IN OUT"`
+	From            time.Time `form:"from" json:"from" time_format:"2006-01-02"`
+	To              time.Time `form:"to" json:"to" time_format:"2006-01-02"` // Inclusive
+}
+
+type YarnComponentRequest struct {
+	YarnType      string  `json:"yarn_type" binding:"required"`
+	GramsPerMeter float64 `json:"grams_per_meter" binding:"required,gt=0"`
+	Notes         string  `json:"notes"`
+}
+
+// SetYarnComponentsRequest replaces a product's bill of materials; an empty list clears it
+type SetYarnComponentsRequest struct {
+	Components []YarnComponentRequest `json:"components" binding:"dive"`
+}
+
+type AllocateYarnRequest struct {
+	YarnTypes    []string `json:"yarn_types"`    // Only these yarn types; all outstanding types when empty
+	AllowPartial bool     `json:"allow_partial"` // Allocate what is free when a yarn type falls short
+}
+
+type IssueYarnAllocationRequest struct {
+	Notes string `json:"notes"`
+}
@@ -7,12 +7,16 @@ package response
 import "time"
 
 type CategoryResponse struct {
-	ID               uint      `json:"id"`
-	CategoryName     string    `json:"category_name"`
-	ParentCategoryID *uint     `json:"parent_category_id"`
-	Description      string    `json:"description"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID                 uint      `json:"id"`
+	CategoryName       string    `json:"category_name"`
+	CategoryNameEN     string    `json:"category_name_en"`
+	ParentCategoryID   *uint     `json:"parent_category_id"`
+	Description        string    `json:"description"`
+	DescriptionEN      string    `json:"description_en"`
+	DisplayName        string    `json:"display_name"`        // Name in the request language
+	DisplayDescription string    `json:"display_description"` // Description in the request language
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
 }
 
 type CategoriesResponse struct {
@@ -21,12 +25,69 @@ type CategoriesResponse struct {
 }
 
 type CategoryDetailResponse struct {
-	ID               uint      `json:"id"`
-	CategoryName     string    `json:"category_name"`
-	ParentCategoryID *uint     `json:"parent_category_id"`
-	Description      string    `json:"description"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID                 uint      `json:"id"`
+	CategoryName       string    `json:"category_name"`
+	CategoryNameEN     string    `json:"category_name_en"`
+	ParentCategoryID   *uint     `json:"parent_category_id"`
+	Description        string    `json:"description"`
+	DescriptionEN      string    `json:"description_en"`
+	DisplayName        string    `json:"display_name"`        // Name in the request language
+	DisplayDescription string    `json:"display_description"` // Description in the request language
+	Breadcrumb         string    `json:"breadcrumb,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// CategoryTreeNode is a category with its sub-categories, breadcrumb path and item counts.
+// Product/sample counts are direct; total_* include every descendant.
+type CategoryTreeNode struct {
+	ID                 uint               `json:"id"`
+	CategoryName       string             `json:"category_name"`
+	CategoryNameEN     string             `json:"category_name_en"`
+	ParentCategoryID   *uint              `json:"parent_category_id"`
+	Description        string             `json:"description"`
+	DescriptionEN      string             `json:"description_en"`
+	DisplayName        string             `json:"display_name"`
+	DisplayDescription string             `json:"display_description"`
+	Depth              int                `json:"depth"`
+	Path               []CategoryPathItem `json:"path"`
+	Breadcrumb         string             `json:"breadcrumb"`
+	ProductCount       int64              `json:"product_count"`
+	SampleCount        int64              `json:"sample_count"`
+	TotalProductCount  int64              `json:"total_product_count"`
+	TotalSampleCount   int64              `json:"total_sample_count"`
+	Children           []CategoryTreeNode `json:"children"`
+}
+
+type CategoryPathItem struct {
+	ID             uint   `json:"id"`
+	CategoryName   string `json:"category_name"`
+	CategoryNameEN string `json:"category_name_en"`
+}
+
+type CategoryTreeResponse struct {
+	Tree  []CategoryTreeNode `json:"tree"`
+	Total int                `json:"total"`
+}
+
+// CategoryDeleteImpact counts the rows a delete strategy changes
+type CategoryDeleteImpact struct {
+	Categories      int64 `json:"categories"`       // Categories deleted
+	ChildCategories int64 `json:"child_categories"` // Child categories reassigned
+	Products        int64 `json:"products"`         // Products reassigned or deleted
+	Samples         int64 `json:"samples"`          // Samples reassigned or deleted
+}
+
+type CategoryDeleteResponse struct {
+	CategoryID       uint                 `json:"category_id"`
+	Strategy         string               `json:"strategy"`
+	TargetCategoryID *uint                `json:"target_category_id,omitempty"`
+	DryRun           bool                 `json:"dry_run"`
+	Deleted          bool                 `json:"deleted"`
+	Affected         CategoryDeleteImpact `json:"affected"` // Rows changed (or that would change on dry run)
+
+	// What each strategy would affect, computed before deleting
+	Strategies map[string]CategoryDeleteImpact `json:"strategies"`
 }
 
 type SuccessResponse struct {
@@ -0,0 +1,76 @@
+// File: internal/dto/response/customer.go
+// Tạo tại: internal/dto/response/customer.go
+// Mục đích: Định nghĩa các response DTO cho Customer API
+
+package response
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+type CustomerResponse struct {
+	ID            uint      `json:"id"`
+	CustomerCode  string    `json:"customer_code"`
+	Name          string    `json:"name"`
+	Email         string    `json:"email"`
+	Phone         string    `json:"phone"`
+	Address       string    `json:"address"`
+	CompanyName   string    `json:"company_name"`
+	TaxID         string    `json:"tax_id"`
+	AccountStatus string    `json:"account_status"`
+	PriceTier     string    `json:"price_tier"`
+	Currency      string    `json:"currency"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// CustomerDetailResponse is a customer with a summary and the latest linked orders, sample dispatches and lap dip tests
+type CustomerDetailResponse struct {
+	CustomerResponse
+	Summary                CustomerSummary                 `json:"summary"`
+	RecentOrders           []CustomerOrderSummary          `json:"recent_orders"`
+	RecentSampleDispatches []CustomerSampleDispatchSummary `json:"recent_sample_dispatches"`
+	RecentLapDipTests      []CustomerLapDipSummary         `json:"recent_lap_dip_tests"`
+}
+
+type CustomerSummary struct {
+	OrderCount          int64                   `json:"order_count"`
+	OrderTotals         map[string]money.Amount `json:"order_totals"` // Per currency
+	SampleDispatchCount int64                   `json:"sample_dispatch_count"`
+	LapDipTestCount     int64                   `json:"lap_dip_test_count"`
+}
+
+type CustomerOrderSummary struct {
+	ID          uint         `json:"id"`
+	OrderCode   string       `json:"order_code"`
+	Status      string       `json:"status"`
+	TotalAmount money.Amount `json:"total_amount"`
+	Currency    string       `json:"currency"`
+	DueDate     *time.Time   `json:"due_date"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+type CustomerSampleDispatchSummary struct {
+	ID               uint      `json:"id"`
+	SampleProductID  uint      `json:"sample_product_id"`
+	SampleSKU        string    `json:"sample_sku"`
+	DispatchDate     time.Time `json:"dispatch_date"`
+	DispatchQuantity float64   `json:"dispatch_quantity"`
+	DispatchWeight   float64   `json:"dispatch_weight"`
+	DispatchColor    string    `json:"dispatch_color"`
+	LotNumber        string    `json:"lot_number"`
+	TrackingNumber   string    `json:"tracking_number"`
+}
+
+type CustomerLapDipSummary struct {
+	ID             uint       `json:"id"`
+	LDCode         string     `json:"ld_code"`
+	ColorName      string     `json:"color_name"`
+	ColorCode      string     `json:"color_code"`
+	Status         string     `json:"status"`
+	SelectedLDCode string     `json:"selected_ld_code"`
+	TestStartDate  time.Time  `json:"test_start_date"`
+	TestEndDate    *time.Time `json:"test_end_date"`
+}
@@ -0,0 +1,17 @@
+// File: internal/dto/response/customer_activity.go
+// Tạo tại: internal/dto/response/customer_activity.go
+// Mục đích: Định nghĩa các response DTO cho timeline hoạt động khách hàng
+
+package response
+
+import "time"
+
+type CustomerActivityResponse struct {
+	ID           uint        `json:"id"`
+	CustomerID   uint        `json:"customer_id"`
+	ActivityType string      `json:"activity_type"`
+	Details      interface{} `json:"details"` // Event fields, or the stored text when it is not JSON
+	ActivityDate time.Time   `json:"activity_date"`
+	IPAddress    string      `json:"ip_address"`
+	DeviceInfo   string      `json:"device_info"`
+}
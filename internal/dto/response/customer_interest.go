@@ -0,0 +1,61 @@
+// File: internal/dto/response/customer_interest.go
+// Tạo tại: internal/dto/response/customer_interest.go
+// Mục đích: Định nghĩa các response DTO cho danh sách lưu, lịch sử tìm kiếm và báo cáo quan tâm của khách hàng
+
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type SavedItemResponse struct {
+	ID              uint      `json:"id"`
+	CustomerID      uint      `json:"customer_id"`
+	ItemType        string    `json:"item_type"` // product or sample
+	ProductID       *uint     `json:"product_id,omitempty"`
+	SampleProductID *uint     `json:"sample_product_id,omitempty"`
+	SKU             string    `json:"sku"`
+	ProductNameVI   string    `json:"product_name_vi"`
+	ProductNameEN   string    `json:"product_name_en"`
+	DisplayName     string    `json:"display_name"` // Name in the request language
+	Color           string    `json:"color"`
+	Notes           string    `json:"notes"`
+	SavedAt         time.Time `json:"saved_at"`
+}
+
+type SearchHistoryResponse struct {
+	ID              uint            `json:"id"`
+	CustomerID      uint            `json:"customer_id"`
+	SearchQuery     string          `json:"search_query"`
+	NormalizedQuery string          `json:"normalized_query"`
+	SearchDate      time.Time       `json:"search_date"`
+	ResultCount     *int            `json:"result_count"`
+	SearchResults   json.RawMessage `json:"search_results,omitempty"`
+}
+
+// CustomerInterestReportResponse summarizes what customers searched for and saved in a period
+type CustomerInterestReportResponse struct {
+	DateFrom          time.Time        `json:"date_from"`
+	DateTo            time.Time        `json:"date_to"`
+	TopSearchTerms    []SearchTermStat `json:"top_search_terms"`
+	MostSavedProducts []SavedItemStat  `json:"most_saved_products"`
+	MostSavedSamples  []SavedItemStat  `json:"most_saved_samples"`
+}
+
+type SearchTermStat struct {
+	Term            string `json:"term"`
+	SearchCount     int64  `json:"search_count"`
+	CustomerCount   int64  `json:"customer_count"`
+	ZeroResultCount int64  `json:"zero_result_count"` // Searches that found nothing: demand the collection does not cover
+}
+
+type SavedItemStat struct {
+	ItemID        uint   `json:"item_id"`
+	SKU           string `json:"sku"`
+	ProductNameVI string `json:"product_name_vi"`
+	ProductNameEN string `json:"product_name_en"`
+	DisplayName   string `json:"display_name"` // Name in the request language
+	SaveCount     int64  `json:"save_count"`
+	CustomerCount int64  `json:"customer_count"`
+}
@@ -0,0 +1,32 @@
+// File: internal/dto/response/customer_merge.go
+// Tạo tại: internal/dto/response/customer_merge.go
+// Mục đích: Định nghĩa các response DTO cho phát hiện và gộp khách hàng trùng lặp
+
+package response
+
+type DuplicateCustomersResponse struct {
+	Pairs []DuplicatePair `json:"pairs"`
+}
+
+// DuplicatePair is two customers that look like the same company, with a 0-100 score
+type DuplicatePair struct {
+	Customer  CustomerResponse  `json:"customer"`
+	Duplicate CustomerResponse  `json:"duplicate"`
+	Score     int               `json:"score"`
+	Reasons   []DuplicateReason `json:"reasons"`
+}
+
+type DuplicateReason struct {
+	Field      string  `json:"field"` // tax_id, email, phone or company_name
+	Similarity float64 `json:"similarity"`
+}
+
+type CustomerMergeResponse struct {
+	MergeID            uint             `json:"merge_id,omitempty"`
+	DryRun             bool             `json:"dry_run"`
+	Survivor           CustomerResponse `json:"survivor"`
+	MergedCustomerID   uint             `json:"merged_customer_id"`
+	MergedCustomerCode string           `json:"merged_customer_code"`
+	MovedRecords       map[string]int64 `json:"moved_records"` // Rows re-pointed per table, or that would be on a dry run
+	FilledFields       []string         `json:"filled_fields"`
+}
@@ -0,0 +1,81 @@
+// File: internal/dto/response/dyeing.go
+// Tạo tại: internal/dto/response/dyeing.go
+// Mục đích: Định nghĩa các response DTO cho nhà nhuộm, lệnh nhuộm và lô nhuộm trả về
+
+package response
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+type DyeingSubcontractorResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	ContactInfo string    `json:"contact_info"`
+	Currency    string    `json:"currency"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type DyeingJobResponse struct {
+	ID                    uint                `json:"id"`
+	JobCode               string              `json:"job_code"`
+	DyeingSubcontractorID uint                `json:"dyeing_subcontractor_id"`
+	SubcontractorName     string              `json:"subcontractor_name"`
+	OrderID               *uint               `json:"order_id"`
+	OrderCode             string              `json:"order_code"`
+	WeavingOrderID        *uint               `json:"weaving_order_id"`
+	WeavingOrderCode      string              `json:"weaving_order_code"`
+	ProductID             *uint               `json:"product_id"`
+	SKU                   string              `json:"sku"`
+	TargetColor           string              `json:"target_color"`
+	ColorCode             string              `json:"color_code"`
+	LDTestID              *uint               `json:"ld_test_id"`
+	LapDipReference       string              `json:"lap_dip_reference"`
+	GreigeLength          float64             `json:"greige_length"`
+	GreigeWeight          float64             `json:"greige_weight"`
+	GreigeRolls           int                 `json:"greige_rolls"`
+	SentDate              time.Time           `json:"sent_date"`
+	ExpectedReturnDate    *time.Time          `json:"expected_return_date"`
+	ReturnedDate          *time.Time          `json:"returned_date"`
+	Overdue               bool                `json:"overdue"` // Still out after the expected return date
+	CostPerKg             money.Amount        `json:"cost_per_kg"`
+	Currency              string              `json:"currency"`
+	Status                string              `json:"status"`
+	ReturnedRolls         int                 `json:"returned_rolls"`  // In lots not rejected
+	ReturnedWeight        float64             `json:"returned_weight"` // In lots not rejected
+	AcceptedWeight        float64             `json:"accepted_weight"`
+	PendingLots           int                 `json:"pending_lots"` // Returned lots not yet accepted or rejected
+	SpecialRequirements   string              `json:"special_requirements"`
+	Notes                 string              `json:"notes"`
+	CreatedBy             *uint               `json:"created_by"`
+	CreatedAt             time.Time           `json:"created_at"`
+	UpdatedAt             time.Time           `json:"updated_at"`
+	Lots                  []DyeingLotResponse `json:"lots"`
+}
+
+type DyeingLotResponse struct {
+	ID                   uint       `json:"id"`
+	DyeingJobID          *uint      `json:"dyeing_job_id"`
+	LotCode              string     `json:"lot_code"`
+	ColorCode            string     `json:"color_code"`
+	Quantity             int        `json:"quantity"`
+	Weight               float64    `json:"weight"`
+	ReturnedDate         *time.Time `json:"returned_date"`
+	Status               string     `json:"status"`
+	ShrinkageLength      *float64   `json:"shrinkage_length"`
+	ShrinkageWidth       *float64   `json:"shrinkage_width"`
+	ColorFastnessWashing *float64   `json:"color_fastness_washing"`
+	ColorFastnessRubbing *float64   `json:"color_fastness_rubbing"`
+	ColorFastnessLight   *float64   `json:"color_fastness_light"`
+	TestedBy             *uint      `json:"tested_by"`
+	TestedAt             *time.Time `json:"tested_at"`
+	DecidedBy            *uint      `json:"decided_by"`
+	DecidedAt            *time.Time `json:"decided_at"`
+	LotID                *uint      `json:"lot_id"` // Warehouse lot made on acceptance
+	QualityIssues        string     `json:"quality_issues"`
+	Notes                string     `json:"notes"`
+	CreatedAt            time.Time  `json:"created_at"`
+}
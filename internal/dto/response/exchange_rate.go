@@ -0,0 +1,49 @@
+// File: internal/dto/response/exchange_rate.go
+// Tạo tại: internal/dto/response/exchange_rate.go
+// Mục đích: Định nghĩa các response DTO cho tỷ giá, quy đổi tiền tệ và báo cáo doanh số theo tiền tệ gốc
+
+package response
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+type ExchangeRateResponse struct {
+	ID            uint      `json:"id"`
+	FromCurrency  string    `json:"from_currency"`
+	ToCurrency    string    `json:"to_currency"`
+	Rate          float64   `json:"rate"`
+	EffectiveDate time.Time `json:"effective_date"`
+	Source        string    `json:"source"`
+	Notes         string    `json:"notes"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type ConversionResponse struct {
+	Amount    money.Amount `json:"amount"`
+	From      string       `json:"from"`
+	To        string       `json:"to"`
+	Rate      float64      `json:"rate"`
+	Converted money.Amount `json:"converted"`
+	Date      time.Time    `json:"date"`
+}
+
+// SalesSummaryResponse totals the orders of a period, each converted at the rate of the day it was placed
+type SalesSummaryResponse struct {
+	Currency   string               `json:"currency"`
+	From       time.Time            `json:"from"`
+	To         time.Time            `json:"to"`
+	OrderCount int64                `json:"order_count"`
+	Total      money.Amount         `json:"total"`
+	ByCurrency []SalesCurrencyTotal `json:"by_currency"`
+}
+
+type SalesCurrencyTotal struct {
+	Currency   string       `json:"currency"`
+	OrderCount int64        `json:"order_count"`
+	Total      money.Amount `json:"total"`     // In this currency
+	Converted  money.Amount `json:"converted"` // In the summary currency
+}
@@ -0,0 +1,26 @@
+// File: internal/dto/response/image.go
+// Tạo tại: internal/dto/response/image.go
+// Mục đích: Response DTO cho hình ảnh sample/product
+
+package response
+
+import "time"
+
+type ImageResponse struct {
+	ID           uint      `json:"id"`
+	ImageURL     string    `json:"image_url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	FileSize     int64     `json:"file_size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	IsPrimary    bool      `json:"is_primary"`
+	SortOrder    int       `json:"sort_order"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ImagesResponse struct {
+	Images []ImageResponse `json:"images"`
+	Total  int             `json:"total"`
+}
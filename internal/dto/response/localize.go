@@ -0,0 +1,157 @@
+// File: internal/dto/response/localize.go
+// Tạo tại: internal/dto/response/localize.go
+// Mục đích: Điền các trường display_* theo ngôn ngữ của request (vi/en) cho response song ngữ
+
+package response
+
+import (
+	"strings"
+
+	"github.com/godiidev/appsynex/pkg/i18n"
+)
+
+// Localizable responses fill their display fields for the request language
+type Localizable interface {
+	Localize(lang i18n.Lang)
+}
+
+func (r *CategoryResponse) Localize(lang i18n.Lang) {
+	r.DisplayName = i18n.Pick(lang, r.CategoryName, r.CategoryNameEN)
+	r.DisplayDescription = i18n.Pick(lang, r.Description, r.DescriptionEN)
+}
+
+func (r *CategoriesResponse) Localize(lang i18n.Lang) {
+	for i := range r.Categories {
+		r.Categories[i].Localize(lang)
+	}
+}
+
+func (r *CategoryDetailResponse) Localize(lang i18n.Lang) {
+	r.DisplayName = i18n.Pick(lang, r.CategoryName, r.CategoryNameEN)
+	r.DisplayDescription = i18n.Pick(lang, r.Description, r.DescriptionEN)
+}
+
+// Localize fills the display fields of the node and its subtree and rebuilds the breadcrumb from localized names
+func (r *CategoryTreeNode) Localize(lang i18n.Lang) {
+	r.DisplayName = i18n.Pick(lang, r.CategoryName, r.CategoryNameEN)
+	r.DisplayDescription = i18n.Pick(lang, r.Description, r.DescriptionEN)
+
+	names := make([]string, len(r.Path))
+	for i, item := range r.Path {
+		names[i] = i18n.Pick(lang, item.CategoryName, item.CategoryNameEN)
+	}
+	r.Breadcrumb = strings.Join(names, " > ")
+
+	for i := range r.Children {
+		r.Children[i].Localize(lang)
+	}
+}
+
+func (r *CategoryTreeResponse) Localize(lang i18n.Lang) {
+	for i := range r.Tree {
+		r.Tree[i].Localize(lang)
+	}
+}
+
+func (r *ProductNameResponse) Localize(lang i18n.Lang) {
+	r.DisplayName = i18n.Pick(lang, r.ProductNameVI, r.ProductNameEN)
+}
+
+func (r *SampleResponse) Localize(lang i18n.Lang) {
+	r.DisplayDescription = i18n.Pick(lang, r.Description, r.DescriptionEN)
+	if r.ProductName != nil {
+		r.ProductName.Localize(lang)
+	}
+	if r.Category != nil {
+		r.Category.Localize(lang)
+	}
+}
+
+func (r *ProductResponse) Localize(lang i18n.Lang) {
+	r.DisplayDescription = i18n.Pick(lang, r.Description, r.DescriptionEN)
+	if r.ProductName != nil {
+		r.ProductName.Localize(lang)
+	}
+	if r.Category != nil {
+		r.Category.Localize(lang)
+	}
+}
+
+func (r *PaginatedResponse) Localize(lang i18n.Lang) {
+	localizeItems(r.Items, lang)
+}
+
+func (r *CursorPaginatedResponse) Localize(lang i18n.Lang) {
+	localizeItems(r.Items, lang)
+}
+
+func (r *SampleSearchResponse) Localize(lang i18n.Lang) {
+	for i := range r.Items {
+		if r.Items[i].Sample != nil {
+			r.Items[i].Sample.Localize(lang)
+		}
+	}
+}
+
+func (r *PermissionResponse) Localize(lang i18n.Lang) {
+	r.DisplayName = i18n.T(lang, r.Description)
+}
+
+func (r *PermissionsResponse) Localize(lang i18n.Lang) {
+	localizePermissions(r.Permissions, r.ModuleGroups, lang)
+}
+
+func (r *RolePermissionsResponse) Localize(lang i18n.Lang) {
+	localizePermissions(r.Permissions, r.ModuleGroups, lang)
+}
+
+func (r *EffectivePermissionsResponse) Localize(lang i18n.Lang) {
+	localizePermissions(r.Permissions, r.ModuleGroups, lang)
+}
+
+func (r *UserPermissionsResponse) Localize(lang i18n.Lang) {
+	localizePermissions(r.RolePermissions, nil, lang)
+	for i := range r.DirectPermissions {
+		r.DirectPermissions[i].Permission.Localize(lang)
+	}
+}
+
+func (r *PermissionGroupsResponse) Localize(lang i18n.Lang) {
+	for i := range r.Groups {
+		r.Groups[i].DisplayName = i18n.T(lang, r.Groups[i].DisplayName)
+		localizePermissions(r.Groups[i].Permissions, nil, lang)
+	}
+}
+
+func (r *SavedItemResponse) Localize(lang i18n.Lang) {
+	r.DisplayName = i18n.Pick(lang, r.ProductNameVI, r.ProductNameEN)
+}
+
+func (r *CustomerInterestReportResponse) Localize(lang i18n.Lang) {
+	for i := range r.MostSavedProducts {
+		r.MostSavedProducts[i].DisplayName = i18n.Pick(lang, r.MostSavedProducts[i].ProductNameVI, r.MostSavedProducts[i].ProductNameEN)
+	}
+	for i := range r.MostSavedSamples {
+		r.MostSavedSamples[i].DisplayName = i18n.Pick(lang, r.MostSavedSamples[i].ProductNameVI, r.MostSavedSamples[i].ProductNameEN)
+	}
+}
+
+// localizeItems localizes page items that support it
+func localizeItems(items []interface{}, lang i18n.Lang) {
+	for _, item := range items {
+		if item, ok := item.(Localizable); ok {
+			item.Localize(lang)
+		}
+	}
+}
+
+func localizePermissions(permissions []PermissionResponse, groups map[string][]PermissionResponse, lang i18n.Lang) {
+	for i := range permissions {
+		permissions[i].Localize(lang)
+	}
+	for _, group := range groups {
+		for i := range group {
+			group[i].Localize(lang)
+		}
+	}
+}
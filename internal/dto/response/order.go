@@ -0,0 +1,81 @@
+// File: internal/dto/response/order.go
+// Tạo tại: internal/dto/response/order.go
+// Mục đích: Định nghĩa các response DTO cho Order API
+
+package response
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+type OrderResponse struct {
+	ID           uint         `json:"id"`
+	OrderCode    string       `json:"order_code"`
+	CustomerID   uint         `json:"customer_id"`
+	CustomerCode string       `json:"customer_code"`
+	CustomerName string       `json:"customer_name"`
+	Status       string       `json:"status"`
+	TotalAmount  money.Amount `json:"total_amount"`
+	Currency     string       `json:"currency"`
+	DueDate      *time.Time   `json:"due_date"`
+	Notes        string       `json:"notes"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// OrderDetailResponse is an order with its items, attachments and full status history
+type OrderDetailResponse struct {
+	OrderResponse
+	Items       []OrderItemResponse       `json:"items"`
+	Attachments []OrderAttachmentResponse `json:"attachments"`
+	History     []OrderHistoryResponse    `json:"history"`
+}
+
+type OrderItemResponse struct {
+	ID        uint         `json:"id"`
+	ProductID uint         `json:"product_id"`
+	SKU       string       `json:"sku"`
+	Color     string       `json:"color"`
+	ColorCode string       `json:"color_code"`
+	Quantity  float64      `json:"quantity"`
+	Price     money.Amount `json:"price"`
+	Subtotal  money.Amount `json:"subtotal"`
+	Notes     string       `json:"notes"`
+}
+
+type OrderAttachmentResponse struct {
+	Name        string    `json:"name"`
+	URL         string    `json:"url"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	UploadedBy  uint      `json:"uploaded_by"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+type OrderHistoryResponse struct {
+	ID            uint      `json:"id"`
+	Status        string    `json:"status"`
+	ChangedAt     time.Time `json:"changed_at"`
+	ChangedBy     uint      `json:"changed_by"`
+	ChangedByName string    `json:"changed_by_name"`
+	Notes         string    `json:"notes"`
+}
+
+type OrderStatusResponse struct {
+	ID          uint   `json:"id"`
+	StatusName  string `json:"status_name"`
+	Description string `json:"description"`
+}
+
+// OrderTransitionResponse is an action the order's current status allows
+type OrderTransitionResponse struct {
+	Action       string `json:"action"`
+	To           string `json:"to"`
+	Permission   string `json:"permission"`
+	NoteRequired bool   `json:"note_required"`
+	Allowed      bool   `json:"allowed"`
+	Reason       string `json:"reason,omitempty"` // Why the action is not allowed for this user or order
+}
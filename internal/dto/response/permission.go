@@ -14,6 +14,7 @@ type PermissionResponse struct {
 	Resource       string `json:"resource"`
 	PermissionName string `json:"permission_name"`
 	Description    string `json:"description"`
+	DisplayName    string `json:"display_name"` // Description translated to the request language
 	IsActive       bool   `json:"is_active"`
 }
 
@@ -0,0 +1,100 @@
+// File: internal/dto/response/portal.go
+// Tạo tại: internal/dto/response/portal.go
+// Mục đích: Định nghĩa các response DTO cho cổng portal khách hàng và quản lý tài khoản portal
+
+package response
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+type PortalLoginResponse struct {
+	Token     string                `json:"token"`
+	ExpiresAt time.Time             `json:"expires_at"`
+	Account   PortalAccountResponse `json:"account"`
+}
+
+// PortalAccountResponse is the signed-in buyer and the customer they act for
+type PortalAccountResponse struct {
+	ID        uint                   `json:"id"`
+	Email     string                 `json:"email"`
+	FullName  string                 `json:"full_name"`
+	LastLogin *time.Time             `json:"last_login"`
+	Customer  PortalCustomerResponse `json:"customer"`
+}
+
+type PortalCustomerResponse struct {
+	ID           uint   `json:"id"`
+	CustomerCode string `json:"customer_code"`
+	Name         string `json:"name"`
+	CompanyName  string `json:"company_name"`
+}
+
+type PortalOrderResponse struct {
+	ID          uint         `json:"id"`
+	OrderCode   string       `json:"order_code"`
+	Status      string       `json:"status"`
+	TotalAmount money.Amount `json:"total_amount"`
+	Currency    string       `json:"currency"`
+	DueDate     *time.Time   `json:"due_date"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+type PortalOrderDetailResponse struct {
+	PortalOrderResponse
+	Items []PortalOrderItemResponse `json:"items"`
+}
+
+type PortalOrderItemResponse struct {
+	ID        uint         `json:"id"`
+	ProductID uint         `json:"product_id"`
+	SKU       string       `json:"sku"`
+	Color     string       `json:"color"`
+	Quantity  float64      `json:"quantity"`
+	Price     money.Amount `json:"price"`
+	Subtotal  money.Amount `json:"subtotal"`
+}
+
+// PortalShipmentResponse leaves out internal fields such as shipping cost and notes
+type PortalShipmentResponse struct {
+	ID                   uint        `json:"id"`
+	OrderID              uint        `json:"order_id"`
+	OrderCode            string      `json:"order_code"`
+	DeliveryStatus       string      `json:"delivery_status"`
+	TrackingInfo         interface{} `json:"tracking_info"`
+	TransportationMethod string      `json:"transportation_method"`
+	VehicleNumber        string      `json:"vehicle_number"`
+	ShippingDate         *time.Time  `json:"shipping_date"`
+	ReceivedBy           string      `json:"received_by"`
+	ReturnedQuantity     int         `json:"returned_quantity"`
+	ReturnedWeight       float64     `json:"returned_weight"`
+}
+
+type PortalLapDipResponse struct {
+	ID               uint       `json:"id"`
+	DispatchCode     string     `json:"dispatch_code"`
+	DispatchDate     time.Time  `json:"dispatch_date"`
+	Status           string     `json:"status"`
+	CustomerResponse string     `json:"customer_response"`
+	ResponseDate     *time.Time `json:"response_date"`
+	FinalLDCode      string     `json:"final_ld_code"`
+	LDCode           string     `json:"ld_code"`
+	ColorName        string     `json:"color_name"`
+	ColorCode        string     `json:"color_code"`
+	LDResults        []string   `json:"ld_results"` // Codes the customer can approve
+	ResultImage      string     `json:"result_image"`
+}
+
+type CustomerAccountResponse struct {
+	ID         uint       `json:"id"`
+	CustomerID uint       `json:"customer_id"`
+	Email      string     `json:"email"`
+	FullName   string     `json:"full_name"`
+	Status     string     `json:"status"`
+	LastLogin  *time.Time `json:"last_login"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,39 @@
+// File: internal/dto/response/product.go
+// Tạo tại: internal/dto/response/product.go
+// Mục đích: Response DTO cho Product API
+
+package response
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+type ProductResponse struct {
+	ID                 uint         `json:"id"`
+	ProductNameID      uint         `json:"product_name_id"`
+	CategoryID         uint         `json:"category_id"`
+	SKU                string       `json:"sku"`
+	SKUVariant         string       `json:"sku_variant"`
+	Description        string       `json:"description"`
+	DescriptionEN      string       `json:"description_en"`
+	DisplayDescription string       `json:"display_description"` // Description in the request language
+	FabricType         string       `json:"fabric_type"`
+	Weight             float64      `json:"weight"`
+	Width              float64      `json:"width"`
+	Color              string       `json:"color"`
+	Quality            string       `json:"quality"`
+	FiberContent       string       `json:"fiber_content"`
+	AdditionalInfo     string       `json:"additional_info"`
+	Price              money.Amount `json:"price"`
+	SalesPrice         money.Amount `json:"sales_price"`
+	Currency           string       `json:"currency"`
+	StockQuantity      float64      `json:"stock_quantity"`
+	CreatedAt          time.Time    `json:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+
+	ProductName *ProductNameResponse `json:"product_name,omitempty"`
+	Category    *CategoryResponse    `json:"category,omitempty"`
+	Images      []ImageResponse      `json:"images"`
+}
@@ -0,0 +1,90 @@
+// File: internal/dto/response/quotation.go
+// Tạo tại: internal/dto/response/quotation.go
+// Mục đích: Định nghĩa các response DTO cho bảng giá và báo giá
+
+package response
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+type PriceListResponse struct {
+	ID           uint                    `json:"id"`
+	Name         string                  `json:"name"`
+	CustomerID   *uint                   `json:"customer_id"`
+	CustomerCode string                  `json:"customer_code,omitempty"`
+	CustomerName string                  `json:"customer_name,omitempty"`
+	PriceTier    string                  `json:"price_tier"`
+	Currency     string                  `json:"currency"`
+	ValidFrom    *time.Time              `json:"valid_from"`
+	ValidTo      *time.Time              `json:"valid_to"`
+	IsActive     bool                    `json:"is_active"`
+	Notes        string                  `json:"notes"`
+	Items        []PriceListItemResponse `json:"items,omitempty"`
+	CreatedAt    time.Time               `json:"created_at"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+}
+
+type PriceListItemResponse struct {
+	ID          uint         `json:"id"`
+	ProductID   uint         `json:"product_id"`
+	SKU         string       `json:"sku"`
+	MinQuantity float64      `json:"min_quantity"`
+	UnitPrice   money.Amount `json:"unit_price"`
+}
+
+// ResolvedPriceResponse is the unit price a customer gets for a product and quantity
+type ResolvedPriceResponse struct {
+	ProductID     uint         `json:"product_id"`
+	Quantity      float64      `json:"quantity"`
+	UnitPrice     money.Amount `json:"unit_price"`
+	Currency      string       `json:"currency"`
+	Source        string       `json:"source"` // price_list or product
+	PriceListID   *uint        `json:"price_list_id,omitempty"`
+	PriceListName string       `json:"price_list_name,omitempty"`
+	MinQuantity   float64      `json:"min_quantity"`
+}
+
+type QuotationResponse struct {
+	ID              uint         `json:"id"`
+	QuotationCode   string       `json:"quotation_code"`
+	CustomerID      uint         `json:"customer_id"`
+	CustomerCode    string       `json:"customer_code"`
+	CustomerName    string       `json:"customer_name"`
+	Status          string       `json:"status"` // expired when a draft or sent quotation is past valid_until
+	IssueDate       time.Time    `json:"issue_date"`
+	ValidUntil      time.Time    `json:"valid_until"`
+	DiscountPercent float64      `json:"discount_percent"`
+	Currency        string       `json:"currency"`
+	Subtotal        money.Amount `json:"subtotal"`
+	DiscountAmount  money.Amount `json:"discount_amount"`
+	TotalAmount     money.Amount `json:"total_amount"`
+	OrderID         *uint        `json:"order_id"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+type QuotationDetailResponse struct {
+	QuotationResponse
+	Notes       string                  `json:"notes"`
+	Terms       string                  `json:"terms"`
+	RespondedAt *time.Time              `json:"responded_at"`
+	Items       []QuotationItemResponse `json:"items"`
+}
+
+type QuotationItemResponse struct {
+	ID              uint         `json:"id"`
+	ProductID       uint         `json:"product_id"`
+	SKU             string       `json:"sku"`
+	ProductName     string       `json:"product_name"`
+	ColorCode       string       `json:"color_code"`
+	Quantity        float64      `json:"quantity"`
+	UnitPrice       money.Amount `json:"unit_price"`
+	DiscountPercent float64      `json:"discount_percent"`
+	NetPrice        money.Amount `json:"net_price"` // After the line and quotation discounts
+	Subtotal        money.Amount `json:"subtotal"`
+	PriceListID     *uint        `json:"price_list_id"`
+	Notes           string       `json:"notes"`
+}
@@ -6,28 +6,31 @@ package response
 import "time"
 
 type SampleResponse struct {
-	ID                uint      `json:"id"`
-	SKU               string    `json:"sku"`
-	ProductNameID     uint      `json:"product_name_id"` // Keep for reference
-	CategoryID        uint      `json:"category_id"`     // Keep for reference
-	Description       string    `json:"description"`
-	SampleType        string    `json:"sample_type"`
-	Weight            float64   `json:"weight"`
-	Width             float64   `json:"width"`
-	Color             string    `json:"color"`
-	ColorCode         string    `json:"color_code"`
-	Quality           string    `json:"quality"`
-	RemainingQuantity int       `json:"remaining_quantity"`
-	FiberContent      string    `json:"fiber_content"`
-	Source            string    `json:"source"`
-	SampleLocation    string    `json:"sample_location"`
-	Barcode           string    `json:"barcode"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
+	ID                 uint      `json:"id"`
+	SKU                string    `json:"sku"`
+	ProductNameID      uint      `json:"product_name_id"` // Keep for reference
+	CategoryID         uint      `json:"category_id"`     // Keep for reference
+	Description        string    `json:"description"`
+	DescriptionEN      string    `json:"description_en"`
+	DisplayDescription string    `json:"display_description"` // Description in the request language
+	SampleType         string    `json:"sample_type"`
+	Weight             float64   `json:"weight"`
+	Width              float64   `json:"width"`
+	Color              string    `json:"color"`
+	ColorCode          string    `json:"color_code"`
+	Quality            string    `json:"quality"`
+	RemainingQuantity  int       `json:"remaining_quantity"`
+	FiberContent       string    `json:"fiber_content"`
+	Source             string    `json:"source"`
+	SampleLocation     string    `json:"sample_location"`
+	Barcode            string    `json:"barcode"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
 
 	// Use existing structs - CategoryResponse is already defined in category.go
 	ProductName *ProductNameResponse `json:"product_name,omitempty"`
 	Category    *CategoryResponse    `json:"category,omitempty"` // This uses the one from category.go
+	Images      []ImageResponse      `json:"images"`
 }
 
 type ProductNameResponse struct {
@@ -35,6 +38,7 @@ type ProductNameResponse struct {
 	ProductNameVI string `json:"product_name_vi"`
 	ProductNameEN string `json:"product_name_en"`
 	SKUParent     string `json:"sku_parent"`
+	DisplayName   string `json:"display_name"` // Name in the request language
 }
 
 // Enhanced response for listings (optional)
@@ -53,6 +57,56 @@ type SampleListResponse struct {
 	UpdatedAt         time.Time `json:"updated_at"`
 }
 
+// SamplePageResponse is a page of samples with optional facet counts per filter value
+type SamplePageResponse struct {
+	PaginatedResponse
+	Facets map[string][]FacetCount `json:"facets,omitempty"`
+}
+
+// SampleCursorPageResponse is a cursor page of samples with optional facet counts
+type SampleCursorPageResponse struct {
+	CursorPaginatedResponse
+	Facets map[string][]FacetCount `json:"facets,omitempty"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
+}
+
+// SampleSearchResult is a sample with its relevance and highlighted snippets per matched field
+type SampleSearchResult struct {
+	Sample     *SampleResponse   `json:"sample"`
+	Relevance  float64           `json:"relevance"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+type SampleSearchResponse struct {
+	Query      string               `json:"query"`
+	Tokens     []string             `json:"tokens"`
+	Fuzzy      bool                 `json:"fuzzy"` // True when results come from typo-tolerant matching
+	Items      []SampleSearchResult `json:"items"`
+	TotalItems int64                `json:"total_items"`
+	Page       int                  `json:"page"`
+	Limit      int                  `json:"limit"`
+	TotalPages int                  `json:"total_pages"`
+}
+
+type SearchReindexResponse struct {
+	Reindexed int `json:"reindexed"`
+}
+
+// CursorPaginatedResponse is a keyset page; pass next_cursor back as cursor to get the following page
+type CursorPaginatedResponse struct {
+	Items          []interface{} `json:"items"`
+	Limit          int           `json:"limit"`
+	NextCursor     string        `json:"next_cursor,omitempty"`
+	HasMore        bool          `json:"has_more"`
+	TotalItems     *int64        `json:"total_items,omitempty"`
+	TotalEstimated bool          `json:"total_estimated,omitempty"`
+}
+
 type PaginatedResponse struct {
 	Items      []interface{} `json:"items"`
 	TotalItems int64         `json:"total_items"`
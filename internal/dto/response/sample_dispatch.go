@@ -0,0 +1,22 @@
+// File: internal/dto/response/sample_dispatch.go
+// Tạo tại: internal/dto/response/sample_dispatch.go
+// Mục đích: Định nghĩa response DTO cho việc gửi mẫu cho khách hàng
+
+package response
+
+import "time"
+
+type SampleDispatchResponse struct {
+	ID               uint      `json:"id"`
+	SampleProductID  uint      `json:"sample_product_id"`
+	SampleSKU        string    `json:"sample_sku"`
+	CustomerID       uint      `json:"customer_id"`
+	CustomerCode     string    `json:"customer_code"`
+	DispatchDate     time.Time `json:"dispatch_date"`
+	DispatchQuantity float64   `json:"dispatch_quantity"`
+	DispatchWeight   float64   `json:"dispatch_weight"`
+	DispatchColor    string    `json:"dispatch_color"`
+	LotNumber        string    `json:"lot_number"`
+	TrackingNumber   string    `json:"tracking_number"`
+	DispatchNotes    string    `json:"dispatch_notes"`
+}
@@ -0,0 +1,24 @@
+// File: internal/dto/response/sample_import.go
+// Tạo tại: internal/dto/response/sample_import.go
+// Mục đích: Response DTOs cho chức năng import mẫu vải
+
+package response
+
+type SampleImportResponse struct {
+	TotalRows int                 `json:"total_rows"`
+	ValidRows int                 `json:"valid_rows"`
+	Created   int                 `json:"created"`
+	Updated   int                 `json:"updated"`
+	Failed    int                 `json:"failed"`
+	DryRun    bool                `json:"dry_run"`
+	Mapping   map[string]string   `json:"mapping"`
+	Errors    []SampleImportError `json:"errors"`
+}
+
+type SampleImportError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,80 @@
+// File: internal/dto/response/stock_reservation.go
+// Tạo tại: internal/dto/response/stock_reservation.go
+// Mục đích: Định nghĩa các response DTO cho giữ hàng theo đơn và khả năng đáp ứng (ATP)
+
+package response
+
+import "time"
+
+// OrderReservationsResponse shows how much of each order line is covered by reserved rolls
+type OrderReservationsResponse struct {
+	OrderID      uint                       `json:"order_id"`
+	OrderCode    string                     `json:"order_code"`
+	Lines        []ReservationLineResponse  `json:"lines"`
+	Reservations []StockReservationResponse `json:"reservations"`
+}
+
+type ReservationLineResponse struct {
+	OrderItemID uint    `json:"order_item_id"`
+	ProductID   uint    `json:"product_id"`
+	SKU         string  `json:"sku"`
+	ColorCode   string  `json:"color_code"`
+	Ordered     float64 `json:"ordered"`
+	Reserved    float64 `json:"reserved"`
+	Outstanding float64 `json:"outstanding"`
+}
+
+type StockReservationResponse struct {
+	ID            uint       `json:"id"`
+	OrderItemID   uint       `json:"order_item_id"`
+	LotID         uint       `json:"lot_id"`
+	LotCode       string     `json:"lot_code"`
+	ColorCode     string     `json:"color_code"`
+	FabricRollID  uint       `json:"fabric_roll_id"`
+	Barcode       string     `json:"barcode"`
+	Length        float64    `json:"length"`
+	Weight        float64    `json:"weight"`
+	Status        string     `json:"status"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	ReleasedAt    *time.Time `json:"released_at,omitempty"`
+	ReleaseReason string     `json:"release_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// AvailableToPromiseResponse answers how many meters of a product and color can be promised by a date.
+// available_to_promise = on_hand - reserved + incoming_weaving + incoming_dyeing - open_demand, never below zero.
+type AvailableToPromiseResponse struct {
+	ProductID          uint                      `json:"product_id"`
+	SKU                string                    `json:"sku"`
+	ColorCode          string                    `json:"color_code"`
+	Date               string                    `json:"date"`
+	Unit               string                    `json:"unit"`
+	OnHand             float64                   `json:"on_hand"`
+	Reserved           float64                   `json:"reserved"`
+	IncomingWeaving    float64                   `json:"incoming_weaving"`
+	IncomingDyeing     float64                   `json:"incoming_dyeing"`
+	OpenDemand         float64                   `json:"open_demand"` // Confirmed order quantity not covered by reservations
+	AvailableToPromise float64                   `json:"available_to_promise"`
+	Lots               []LotAvailabilityResponse `json:"lots"`
+	Incoming           []IncomingSupplyResponse  `json:"incoming"`
+}
+
+type LotAvailabilityResponse struct {
+	LotID     uint    `json:"lot_id"`
+	LotCode   string  `json:"lot_code"`
+	ColorCode string  `json:"color_code"`
+	Rolls     int64   `json:"rolls"`
+	OnHand    float64 `json:"on_hand"`
+	Reserved  float64 `json:"reserved"`
+	Available float64 `json:"available"`
+}
+
+type IncomingSupplyResponse struct {
+	Source       string     `json:"source"` // weaving, dyeing
+	ID           uint       `json:"id"`
+	Code         string     `json:"code"`
+	ColorCode    string     `json:"color_code"`
+	Status       string     `json:"status"`
+	ExpectedDate *time.Time `json:"expected_date"`
+	Length       float64    `json:"length"`
+}
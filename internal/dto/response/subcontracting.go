@@ -0,0 +1,107 @@
+// File: internal/dto/response/subcontracting.go
+// Tạo tại: internal/dto/response/subcontracting.go
+// Mục đích: Định nghĩa các response DTO cho bảng kê công nợ, thanh toán và tuổi nợ nhà gia công dệt/nhuộm
+
+package response
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+// SubcontractJobResponse is a job sent to a subcontractor and what is owed for it. Invoiced jobs owe the
+// invoice balance; jobs without an invoice carry an estimate at the agreed rate for the quantity returned.
+type SubcontractJobResponse struct {
+	Type             string       `json:"type"` // weaving, dyeing
+	JobID            uint         `json:"job_id"`
+	JobCode          string       `json:"job_code"`
+	Description      string       `json:"description"` // Product SKU or target color
+	SentAt           *time.Time   `json:"sent_at"`
+	SentQuantity     float64      `json:"sent_quantity"`
+	ReturnedQuantity float64      `json:"returned_quantity"`
+	UnitCost         money.Amount `json:"unit_cost"`
+	Currency         string       `json:"currency"`
+	Invoiced         bool         `json:"invoiced"`
+	InvoiceNumber    string       `json:"invoice_number"`
+	InvoiceDate      *time.Time   `json:"invoice_date"`
+	DueDate          *time.Time   `json:"due_date"`
+	BilledQuantity   float64      `json:"billed_quantity"`
+	AmountDue        money.Amount `json:"amount_due"` // Invoice total, or the estimate when not invoiced
+	AmountPaid       money.Amount `json:"amount_paid"`
+	Balance          money.Amount `json:"balance"`
+	PaymentStatus    string       `json:"payment_status"` // pending, partial, paid; empty when not invoiced
+}
+
+// SubcontractorStatementResponse totals a subcontractor's jobs in one currency
+type SubcontractorStatementResponse struct {
+	Type              string                   `json:"type"`
+	SubcontractorName string                   `json:"subcontractor_name"`
+	Currency          string                   `json:"currency"`
+	Jobs              []SubcontractJobResponse `json:"jobs"`
+	TotalInvoiced     money.Amount             `json:"total_invoiced"`
+	TotalPaid         money.Amount             `json:"total_paid"`
+	TotalBalance      money.Amount             `json:"total_balance"`
+	TotalUninvoiced   money.Amount             `json:"total_uninvoiced"` // Estimated for jobs not yet invoiced
+}
+
+// SubcontractorStatementsResponse converts the statement totals into Currency at the rate of the day
+type SubcontractorStatementsResponse struct {
+	Currency        string                           `json:"currency"`
+	Statements      []SubcontractorStatementResponse `json:"statements"`
+	TotalInvoiced   money.Amount                     `json:"total_invoiced"`
+	TotalPaid       money.Amount                     `json:"total_paid"`
+	TotalBalance    money.Amount                     `json:"total_balance"`
+	TotalUninvoiced money.Amount                     `json:"total_uninvoiced"`
+}
+
+type SubcontractorPaymentResponse struct {
+	ID        uint         `json:"id"`
+	Type      string       `json:"type"`
+	JobID     uint         `json:"job_id"`
+	Amount    money.Amount `json:"amount"`
+	Currency  string       `json:"currency"`
+	PaidAt    time.Time    `json:"paid_at"`
+	Method    string       `json:"method"`
+	Reference string       `json:"reference"`
+	Notes     string       `json:"notes"`
+	CreatedBy *uint        `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// AgingBuckets splits unpaid balances by days past due
+type AgingBuckets struct {
+	Current    money.Amount `json:"current"` // Not yet due
+	Days1To30  money.Amount `json:"days_1_30"`
+	Days31To60 money.Amount `json:"days_31_60"`
+	Days61To90 money.Amount `json:"days_61_90"`
+	Over90     money.Amount `json:"over_90"`
+	Total      money.Amount `json:"total"`
+}
+
+type PayablesAgingInvoiceResponse struct {
+	Type          string       `json:"type"`
+	JobID         uint         `json:"job_id"`
+	JobCode       string       `json:"job_code"`
+	InvoiceNumber string       `json:"invoice_number"`
+	InvoiceDate   *time.Time   `json:"invoice_date"`
+	DueDate       *time.Time   `json:"due_date"` // Invoice date when the invoice has no due date
+	DaysOverdue   int          `json:"days_overdue"`
+	Balance       money.Amount `json:"balance"`
+}
+
+type PayablesAgingSubcontractorResponse struct {
+	Type              string                         `json:"type"`
+	SubcontractorName string                         `json:"subcontractor_name"`
+	Currency          string                         `json:"currency"`
+	Buckets           AgingBuckets                   `json:"buckets"`
+	Invoices          []PayablesAgingInvoiceResponse `json:"invoices"`
+}
+
+// PayablesAgingResponse converts the subcontractor buckets into Currency at the as_of rate for the totals
+type PayablesAgingResponse struct {
+	AsOf           time.Time                            `json:"as_of"`
+	Currency       string                               `json:"currency"`
+	Subcontractors []PayablesAgingSubcontractorResponse `json:"subcontractors"`
+	Totals         AgingBuckets                         `json:"totals"`
+}
@@ -0,0 +1,110 @@
+// File: internal/dto/response/weaving.go
+// Tạo tại: internal/dto/response/weaving.go
+// Mục đích: Định nghĩa các response DTO cho xưởng dệt, ca, công nhân, lệnh dệt, công đoạn dệt và tiến độ dệt của đơn hàng
+
+package response
+
+import "time"
+
+type WeavingFacilityResponse struct {
+	ID           uint                   `json:"id"`
+	FacilityName string                 `json:"facility_name"`
+	Location     string                 `json:"location"`
+	ContactInfo  string                 `json:"contact_info"`
+	FacilityType string                 `json:"facility_type"`
+	Shifts       []WeavingShiftResponse `json:"shifts,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+type WeavingShiftResponse struct {
+	ID                uint   `json:"id"`
+	WeavingFacilityID uint   `json:"weaving_facility_id"`
+	ShiftName         string `json:"shift_name"`
+	StartTime         string `json:"start_time"`
+	EndTime           string `json:"end_time"`
+	ShiftLeader       string `json:"shift_leader"`
+}
+
+type WeavingStaffResponse struct {
+	ID                uint      `json:"id"`
+	WeavingFacilityID uint      `json:"weaving_facility_id"`
+	FacilityName      string    `json:"facility_name"`
+	StaffName         string    `json:"staff_name"`
+	Position          string    `json:"position"`
+	ContactInfo       string    `json:"contact_info"`
+	ShiftID           *uint     `json:"shift_id"`
+	ShiftName         string    `json:"shift_name"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type WeavingOrderResponse struct {
+	ID                uint       `json:"id"`
+	OrderCode         string     `json:"order_code"`
+	WeavingFacilityID uint       `json:"weaving_facility_id"`
+	FacilityName      string     `json:"facility_name"`
+	LinkedOrderID     uint       `json:"linked_order_id"`
+	LinkedOrderCode   string     `json:"linked_order_code"`
+	YarnBoxID         uint       `json:"yarn_box_id"`
+	BoxCode           string     `json:"box_code"`
+	YarnType          string     `json:"yarn_type"`
+	ProductID         uint       `json:"product_id"`
+	SKU               string     `json:"sku"`
+	ColorCode         string     `json:"color_code"`
+	PlannedQuantity   float64    `json:"planned_quantity"`
+	ProducedQuantity  float64    `json:"produced_quantity"`
+	Progress          float64    `json:"progress"` // Percent of the planned quantity woven
+	StartDate         *time.Time `json:"start_date"`
+	EndDate           *time.Time `json:"end_date"`
+	Status            string     `json:"status"`
+	Notes             string     `json:"notes"`
+	CreatedBy         *uint      `json:"created_by"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type WeavingOperationResponse struct {
+	ID                 uint       `json:"id"`
+	WeavingOrderID     uint       `json:"weaving_order_id"`
+	WeavingOrderCode   string     `json:"weaving_order_code"`
+	WeavingFacilityID  uint       `json:"weaving_facility_id"`
+	WeavingStaffID     uint       `json:"weaving_staff_id"`
+	StaffName          string     `json:"staff_name"`
+	WeavingShiftID     *uint      `json:"weaving_shift_id"`
+	ShiftName          string     `json:"shift_name"`
+	YarnBoxID          uint       `json:"yarn_box_id"`
+	BoxCode            string     `json:"box_code"`
+	WeavingMachineID   *uint      `json:"weaving_machine_id"`
+	MachineCode        string     `json:"machine_code"`
+	CamLayout          string     `json:"cam_layout"`
+	StartTime          *time.Time `json:"start_time"`
+	EndTime            *time.Time `json:"end_time"`
+	Quantity           float64    `json:"quantity"`
+	QualityCheckStatus string     `json:"quality_check_status"`
+	Notes              string     `json:"notes"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// WeavingProductProgressResponse is the weaving of one product of a sales order
+type WeavingProductProgressResponse struct {
+	ProductID uint    `json:"product_id"`
+	SKU       string  `json:"sku"`
+	Ordered   float64 `json:"ordered"`
+	Planned   float64 `json:"planned"`
+	Produced  float64 `json:"produced"`
+	Progress  float64 `json:"progress"` // Percent of the ordered quantity woven
+}
+
+// OrderWeavingResponse rolls a sales order's weaving orders up to the order
+type OrderWeavingResponse struct {
+	OrderID       uint                             `json:"order_id"`
+	OrderCode     string                           `json:"order_code"`
+	OrderStatus   string                           `json:"order_status"`
+	Ordered       float64                          `json:"ordered"`
+	Planned       float64                          `json:"planned"`
+	Produced      float64                          `json:"produced"`
+	Progress      float64                          `json:"progress"`
+	Products      []WeavingProductProgressResponse `json:"products"`
+	WeavingOrders []WeavingOrderResponse           `json:"weaving_orders"`
+}
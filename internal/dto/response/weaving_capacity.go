@@ -0,0 +1,85 @@
+// File: internal/dto/response/weaving_capacity.go
+// Tạo tại: internal/dto/response/weaving_capacity.go
+// Mục đích: Định nghĩa các response DTO cho máy dệt, lịch phân ca công nhân và kế hoạch công suất dệt
+
+package response
+
+import "time"
+
+type WeavingMachineResponse struct {
+	ID                uint      `json:"id"`
+	WeavingFacilityID uint      `json:"weaving_facility_id"`
+	FacilityName      string    `json:"facility_name"`
+	MachineCode       string    `json:"machine_code"`
+	LoomType          string    `json:"loom_type"`
+	MaxWidth          float64   `json:"max_width"`
+	SpeedRPM          int       `json:"speed_rpm"`
+	OutputPerHour     float64   `json:"output_per_hour"`
+	Status            string    `json:"status"`
+	Notes             string    `json:"notes"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type WeavingRosterResponse struct {
+	ID               uint      `json:"id"`
+	WorkDate         time.Time `json:"work_date"`
+	WeavingStaffID   uint      `json:"weaving_staff_id"`
+	StaffName        string    `json:"staff_name"`
+	WeavingShiftID   uint      `json:"weaving_shift_id"`
+	ShiftName        string    `json:"shift_name"`
+	StartTime        string    `json:"start_time"`
+	EndTime          string    `json:"end_time"`
+	WeavingMachineID *uint     `json:"weaving_machine_id"`
+	MachineCode      string    `json:"machine_code"`
+	Notes            string    `json:"notes"`
+}
+
+// WeavingCapacityPlanResponse proposes a machine and shifts for each open weaving order of a facility
+type WeavingCapacityPlanResponse struct {
+	FacilityID   uint                         `json:"facility_id"`
+	FacilityName string                       `json:"facility_name"`
+	From         time.Time                    `json:"from"`
+	To           time.Time                    `json:"to"` // Exclusive
+	Overloaded   bool                         `json:"overloaded"`
+	Issues       []string                     `json:"issues"`
+	Orders       []WeavingOrderPlanResponse   `json:"orders"`
+	Machines     []WeavingMachineLoadResponse `json:"machines"`
+}
+
+type WeavingOrderPlanResponse struct {
+	WeavingOrderID      uint                            `json:"weaving_order_id"`
+	OrderCode           string                          `json:"order_code"`
+	Status              string                          `json:"status"`
+	LinkedOrderCode     string                          `json:"linked_order_code"`
+	SKU                 string                          `json:"sku"`
+	DueDate             *time.Time                      `json:"due_date"`
+	RemainingQuantity   float64                         `json:"remaining_quantity"` // Meters still to weave
+	ScheduledQuantity   float64                         `json:"scheduled_quantity"` // Meters that fit in the planning window
+	MachineID           *uint                           `json:"machine_id"`
+	MachineCode         string                          `json:"machine_code"`
+	ProjectedStart      *time.Time                      `json:"projected_start"`
+	ProjectedCompletion *time.Time                      `json:"projected_completion"`
+	Late                bool                            `json:"late"`
+	Overloaded          bool                            `json:"overloaded"`
+	Issues              []string                        `json:"issues"`
+	Assignments         []WeavingPlanAssignmentResponse `json:"assignments"`
+}
+
+// WeavingPlanAssignmentResponse is the part of an order woven in one shift
+type WeavingPlanAssignmentResponse struct {
+	Date      time.Time `json:"date"`
+	ShiftID   uint      `json:"shift_id"`
+	ShiftName string    `json:"shift_name"`
+	Hours     float64   `json:"hours"`
+	Quantity  float64   `json:"quantity"`
+}
+
+type WeavingMachineLoadResponse struct {
+	MachineID      uint    `json:"machine_id"`
+	MachineCode    string  `json:"machine_code"`
+	LoomType       string  `json:"loom_type"`
+	AvailableHours float64 `json:"available_hours"` // Shift hours in the planning window
+	PlannedHours   float64 `json:"planned_hours"`
+	Utilization    float64 `json:"utilization"` // Percent
+}
@@ -0,0 +1,54 @@
+// File: internal/dto/response/weaving_quality.go
+// Tạo tại: internal/dto/response/weaving_quality.go
+// Mục đích: Định nghĩa các response DTO cho danh mục lỗi vải và phiếu kiểm tra chất lượng dệt
+
+package response
+
+import "time"
+
+type DefectTypeResponse struct {
+	ID          uint      `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Category    string    `json:"category"`
+	Description string    `json:"description"`
+	FixedPoints *int      `json:"fixed_points"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type WeavingInspectionResponse struct {
+	ID                 uint                              `json:"id"`
+	WeavingOrderID     uint                              `json:"weaving_order_id"`
+	WeavingOrderCode   string                            `json:"weaving_order_code"`
+	WeavingOperationID *uint                             `json:"weaving_operation_id"`
+	QualityInspector   string                            `json:"quality_inspector"`
+	InspectedBy        *uint                             `json:"inspected_by"`
+	QualityCheckTime   time.Time                         `json:"quality_check_time"`
+	InspectedLength    float64                           `json:"inspected_length"` // Meters
+	FabricWidth        float64                           `json:"fabric_width"`     // Centimeters
+	TotalPoints        int                               `json:"total_points"`
+	PointsPer100SqYd   float64                           `json:"points_per_100_sq_yd"`
+	MaxPoints          float64                           `json:"max_points"`
+	Status             string                            `json:"status"`
+	DefectFound        bool                              `json:"defect_found"`
+	DefectType         string                            `json:"defect_type"`
+	Defects            []WeavingInspectionDefectResponse `json:"defects"`
+	CorrectiveAction   string                            `json:"corrective_action"`
+	CorrectedAt        *time.Time                        `json:"corrected_at"`
+	CorrectedBy        *uint                             `json:"corrected_by"`
+	Notes              string                            `json:"notes"`
+	CreatedAt          time.Time                         `json:"created_at"`
+}
+
+type WeavingInspectionDefectResponse struct {
+	ID           uint     `json:"id"`
+	DefectTypeID uint     `json:"defect_type_id"`
+	DefectCode   string   `json:"defect_code"`
+	DefectName   string   `json:"defect_name"`
+	Position     *float64 `json:"position"`
+	Length       float64  `json:"length"`
+	Points       int      `json:"points"`
+	Notes        string   `json:"notes"`
+}
@@ -0,0 +1,103 @@
+// File: internal/dto/response/yarn.go
+// Tạo tại: internal/dto/response/yarn.go
+// Mục đích: Định nghĩa các response DTO cho thùng sợi, sổ nhập/xuất, tồn sợi theo loại, nhu cầu và phân bổ sợi cho đơn
+
+package response
+
+import "time"
+
+type YarnBoxResponse struct {
+	ID                uint       `json:"id"`
+	BoxCode           string     `json:"box_code"`
+	YarnType          string     `json:"yarn_type"`
+	ConeQuantity      int        `json:"cone_quantity"`
+	TotalWeight       float64    `json:"total_weight"`
+	WarehouseLocation string     `json:"warehouse_location"`
+	Status            string     `json:"status"`
+	ReceivedAt        *time.Time `json:"received_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type YarnTransactionResponse struct {
+	ID                uint      `json:"id"`
+	YarnBoxID         uint      `json:"yarn_box_id"`
+	BoxCode           string    `json:"box_code"`
+	TransactionType   string    `json:"transaction_type"`
+	TransactionDate   time.Time `json:"transaction_date"`
+	Quantity          int       `json:"quantity"`
+	Weight            float64   `json:"weight"`
+	RemainingQuantity int       `json:"remaining_quantity"`
+	RemainingWeight   float64   `json:"remaining_weight"`
+	HandledBy         uint      `json:"handled_by"`
+	HandledByName     string    `json:"handled_by_name"`
+	Notes             string    `json:"notes"`
+}
+
+// YarnMovementResponse is a recorded movement with the box after it
+type YarnMovementResponse struct {
+	Transaction YarnTransactionResponse `json:"transaction"`
+	Box         YarnBoxResponse         `json:"box"`
+}
+
+// YarnStockResponse is the yarn of one type in boxes that are not depleted
+type YarnStockResponse struct {
+	YarnType        string  `json:"yarn_type"`
+	Boxes           int64   `json:"boxes"`
+	Cones           int64   `json:"cones"`
+	Weight          float64 `json:"weight"`
+	AllocatedCones  int64   `json:"allocated_cones"`
+	AllocatedWeight float64 `json:"allocated_weight"`
+	FreeCones       int64   `json:"free_cones"`
+	FreeWeight      float64 `json:"free_weight"`
+}
+
+type YarnComponentResponse struct {
+	ID            uint    `json:"id"`
+	ProductID     uint    `json:"product_id"`
+	YarnType      string  `json:"yarn_type"`
+	GramsPerMeter float64 `json:"grams_per_meter"`
+	Notes         string  `json:"notes"`
+}
+
+// OrderYarnResponse shows the yarn an order needs per type and the boxes allocated to it
+type OrderYarnResponse struct {
+	OrderID     uint                          `json:"order_id"`
+	OrderCode   string                        `json:"order_code"`
+	Lines       []YarnRequirementLineResponse `json:"lines"`
+	Allocations []YarnAllocationResponse      `json:"allocations"`
+}
+
+// YarnRequirementLineResponse is the yarn of one type an order needs, in kilograms.
+// outstanding = required - allocated - issued; shortage is what free stock cannot cover.
+type YarnRequirementLineResponse struct {
+	YarnType    string  `json:"yarn_type"`
+	Required    float64 `json:"required"`
+	Allocated   float64 `json:"allocated"`
+	Issued      float64 `json:"issued"`
+	Outstanding float64 `json:"outstanding"`
+	FreeStock   float64 `json:"free_stock"`
+	Shortage    float64 `json:"shortage"`
+}
+
+type YarnAllocationResponse struct {
+	ID            uint       `json:"id"`
+	YarnBoxID     uint       `json:"yarn_box_id"`
+	BoxCode       string     `json:"box_code"`
+	YarnType      string     `json:"yarn_type"`
+	ConeQuantity  int        `json:"cone_quantity"`
+	TotalWeight   float64    `json:"total_weight"`
+	Status        string     `json:"status"`
+	ReleasedAt    *time.Time `json:"released_at,omitempty"`
+	ReleaseReason string     `json:"release_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// YarnShortageResponse is the yarn of one type to purchase for the open orders, in kilograms
+type YarnShortageResponse struct {
+	YarnType    string  `json:"yarn_type"`
+	Orders      int     `json:"orders"`      // Open orders still needing the yarn
+	Outstanding float64 `json:"outstanding"` // Needed and not yet allocated or issued
+	FreeStock   float64 `json:"free_stock"`
+	Shortage    float64 `json:"shortage"`
+}
@@ -0,0 +1,44 @@
+// File: internal/repository/interfaces/customer.go
+// Tạo tại: internal/repository/interfaces/customer.go
+// Mục đích: Interface cho Customer Repository
+
+package interfaces
+
+import (
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+type CustomerRepository interface {
+	FindAll(page, limit int, spec QuerySpec) ([]models.Customer, int64, error)
+	FindPage(spec QuerySpec, after *Cursor, limit int) ([]models.Customer, *Cursor, error)
+	Count(spec QuerySpec) (int64, error)
+	EstimateCount(spec QuerySpec) (int64, error)
+	FindByID(id uint) (*models.Customer, error)
+	FindByCode(code string) (*models.Customer, error)
+	LastCodeWithPrefix(prefix string) (string, error)
+	Create(customer *models.Customer) error
+	Update(customer *models.Customer) error
+	Delete(id uint) error
+
+	// Linked records shown on the customer detail
+	CountLinks(customerID uint) (*CustomerLinkCounts, error)
+	FindRecentOrders(customerID uint, limit int) ([]models.Order, error)
+	FindRecentSampleDispatches(customerID uint, limit int) ([]models.SampleDispatch, error)
+	FindRecentLapDipTests(customerID uint, limit int) ([]models.LapDipTest, error)
+
+	// Deduplication and merge
+	FindDedupCandidates() ([]models.Customer, error)
+	CountReferences(customerID uint) (map[string]int64, error)
+	// Merge re-points every record of duplicateID to survivor, saves survivor, deletes the duplicate
+	// and creates the audit record in one transaction. It returns the rows moved per table.
+	Merge(survivor *models.Customer, duplicateID uint, audit *models.CustomerMerge) (map[string]int64, error)
+}
+
+// CustomerLinkCounts counts the records that reference a customer
+type CustomerLinkCounts struct {
+	Orders           int64
+	OrderTotals      map[string]money.Amount // Order amounts per currency
+	SampleDispatches int64
+	LapDipTests      int64
+}
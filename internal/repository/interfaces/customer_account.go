@@ -0,0 +1,16 @@
+// File: internal/repository/interfaces/customer_account.go
+// Tạo tại: internal/repository/interfaces/customer_account.go
+// Mục đích: Interface cho Customer Account Repository
+
+package interfaces
+
+import "github.com/godiidev/appsynex/internal/domain/models"
+
+type CustomerAccountRepository interface {
+	FindByID(id uint) (*models.CustomerAccount, error)
+	FindByEmail(email string) (*models.CustomerAccount, error)
+	FindByCustomer(customerID uint) ([]models.CustomerAccount, error)
+	Create(account *models.CustomerAccount) error
+	Update(account *models.CustomerAccount) error
+	Delete(id uint) error
+}
@@ -0,0 +1,15 @@
+// File: internal/repository/interfaces/customer_activity.go
+// Tạo tại: internal/repository/interfaces/customer_activity.go
+// Mục đích: Interface cho Customer Activity Repository
+
+package interfaces
+
+import "github.com/godiidev/appsynex/internal/domain/models"
+
+type CustomerActivityRepository interface {
+	FindAll(page, limit int, spec QuerySpec) ([]models.CustomerActivity, int64, error)
+	FindPage(spec QuerySpec, after *Cursor, limit int) ([]models.CustomerActivity, *Cursor, error)
+	Count(spec QuerySpec) (int64, error)
+	EstimateCount(spec QuerySpec) (int64, error)
+	Create(activity *models.CustomerActivity) error
+}
@@ -0,0 +1,53 @@
+// File: internal/repository/interfaces/customer_interest.go
+// Tạo tại: internal/repository/interfaces/customer_interest.go
+// Mục đích: Interface cho Customer Saved Item và Customer Search History Repository
+
+package interfaces
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+)
+
+// Saved item types
+const (
+	SavedItemProduct = "product"
+	SavedItemSample  = "sample"
+)
+
+type CustomerSavedItemRepository interface {
+	// FindByCustomer lists a customer's saved items, newest first; itemType "" lists both kinds
+	FindByCustomer(customerID uint, itemType string, page, limit int) ([]models.CustomerSavedItem, int64, error)
+	FindByID(id uint) (*models.CustomerSavedItem, error)
+	FindExisting(customerID uint, itemType string, itemID uint) (*models.CustomerSavedItem, error)
+	Create(item *models.CustomerSavedItem) error
+	Update(item *models.CustomerSavedItem) error
+	Delete(id uint) error
+	MostSaved(itemType string, from, to time.Time, limit int) ([]SavedItemCount, error)
+}
+
+type CustomerSearchHistoryRepository interface {
+	// FindByCustomer lists a customer's searches in [from, to), newest first; zero times leave the range open
+	FindByCustomer(customerID uint, from, to time.Time, page, limit int) ([]models.CustomerSearchHistory, int64, error)
+	Create(search *models.CustomerSearchHistory) error
+	TopTerms(from, to time.Time, limit int) ([]SearchTermCount, error)
+}
+
+// SavedItemCount is how often a product or sample was saved in a period
+type SavedItemCount struct {
+	ItemID        uint
+	SKU           string
+	ProductNameVI string
+	ProductNameEN string
+	SaveCount     int64
+	CustomerCount int64
+}
+
+// SearchTermCount is how often a normalized search term was used in a period
+type SearchTermCount struct {
+	Term            string
+	SearchCount     int64
+	CustomerCount   int64
+	ZeroResultCount int64
+}
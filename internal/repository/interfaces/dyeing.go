@@ -0,0 +1,76 @@
+// File: internal/repository/interfaces/dyeing.go
+// Tạo tại: internal/repository/interfaces/dyeing.go
+// Mục đích: Interface cho Dyeing Repository
+
+package interfaces
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrDyeingJobStatusChanged is returned when a dyeing job left the expected status before a change was saved
+	ErrDyeingJobStatusChanged = errors.New("dyeing job status was changed by someone else; reload and try again")
+	// ErrDyeingLotStatusChanged is returned when a dyeing lot was accepted or rejected meanwhile
+	ErrDyeingLotStatusChanged = errors.New("dyeing lot was already accepted or rejected")
+)
+
+type DyeingJobFilter struct {
+	Search          string // Job code, dye house, target color or lap dip reference
+	Statuses        []string
+	SubcontractorID uint
+	OrderID         uint
+	WeavingOrderID  uint
+	DueBy           *time.Time // Expected back on or before, inclusive
+}
+
+type DyeingRepository interface {
+	FindSubcontractors(search string) ([]models.DyeingSubcontractor, error)
+	FindSubcontractorByID(id uint) (*models.DyeingSubcontractor, error)
+	CreateSubcontractor(subcontractor *models.DyeingSubcontractor) error
+	UpdateSubcontractor(subcontractor *models.DyeingSubcontractor) error
+	DeleteSubcontractor(id uint) error
+	// CountOpenJobs counts the dye house's jobs that are sent or partially returned
+	CountOpenJobs(subcontractorID uint) (int64, error)
+
+	// FindJobs and FindJobByID load the jobs with their lots
+	FindJobs(filter DyeingJobFilter, page, limit int) ([]models.DyeingJob, int64, error)
+	FindJobByID(id uint) (*models.DyeingJob, error)
+	FindJobByCode(code string) (*models.DyeingJob, error)
+	// LastCodeWithPrefix returns the highest job code made of prefix followed by digits, or "" when there is none
+	LastCodeWithPrefix(prefix string) (string, error)
+	// CreateJob inserts the job. With sendWeavingOrder its completed weaving order is sent to dyeing in the
+	// same transaction, failing with ErrWeavingOrderStatusChanged when the order is no longer completed.
+	// A non-nil inTx runs before the insert with the weaving order row locked; its error rolls back.
+	CreateJob(job *models.DyeingJob, sendWeavingOrder bool, inTx func(tx *gorm.DB) error) error
+	// UpdateJob saves the color, lap dip, greige, dates, rate and notes
+	UpdateJob(job *models.DyeingJob) error
+	DeleteJob(id uint) error
+	// ChangeJobStatus saves the status and returned date when the job is still in one of fromStatuses.
+	// It fails with ErrDyeingJobStatusChanged when it is in none of them.
+	ChangeJobStatus(job *models.DyeingJob, fromStatuses []string) error
+
+	FindLotByID(id uint) (*models.DyeingLot, error)
+	FindLotByCode(code string) (*models.DyeingLot, error)
+	// WarehouseLotExists reports whether a warehouse lot already uses the code
+	WarehouseLotExists(code string) (bool, error)
+	// CreateLot locks the job, which must be sent or partially returned, saves the returned lot and marks
+	// a sent job partially returned in one transaction. It fails with ErrDyeingJobStatusChanged otherwise.
+	CreateLot(lot *models.DyeingLot) error
+	// SaveLotResults saves the shrinkage and color fastness results of a lot that is still pending.
+	// It fails with ErrDyeingLotStatusChanged otherwise.
+	SaveLotResults(lot *models.DyeingLot) error
+	// AcceptLot creates the warehouse lot and marks the pending dyeing lot accepted with a link to it in one
+	// transaction. It fails with ErrDyeingLotStatusChanged when the lot is no longer pending.
+	AcceptLot(lot *models.DyeingLot, stock *models.Lot) error
+	// RejectLot marks a pending lot rejected with its quality issues.
+	// It fails with ErrDyeingLotStatusChanged when the lot is no longer pending.
+	RejectLot(lot *models.DyeingLot) error
+	DeleteLot(id uint) error
+
+	FindLapDipTestByID(id uint) (*models.LapDipTest, error)
+}
@@ -0,0 +1,31 @@
+// File: internal/repository/interfaces/exchange_rate.go
+// Tạo tại: internal/repository/interfaces/exchange_rate.go
+// Mục đích: Interface cho Exchange Rate Repository
+
+package interfaces
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+)
+
+// ExchangeRateFilter narrows the rate listing; empty currencies match every pair
+type ExchangeRateFilter struct {
+	FromCurrency string
+	ToCurrency   string
+}
+
+type ExchangeRateRepository interface {
+	// FindAll lists rates, newest effective date first
+	FindAll(filter ExchangeRateFilter, page, limit int) ([]models.ExchangeRate, int64, error)
+	FindByID(id uint) (*models.ExchangeRate, error)
+	// FindByPairDate returns the rate of the pair set for exactly that date
+	FindByPairDate(from, to string, date time.Time) (*models.ExchangeRate, error)
+	// FindEffective returns the rate of the pair with the latest effective date not after date,
+	// or nil when the pair has none
+	FindEffective(from, to string, date time.Time) (*models.ExchangeRate, error)
+	Create(rate *models.ExchangeRate) error
+	Update(rate *models.ExchangeRate) error
+	Delete(id uint) error
+}
@@ -0,0 +1,47 @@
+// File: internal/repository/interfaces/inventory.go
+// Tạo tại: internal/repository/interfaces/inventory.go
+// Mục đích: Interface cho Inventory Repository (tồn kho theo lô và nguồn hàng sắp về)
+
+package interfaces
+
+import "time"
+
+// StockCriteria selects the stock and production of one product counted for availability
+type StockCriteria struct {
+	ProductID    uint
+	ColorCode    string    // Empty matches any color
+	ProductColor string    // Color of order lines that name none
+	Until        time.Time // Production due after this date is not counted
+	Now          time.Time // Reservations expired by now are not counted
+}
+
+// LotStock is the sellable stock of a lot in meters
+type LotStock struct {
+	LotID     uint
+	LotCode   string
+	ColorCode string
+	Rolls     int64
+	OnHand    float64
+	Reserved  float64
+}
+
+// IncomingSupply is production expected to add stock
+type IncomingSupply struct {
+	Source       string // weaving, dyeing
+	ID           uint
+	Code         string
+	ColorCode    string
+	Status       string
+	ExpectedDate *time.Time
+	Length       float64
+}
+
+type InventoryRepository interface {
+	// StockByLot lists lots with available rolls of good quality, with their reserved length
+	StockByLot(criteria StockCriteria) ([]LotStock, error)
+	// IncomingWeaving and IncomingDyeing list unfinished production due by criteria.Until
+	IncomingWeaving(criteria StockCriteria) ([]IncomingSupply, error)
+	IncomingDyeing(criteria StockCriteria) ([]IncomingSupply, error)
+	// OpenDemand is the length ordered on confirmed, in-production or held orders not yet covered by a reservation
+	OpenDemand(criteria StockCriteria) (float64, error)
+}
@@ -0,0 +1,55 @@
+// File: internal/repository/interfaces/order.go
+// Tạo tại: internal/repository/interfaces/order.go
+// Mục đích: Interface cho Order Repository
+
+package interfaces
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/pkg/money"
+	"gorm.io/gorm"
+)
+
+// ErrOrderStatusChanged is returned when an order left the expected status before a transition was saved
+var ErrOrderStatusChanged = errors.New("order status was changed by someone else; reload and try again")
+
+// CurrencyDayTotal sums the orders placed in one currency on one day
+type CurrencyDayTotal struct {
+	Currency string
+	Day      time.Time
+	Orders   int64
+	Total    money.Amount
+}
+
+type OrderRepository interface {
+	FindAll(page, limit int, spec QuerySpec) ([]models.Order, int64, error)
+	FindPage(spec QuerySpec, after *Cursor, limit int) ([]models.Order, *Cursor, error)
+	Count(spec QuerySpec) (int64, error)
+	EstimateCount(spec QuerySpec) (int64, error)
+	FindByID(id uint) (*models.Order, error)
+	FindByCode(code string) (*models.Order, error)
+	LastCodeWithPrefix(prefix string) (string, error)
+	FindHistory(orderID uint) ([]models.OrderHistory, error)
+	FindStatuses() ([]models.OrderStatus, error)
+	FindStatusByName(name string) (*models.OrderStatus, error)
+
+	// Create saves the order with its items and first history entry in one transaction
+	Create(order *models.Order, history *models.OrderHistory) error
+	// Update saves the order details in one transaction. Non-nil items replace the order's items
+	// and a non-nil history entry is added. It fails with ErrOrderStatusChanged when the order
+	// is no longer in order.OrderStatusID.
+	Update(order *models.Order, items []models.OrderItem, history *models.OrderHistory) error
+	Delete(id uint) error
+
+	// ChangeStatus moves the order from fromStatusID to order.OrderStatusID and adds the history entry
+	// in one transaction. It fails with ErrOrderStatusChanged when the order is no longer in fromStatusID.
+	// A non-nil inTx runs last in the same transaction; its error rolls the change back.
+	ChangeStatus(order *models.Order, fromStatusID uint, history *models.OrderHistory, inTx func(tx *gorm.DB) error) error
+	CountPackingLists(orderID uint) (int64, error)
+
+	// TotalsByCurrency sums the orders created in [from, to) that were not cancelled, per currency and day
+	TotalsByCurrency(from, to time.Time) ([]CurrencyDayTotal, error)
+}
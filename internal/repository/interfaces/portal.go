@@ -0,0 +1,30 @@
+// File: internal/repository/interfaces/portal.go
+// Tạo tại: internal/repository/interfaces/portal.go
+// Mục đích: Interface cho Portal Repository (dữ liệu khách hàng tự xem qua portal)
+
+package interfaces
+
+import (
+	"errors"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+)
+
+// ErrLapDipAnswered is returned when a lap dip dispatch no longer awaits the customer's answer
+var ErrLapDipAnswered = errors.New("lap dip has already been answered")
+
+// PortalRepository reads the records a portal customer may see. Every method takes the
+// customer ID from the token and only returns rows that belong to that customer.
+type PortalRepository interface {
+	FindOrders(customerID uint, status string, page, limit int) ([]models.Order, int64, error)
+	FindOrder(customerID, orderID uint) (*models.Order, error)
+	FindShipments(customerID uint, orderID uint, page, limit int) ([]models.Shipment, int64, error)
+	FindSampleDispatches(customerID uint, page, limit int) ([]models.SampleDispatch, int64, error)
+	FindLapDipDispatches(customerID uint, status string, page, limit int) ([]models.LapDipDispatch, int64, error)
+	FindLapDipDispatch(customerID, dispatchID uint) (*models.LapDipDispatch, error)
+
+	// RespondLapDip saves the customer's answer on a dispatch still awaiting one, updates the test
+	// and, on approval, stores the final selection. It fails with ErrLapDipAnswered when the dispatch
+	// was answered in the meantime.
+	RespondLapDip(dispatch *models.LapDipDispatch, selection *models.LapDipFinalSelection) error
+}
@@ -0,0 +1,13 @@
+// File: internal/repository/interfaces/product.go
+// Tạo tại: internal/repository/interfaces/product.go
+// Mục đích: Interface cho Product Repository
+
+package interfaces
+
+import "github.com/godiidev/appsynex/internal/domain/models"
+
+type ProductRepository interface {
+	FindAll(page, limit int, search, category string) ([]models.Product, int64, error)
+	FindByID(id uint) (*models.Product, error)
+	FindBySKU(sku string) (*models.Product, error)
+}
@@ -8,4 +8,17 @@ type ProductCategoryRepository interface {
 	Create(category *models.ProductCategory) error
 	Update(category *models.ProductCategory) error
 	Delete(id uint) error
+	UpdateParent(id uint, parentID *uint) error
+	CountProductsByCategory() (map[uint]int64, error)
+	CountSamplesByCategory() (map[uint]int64, error)
+	DeleteAndReassign(id, targetID uint) (*CategoryDeleteResult, error)
+	DeleteCascade(ids []uint) (*CategoryDeleteResult, error)
+}
+
+// CategoryDeleteResult counts the rows changed by a category delete
+type CategoryDeleteResult struct {
+	Categories      int64 // Categories deleted
+	ChildCategories int64 // Child categories reassigned
+	Products        int64 // Products reassigned or deleted
+	Samples         int64 // Samples reassigned or deleted
 }
@@ -0,0 +1,18 @@
+// File: internal/repository/interfaces/product_image.go
+// Tạo tại: internal/repository/interfaces/product_image.go
+// Mục đích: Interface cho Product Image Repository
+
+package interfaces
+
+import "github.com/godiidev/appsynex/internal/domain/models"
+
+type ProductImageRepository interface {
+	FindByProductID(productID uint) ([]models.ProductImage, error)
+	FindByID(id uint) (*models.ProductImage, error)
+	NextSortOrder(productID uint) (int, error)
+	// Create inserts the images of one upload in a single statement, so a failed batch saves none
+	Create(images []models.ProductImage) error
+	Delete(id uint) error
+	SetPrimary(productID uint, imageID uint) error
+	Reorder(productID uint, imageIDs []uint) error
+}
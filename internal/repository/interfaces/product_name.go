@@ -6,6 +6,7 @@ type ProductNameRepository interface {
 	FindAll() ([]models.ProductName, error)
 	FindByID(id uint) (*models.ProductName, error)
 	Create(productName *models.ProductName) error
+	// Update also refreshes search_text of the samples that use the name
 	Update(productName *models.ProductName) error
 	Delete(id uint) error
 }
@@ -0,0 +1,73 @@
+// File: internal/repository/interfaces/query.go
+// Tạo tại: internal/repository/interfaces/query.go
+// Mục đích: Tiêu chí lọc, sắp xếp, facet và cursor dùng chung cho các repository danh sách
+
+package interfaces
+
+import "time"
+
+// FilterOp is a comparison applied by a repository filter
+type FilterOp string
+
+const (
+	FilterEq       FilterOp = "eq"
+	FilterIn       FilterOp = "in"
+	FilterContains FilterOp = "contains"
+	FilterGte      FilterOp = "gte"
+	FilterLte      FilterOp = "lte"
+	FilterLt       FilterOp = "lt"
+)
+
+// Filter restricts a query on a field. Field names are resolved by each repository's whitelist.
+type Filter struct {
+	Field string
+	Op    FilterOp
+	Value interface{}
+}
+
+// SortField orders a query on a field
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// QuerySpec is a generic filter and sort specification for list queries
+type QuerySpec struct {
+	Search  string
+	Filters []Filter
+	Sort    []SortField
+}
+
+// FacetCount is the number of rows having a field value
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// Cursor is the keyset position after the last row of a page: its sort value and ID
+type Cursor struct {
+	Field string      `json:"f"`
+	Desc  bool        `json:"d,omitempty"`
+	Value interface{} `json:"v,omitempty"`
+	Time  *time.Time  `json:"t,omitempty"` // Set instead of Value for time columns
+	ID    uint        `json:"id"`
+}
+
+// NewCursor builds a cursor for a row, keeping time values typed
+func NewCursor(sort SortField, value interface{}, id uint) *Cursor {
+	cursor := &Cursor{Field: sort.Field, Desc: sort.Desc, ID: id}
+	if t, ok := value.(time.Time); ok {
+		cursor.Time = &t
+	} else {
+		cursor.Value = value
+	}
+	return cursor
+}
+
+// SortValue returns the sort column value of the cursor row
+func (c *Cursor) SortValue() interface{} {
+	if c.Time != nil {
+		return *c.Time
+	}
+	return c.Value
+}
@@ -0,0 +1,72 @@
+// File: internal/repository/interfaces/quotation.go
+// Tạo tại: internal/repository/interfaces/quotation.go
+// Mục đích: Interface cho Price List và Quotation Repository
+
+package interfaces
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+)
+
+// ErrQuotationStatusChanged is returned when a quotation left the expected status before a change was saved
+var ErrQuotationStatusChanged = errors.New("quotation status was changed by someone else; reload and try again")
+
+// PriceListFilter narrows the price list listing; zero values match everything
+type PriceListFilter struct {
+	CustomerID uint
+	PriceTier  string
+	Currency   string
+	ActiveOn   *time.Time // Only active lists valid on this date
+}
+
+// QuotationFilter narrows the quotation listing; zero values match everything
+type QuotationFilter struct {
+	Search     string // Quotation code, customer code or name
+	CustomerID uint
+	// Statuses may include expired: a draft or sent quotation whose valid_until is before Today.
+	// draft and sent then only match quotations still valid on Today.
+	Statuses []string
+	Today    time.Time
+}
+
+// ApplicablePrice is the price list row used for a product, with the list it belongs to
+type ApplicablePrice struct {
+	PriceList models.PriceList
+	Item      models.PriceListItem
+}
+
+type PriceListRepository interface {
+	FindAll(filter PriceListFilter, page, limit int) ([]models.PriceList, int64, error)
+	FindByID(id uint) (*models.PriceList, error)
+	// Create saves the list with its items in one transaction
+	Create(list *models.PriceList) error
+	// Update saves the list in one transaction; non-nil items replace the list's items
+	Update(list *models.PriceList, items []models.PriceListItem) error
+	Delete(id uint) error
+
+	// FindApplicable returns the price of the product for the quantity from the most specific active list
+	// in the currency valid on the date: the customer's own lists first, then lists of the tier, then general
+	// lists. Within a level the newest list wins, and its quantity break with the highest min_quantity not
+	// above quantity applies. It returns nil when no list prices the product.
+	FindApplicable(customerID uint, tier, currency string, productID uint, quantity float64, date time.Time) (*ApplicablePrice, error)
+}
+
+type QuotationRepository interface {
+	FindAll(filter QuotationFilter, page, limit int) ([]models.Quotation, int64, error)
+	FindByID(id uint) (*models.Quotation, error)
+	FindByCode(code string) (*models.Quotation, error)
+	LastCodeWithPrefix(prefix string) (string, error)
+
+	// Create saves the quotation with its items in one transaction
+	Create(quotation *models.Quotation) error
+	// Update saves the quotation in one transaction; non-nil items replace the quotation's items
+	Update(quotation *models.Quotation, items []models.QuotationItem) error
+	Delete(id uint) error
+
+	// ChangeStatus moves the quotation from one of fromStatuses to quotation.Status, also saving
+	// OrderID and RespondedAt. It fails with ErrQuotationStatusChanged when the quotation is in none of them.
+	ChangeStatus(quotation *models.Quotation, fromStatuses []string) error
+}
@@ -3,10 +3,21 @@ package interfaces
 import "github.com/godiidev/appsynex/internal/domain/models"
 
 type SampleRepository interface {
-	FindAll(page, limit int, search, category string, filters map[string]interface{}) ([]models.SampleProduct, int64, error)
+	FindAll(page, limit int, spec QuerySpec) ([]models.SampleProduct, int64, error)
+	FindPage(spec QuerySpec, after *Cursor, limit int) ([]models.SampleProduct, *Cursor, error)
+	Count(spec QuerySpec) (int64, error)
+	EstimateCount(spec QuerySpec) (int64, error)
 	FindByID(id uint) (*models.SampleProduct, error)
 	FindBySKU(sku string) (*models.SampleProduct, error)
+	FindBySKUs(skus []string) ([]models.SampleProduct, error)
+	Search(booleanQuery string, spec QuerySpec, limit int) ([]models.SampleProduct, error)
+	FindAllInBatches(spec QuerySpec, batchSize int, fn func([]models.SampleProduct) error) error
+	FacetCounts(spec QuerySpec, field string, limit int) ([]FacetCount, error)
 	Create(sample *models.SampleProduct) error
 	Update(sample *models.SampleProduct) error
 	Delete(id uint) error
+	RebuildSearchText(batchSize int) (int, error)
+	// FillMissingSearchText computes search_text for the samples that have none and returns their number
+	FillMissingSearchText(batchSize int) (int, error)
+	SaveBatch(creates []*models.SampleProduct, updates []*models.SampleProduct) error
 }
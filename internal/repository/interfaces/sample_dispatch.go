@@ -0,0 +1,11 @@
+// File: internal/repository/interfaces/sample_dispatch.go
+// Tạo tại: internal/repository/interfaces/sample_dispatch.go
+// Mục đích: Interface cho Sample Dispatch Repository
+
+package interfaces
+
+import "github.com/godiidev/appsynex/internal/domain/models"
+
+type SampleDispatchRepository interface {
+	Create(dispatch *models.SampleDispatch) error
+}
@@ -0,0 +1,18 @@
+// File: internal/repository/interfaces/sample_image.go
+// Tạo tại: internal/repository/interfaces/sample_image.go
+// Mục đích: Interface cho Sample Image Repository
+
+package interfaces
+
+import "github.com/godiidev/appsynex/internal/domain/models"
+
+type SampleImageRepository interface {
+	FindBySampleID(sampleID uint) ([]models.SampleImage, error)
+	FindByID(id uint) (*models.SampleImage, error)
+	NextSortOrder(sampleID uint) (int, error)
+	// Create inserts the images of one upload in a single statement, so a failed batch saves none
+	Create(images []models.SampleImage) error
+	Delete(id uint) error
+	SetPrimary(sampleID uint, imageID uint) error
+	Reorder(sampleID uint, imageIDs []uint) error
+}
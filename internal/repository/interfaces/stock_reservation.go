@@ -0,0 +1,54 @@
+// File: internal/repository/interfaces/stock_reservation.go
+// Tạo tại: internal/repository/interfaces/stock_reservation.go
+// Mục đích: Interface cho Stock Reservation Repository
+
+package interfaces
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+// ErrInsufficientStock is returned when the free rolls do not cover the requested length
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// RollCriteria selects the fabric rolls that may fill an order line
+type RollCriteria struct {
+	ProductID uint
+	ColorCode string // Empty matches any color
+	LotID     uint   // 0 matches any lot
+	RollIDs   []uint // When set, only these rolls
+}
+
+// ReservationLine asks for rolls covering Length meters for one order line. Every reservation copies
+// Template; with Criteria.RollIDs set, Length is ignored and all listed rolls are reserved.
+type ReservationLine struct {
+	Template models.StockReservation
+	Criteria RollCriteria
+	Length   float64
+}
+
+type StockReservationRepository interface {
+	FindByOrder(orderID uint) ([]models.StockReservation, error)
+	FindByID(id uint) (*models.StockReservation, error)
+
+	// ReservedLength sums the active, unexpired reservations of each line of the order
+	ReservedLength(orderID uint, now time.Time) (map[uint]float64, error)
+
+	// Reserve locks the free rolls matching each line's criteria, oldest lot first, and reserves whole
+	// rolls until the line's length is covered, all in one transaction. Without allowPartial nothing is
+	// reserved when a line falls short, and the error wraps ErrInsufficientStock.
+	Reserve(lines []ReservationLine, allowPartial bool, now time.Time) ([]models.StockReservation, error)
+
+	// WithTx returns the repository working in tx, e.g. for an order status change
+	WithTx(tx *gorm.DB) StockReservationRepository
+	// Release ends the active reservations given by ID, or all of the order's when ids is empty,
+	// with status released or consumed
+	Release(orderID uint, ids []uint, status, reason string, now time.Time) (int64, error)
+
+	// ExpireDue marks active reservations past their expiry as expired
+	ExpireDue(now time.Time) (int64, error)
+}
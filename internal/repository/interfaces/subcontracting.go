@@ -0,0 +1,67 @@
+// File: internal/repository/interfaces/subcontracting.go
+// Tạo tại: internal/repository/interfaces/subcontracting.go
+// Mục đích: Interface cho Subcontract Repository
+
+package interfaces
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/pkg/money"
+)
+
+var (
+	ErrPaymentExceedsBalance = errors.New("payment exceeds the invoice balance")
+	ErrInvoiceBelowPaid      = errors.New("invoice total is less than the amount already paid")
+	ErrInvoiceCurrencyLocked = errors.New("invoice currency cannot change once payments are recorded")
+)
+
+type SubcontractJobFilter struct {
+	Type       string // models.SubcontractWeaving or models.SubcontractDyeing; both when empty
+	JobID      uint
+	Search     string // Subcontractor name or job code
+	From       *time.Time
+	To         *time.Time // Inclusive, on the day the job was sent out
+	UnpaidOnly bool       // Only jobs with an invoice that is not fully paid
+}
+
+// SubcontractJob is a weaving order at an outside mill or a dyeing job, with its invoice when there is one.
+// Weaving is counted in meters; dyeing in kilograms of greige, with rejected lots not counted as returned.
+type SubcontractJob struct {
+	Type              string
+	JobID             uint
+	JobCode           string
+	Description       string
+	SubcontractorName string
+	SentAt            *time.Time
+	SentQuantity      float64
+	ReturnedQuantity  float64
+	UnitCost          money.Amount // The invoice's, else the agreed rate
+	Currency          string       // The invoice's, else the job's
+	FinancialID       *uint
+	BilledQuantity    float64
+	TotalCost         money.Amount
+	AmountPaid        money.Amount
+	PaymentStatus     string
+	InvoiceNumber     string
+	InvoiceDate       *time.Time
+	DueDate           *time.Time
+}
+
+type SubcontractRepository interface {
+	FindJobs(filter SubcontractJobFilter) ([]SubcontractJob, error)
+	FindJob(jobType string, jobID uint) (*SubcontractJob, error)
+
+	// SaveWeavingInvoice and SaveDyeingInvoice create the invoice when its ID is 0, else update its amounts
+	// and terms; the currency is fixed once it has payments. Either way the payment status is set from the
+	// amount paid so far.
+	SaveWeavingInvoice(financial *models.WeavingFinancial) error
+	SaveDyeingInvoice(financial *models.DyeingFinancial) error
+
+	// RecordPayment adds the payment to the amount paid on its invoice and updates the payment status
+	// in one transaction
+	RecordPayment(payment *models.SubcontractorPayment) error
+	FindPayments(jobType string, financialID uint) ([]models.SubcontractorPayment, error)
+}
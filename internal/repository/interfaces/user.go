@@ -4,6 +4,9 @@ import "github.com/godiidev/appsynex/internal/domain/models"
 
 type UserRepository interface {
 	FindAll(page, limit int, search string) ([]models.User, int64, error)
+	FindPage(spec QuerySpec, after *Cursor, limit int) ([]models.User, *Cursor, error)
+	Count(spec QuerySpec) (int64, error)
+	EstimateCount(spec QuerySpec) (int64, error)
 	FindByID(id uint) (*models.User, error)
 	FindByUsername(username string) (*models.User, error)
 	Create(user *models.User) error
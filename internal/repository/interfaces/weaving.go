@@ -0,0 +1,109 @@
+// File: internal/repository/interfaces/weaving.go
+// Tạo tại: internal/repository/interfaces/weaving.go
+// Mục đích: Interface cho Weaving Facility và Weaving Order Repository
+
+package interfaces
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+// ErrWeavingOrderStatusChanged is returned when a weaving order left the expected status before a change was saved
+var ErrWeavingOrderStatusChanged = errors.New("weaving order status was changed by someone else; reload and try again")
+
+type WeavingStaffFilter struct {
+	FacilityID uint
+	ShiftID    uint
+	Search     string // Name or position
+}
+
+type WeavingFacilityRepository interface {
+	FindAll(search, facilityType string) ([]models.WeavingFacility, error)
+	FindByID(id uint) (*models.WeavingFacility, error)
+	Create(facility *models.WeavingFacility) error
+	Update(facility *models.WeavingFacility) error
+	Delete(id uint) error
+	// CountOpenOrders counts the facility's weaving orders that are pending or in progress
+	CountOpenOrders(id uint) (int64, error)
+
+	FindShifts(facilityID uint) ([]models.WeavingShift, error)
+	FindShiftByID(id uint) (*models.WeavingShift, error)
+	CreateShift(shift *models.WeavingShift) error
+	UpdateShift(shift *models.WeavingShift) error
+	// DeleteShift removes the shift; its staff and operations keep no shift
+	DeleteShift(id uint) error
+
+	FindStaff(filter WeavingStaffFilter, page, limit int) ([]models.WeavingStaff, int64, error)
+	FindStaffByID(id uint) (*models.WeavingStaff, error)
+	CreateStaff(staff *models.WeavingStaff) error
+	UpdateStaff(staff *models.WeavingStaff) error
+	DeleteStaff(id uint) error
+}
+
+type WeavingOrderFilter struct {
+	Search        string // Weaving order code or sales order code
+	Statuses      []string
+	FacilityID    uint
+	LinkedOrderID uint
+	ProductID     uint
+}
+
+type WeavingOperationFilter struct {
+	WeavingOrderID uint
+	FacilityID     uint
+	StaffID        uint
+	ShiftID        uint
+	MachineID      uint
+	From           *time.Time
+	To             *time.Time // Exclusive
+}
+
+// WeavingProgress is the meters of a product a sales order has ordered, planned in weaving orders that
+// are not cancelled and woven so far
+type WeavingProgress struct {
+	ProductID uint
+	Ordered   float64
+	Planned   float64
+	Produced  float64
+}
+
+type WeavingOrderRepository interface {
+	FindAll(filter WeavingOrderFilter, page, limit int) ([]models.WeavingOrder, int64, error)
+	FindByID(id uint) (*models.WeavingOrder, error)
+	FindByCode(code string) (*models.WeavingOrder, error)
+	FindByLinkedOrder(orderID uint) ([]models.WeavingOrder, error)
+	// FindOpen returns the facility's pending and in-progress weaving orders with their sales order
+	FindOpen(facilityID uint) ([]models.WeavingOrder, error)
+	// LastCodeWithPrefix returns the highest order code made of prefix followed by digits, or "" when there is none
+	LastCodeWithPrefix(prefix string) (string, error)
+	Create(order *models.WeavingOrder) error
+	// Update saves the facility, yarn box, planned quantity and notes
+	Update(order *models.WeavingOrder) error
+	Delete(id uint) error
+
+	// ChangeStatus saves the status, start and end date when the order is still in one of fromStatuses.
+	// It fails with ErrWeavingOrderStatusChanged when it is in none of them. A non-nil inTx runs in the
+	// same transaction with the order row locked; its error rolls the change back.
+	ChangeStatus(order *models.WeavingOrder, fromStatuses []string, inTx func(tx *gorm.DB) error) error
+	// WithTx returns the repository working in tx, e.g. for an order status change
+	WithTx(tx *gorm.DB) WeavingOrderRepository
+	// CancelByLinkedOrder cancels the sales order's weaving orders that are pending or in progress
+	CancelByLinkedOrder(orderID uint, now time.Time) (int64, error)
+
+	// Progress sums the sales order's lines and weaving orders per product
+	Progress(orderID uint) ([]WeavingProgress, error)
+
+	FindOperations(filter WeavingOperationFilter, page, limit int) ([]models.WeavingOperation, int64, error)
+	FindOperationByID(id uint) (*models.WeavingOperation, error)
+	// CreateOperation, UpdateOperation and DeleteOperation lock the weaving order, which must be pending or
+	// in progress, save the operation and refresh the order's produced quantity in one transaction. The
+	// first operation starts a pending order. They fail with ErrWeavingOrderStatusChanged when the order
+	// is completed or cancelled.
+	CreateOperation(operation *models.WeavingOperation) error
+	UpdateOperation(operation *models.WeavingOperation) error
+	DeleteOperation(operation *models.WeavingOperation) error
+}
@@ -0,0 +1,46 @@
+// File: internal/repository/interfaces/weaving_capacity.go
+// Tạo tại: internal/repository/interfaces/weaving_capacity.go
+// Mục đích: Interface cho Weaving Machine Repository
+
+package interfaces
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+)
+
+type WeavingMachineFilter struct {
+	FacilityID uint
+	LoomType   string
+	Statuses   []string
+	Search     string // Machine code or notes
+}
+
+type WeavingRosterFilter struct {
+	FacilityID uint
+	StaffID    uint
+	ShiftID    uint
+	MachineID  uint
+	From       *time.Time
+	To         *time.Time // Inclusive
+}
+
+type WeavingMachineRepository interface {
+	FindMachines(filter WeavingMachineFilter) ([]models.WeavingMachine, error)
+	FindMachineByID(id uint) (*models.WeavingMachine, error)
+	// FindMachineByCode also finds deleted machines, whose codes stay taken
+	FindMachineByCode(code string) (*models.WeavingMachine, error)
+	CreateMachine(machine *models.WeavingMachine) error
+	UpdateMachine(machine *models.WeavingMachine) error
+	DeleteMachine(id uint) error
+
+	FindRosters(filter WeavingRosterFilter) ([]models.WeavingRoster, error)
+	FindRosterByID(id uint) (*models.WeavingRoster, error)
+	// SaveRosters creates the entries in one statement; a staff member already rostered on a day gets
+	// the new shift, machine and notes instead
+	SaveRosters(rosters []models.WeavingRoster) error
+	DeleteRoster(id uint) error
+	// StaffPerShift counts the facility's staff by their usual shift
+	StaffPerShift(facilityID uint) (map[uint]int, error)
+}
@@ -0,0 +1,53 @@
+// File: internal/repository/interfaces/weaving_quality.go
+// Tạo tại: internal/repository/interfaces/weaving_quality.go
+// Mục đích: Interface cho Weaving Quality Repository
+
+package interfaces
+
+import (
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type DefectTypeFilter struct {
+	Search     string // Code or name
+	Category   string
+	ActiveOnly bool
+}
+
+type WeavingInspectionFilter struct {
+	WeavingOrderID uint
+	OperationID    uint
+	Status         string
+	Uncorrected    bool // Failed inspections without corrective action
+	From           *time.Time
+	To             *time.Time // Exclusive
+}
+
+type WeavingQualityRepository interface {
+	FindDefectTypes(filter DefectTypeFilter) ([]models.DefectType, error)
+	FindDefectTypeByID(id uint) (*models.DefectType, error)
+	FindDefectTypeByCode(code string) (*models.DefectType, error)
+	CreateDefectType(defectType *models.DefectType) error
+	UpdateDefectType(defectType *models.DefectType) error
+	// DeleteDefectType soft-deletes the defect type; inspections that recorded it keep it
+	DeleteDefectType(id uint) error
+
+	FindInspections(filter WeavingInspectionFilter, page, limit int) ([]models.WeavingQualityControl, int64, error)
+	FindInspectionByID(id uint) (*models.WeavingQualityControl, error)
+	// CreateInspection saves the inspection with its defects and sets the operation's quality check
+	// status to the result in one transaction. It locks the weaving order and fails with
+	// ErrWeavingOrderStatusChanged when the order is in none of orderStatuses.
+	CreateInspection(qc *models.WeavingQualityControl, orderStatuses []string) error
+	// DeleteInspection removes the inspection and sets the operation's quality check status back to
+	// its latest remaining inspection, or pending when there is none
+	DeleteInspection(qc *models.WeavingQualityControl) error
+	// RecordCorrectiveAction saves the corrective action and who recorded it when
+	RecordCorrectiveAction(qc *models.WeavingQualityControl) error
+	// WithTx returns the repository working in tx, e.g. for a weaving order status change
+	WithTx(tx *gorm.DB) WeavingQualityRepository
+	// CountUncorrectedFailures counts the weaving order's failed inspections without corrective action
+	CountUncorrectedFailures(weavingOrderID uint) (int64, error)
+}
@@ -0,0 +1,73 @@
+// File: internal/repository/interfaces/yarn.go
+// Tạo tại: internal/repository/interfaces/yarn.go
+// Mục đích: Interface cho Yarn Box Repository
+
+package interfaces
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+)
+
+var (
+	// ErrInsufficientYarn is returned when an issue takes more cones or weight than the box holds
+	ErrInsufficientYarn = errors.New("insufficient yarn")
+	// ErrYarnBoxStatusChanged is returned when a yarn box left the expected status before a change was saved
+	ErrYarnBoxStatusChanged = errors.New("yarn box status was changed by someone else; reload and try again")
+)
+
+type YarnBoxFilter struct {
+	Search   string // Box code or yarn type
+	YarnType string
+	Location string // Prefix of the warehouse location
+	Statuses []string
+}
+
+type YarnTransactionFilter struct {
+	YarnBoxID       uint
+	TransactionType string
+	From            *time.Time
+	To              *time.Time // Exclusive
+}
+
+// YarnStock is the yarn held in boxes of one yarn type that are not depleted. Allocated counts whole
+// boxes set to allocated and the yarn of available boxes allocated to orders.
+type YarnStock struct {
+	YarnType        string
+	Boxes           int64
+	Cones           int64
+	Weight          float64
+	AllocatedCones  int64
+	AllocatedWeight float64
+}
+
+type YarnBoxRepository interface {
+	FindAll(filter YarnBoxFilter, page, limit int) ([]models.YarnBox, int64, error)
+	FindByID(id uint) (*models.YarnBox, error)
+	FindByCode(code string) (*models.YarnBox, error)
+	// LastCodeWithPrefix returns the highest box code made of prefix followed by digits, or "" when there is none
+	LastCodeWithPrefix(prefix string) (string, error)
+	// Create stores the box and, when opening is not nil, the IN transaction of its first stock
+	Create(box *models.YarnBox, opening *models.YarnInventoryTransaction) error
+	// Update saves the yarn type and warehouse location
+	Update(box *models.YarnBox) error
+	Delete(id uint) error
+
+	// ChangeStatus sets the box status when it is still in one of fromStatuses. It fails with
+	// ErrYarnBoxStatusChanged when the box is in none of them.
+	ChangeStatus(id uint, status string, fromStatuses []string) error
+
+	// Record locks the box, applies the IN or OUT movement to its balance and stores the transaction with
+	// the balance after it. An OUT larger than the balance less what allocations not yet issued hold of the
+	// box fails with an error wrapping ErrInsufficientYarn.
+	// The box becomes depleted when its balance reaches zero and available when a depleted box is
+	// restocked; a non-empty location moves the box.
+	Record(transaction *models.YarnInventoryTransaction, location string) (*models.YarnBox, error)
+
+	FindTransactions(filter YarnTransactionFilter, page, limit int) ([]models.YarnInventoryTransaction, int64, error)
+
+	// StockByType sums the boxes that are not depleted per yarn type, optionally of one type
+	StockByType(yarnType string) ([]YarnStock, error)
+}
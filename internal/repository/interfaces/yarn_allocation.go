@@ -0,0 +1,65 @@
+// File: internal/repository/interfaces/yarn_allocation.go
+// Tạo tại: internal/repository/interfaces/yarn_allocation.go
+// Mục đích: Interface cho Yarn Component và Yarn Allocation Repository
+
+package interfaces
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+// ErrYarnAllocationChanged is returned when an allocation left the allocated status before a change was saved
+var ErrYarnAllocationChanged = errors.New("yarn allocation was changed by someone else; reload and try again")
+
+type YarnComponentRepository interface {
+	FindByProduct(productID uint) ([]models.ProductYarnComponent, error)
+	FindByProducts(productIDs []uint) ([]models.ProductYarnComponent, error)
+	// Replace swaps the product's bill of materials for components in one transaction
+	Replace(productID uint, components []models.ProductYarnComponent) error
+}
+
+// YarnAllocationLine asks for Weight kg of a yarn type. Every allocation copies Template.
+type YarnAllocationLine struct {
+	Template models.YarnOrder
+	YarnType string
+	Weight   float64
+}
+
+// YarnDemand is the yarn an order's lines need of one type, from the bill of materials,
+// and what its allocated and issued yarn covers
+type YarnDemand struct {
+	OrderID   uint
+	OrderCode string
+	YarnType  string
+	Required  float64
+	Allocated float64
+	Issued    float64
+}
+
+type YarnAllocationRepository interface {
+	FindByOrder(orderID uint) ([]models.YarnOrder, error)
+	FindByID(id uint) (*models.YarnOrder, error)
+
+	// Allocate locks the available boxes of each line's yarn type, oldest receipt first, and allocates
+	// their free yarn until the line's weight is covered, all in one transaction. Free yarn is what a box
+	// holds less its allocations. Without allowPartial nothing is allocated when a line falls short,
+	// and the error wraps ErrInsufficientYarn.
+	Allocate(lines []YarnAllocationLine, allowPartial bool) ([]models.YarnOrder, error)
+
+	// WithTx returns the repository working in tx, e.g. for an order status change
+	WithTx(tx *gorm.DB) YarnAllocationRepository
+	// Release ends the allocated rows given by ID, or all of the order's when ids is empty
+	Release(orderID uint, ids []uint, reason string, now time.Time) (int64, error)
+
+	// ChangeStatus sets the allocation status when it is still in fromStatus. It fails with
+	// ErrYarnAllocationChanged otherwise.
+	ChangeStatus(id uint, status, fromStatus string) error
+
+	// Demand lists the yarn needed per order and yarn type for the order, or for every order in one
+	// of statuses when orderID is 0
+	Demand(orderID uint, statuses []string) ([]YarnDemand, error)
+}
@@ -0,0 +1,246 @@
+// File: internal/repository/mysql/customer.go
+// Tạo tại: internal/repository/mysql/customer.go
+// Mục đích: MySQL implementation cho Customer Repository (tìm kiếm, liên hệ, phân trang)
+
+package mysql
+
+import (
+	"regexp"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/money"
+	"gorm.io/gorm"
+)
+
+type customerRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomerRepository(db *gorm.DB) interfaces.CustomerRepository {
+	return &customerRepository{db: db}
+}
+
+// customerFilterColumns whitelists the fields usable in customer filters
+var customerFilterColumns = map[string]string{
+	"account_status": "customers.account_status",
+}
+
+// customerSortColumns whitelists the fields customers can be sorted by
+var customerSortColumns = map[string]string{
+	"id":             "customers.id",
+	"customer_code":  "customers.customer_code",
+	"name":           "customers.name",
+	"company_name":   "customers.company_name",
+	"account_status": "customers.account_status",
+	"created_at":     "customers.created_at",
+}
+
+var defaultCustomerSort = interfaces.SortField{Field: "created_at", Desc: true}
+
+// customerKeysetColumns are the indexed columns customers can be paged by with a cursor
+var customerKeysetColumns = map[string]keysetColumn[models.Customer]{
+	"id":            {"customers.id", func(c *models.Customer) interface{} { return c.ID }},
+	"customer_code": {"customers.customer_code", func(c *models.Customer) interface{} { return c.CustomerCode }},
+	"name":          {"customers.name", func(c *models.Customer) interface{} { return c.Name }},
+	"created_at":    {"customers.created_at", func(c *models.Customer) interface{} { return c.CreatedAt }},
+}
+
+func (r *customerRepository) FindAll(page, limit int, spec interfaces.QuerySpec) ([]models.Customer, int64, error) {
+	var customers []models.Customer
+	var count int64
+
+	query, err := applyCustomerQuery(r.db.Model(&models.Customer{}), spec)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query, err = applySort(query, spec.Sort, customerSortColumns, defaultCustomerSort, "customers.id")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	if err := query.Offset(offset).Limit(limit).Find(&customers).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return customers, count, nil
+}
+
+// FindPage returns up to limit customers after the cursor, ordered by the first spec sort field and ID.
+// The returned cursor is nil on the last page.
+func (r *customerRepository) FindPage(spec interfaces.QuerySpec, after *interfaces.Cursor, limit int) ([]models.Customer, *interfaces.Cursor, error) {
+	var customers []models.Customer
+
+	sort := defaultCustomerSort
+	if len(spec.Sort) > 0 {
+		sort = spec.Sort[0]
+	}
+
+	query, err := applyCustomerQuery(r.db.Model(&models.Customer{}), spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	query, err = applyKeyset(query, sort, after, customerKeysetColumns, "customers.id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Limit(limit + 1).Find(&customers).Error; err != nil {
+		return nil, nil, err
+	}
+
+	customers, next := keysetPage(customers, limit, sort, customerKeysetColumns, func(c *models.Customer) uint { return c.ID })
+	return customers, next, nil
+}
+
+func (r *customerRepository) Count(spec interfaces.QuerySpec) (int64, error) {
+	var count int64
+	query, err := applyCustomerQuery(r.db.Model(&models.Customer{}), spec)
+	if err != nil {
+		return 0, err
+	}
+	err = query.Count(&count).Error
+	return count, err
+}
+
+func (r *customerRepository) EstimateCount(spec interfaces.QuerySpec) (int64, error) {
+	filtered := spec.Search != "" || len(spec.Filters) > 0
+	var buildErr error
+	count, err := estimateCount(r.db, "customers", filtered, func(tx *gorm.DB) *gorm.DB {
+		query, err := applyCustomerQuery(tx.Model(&models.Customer{}), spec)
+		if err != nil {
+			buildErr = err
+			return tx
+		}
+		return query.Find(&[]models.Customer{})
+	})
+	if buildErr != nil {
+		return 0, buildErr
+	}
+	return count, err
+}
+
+// applyCustomerQuery applies the search over code, names and contact fields, then the spec filters
+func applyCustomerQuery(query *gorm.DB, spec interfaces.QuerySpec) (*gorm.DB, error) {
+	if spec.Search != "" {
+		term := "%" + escapeLike(spec.Search) + "%"
+		query = query.Where(
+			"customers.customer_code LIKE ? OR customers.name LIKE ? OR customers.company_name LIKE ? OR "+
+				"customers.email LIKE ? OR customers.phone LIKE ? OR customers.tax_id LIKE ?",
+			term, term, term, term, term, term,
+		)
+	}
+	return applyFilters(query, spec.Filters, customerFilterColumns)
+}
+
+func (r *customerRepository) FindByID(id uint) (*models.Customer, error) {
+	var customer models.Customer
+	if err := r.db.First(&customer, id).Error; err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// FindByCode looks up a customer by code, including soft-deleted ones since codes stay unique
+func (r *customerRepository) FindByCode(code string) (*models.Customer, error) {
+	var customer models.Customer
+	if err := r.db.Unscoped().Where("customer_code = ?", code).First(&customer).Error; err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// LastCodeWithPrefix returns the highest code made of prefix followed by digits, or "" when there is none.
+// Soft-deleted customers count since their codes stay reserved.
+func (r *customerRepository) LastCodeWithPrefix(prefix string) (string, error) {
+	var codes []string
+	err := r.db.Unscoped().Model(&models.Customer{}).
+		Where("customer_code REGEXP ?", "^"+regexp.QuoteMeta(prefix)+"[0-9]+$").
+		Order("CHAR_LENGTH(customer_code) DESC, customer_code DESC").
+		Limit(1).
+		Pluck("customer_code", &codes).Error
+	if err != nil || len(codes) == 0 {
+		return "", err
+	}
+	return codes[0], nil
+}
+
+func (r *customerRepository) Create(customer *models.Customer) error {
+	return r.db.Create(customer).Error
+}
+
+func (r *customerRepository) Update(customer *models.Customer) error {
+	return r.db.Save(customer).Error
+}
+
+func (r *customerRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Customer{}, id).Error
+}
+
+func (r *customerRepository) CountLinks(customerID uint) (*interfaces.CustomerLinkCounts, error) {
+	counts := &interfaces.CustomerLinkCounts{}
+
+	var orders []struct {
+		Currency string
+		Count    int64
+		Total    money.Amount
+	}
+	err := r.db.Model(&models.Order{}).
+		Select("currency, COUNT(*) AS count, COALESCE(SUM(total_amount), 0) AS total").
+		Where("customer_id = ?", customerID).
+		Group("currency").
+		Scan(&orders).Error
+	if err != nil {
+		return nil, err
+	}
+	counts.OrderTotals = make(map[string]money.Amount, len(orders))
+	for _, row := range orders {
+		counts.Orders += row.Count
+		counts.OrderTotals[row.Currency] = row.Total
+	}
+
+	if err := r.db.Model(&models.SampleDispatch{}).Where("customer_id = ?", customerID).Count(&counts.SampleDispatches).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Model(&models.LapDipTest{}).Where("customer_id = ?", customerID).Count(&counts.LapDipTests).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *customerRepository) FindRecentOrders(customerID uint, limit int) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.Preload("OrderStatus").
+		Where("customer_id = ?", customerID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&orders).Error
+	return orders, err
+}
+
+func (r *customerRepository) FindRecentSampleDispatches(customerID uint, limit int) ([]models.SampleDispatch, error) {
+	var dispatches []models.SampleDispatch
+	err := r.db.Preload("SampleProduct").
+		Where("customer_id = ?", customerID).
+		Order("dispatch_date DESC, id DESC").
+		Limit(limit).
+		Find(&dispatches).Error
+	return dispatches, err
+}
+
+func (r *customerRepository) FindRecentLapDipTests(customerID uint, limit int) ([]models.LapDipTest, error) {
+	var tests []models.LapDipTest
+	err := r.db.Where("customer_id = ?", customerID).
+		Order("test_start_date DESC, id DESC").
+		Limit(limit).
+		Find(&tests).Error
+	return tests, err
+}
@@ -0,0 +1,53 @@
+// File: internal/repository/mysql/customer_account.go
+// Tạo tại: internal/repository/mysql/customer_account.go
+// Mục đích: MySQL implementation cho tài khoản cổng khách hàng (portal)
+
+package mysql
+
+import (
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type customerAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomerAccountRepository(db *gorm.DB) interfaces.CustomerAccountRepository {
+	return &customerAccountRepository{db: db}
+}
+
+func (r *customerAccountRepository) FindByID(id uint) (*models.CustomerAccount, error) {
+	var account models.CustomerAccount
+	if err := r.db.Preload("Customer").First(&account, id).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *customerAccountRepository) FindByEmail(email string) (*models.CustomerAccount, error) {
+	var account models.CustomerAccount
+	if err := r.db.Preload("Customer").Where("email = ?", email).First(&account).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *customerAccountRepository) FindByCustomer(customerID uint) ([]models.CustomerAccount, error) {
+	var accounts []models.CustomerAccount
+	err := r.db.Where("customer_id = ?", customerID).Order("id").Find(&accounts).Error
+	return accounts, err
+}
+
+func (r *customerAccountRepository) Create(account *models.CustomerAccount) error {
+	return r.db.Create(account).Error
+}
+
+func (r *customerAccountRepository) Update(account *models.CustomerAccount) error {
+	return r.db.Omit("Customer").Save(account).Error
+}
+
+func (r *customerAccountRepository) Delete(id uint) error {
+	return r.db.Delete(&models.CustomerAccount{}, id).Error
+}
@@ -0,0 +1,118 @@
+// File: internal/repository/mysql/customer_activity.go
+// Tạo tại: internal/repository/mysql/customer_activity.go
+// Mục đích: MySQL implementation cho nhật ký hoạt động khách hàng
+
+package mysql
+
+import (
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type customerActivityRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomerActivityRepository(db *gorm.DB) interfaces.CustomerActivityRepository {
+	return &customerActivityRepository{db: db}
+}
+
+// customerActivityColumns whitelists the fields usable in activity filters and sorts
+var customerActivityColumns = map[string]string{
+	"customer_id":   "customer_activity_log.customer_id",
+	"activity_type": "customer_activity_log.activity_type",
+	"activity_date": "customer_activity_log.activity_date",
+}
+
+var defaultCustomerActivitySort = interfaces.SortField{Field: "activity_date", Desc: true}
+
+// customerActivityKeysetColumns are the columns the timeline can be paged by with a cursor
+var customerActivityKeysetColumns = map[string]keysetColumn[models.CustomerActivity]{
+	"activity_date": {"customer_activity_log.activity_date", func(a *models.CustomerActivity) interface{} { return a.ActivityDate }},
+}
+
+func (r *customerActivityRepository) FindAll(page, limit int, spec interfaces.QuerySpec) ([]models.CustomerActivity, int64, error) {
+	var activities []models.CustomerActivity
+	var count int64
+
+	query, err := applyFilters(r.db.Model(&models.CustomerActivity{}), spec.Filters, customerActivityColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query, err = applySort(query, spec.Sort, customerActivityColumns, defaultCustomerActivitySort, "customer_activity_log.id")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	if err := query.Offset(offset).Limit(limit).Find(&activities).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return activities, count, nil
+}
+
+// FindPage returns up to limit activities after the cursor, ordered by activity date and ID.
+// The returned cursor is nil on the last page.
+func (r *customerActivityRepository) FindPage(spec interfaces.QuerySpec, after *interfaces.Cursor, limit int) ([]models.CustomerActivity, *interfaces.Cursor, error) {
+	var activities []models.CustomerActivity
+
+	sort := defaultCustomerActivitySort
+	if len(spec.Sort) > 0 {
+		sort = spec.Sort[0]
+	}
+
+	query, err := applyFilters(r.db.Model(&models.CustomerActivity{}), spec.Filters, customerActivityColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+	query, err = applyKeyset(query, sort, after, customerActivityKeysetColumns, "customer_activity_log.id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Limit(limit + 1).Find(&activities).Error; err != nil {
+		return nil, nil, err
+	}
+
+	activities, next := keysetPage(activities, limit, sort, customerActivityKeysetColumns, func(a *models.CustomerActivity) uint { return a.ID })
+	return activities, next, nil
+}
+
+func (r *customerActivityRepository) Count(spec interfaces.QuerySpec) (int64, error) {
+	var count int64
+	query, err := applyFilters(r.db.Model(&models.CustomerActivity{}), spec.Filters, customerActivityColumns)
+	if err != nil {
+		return 0, err
+	}
+	err = query.Count(&count).Error
+	return count, err
+}
+
+func (r *customerActivityRepository) EstimateCount(spec interfaces.QuerySpec) (int64, error) {
+	var buildErr error
+	count, err := estimateCount(r.db, "customer_activity_log", len(spec.Filters) > 0, func(tx *gorm.DB) *gorm.DB {
+		query, err := applyFilters(tx.Model(&models.CustomerActivity{}), spec.Filters, customerActivityColumns)
+		if err != nil {
+			buildErr = err
+			return tx
+		}
+		return query.Find(&[]models.CustomerActivity{})
+	})
+	if buildErr != nil {
+		return 0, buildErr
+	}
+	return count, err
+}
+
+func (r *customerActivityRepository) Create(activity *models.CustomerActivity) error {
+	return r.db.Create(activity).Error
+}
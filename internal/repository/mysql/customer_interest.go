@@ -0,0 +1,172 @@
+// File: internal/repository/mysql/customer_interest.go
+// Tạo tại: internal/repository/mysql/customer_interest.go
+// Mục đích: MySQL implementation cho sản phẩm/mẫu khách hàng lưu quan tâm
+
+package mysql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type customerSavedItemRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomerSavedItemRepository(db *gorm.DB) interfaces.CustomerSavedItemRepository {
+	return &customerSavedItemRepository{db: db}
+}
+
+// savedItemTargets maps a saved item type to its ID column and the table it references
+var savedItemTargets = map[string]struct {
+	column string
+	table  string
+}{
+	interfaces.SavedItemProduct: {"product_id", "products"},
+	interfaces.SavedItemSample:  {"sample_product_id", "sample_products"},
+}
+
+func (r *customerSavedItemRepository) FindByCustomer(customerID uint, itemType string, page, limit int) ([]models.CustomerSavedItem, int64, error) {
+	var items []models.CustomerSavedItem
+	var count int64
+
+	query := r.db.Model(&models.CustomerSavedItem{}).Where("customer_id = ?", customerID)
+	if itemType != "" {
+		target, ok := savedItemTargets[itemType]
+		if !ok {
+			return nil, 0, fmt.Errorf("unsupported saved item type: %s", itemType)
+		}
+		query = query.Where(target.column + " IS NOT NULL")
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	err := query.Preload("Product.ProductName").
+		Preload("SampleProduct.ProductName").
+		Order("saved_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, count, nil
+}
+
+func (r *customerSavedItemRepository) FindByID(id uint) (*models.CustomerSavedItem, error) {
+	var item models.CustomerSavedItem
+	err := r.db.Preload("Product.ProductName").
+		Preload("SampleProduct.ProductName").
+		First(&item, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *customerSavedItemRepository) FindExisting(customerID uint, itemType string, itemID uint) (*models.CustomerSavedItem, error) {
+	target, ok := savedItemTargets[itemType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported saved item type: %s", itemType)
+	}
+
+	var item models.CustomerSavedItem
+	if err := r.db.Where("customer_id = ? AND "+target.column+" = ?", customerID, itemID).First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *customerSavedItemRepository) Create(item *models.CustomerSavedItem) error {
+	return r.db.Omit("Product", "SampleProduct").Create(item).Error
+}
+
+func (r *customerSavedItemRepository) Update(item *models.CustomerSavedItem) error {
+	return r.db.Model(item).Update("notes", item.Notes).Error
+}
+
+func (r *customerSavedItemRepository) Delete(id uint) error {
+	return r.db.Delete(&models.CustomerSavedItem{}, id).Error
+}
+
+// MostSaved counts saves per product or sample made in [from, to), most saved first
+func (r *customerSavedItemRepository) MostSaved(itemType string, from, to time.Time, limit int) ([]interfaces.SavedItemCount, error) {
+	target, ok := savedItemTargets[itemType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported saved item type: %s", itemType)
+	}
+
+	var counts []interfaces.SavedItemCount
+	err := r.db.Table("customer_saved_items s").
+		Select("s."+target.column+" AS item_id, t.sku, pn.product_name_vi, pn.product_name_en, "+
+			"COUNT(*) AS save_count, COUNT(DISTINCT s.customer_id) AS customer_count").
+		Joins("JOIN "+target.table+" t ON t.id = s."+target.column+" AND t.deleted_at IS NULL").
+		Joins("LEFT JOIN product_names pn ON pn.id = t.product_name_id").
+		Where("s.saved_at >= ? AND s.saved_at < ?", from, to).
+		Group("s." + target.column + ", t.sku, pn.product_name_vi, pn.product_name_en").
+		Order("save_count DESC, item_id").
+		Limit(limit).
+		Scan(&counts).Error
+	return counts, err
+}
+
+type customerSearchHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomerSearchHistoryRepository(db *gorm.DB) interfaces.CustomerSearchHistoryRepository {
+	return &customerSearchHistoryRepository{db: db}
+}
+
+func (r *customerSearchHistoryRepository) FindByCustomer(customerID uint, from, to time.Time, page, limit int) ([]models.CustomerSearchHistory, int64, error) {
+	var searches []models.CustomerSearchHistory
+	var count int64
+
+	query := r.db.Model(&models.CustomerSearchHistory{}).Where("customer_id = ?", customerID)
+	if !from.IsZero() {
+		query = query.Where("search_date >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("search_date < ?", to)
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	if err := query.Order("search_date DESC, id DESC").Offset(offset).Limit(limit).Find(&searches).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return searches, count, nil
+}
+
+func (r *customerSearchHistoryRepository) Create(search *models.CustomerSearchHistory) error {
+	return r.db.Create(search).Error
+}
+
+// TopTerms counts searches per normalized term made in [from, to), most searched first
+func (r *customerSearchHistoryRepository) TopTerms(from, to time.Time, limit int) ([]interfaces.SearchTermCount, error) {
+	var counts []interfaces.SearchTermCount
+	err := r.db.Model(&models.CustomerSearchHistory{}).
+		Select("normalized_query AS term, COUNT(*) AS search_count, COUNT(DISTINCT customer_id) AS customer_count, "+
+			"COALESCE(SUM(result_count = 0), 0) AS zero_result_count").
+		Where("search_date >= ? AND search_date < ? AND normalized_query <> ''", from, to).
+		Group("normalized_query").
+		Order("search_count DESC, term").
+		Limit(limit).
+		Scan(&counts).Error
+	return counts, err
+}
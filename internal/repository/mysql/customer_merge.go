@@ -0,0 +1,113 @@
+// File: internal/repository/mysql/customer_merge.go
+// Tạo tại: internal/repository/mysql/customer_merge.go
+// Mục đích: Tìm khách hàng có thể trùng lặp và gộp khách hàng trùng trong một transaction
+
+package mysql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// customerReferences lists the columns that point at a customer, keyed by table
+var customerReferences = []struct {
+	table  string
+	column string
+}{
+	{"orders", "customer_id"},
+	{"customer_orders", "customer_id"},
+	{"sample_dispatches", "customer_id"},
+	{"lap_dip_tests", "customer_id"},
+	{"lap_dip_final_selection", "selected_by_customer_id"},
+	{"sales", "customer_id"},
+	{"weaving_financials", "customer_id"},
+	{"customer_activity_log", "customer_id"},
+	{"customer_search_history", "customer_id"},
+	{"customer_saved_items", "customer_id"},
+	{"customer_accounts", "customer_id"},
+	{"price_lists", "customer_id"},
+	{"quotations", "customer_id"},
+}
+
+// FindDedupCandidates returns the fields duplicate detection compares for every customer
+func (r *customerRepository) FindDedupCandidates() ([]models.Customer, error) {
+	var customers []models.Customer
+	err := r.db.Select("id, customer_code, name, email, phone, company_name, tax_id, account_status, created_at, updated_at").
+		Order("id").
+		Find(&customers).Error
+	return customers, err
+}
+
+func (r *customerRepository) CountReferences(customerID uint) (map[string]int64, error) {
+	counts := make(map[string]int64, len(customerReferences))
+	for _, ref := range customerReferences {
+		var count int64
+		if err := r.db.Table(ref.table).Where(ref.column+" = ?", customerID).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		counts[ref.table] = count
+	}
+	return counts, nil
+}
+
+func (r *customerRepository) Merge(survivor *models.Customer, duplicateID uint, audit *models.CustomerMerge) (map[string]int64, error) {
+	moved := make(map[string]int64, len(customerReferences))
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock both customers so concurrent merges or edits wait
+		var locked []models.Customer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", []uint{survivor.ID, duplicateID}).
+			Find(&locked).Error; err != nil {
+			return err
+		}
+		if len(locked) != 2 {
+			return errors.New("customer not found")
+		}
+
+		// Saved items the survivor already has would break the unique indexes
+		if err := tx.Exec(
+			"DELETE d FROM customer_saved_items d JOIN customer_saved_items s "+
+				"ON s.customer_id = ? AND (s.product_id = d.product_id OR s.sample_product_id = d.sample_product_id) "+
+				"WHERE d.customer_id = ?",
+			survivor.ID, duplicateID,
+		).Error; err != nil {
+			return err
+		}
+
+		for _, ref := range customerReferences {
+			result := tx.Exec(fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ?", ref.table, ref.column, ref.column), survivor.ID, duplicateID)
+			if result.Error != nil {
+				return result.Error
+			}
+			moved[ref.table] = result.RowsAffected
+		}
+
+		if err := tx.Save(survivor).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Customer{}).Where("id = ?", duplicateID).
+			Update("account_status", models.CustomerStatusInactive).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Customer{}, duplicateID).Error; err != nil {
+			return err
+		}
+
+		encoded, err := json.Marshal(moved)
+		if err != nil {
+			return err
+		}
+		audit.MovedRecords = string(encoded)
+		return tx.Create(audit).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return moved, nil
+}
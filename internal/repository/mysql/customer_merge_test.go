@@ -0,0 +1,58 @@
+// File: internal/repository/mysql/customer_merge_test.go
+// Tạo tại: internal/repository/mysql/customer_merge_test.go
+// Mục đích: Kiểm thử danh sách cột tham chiếu khách hàng được gộp bao phủ mọi khóa ngoại tới customers trong migrations
+
+package mysql
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+var (
+	migrationTablePattern      = regexp.MustCompile(`(?i)^\s*(?:CREATE TABLE(?: IF NOT EXISTS)?|ALTER TABLE)\s+` + "`?" + `(\w+)`)
+	migrationCustomerFKPattern = regexp.MustCompile(`(?i)FOREIGN KEY \((\w+)\) REFERENCES customers \(id\)`)
+)
+
+// mergeAuditTables reference customers to record a merge and must keep pointing at the merged customer
+var mergeAuditTables = map[string]bool{"customer_merges": true}
+
+// TestCustomerReferencesCoverMigrations fails when a migration adds a foreign key to customers that Merge
+// does not move to the surviving customer
+func TestCustomerReferencesCoverMigrations(t *testing.T) {
+	listed := make(map[[2]string]bool, len(customerReferences))
+	for _, ref := range customerReferences {
+		listed[[2]string{ref.table, ref.column}] = true
+	}
+
+	files, err := filepath.Glob("../../../migrations/*.up.sql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+	found := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		table := ""
+		for _, line := range regexp.MustCompile(`\r?\n`).Split(string(data), -1) {
+			if match := migrationTablePattern.FindStringSubmatch(line); match != nil {
+				table = match[1]
+			}
+			match := migrationCustomerFKPattern.FindStringSubmatch(line)
+			if match == nil || mergeAuditTables[table] {
+				continue
+			}
+			found++
+			if !listed[[2]string{table, match[1]}] {
+				t.Errorf("%s: %s.%s references customers but is not in customerReferences", filepath.Base(file), table, match[1])
+			}
+		}
+	}
+	if found == 0 {
+		t.Error("no foreign keys to customers found; the migration patterns are out of date")
+	}
+}
@@ -0,0 +1,337 @@
+// File: internal/repository/mysql/dyeing.go
+// Tạo tại: internal/repository/mysql/dyeing.go
+// Mục đích: MySQL implementation cho nhà nhuộm, lệnh nhuộm và lô nhuộm trả về
+
+package mysql
+
+import (
+	"regexp"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// openDyeingStatuses are the dyeing job statuses that still take returned lots
+var openDyeingStatuses = []string{models.DyeingJobSent, models.DyeingJobPartiallyReturned}
+
+type dyeingRepository struct {
+	db *gorm.DB
+}
+
+func NewDyeingRepository(db *gorm.DB) interfaces.DyeingRepository {
+	return &dyeingRepository{db: db}
+}
+
+func preloadDyeingJob(db *gorm.DB) *gorm.DB {
+	unscoped := func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+	return db.Preload("DyeingSubcontractor", unscoped).
+		Preload("Order", unscoped).
+		Preload("WeavingOrder").
+		Preload("Product", unscoped).
+		Preload("Lots", func(db *gorm.DB) *gorm.DB { return db.Order("dyeing_lots.id") })
+}
+
+func (r *dyeingRepository) FindSubcontractors(search string) ([]models.DyeingSubcontractor, error) {
+	var subcontractors []models.DyeingSubcontractor
+
+	query := r.db.Model(&models.DyeingSubcontractor{})
+	if search != "" {
+		term := "%" + escapeLike(search) + "%"
+		query = query.Where("name LIKE ? OR contact_info LIKE ?", term, term)
+	}
+
+	err := query.Order("name").Find(&subcontractors).Error
+	return subcontractors, err
+}
+
+func (r *dyeingRepository) FindSubcontractorByID(id uint) (*models.DyeingSubcontractor, error) {
+	var subcontractor models.DyeingSubcontractor
+	if err := r.db.First(&subcontractor, id).Error; err != nil {
+		return nil, err
+	}
+	return &subcontractor, nil
+}
+
+func (r *dyeingRepository) CreateSubcontractor(subcontractor *models.DyeingSubcontractor) error {
+	return r.db.Create(subcontractor).Error
+}
+
+func (r *dyeingRepository) UpdateSubcontractor(subcontractor *models.DyeingSubcontractor) error {
+	return r.db.Model(subcontractor).Updates(map[string]interface{}{
+		"name":         subcontractor.Name,
+		"contact_info": subcontractor.ContactInfo,
+		"currency":     subcontractor.Currency,
+	}).Error
+}
+
+func (r *dyeingRepository) DeleteSubcontractor(id uint) error {
+	return r.db.Delete(&models.DyeingSubcontractor{}, id).Error
+}
+
+func (r *dyeingRepository) CountOpenJobs(subcontractorID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.DyeingJob{}).
+		Where("dyeing_subcontractor_id = ? AND status IN ?", subcontractorID, openDyeingStatuses).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *dyeingRepository) FindJobs(filter interfaces.DyeingJobFilter, page, limit int) ([]models.DyeingJob, int64, error) {
+	var jobs []models.DyeingJob
+	var count int64
+
+	query := r.db.Model(&models.DyeingJob{})
+	if filter.Search != "" {
+		term := "%" + escapeLike(filter.Search) + "%"
+		query = query.Joins("JOIN dyeing_subcontractors ON dyeing_subcontractors.id = dyeing_jobs.dyeing_subcontractor_id").
+			Where("dyeing_jobs.job_code LIKE ? OR dyeing_subcontractors.name LIKE ? OR dyeing_jobs.target_color LIKE ? OR dyeing_jobs.lap_dip_reference LIKE ?",
+				term, term, term, term)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("dyeing_jobs.status IN ?", filter.Statuses)
+	}
+	if filter.SubcontractorID != 0 {
+		query = query.Where("dyeing_jobs.dyeing_subcontractor_id = ?", filter.SubcontractorID)
+	}
+	if filter.OrderID != 0 {
+		query = query.Where("dyeing_jobs.order_id = ?", filter.OrderID)
+	}
+	if filter.WeavingOrderID != 0 {
+		query = query.Where("dyeing_jobs.weaving_order_id = ?", filter.WeavingOrderID)
+	}
+	if filter.DueBy != nil {
+		query = query.Where("dyeing_jobs.expected_return_date <= ?", filter.DueBy.Format("2006-01-02"))
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	err := preloadDyeingJob(query).
+		Order("dyeing_jobs.sent_date DESC, dyeing_jobs.id DESC").
+		Offset(offset).Limit(limit).
+		Find(&jobs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, count, nil
+}
+
+func (r *dyeingRepository) FindJobByID(id uint) (*models.DyeingJob, error) {
+	var job models.DyeingJob
+	if err := preloadDyeingJob(r.db).First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *dyeingRepository) FindJobByCode(code string) (*models.DyeingJob, error) {
+	var job models.DyeingJob
+	if err := r.db.Unscoped().Where("job_code = ?", code).First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *dyeingRepository) LastCodeWithPrefix(prefix string) (string, error) {
+	var codes []string
+	err := r.db.Unscoped().Model(&models.DyeingJob{}).
+		Where("job_code REGEXP ?", "^"+regexp.QuoteMeta(prefix)+"[0-9]+$").
+		Order("CHAR_LENGTH(job_code) DESC, job_code DESC").
+		Limit(1).
+		Pluck("job_code", &codes).Error
+	if err != nil || len(codes) == 0 {
+		return "", err
+	}
+	return codes[0], nil
+}
+
+func (r *dyeingRepository) CreateJob(job *models.DyeingJob, sendWeavingOrder bool, inTx func(tx *gorm.DB) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if job.WeavingOrderID != nil {
+			var statuses []string
+			if sendWeavingOrder {
+				statuses = []string{models.WeavingOrderCompleted}
+			}
+			if err := lockWeavingOrder(tx, *job.WeavingOrderID, statuses); err != nil {
+				return err
+			}
+		}
+		if inTx != nil {
+			if err := inTx(tx); err != nil {
+				return err
+			}
+		}
+		if sendWeavingOrder && job.WeavingOrderID != nil {
+			err := tx.Model(&models.WeavingOrder{}).
+				Where("id = ?", *job.WeavingOrderID).
+				Update("status", models.WeavingOrderSentToDyeing).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Omit(clause.Associations).Create(job).Error
+	})
+}
+
+func (r *dyeingRepository) UpdateJob(job *models.DyeingJob) error {
+	return r.db.Model(job).Updates(map[string]interface{}{
+		"target_color":         job.TargetColor,
+		"color_code":           job.ColorCode,
+		"lap_dip_reference":    job.LapDipReference,
+		"product_id":           job.ProductID,
+		"greige_length":        job.GreigeLength,
+		"greige_weight":        job.GreigeWeight,
+		"greige_rolls":         job.GreigeRolls,
+		"expected_return_date": job.ExpectedReturnDate,
+		"cost_per_kg":          job.CostPerKg,
+		"special_requirements": job.SpecialRequirements,
+		"notes":                job.Notes,
+	}).Error
+}
+
+func (r *dyeingRepository) DeleteJob(id uint) error {
+	return r.db.Delete(&models.DyeingJob{}, id).Error
+}
+
+func (r *dyeingRepository) ChangeJobStatus(job *models.DyeingJob, fromStatuses []string) error {
+	result := r.db.Model(&models.DyeingJob{}).
+		Where("id = ? AND status IN ?", job.ID, fromStatuses).
+		Updates(map[string]interface{}{
+			"status":        job.Status,
+			"returned_date": job.ReturnedDate,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrDyeingJobStatusChanged
+	}
+	return nil
+}
+
+func (r *dyeingRepository) FindLotByID(id uint) (*models.DyeingLot, error) {
+	var lot models.DyeingLot
+	if err := r.db.First(&lot, id).Error; err != nil {
+		return nil, err
+	}
+	return &lot, nil
+}
+
+func (r *dyeingRepository) FindLotByCode(code string) (*models.DyeingLot, error) {
+	var lot models.DyeingLot
+	if err := r.db.Where("lot_code = ?", code).First(&lot).Error; err != nil {
+		return nil, err
+	}
+	return &lot, nil
+}
+
+func (r *dyeingRepository) WarehouseLotExists(code string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Lot{}).Where("lot_code = ?", code).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *dyeingRepository) CreateLot(lot *models.DyeingLot) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var job models.DyeingJob
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&job, lot.DyeingJobID).Error; err != nil {
+			return err
+		}
+		if job.Status != models.DyeingJobSent && job.Status != models.DyeingJobPartiallyReturned {
+			return interfaces.ErrDyeingJobStatusChanged
+		}
+
+		if err := tx.Create(lot).Error; err != nil {
+			return err
+		}
+		if job.Status == models.DyeingJobSent {
+			return tx.Model(&job).Update("status", models.DyeingJobPartiallyReturned).Error
+		}
+		return nil
+	})
+}
+
+func (r *dyeingRepository) SaveLotResults(lot *models.DyeingLot) error {
+	result := r.db.Model(&models.DyeingLot{}).
+		Where("id = ? AND status = ?", lot.ID, models.DyeingLotPending).
+		Updates(map[string]interface{}{
+			"shrinkage_length":       lot.ShrinkageLength,
+			"shrinkage_width":        lot.ShrinkageWidth,
+			"color_fastness_washing": lot.ColorFastnessWashing,
+			"color_fastness_rubbing": lot.ColorFastnessRubbing,
+			"color_fastness_light":   lot.ColorFastnessLight,
+			"tested_by":              lot.TestedBy,
+			"tested_at":              lot.TestedAt,
+			"quality_issues":         lot.QualityIssues,
+			"notes":                  lot.Notes,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrDyeingLotStatusChanged
+	}
+	return nil
+}
+
+func (r *dyeingRepository) AcceptLot(lot *models.DyeingLot, stock *models.Lot) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current models.DyeingLot
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, lot.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != models.DyeingLotPending {
+			return interfaces.ErrDyeingLotStatusChanged
+		}
+
+		if err := tx.Create(stock).Error; err != nil {
+			return err
+		}
+		lot.Status = models.DyeingLotAccepted
+		lot.LotID = &stock.ID
+		return tx.Model(&current).Updates(map[string]interface{}{
+			"status":     lot.Status,
+			"lot_id":     lot.LotID,
+			"decided_by": lot.DecidedBy,
+			"decided_at": lot.DecidedAt,
+		}).Error
+	})
+}
+
+func (r *dyeingRepository) RejectLot(lot *models.DyeingLot) error {
+	result := r.db.Model(&models.DyeingLot{}).
+		Where("id = ? AND status = ?", lot.ID, models.DyeingLotPending).
+		Updates(map[string]interface{}{
+			"status":         models.DyeingLotRejected,
+			"quality_issues": lot.QualityIssues,
+			"decided_by":     lot.DecidedBy,
+			"decided_at":     lot.DecidedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrDyeingLotStatusChanged
+	}
+	lot.Status = models.DyeingLotRejected
+	return nil
+}
+
+func (r *dyeingRepository) DeleteLot(id uint) error {
+	return r.db.Delete(&models.DyeingLot{}, id).Error
+}
+
+func (r *dyeingRepository) FindLapDipTestByID(id uint) (*models.LapDipTest, error) {
+	var test models.LapDipTest
+	if err := r.db.First(&test, id).Error; err != nil {
+		return nil, err
+	}
+	return &test, nil
+}
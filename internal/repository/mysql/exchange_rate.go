@@ -0,0 +1,95 @@
+// File: internal/repository/mysql/exchange_rate.go
+// Tạo tại: internal/repository/mysql/exchange_rate.go
+// Mục đích: MySQL implementation cho tỷ giá theo ngày hiệu lực
+
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type exchangeRateRepository struct {
+	db *gorm.DB
+}
+
+func NewExchangeRateRepository(db *gorm.DB) interfaces.ExchangeRateRepository {
+	return &exchangeRateRepository{db: db}
+}
+
+func (r *exchangeRateRepository) FindAll(filter interfaces.ExchangeRateFilter, page, limit int) ([]models.ExchangeRate, int64, error) {
+	var rates []models.ExchangeRate
+	var count int64
+
+	query := r.db.Model(&models.ExchangeRate{})
+	if filter.FromCurrency != "" {
+		query = query.Where("from_currency = ?", filter.FromCurrency)
+	}
+	if filter.ToCurrency != "" {
+		query = query.Where("to_currency = ?", filter.ToCurrency)
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	err := query.Order("effective_date DESC, from_currency, to_currency").
+		Offset(offset).Limit(limit).
+		Find(&rates).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return rates, count, nil
+}
+
+func (r *exchangeRateRepository) FindByID(id uint) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	if err := r.db.First(&rate, id).Error; err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *exchangeRateRepository) FindByPairDate(from, to string, date time.Time) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	err := r.db.Where("from_currency = ? AND to_currency = ? AND effective_date = ?", from, to, date.Format("2006-01-02")).
+		First(&rate).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *exchangeRateRepository) FindEffective(from, to string, date time.Time) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	err := r.db.Where("from_currency = ? AND to_currency = ? AND effective_date <= ?", from, to, date.Format("2006-01-02")).
+		Order("effective_date DESC").
+		First(&rate).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *exchangeRateRepository) Create(rate *models.ExchangeRate) error {
+	return r.db.Create(rate).Error
+}
+
+func (r *exchangeRateRepository) Update(rate *models.ExchangeRate) error {
+	return r.db.Save(rate).Error
+}
+
+func (r *exchangeRateRepository) Delete(id uint) error {
+	return r.db.Delete(&models.ExchangeRate{}, id).Error
+}
@@ -0,0 +1,96 @@
+// File: internal/repository/mysql/inventory.go
+// Tạo tại: internal/repository/mysql/inventory.go
+// Mục đích: Truy vấn tồn kho theo lô, nguồn cung đang dệt/nhuộm và nhu cầu mở cho ATP
+
+package mysql
+
+import (
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+// finishedProductionStatuses are weaving order and dyeing lot statuses that no longer add stock; greige sent
+// to dyeing comes in through its dyeing lots, and accepted dyeing lots are already stocked as warehouse lots
+var finishedProductionStatuses = []string{"completed", "cancelled", "rejected", models.WeavingOrderSentToDyeing, models.DyeingLotAccepted}
+
+// openOrderStatuses are the orders whose lines count as promised demand
+var openOrderStatuses = []string{models.OrderStatusConfirmed, models.OrderStatusInProduction, models.OrderStatusOnHold}
+
+type inventoryRepository struct {
+	db *gorm.DB
+}
+
+func NewInventoryRepository(db *gorm.DB) interfaces.InventoryRepository {
+	return &inventoryRepository{db: db}
+}
+
+func (r *inventoryRepository) StockByLot(criteria interfaces.StockCriteria) ([]interfaces.LotStock, error) {
+	var stock []interfaces.LotStock
+
+	// A roll has at most one active reservation, so the join does not repeat rolls
+	query := r.db.Table("fabric_rolls").
+		Select("lots.id AS lot_id, lots.lot_code, lots.color_code, COUNT(fabric_rolls.id) AS rolls, "+
+			"SUM(fabric_rolls.length) AS on_hand, "+
+			"COALESCE(SUM(CASE WHEN stock_reservations.id IS NOT NULL THEN fabric_rolls.length END), 0) AS reserved").
+		Joins("JOIN lots ON lots.id = fabric_rolls.lot_id").
+		Joins("LEFT JOIN stock_reservations ON stock_reservations.fabric_roll_id = fabric_rolls.id AND "+activeReservation, criteria.Now).
+		Where("lots.product_id = ? AND lots.status = ? AND lots.quality_status = ? AND fabric_rolls.status = ?",
+			criteria.ProductID, models.LotStatusAvailable, models.LotQualityGood, models.RollStatusAvailable)
+	if criteria.ColorCode != "" {
+		query = query.Where("lots.color_code = ?", criteria.ColorCode)
+	}
+
+	err := query.Group("lots.id, lots.lot_code, lots.color_code, lots.created_at").
+		Order("lots.created_at, lots.id").
+		Scan(&stock).Error
+	return stock, err
+}
+
+func (r *inventoryRepository) IncomingWeaving(criteria interfaces.StockCriteria) ([]interfaces.IncomingSupply, error) {
+	var supply []interfaces.IncomingSupply
+	query := r.db.Table("weaving_orders").
+		Select("'weaving' AS source, id, order_code AS code, color_code, status, end_date AS expected_date, "+
+			"planned_length - produced_quantity AS length").
+		Where("product_id = ? AND status NOT IN ? AND planned_length > produced_quantity", criteria.ProductID, finishedProductionStatuses).
+		Where("end_date IS NOT NULL AND end_date <= ?", criteria.Until)
+	if criteria.ColorCode != "" {
+		query = query.Where("color_code = ?", criteria.ColorCode)
+	}
+	err := query.Order("end_date, id").Scan(&supply).Error
+	return supply, err
+}
+
+func (r *inventoryRepository) IncomingDyeing(criteria interfaces.StockCriteria) ([]interfaces.IncomingSupply, error) {
+	var supply []interfaces.IncomingSupply
+	query := r.db.Table("dyeing_lots").
+		Select("'dyeing' AS source, id, lot_code AS code, color_code, status, expected_date, planned_length AS length").
+		Where("product_id = ? AND status NOT IN ? AND planned_length > 0", criteria.ProductID, finishedProductionStatuses).
+		Where("expected_date IS NOT NULL AND expected_date <= ?", criteria.Until)
+	if criteria.ColorCode != "" {
+		query = query.Where("color_code = ?", criteria.ColorCode)
+	}
+	err := query.Order("expected_date, id").Scan(&supply).Error
+	return supply, err
+}
+
+func (r *inventoryRepository) OpenDemand(criteria interfaces.StockCriteria) (float64, error) {
+	reserved := r.db.Table("stock_reservations").
+		Select("order_item_id, SUM(length) AS length").
+		Where(activeReservation, criteria.Now).
+		Group("order_item_id")
+
+	query := r.db.Table("order_items").
+		Select("COALESCE(SUM(GREATEST(order_items.quantity - COALESCE(reserved.length, 0), 0)), 0)").
+		Joins("JOIN orders ON orders.id = order_items.order_id AND orders.deleted_at IS NULL").
+		Joins("JOIN order_statuses ON order_statuses.id = orders.order_status_id").
+		Joins("LEFT JOIN (?) AS reserved ON reserved.order_item_id = order_items.id", reserved).
+		Where("order_items.product_id = ? AND order_statuses.status_name IN ?", criteria.ProductID, openOrderStatuses)
+	if criteria.ColorCode != "" {
+		query = query.Where("COALESCE(NULLIF(order_items.color_code, ''), ?) = ?", criteria.ProductColor, criteria.ColorCode)
+	}
+
+	var demand float64
+	err := query.Scan(&demand).Error
+	return demand, err
+}
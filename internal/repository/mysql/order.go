@@ -0,0 +1,365 @@
+// File: internal/repository/mysql/order.go
+// Tạo tại: internal/repository/mysql/order.go
+// Mục đích: MySQL implementation cho Order Repository (đơn hàng, dòng hàng, lịch sử trạng thái)
+
+package mysql
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type orderRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) interfaces.OrderRepository {
+	return &orderRepository{db: db}
+}
+
+// orderFilterColumns whitelists the fields usable in order filters
+var orderFilterColumns = map[string]string{
+	"customer_id":     "orders.customer_id",
+	"order_status_id": "orders.order_status_id",
+	"due_date":        "orders.due_date",
+	"created_at":      "orders.created_at",
+}
+
+// orderSortColumns whitelists the fields orders can be sorted by
+var orderSortColumns = map[string]string{
+	"id":           "orders.id",
+	"order_code":   "orders.order_code",
+	"total_amount": "orders.total_amount",
+	"due_date":     "orders.due_date",
+	"created_at":   "orders.created_at",
+}
+
+var defaultOrderSort = interfaces.SortField{Field: "created_at", Desc: true}
+
+// orderKeysetColumns are the indexed columns orders can be paged by with a cursor
+var orderKeysetColumns = map[string]keysetColumn[models.Order]{
+	"id":         {"orders.id", func(o *models.Order) interface{} { return o.ID }},
+	"order_code": {"orders.order_code", func(o *models.Order) interface{} { return o.OrderCode }},
+	"created_at": {"orders.created_at", func(o *models.Order) interface{} { return o.CreatedAt }},
+}
+
+func (r *orderRepository) FindAll(page, limit int, spec interfaces.QuerySpec) ([]models.Order, int64, error) {
+	var orders []models.Order
+	var count int64
+
+	query, err := applyOrderQuery(r.db.Model(&models.Order{}), spec)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query, err = applySort(query, spec.Sort, orderSortColumns, defaultOrderSort, "orders.id")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	if err := query.Preload("Customer").Preload("OrderStatus").Offset(offset).Limit(limit).Find(&orders).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return orders, count, nil
+}
+
+// FindPage returns up to limit orders after the cursor, ordered by the first spec sort field and ID.
+// The returned cursor is nil on the last page.
+func (r *orderRepository) FindPage(spec interfaces.QuerySpec, after *interfaces.Cursor, limit int) ([]models.Order, *interfaces.Cursor, error) {
+	var orders []models.Order
+
+	sort := defaultOrderSort
+	if len(spec.Sort) > 0 {
+		sort = spec.Sort[0]
+	}
+
+	query, err := applyOrderQuery(r.db.Model(&models.Order{}), spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	query, err = applyKeyset(query, sort, after, orderKeysetColumns, "orders.id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Preload("Customer").Preload("OrderStatus").Limit(limit + 1).Find(&orders).Error; err != nil {
+		return nil, nil, err
+	}
+
+	orders, next := keysetPage(orders, limit, sort, orderKeysetColumns, func(o *models.Order) uint { return o.ID })
+	return orders, next, nil
+}
+
+func (r *orderRepository) Count(spec interfaces.QuerySpec) (int64, error) {
+	var count int64
+	query, err := applyOrderQuery(r.db.Model(&models.Order{}), spec)
+	if err != nil {
+		return 0, err
+	}
+	err = query.Count(&count).Error
+	return count, err
+}
+
+func (r *orderRepository) EstimateCount(spec interfaces.QuerySpec) (int64, error) {
+	filtered := spec.Search != "" || len(spec.Filters) > 0
+	var buildErr error
+	count, err := estimateCount(r.db, "orders", filtered, func(tx *gorm.DB) *gorm.DB {
+		query, err := applyOrderQuery(tx.Model(&models.Order{}), spec)
+		if err != nil {
+			buildErr = err
+			return tx
+		}
+		return query.Find(&[]models.Order{})
+	})
+	if buildErr != nil {
+		return 0, buildErr
+	}
+	return count, err
+}
+
+// applyOrderQuery applies the search over the order code and the customer code and name, then the spec filters
+func applyOrderQuery(query *gorm.DB, spec interfaces.QuerySpec) (*gorm.DB, error) {
+	if spec.Search != "" {
+		term := "%" + escapeLike(spec.Search) + "%"
+		query = query.Joins("JOIN customers ON customers.id = orders.customer_id").
+			Where("orders.order_code LIKE ? OR customers.customer_code LIKE ? OR customers.name LIKE ? OR customers.company_name LIKE ?",
+				term, term, term, term)
+	}
+	return applyFilters(query, spec.Filters, orderFilterColumns)
+}
+
+func (r *orderRepository) FindByID(id uint) (*models.Order, error) {
+	var order models.Order
+	err := r.db.Preload("Customer").Preload("OrderStatus").Preload("Items.Product").
+		First(&order, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// FindByCode looks up an order by code, including soft-deleted ones since codes stay unique
+func (r *orderRepository) FindByCode(code string) (*models.Order, error) {
+	var order models.Order
+	if err := r.db.Unscoped().Where("order_code = ?", code).First(&order).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// LastCodeWithPrefix returns the highest code made of prefix followed by digits, or "" when there is none.
+// Soft-deleted orders count since their codes stay reserved.
+func (r *orderRepository) LastCodeWithPrefix(prefix string) (string, error) {
+	var codes []string
+	err := r.db.Unscoped().Model(&models.Order{}).
+		Where("order_code REGEXP ?", "^"+regexp.QuoteMeta(prefix)+"[0-9]+$").
+		Order("CHAR_LENGTH(order_code) DESC, order_code DESC").
+		Limit(1).
+		Pluck("order_code", &codes).Error
+	if err != nil || len(codes) == 0 {
+		return "", err
+	}
+	return codes[0], nil
+}
+
+func (r *orderRepository) FindHistory(orderID uint) ([]models.OrderHistory, error) {
+	var history []models.OrderHistory
+	err := r.db.Preload("Status").Preload("User").
+		Where("order_id = ?", orderID).
+		Order("changed_at, id").
+		Find(&history).Error
+	return history, err
+}
+
+func (r *orderRepository) FindStatuses() ([]models.OrderStatus, error) {
+	var statuses []models.OrderStatus
+	err := r.db.Order("id").Find(&statuses).Error
+	return statuses, err
+}
+
+func (r *orderRepository) FindStatusByName(name string) (*models.OrderStatus, error) {
+	var status models.OrderStatus
+	if err := r.db.Where("status_name = ?", name).First(&status).Error; err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func (r *orderRepository) Create(order *models.Order, history *models.OrderHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Customer", "OrderStatus", "Items").Create(order).Error; err != nil {
+			return err
+		}
+		if len(order.Items) > 0 {
+			for i := range order.Items {
+				order.Items[i].OrderID = order.ID
+			}
+			if err := tx.Omit("Product").Create(&order.Items).Error; err != nil {
+				return err
+			}
+		}
+
+		history.OrderID = order.ID
+		if err := tx.Omit("Status", "User").Create(history).Error; err != nil {
+			return err
+		}
+
+		return syncCustomerOrder(tx, order, true)
+	})
+}
+
+func (r *orderRepository) Update(order *models.Order, items []models.OrderItem, history *models.OrderHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Only the edited columns are written, and only while the order keeps the status it was loaded with
+		result := tx.Model(&models.Order{}).
+			Where("id = ? AND order_status_id = ?", order.ID, order.OrderStatusID).
+			Updates(map[string]interface{}{
+				"due_date":        order.DueDate,
+				"notes":           order.Notes,
+				"total_amount":    order.TotalAmount,
+				"file_attachment": order.FileAttachment,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// MySQL reports no affected rows when nothing changed, so tell that apart from a moved order
+			var count int64
+			err := tx.Model(&models.Order{}).
+				Where("id = ? AND order_status_id = ?", order.ID, order.OrderStatusID).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return interfaces.ErrOrderStatusChanged
+			}
+		}
+
+		if items != nil {
+			if err := tx.Where("order_id = ?", order.ID).Delete(&models.OrderItem{}).Error; err != nil {
+				return err
+			}
+			for i := range items {
+				items[i].ID = 0
+				items[i].OrderID = order.ID
+			}
+			if err := tx.Omit("Product").Create(&items).Error; err != nil {
+				return err
+			}
+			order.Items = items
+		}
+
+		if history != nil {
+			history.OrderID = order.ID
+			if err := tx.Omit("Status", "User").Create(history).Error; err != nil {
+				return err
+			}
+		}
+
+		return syncCustomerOrder(tx, order, false)
+	})
+}
+
+// Delete soft-deletes the order and frees the stock it still holds
+func (r *orderRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.StockReservation{}).
+			Where("order_id = ? AND status = ?", id, models.ReservationActive).
+			Updates(map[string]interface{}{
+				"status":         models.ReservationReleased,
+				"released_at":    time.Now(),
+				"release_reason": "order deleted",
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&models.Order{}, id).Error
+	})
+}
+
+func (r *orderRepository) ChangeStatus(order *models.Order, fromStatusID uint, history *models.OrderHistory, inTx func(tx *gorm.DB) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Order{}).
+			Where("id = ? AND order_status_id = ?", order.ID, fromStatusID).
+			Update("order_status_id", order.OrderStatusID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return interfaces.ErrOrderStatusChanged
+		}
+
+		history.OrderID = order.ID
+		if err := tx.Omit("Status", "User").Create(history).Error; err != nil {
+			return err
+		}
+
+		if err := syncCustomerOrder(tx, order, false); err != nil {
+			return err
+		}
+		if inTx != nil {
+			return inTx(tx)
+		}
+		return nil
+	})
+}
+
+func (r *orderRepository) CountPackingLists(orderID uint) (int64, error) {
+	var count int64
+	err := r.db.Table("packing_lists").Where("order_id = ?", orderID).Count(&count).Error
+	return count, err
+}
+
+func (r *orderRepository) TotalsByCurrency(from, to time.Time) ([]interfaces.CurrencyDayTotal, error) {
+	var totals []interfaces.CurrencyDayTotal
+	err := r.db.Model(&models.Order{}).
+		Select("orders.currency, DATE(orders.created_at) AS day, COUNT(*) AS orders, SUM(orders.total_amount) AS total").
+		Joins("JOIN order_statuses ON order_statuses.id = orders.order_status_id").
+		Where("orders.created_at >= ? AND orders.created_at < ?", from, to).
+		Where("order_statuses.status_name <> ?", models.OrderStatusCancelled).
+		Group("orders.currency, DATE(orders.created_at)").
+		Order("day, orders.currency").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// syncCustomerOrder keeps the customer_orders tracking row in step with the order
+func syncCustomerOrder(tx *gorm.DB, order *models.Order, created bool) error {
+	var names []string
+	if err := tx.Model(&models.OrderStatus{}).Where("id = ?", order.OrderStatusID).Pluck("status_name", &names).Error; err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return errors.New("order status not found")
+	}
+	status := names[0]
+
+	if created {
+		return tx.Table("customer_orders").Create(map[string]interface{}{
+			"customer_id":  order.CustomerID,
+			"order_id":     order.ID,
+			"order_status": status,
+			"total_amount": order.TotalAmount,
+			"currency":     order.Currency,
+		}).Error
+	}
+	return tx.Table("customer_orders").Where("order_id = ?", order.ID).Updates(map[string]interface{}{
+		"order_status": status,
+		"total_amount": order.TotalAmount,
+		"currency":     order.Currency,
+	}).Error
+}
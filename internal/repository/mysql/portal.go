@@ -0,0 +1,180 @@
+// File: internal/repository/mysql/portal.go
+// Tạo tại: internal/repository/mysql/portal.go
+// Mục đích: MySQL implementation cho đơn hàng, giao hàng, mẫu và lap dip khách hàng xem trên portal
+
+package mysql
+
+import (
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type portalRepository struct {
+	db *gorm.DB
+}
+
+func NewPortalRepository(db *gorm.DB) interfaces.PortalRepository {
+	return &portalRepository{db: db}
+}
+
+func (r *portalRepository) FindOrders(customerID uint, status string, page, limit int) ([]models.Order, int64, error) {
+	var orders []models.Order
+	var count int64
+
+	query := r.db.Model(&models.Order{}).Where("orders.customer_id = ?", customerID)
+	if status != "" {
+		query = query.Joins("JOIN order_statuses ON order_statuses.id = orders.order_status_id").
+			Where("order_statuses.status_name = ?", status)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("OrderStatus").
+		Order("orders.created_at DESC, orders.id DESC").
+		Offset(offset).Limit(limit).
+		Find(&orders).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return orders, count, nil
+}
+
+func (r *portalRepository) FindOrder(customerID, orderID uint) (*models.Order, error) {
+	var order models.Order
+	err := r.db.Preload("OrderStatus").Preload("Items.Product").
+		Where("id = ? AND customer_id = ?", orderID, customerID).
+		First(&order).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *portalRepository) FindShipments(customerID uint, orderID uint, page, limit int) ([]models.Shipment, int64, error) {
+	var shipments []models.Shipment
+	var count int64
+
+	query := r.db.Model(&models.Shipment{}).
+		Joins("JOIN orders ON orders.id = shipping.order_id AND orders.deleted_at IS NULL").
+		Where("orders.customer_id = ?", customerID)
+	if orderID != 0 {
+		query = query.Where("shipping.order_id = ?", orderID)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("Order").
+		Order("shipping.shipping_date IS NULL, shipping.shipping_date DESC, shipping.id DESC").
+		Offset(offset).Limit(limit).
+		Find(&shipments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return shipments, count, nil
+}
+
+func (r *portalRepository) FindSampleDispatches(customerID uint, page, limit int) ([]models.SampleDispatch, int64, error) {
+	var dispatches []models.SampleDispatch
+	var count int64
+
+	query := r.db.Model(&models.SampleDispatch{}).Where("customer_id = ?", customerID)
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("SampleProduct").
+		Order("dispatch_date DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&dispatches).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return dispatches, count, nil
+}
+
+// lapDipDispatchesOf restricts lap dip dispatches to the tests of one customer
+func lapDipDispatchesOf(db *gorm.DB, customerID uint) *gorm.DB {
+	return db.Model(&models.LapDipDispatch{}).
+		Joins("JOIN lap_dip_tests ON lap_dip_tests.id = lap_dip_dispatches.ld_test_id").
+		Where("lap_dip_tests.customer_id = ?", customerID)
+}
+
+func (r *portalRepository) FindLapDipDispatches(customerID uint, status string, page, limit int) ([]models.LapDipDispatch, int64, error) {
+	var dispatches []models.LapDipDispatch
+	var count int64
+
+	query := lapDipDispatchesOf(r.db, customerID)
+	if status != "" {
+		query = query.Where("lap_dip_dispatches.status = ?", status)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("LapDipTest").
+		Order("lap_dip_dispatches.dispatch_date DESC, lap_dip_dispatches.id DESC").
+		Offset(offset).Limit(limit).
+		Find(&dispatches).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return dispatches, count, nil
+}
+
+func (r *portalRepository) FindLapDipDispatch(customerID, dispatchID uint) (*models.LapDipDispatch, error) {
+	var dispatch models.LapDipDispatch
+	err := lapDipDispatchesOf(r.db, customerID).
+		Preload("LapDipTest").
+		Where("lap_dip_dispatches.id = ?", dispatchID).
+		First(&dispatch).Error
+	if err != nil {
+		return nil, err
+	}
+	return &dispatch, nil
+}
+
+func (r *portalRepository) RespondLapDip(dispatch *models.LapDipDispatch, selection *models.LapDipFinalSelection) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the dispatch so two buyers answering at once cannot both win
+		var current models.LapDipDispatch
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, dispatch.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != models.LapDipDispatched {
+			return interfaces.ErrLapDipAnswered
+		}
+
+		if err := tx.Model(&models.LapDipDispatch{}).Where("id = ?", dispatch.ID).Updates(map[string]interface{}{
+			"status":            dispatch.Status,
+			"customer_response": dispatch.CustomerResponse,
+			"response_date":     dispatch.ResponseDate,
+			"final_ld_code":     dispatch.FinalLDCode,
+		}).Error; err != nil {
+			return err
+		}
+
+		test := map[string]interface{}{"status": dispatch.Status}
+		if dispatch.Status == models.LapDipApproved {
+			test["selected_ld_code"] = dispatch.FinalLDCode
+		}
+		if err := tx.Model(&models.LapDipTest{}).Where("id = ?", dispatch.LDTestID).Updates(test).Error; err != nil {
+			return err
+		}
+
+		if selection != nil {
+			return tx.Create(selection).Error
+		}
+		return nil
+	})
+}
@@ -0,0 +1,76 @@
+// File: internal/repository/mysql/product.go
+// Tạo tại: internal/repository/mysql/product.go
+// Mục đích: MySQL implementation cho Product Repository (auto-load relationships và images)
+
+package mysql
+
+import (
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type productRepository struct {
+	db *gorm.DB
+}
+
+func NewProductRepository(db *gorm.DB) interfaces.ProductRepository {
+	return &productRepository{db: db}
+}
+
+func (r *productRepository) FindAll(page, limit int, search, category string) ([]models.Product, int64, error) {
+	var products []models.Product
+	var count int64
+
+	query := r.db.Model(&models.Product{})
+
+	// Apply search
+	if search != "" {
+		query = query.Joins("JOIN product_names ON products.product_name_id = product_names.id").
+			Where("products.sku LIKE ? OR product_names.product_name_vi LIKE ? OR product_names.product_name_en LIKE ?",
+				"%"+search+"%", "%"+search+"%", "%"+search+"%")
+	}
+
+	// Apply category filter
+	if category != "" {
+		query = query.Where("products.category_id = ?", category)
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Offset(offset).Limit(limit).
+		Preload("ProductName").
+		Preload("Category").
+		Preload("Images", orderImages).
+		Order("products.created_at DESC").
+		Find(&products).Error
+
+	return products, count, err
+}
+
+func (r *productRepository) FindByID(id uint) (*models.Product, error) {
+	var product models.Product
+	if err := r.db.Preload("ProductName").Preload("Category").Preload("Images", orderImages).
+		First(&product, id).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *productRepository) FindBySKU(sku string) (*models.Product, error) {
+	var product models.Product
+	if err := r.db.Preload("ProductName").Preload("Category").Preload("Images", orderImages).
+		Where("sku = ?", sku).First(&product).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// orderImages sorts preloaded images by display order
+func orderImages(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC, id ASC")
+}
@@ -41,3 +41,108 @@ func (r *productCategoryRepository) Update(category *models.ProductCategory) err
 func (r *productCategoryRepository) Delete(id uint) error {
 	return r.db.Delete(&models.ProductCategory{}, id).Error
 }
+
+// UpdateParent sets the parent of a category; nil makes it a root category
+func (r *productCategoryRepository) UpdateParent(id uint, parentID *uint) error {
+	return r.db.Model(&models.ProductCategory{}).Where("id = ?", id).
+		Update("parent_category_id", parentID).Error
+}
+
+// CountProductsByCategory returns the number of products directly in each category
+func (r *productCategoryRepository) CountProductsByCategory() (map[uint]int64, error) {
+	return countByCategory(r.db.Model(&models.Product{}), "products.category_id")
+}
+
+// CountSamplesByCategory returns the number of samples directly in each category
+func (r *productCategoryRepository) CountSamplesByCategory() (map[uint]int64, error) {
+	return countByCategory(r.db.Model(&models.SampleProduct{}), "sample_products.category_id")
+}
+
+// DeleteAndReassign moves child categories, products and samples to targetID, then deletes the category
+func (r *productCategoryRepository) DeleteAndReassign(id, targetID uint) (*interfaces.CategoryDeleteResult, error) {
+	result := &interfaces.CategoryDeleteResult{}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		update := tx.Model(&models.ProductCategory{}).Where("parent_category_id = ?", id).
+			Update("parent_category_id", targetID)
+		if update.Error != nil {
+			return update.Error
+		}
+		result.ChildCategories = update.RowsAffected
+
+		update = tx.Model(&models.Product{}).Where("category_id = ?", id).Update("category_id", targetID)
+		if update.Error != nil {
+			return update.Error
+		}
+		result.Products = update.RowsAffected
+
+		update = tx.Model(&models.SampleProduct{}).Where("category_id = ?", id).Update("category_id", targetID)
+		if update.Error != nil {
+			return update.Error
+		}
+		result.Samples = update.RowsAffected
+
+		deleted := tx.Delete(&models.ProductCategory{}, id)
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		result.Categories = deleted.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteCascade deletes the categories with every product and sample in them
+func (r *productCategoryRepository) DeleteCascade(ids []uint) (*interfaces.CategoryDeleteResult, error) {
+	result := &interfaces.CategoryDeleteResult{}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Where("category_id IN ?", ids).Delete(&models.Product{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		result.Products = deleted.RowsAffected
+
+		deleted = tx.Where("category_id IN ?", ids).Delete(&models.SampleProduct{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		result.Samples = deleted.RowsAffected
+
+		deleted = tx.Where("id IN ?", ids).Delete(&models.ProductCategory{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		result.Categories = deleted.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func countByCategory(query *gorm.DB, column string) (map[uint]int64, error) {
+	var rows []struct {
+		CategoryID uint
+		Count      int64
+	}
+	err := query.Select(column + " AS category_id, COUNT(*) AS count").
+		Group(column).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Count
+	}
+	return counts, nil
+}
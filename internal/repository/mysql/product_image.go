@@ -0,0 +1,85 @@
+// File: internal/repository/mysql/product_image.go
+// Tạo tại: internal/repository/mysql/product_image.go
+// Mục đích: MySQL implementation cho product_images (ảnh chính, thứ tự hiển thị)
+
+package mysql
+
+import (
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type productImageRepository struct {
+	db *gorm.DB
+}
+
+func NewProductImageRepository(db *gorm.DB) interfaces.ProductImageRepository {
+	return &productImageRepository{db: db}
+}
+
+func (r *productImageRepository) FindByProductID(productID uint) ([]models.ProductImage, error) {
+	var images []models.ProductImage
+	err := r.db.Where("product_id = ?", productID).
+		Order("sort_order ASC, id ASC").
+		Find(&images).Error
+	return images, err
+}
+
+func (r *productImageRepository) FindByID(id uint) (*models.ProductImage, error) {
+	var image models.ProductImage
+	if err := r.db.First(&image, id).Error; err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+func (r *productImageRepository) NextSortOrder(productID uint) (int, error) {
+	var maxOrder *int
+	err := r.db.Model(&models.ProductImage{}).
+		Where("product_id = ?", productID).
+		Select("MAX(sort_order)").
+		Scan(&maxOrder).Error
+	if err != nil || maxOrder == nil {
+		return 0, err
+	}
+	return *maxOrder + 1, nil
+}
+
+func (r *productImageRepository) Create(images []models.ProductImage) error {
+	if len(images) == 0 {
+		return nil
+	}
+	return r.db.Create(&images).Error
+}
+
+func (r *productImageRepository) Delete(id uint) error {
+	return r.db.Delete(&models.ProductImage{}, id).Error
+}
+
+func (r *productImageRepository) SetPrimary(productID uint, imageID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ProductImage{}).
+			Where("product_id = ?", productID).
+			Update("is_primary", false).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.ProductImage{}).
+			Where("id = ? AND product_id = ?", imageID, productID).
+			Update("is_primary", true).Error
+	})
+}
+
+func (r *productImageRepository) Reorder(productID uint, imageIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, imageID := range imageIDs {
+			if err := tx.Model(&models.ProductImage{}).
+				Where("id = ? AND product_id = ?", imageID, productID).
+				Update("sort_order", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -34,8 +34,17 @@ func (r *productNameRepository) Create(productName *models.ProductName) error {
 	return r.db.Create(productName).Error
 }
 
+// Update saves the product name and refreshes search_text of its samples, which contains the name
 func (r *productNameRepository) Update(productName *models.ProductName) error {
-	return r.db.Save(productName).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(productName).Error; err != nil {
+			return err
+		}
+		samples := &sampleRepository{db: tx}
+		_, err := samples.writeSearchText(tx.Model(&models.SampleProduct{}).Where("product_name_id = ?", productName.ID),
+			searchTextBatchSize)
+		return err
+	})
 }
 
 func (r *productNameRepository) Delete(id uint) error {
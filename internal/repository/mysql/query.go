@@ -0,0 +1,172 @@
+// File: internal/repository/mysql/query.go
+// Tạo tại: internal/repository/mysql/query.go
+// Mục đích: Tiện ích truy vấn dùng chung: lọc và sắp xếp theo danh sách cột cho phép, phân trang keyset, escape LIKE
+
+package mysql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+// applyFilters applies spec filters, resolving field names through the columns whitelist
+func applyFilters(query *gorm.DB, filters []interfaces.Filter, columns map[string]string) (*gorm.DB, error) {
+	for _, filter := range filters {
+		column, ok := columns[filter.Field]
+		if !ok {
+			return nil, fmt.Errorf("unsupported filter field: %s", filter.Field)
+		}
+
+		switch filter.Op {
+		case interfaces.FilterEq:
+			query = query.Where(column+" = ?", filter.Value)
+		case interfaces.FilterIn:
+			query = query.Where(column+" IN ?", filter.Value)
+		case interfaces.FilterContains:
+			query = query.Where(column+" LIKE ?", "%"+escapeLike(fmt.Sprint(filter.Value))+"%")
+		case interfaces.FilterGte:
+			query = query.Where(column+" >= ?", filter.Value)
+		case interfaces.FilterLte:
+			query = query.Where(column+" <= ?", filter.Value)
+		case interfaces.FilterLt:
+			query = query.Where(column+" < ?", filter.Value)
+		default:
+			return nil, fmt.Errorf("unsupported filter operator: %s", filter.Op)
+		}
+	}
+	return query, nil
+}
+
+// applySort orders by the spec sort fields (or the default), always ending with idColumn for a stable order
+func applySort(query *gorm.DB, sorts []interfaces.SortField, columns map[string]string, defaultSort interfaces.SortField, idColumn string) (*gorm.DB, error) {
+	if len(sorts) == 0 {
+		sorts = []interfaces.SortField{defaultSort}
+	}
+
+	hasID := false
+	for _, sort := range sorts {
+		column, ok := columns[sort.Field]
+		if !ok {
+			return nil, fmt.Errorf("unsupported sort field: %s", sort.Field)
+		}
+		if column == idColumn {
+			hasID = true
+		}
+		query = query.Order(sortClause(column, sort.Desc))
+	}
+	if !hasID {
+		query = query.Order(sortClause(idColumn, sorts[len(sorts)-1].Desc))
+	}
+	return query, nil
+}
+
+// withoutField returns the filters that do not target field (used for disjunctive facet counts)
+func withoutField(filters []interfaces.Filter, field string) []interfaces.Filter {
+	result := make([]interfaces.Filter, 0, len(filters))
+	for _, filter := range filters {
+		if filter.Field != field {
+			result = append(result, filter)
+		}
+	}
+	return result
+}
+
+func sortClause(column string, desc bool) string {
+	if desc {
+		return column + " DESC"
+	}
+	return column + " ASC"
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// keysetColumn is a sortable column usable for cursor pagination of T
+type keysetColumn[T any] struct {
+	expr  string                   // SQL expression, COALESCE'd for nullable columns
+	value func(row *T) interface{} // Value of the column in a loaded row
+}
+
+// applyKeyset orders by sort and the ID column, starting after the cursor position when given
+func applyKeyset[T any](query *gorm.DB, sort interfaces.SortField, after *interfaces.Cursor, columns map[string]keysetColumn[T], idColumn string) (*gorm.DB, error) {
+	column, ok := columns[sort.Field]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sort field: %s", sort.Field)
+	}
+
+	if after != nil {
+		op := ">"
+		if sort.Desc {
+			op = "<"
+		}
+		value := after.SortValue()
+		query = query.Where(
+			fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", column.expr, op, column.expr, idColumn, op),
+			value, value, after.ID,
+		)
+	}
+
+	query = query.Order(sortClause(column.expr, sort.Desc))
+	if column.expr != idColumn {
+		query = query.Order(sortClause(idColumn, sort.Desc))
+	}
+	return query, nil
+}
+
+// keysetPage trims rows fetched with limit+1 and returns the cursor of the last row when more rows follow
+func keysetPage[T any](rows []T, limit int, sort interfaces.SortField, columns map[string]keysetColumn[T], id func(row *T) uint) ([]T, *interfaces.Cursor) {
+	if len(rows) <= limit {
+		return rows, nil
+	}
+	rows = rows[:limit]
+	last := &rows[len(rows)-1]
+	return rows, interfaces.NewCursor(sort, columns[sort.Field].value(last), id(last))
+}
+
+// estimateCount returns an approximate row count: table statistics when unfiltered,
+// otherwise the optimizer's row estimate from EXPLAIN of the list query
+func estimateCount(db *gorm.DB, table string, filtered bool, build func(tx *gorm.DB) *gorm.DB) (int64, error) {
+	if !filtered {
+		var rows int64
+		err := db.Raw("SELECT COALESCE(TABLE_ROWS, 0) FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?", table).
+			Scan(&rows).Error
+		return rows, err
+	}
+
+	sql := db.ToSQL(build)
+	var plan []map[string]interface{}
+	if err := db.Raw("EXPLAIN " + sql).Scan(&plan).Error; err != nil {
+		return 0, err
+	}
+
+	var estimate float64
+	for _, step := range plan {
+		rows := explainNumber(step["rows"])
+		if percent, ok := step["filtered"]; ok && percent != nil {
+			rows = rows * explainNumber(percent) / 100
+		}
+		if rows > estimate {
+			estimate = rows
+		}
+	}
+	return int64(math.Round(estimate)), nil
+}
+
+func explainNumber(value interface{}) float64 {
+	switch v := value.(type) {
+	case []byte:
+		n, _ := strconv.ParseFloat(string(v), 64)
+		return n
+	case nil:
+		return 0
+	default:
+		n, _ := strconv.ParseFloat(fmt.Sprint(v), 64)
+		return n
+	}
+}
@@ -0,0 +1,314 @@
+// File: internal/repository/mysql/quotation.go
+// Tạo tại: internal/repository/mysql/quotation.go
+// Mục đích: MySQL implementation cho bảng giá, báo giá và dòng báo giá
+
+package mysql
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type priceListRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceListRepository(db *gorm.DB) interfaces.PriceListRepository {
+	return &priceListRepository{db: db}
+}
+
+// validOn restricts price lists to the active ones whose validity covers the date
+func validOn(query *gorm.DB, date time.Time) *gorm.DB {
+	day := date.Format("2006-01-02")
+	return query.Where("price_lists.is_active = ?", true).
+		Where("price_lists.valid_from IS NULL OR price_lists.valid_from <= ?", day).
+		Where("price_lists.valid_to IS NULL OR price_lists.valid_to >= ?", day)
+}
+
+func (r *priceListRepository) FindAll(filter interfaces.PriceListFilter, page, limit int) ([]models.PriceList, int64, error) {
+	var lists []models.PriceList
+	var count int64
+
+	query := r.db.Model(&models.PriceList{})
+	if filter.CustomerID != 0 {
+		query = query.Where("price_lists.customer_id = ?", filter.CustomerID)
+	}
+	if filter.PriceTier != "" {
+		query = query.Where("price_lists.price_tier = ?", filter.PriceTier)
+	}
+	if filter.Currency != "" {
+		query = query.Where("price_lists.currency = ?", filter.Currency)
+	}
+	if filter.ActiveOn != nil {
+		query = validOn(query, *filter.ActiveOn)
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	err := query.Preload("Customer").
+		Order("price_lists.created_at DESC, price_lists.id DESC").
+		Offset(offset).Limit(limit).
+		Find(&lists).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return lists, count, nil
+}
+
+func (r *priceListRepository) FindByID(id uint) (*models.PriceList, error) {
+	var list models.PriceList
+	err := r.db.Preload("Customer").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("product_id, min_quantity") }).
+		Preload("Items.Product").
+		First(&list, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (r *priceListRepository) Create(list *models.PriceList) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Customer", "Items").Create(list).Error; err != nil {
+			return err
+		}
+		if len(list.Items) == 0 {
+			return nil
+		}
+		for i := range list.Items {
+			list.Items[i].PriceListID = list.ID
+		}
+		return tx.Omit("Product").Create(&list.Items).Error
+	})
+}
+
+func (r *priceListRepository) Update(list *models.PriceList, items []models.PriceListItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if items != nil {
+			if err := tx.Where("price_list_id = ?", list.ID).Delete(&models.PriceListItem{}).Error; err != nil {
+				return err
+			}
+			if len(items) > 0 {
+				for i := range items {
+					items[i].ID = 0
+					items[i].PriceListID = list.ID
+				}
+				if err := tx.Omit("Product").Create(&items).Error; err != nil {
+					return err
+				}
+			}
+			list.Items = items
+		}
+
+		return tx.Omit("Customer", "Items").Save(list).Error
+	})
+}
+
+func (r *priceListRepository) Delete(id uint) error {
+	return r.db.Delete(&models.PriceList{}, id).Error
+}
+
+func (r *priceListRepository) FindApplicable(customerID uint, tier, currency string, productID uint, quantity float64, date time.Time) (*interfaces.ApplicablePrice, error) {
+	// Lists of the customer, of the tier and general ones, most specific first
+	scope := r.db.Where("price_lists.customer_id = ?", customerID).
+		Or("price_lists.customer_id IS NULL AND COALESCE(price_lists.price_tier, '') = ''")
+	levelOrder := "CASE WHEN price_lists.customer_id IS NOT NULL THEN 0 ELSE 2 END"
+	if tier != "" {
+		scope = scope.Or("price_lists.customer_id IS NULL AND price_lists.price_tier = ?", tier)
+		levelOrder = "CASE WHEN price_lists.customer_id IS NOT NULL THEN 0 WHEN COALESCE(price_lists.price_tier, '') <> '' THEN 1 ELSE 2 END"
+	}
+
+	var list models.PriceList
+	err := validOn(r.db.Model(&models.PriceList{}), date).
+		Where("price_lists.currency = ?", currency).
+		Where(scope).
+		Where("EXISTS (SELECT 1 FROM price_list_items i WHERE i.price_list_id = price_lists.id AND i.product_id = ? AND i.min_quantity <= ?)",
+			productID, quantity).
+		Order(levelOrder + ", price_lists.created_at DESC, price_lists.id DESC").
+		First(&list).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var item models.PriceListItem
+	err = r.db.Where("price_list_id = ? AND product_id = ? AND min_quantity <= ?", list.ID, productID, quantity).
+		Order("min_quantity DESC").
+		First(&item).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces.ApplicablePrice{PriceList: list, Item: item}, nil
+}
+
+type quotationRepository struct {
+	db *gorm.DB
+}
+
+func NewQuotationRepository(db *gorm.DB) interfaces.QuotationRepository {
+	return &quotationRepository{db: db}
+}
+
+func (r *quotationRepository) FindAll(filter interfaces.QuotationFilter, page, limit int) ([]models.Quotation, int64, error) {
+	var quotations []models.Quotation
+	var count int64
+
+	query := r.db.Model(&models.Quotation{})
+	if filter.Search != "" {
+		term := "%" + escapeLike(filter.Search) + "%"
+		query = query.Joins("JOIN customers ON customers.id = quotations.customer_id").
+			Where("quotations.quotation_code LIKE ? OR customers.customer_code LIKE ? OR customers.name LIKE ? OR customers.company_name LIKE ?",
+				term, term, term, term)
+	}
+	if filter.CustomerID != 0 {
+		query = query.Where("quotations.customer_id = ?", filter.CustomerID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where(quotationStatusScope(r.db, filter.Statuses, filter.Today))
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	err := query.Preload("Customer").
+		Order("quotations.created_at DESC, quotations.id DESC").
+		Offset(offset).Limit(limit).
+		Find(&quotations).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return quotations, count, nil
+}
+
+// quotationStatusScope matches the statuses, where draft and sent turn into expired past valid_until
+func quotationStatusScope(db *gorm.DB, statuses []string, today time.Time) *gorm.DB {
+	day := today.Format("2006-01-02")
+	scope := db.Where("1 = 0")
+	var closed []string
+	for _, status := range statuses {
+		switch status {
+		case models.QuotationDraft, models.QuotationSent:
+			scope = scope.Or("quotations.status = ? AND quotations.valid_until >= ?", status, day)
+		case models.QuotationExpired:
+			scope = scope.Or("quotations.status IN ? AND quotations.valid_until < ?",
+				[]string{models.QuotationDraft, models.QuotationSent}, day)
+		default:
+			closed = append(closed, status)
+		}
+	}
+	if len(closed) > 0 {
+		scope = scope.Or("quotations.status IN ?", closed)
+	}
+	return scope
+}
+
+func (r *quotationRepository) FindByID(id uint) (*models.Quotation, error) {
+	var quotation models.Quotation
+	err := r.db.Preload("Customer").Preload("Items.Product.ProductName").
+		First(&quotation, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &quotation, nil
+}
+
+// FindByCode looks up a quotation by code, including soft-deleted ones since codes stay unique
+func (r *quotationRepository) FindByCode(code string) (*models.Quotation, error) {
+	var quotation models.Quotation
+	if err := r.db.Unscoped().Where("quotation_code = ?", code).First(&quotation).Error; err != nil {
+		return nil, err
+	}
+	return &quotation, nil
+}
+
+// LastCodeWithPrefix returns the highest code made of prefix followed by digits, or "" when there is none.
+// Soft-deleted quotations count since their codes stay reserved.
+func (r *quotationRepository) LastCodeWithPrefix(prefix string) (string, error) {
+	var codes []string
+	err := r.db.Unscoped().Model(&models.Quotation{}).
+		Where("quotation_code REGEXP ?", "^"+regexp.QuoteMeta(prefix)+"[0-9]+$").
+		Order("CHAR_LENGTH(quotation_code) DESC, quotation_code DESC").
+		Limit(1).
+		Pluck("quotation_code", &codes).Error
+	if err != nil || len(codes) == 0 {
+		return "", err
+	}
+	return codes[0], nil
+}
+
+func (r *quotationRepository) Create(quotation *models.Quotation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Customer", "Items").Create(quotation).Error; err != nil {
+			return err
+		}
+		if len(quotation.Items) == 0 {
+			return nil
+		}
+		for i := range quotation.Items {
+			quotation.Items[i].QuotationID = quotation.ID
+		}
+		return tx.Omit("Product").Create(&quotation.Items).Error
+	})
+}
+
+func (r *quotationRepository) Update(quotation *models.Quotation, items []models.QuotationItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if items != nil {
+			if err := tx.Where("quotation_id = ?", quotation.ID).Delete(&models.QuotationItem{}).Error; err != nil {
+				return err
+			}
+			if len(items) > 0 {
+				for i := range items {
+					items[i].ID = 0
+					items[i].QuotationID = quotation.ID
+				}
+				if err := tx.Omit("Product").Create(&items).Error; err != nil {
+					return err
+				}
+			}
+			quotation.Items = items
+		}
+
+		return tx.Omit("Customer", "Items").Save(quotation).Error
+	})
+}
+
+func (r *quotationRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Quotation{}, id).Error
+}
+
+func (r *quotationRepository) ChangeStatus(quotation *models.Quotation, fromStatuses []string) error {
+	result := r.db.Model(&models.Quotation{}).
+		Where("id = ? AND status IN ?", quotation.ID, fromStatuses).
+		Updates(map[string]interface{}{
+			"status":       quotation.Status,
+			"order_id":     quotation.OrderID,
+			"responded_at": quotation.RespondedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrQuotationStatusChanged
+	}
+	return nil
+}
@@ -4,8 +4,12 @@
 package mysql
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/godiidev/appsynex/internal/domain/models"
 	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/textsearch"
 	"gorm.io/gorm"
 )
 
@@ -17,40 +21,74 @@ func NewSampleRepository(db *gorm.DB) interfaces.SampleRepository {
 	return &sampleRepository{db: db}
 }
 
-func (r *sampleRepository) FindAll(page, limit int, search, category string, filters map[string]interface{}) ([]models.SampleProduct, int64, error) {
+// sampleFilterColumns whitelists the fields usable in sample filters
+var sampleFilterColumns = map[string]string{
+	"id":                 "sample_products.id",
+	"sku":                "sample_products.sku",
+	"product_name_id":    "sample_products.product_name_id",
+	"category_id":        "sample_products.category_id",
+	"sample_type":        "sample_products.sample_type",
+	"weight":             "sample_products.weight",
+	"width":              "sample_products.width",
+	"color":              "sample_products.color",
+	"color_code":         "sample_products.color_code",
+	"remaining_quantity": "sample_products.remaining_quantity",
+	"fiber_content":      "sample_products.fiber_content",
+	"source":             "sample_products.source",
+	"sample_location":    "sample_products.sample_location",
+	"barcode":            "sample_products.barcode",
+	"created_at":         "sample_products.created_at",
+	"updated_at":         "sample_products.updated_at",
+}
+
+// sampleSortColumns whitelists the indexed columns samples can be sorted by
+var sampleSortColumns = map[string]string{
+	"id":                 "sample_products.id",
+	"sku":                "sample_products.sku",
+	"category_id":        "sample_products.category_id",
+	"sample_type":        "sample_products.sample_type",
+	"weight":             "sample_products.weight",
+	"width":              "sample_products.width",
+	"color":              "sample_products.color",
+	"remaining_quantity": "sample_products.remaining_quantity",
+	"sample_location":    "sample_products.sample_location",
+	"created_at":         "sample_products.created_at",
+	"updated_at":         "sample_products.updated_at",
+}
+
+// sampleFacetColumns whitelists the fields samples can be grouped by for facet counts
+var sampleFacetColumns = map[string]string{
+	"sample_type":     "sample_products.sample_type",
+	"category_id":     "sample_products.category_id",
+	"color":           "sample_products.color",
+	"fiber_content":   "sample_products.fiber_content",
+	"sample_location": "sample_products.sample_location",
+}
+
+var defaultSampleSort = interfaces.SortField{Field: "created_at", Desc: true}
+
+// sampleKeysetColumns are the sort columns usable for cursor pagination
+var sampleKeysetColumns = map[string]keysetColumn[models.SampleProduct]{
+	"id":                 {"sample_products.id", func(s *models.SampleProduct) interface{} { return s.ID }},
+	"sku":                {"sample_products.sku", func(s *models.SampleProduct) interface{} { return s.SKU }},
+	"category_id":        {"sample_products.category_id", func(s *models.SampleProduct) interface{} { return s.CategoryID }},
+	"sample_type":        {"COALESCE(sample_products.sample_type, '')", func(s *models.SampleProduct) interface{} { return s.SampleType }},
+	"weight":             {"COALESCE(sample_products.weight, 0)", func(s *models.SampleProduct) interface{} { return s.Weight }},
+	"width":              {"COALESCE(sample_products.width, 0)", func(s *models.SampleProduct) interface{} { return s.Width }},
+	"color":              {"COALESCE(sample_products.color, '')", func(s *models.SampleProduct) interface{} { return s.Color }},
+	"remaining_quantity": {"sample_products.remaining_quantity", func(s *models.SampleProduct) interface{} { return s.RemainingQuantity }},
+	"sample_location":    {"COALESCE(sample_products.sample_location, '')", func(s *models.SampleProduct) interface{} { return s.SampleLocation }},
+	"created_at":         {"sample_products.created_at", func(s *models.SampleProduct) interface{} { return s.CreatedAt }},
+	"updated_at":         {"sample_products.updated_at", func(s *models.SampleProduct) interface{} { return s.UpdatedAt }},
+}
+
+func (r *sampleRepository) FindAll(page, limit int, spec interfaces.QuerySpec) ([]models.SampleProduct, int64, error) {
 	var samples []models.SampleProduct
 	var count int64
 
-	query := r.db.Model(&models.SampleProduct{})
-
-	// Apply search
-	if search != "" {
-		query = query.Joins("JOIN product_names ON sample_products.product_name_id = product_names.id").
-			Where("sample_products.sku LIKE ? OR product_names.product_name_vi LIKE ? OR product_names.product_name_en LIKE ?",
-				"%"+search+"%", "%"+search+"%", "%"+search+"%")
-	}
-
-	// Apply category filter
-	if category != "" {
-		query = query.Where("category_id = ?", category)
-	}
-
-	// Apply other filters
-	for key, value := range filters {
-		switch key {
-		case "weight_min":
-			query = query.Where("weight >= ?", value)
-		case "weight_max":
-			query = query.Where("weight <= ?", value)
-		case "width_min":
-			query = query.Where("width >= ?", value)
-		case "width_max":
-			query = query.Where("width <= ?", value)
-		case "sample_type":
-			query = query.Where("sample_type = ?", value)
-		case "color":
-			query = query.Where("color LIKE ?", "%"+value.(string)+"%")
-		}
+	query, err := applySampleQuery(r.db.Model(&models.SampleProduct{}), spec)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// Count total
@@ -58,21 +96,262 @@ func (r *sampleRepository) FindAll(page, limit int, search, category string, fil
 		return nil, 0, err
 	}
 
+	query, err = applySort(query, spec.Sort, sampleSortColumns, defaultSampleSort, "sample_products.id")
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Apply pagination and ALWAYS preload relationships
 	offset := (page - 1) * limit
-	err := query.Offset(offset).Limit(limit).
-		Preload("ProductName").   // Auto load product name
-		Preload("Category").      // Auto load category
-		Order("created_at DESC"). // Order by newest first
+	err = query.Offset(offset).Limit(limit).
+		Preload("ProductName"). // Auto load product name
+		Preload("Category").    // Auto load category
+		Preload("Images", orderImages).
 		Find(&samples).Error
 
 	return samples, count, err
 }
 
+// FindPage returns up to limit samples after the cursor, ordered by the first spec sort field and ID.
+// The returned cursor is nil on the last page.
+func (r *sampleRepository) FindPage(spec interfaces.QuerySpec, after *interfaces.Cursor, limit int) ([]models.SampleProduct, *interfaces.Cursor, error) {
+	var samples []models.SampleProduct
+
+	sort := defaultSampleSort
+	if len(spec.Sort) > 0 {
+		sort = spec.Sort[0]
+	}
+
+	query, err := applySampleQuery(r.db.Model(&models.SampleProduct{}), spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	query, err = applyKeyset(query, sort, after, sampleKeysetColumns, "sample_products.id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = query.Limit(limit+1).
+		Preload("ProductName").
+		Preload("Category").
+		Preload("Images", orderImages).
+		Find(&samples).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	samples, next := keysetPage(samples, limit, sort, sampleKeysetColumns, func(s *models.SampleProduct) uint { return s.ID })
+	return samples, next, nil
+}
+
+func (r *sampleRepository) Count(spec interfaces.QuerySpec) (int64, error) {
+	var count int64
+	query, err := applySampleQuery(r.db.Model(&models.SampleProduct{}), spec)
+	if err != nil {
+		return 0, err
+	}
+	err = query.Count(&count).Error
+	return count, err
+}
+
+func (r *sampleRepository) EstimateCount(spec interfaces.QuerySpec) (int64, error) {
+	filtered := spec.Search != "" || len(spec.Filters) > 0
+	var buildErr error
+	count, err := estimateCount(r.db, "sample_products", filtered, func(tx *gorm.DB) *gorm.DB {
+		query, err := applySampleQuery(tx.Model(&models.SampleProduct{}), spec)
+		if err != nil {
+			buildErr = err
+			return tx
+		}
+		return query.Find(&[]models.SampleProduct{})
+	})
+	if buildErr != nil {
+		return 0, buildErr
+	}
+	return count, err
+}
+
+// FindAllInBatches walks every sample matching the spec in ID order, batchSize rows at a time
+func (r *sampleRepository) FindAllInBatches(spec interfaces.QuerySpec, batchSize int, fn func([]models.SampleProduct) error) error {
+	var samples []models.SampleProduct
+
+	query, err := applySampleQuery(r.db.Model(&models.SampleProduct{}), spec)
+	if err != nil {
+		return err
+	}
+
+	return query.
+		Preload("ProductName").
+		Preload("Category").
+		FindInBatches(&samples, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(samples)
+		}).Error
+}
+
+// FacetCounts counts samples per value of field. Filters on the field itself are ignored
+// so every value of a multi-select facet keeps its count.
+func (r *sampleRepository) FacetCounts(spec interfaces.QuerySpec, field string, limit int) ([]interfaces.FacetCount, error) {
+	column, ok := sampleFacetColumns[field]
+	if !ok {
+		return nil, fmt.Errorf("unsupported facet field: %s", field)
+	}
+
+	spec.Filters = withoutField(spec.Filters, field)
+	query, err := applySampleQuery(r.db.Model(&models.SampleProduct{}), spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var facets []interfaces.FacetCount
+	err = query.
+		Select("COALESCE(CAST(" + column + " AS CHAR), '') AS value, COUNT(*) AS count").
+		Where(column + " IS NOT NULL AND " + column + " <> ''").
+		Group(column).
+		Order("count DESC").
+		Order("value ASC").
+		Limit(limit).
+		Scan(&facets).Error
+
+	return facets, err
+}
+
+func (r *sampleRepository) FindBySKUs(skus []string) ([]models.SampleProduct, error) {
+	var samples []models.SampleProduct
+	if len(skus) == 0 {
+		return samples, nil
+	}
+	err := r.db.Where("sku IN ?", skus).Find(&samples).Error
+	return samples, err
+}
+
+// SaveBatch creates new samples and updates existing ones in a single transaction
+func (r *sampleRepository) SaveBatch(creates []*models.SampleProduct, updates []*models.SampleProduct) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, sample := range creates {
+			r.fillSearchText(tx, sample)
+		}
+		for _, sample := range updates {
+			r.fillSearchText(tx, sample)
+		}
+		if len(creates) > 0 {
+			if err := tx.CreateInBatches(creates, 100).Error; err != nil {
+				return err
+			}
+		}
+		for _, sample := range updates {
+			if err := tx.Save(sample).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Search returns up to limit samples matching a FULLTEXT boolean query, best MATCH score first
+func (r *sampleRepository) Search(booleanQuery string, spec interfaces.QuerySpec, limit int) ([]models.SampleProduct, error) {
+	var samples []models.SampleProduct
+
+	spec.Search = ""
+	query, err := applySampleQuery(r.db.Model(&models.SampleProduct{}), spec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.
+		Where("MATCH(sample_products.search_text) AGAINST(? IN BOOLEAN MODE)", booleanQuery).
+		Order(gorm.Expr("MATCH(sample_products.search_text) AGAINST(? IN BOOLEAN MODE) DESC", booleanQuery)).
+		Order("sample_products.id ASC").
+		Limit(limit).
+		Preload("ProductName").
+		Preload("Category").
+		Preload("Images", orderImages).
+		Find(&samples).Error
+
+	return samples, err
+}
+
+// RebuildSearchText recomputes search_text for every sample and returns the number of rows updated
+func (r *sampleRepository) RebuildSearchText(batchSize int) (int, error) {
+	return r.writeSearchText(r.db.Model(&models.SampleProduct{}), batchSize)
+}
+
+func (r *sampleRepository) FillMissingSearchText(batchSize int) (int, error) {
+	return r.writeSearchText(r.db.Model(&models.SampleProduct{}).Where("search_text IS NULL"), batchSize)
+}
+
+// searchTextBatchSize is how many samples writeSearchText loads at a time when a change touches many samples
+const searchTextBatchSize = 500
+
+// writeSearchText recomputes search_text for the samples of query in batches of batchSize
+func (r *sampleRepository) writeSearchText(query *gorm.DB, batchSize int) (int, error) {
+	var samples []models.SampleProduct
+	updated := 0
+
+	err := query.Preload("ProductName").
+		FindInBatches(&samples, batchSize, func(_ *gorm.DB, _ int) error {
+			return r.db.Transaction(func(tx *gorm.DB) error {
+				for i := range samples {
+					text := sampleSearchText(&samples[i], &samples[i].ProductName)
+					if err := tx.Model(&models.SampleProduct{}).Where("id = ?", samples[i].ID).
+						UpdateColumn("search_text", text).Error; err != nil {
+						return err
+					}
+					updated++
+				}
+				return nil
+			})
+		}).Error
+
+	return updated, err
+}
+
+// fillSearchText sets the normalized search_text, loading the product name when it is not preloaded
+func (r *sampleRepository) fillSearchText(db *gorm.DB, sample *models.SampleProduct) {
+	productName := &sample.ProductName
+	if productName.ID != sample.ProductNameID {
+		productName = &models.ProductName{}
+		if err := db.Session(&gorm.Session{NewDB: true}).First(productName, sample.ProductNameID).Error; err != nil {
+			productName = &models.ProductName{}
+		}
+	}
+	sample.SearchText = sampleSearchText(sample, productName)
+}
+
+// sampleSearchText joins every searchable field of a sample with diacritics removed
+func sampleSearchText(sample *models.SampleProduct, productName *models.ProductName) string {
+	return textsearch.Normalize(strings.Join([]string{
+		sample.SKU,
+		productName.ProductNameVI,
+		productName.ProductNameEN,
+		productName.SKUParent,
+		sample.Color,
+		sample.ColorCode,
+		sample.FiberContent,
+		sample.Description,
+		sample.DescriptionEN,
+		sample.Source,
+	}, " "))
+}
+
+// applySampleQuery applies the search term and spec filters shared by list, export and facet queries
+func applySampleQuery(query *gorm.DB, spec interfaces.QuerySpec) (*gorm.DB, error) {
+	// Apply search on the normalized search_text FULLTEXT index
+	if spec.Search != "" {
+		if booleanQuery := textsearch.BooleanQuery(textsearch.Tokenize(spec.Search), true); booleanQuery != "" {
+			query = query.Where("MATCH(sample_products.search_text) AGAINST(? IN BOOLEAN MODE)", booleanQuery)
+		} else {
+			// Single-character terms are below the ngram token size
+			query = query.Where("sample_products.search_text LIKE ?", "%"+escapeLike(textsearch.Normalize(spec.Search))+"%")
+		}
+	}
+
+	return applyFilters(query, spec.Filters, sampleFilterColumns)
+}
+
 func (r *sampleRepository) FindByID(id uint) (*models.SampleProduct, error) {
 	var sample models.SampleProduct
 	// ALWAYS preload relationships when finding by ID
-	if err := r.db.Preload("ProductName").Preload("Category").First(&sample, id).Error; err != nil {
+	if err := r.db.Preload("ProductName").Preload("Category").Preload("Images", orderImages).First(&sample, id).Error; err != nil {
 		return nil, err
 	}
 	return &sample, nil
@@ -81,17 +360,19 @@ func (r *sampleRepository) FindByID(id uint) (*models.SampleProduct, error) {
 func (r *sampleRepository) FindBySKU(sku string) (*models.SampleProduct, error) {
 	var sample models.SampleProduct
 	// ALWAYS preload relationships when finding by SKU
-	if err := r.db.Preload("ProductName").Preload("Category").Where("sku = ?", sku).First(&sample).Error; err != nil {
+	if err := r.db.Preload("ProductName").Preload("Category").Preload("Images", orderImages).Where("sku = ?", sku).First(&sample).Error; err != nil {
 		return nil, err
 	}
 	return &sample, nil
 }
 
 func (r *sampleRepository) Create(sample *models.SampleProduct) error {
+	r.fillSearchText(r.db, sample)
 	return r.db.Create(sample).Error
 }
 
 func (r *sampleRepository) Update(sample *models.SampleProduct) error {
+	r.fillSearchText(r.db, sample)
 	return r.db.Save(sample).Error
 }
 
@@ -0,0 +1,23 @@
+// File: internal/repository/mysql/sample_dispatch.go
+// Tạo tại: internal/repository/mysql/sample_dispatch.go
+// Mục đích: MySQL implementation cho phiếu gửi mẫu vải cho khách hàng
+
+package mysql
+
+import (
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type sampleDispatchRepository struct {
+	db *gorm.DB
+}
+
+func NewSampleDispatchRepository(db *gorm.DB) interfaces.SampleDispatchRepository {
+	return &sampleDispatchRepository{db: db}
+}
+
+func (r *sampleDispatchRepository) Create(dispatch *models.SampleDispatch) error {
+	return r.db.Create(dispatch).Error
+}
@@ -0,0 +1,85 @@
+// File: internal/repository/mysql/sample_image.go
+// Tạo tại: internal/repository/mysql/sample_image.go
+// Mục đích: MySQL implementation cho sample_images (ảnh chính, thứ tự hiển thị)
+
+package mysql
+
+import (
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type sampleImageRepository struct {
+	db *gorm.DB
+}
+
+func NewSampleImageRepository(db *gorm.DB) interfaces.SampleImageRepository {
+	return &sampleImageRepository{db: db}
+}
+
+func (r *sampleImageRepository) FindBySampleID(sampleID uint) ([]models.SampleImage, error) {
+	var images []models.SampleImage
+	err := r.db.Where("sample_product_id = ?", sampleID).
+		Order("sort_order ASC, id ASC").
+		Find(&images).Error
+	return images, err
+}
+
+func (r *sampleImageRepository) FindByID(id uint) (*models.SampleImage, error) {
+	var image models.SampleImage
+	if err := r.db.First(&image, id).Error; err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+func (r *sampleImageRepository) NextSortOrder(sampleID uint) (int, error) {
+	var maxOrder *int
+	err := r.db.Model(&models.SampleImage{}).
+		Where("sample_product_id = ?", sampleID).
+		Select("MAX(sort_order)").
+		Scan(&maxOrder).Error
+	if err != nil || maxOrder == nil {
+		return 0, err
+	}
+	return *maxOrder + 1, nil
+}
+
+func (r *sampleImageRepository) Create(images []models.SampleImage) error {
+	if len(images) == 0 {
+		return nil
+	}
+	return r.db.Create(&images).Error
+}
+
+func (r *sampleImageRepository) Delete(id uint) error {
+	return r.db.Delete(&models.SampleImage{}, id).Error
+}
+
+func (r *sampleImageRepository) SetPrimary(sampleID uint, imageID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.SampleImage{}).
+			Where("sample_product_id = ?", sampleID).
+			Update("is_primary", false).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.SampleImage{}).
+			Where("id = ? AND sample_product_id = ?", imageID, sampleID).
+			Update("is_primary", true).Error
+	})
+}
+
+func (r *sampleImageRepository) Reorder(sampleID uint, imageIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, imageID := range imageIDs {
+			if err := tx.Model(&models.SampleImage{}).
+				Where("id = ? AND sample_product_id = ?", imageID, sampleID).
+				Update("sort_order", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
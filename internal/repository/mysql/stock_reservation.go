@@ -0,0 +1,205 @@
+// File: internal/repository/mysql/stock_reservation.go
+// Tạo tại: internal/repository/mysql/stock_reservation.go
+// Mục đích: MySQL implementation giữ cuộn vải cho dòng đơn hàng, khóa cuộn khi giữ/giải phóng
+
+package mysql
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// activeReservation matches reservations that still hold their roll
+const activeReservation = "stock_reservations.status = 'active' AND " +
+	"(stock_reservations.expires_at IS NULL OR stock_reservations.expires_at > ?)"
+
+type stockReservationRepository struct {
+	db *gorm.DB
+}
+
+func NewStockReservationRepository(db *gorm.DB) interfaces.StockReservationRepository {
+	return &stockReservationRepository{db: db}
+}
+
+func (r *stockReservationRepository) FindByOrder(orderID uint) ([]models.StockReservation, error) {
+	var reservations []models.StockReservation
+	err := r.db.Preload("Lot").Preload("FabricRoll").
+		Where("order_id = ?", orderID).
+		Order("created_at DESC, id DESC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *stockReservationRepository) FindByID(id uint) (*models.StockReservation, error) {
+	var reservation models.StockReservation
+	if err := r.db.Preload("Lot").Preload("FabricRoll").First(&reservation, id).Error; err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (r *stockReservationRepository) ReservedLength(orderID uint, now time.Time) (map[uint]float64, error) {
+	var rows []struct {
+		OrderItemID uint
+		Length      float64
+	}
+	err := r.db.Model(&models.StockReservation{}).
+		Select("order_item_id, SUM(length) AS length").
+		Where("order_id = ?", orderID).
+		Where(activeReservation, now).
+		Group("order_item_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := make(map[uint]float64, len(rows))
+	for _, row := range rows {
+		reserved[row.OrderItemID] = row.Length
+	}
+	return reserved, nil
+}
+
+func (r *stockReservationRepository) Reserve(lines []interfaces.ReservationLine, allowPartial bool, now time.Time) ([]models.StockReservation, error) {
+	var reserved []models.StockReservation
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Rolls taken by earlier lines of this request
+		taken := make(map[uint]bool)
+
+		for _, line := range lines {
+			rolls, err := lockFreeRolls(tx, line.Criteria, now)
+			if err != nil {
+				return err
+			}
+
+			explicit := len(line.Criteria.RollIDs) > 0
+			free := 0.0
+			picked := 0
+			for _, roll := range rolls {
+				if taken[roll.ID] || (!explicit && free >= line.Length) {
+					continue
+				}
+				taken[roll.ID] = true
+				free += roll.Length
+				picked++
+
+				reservation := line.Template
+				reservation.LotID = roll.LotID
+				reservation.FabricRollID = roll.ID
+				reservation.Length = roll.Length
+				reservation.Weight = roll.Weight
+				reservation.Status = models.ReservationActive
+				reserved = append(reserved, reservation)
+			}
+
+			if allowPartial {
+				continue
+			}
+			if explicit && picked < len(line.Criteria.RollIDs) {
+				return fmt.Errorf("%w: order line %d: %d of %d rolls are not free",
+					interfaces.ErrInsufficientStock, line.Template.OrderItemID, len(line.Criteria.RollIDs)-picked, len(line.Criteria.RollIDs))
+			}
+			if !explicit && free < line.Length {
+				return fmt.Errorf("%w: order line %d needs %v m, %v m free",
+					interfaces.ErrInsufficientStock, line.Template.OrderItemID, line.Length, math.Round(free*100)/100)
+			}
+		}
+
+		if len(reserved) == 0 {
+			return nil
+		}
+		return tx.Omit("Lot", "FabricRoll").Create(&reserved).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reserved, nil
+}
+
+// lockFreeRolls locks the rolls matching criteria, so concurrent reservations of the same stock
+// queue up, and returns those without an active reservation, oldest lot first
+func lockFreeRolls(tx *gorm.DB, criteria interfaces.RollCriteria, now time.Time) ([]models.FabricRoll, error) {
+	query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Joins("JOIN lots ON lots.id = fabric_rolls.lot_id").
+		Where("lots.product_id = ? AND lots.status = ? AND lots.quality_status = ? AND fabric_rolls.status = ?",
+			criteria.ProductID, models.LotStatusAvailable, models.LotQualityGood, models.RollStatusAvailable)
+	if criteria.ColorCode != "" {
+		query = query.Where("lots.color_code = ?", criteria.ColorCode)
+	}
+	if criteria.LotID != 0 {
+		query = query.Where("lots.id = ?", criteria.LotID)
+	}
+	if len(criteria.RollIDs) > 0 {
+		query = query.Where("fabric_rolls.id IN ?", criteria.RollIDs)
+	}
+
+	var rolls []models.FabricRoll
+	if err := query.Order("lots.created_at, lots.id, fabric_rolls.id").Find(&rolls).Error; err != nil {
+		return nil, err
+	}
+	if len(rolls) == 0 {
+		return nil, nil
+	}
+
+	// Read after the lock so reservations committed by the transaction we waited for are seen
+	rollIDs := make([]uint, len(rolls))
+	for i, roll := range rolls {
+		rollIDs[i] = roll.ID
+	}
+	var heldIDs []uint
+	err := tx.Model(&models.StockReservation{}).
+		Where("fabric_roll_id IN ?", rollIDs).
+		Where(activeReservation, now).
+		Pluck("fabric_roll_id", &heldIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[uint]bool, len(heldIDs))
+	for _, id := range heldIDs {
+		held[id] = true
+	}
+
+	free := rolls[:0]
+	for _, roll := range rolls {
+		if !held[roll.ID] {
+			free = append(free, roll)
+		}
+	}
+	return free, nil
+}
+
+func (r *stockReservationRepository) WithTx(tx *gorm.DB) interfaces.StockReservationRepository {
+	return &stockReservationRepository{db: tx}
+}
+
+func (r *stockReservationRepository) Release(orderID uint, ids []uint, status, reason string, now time.Time) (int64, error) {
+	query := r.db.Model(&models.StockReservation{}).
+		Where("order_id = ? AND status = ?", orderID, models.ReservationActive)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	result := query.Updates(map[string]interface{}{
+		"status":         status,
+		"released_at":    now,
+		"release_reason": reason,
+	})
+	return result.RowsAffected, result.Error
+}
+
+func (r *stockReservationRepository) ExpireDue(now time.Time) (int64, error) {
+	result := r.db.Model(&models.StockReservation{}).
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", models.ReservationActive, now).
+		Updates(map[string]interface{}{
+			"status":         models.ReservationExpired,
+			"released_at":    now,
+			"release_reason": "expired",
+		})
+	return result.RowsAffected, result.Error
+}
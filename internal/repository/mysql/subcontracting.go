@@ -0,0 +1,257 @@
+// File: internal/repository/mysql/subcontracting.go
+// Tạo tại: internal/repository/mysql/subcontracting.go
+// Mục đích: Truy vấn công việc gia công dệt/nhuộm, hóa đơn và thanh toán nhà gia công
+
+package mysql
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"github.com/godiidev/appsynex/pkg/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type subcontractRepository struct {
+	db *gorm.DB
+}
+
+func NewSubcontractRepository(db *gorm.DB) interfaces.SubcontractRepository {
+	return &subcontractRepository{db: db}
+}
+
+func (r *subcontractRepository) FindJobs(filter interfaces.SubcontractJobFilter) ([]interfaces.SubcontractJob, error) {
+	var jobs []interfaces.SubcontractJob
+
+	if filter.Type == "" || filter.Type == models.SubcontractWeaving {
+		var weaving []interfaces.SubcontractJob
+		if err := r.weavingJobs(filter).Scan(&weaving).Error; err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, weaving...)
+	}
+	if filter.Type == "" || filter.Type == models.SubcontractDyeing {
+		var dyeing []interfaces.SubcontractJob
+		if err := r.dyeingJobs(filter).Scan(&dyeing).Error; err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, dyeing...)
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].SubcontractorName != jobs[j].SubcontractorName {
+			return jobs[i].SubcontractorName < jobs[j].SubcontractorName
+		}
+		if jobs[i].Type != jobs[j].Type {
+			return jobs[i].Type > jobs[j].Type
+		}
+		return jobs[i].JobID < jobs[j].JobID
+	})
+	return jobs, nil
+}
+
+// weavingJobs lists the live weaving orders placed at outside mills with their invoice
+func (r *subcontractRepository) weavingJobs(filter interfaces.SubcontractJobFilter) *gorm.DB {
+	query := r.db.Table("weaving_orders wo").
+		Select(`? AS type, wo.id AS job_id, wo.order_code AS job_code, COALESCE(p.sku, '') AS description,
+			f.facility_name AS subcontractor_name, COALESCE(wo.start_date, wo.created_at) AS sent_at,
+			wo.planned_length AS sent_quantity, wo.produced_quantity AS returned_quantity,
+			COALESCE(wf.unit_cost, 0) AS unit_cost, COALESCE(wf.currency, ?) AS currency, wf.id AS financial_id, COALESCE(wf.quantity, 0) AS billed_quantity,
+			wf.total_cost, wf.amount_paid, COALESCE(wf.payment_status, '') AS payment_status,
+			COALESCE(wf.invoice_number, '') AS invoice_number, wf.invoice_date, wf.due_date`, models.SubcontractWeaving, models.DefaultCurrency).
+		Joins("JOIN weaving_facilities f ON f.id = wo.weaving_facility_id").
+		Joins("LEFT JOIN products p ON p.id = wo.product_id").
+		Joins("LEFT JOIN weaving_financials wf ON wf.weaving_order_id = wo.id").
+		Where("f.facility_type = ? AND wo.status <> ?", models.WeavingFacilityExternal, models.WeavingOrderCancelled)
+
+	if filter.JobID != 0 {
+		query = query.Where("wo.id = ?", filter.JobID)
+	}
+	if filter.Search != "" {
+		term := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("f.facility_name LIKE ? OR wo.order_code LIKE ?", term, term)
+	}
+	if filter.From != nil {
+		query = query.Where("DATE(COALESCE(wo.start_date, wo.created_at)) >= ?", filter.From.Format("2006-01-02"))
+	}
+	if filter.To != nil {
+		query = query.Where("DATE(COALESCE(wo.start_date, wo.created_at)) <= ?", filter.To.Format("2006-01-02"))
+	}
+	if filter.UnpaidOnly {
+		query = query.Where("wf.id IS NOT NULL AND wf.payment_status <> ?", models.PaymentStatusPaid)
+	}
+	return query
+}
+
+// dyeingJobs lists the live dyeing jobs with the kilograms returned in lots that were not rejected
+func (r *subcontractRepository) dyeingJobs(filter interfaces.SubcontractJobFilter) *gorm.DB {
+	query := r.db.Table("dyeing_jobs j").
+		Select(`? AS type, j.id AS job_id, j.job_code, j.target_color AS description,
+			ds.name AS subcontractor_name, j.sent_date AS sent_at, j.greige_weight AS sent_quantity,
+			COALESCE((SELECT SUM(l.weight) FROM dyeing_lots l WHERE l.dyeing_job_id = j.id AND l.status <> ?), 0) AS returned_quantity,
+			COALESCE(df.unit_cost, j.cost_per_kg) AS unit_cost, COALESCE(df.currency, j.currency) AS currency,
+			df.id AS financial_id, COALESCE(df.quantity, 0) AS billed_quantity, df.total_cost, df.amount_paid,
+			COALESCE(df.payment_status, '') AS payment_status, COALESCE(df.invoice_number, '') AS invoice_number,
+			df.invoice_date, df.due_date`, models.SubcontractDyeing, models.DyeingLotRejected).
+		Joins("JOIN dyeing_subcontractors ds ON ds.id = j.dyeing_subcontractor_id").
+		Joins("LEFT JOIN dyeing_financials df ON df.dyeing_job_id = j.id").
+		Where("j.deleted_at IS NULL AND j.status <> ?", models.DyeingJobCancelled)
+
+	if filter.JobID != 0 {
+		query = query.Where("j.id = ?", filter.JobID)
+	}
+	if filter.Search != "" {
+		term := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("ds.name LIKE ? OR j.job_code LIKE ? OR j.target_color LIKE ?", term, term, term)
+	}
+	if filter.From != nil {
+		query = query.Where("j.sent_date >= ?", filter.From.Format("2006-01-02"))
+	}
+	if filter.To != nil {
+		query = query.Where("j.sent_date <= ?", filter.To.Format("2006-01-02"))
+	}
+	if filter.UnpaidOnly {
+		query = query.Where("df.id IS NOT NULL AND df.payment_status <> ?", models.PaymentStatusPaid)
+	}
+	return query
+}
+
+func (r *subcontractRepository) FindJob(jobType string, jobID uint) (*interfaces.SubcontractJob, error) {
+	jobs, err := r.FindJobs(interfaces.SubcontractJobFilter{Type: jobType, JobID: jobID})
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &jobs[0], nil
+}
+
+func (r *subcontractRepository) SaveWeavingInvoice(financial *models.WeavingFinancial) error {
+	if financial.ID == 0 {
+		financial.PaymentStatus = paymentStatus(financial.TotalCost, 0)
+		return r.db.Create(financial).Error
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current models.WeavingFinancial
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, financial.ID).Error; err != nil {
+			return err
+		}
+		if financial.TotalCost < current.AmountPaid {
+			return interfaces.ErrInvoiceBelowPaid
+		}
+		if current.AmountPaid > 0 && financial.Currency != current.Currency {
+			return interfaces.ErrInvoiceCurrencyLocked
+		}
+		financial.AmountPaid = current.AmountPaid
+		financial.PaymentStatus = paymentStatus(financial.TotalCost, current.AmountPaid)
+		return tx.Model(&current).Updates(map[string]interface{}{
+			"unit_cost":      financial.UnitCost,
+			"quantity":       financial.Quantity,
+			"total_cost":     financial.TotalCost,
+			"currency":       financial.Currency,
+			"payment_status": financial.PaymentStatus,
+			"due_date":       financial.DueDate,
+			"invoice_number": financial.InvoiceNumber,
+			"invoice_date":   financial.InvoiceDate,
+			"notes":          financial.Notes,
+		}).Error
+	})
+}
+
+func (r *subcontractRepository) SaveDyeingInvoice(financial *models.DyeingFinancial) error {
+	if financial.ID == 0 {
+		financial.PaymentStatus = paymentStatus(financial.TotalCost, 0)
+		return r.db.Create(financial).Error
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current models.DyeingFinancial
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, financial.ID).Error; err != nil {
+			return err
+		}
+		if financial.TotalCost < current.AmountPaid {
+			return interfaces.ErrInvoiceBelowPaid
+		}
+		if current.AmountPaid > 0 && financial.Currency != current.Currency {
+			return interfaces.ErrInvoiceCurrencyLocked
+		}
+		financial.AmountPaid = current.AmountPaid
+		financial.PaymentStatus = paymentStatus(financial.TotalCost, current.AmountPaid)
+		return tx.Model(&current).Updates(map[string]interface{}{
+			"unit_cost":      financial.UnitCost,
+			"quantity":       financial.Quantity,
+			"total_cost":     financial.TotalCost,
+			"currency":       financial.Currency,
+			"payment_status": financial.PaymentStatus,
+			"due_date":       financial.DueDate,
+			"invoice_number": financial.InvoiceNumber,
+			"invoice_date":   financial.InvoiceDate,
+			"notes":          financial.Notes,
+		}).Error
+	})
+}
+
+func (r *subcontractRepository) RecordPayment(payment *models.SubcontractorPayment) error {
+	table, id := "weaving_financials", payment.WeavingFinancialID
+	if payment.DyeingFinancialID != nil {
+		table, id = "dyeing_financials", payment.DyeingFinancialID
+	}
+	if id == nil {
+		return errors.New("payment has no invoice")
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var invoice struct {
+			TotalCost  money.Amount
+			AmountPaid money.Amount
+		}
+		err := tx.Table(table).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("total_cost, amount_paid").
+			Where("id = ?", *id).
+			Take(&invoice).Error
+		if err != nil {
+			return err
+		}
+		if payment.Amount > invoice.TotalCost-invoice.AmountPaid {
+			return interfaces.ErrPaymentExceedsBalance
+		}
+
+		if err := tx.Create(payment).Error; err != nil {
+			return err
+		}
+		paid := invoice.AmountPaid + payment.Amount
+		return tx.Table(table).Where("id = ?", *id).Updates(map[string]interface{}{
+			"amount_paid":    paid,
+			"payment_status": paymentStatus(invoice.TotalCost, paid),
+		}).Error
+	})
+}
+
+func (r *subcontractRepository) FindPayments(jobType string, financialID uint) ([]models.SubcontractorPayment, error) {
+	column := "weaving_financial_id"
+	if jobType == models.SubcontractDyeing {
+		column = "dyeing_financial_id"
+	}
+
+	var payments []models.SubcontractorPayment
+	err := r.db.Where(column+" = ?", financialID).
+		Order("paid_at, id").
+		Find(&payments).Error
+	return payments, err
+}
+
+// paymentStatus follows the amount paid against the invoice total
+func paymentStatus(total, paid money.Amount) string {
+	switch {
+	case paid > 0 && paid >= total:
+		return models.PaymentStatusPaid
+	case paid > 0:
+		return models.PaymentStatusPartial
+	default:
+		return models.PaymentStatusPending
+	}
+}
@@ -38,6 +38,84 @@ func (r *userRepository) FindAll(page, limit int, search string) ([]models.User,
 	return users, count, nil
 }
 
+// userFilterColumns whitelists the fields usable in user filters
+var userFilterColumns = map[string]string{
+	"account_status": "users.account_status",
+}
+
+var defaultUserSort = interfaces.SortField{Field: "id", Desc: false}
+
+// userKeysetColumns are the indexed columns users can be paged by with a cursor
+var userKeysetColumns = map[string]keysetColumn[models.User]{
+	"id":             {"users.id", func(u *models.User) interface{} { return u.ID }},
+	"username":       {"users.username", func(u *models.User) interface{} { return u.Username }},
+	"email":          {"users.email", func(u *models.User) interface{} { return u.Email }},
+	"account_status": {"users.account_status", func(u *models.User) interface{} { return u.AccountStatus }},
+	"created_at":     {"users.created_at", func(u *models.User) interface{} { return u.CreatedAt }},
+}
+
+// FindPage returns up to limit users after the cursor, ordered by the first spec sort field and ID.
+// The returned cursor is nil on the last page.
+func (r *userRepository) FindPage(spec interfaces.QuerySpec, after *interfaces.Cursor, limit int) ([]models.User, *interfaces.Cursor, error) {
+	var users []models.User
+
+	sort := defaultUserSort
+	if len(spec.Sort) > 0 {
+		sort = spec.Sort[0]
+	}
+
+	query, err := applyUserQuery(r.db.Model(&models.User{}), spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	query, err = applyKeyset(query, sort, after, userKeysetColumns, "users.id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := query.Limit(limit + 1).Preload("Roles").Find(&users).Error; err != nil {
+		return nil, nil, err
+	}
+
+	users, next := keysetPage(users, limit, sort, userKeysetColumns, func(u *models.User) uint { return u.ID })
+	return users, next, nil
+}
+
+func (r *userRepository) Count(spec interfaces.QuerySpec) (int64, error) {
+	var count int64
+	query, err := applyUserQuery(r.db.Model(&models.User{}), spec)
+	if err != nil {
+		return 0, err
+	}
+	err = query.Count(&count).Error
+	return count, err
+}
+
+func (r *userRepository) EstimateCount(spec interfaces.QuerySpec) (int64, error) {
+	filtered := spec.Search != "" || len(spec.Filters) > 0
+	var buildErr error
+	count, err := estimateCount(r.db, "users", filtered, func(tx *gorm.DB) *gorm.DB {
+		query, err := applyUserQuery(tx.Model(&models.User{}), spec)
+		if err != nil {
+			buildErr = err
+			return tx
+		}
+		return query.Find(&[]models.User{})
+	})
+	if buildErr != nil {
+		return 0, buildErr
+	}
+	return count, err
+}
+
+// applyUserQuery applies the username/email search and spec filters
+func applyUserQuery(query *gorm.DB, spec interfaces.QuerySpec) (*gorm.DB, error) {
+	if spec.Search != "" {
+		query = query.Where("users.username LIKE ? OR users.email LIKE ?", "%"+spec.Search+"%", "%"+spec.Search+"%")
+	}
+	return applyFilters(query, spec.Filters, userFilterColumns)
+}
+
 func (r *userRepository) FindByID(id uint) (*models.User, error) {
 	var user models.User
 	if err := r.db.First(&user, id).Error; err != nil {
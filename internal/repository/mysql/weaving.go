@@ -0,0 +1,391 @@
+// File: internal/repository/mysql/weaving.go
+// Tạo tại: internal/repository/mysql/weaving.go
+// Mục đích: MySQL implementation cho lệnh dệt, công đoạn dệt và tiến độ theo đơn hàng
+
+package mysql
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// openWeavingStatuses are the weaving order statuses that take operations
+var openWeavingStatuses = []string{models.WeavingOrderPending, models.WeavingOrderInProgress}
+
+type weavingOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewWeavingOrderRepository(db *gorm.DB) interfaces.WeavingOrderRepository {
+	return &weavingOrderRepository{db: db}
+}
+
+// preloadWeavingOrder loads what a weaving order response shows; the yarn box and facility may be soft-deleted
+func preloadWeavingOrder(db *gorm.DB) *gorm.DB {
+	unscoped := func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+	return db.Preload("WeavingFacility", unscoped).
+		Preload("LinkedOrder", unscoped).
+		Preload("YarnBox", unscoped).
+		Preload("Product", unscoped)
+}
+
+func (r *weavingOrderRepository) FindAll(filter interfaces.WeavingOrderFilter, page, limit int) ([]models.WeavingOrder, int64, error) {
+	var orders []models.WeavingOrder
+	var count int64
+
+	query := r.db.Model(&models.WeavingOrder{})
+	if filter.Search != "" {
+		term := "%" + escapeLike(filter.Search) + "%"
+		query = query.Joins("LEFT JOIN orders ON orders.id = weaving_orders.linked_order_id").
+			Where("weaving_orders.order_code LIKE ? OR orders.order_code LIKE ?", term, term)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("weaving_orders.status IN ?", filter.Statuses)
+	}
+	if filter.FacilityID != 0 {
+		query = query.Where("weaving_orders.weaving_facility_id = ?", filter.FacilityID)
+	}
+	if filter.LinkedOrderID != 0 {
+		query = query.Where("weaving_orders.linked_order_id = ?", filter.LinkedOrderID)
+	}
+	if filter.ProductID != 0 {
+		query = query.Where("weaving_orders.product_id = ?", filter.ProductID)
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	err := preloadWeavingOrder(query).
+		Order("weaving_orders.created_at DESC, weaving_orders.id DESC").
+		Offset(offset).Limit(limit).
+		Find(&orders).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return orders, count, nil
+}
+
+func (r *weavingOrderRepository) FindByID(id uint) (*models.WeavingOrder, error) {
+	var order models.WeavingOrder
+	if err := preloadWeavingOrder(r.db).First(&order, id).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *weavingOrderRepository) FindByCode(code string) (*models.WeavingOrder, error) {
+	var order models.WeavingOrder
+	if err := r.db.Where("order_code = ?", code).First(&order).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *weavingOrderRepository) FindByLinkedOrder(orderID uint) ([]models.WeavingOrder, error) {
+	var orders []models.WeavingOrder
+	err := preloadWeavingOrder(r.db).
+		Where("linked_order_id = ?", orderID).
+		Order("created_at, id").
+		Find(&orders).Error
+	return orders, err
+}
+
+func (r *weavingOrderRepository) FindOpen(facilityID uint) ([]models.WeavingOrder, error) {
+	var orders []models.WeavingOrder
+	err := preloadWeavingOrder(r.db).
+		Where("weaving_facility_id = ? AND status IN ?", facilityID, openWeavingStatuses).
+		Order("created_at, id").
+		Find(&orders).Error
+	return orders, err
+}
+
+func (r *weavingOrderRepository) LastCodeWithPrefix(prefix string) (string, error) {
+	var codes []string
+	err := r.db.Model(&models.WeavingOrder{}).
+		Where("order_code REGEXP ?", "^"+regexp.QuoteMeta(prefix)+"[0-9]+$").
+		Order("CHAR_LENGTH(order_code) DESC, order_code DESC").
+		Limit(1).
+		Pluck("order_code", &codes).Error
+	if err != nil || len(codes) == 0 {
+		return "", err
+	}
+	return codes[0], nil
+}
+
+func (r *weavingOrderRepository) Create(order *models.WeavingOrder) error {
+	return r.db.Omit(clause.Associations).Create(order).Error
+}
+
+func (r *weavingOrderRepository) Update(order *models.WeavingOrder) error {
+	return r.db.Model(order).Updates(map[string]interface{}{
+		"weaving_facility_id": order.WeavingFacilityID,
+		"yarn_box_id":         order.YarnBoxID,
+		"color_code":          order.ColorCode,
+		"planned_length":      order.PlannedQuantity,
+		"end_date":            order.EndDate,
+		"notes":               order.Notes,
+	}).Error
+}
+
+func (r *weavingOrderRepository) Delete(id uint) error {
+	return r.db.Delete(&models.WeavingOrder{}, id).Error
+}
+
+func (r *weavingOrderRepository) ChangeStatus(order *models.WeavingOrder, fromStatuses []string, inTx func(tx *gorm.DB) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockWeavingOrder(tx, order.ID, fromStatuses); err != nil {
+			return err
+		}
+		if inTx != nil {
+			if err := inTx(tx); err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.WeavingOrder{}).
+			Where("id = ?", order.ID).
+			Updates(map[string]interface{}{
+				"status":     order.Status,
+				"start_date": order.StartDate,
+				"end_date":   order.EndDate,
+			}).Error
+	})
+}
+
+// lockWeavingOrder locks the weaving order row for the rest of tx. It fails with
+// ErrWeavingOrderStatusChanged when the order is in none of statuses; nil statuses take any.
+func lockWeavingOrder(tx *gorm.DB, id uint, statuses []string) error {
+	query := tx.Model(&models.WeavingOrder{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id)
+	if statuses != nil {
+		query = query.Where("status IN ?", statuses)
+	}
+	var ids []uint
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return interfaces.ErrWeavingOrderStatusChanged
+	}
+	return nil
+}
+
+func (r *weavingOrderRepository) WithTx(tx *gorm.DB) interfaces.WeavingOrderRepository {
+	return &weavingOrderRepository{db: tx}
+}
+
+func (r *weavingOrderRepository) CancelByLinkedOrder(orderID uint, now time.Time) (int64, error) {
+	result := r.db.Model(&models.WeavingOrder{}).
+		Where("linked_order_id = ? AND status IN ?", orderID, openWeavingStatuses).
+		Updates(map[string]interface{}{
+			"status":   models.WeavingOrderCancelled,
+			"end_date": now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *weavingOrderRepository) Progress(orderID uint) ([]interfaces.WeavingProgress, error) {
+	var ordered []interfaces.WeavingProgress
+	err := r.db.Model(&models.OrderItem{}).
+		Select("product_id, SUM(quantity) AS ordered").
+		Where("order_id = ?", orderID).
+		Group("product_id").
+		Order("product_id").
+		Scan(&ordered).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var woven []interfaces.WeavingProgress
+	err = r.db.Model(&models.WeavingOrder{}).
+		Select("product_id, SUM(planned_length) AS planned, SUM(produced_quantity) AS produced").
+		Where("linked_order_id = ? AND status <> ?", orderID, models.WeavingOrderCancelled).
+		Group("product_id").
+		Order("product_id").
+		Scan(&woven).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Weaving orders of a product that is no longer on the order are kept
+	index := make(map[uint]int, len(ordered))
+	for i, row := range ordered {
+		row.Ordered = roundWeight(row.Ordered)
+		ordered[i] = row
+		index[row.ProductID] = i
+	}
+	for _, row := range woven {
+		i, ok := index[row.ProductID]
+		if !ok {
+			ordered = append(ordered, interfaces.WeavingProgress{ProductID: row.ProductID})
+			i = len(ordered) - 1
+		}
+		ordered[i].Planned = roundWeight(row.Planned)
+		ordered[i].Produced = roundWeight(row.Produced)
+	}
+	return ordered, nil
+}
+
+func (r *weavingOrderRepository) FindOperations(filter interfaces.WeavingOperationFilter, page, limit int) ([]models.WeavingOperation, int64, error) {
+	var operations []models.WeavingOperation
+	var count int64
+
+	query := r.db.Model(&models.WeavingOperation{})
+	if filter.WeavingOrderID != 0 {
+		query = query.Where("weaving_order_id = ?", filter.WeavingOrderID)
+	}
+	if filter.FacilityID != 0 {
+		query = query.Where("weaving_facility_id = ?", filter.FacilityID)
+	}
+	if filter.StaffID != 0 {
+		query = query.Where("weaving_staff_id = ?", filter.StaffID)
+	}
+	if filter.ShiftID != 0 {
+		query = query.Where("weaving_shift_id = ?", filter.ShiftID)
+	}
+	if filter.MachineID != 0 {
+		query = query.Where("weaving_machine_id = ?", filter.MachineID)
+	}
+	if filter.From != nil {
+		query = query.Where("COALESCE(start_time, created_at) >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("COALESCE(start_time, created_at) < ?", *filter.To)
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	err := preloadWeavingOperation(query).
+		Order("COALESCE(start_time, created_at) DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&operations).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return operations, count, nil
+}
+
+func (r *weavingOrderRepository) FindOperationByID(id uint) (*models.WeavingOperation, error) {
+	var operation models.WeavingOperation
+	if err := preloadWeavingOperation(r.db).First(&operation, id).Error; err != nil {
+		return nil, err
+	}
+	return &operation, nil
+}
+
+func preloadWeavingOperation(db *gorm.DB) *gorm.DB {
+	unscoped := func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+	return db.Preload("WeavingOrder").
+		Preload("WeavingStaff", unscoped).
+		Preload("WeavingShift").
+		Preload("YarnBox", unscoped).
+		Preload("WeavingMachine", unscoped)
+}
+
+func (r *weavingOrderRepository) CreateOperation(operation *models.WeavingOperation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		order, err := lockOpenWeavingOrder(tx, operation.WeavingOrderID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(operation).Error; err != nil {
+			return err
+		}
+		return refreshProducedQuantity(tx, order, operationStart(operation))
+	})
+}
+
+func (r *weavingOrderRepository) UpdateOperation(operation *models.WeavingOperation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		order, err := lockOpenWeavingOrder(tx, operation.WeavingOrderID)
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&models.WeavingOperation{ID: operation.ID}).Updates(map[string]interface{}{
+			"weaving_staff_id":   operation.WeavingStaffID,
+			"weaving_shift_id":   operation.WeavingShiftID,
+			"yarn_box_id":        operation.YarnBoxID,
+			"weaving_machine_id": operation.WeavingMachineID,
+			"cam_layout":         operation.CamLayout,
+			"start_time":         operation.StartTime,
+			"end_time":           operation.EndTime,
+			"quantity":           operation.Quantity,
+			"notes":              operation.Notes,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return refreshProducedQuantity(tx, order, operationStart(operation))
+	})
+}
+
+func (r *weavingOrderRepository) DeleteOperation(operation *models.WeavingOperation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		order, err := lockOpenWeavingOrder(tx, operation.WeavingOrderID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.WeavingOperation{}, operation.ID).Error; err != nil {
+			return err
+		}
+		return refreshProducedQuantity(tx, order, nil)
+	})
+}
+
+// lockOpenWeavingOrder locks the weaving order so its operations and produced quantity change one
+// writer at a time, and checks it still takes operations
+func lockOpenWeavingOrder(tx *gorm.DB, id uint) (*models.WeavingOrder, error) {
+	var order models.WeavingOrder
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, id).Error; err != nil {
+		return nil, err
+	}
+	if order.Status != models.WeavingOrderPending && order.Status != models.WeavingOrderInProgress {
+		return nil, interfaces.ErrWeavingOrderStatusChanged
+	}
+	return &order, nil
+}
+
+// refreshProducedQuantity sums the order's operations into its produced quantity and, when startedAt
+// is not nil, starts a pending order at that time
+func refreshProducedQuantity(tx *gorm.DB, order *models.WeavingOrder, startedAt *time.Time) error {
+	var produced float64
+	err := tx.Model(&models.WeavingOperation{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("weaving_order_id = ?", order.ID).
+		Scan(&produced).Error
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{"produced_quantity": roundWeight(produced)}
+	if order.Status == models.WeavingOrderPending && startedAt != nil {
+		updates["status"] = models.WeavingOrderInProgress
+		if order.StartDate == nil {
+			updates["start_date"] = *startedAt
+		}
+	}
+	return tx.Model(&models.WeavingOrder{ID: order.ID}).Updates(updates).Error
+}
+
+// operationStart is when the operation started, or now when it has no start time
+func operationStart(operation *models.WeavingOperation) *time.Time {
+	if operation.StartTime != nil {
+		return operation.StartTime
+	}
+	now := time.Now()
+	return &now
+}
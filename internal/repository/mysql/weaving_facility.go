@@ -0,0 +1,143 @@
+// File: internal/repository/mysql/weaving_facility.go
+// Tạo tại: internal/repository/mysql/weaving_facility.go
+// Mục đích: MySQL implementation cho xưởng dệt, ca và công nhân
+
+package mysql
+
+import (
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+)
+
+type weavingFacilityRepository struct {
+	db *gorm.DB
+}
+
+func NewWeavingFacilityRepository(db *gorm.DB) interfaces.WeavingFacilityRepository {
+	return &weavingFacilityRepository{db: db}
+}
+
+func (r *weavingFacilityRepository) FindAll(search, facilityType string) ([]models.WeavingFacility, error) {
+	var facilities []models.WeavingFacility
+
+	query := r.db.Model(&models.WeavingFacility{})
+	if search != "" {
+		term := "%" + escapeLike(search) + "%"
+		query = query.Where("facility_name LIKE ? OR location LIKE ?", term, term)
+	}
+	if facilityType != "" {
+		query = query.Where("facility_type = ?", facilityType)
+	}
+
+	err := query.Order("facility_name, id").Find(&facilities).Error
+	return facilities, err
+}
+
+func (r *weavingFacilityRepository) FindByID(id uint) (*models.WeavingFacility, error) {
+	var facility models.WeavingFacility
+	if err := r.db.First(&facility, id).Error; err != nil {
+		return nil, err
+	}
+	return &facility, nil
+}
+
+func (r *weavingFacilityRepository) Create(facility *models.WeavingFacility) error {
+	return r.db.Create(facility).Error
+}
+
+func (r *weavingFacilityRepository) Update(facility *models.WeavingFacility) error {
+	return r.db.Save(facility).Error
+}
+
+func (r *weavingFacilityRepository) Delete(id uint) error {
+	return r.db.Delete(&models.WeavingFacility{}, id).Error
+}
+
+func (r *weavingFacilityRepository) CountOpenOrders(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.WeavingOrder{}).
+		Where("weaving_facility_id = ? AND status IN ?", id, []string{models.WeavingOrderPending, models.WeavingOrderInProgress}).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *weavingFacilityRepository) FindShifts(facilityID uint) ([]models.WeavingShift, error) {
+	var shifts []models.WeavingShift
+	err := r.db.Where("weaving_facility_id = ?", facilityID).Order("start_time, id").Find(&shifts).Error
+	return shifts, err
+}
+
+func (r *weavingFacilityRepository) FindShiftByID(id uint) (*models.WeavingShift, error) {
+	var shift models.WeavingShift
+	if err := r.db.First(&shift, id).Error; err != nil {
+		return nil, err
+	}
+	return &shift, nil
+}
+
+func (r *weavingFacilityRepository) CreateShift(shift *models.WeavingShift) error {
+	return r.db.Create(shift).Error
+}
+
+func (r *weavingFacilityRepository) UpdateShift(shift *models.WeavingShift) error {
+	return r.db.Save(shift).Error
+}
+
+func (r *weavingFacilityRepository) DeleteShift(id uint) error {
+	return r.db.Delete(&models.WeavingShift{}, id).Error
+}
+
+func (r *weavingFacilityRepository) FindStaff(filter interfaces.WeavingStaffFilter, page, limit int) ([]models.WeavingStaff, int64, error) {
+	var staff []models.WeavingStaff
+	var count int64
+
+	query := r.db.Model(&models.WeavingStaff{})
+	if filter.FacilityID != 0 {
+		query = query.Where("weaving_facility_id = ?", filter.FacilityID)
+	}
+	if filter.ShiftID != 0 {
+		query = query.Where("shift_id = ?", filter.ShiftID)
+	}
+	if filter.Search != "" {
+		term := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("staff_name LIKE ? OR position LIKE ?", term, term)
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	err := query.Preload("WeavingFacility").Preload("Shift").
+		Order("staff_name, id").
+		Offset(offset).Limit(limit).
+		Find(&staff).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return staff, count, nil
+}
+
+func (r *weavingFacilityRepository) FindStaffByID(id uint) (*models.WeavingStaff, error) {
+	var staff models.WeavingStaff
+	if err := r.db.Preload("WeavingFacility").Preload("Shift").First(&staff, id).Error; err != nil {
+		return nil, err
+	}
+	return &staff, nil
+}
+
+func (r *weavingFacilityRepository) CreateStaff(staff *models.WeavingStaff) error {
+	return r.db.Omit("WeavingFacility", "Shift").Create(staff).Error
+}
+
+func (r *weavingFacilityRepository) UpdateStaff(staff *models.WeavingStaff) error {
+	return r.db.Omit("WeavingFacility", "Shift").Save(staff).Error
+}
+
+func (r *weavingFacilityRepository) DeleteStaff(id uint) error {
+	return r.db.Delete(&models.WeavingStaff{}, id).Error
+}
@@ -0,0 +1,157 @@
+// File: internal/repository/mysql/weaving_machine.go
+// Tạo tại: internal/repository/mysql/weaving_machine.go
+// Mục đích: MySQL implementation cho máy dệt, phân ca và dữ liệu kế hoạch công suất
+
+package mysql
+
+import (
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type weavingMachineRepository struct {
+	db *gorm.DB
+}
+
+func NewWeavingMachineRepository(db *gorm.DB) interfaces.WeavingMachineRepository {
+	return &weavingMachineRepository{db: db}
+}
+
+func (r *weavingMachineRepository) FindMachines(filter interfaces.WeavingMachineFilter) ([]models.WeavingMachine, error) {
+	var machines []models.WeavingMachine
+
+	query := r.db.Model(&models.WeavingMachine{})
+	if filter.FacilityID != 0 {
+		query = query.Where("weaving_facility_id = ?", filter.FacilityID)
+	}
+	if filter.LoomType != "" {
+		query = query.Where("loom_type = ?", filter.LoomType)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.Search != "" {
+		term := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("machine_code LIKE ? OR notes LIKE ?", term, term)
+	}
+
+	err := query.Preload("WeavingFacility").
+		Order("weaving_facility_id, machine_code").
+		Find(&machines).Error
+	return machines, err
+}
+
+func (r *weavingMachineRepository) FindMachineByID(id uint) (*models.WeavingMachine, error) {
+	var machine models.WeavingMachine
+	if err := r.db.Preload("WeavingFacility").First(&machine, id).Error; err != nil {
+		return nil, err
+	}
+	return &machine, nil
+}
+
+func (r *weavingMachineRepository) FindMachineByCode(code string) (*models.WeavingMachine, error) {
+	var machine models.WeavingMachine
+	if err := r.db.Unscoped().Where("machine_code = ?", code).First(&machine).Error; err != nil {
+		return nil, err
+	}
+	return &machine, nil
+}
+
+func (r *weavingMachineRepository) CreateMachine(machine *models.WeavingMachine) error {
+	return r.db.Omit(clause.Associations).Create(machine).Error
+}
+
+func (r *weavingMachineRepository) UpdateMachine(machine *models.WeavingMachine) error {
+	return r.db.Model(machine).Updates(map[string]interface{}{
+		"machine_code":    machine.MachineCode,
+		"loom_type":       machine.LoomType,
+		"max_width":       machine.MaxWidth,
+		"speed_rpm":       machine.SpeedRPM,
+		"output_per_hour": machine.OutputPerHour,
+		"status":          machine.Status,
+		"notes":           machine.Notes,
+	}).Error
+}
+
+func (r *weavingMachineRepository) DeleteMachine(id uint) error {
+	return r.db.Delete(&models.WeavingMachine{}, id).Error
+}
+
+func (r *weavingMachineRepository) FindRosters(filter interfaces.WeavingRosterFilter) ([]models.WeavingRoster, error) {
+	var rosters []models.WeavingRoster
+
+	query := r.db.Model(&models.WeavingRoster{})
+	if filter.FacilityID != 0 {
+		query = query.Joins("JOIN weaving_shifts ON weaving_shifts.id = weaving_rosters.weaving_shift_id").
+			Where("weaving_shifts.weaving_facility_id = ?", filter.FacilityID)
+	}
+	if filter.StaffID != 0 {
+		query = query.Where("weaving_rosters.weaving_staff_id = ?", filter.StaffID)
+	}
+	if filter.ShiftID != 0 {
+		query = query.Where("weaving_rosters.weaving_shift_id = ?", filter.ShiftID)
+	}
+	if filter.MachineID != 0 {
+		query = query.Where("weaving_rosters.weaving_machine_id = ?", filter.MachineID)
+	}
+	if filter.From != nil {
+		query = query.Where("weaving_rosters.work_date >= ?", filter.From.Format("2006-01-02"))
+	}
+	if filter.To != nil {
+		query = query.Where("weaving_rosters.work_date <= ?", filter.To.Format("2006-01-02"))
+	}
+
+	unscoped := func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+	err := query.Preload("WeavingStaff", unscoped).
+		Preload("WeavingShift").
+		Preload("WeavingMachine", unscoped).
+		Order("weaving_rosters.work_date, weaving_rosters.weaving_shift_id, weaving_rosters.id").
+		Find(&rosters).Error
+	return rosters, err
+}
+
+func (r *weavingMachineRepository) FindRosterByID(id uint) (*models.WeavingRoster, error) {
+	var roster models.WeavingRoster
+	if err := r.db.First(&roster, id).Error; err != nil {
+		return nil, err
+	}
+	return &roster, nil
+}
+
+func (r *weavingMachineRepository) SaveRosters(rosters []models.WeavingRoster) error {
+	if len(rosters) == 0 {
+		return nil
+	}
+	return r.db.Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{"weaving_shift_id", "weaving_machine_id", "notes", "updated_at"}),
+		}).
+		Create(&rosters).Error
+}
+
+func (r *weavingMachineRepository) DeleteRoster(id uint) error {
+	return r.db.Delete(&models.WeavingRoster{}, id).Error
+}
+
+func (r *weavingMachineRepository) StaffPerShift(facilityID uint) (map[uint]int, error) {
+	var rows []struct {
+		ShiftID uint
+		Staff   int
+	}
+	err := r.db.Model(&models.WeavingStaff{}).
+		Select("shift_id, COUNT(*) AS staff").
+		Where("weaving_facility_id = ? AND shift_id IS NOT NULL", facilityID).
+		Group("shift_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		result[row.ShiftID] = row.Staff
+	}
+	return result, nil
+}
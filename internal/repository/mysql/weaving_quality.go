@@ -0,0 +1,202 @@
+// File: internal/repository/mysql/weaving_quality.go
+// Tạo tại: internal/repository/mysql/weaving_quality.go
+// Mục đích: MySQL implementation cho danh mục lỗi vải và phiếu kiểm tra 4 điểm
+
+package mysql
+
+import (
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type weavingQualityRepository struct {
+	db *gorm.DB
+}
+
+func NewWeavingQualityRepository(db *gorm.DB) interfaces.WeavingQualityRepository {
+	return &weavingQualityRepository{db: db}
+}
+
+func (r *weavingQualityRepository) FindDefectTypes(filter interfaces.DefectTypeFilter) ([]models.DefectType, error) {
+	var defectTypes []models.DefectType
+
+	query := r.db.Model(&models.DefectType{})
+	if filter.Search != "" {
+		term := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("code LIKE ? OR name LIKE ?", term, term)
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	err := query.Order("category, code").Find(&defectTypes).Error
+	return defectTypes, err
+}
+
+func (r *weavingQualityRepository) FindDefectTypeByID(id uint) (*models.DefectType, error) {
+	var defectType models.DefectType
+	if err := r.db.First(&defectType, id).Error; err != nil {
+		return nil, err
+	}
+	return &defectType, nil
+}
+
+func (r *weavingQualityRepository) FindDefectTypeByCode(code string) (*models.DefectType, error) {
+	var defectType models.DefectType
+	if err := r.db.Unscoped().Where("code = ?", code).First(&defectType).Error; err != nil {
+		return nil, err
+	}
+	return &defectType, nil
+}
+
+func (r *weavingQualityRepository) CreateDefectType(defectType *models.DefectType) error {
+	return r.db.Create(defectType).Error
+}
+
+func (r *weavingQualityRepository) UpdateDefectType(defectType *models.DefectType) error {
+	return r.db.Model(defectType).Updates(map[string]interface{}{
+		"name":         defectType.Name,
+		"category":     defectType.Category,
+		"description":  defectType.Description,
+		"fixed_points": defectType.FixedPoints,
+		"is_active":    defectType.IsActive,
+	}).Error
+}
+
+func (r *weavingQualityRepository) DeleteDefectType(id uint) error {
+	return r.db.Delete(&models.DefectType{}, id).Error
+}
+
+// preloadInspection loads what an inspection response shows; defect types may be soft-deleted
+func preloadInspection(db *gorm.DB) *gorm.DB {
+	return db.Preload("WeavingOrder").
+		Preload("Defects", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") }).
+		Preload("Defects.DefectType", func(db *gorm.DB) *gorm.DB { return db.Unscoped() })
+}
+
+func (r *weavingQualityRepository) FindInspections(filter interfaces.WeavingInspectionFilter, page, limit int) ([]models.WeavingQualityControl, int64, error) {
+	var inspections []models.WeavingQualityControl
+	var count int64
+
+	query := r.db.Model(&models.WeavingQualityControl{})
+	if filter.WeavingOrderID != 0 {
+		query = query.Where("weaving_order_id = ?", filter.WeavingOrderID)
+	}
+	if filter.OperationID != 0 {
+		query = query.Where("weaving_operation_id = ?", filter.OperationID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Uncorrected {
+		query = query.Where("status = ? AND corrected_at IS NULL", models.WeavingQCFailed)
+	}
+	if filter.From != nil {
+		query = query.Where("quality_check_time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("quality_check_time < ?", *filter.To)
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	err := preloadInspection(query).
+		Order("quality_check_time DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&inspections).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return inspections, count, nil
+}
+
+func (r *weavingQualityRepository) FindInspectionByID(id uint) (*models.WeavingQualityControl, error) {
+	var qc models.WeavingQualityControl
+	if err := preloadInspection(r.db).First(&qc, id).Error; err != nil {
+		return nil, err
+	}
+	return &qc, nil
+}
+
+func (r *weavingQualityRepository) CreateInspection(qc *models.WeavingQualityControl, orderStatuses []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Holds a concurrent send to dyeing back until the inspection is saved, so its guards see it
+		if err := lockWeavingOrder(tx, qc.WeavingOrderID, orderStatuses); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(qc).Error; err != nil {
+			return err
+		}
+		for i := range qc.Defects {
+			qc.Defects[i].WeavingQualityControlID = qc.ID
+		}
+		if len(qc.Defects) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&qc.Defects).Error; err != nil {
+				return err
+			}
+		}
+		if qc.WeavingOperationID == nil {
+			return nil
+		}
+		return tx.Model(&models.WeavingOperation{ID: *qc.WeavingOperationID}).
+			Update("quality_check_status", qc.Status).Error
+	})
+}
+
+func (r *weavingQualityRepository) DeleteInspection(qc *models.WeavingQualityControl) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.WeavingQualityControl{}, qc.ID).Error; err != nil {
+			return err
+		}
+		if qc.WeavingOperationID == nil {
+			return nil
+		}
+
+		status := models.WeavingQCPending
+		var latest []string
+		err := tx.Model(&models.WeavingQualityControl{}).
+			Where("weaving_operation_id = ?", *qc.WeavingOperationID).
+			Order("quality_check_time DESC, id DESC").
+			Limit(1).
+			Pluck("status", &latest).Error
+		if err != nil {
+			return err
+		}
+		if len(latest) > 0 {
+			status = latest[0]
+		}
+		return tx.Model(&models.WeavingOperation{ID: *qc.WeavingOperationID}).
+			Update("quality_check_status", status).Error
+	})
+}
+
+func (r *weavingQualityRepository) RecordCorrectiveAction(qc *models.WeavingQualityControl) error {
+	return r.db.Model(&models.WeavingQualityControl{ID: qc.ID}).Updates(map[string]interface{}{
+		"corrective_action": qc.CorrectiveAction,
+		"corrected_at":      qc.CorrectedAt,
+		"corrected_by":      qc.CorrectedBy,
+	}).Error
+}
+
+func (r *weavingQualityRepository) WithTx(tx *gorm.DB) interfaces.WeavingQualityRepository {
+	return &weavingQualityRepository{db: tx}
+}
+
+func (r *weavingQualityRepository) CountUncorrectedFailures(weavingOrderID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.WeavingQualityControl{}).
+		Where("weaving_order_id = ? AND status = ? AND corrected_at IS NULL", weavingOrderID, models.WeavingQCFailed).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,273 @@
+// File: internal/repository/mysql/yarn.go
+// Tạo tại: internal/repository/mysql/yarn.go
+// Mục đích: MySQL implementation cho thùng sợi và phiếu nhập/xuất sợi, khóa thùng khi ghi số dư
+
+package mysql
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type yarnBoxRepository struct {
+	db *gorm.DB
+}
+
+func NewYarnBoxRepository(db *gorm.DB) interfaces.YarnBoxRepository {
+	return &yarnBoxRepository{db: db}
+}
+
+func (r *yarnBoxRepository) FindAll(filter interfaces.YarnBoxFilter, page, limit int) ([]models.YarnBox, int64, error) {
+	var boxes []models.YarnBox
+	var count int64
+
+	query := r.db.Model(&models.YarnBox{})
+	if filter.Search != "" {
+		term := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("box_code LIKE ? OR yarn_type LIKE ?", term, term)
+	}
+	if filter.YarnType != "" {
+		query = query.Where("yarn_type = ?", filter.YarnType)
+	}
+	if filter.Location != "" {
+		query = query.Where("warehouse_location LIKE ?", escapeLike(filter.Location)+"%")
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&boxes).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return boxes, count, nil
+}
+
+func (r *yarnBoxRepository) FindByID(id uint) (*models.YarnBox, error) {
+	var box models.YarnBox
+	if err := r.db.First(&box, id).Error; err != nil {
+		return nil, err
+	}
+	return &box, nil
+}
+
+func (r *yarnBoxRepository) FindByCode(code string) (*models.YarnBox, error) {
+	var box models.YarnBox
+	if err := r.db.Where("box_code = ?", code).First(&box).Error; err != nil {
+		return nil, err
+	}
+	return &box, nil
+}
+
+// LastCodeWithPrefix returns the highest code made of prefix followed by digits, or "" when there is none.
+// Soft-deleted boxes count since their codes stay reserved.
+func (r *yarnBoxRepository) LastCodeWithPrefix(prefix string) (string, error) {
+	var codes []string
+	err := r.db.Unscoped().Model(&models.YarnBox{}).
+		Where("box_code REGEXP ?", "^"+regexp.QuoteMeta(prefix)+"[0-9]+$").
+		Order("CHAR_LENGTH(box_code) DESC, box_code DESC").
+		Limit(1).
+		Pluck("box_code", &codes).Error
+	if err != nil || len(codes) == 0 {
+		return "", err
+	}
+	return codes[0], nil
+}
+
+func (r *yarnBoxRepository) Create(box *models.YarnBox, opening *models.YarnInventoryTransaction) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(box).Error; err != nil {
+			return err
+		}
+		if opening == nil {
+			return nil
+		}
+		opening.ID = 0
+		opening.YarnBoxID = box.ID
+		return tx.Omit("YarnBox", "User").Create(opening).Error
+	})
+}
+
+func (r *yarnBoxRepository) Update(box *models.YarnBox) error {
+	return r.db.Model(box).Updates(map[string]interface{}{
+		"yarn_type":          box.YarnType,
+		"warehouse_location": box.WarehouseLocation,
+	}).Error
+}
+
+func (r *yarnBoxRepository) Delete(id uint) error {
+	return r.db.Delete(&models.YarnBox{}, id).Error
+}
+
+func (r *yarnBoxRepository) ChangeStatus(id uint, status string, fromStatuses []string) error {
+	result := r.db.Model(&models.YarnBox{}).
+		Where("id = ? AND status IN ?", id, fromStatuses).
+		Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrYarnBoxStatusChanged
+	}
+	return nil
+}
+
+func (r *yarnBoxRepository) Record(transaction *models.YarnInventoryTransaction, location string) (*models.YarnBox, error) {
+	var box models.YarnBox
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the box so concurrent movements apply one after the other
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&box, transaction.YarnBoxID).Error; err != nil {
+			return err
+		}
+
+		cones, weight := box.ConeQuantity, box.TotalWeight
+		switch transaction.TransactionType {
+		case models.YarnTransactionIn:
+			cones += transaction.Quantity
+			weight += transaction.Weight
+		case models.YarnTransactionOut:
+			// Yarn allocated to orders stays in the box until the allocation is issued; an issued
+			// allocation has already left the allocated status, so it is not held against itself
+			held, err := findHeldYarn(tx, []uint{box.ID})
+			if err != nil {
+				return err
+			}
+			freeCones, free := cones, weight
+			for _, row := range held {
+				freeCones = max(freeCones-row.Cones, 0)
+				free = roundWeight(free - row.Weight)
+			}
+			if transaction.Quantity > freeCones || roundWeight(transaction.Weight) > roundWeight(free) {
+				if len(held) > 0 {
+					return fmt.Errorf("%w: box %s holds %d cones, %v kg not allocated to orders",
+						interfaces.ErrInsufficientYarn, box.BoxCode, freeCones, max(free, 0))
+				}
+				return fmt.Errorf("%w: box %s holds %d cones, %v kg", interfaces.ErrInsufficientYarn, box.BoxCode, cones, roundWeight(weight))
+			}
+			cones -= transaction.Quantity
+			weight -= transaction.Weight
+		default:
+			return fmt.Errorf("unknown transaction type %q", transaction.TransactionType)
+		}
+		weight = roundWeight(weight)
+
+		if transaction.TransactionType == models.YarnTransactionIn && (box.Status == models.YarnBoxDepleted || box.ReceivedAt == nil) {
+			box.ReceivedAt = &transaction.TransactionDate
+		}
+		switch {
+		case cones == 0 && weight == 0:
+			box.Status = models.YarnBoxDepleted
+		case box.Status == models.YarnBoxDepleted:
+			box.Status = models.YarnBoxAvailable
+		}
+		box.ConeQuantity = cones
+		box.TotalWeight = weight
+		if location != "" {
+			box.WarehouseLocation = location
+		}
+
+		err := tx.Model(&box).Updates(map[string]interface{}{
+			"cone_quantity":      box.ConeQuantity,
+			"total_weight":       box.TotalWeight,
+			"status":             box.Status,
+			"warehouse_location": box.WarehouseLocation,
+			"received_at":        box.ReceivedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		transaction.RemainingQuantity = cones
+		transaction.RemainingWeight = weight
+		return tx.Omit("YarnBox", "User").Create(transaction).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &box, nil
+}
+
+func (r *yarnBoxRepository) FindTransactions(filter interfaces.YarnTransactionFilter, page, limit int) ([]models.YarnInventoryTransaction, int64, error) {
+	var transactions []models.YarnInventoryTransaction
+	var count int64
+
+	query := r.db.Model(&models.YarnInventoryTransaction{})
+	if filter.YarnBoxID != 0 {
+		query = query.Where("yarn_box_id = ?", filter.YarnBoxID)
+	}
+	if filter.TransactionType != "" {
+		query = query.Where("transaction_type = ?", filter.TransactionType)
+	}
+	if filter.From != nil {
+		query = query.Where("transaction_date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("transaction_date < ?", *filter.To)
+	}
+
+	// Count total
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Paginate
+	offset := (page - 1) * limit
+	err := query.Preload("YarnBox", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("User").
+		Order("transaction_date DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return transactions, count, nil
+}
+
+func (r *yarnBoxRepository) StockByType(yarnType string) ([]interfaces.YarnStock, error) {
+	// Yarn held for orders, per box
+	held := r.db.Model(&models.YarnOrder{}).
+		Select("yarn_box_id, SUM(cone_quantity) AS cones, SUM(total_weight) AS weight").
+		Where("status = ?", models.YarnAllocationAllocated).
+		Group("yarn_box_id")
+
+	query := r.db.Model(&models.YarnBox{}).
+		Joins("LEFT JOIN (?) AS held ON held.yarn_box_id = yarn_boxes.id", held).
+		Select("yarn_boxes.yarn_type, COUNT(*) AS boxes, "+
+			"COALESCE(SUM(yarn_boxes.cone_quantity), 0) AS cones, COALESCE(SUM(yarn_boxes.total_weight), 0) AS weight, "+
+			"COALESCE(SUM(CASE WHEN yarn_boxes.status = ? THEN yarn_boxes.cone_quantity "+
+			"ELSE LEAST(yarn_boxes.cone_quantity, COALESCE(held.cones, 0)) END), 0) AS allocated_cones, "+
+			"COALESCE(SUM(CASE WHEN yarn_boxes.status = ? THEN yarn_boxes.total_weight "+
+			"ELSE LEAST(yarn_boxes.total_weight, COALESCE(held.weight, 0)) END), 0) AS allocated_weight",
+			models.YarnBoxAllocated, models.YarnBoxAllocated).
+		Where("yarn_boxes.status <> ?", models.YarnBoxDepleted)
+	if yarnType != "" {
+		query = query.Where("yarn_boxes.yarn_type = ?", yarnType)
+	}
+
+	var stock []interfaces.YarnStock
+	err := query.Group("yarn_boxes.yarn_type").Order("yarn_boxes.yarn_type").Scan(&stock).Error
+	return stock, err
+}
+
+// roundWeight rounds to the 2 decimals the weight columns keep
+func roundWeight(weight float64) float64 {
+	return math.Round(weight*100) / 100
+}
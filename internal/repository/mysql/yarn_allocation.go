@@ -0,0 +1,318 @@
+// File: internal/repository/mysql/yarn_allocation.go
+// Tạo tại: internal/repository/mysql/yarn_allocation.go
+// Mục đích: MySQL implementation định mức sợi và phân bổ sợi FIFO cho đơn hàng
+
+package mysql
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+	"github.com/godiidev/appsynex/internal/repository/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type yarnComponentRepository struct {
+	db *gorm.DB
+}
+
+func NewYarnComponentRepository(db *gorm.DB) interfaces.YarnComponentRepository {
+	return &yarnComponentRepository{db: db}
+}
+
+func (r *yarnComponentRepository) FindByProduct(productID uint) ([]models.ProductYarnComponent, error) {
+	var components []models.ProductYarnComponent
+	err := r.db.Where("product_id = ?", productID).Order("yarn_type").Find(&components).Error
+	return components, err
+}
+
+func (r *yarnComponentRepository) FindByProducts(productIDs []uint) ([]models.ProductYarnComponent, error) {
+	var components []models.ProductYarnComponent
+	if len(productIDs) == 0 {
+		return components, nil
+	}
+	err := r.db.Where("product_id IN ?", productIDs).Order("product_id, yarn_type").Find(&components).Error
+	return components, err
+}
+
+func (r *yarnComponentRepository) Replace(productID uint, components []models.ProductYarnComponent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", productID).Delete(&models.ProductYarnComponent{}).Error; err != nil {
+			return err
+		}
+		if len(components) == 0 {
+			return nil
+		}
+		for i := range components {
+			components[i].ID = 0
+			components[i].ProductID = productID
+		}
+		return tx.Create(&components).Error
+	})
+}
+
+type yarnAllocationRepository struct {
+	db *gorm.DB
+}
+
+func NewYarnAllocationRepository(db *gorm.DB) interfaces.YarnAllocationRepository {
+	return &yarnAllocationRepository{db: db}
+}
+
+func (r *yarnAllocationRepository) FindByOrder(orderID uint) ([]models.YarnOrder, error) {
+	var allocations []models.YarnOrder
+	err := r.db.Preload("YarnBox", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("order_id = ?", orderID).
+		Order("created_at DESC, id DESC").
+		Find(&allocations).Error
+	return allocations, err
+}
+
+func (r *yarnAllocationRepository) FindByID(id uint) (*models.YarnOrder, error) {
+	var allocation models.YarnOrder
+	if err := r.db.Preload("YarnBox", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).First(&allocation, id).Error; err != nil {
+		return nil, err
+	}
+	return &allocation, nil
+}
+
+func (r *yarnAllocationRepository) Allocate(lines []interfaces.YarnAllocationLine, allowPartial bool) ([]models.YarnOrder, error) {
+	var allocated []models.YarnOrder
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Yarn taken from each box by earlier lines of this request
+		takenWeight := make(map[uint]float64)
+		takenCones := make(map[uint]int)
+
+		for _, line := range lines {
+			boxes, err := lockFreeYarn(tx, line.YarnType)
+			if err != nil {
+				return err
+			}
+
+			takes, need := splitYarn(boxes, line.Weight, takenWeight, takenCones)
+			for _, take := range takes {
+				allocation := line.Template
+				allocation.YarnBoxID = take.YarnBoxID
+				allocation.YarnType = take.YarnType
+				allocation.ConeQuantity = take.Cones
+				allocation.TotalWeight = take.Weight
+				allocation.Status = models.YarnAllocationAllocated
+				allocated = append(allocated, allocation)
+			}
+
+			if need > 0 && !allowPartial {
+				return fmt.Errorf("%w: %s needs %v kg more", interfaces.ErrInsufficientYarn, line.YarnType, need)
+			}
+		}
+
+		if len(allocated) == 0 {
+			return nil
+		}
+		return tx.Omit("YarnBox").Create(&allocated).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return allocated, nil
+}
+
+// yarnTake is the part of one box a line of an allocation request takes
+type yarnTake struct {
+	YarnBoxID uint
+	YarnType  string
+	Cones     int
+	Weight    float64
+}
+
+// splitYarn takes weight from the boxes in order, oldest first, and returns the takes with the weight
+// still missing. A box that counts cones gives whole cones, so a take can weigh a little more than
+// asked for. takenWeight and takenCones hold what earlier lines took and are updated.
+func splitYarn(boxes []models.YarnBox, want float64, takenWeight map[uint]float64, takenCones map[uint]int) ([]yarnTake, float64) {
+	var takes []yarnTake
+	need := roundWeight(want)
+	for _, box := range boxes {
+		if need <= 0 {
+			break
+		}
+		free := roundWeight(box.TotalWeight - takenWeight[box.ID])
+		freeCones := box.ConeQuantity - takenCones[box.ID]
+		if free <= 0 {
+			continue
+		}
+
+		weight, cones := free, freeCones
+		if need < free {
+			weight, cones = need, 0
+			// Whole cones where the box counts them
+			if box.ConeQuantity > 0 && freeCones > 0 {
+				perCone := box.TotalWeight / float64(box.ConeQuantity)
+				cones = int(math.Ceil(need / perCone))
+				if cones >= freeCones {
+					weight, cones = free, freeCones
+				} else {
+					weight = math.Min(free, roundWeight(float64(cones)*perCone))
+				}
+			}
+		}
+		takenWeight[box.ID] += weight
+		takenCones[box.ID] += cones
+		need = roundWeight(need - weight)
+
+		takes = append(takes, yarnTake{YarnBoxID: box.ID, YarnType: box.YarnType, Cones: cones, Weight: weight})
+	}
+	return takes, max(need, 0)
+}
+
+// lockFreeYarn locks the available boxes of the yarn type, so concurrent allocations of the same yarn
+// queue up, and returns them oldest receipt first with their cones and weight reduced by what is
+// already allocated
+func lockFreeYarn(tx *gorm.DB, yarnType string) ([]models.YarnBox, error) {
+	var boxes []models.YarnBox
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("yarn_type = ? AND status = ?", yarnType, models.YarnBoxAvailable).
+		Order("COALESCE(received_at, created_at), id").
+		Find(&boxes).Error
+	if err != nil || len(boxes) == 0 {
+		return nil, err
+	}
+
+	// Read after the lock so allocations committed by the transaction we waited for are seen
+	boxIDs := make([]uint, len(boxes))
+	for i, box := range boxes {
+		boxIDs[i] = box.ID
+	}
+	held, err := findHeldYarn(tx, boxIDs)
+	if err != nil {
+		return nil, err
+	}
+	heldByBox := make(map[uint]int, len(held))
+	for i, row := range held {
+		heldByBox[row.YarnBoxID] = i
+	}
+
+	free := boxes[:0]
+	for _, box := range boxes {
+		if i, ok := heldByBox[box.ID]; ok {
+			box.ConeQuantity = max(box.ConeQuantity-held[i].Cones, 0)
+			box.TotalWeight = roundWeight(box.TotalWeight - held[i].Weight)
+		}
+		if box.TotalWeight > 0 {
+			free = append(free, box)
+		}
+	}
+	return free, nil
+}
+
+// heldYarn is what the allocations not yet issued hold of a box
+type heldYarn struct {
+	YarnBoxID uint
+	Cones     int
+	Weight    float64
+}
+
+// findHeldYarn sums the allocated, not yet issued cones and weight per box
+func findHeldYarn(tx *gorm.DB, boxIDs []uint) ([]heldYarn, error) {
+	var held []heldYarn
+	err := tx.Model(&models.YarnOrder{}).
+		Select("yarn_box_id, SUM(cone_quantity) AS cones, SUM(total_weight) AS weight").
+		Where("yarn_box_id IN ? AND status = ?", boxIDs, models.YarnAllocationAllocated).
+		Group("yarn_box_id").
+		Scan(&held).Error
+	return held, err
+}
+
+func (r *yarnAllocationRepository) WithTx(tx *gorm.DB) interfaces.YarnAllocationRepository {
+	return &yarnAllocationRepository{db: tx}
+}
+
+func (r *yarnAllocationRepository) Release(orderID uint, ids []uint, reason string, now time.Time) (int64, error) {
+	query := r.db.Model(&models.YarnOrder{}).
+		Where("order_id = ? AND status = ?", orderID, models.YarnAllocationAllocated)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	result := query.Updates(map[string]interface{}{
+		"status":         models.YarnAllocationReleased,
+		"released_at":    now,
+		"release_reason": reason,
+	})
+	return result.RowsAffected, result.Error
+}
+
+func (r *yarnAllocationRepository) ChangeStatus(id uint, status, fromStatus string) error {
+	result := r.db.Model(&models.YarnOrder{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrYarnAllocationChanged
+	}
+	return nil
+}
+
+func (r *yarnAllocationRepository) Demand(orderID uint, statuses []string) ([]interfaces.YarnDemand, error) {
+	// scope limits rows of a table with an order_id to the order or the orders in statuses
+	scope := func(table string) *gorm.DB {
+		query := r.db.Table(table).
+			Joins("JOIN orders ON orders.id = " + table + ".order_id AND orders.deleted_at IS NULL")
+		if orderID != 0 {
+			return query.Where("orders.id = ?", orderID)
+		}
+		return query.Joins("JOIN order_statuses ON order_statuses.id = orders.order_status_id").
+			Where("order_statuses.status_name IN ?", statuses)
+	}
+
+	var demand []interfaces.YarnDemand
+	err := scope("order_items").
+		Select("orders.id AS order_id, orders.order_code, product_yarn_components.yarn_type, " +
+			"SUM(order_items.quantity * product_yarn_components.grams_per_meter) / 1000 AS required").
+		Joins("JOIN product_yarn_components ON product_yarn_components.product_id = order_items.product_id").
+		Group("orders.id, orders.order_code, product_yarn_components.yarn_type").
+		Order("orders.id, product_yarn_components.yarn_type").
+		Scan(&demand).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var covered []interfaces.YarnDemand
+	err = scope("yarn_orders").
+		Select("orders.id AS order_id, orders.order_code, yarn_orders.yarn_type, "+
+			"SUM(CASE WHEN yarn_orders.status = ? THEN yarn_orders.total_weight ELSE 0 END) AS allocated, "+
+			"SUM(CASE WHEN yarn_orders.status = ? THEN yarn_orders.total_weight ELSE 0 END) AS issued",
+			models.YarnAllocationAllocated, models.YarnAllocationIssued).
+		Where("yarn_orders.status IN ?", []string{models.YarnAllocationAllocated, models.YarnAllocationIssued}).
+		Group("orders.id, orders.order_code, yarn_orders.yarn_type").
+		Scan(&covered).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Merge coverage into the demand; yarn allocated of a type no longer in the bill of materials is kept
+	type key struct {
+		orderID  uint
+		yarnType string
+	}
+	index := make(map[key]int, len(demand))
+	for i, row := range demand {
+		row.Required = roundWeight(row.Required)
+		demand[i] = row
+		index[key{row.OrderID, row.YarnType}] = i
+	}
+	for _, row := range covered {
+		i, ok := index[key{row.OrderID, row.YarnType}]
+		if !ok {
+			demand = append(demand, interfaces.YarnDemand{OrderID: row.OrderID, OrderCode: row.OrderCode, YarnType: row.YarnType})
+			i = len(demand) - 1
+			index[key{row.OrderID, row.YarnType}] = i
+		}
+		demand[i].Allocated = roundWeight(row.Allocated)
+		demand[i].Issued = roundWeight(row.Issued)
+	}
+	return demand, nil
+}
@@ -0,0 +1,86 @@
+// File: internal/repository/mysql/yarn_allocation_test.go
+// Tạo tại: internal/repository/mysql/yarn_allocation_test.go
+// Mục đích: Kiểm thử chia khối lượng sợi FIFO theo thùng, làm tròn lên nguyên quả và phần còn thiếu
+
+package mysql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/godiidev/appsynex/internal/domain/models"
+)
+
+// testYarnBoxes: box 1 holds 10 cones of 2 kg, box 2 is 5 kg loose, box 3 holds 4 cones of 2 kg
+func testYarnBoxes() []models.YarnBox {
+	return []models.YarnBox{
+		{ID: 1, YarnType: "CT 30/1", ConeQuantity: 10, TotalWeight: 20},
+		{ID: 2, YarnType: "CT 30/1", TotalWeight: 5},
+		{ID: 3, YarnType: "CT 30/1", ConeQuantity: 4, TotalWeight: 8},
+	}
+}
+
+func yarnTakes(takes ...[3]float64) []yarnTake {
+	result := make([]yarnTake, len(takes))
+	for i, take := range takes {
+		result[i] = yarnTake{YarnBoxID: uint(take[0]), YarnType: "CT 30/1", Cones: int(take[1]), Weight: take[2]}
+	}
+	return result
+}
+
+func TestSplitYarn(t *testing.T) {
+	tests := []struct {
+		name        string
+		want        float64
+		takenWeight map[uint]float64
+		takenCones  map[uint]int
+		takes       []yarnTake
+		short       float64
+	}{
+		{name: "nothing asked", want: 0},
+		{name: "rounds up to whole cones", want: 5, takes: yarnTakes([3]float64{1, 3, 6})},
+		{name: "whole box", want: 20, takes: yarnTakes([3]float64{1, 10, 20})},
+		{name: "oldest box first, then the next", want: 23,
+			takes: yarnTakes([3]float64{1, 10, 20}, [3]float64{2, 0, 3})},
+		{name: "not enough yarn", want: 40,
+			takes: yarnTakes([3]float64{1, 10, 20}, [3]float64{2, 0, 5}, [3]float64{3, 4, 8}), short: 7},
+		{name: "rest of a box taken by an earlier line", want: 3,
+			takenWeight: map[uint]float64{1: 18}, takenCones: map[uint]int{1: 9},
+			takes: yarnTakes([3]float64{1, 1, 2}, [3]float64{2, 0, 1})},
+		{name: "cones round up to all that is left", want: 5,
+			takenWeight: map[uint]float64{1: 14}, takenCones: map[uint]int{1: 7},
+			takes: yarnTakes([3]float64{1, 3, 6})},
+		{name: "empty boxes are skipped", want: 25,
+			takenWeight: map[uint]float64{1: 20, 2: 5}, takenCones: map[uint]int{1: 10},
+			takes: yarnTakes([3]float64{3, 4, 8}), short: 17},
+		{name: "asked weight is rounded to 10 g first", want: 1.004, takes: yarnTakes([3]float64{1, 1, 2})},
+	}
+	for _, tt := range tests {
+		takenWeight, takenCones := tt.takenWeight, tt.takenCones
+		if takenWeight == nil {
+			takenWeight, takenCones = map[uint]float64{}, map[uint]int{}
+		}
+		takes, short := splitYarn(testYarnBoxes(), tt.want, takenWeight, takenCones)
+		if !reflect.DeepEqual(takes, tt.takes) || short != tt.short {
+			t.Errorf("%s: splitYarn = %+v, %v, want %+v, %v", tt.name, takes, short, tt.takes, tt.short)
+		}
+	}
+}
+
+func TestSplitYarnSharesBoxesAcrossLines(t *testing.T) {
+	takenWeight, takenCones := map[uint]float64{}, map[uint]int{}
+	boxes := testYarnBoxes()
+
+	first, _ := splitYarn(boxes, 15, takenWeight, takenCones)
+	second, short := splitYarn(boxes, 10, takenWeight, takenCones)
+
+	if want := yarnTakes([3]float64{1, 8, 16}); !reflect.DeepEqual(first, want) {
+		t.Errorf("first line = %+v, want %+v", first, want)
+	}
+	if want := yarnTakes([3]float64{1, 2, 4}, [3]float64{2, 0, 5}, [3]float64{3, 1, 2}); !reflect.DeepEqual(second, want) || short != 0 {
+		t.Errorf("second line = %+v, %v, want %+v, 0", second, short, want)
+	}
+	if takenWeight[1] != 20 || takenCones[1] != 10 || takenWeight[3] != 2 || takenCones[3] != 1 {
+		t.Errorf("taken = %v, %v", takenWeight, takenCones)
+	}
+}
@@ -0,0 +1,26 @@
+-- File: migrations/000014_image_management.down.sql
+-- Tạo tại: migrations/000014_image_management.down.sql
+
+ALTER TABLE product_images
+    DROP INDEX idx_product_images_sort,
+    DROP COLUMN sort_order,
+    DROP COLUMN height,
+    DROP COLUMN width,
+    DROP COLUMN file_size,
+    DROP COLUMN content_type,
+    DROP COLUMN file_name,
+    DROP COLUMN thumbnail_key,
+    DROP COLUMN thumbnail_url,
+    DROP COLUMN storage_key;
+
+ALTER TABLE sample_images
+    DROP INDEX idx_sample_images_sort,
+    DROP COLUMN sort_order,
+    DROP COLUMN height,
+    DROP COLUMN width,
+    DROP COLUMN file_size,
+    DROP COLUMN content_type,
+    DROP COLUMN file_name,
+    DROP COLUMN thumbnail_key,
+    DROP COLUMN thumbnail_url,
+    DROP COLUMN storage_key;
@@ -0,0 +1,27 @@
+-- File: migrations/000014_image_management.up.sql
+-- Tạo tại: migrations/000014_image_management.up.sql
+-- Mục đích: Bổ sung thông tin lưu trữ, thumbnail và thứ tự hiển thị cho sample_images, product_images
+
+ALTER TABLE sample_images
+    ADD COLUMN storage_key VARCHAR(500) NULL AFTER image_url,
+    ADD COLUMN thumbnail_url TEXT NULL AFTER storage_key,
+    ADD COLUMN thumbnail_key VARCHAR(500) NULL AFTER thumbnail_url,
+    ADD COLUMN file_name VARCHAR(255) NULL AFTER thumbnail_key,
+    ADD COLUMN content_type VARCHAR(100) NULL AFTER file_name,
+    ADD COLUMN file_size BIGINT NOT NULL DEFAULT 0 AFTER content_type,
+    ADD COLUMN width INT NOT NULL DEFAULT 0 AFTER file_size,
+    ADD COLUMN height INT NOT NULL DEFAULT 0 AFTER width,
+    ADD COLUMN sort_order INT NOT NULL DEFAULT 0 AFTER is_primary,
+    ADD INDEX idx_sample_images_sort (sample_product_id, sort_order);
+
+ALTER TABLE product_images
+    ADD COLUMN storage_key VARCHAR(500) NULL AFTER image_url,
+    ADD COLUMN thumbnail_url TEXT NULL AFTER storage_key,
+    ADD COLUMN thumbnail_key VARCHAR(500) NULL AFTER thumbnail_url,
+    ADD COLUMN file_name VARCHAR(255) NULL AFTER thumbnail_key,
+    ADD COLUMN content_type VARCHAR(100) NULL AFTER file_name,
+    ADD COLUMN file_size BIGINT NOT NULL DEFAULT 0 AFTER content_type,
+    ADD COLUMN width INT NOT NULL DEFAULT 0 AFTER file_size,
+    ADD COLUMN height INT NOT NULL DEFAULT 0 AFTER width,
+    ADD COLUMN sort_order INT NOT NULL DEFAULT 0 AFTER is_primary,
+    ADD INDEX idx_product_images_sort (product_id, sort_order);
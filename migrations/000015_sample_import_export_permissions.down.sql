@@ -0,0 +1,8 @@
+-- File: migrations/000015_sample_import_export_permissions.down.sql
+-- Tạo tại: migrations/000015_sample_import_export_permissions.down.sql
+
+DELETE rp FROM role_permissions rp
+JOIN permissions p ON rp.permission_id = p.id
+WHERE p.permission_name IN ('SAMPLE_IMPORT', 'SAMPLE_EXPORT');
+
+DELETE FROM permissions WHERE permission_name IN ('SAMPLE_IMPORT', 'SAMPLE_EXPORT');
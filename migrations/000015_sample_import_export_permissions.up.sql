@@ -0,0 +1,19 @@
+-- File: migrations/000015_sample_import_export_permissions.up.sql
+-- Tạo tại: migrations/000015_sample_import_export_permissions.up.sql
+-- Mục đích: Thêm quyền import/export dữ liệu mẫu vải
+
+INSERT INTO permissions (module, action, permission_name, description) VALUES
+('SAMPLE', 'IMPORT', 'SAMPLE_IMPORT', 'Import sample data'),
+('SAMPLE', 'EXPORT', 'SAMPLE_EXPORT', 'Export sample data');
+
+-- Grant new permissions to ADMIN role
+INSERT INTO role_permissions (role_id, permission_id, granted_by, granted_at)
+SELECT
+    r.id as role_id,
+    p.id as permission_id,
+    1 as granted_by,
+    NOW() as granted_at
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.role_name = 'ADMIN'
+AND p.permission_name IN ('SAMPLE_IMPORT', 'SAMPLE_EXPORT');
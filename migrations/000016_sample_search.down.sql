@@ -0,0 +1,6 @@
+-- File: migrations/000016_sample_search.down.sql
+-- Tạo tại: migrations/000016_sample_search.down.sql
+
+ALTER TABLE sample_products
+    DROP INDEX ft_sample_products_search,
+    DROP COLUMN search_text;
@@ -0,0 +1,12 @@
+-- File: migrations/000016_sample_search.up.sql
+-- Tạo tại: migrations/000016_sample_search.up.sql
+-- Mục đích: Cột search_text (đã bỏ dấu) và FULLTEXT index ngram cho tìm kiếm mẫu vải
+
+ALTER TABLE sample_products
+    ADD COLUMN search_text TEXT NULL AFTER barcode;
+
+-- No SQL backfill: LOWER() keeps the diacritics the search strips from queries. The API fills the
+-- search_text left NULL with the Go normalizer when it starts; POST /samples/search/reindex rebuilds it all.
+
+ALTER TABLE sample_products
+    ADD FULLTEXT INDEX ft_sample_products_search (search_text) WITH PARSER ngram;
@@ -0,0 +1,11 @@
+-- File: migrations/000017_sample_filter_indexes.down.sql
+-- Tạo tại: migrations/000017_sample_filter_indexes.down.sql
+
+ALTER TABLE sample_products
+    DROP INDEX idx_sample_weight,
+    DROP INDEX idx_sample_width,
+    DROP INDEX idx_sample_remaining_quantity,
+    DROP INDEX idx_sample_location,
+    DROP INDEX idx_sample_fiber_content,
+    DROP INDEX idx_sample_created_at,
+    DROP INDEX idx_sample_updated_at;
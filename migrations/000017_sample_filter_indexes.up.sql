@@ -0,0 +1,12 @@
+-- File: migrations/000017_sample_filter_indexes.up.sql
+-- Tạo tại: migrations/000017_sample_filter_indexes.up.sql
+-- Mục đích: Index cho các cột lọc/sắp xếp của sample_products
+
+ALTER TABLE sample_products
+    ADD INDEX idx_sample_weight (weight),
+    ADD INDEX idx_sample_width (width),
+    ADD INDEX idx_sample_remaining_quantity (remaining_quantity),
+    ADD INDEX idx_sample_location (sample_location),
+    ADD INDEX idx_sample_fiber_content (fiber_content),
+    ADD INDEX idx_sample_created_at (created_at),
+    ADD INDEX idx_sample_updated_at (updated_at);
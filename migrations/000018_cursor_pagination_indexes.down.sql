@@ -0,0 +1,8 @@
+-- File: migrations/000018_cursor_pagination_indexes.down.sql
+-- Tạo tại: migrations/000018_cursor_pagination_indexes.down.sql
+
+ALTER TABLE sample_products
+    DROP INDEX idx_sample_created_at_id;
+
+ALTER TABLE users
+    DROP INDEX idx_users_created_at_id;
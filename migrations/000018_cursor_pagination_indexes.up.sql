@@ -0,0 +1,9 @@
+-- File: migrations/000018_cursor_pagination_indexes.up.sql
+-- Tạo tại: migrations/000018_cursor_pagination_indexes.up.sql
+-- Mục đích: Index (cột sắp xếp, id) cho phân trang keyset
+
+ALTER TABLE users
+    ADD INDEX idx_users_created_at_id (created_at, id);
+
+ALTER TABLE sample_products
+    ADD INDEX idx_sample_created_at_id (created_at, id);
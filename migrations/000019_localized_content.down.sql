@@ -0,0 +1,12 @@
+-- File: migrations/000019_localized_content.down.sql
+-- Tạo tại: migrations/000019_localized_content.down.sql
+
+ALTER TABLE sample_products
+    DROP COLUMN description_en;
+
+ALTER TABLE products
+    DROP COLUMN description_en;
+
+ALTER TABLE product_categories
+    DROP COLUMN category_name_en,
+    DROP COLUMN description_en;
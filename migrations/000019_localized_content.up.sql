@@ -0,0 +1,13 @@
+-- File: migrations/000019_localized_content.up.sql
+-- Tạo tại: migrations/000019_localized_content.up.sql
+-- Mục đích: Cột tiếng Anh cho tên/mô tả danh mục và mô tả sản phẩm, mẫu vải
+
+ALTER TABLE product_categories
+    ADD COLUMN category_name_en VARCHAR(255) NULL AFTER category_name,
+    ADD COLUMN description_en TEXT NULL AFTER description;
+
+ALTER TABLE products
+    ADD COLUMN description_en TEXT NULL AFTER description;
+
+ALTER TABLE sample_products
+    ADD COLUMN description_en TEXT NULL AFTER description;
@@ -0,0 +1,7 @@
+-- File: migrations/000020_customer_indexes.down.sql
+-- Tạo tại: migrations/000020_customer_indexes.down.sql
+
+ALTER TABLE customers
+    DROP INDEX idx_customers_created_at_id,
+    DROP INDEX idx_customers_company_name,
+    DROP INDEX idx_customers_account_status;
@@ -0,0 +1,8 @@
+-- File: migrations/000020_customer_indexes.up.sql
+-- Tạo tại: migrations/000020_customer_indexes.up.sql
+-- Mục đích: Index cho tìm kiếm, lọc và phân trang danh sách khách hàng
+
+ALTER TABLE customers
+    ADD INDEX idx_customers_created_at_id (created_at, id),
+    ADD INDEX idx_customers_company_name (company_name),
+    ADD INDEX idx_customers_account_status (account_status);
@@ -0,0 +1,6 @@
+-- File: migrations/000021_customer_activity_timeline.down.sql
+-- Tạo tại: migrations/000021_customer_activity_timeline.down.sql
+
+ALTER TABLE customer_activity_log
+    DROP INDEX idx_customer_activity_timeline,
+    DROP INDEX idx_customer_activity_type;
@@ -0,0 +1,7 @@
+-- File: migrations/000021_customer_activity_timeline.up.sql
+-- Tạo tại: migrations/000021_customer_activity_timeline.up.sql
+-- Mục đích: Index cho timeline hoạt động khách hàng (lọc theo khách hàng, loại hoạt động và ngày)
+
+ALTER TABLE customer_activity_log
+    ADD INDEX idx_customer_activity_timeline (customer_id, activity_date, id),
+    ADD INDEX idx_customer_activity_type (customer_id, activity_type, activity_date);
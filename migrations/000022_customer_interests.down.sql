@@ -0,0 +1,19 @@
+-- File: migrations/000022_customer_interests.down.sql
+-- Tạo tại: migrations/000022_customer_interests.down.sql
+
+ALTER TABLE customer_search_history
+    DROP INDEX idx_customer_search_timeline,
+    DROP INDEX idx_customer_search_term,
+    DROP COLUMN normalized_query,
+    DROP COLUMN result_count;
+
+DELETE FROM customer_saved_items WHERE product_id IS NULL;
+
+ALTER TABLE customer_saved_items
+    DROP FOREIGN KEY fk_customer_saved_sample,
+    DROP INDEX idx_customer_saved_product,
+    DROP INDEX idx_customer_saved_sample,
+    DROP INDEX idx_customer_saved_sample_id,
+    DROP INDEX idx_customer_saved_at,
+    DROP COLUMN sample_product_id,
+    MODIFY product_id INT UNSIGNED NOT NULL;
@@ -0,0 +1,20 @@
+-- File: migrations/000022_customer_interests.up.sql
+-- Tạo tại: migrations/000022_customer_interests.up.sql
+-- Mục đích: Cho phép lưu cả mẫu vào danh sách yêu thích của khách hàng và chuẩn hóa lịch sử tìm kiếm để thống kê
+
+-- Saved items: a product or a sample, each at most once per customer
+ALTER TABLE customer_saved_items
+    MODIFY product_id INT UNSIGNED NULL,
+    ADD COLUMN sample_product_id INT UNSIGNED NULL AFTER product_id,
+    ADD UNIQUE INDEX idx_customer_saved_product (customer_id, product_id),
+    ADD UNIQUE INDEX idx_customer_saved_sample (customer_id, sample_product_id),
+    ADD INDEX idx_customer_saved_sample_id (sample_product_id),
+    ADD INDEX idx_customer_saved_at (saved_at),
+    ADD CONSTRAINT fk_customer_saved_sample FOREIGN KEY (sample_product_id) REFERENCES sample_products (id) ON DELETE CASCADE;
+
+-- Search history: normalized query for grouping equal searches, and the result count
+ALTER TABLE customer_search_history
+    ADD COLUMN normalized_query VARCHAR(255) NOT NULL DEFAULT '' AFTER search_query,
+    ADD COLUMN result_count INT NULL AFTER search_date,
+    ADD INDEX idx_customer_search_timeline (customer_id, search_date, id),
+    ADD INDEX idx_customer_search_term (search_date, normalized_query);
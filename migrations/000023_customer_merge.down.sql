@@ -0,0 +1,15 @@
+-- File: migrations/000023_customer_merge.down.sql
+-- Tạo tại: migrations/000023_customer_merge.down.sql
+
+DELETE rp FROM role_permissions rp
+JOIN permissions p ON rp.permission_id = p.id
+WHERE p.permission_name IN ('CUSTOMER_MERGE');
+
+DELETE FROM permissions WHERE permission_name IN ('CUSTOMER_MERGE');
+
+ALTER TABLE customers
+    DROP INDEX idx_customers_tax_id,
+    DROP INDEX idx_customers_email,
+    DROP INDEX idx_customers_phone;
+
+DROP TABLE IF EXISTS customer_merges;
@@ -0,0 +1,42 @@
+-- File: migrations/000023_customer_merge.up.sql
+-- Tạo tại: migrations/000023_customer_merge.up.sql
+-- Mục đích: Bảng lưu vết gộp khách hàng trùng lặp và quyền gộp khách hàng
+
+CREATE TABLE IF NOT EXISTS customer_merges (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    survivor_id INT UNSIGNED NOT NULL,
+    merged_customer_id INT UNSIGNED NOT NULL,
+    merged_customer_code VARCHAR(100) NOT NULL,
+    merged_snapshot JSON NOT NULL, -- Customer row as it was before the merge
+    moved_records JSON NOT NULL, -- Rows re-pointed per table
+    reason TEXT NULL,
+    merged_by INT UNSIGNED NULL,
+    merged_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_customer_merges_survivor (survivor_id),
+    INDEX idx_customer_merges_merged (merged_customer_id),
+    CONSTRAINT fk_customer_merges_survivor FOREIGN KEY (survivor_id) REFERENCES customers (id),
+    CONSTRAINT fk_customer_merges_merged FOREIGN KEY (merged_customer_id) REFERENCES customers (id),
+    CONSTRAINT fk_customer_merges_user FOREIGN KEY (merged_by) REFERENCES users (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Duplicate detection looks customers up by tax ID, email and phone
+ALTER TABLE customers
+    ADD INDEX idx_customers_tax_id (tax_id),
+    ADD INDEX idx_customers_email (email),
+    ADD INDEX idx_customers_phone (phone);
+
+INSERT INTO permissions (module, action, permission_name, description) VALUES
+('CUSTOMER', 'MERGE', 'CUSTOMER_MERGE', 'Merge duplicate customers');
+
+-- Grant new permissions to ADMIN role
+INSERT INTO role_permissions (role_id, permission_id, granted_by, granted_at)
+SELECT
+    r.id as role_id,
+    p.id as permission_id,
+    1 as granted_by,
+    NOW() as granted_at
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.role_name = 'ADMIN'
+AND p.permission_name IN ('CUSTOMER_MERGE');
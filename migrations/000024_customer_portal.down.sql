@@ -0,0 +1,7 @@
+-- File: migrations/000024_customer_portal.down.sql
+-- Tạo tại: migrations/000024_customer_portal.down.sql
+
+ALTER TABLE lap_dip_dispatches
+    DROP INDEX idx_lap_dip_dispatches_status;
+
+DROP TABLE IF EXISTS customer_accounts;
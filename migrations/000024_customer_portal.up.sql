@@ -0,0 +1,23 @@
+-- File: migrations/000024_customer_portal.up.sql
+-- Tạo tại: migrations/000024_customer_portal.up.sql
+-- Mục đích: Tài khoản đăng nhập cổng portal cho khách hàng (customer_accounts)
+
+CREATE TABLE IF NOT EXISTS customer_accounts (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    customer_id INT UNSIGNED NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    full_name VARCHAR(255) NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'active', -- active, disabled
+    last_login TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_customer_accounts_email (email),
+    INDEX idx_customer_accounts_customer (customer_id),
+    CONSTRAINT fk_customer_accounts_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Portal lap dip lists filter by status and sort by dispatch date
+ALTER TABLE lap_dip_dispatches
+    ADD INDEX idx_lap_dip_dispatches_status (status);
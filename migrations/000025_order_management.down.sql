@@ -0,0 +1,9 @@
+-- File: migrations/000025_order_management.down.sql
+-- Tạo tại: migrations/000025_order_management.down.sql
+
+ALTER TABLE order_histories
+    DROP INDEX idx_order_histories_order_changed;
+
+ALTER TABLE orders
+    DROP INDEX idx_orders_due_date,
+    DROP INDEX idx_orders_created_at;
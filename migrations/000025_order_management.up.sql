@@ -0,0 +1,12 @@
+-- File: migrations/000025_order_management.up.sql
+-- Tạo tại: migrations/000025_order_management.up.sql
+-- Mục đích: Index cho danh sách đơn hàng (lọc theo hạn giao, sắp xếp theo ngày tạo) và lịch sử trạng thái
+
+-- Order lists filter by due date and sort by creation date
+ALTER TABLE orders
+    ADD INDEX idx_orders_due_date (due_date),
+    ADD INDEX idx_orders_created_at (created_at);
+
+-- Order detail reads the history of one order in time order
+ALTER TABLE order_histories
+    ADD INDEX idx_order_histories_order_changed (order_id, changed_at);
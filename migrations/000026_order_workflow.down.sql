@@ -0,0 +1,4 @@
+-- File: migrations/000026_order_workflow.down.sql
+-- Tạo tại: migrations/000026_order_workflow.down.sql
+
+DELETE FROM order_statuses WHERE status_name = 'SHIPPED';
@@ -0,0 +1,6 @@
+-- File: migrations/000026_order_workflow.up.sql
+-- Tạo tại: migrations/000026_order_workflow.up.sql
+-- Mục đích: Thêm trạng thái SHIPPED cho luồng duyệt/hủy/giao đơn hàng
+
+INSERT IGNORE INTO order_statuses (status_name, description) VALUES
+('SHIPPED', 'Đã giao hàng');
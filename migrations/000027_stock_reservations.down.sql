@@ -0,0 +1,23 @@
+-- File: migrations/000027_stock_reservations.down.sql
+-- Tạo tại: migrations/000027_stock_reservations.down.sql
+
+ALTER TABLE dyeing_lots
+    DROP FOREIGN KEY fk_dyeing_lots_product,
+    DROP INDEX idx_dyeing_lots_product_color,
+    DROP COLUMN expected_date,
+    DROP COLUMN planned_length,
+    DROP COLUMN color_code,
+    DROP COLUMN product_id;
+
+ALTER TABLE weaving_orders
+    DROP INDEX idx_weaving_orders_product_status,
+    DROP COLUMN planned_length,
+    DROP COLUMN color_code;
+
+ALTER TABLE lots
+    DROP INDEX idx_lots_product_color;
+
+ALTER TABLE order_items
+    DROP COLUMN color_code;
+
+DROP TABLE IF EXISTS stock_reservations;
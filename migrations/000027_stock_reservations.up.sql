@@ -0,0 +1,55 @@
+-- File: migrations/000027_stock_reservations.up.sql
+-- Tạo tại: migrations/000027_stock_reservations.up.sql
+-- Mục đích: Giữ hàng (cuộn vải theo lô) cho dòng đơn hàng và dữ liệu tính khả năng đáp ứng (ATP) từ tồn kho, dệt và nhuộm
+
+-- A reservation holds one whole fabric roll for an order line until it is released, expires or ships
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    order_id INT UNSIGNED NOT NULL,
+    order_item_id INT UNSIGNED NOT NULL,
+    lot_id INT UNSIGNED NOT NULL,
+    fabric_roll_id INT UNSIGNED NOT NULL,
+    length DECIMAL(10,2) NOT NULL,
+    weight DECIMAL(10,2) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active', -- 'active', 'released', 'expired', 'consumed'
+    expires_at TIMESTAMP NULL,
+    reserved_by INT UNSIGNED NULL,
+    released_at TIMESTAMP NULL,
+    release_reason VARCHAR(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_stock_reservations_order (order_id, status),
+    INDEX idx_stock_reservations_item (order_item_id),
+    INDEX idx_stock_reservations_roll (fabric_roll_id, status),
+    INDEX idx_stock_reservations_lot (lot_id),
+    INDEX idx_stock_reservations_expiry (status, expires_at),
+    CONSTRAINT fk_stock_reservations_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE,
+    CONSTRAINT fk_stock_reservations_item FOREIGN KEY (order_item_id) REFERENCES order_items (id) ON DELETE CASCADE,
+    CONSTRAINT fk_stock_reservations_lot FOREIGN KEY (lot_id) REFERENCES lots (id) ON DELETE CASCADE,
+    CONSTRAINT fk_stock_reservations_roll FOREIGN KEY (fabric_roll_id) REFERENCES fabric_rolls (id) ON DELETE CASCADE,
+    CONSTRAINT fk_stock_reservations_user FOREIGN KEY (reserved_by) REFERENCES users (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Order lines name the color to deliver; empty means the product color
+ALTER TABLE order_items
+    ADD COLUMN color_code VARCHAR(100) NULL AFTER product_id;
+
+-- Availability looks lots up by product and color
+ALTER TABLE lots
+    ADD INDEX idx_lots_product_color (product_id, color_code);
+
+-- Planned output of weaving orders in meters; color_code is the color the greige will be dyed to, if known
+ALTER TABLE weaving_orders
+    ADD COLUMN color_code VARCHAR(100) NULL AFTER product_id,
+    ADD COLUMN planned_length DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER color_code,
+    ADD INDEX idx_weaving_orders_product_status (product_id, status);
+
+-- What a dyeing lot will yield and when
+ALTER TABLE dyeing_lots
+    ADD COLUMN product_id INT UNSIGNED NULL AFTER dyeing_subcontractor_id,
+    ADD COLUMN color_code VARCHAR(100) NULL AFTER product_id,
+    ADD COLUMN planned_length DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER weight,
+    ADD COLUMN expected_date TIMESTAMP NULL AFTER planned_length,
+    ADD INDEX idx_dyeing_lots_product_color (product_id, color_code),
+    ADD CONSTRAINT fk_dyeing_lots_product FOREIGN KEY (product_id) REFERENCES products (id);
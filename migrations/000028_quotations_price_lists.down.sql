@@ -0,0 +1,17 @@
+-- File: migrations/000028_quotations_price_lists.down.sql
+-- Tạo tại: migrations/000028_quotations_price_lists.down.sql
+
+DELETE rp FROM role_permissions rp
+JOIN permissions p ON rp.permission_id = p.id
+WHERE p.permission_name IN ('ORDER_QUOTE', 'PRODUCT_PRICING');
+
+DELETE FROM permissions WHERE permission_name IN ('ORDER_QUOTE', 'PRODUCT_PRICING');
+
+DROP TABLE IF EXISTS quotation_items;
+DROP TABLE IF EXISTS quotations;
+DROP TABLE IF EXISTS price_list_items;
+DROP TABLE IF EXISTS price_lists;
+
+ALTER TABLE customers
+    DROP INDEX idx_customers_price_tier,
+    DROP COLUMN price_tier;
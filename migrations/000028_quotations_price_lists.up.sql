@@ -0,0 +1,111 @@
+-- File: migrations/000028_quotations_price_lists.up.sql
+-- Tạo tại: migrations/000028_quotations_price_lists.up.sql
+-- Mục đích: Bảng giá theo khách hàng/nhóm giá (có bậc số lượng, thời hạn), báo giá và quyền liên quan
+
+-- Customers on a tier get that tier's price lists
+ALTER TABLE customers
+    ADD COLUMN price_tier VARCHAR(50) NULL AFTER account_status,
+    ADD INDEX idx_customers_price_tier (price_tier);
+
+-- A price list applies to one customer, one tier, or everyone when both are empty
+CREATE TABLE IF NOT EXISTS price_lists (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    customer_id INT UNSIGNED NULL,
+    price_tier VARCHAR(50) NULL,
+    valid_from DATE NULL,
+    valid_to DATE NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    notes TEXT NULL,
+    created_by INT UNSIGNED NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    PRIMARY KEY (id),
+    INDEX idx_price_lists_customer (customer_id),
+    INDEX idx_price_lists_tier (price_tier),
+    INDEX idx_price_lists_deleted_at (deleted_at),
+    CONSTRAINT fk_price_lists_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE CASCADE,
+    CONSTRAINT fk_price_lists_user FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Quantity breaks: the row with the highest min_quantity not above the ordered quantity applies
+CREATE TABLE IF NOT EXISTS price_list_items (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    price_list_id INT UNSIGNED NOT NULL,
+    product_id INT UNSIGNED NOT NULL,
+    min_quantity DECIMAL(10,2) NOT NULL DEFAULT 0.00,
+    unit_price DECIMAL(15,2) NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_price_list_items_break (price_list_id, product_id, min_quantity),
+    INDEX idx_price_list_items_product (product_id),
+    CONSTRAINT fk_price_list_items_list FOREIGN KEY (price_list_id) REFERENCES price_lists (id) ON DELETE CASCADE,
+    CONSTRAINT fk_price_list_items_product FOREIGN KEY (product_id) REFERENCES products (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS quotations (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    quotation_code VARCHAR(100) NOT NULL,
+    customer_id INT UNSIGNED NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft', -- 'draft', 'sent', 'accepted', 'rejected'
+    issue_date DATE NOT NULL,
+    valid_until DATE NOT NULL,
+    discount_percent DECIMAL(5,2) NOT NULL DEFAULT 0.00,
+    subtotal DECIMAL(15,2) NOT NULL DEFAULT 0.00, -- Before discounts
+    discount_amount DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    total_amount DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    notes TEXT NULL,
+    terms TEXT NULL,
+    order_id INT UNSIGNED NULL, -- Order the accepted quotation was converted into
+    created_by INT UNSIGNED NULL,
+    responded_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_quotations_code (quotation_code),
+    INDEX idx_quotations_customer (customer_id),
+    INDEX idx_quotations_status (status),
+    INDEX idx_quotations_created_at (created_at),
+    INDEX idx_quotations_deleted_at (deleted_at),
+    CONSTRAINT fk_quotations_customer FOREIGN KEY (customer_id) REFERENCES customers (id),
+    CONSTRAINT fk_quotations_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE SET NULL,
+    CONSTRAINT fk_quotations_user FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- net_price is the unit price after the line and quotation discounts; orders are created at this price
+CREATE TABLE IF NOT EXISTS quotation_items (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    quotation_id INT UNSIGNED NOT NULL,
+    product_id INT UNSIGNED NOT NULL,
+    color_code VARCHAR(100) NULL,
+    quantity DECIMAL(10,2) NOT NULL,
+    unit_price DECIMAL(15,2) NOT NULL,
+    discount_percent DECIMAL(5,2) NOT NULL DEFAULT 0.00,
+    net_price DECIMAL(15,2) NOT NULL,
+    subtotal DECIMAL(15,2) NOT NULL,
+    price_list_id INT UNSIGNED NULL, -- Where unit_price came from; NULL when entered or from the product
+    notes TEXT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_quotation_items_quotation (quotation_id),
+    INDEX idx_quotation_items_product (product_id),
+    CONSTRAINT fk_quotation_items_quotation FOREIGN KEY (quotation_id) REFERENCES quotations (id) ON DELETE CASCADE,
+    CONSTRAINT fk_quotation_items_product FOREIGN KEY (product_id) REFERENCES products (id),
+    CONSTRAINT fk_quotation_items_price_list FOREIGN KEY (price_list_id) REFERENCES price_lists (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO permissions (module, action, permission_name, description) VALUES
+('ORDER', 'QUOTE', 'ORDER_QUOTE', 'Create and manage quotations'),
+('PRODUCT', 'PRICING', 'PRODUCT_PRICING', 'Manage price lists');
+
+-- Grant new permissions to ADMIN role
+INSERT INTO role_permissions (role_id, permission_id, granted_by, granted_at)
+SELECT
+    r.id as role_id,
+    p.id as permission_id,
+    1 as granted_by,
+    NOW() as granted_at
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.role_name = 'ADMIN'
+AND p.permission_name IN ('ORDER_QUOTE', 'PRODUCT_PRICING');
@@ -0,0 +1,25 @@
+-- File: migrations/000029_multi_currency.down.sql
+-- Tạo tại: migrations/000029_multi_currency.down.sql
+
+DELETE rp FROM role_permissions rp
+JOIN permissions p ON rp.permission_id = p.id
+WHERE p.permission_name IN ('FINANCE_RATES');
+
+DELETE FROM permissions WHERE permission_name IN ('FINANCE_RATES');
+
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE quotations DROP COLUMN currency;
+ALTER TABLE price_lists DROP COLUMN currency;
+ALTER TABLE sales DROP COLUMN currency;
+ALTER TABLE dyeing_subcontractors DROP COLUMN currency;
+ALTER TABLE weaving_financials DROP COLUMN currency;
+ALTER TABLE shipping DROP COLUMN currency;
+ALTER TABLE customer_orders DROP COLUMN currency;
+
+ALTER TABLE orders
+    DROP INDEX idx_orders_currency_created,
+    DROP COLUMN currency;
+
+ALTER TABLE customers DROP COLUMN currency;
+ALTER TABLE products DROP COLUMN currency;
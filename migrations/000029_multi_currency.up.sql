@@ -0,0 +1,70 @@
+-- File: migrations/000029_multi_currency.up.sql
+-- Tạo tại: migrations/000029_multi_currency.up.sql
+-- Mục đích: Thêm mã tiền tệ cho các cột tiền, bảng tỷ giá theo ngày hiệu lực và quyền quản lý tỷ giá
+
+-- Every amount is in the currency of its row; existing rows were entered in VND
+ALTER TABLE products
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'VND' AFTER sales_price;
+
+-- Currency new orders and quotations of the customer default to
+ALTER TABLE customers
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'VND' AFTER price_tier;
+
+-- Order items are priced in the order currency
+ALTER TABLE orders
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'VND' AFTER total_amount,
+    ADD INDEX idx_orders_currency_created (currency, created_at);
+
+ALTER TABLE customer_orders
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'VND' AFTER total_amount;
+
+ALTER TABLE shipping
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'VND' AFTER shipping_cost;
+
+ALTER TABLE weaving_financials
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'VND' AFTER total_cost;
+
+ALTER TABLE dyeing_subcontractors
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'VND' AFTER dyeing_cost_per_unit;
+
+ALTER TABLE sales
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'VND' AFTER price;
+
+-- Price list and quotation items are in the currency of their list or quotation
+ALTER TABLE price_lists
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'VND' AFTER price_tier;
+
+ALTER TABLE quotations
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'VND' AFTER valid_until;
+
+-- One unit of from_currency is worth rate units of to_currency from effective_date until the next rate of the pair
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    from_currency CHAR(3) NOT NULL,
+    to_currency CHAR(3) NOT NULL,
+    rate DECIMAL(20,8) NOT NULL,
+    effective_date DATE NOT NULL,
+    source VARCHAR(100) NULL, -- e.g. Vietcombank, SBV
+    notes TEXT NULL,
+    created_by INT UNSIGNED NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_exchange_rates_pair_date (from_currency, to_currency, effective_date),
+    CONSTRAINT fk_exchange_rates_user FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO permissions (module, action, permission_name, description) VALUES
+('FINANCE', 'RATES', 'FINANCE_RATES', 'Manage exchange rates');
+
+-- Grant new permissions to ADMIN role
+INSERT INTO role_permissions (role_id, permission_id, granted_by, granted_at)
+SELECT
+    r.id as role_id,
+    p.id as permission_id,
+    1 as granted_by,
+    NOW() as granted_at
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.role_name = 'ADMIN'
+AND p.permission_name IN ('FINANCE_RATES');
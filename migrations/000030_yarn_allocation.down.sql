@@ -0,0 +1,16 @@
+-- File: migrations/000030_yarn_allocation.down.sql
+-- Tạo tại: migrations/000030_yarn_allocation.down.sql
+
+ALTER TABLE yarn_orders
+    DROP FOREIGN KEY fk_yarn_orders_user,
+    DROP INDEX idx_yarn_orders_box_status,
+    DROP INDEX idx_yarn_orders_order_status,
+    DROP COLUMN release_reason,
+    DROP COLUMN released_at,
+    DROP COLUMN allocated_by;
+
+ALTER TABLE yarn_boxes
+    DROP INDEX idx_yarn_boxes_type_received,
+    DROP COLUMN received_at;
+
+DROP TABLE IF EXISTS product_yarn_components;
@@ -0,0 +1,38 @@
+-- File: migrations/000030_yarn_allocation.up.sql
+-- Tạo tại: migrations/000030_yarn_allocation.up.sql
+-- Mục đích: Định mức sợi theo sản phẩm (gram/mét), ngày nhận thùng sợi để phân bổ FIFO và theo dõi phân bổ sợi cho đơn hàng
+
+-- Bill of materials: grams of each yarn type woven into one meter of the product
+CREATE TABLE IF NOT EXISTS product_yarn_components (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    product_id INT UNSIGNED NOT NULL,
+    yarn_type VARCHAR(255) NOT NULL,
+    grams_per_meter DECIMAL(10,2) NOT NULL,
+    notes TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_product_yarn_components_type (product_id, yarn_type),
+    CONSTRAINT fk_product_yarn_components_product FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Boxes are allocated oldest receipt first
+ALTER TABLE yarn_boxes
+    ADD COLUMN received_at TIMESTAMP NULL AFTER status,
+    ADD INDEX idx_yarn_boxes_type_received (yarn_type, status, received_at);
+
+UPDATE yarn_boxes
+SET received_at = COALESCE(
+    (SELECT MIN(t.transaction_date) FROM yarn_inventory_transactions t
+     WHERE t.yarn_box_id = yarn_boxes.id AND t.transaction_type = 'IN'),
+    created_at);
+
+-- An allocation holds part of a box for an order until the yarn is issued to weaving or released
+ALTER TABLE yarn_orders
+    MODIFY COLUMN status VARCHAR(50) NOT NULL DEFAULT 'allocated', -- 'allocated', 'issued', 'released'
+    ADD COLUMN allocated_by INT UNSIGNED NULL AFTER status,
+    ADD COLUMN released_at TIMESTAMP NULL AFTER allocated_by,
+    ADD COLUMN release_reason VARCHAR(255) NULL AFTER released_at,
+    ADD INDEX idx_yarn_orders_order_status (order_id, status),
+    ADD INDEX idx_yarn_orders_box_status (yarn_box_id, status),
+    ADD CONSTRAINT fk_yarn_orders_user FOREIGN KEY (allocated_by) REFERENCES users (id) ON DELETE SET NULL;
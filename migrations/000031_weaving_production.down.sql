@@ -0,0 +1,24 @@
+-- File: migrations/000031_weaving_production.down.sql
+-- Tạo tại: migrations/000031_weaving_production.down.sql
+
+DELETE rp FROM role_permissions rp
+JOIN permissions p ON rp.permission_id = p.id
+WHERE p.permission_name IN ('PRODUCTION_VIEW', 'PRODUCTION_CREATE', 'PRODUCTION_UPDATE', 'PRODUCTION_DELETE');
+
+DELETE FROM permission_groups WHERE group_name = 'PRODUCTION_MANAGEMENT';
+
+DELETE FROM permissions WHERE permission_name IN ('PRODUCTION_VIEW', 'PRODUCTION_CREATE', 'PRODUCTION_UPDATE', 'PRODUCTION_DELETE');
+
+ALTER TABLE weaving_operations
+    DROP FOREIGN KEY fk_weaving_operations_shift,
+    DROP INDEX idx_weaving_operations_machine,
+    DROP INDEX idx_weaving_operations_shift,
+    MODIFY COLUMN quantity INT NOT NULL DEFAULT 0,
+    DROP COLUMN weaving_shift_id;
+
+ALTER TABLE weaving_orders
+    DROP FOREIGN KEY fk_weaving_orders_user,
+    DROP INDEX idx_weaving_orders_status,
+    DROP COLUMN created_by,
+    DROP COLUMN notes,
+    DROP COLUMN produced_quantity;
@@ -0,0 +1,45 @@
+-- File: migrations/000031_weaving_production.up.sql
+-- Tạo tại: migrations/000031_weaving_production.up.sql
+-- Mục đích: Lệnh dệt theo dõi sản lượng kế hoạch/đã dệt, công đoạn dệt theo máy, công nhân và ca, quyền module PRODUCTION
+
+-- Meters woven next to planned_length (000027), the meters planned; produced_quantity is the sum of the order's operations
+ALTER TABLE weaving_orders
+    ADD COLUMN produced_quantity DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER planned_length,
+    ADD COLUMN notes TEXT NULL AFTER status,
+    ADD COLUMN created_by INT UNSIGNED NULL AFTER notes,
+    MODIFY COLUMN status VARCHAR(50) NOT NULL DEFAULT 'pending', -- 'pending', 'in_progress', 'completed', 'cancelled'
+    ADD INDEX idx_weaving_orders_status (status),
+    ADD CONSTRAINT fk_weaving_orders_user FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL;
+
+-- Operations are logged per machine, staff and shift; quantity is meters woven
+ALTER TABLE weaving_operations
+    ADD COLUMN weaving_shift_id INT UNSIGNED NULL AFTER weaving_staff_id,
+    MODIFY COLUMN quantity DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    ADD INDEX idx_weaving_operations_shift (weaving_shift_id),
+    ADD INDEX idx_weaving_operations_machine (weaving_machine_id, start_time),
+    ADD CONSTRAINT fk_weaving_operations_shift FOREIGN KEY (weaving_shift_id) REFERENCES weaving_shifts (id) ON DELETE SET NULL;
+
+UPDATE weaving_orders
+SET produced_quantity = COALESCE(
+    (SELECT SUM(o.quantity) FROM weaving_operations o WHERE o.weaving_order_id = weaving_orders.id), 0);
+
+INSERT INTO permissions (module, action, permission_name, description) VALUES
+('PRODUCTION', 'VIEW', 'PRODUCTION_VIEW', 'View weaving facilities, orders and operations'),
+('PRODUCTION', 'CREATE', 'PRODUCTION_CREATE', 'Create weaving orders and log operations'),
+('PRODUCTION', 'UPDATE', 'PRODUCTION_UPDATE', 'Update weaving data and order status'),
+('PRODUCTION', 'DELETE', 'PRODUCTION_DELETE', 'Delete weaving data');
+
+INSERT INTO permission_groups (group_name, display_name, description, module, sort_order) VALUES
+('PRODUCTION_MANAGEMENT', 'Production Management', 'Manage weaving facilities, orders and operations', 'PRODUCTION', 12);
+
+-- Grant new permissions to ADMIN role
+INSERT INTO role_permissions (role_id, permission_id, granted_by, granted_at)
+SELECT
+    r.id as role_id,
+    p.id as permission_id,
+    1 as granted_by,
+    NOW() as granted_at
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.role_name = 'ADMIN'
+AND p.permission_name IN ('PRODUCTION_VIEW', 'PRODUCTION_CREATE', 'PRODUCTION_UPDATE', 'PRODUCTION_DELETE');
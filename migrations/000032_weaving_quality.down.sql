@@ -0,0 +1,24 @@
+-- File: migrations/000032_weaving_quality.down.sql
+-- Tạo tại: migrations/000032_weaving_quality.down.sql
+
+UPDATE weaving_orders SET status = 'completed' WHERE status = 'sent_to_dyeing';
+
+DROP TABLE IF EXISTS weaving_qc_defects;
+
+ALTER TABLE weaving_quality_control
+    DROP FOREIGN KEY fk_weaving_qc_corrected_by,
+    DROP FOREIGN KEY fk_weaving_qc_inspected_by,
+    DROP FOREIGN KEY fk_weaving_qc_operation,
+    DROP INDEX idx_weaving_qc_status,
+    DROP INDEX idx_weaving_qc_operation,
+    DROP COLUMN corrected_by,
+    DROP COLUMN corrected_at,
+    DROP COLUMN max_points,
+    DROP COLUMN points_per_100_sq_yd,
+    DROP COLUMN total_points,
+    DROP COLUMN fabric_width,
+    DROP COLUMN inspected_length,
+    DROP COLUMN inspected_by,
+    DROP COLUMN weaving_operation_id;
+
+DROP TABLE IF EXISTS defect_types;
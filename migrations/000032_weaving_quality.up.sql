@@ -0,0 +1,68 @@
+-- File: migrations/000032_weaving_quality.up.sql
+-- Tạo tại: migrations/000032_weaving_quality.up.sql
+-- Mục đích: Danh mục lỗi vải, kiểm tra chất lượng dệt theo công đoạn với hệ thống chấm điểm 4 điểm và biện pháp khắc phục
+
+CREATE TABLE IF NOT EXISTS defect_types (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    category VARCHAR(50) NOT NULL DEFAULT 'other', -- 'yarn', 'warp', 'weft', 'fabric', 'other'
+    description TEXT NULL,
+    fixed_points TINYINT UNSIGNED NULL, -- Scored regardless of length, e.g. 4 for holes
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_defect_types_code (code),
+    INDEX idx_defect_types_category (category),
+    INDEX idx_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO defect_types (code, name, category, description, fixed_points) VALUES
+('BROKEN_END', 'Broken end', 'warp', 'Missing or broken warp yarn', NULL),
+('REED_MARK', 'Reed mark', 'warp', 'Warp-wise streak caused by a damaged reed', NULL),
+('BROKEN_PICK', 'Broken pick', 'weft', 'Missing or broken weft yarn', NULL),
+('STARTING_MARK', 'Starting mark', 'weft', 'Weft-wise band where the loom restarted', NULL),
+('THICK_PLACE', 'Thick place', 'weft', 'Weft-wise band of higher density', NULL),
+('SLUB', 'Slub', 'yarn', 'Thick section of yarn woven into the fabric', NULL),
+('FLOAT', 'Float', 'fabric', 'Yarn passing over threads it should interlace with', NULL),
+('OIL_STAIN', 'Oil stain', 'fabric', 'Oil or grease stain from the loom', NULL),
+('HOLE', 'Hole', 'fabric', 'Hole or cut in the fabric', 4);
+
+-- Inspections score an operation's fabric with the 4-point system: each defect takes 1 to 4 points by
+-- length, and the fabric passes when the points per 100 square yards stay within max_points
+ALTER TABLE weaving_quality_control
+    ADD COLUMN weaving_operation_id INT UNSIGNED NULL AFTER weaving_order_id,
+    ADD COLUMN inspected_by INT UNSIGNED NULL AFTER quality_inspector,
+    ADD COLUMN inspected_length DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER quality_check_time, -- Meters
+    ADD COLUMN fabric_width DECIMAL(10,2) NOT NULL DEFAULT 0.00 AFTER inspected_length, -- Centimeters
+    ADD COLUMN total_points INT UNSIGNED NOT NULL DEFAULT 0 AFTER fabric_width,
+    ADD COLUMN points_per_100_sq_yd DECIMAL(10,2) NOT NULL DEFAULT 0.00 AFTER total_points,
+    ADD COLUMN max_points DECIMAL(10,2) NOT NULL DEFAULT 40.00 AFTER points_per_100_sq_yd,
+    ADD COLUMN corrected_at TIMESTAMP NULL AFTER corrective_action,
+    ADD COLUMN corrected_by INT UNSIGNED NULL AFTER corrected_at,
+    MODIFY COLUMN status VARCHAR(50) NOT NULL DEFAULT 'passed', -- 'passed', 'failed'
+    ADD INDEX idx_weaving_qc_operation (weaving_operation_id),
+    ADD INDEX idx_weaving_qc_status (weaving_order_id, status),
+    ADD CONSTRAINT fk_weaving_qc_operation FOREIGN KEY (weaving_operation_id) REFERENCES weaving_operations (id) ON DELETE SET NULL,
+    ADD CONSTRAINT fk_weaving_qc_inspected_by FOREIGN KEY (inspected_by) REFERENCES users (id) ON DELETE SET NULL,
+    ADD CONSTRAINT fk_weaving_qc_corrected_by FOREIGN KEY (corrected_by) REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS weaving_qc_defects (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    weaving_quality_control_id INT UNSIGNED NOT NULL,
+    defect_type_id INT UNSIGNED NOT NULL,
+    position DECIMAL(15,2) NULL, -- Meter mark from the start of the inspected length
+    length DECIMAL(10,2) NOT NULL DEFAULT 0.00, -- Inches
+    points TINYINT UNSIGNED NOT NULL,
+    notes TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_weaving_qc_defects_qc (weaving_quality_control_id),
+    INDEX idx_weaving_qc_defects_type (defect_type_id),
+    CONSTRAINT fk_weaving_qc_defects_qc FOREIGN KEY (weaving_quality_control_id) REFERENCES weaving_quality_control (id) ON DELETE CASCADE,
+    CONSTRAINT fk_weaving_qc_defects_type FOREIGN KEY (defect_type_id) REFERENCES defect_types (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- weaving_orders.status gains 'sent_to_dyeing', reached from 'completed' once no failed inspection lacks corrective action
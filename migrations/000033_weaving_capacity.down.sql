@@ -0,0 +1,9 @@
+-- File: migrations/000033_weaving_capacity.down.sql
+-- Tạo tại: migrations/000033_weaving_capacity.down.sql
+
+DROP TABLE IF EXISTS weaving_rosters;
+
+ALTER TABLE weaving_operations
+    DROP FOREIGN KEY fk_weaving_operations_machine;
+
+DROP TABLE IF EXISTS weaving_machines;
@@ -0,0 +1,54 @@
+-- File: migrations/000033_weaving_capacity.up.sql
+-- Tạo tại: migrations/000033_weaving_capacity.up.sql
+-- Mục đích: Danh mục máy dệt (loại máy, khổ, tốc độ, năng suất), lịch phân ca công nhân theo ngày và khóa ngoại máy cho công đoạn dệt
+
+CREATE TABLE IF NOT EXISTS weaving_machines (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    weaving_facility_id INT UNSIGNED NOT NULL,
+    machine_code VARCHAR(50) NOT NULL,
+    loom_type VARCHAR(50) NOT NULL DEFAULT 'rapier', -- 'air_jet', 'rapier', 'water_jet', 'projectile', 'shuttle'
+    max_width DECIMAL(10,2) NOT NULL DEFAULT 0.00, -- Widest fabric in centimeters
+    speed_rpm INT UNSIGNED NOT NULL DEFAULT 0, -- Picks per minute
+    output_per_hour DECIMAL(10,2) NOT NULL DEFAULT 0.00, -- Meters woven per running hour
+    status VARCHAR(50) NOT NULL DEFAULT 'active', -- 'active', 'maintenance', 'retired'
+    notes TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_weaving_machines_code (machine_code),
+    INDEX idx_weaving_machines_facility (weaving_facility_id, status),
+    INDEX idx_deleted_at (deleted_at),
+    CONSTRAINT fk_weaving_machines_facility FOREIGN KEY (weaving_facility_id) REFERENCES weaving_facilities (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Machines operations already refer to keep their IDs; their details are filled in later
+INSERT INTO weaving_machines (id, weaving_facility_id, machine_code, notes)
+SELECT o.weaving_machine_id, MIN(o.weaving_facility_id), CONCAT('LOOM-', o.weaving_machine_id), 'Created from weaving operations'
+FROM weaving_operations o
+WHERE o.weaving_machine_id IS NOT NULL
+GROUP BY o.weaving_machine_id;
+
+ALTER TABLE weaving_operations
+    ADD CONSTRAINT fk_weaving_operations_machine FOREIGN KEY (weaving_machine_id) REFERENCES weaving_machines (id) ON DELETE SET NULL;
+
+-- A staff member works one shift a day, optionally on a given machine
+CREATE TABLE IF NOT EXISTS weaving_rosters (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    weaving_staff_id INT UNSIGNED NOT NULL,
+    weaving_shift_id INT UNSIGNED NOT NULL,
+    weaving_machine_id INT UNSIGNED NULL,
+    work_date DATE NOT NULL,
+    notes TEXT NULL,
+    created_by INT UNSIGNED NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_weaving_rosters_staff_date (weaving_staff_id, work_date),
+    INDEX idx_weaving_rosters_shift_date (weaving_shift_id, work_date),
+    INDEX idx_weaving_rosters_machine (weaving_machine_id),
+    CONSTRAINT fk_weaving_rosters_staff FOREIGN KEY (weaving_staff_id) REFERENCES weaving_staff (id) ON DELETE CASCADE,
+    CONSTRAINT fk_weaving_rosters_shift FOREIGN KEY (weaving_shift_id) REFERENCES weaving_shifts (id) ON DELETE CASCADE,
+    CONSTRAINT fk_weaving_rosters_machine FOREIGN KEY (weaving_machine_id) REFERENCES weaving_machines (id) ON DELETE SET NULL,
+    CONSTRAINT fk_weaving_rosters_user FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
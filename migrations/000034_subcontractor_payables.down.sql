@@ -0,0 +1,13 @@
+-- File: migrations/000034_subcontractor_payables.down.sql
+-- Tạo tại: migrations/000034_subcontractor_payables.down.sql
+
+DROP TABLE IF EXISTS subcontractor_payments;
+DROP TABLE IF EXISTS dyeing_financials;
+
+ALTER TABLE weaving_financials
+    DROP COLUMN notes,
+    DROP COLUMN invoice_date,
+    DROP COLUMN invoice_number,
+    DROP COLUMN amount_paid,
+    DROP COLUMN quantity,
+    DROP COLUMN unit_cost;
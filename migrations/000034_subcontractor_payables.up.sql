@@ -0,0 +1,59 @@
+-- File: migrations/000034_subcontractor_payables.up.sql
+-- Tạo tại: migrations/000034_subcontractor_payables.up.sql
+-- Mục đích: Hóa đơn gia công dệt/nhuộm (đơn giá, số tiền, đã trả, trạng thái thanh toán) và sổ thanh toán cho nhà gia công
+
+-- A weaving financial is the subcontractor's invoice for a weaving order, in its currency column from migration 000029
+ALTER TABLE weaving_financials
+    MODIFY COLUMN customer_id INT UNSIGNED NULL,
+    ADD COLUMN unit_cost DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER customer_id, -- Per meter woven
+    ADD COLUMN quantity DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER unit_cost, -- Meters billed
+    ADD COLUMN amount_paid DECIMAL(15,2) NOT NULL DEFAULT 0.00 AFTER total_cost,
+    MODIFY COLUMN payment_status VARCHAR(50) NOT NULL DEFAULT 'pending', -- 'pending', 'partial', 'paid'
+    ADD COLUMN invoice_number VARCHAR(100) NULL AFTER invoice_id,
+    ADD COLUMN invoice_date DATE NULL AFTER invoice_number,
+    ADD COLUMN notes TEXT NULL AFTER invoice_date;
+
+UPDATE weaving_financials SET amount_paid = total_cost WHERE payment_status = 'paid';
+
+-- The dyeing subcontractor's invoice for a job; the currency defaults to the job's
+CREATE TABLE IF NOT EXISTS dyeing_financials (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    dyeing_subcontractor_id INT UNSIGNED NOT NULL,
+    unit_cost DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    quantity DECIMAL(15,2) NOT NULL DEFAULT 0.00, -- Units billed
+    total_cost DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    currency CHAR(3) NOT NULL DEFAULT 'VND',
+    amount_paid DECIMAL(15,2) NOT NULL DEFAULT 0.00,
+    payment_status VARCHAR(50) NOT NULL DEFAULT 'pending', -- 'pending', 'partial', 'paid'
+    due_date DATE NULL,
+    invoice_number VARCHAR(100) NULL,
+    invoice_date DATE NULL,
+    notes TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_dyeing_financials_subcontractor (dyeing_subcontractor_id),
+    INDEX idx_dyeing_financials_status (payment_status),
+    CONSTRAINT fk_dyeing_financials_subcontractor FOREIGN KEY (dyeing_subcontractor_id) REFERENCES dyeing_subcontractors (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Each payment settles part of one weaving or dyeing invoice, in the invoice currency
+CREATE TABLE IF NOT EXISTS subcontractor_payments (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    weaving_financial_id INT UNSIGNED NULL,
+    dyeing_financial_id INT UNSIGNED NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    paid_at DATE NOT NULL,
+    method VARCHAR(50) NOT NULL DEFAULT 'bank_transfer', -- 'bank_transfer', 'cash', 'other'
+    reference VARCHAR(100) NULL,
+    notes TEXT NULL,
+    created_by INT UNSIGNED NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_subcontractor_payments_weaving (weaving_financial_id),
+    INDEX idx_subcontractor_payments_dyeing (dyeing_financial_id),
+    INDEX idx_subcontractor_payments_paid_at (paid_at),
+    CONSTRAINT fk_subcontractor_payments_weaving FOREIGN KEY (weaving_financial_id) REFERENCES weaving_financials (id) ON DELETE CASCADE,
+    CONSTRAINT fk_subcontractor_payments_dyeing FOREIGN KEY (dyeing_financial_id) REFERENCES dyeing_financials (id) ON DELETE CASCADE,
+    CONSTRAINT fk_subcontractor_payments_user FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
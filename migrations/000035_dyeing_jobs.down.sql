@@ -0,0 +1,42 @@
+-- File: migrations/000035_dyeing_jobs.down.sql
+-- Tạo tại: migrations/000035_dyeing_jobs.down.sql
+
+ALTER TABLE dyeing_financials
+    ADD COLUMN dyeing_subcontractor_id INT UNSIGNED NULL AFTER id;
+
+UPDATE dyeing_financials f
+JOIN dyeing_jobs j ON j.id = f.dyeing_job_id
+SET f.dyeing_subcontractor_id = j.dyeing_subcontractor_id;
+
+-- A dye house keeps the invoice of its first job only
+DELETE f FROM dyeing_financials f
+JOIN dyeing_financials o ON o.dyeing_subcontractor_id = f.dyeing_subcontractor_id AND o.id < f.id;
+
+ALTER TABLE dyeing_financials
+    DROP FOREIGN KEY fk_dyeing_financials_job,
+    DROP INDEX uk_dyeing_financials_job,
+    DROP COLUMN dyeing_job_id,
+    MODIFY COLUMN dyeing_subcontractor_id INT UNSIGNED NOT NULL,
+    ADD UNIQUE KEY uk_dyeing_financials_subcontractor (dyeing_subcontractor_id),
+    ADD CONSTRAINT fk_dyeing_financials_subcontractor FOREIGN KEY (dyeing_subcontractor_id) REFERENCES dyeing_subcontractors (id) ON DELETE CASCADE;
+
+ALTER TABLE dyeing_lots
+    DROP FOREIGN KEY fk_dyeing_lots_job,
+    DROP FOREIGN KEY fk_dyeing_lots_tested_by,
+    DROP FOREIGN KEY fk_dyeing_lots_decided_by,
+    DROP FOREIGN KEY fk_dyeing_lots_lot,
+    DROP INDEX idx_dyeing_lots_job,
+    DROP COLUMN lot_id,
+    DROP COLUMN decided_at,
+    DROP COLUMN decided_by,
+    DROP COLUMN tested_at,
+    DROP COLUMN tested_by,
+    DROP COLUMN color_fastness_light,
+    DROP COLUMN color_fastness_rubbing,
+    DROP COLUMN color_fastness_washing,
+    DROP COLUMN shrinkage_width,
+    DROP COLUMN shrinkage_length,
+    DROP COLUMN returned_date,
+    DROP COLUMN dyeing_job_id;
+
+DROP TABLE IF EXISTS dyeing_jobs;
@@ -0,0 +1,102 @@
+-- File: migrations/000035_dyeing_jobs.up.sql
+-- Tạo tại: migrations/000035_dyeing_jobs.up.sql
+-- Mục đích: Lệnh nhuộm tách khỏi danh mục nhà nhuộm (vải mộc gửi đi, màu mục tiêu, lap dip, hạn trả), kết quả co rút và
+-- độ bền màu theo lô nhuộm, lô kho tạo khi lô nhuộm được nghiệm thu; hóa đơn nhuộm gắn với lệnh nhuộm
+
+-- A dyeing job sends greige to a dye house (dyeing_subcontractors, now master data) to be dyed to a target color.
+-- The job columns of dyeing_subcontractors are kept for history; jobs carry them from now on.
+CREATE TABLE IF NOT EXISTS dyeing_jobs (
+    id INT UNSIGNED NOT NULL AUTO_INCREMENT,
+    job_code VARCHAR(100) NOT NULL,
+    dyeing_subcontractor_id INT UNSIGNED NOT NULL,
+    order_id INT UNSIGNED NULL, -- Sales order the fabric is for
+    weaving_order_id INT UNSIGNED NULL, -- Where the greige was woven
+    product_id INT UNSIGNED NULL, -- Finished product the dyed lots are stocked as
+    target_color VARCHAR(255) NOT NULL,
+    color_code VARCHAR(100) NULL,
+    ld_test_id INT UNSIGNED NULL,
+    lap_dip_reference VARCHAR(100) NULL, -- Approved lap dip code the dye house matches
+    greige_length DECIMAL(15,2) NOT NULL DEFAULT 0.00, -- Meters sent
+    greige_weight DECIMAL(15,2) NOT NULL DEFAULT 0.00, -- Kilograms sent
+    greige_rolls INT NOT NULL DEFAULT 0,
+    sent_date DATE NOT NULL,
+    expected_return_date DATE NULL,
+    returned_date DATE NULL,
+    cost_per_kg DECIMAL(15,2) NOT NULL DEFAULT 0.00, -- Agreed dyeing rate per kilogram of greige
+    currency CHAR(3) NOT NULL DEFAULT 'VND',
+    status VARCHAR(50) NOT NULL DEFAULT 'sent', -- 'sent', 'partially_returned', 'returned', 'cancelled'
+    special_requirements TEXT NULL,
+    notes TEXT NULL,
+    created_by INT UNSIGNED NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_dyeing_jobs_code (job_code),
+    INDEX idx_dyeing_jobs_subcontractor (dyeing_subcontractor_id, status),
+    INDEX idx_dyeing_jobs_order (order_id),
+    INDEX idx_dyeing_jobs_weaving_order (weaving_order_id),
+    INDEX idx_dyeing_jobs_status_return (status, expected_return_date),
+    INDEX idx_dyeing_jobs_deleted_at (deleted_at),
+    CONSTRAINT fk_dyeing_jobs_subcontractor FOREIGN KEY (dyeing_subcontractor_id) REFERENCES dyeing_subcontractors (id),
+    CONSTRAINT fk_dyeing_jobs_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE SET NULL,
+    CONSTRAINT fk_dyeing_jobs_weaving_order FOREIGN KEY (weaving_order_id) REFERENCES weaving_orders (id) ON DELETE SET NULL,
+    CONSTRAINT fk_dyeing_jobs_product FOREIGN KEY (product_id) REFERENCES products (id),
+    CONSTRAINT fk_dyeing_jobs_lap_dip FOREIGN KEY (ld_test_id) REFERENCES lap_dip_tests (id) ON DELETE SET NULL,
+    CONSTRAINT fk_dyeing_jobs_user FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Every existing dye house row was one job
+INSERT INTO dyeing_jobs (job_code, dyeing_subcontractor_id, order_id, target_color, greige_rolls, sent_date,
+    expected_return_date, cost_per_kg, currency, status, special_requirements, created_at)
+SELECT CONCAT('DJ', LPAD(ds.id, 6, '0')), ds.id, ds.assigned_order_id, COALESCE(ds.dye_color, ''),
+    COALESCE(ds.dyeing_quantity, 0), DATE(COALESCE(ds.dyeing_start_time, ds.created_at)), DATE(ds.dyeing_end_time),
+    COALESCE(ds.dyeing_cost_per_unit, 0), ds.currency,
+    CASE WHEN EXISTS (SELECT 1 FROM dyeing_lots l WHERE l.dyeing_subcontractor_id = ds.id) THEN 'partially_returned' ELSE 'sent' END,
+    ds.special_requirements, ds.created_at
+FROM dyeing_subcontractors ds
+WHERE ds.deleted_at IS NULL
+  AND (ds.dye_color IS NOT NULL OR ds.assigned_order_id IS NOT NULL OR ds.dyeing_quantity IS NOT NULL
+       OR EXISTS (SELECT 1 FROM dyeing_lots l WHERE l.dyeing_subcontractor_id = ds.id)
+       OR EXISTS (SELECT 1 FROM dyeing_financials f WHERE f.dyeing_subcontractor_id = ds.id));
+
+-- Lots belong to a job and carry their shrinkage and color fastness results. Statuses:
+-- 'pending' (returned, not yet judged), 'accepted' (stocked as a warehouse lot), 'rejected'.
+ALTER TABLE dyeing_lots
+    ADD COLUMN dyeing_job_id INT UNSIGNED NULL AFTER id,
+    ADD COLUMN returned_date DATE NULL AFTER expected_date,
+    ADD COLUMN shrinkage_length DECIMAL(5,2) NULL AFTER returned_date, -- Percent along the warp after washing
+    ADD COLUMN shrinkage_width DECIMAL(5,2) NULL AFTER shrinkage_length, -- Percent along the weft
+    ADD COLUMN color_fastness_washing DECIMAL(2,1) NULL AFTER shrinkage_width, -- Grey scale 1-5
+    ADD COLUMN color_fastness_rubbing DECIMAL(2,1) NULL AFTER color_fastness_washing, -- Grey scale 1-5
+    ADD COLUMN color_fastness_light DECIMAL(2,1) NULL AFTER color_fastness_rubbing, -- Blue wool scale 1-8
+    ADD COLUMN tested_by INT UNSIGNED NULL AFTER color_fastness_light,
+    ADD COLUMN tested_at TIMESTAMP NULL AFTER tested_by,
+    ADD COLUMN decided_by INT UNSIGNED NULL AFTER tested_at,
+    ADD COLUMN decided_at TIMESTAMP NULL AFTER decided_by,
+    ADD COLUMN lot_id INT UNSIGNED NULL AFTER decided_at, -- Warehouse lot made on acceptance
+    ADD INDEX idx_dyeing_lots_job (dyeing_job_id),
+    ADD CONSTRAINT fk_dyeing_lots_job FOREIGN KEY (dyeing_job_id) REFERENCES dyeing_jobs (id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_dyeing_lots_tested_by FOREIGN KEY (tested_by) REFERENCES users (id) ON DELETE SET NULL,
+    ADD CONSTRAINT fk_dyeing_lots_decided_by FOREIGN KEY (decided_by) REFERENCES users (id) ON DELETE SET NULL,
+    ADD CONSTRAINT fk_dyeing_lots_lot FOREIGN KEY (lot_id) REFERENCES lots (id) ON DELETE SET NULL;
+
+UPDATE dyeing_lots l
+JOIN dyeing_jobs j ON j.dyeing_subcontractor_id = l.dyeing_subcontractor_id
+SET l.dyeing_job_id = j.id;
+
+-- Dyeing invoices are per job
+ALTER TABLE dyeing_financials
+    ADD COLUMN dyeing_job_id INT UNSIGNED NULL AFTER id;
+
+UPDATE dyeing_financials f
+JOIN dyeing_jobs j ON j.dyeing_subcontractor_id = f.dyeing_subcontractor_id
+SET f.dyeing_job_id = j.id;
+
+ALTER TABLE dyeing_financials
+    DROP FOREIGN KEY fk_dyeing_financials_subcontractor,
+    DROP INDEX uk_dyeing_financials_subcontractor,
+    DROP COLUMN dyeing_subcontractor_id,
+    MODIFY COLUMN dyeing_job_id INT UNSIGNED NOT NULL,
+    ADD UNIQUE KEY uk_dyeing_financials_job (dyeing_job_id),
+    ADD CONSTRAINT fk_dyeing_financials_job FOREIGN KEY (dyeing_job_id) REFERENCES dyeing_jobs (id) ON DELETE CASCADE;
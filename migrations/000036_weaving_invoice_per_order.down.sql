@@ -0,0 +1,9 @@
+-- File: migrations/000036_weaving_invoice_per_order.down.sql
+-- Tạo tại: migrations/000036_weaving_invoice_per_order.down.sql
+
+-- Merged invoices stay merged
+ALTER TABLE weaving_financials
+    ADD INDEX idx_weaving_order_id (weaving_order_id);
+
+ALTER TABLE weaving_financials
+    DROP INDEX uk_weaving_financials_order;
@@ -0,0 +1,40 @@
+-- File: migrations/000036_weaving_invoice_per_order.up.sql
+-- Tạo tại: migrations/000036_weaving_invoice_per_order.up.sql
+-- Mục đích: Mỗi lệnh dệt có một hóa đơn gia công; các hóa đơn cũ của cùng lệnh được gộp vào hóa đơn mới nhất
+
+-- The latest invoice of an order takes over the meters, amounts and payments of the older ones
+UPDATE weaving_financials f
+JOIN (
+    SELECT weaving_order_id, MAX(id) AS keep_id, SUM(quantity) AS quantity,
+        SUM(total_cost) AS total_cost, SUM(amount_paid) AS amount_paid
+    FROM weaving_financials
+    GROUP BY weaving_order_id
+    HAVING COUNT(*) > 1
+) d ON d.keep_id = f.id
+SET f.quantity = d.quantity,
+    f.total_cost = d.total_cost,
+    f.amount_paid = d.amount_paid,
+    f.payment_status = CASE
+        WHEN d.amount_paid > 0 AND d.amount_paid >= d.total_cost THEN 'paid'
+        WHEN d.amount_paid > 0 THEN 'partial'
+        ELSE 'pending'
+    END;
+
+UPDATE subcontractor_payments p
+JOIN weaving_financials f ON f.id = p.weaving_financial_id
+JOIN (
+    SELECT weaving_order_id, MAX(id) AS keep_id
+    FROM weaving_financials
+    GROUP BY weaving_order_id
+) k ON k.weaving_order_id = f.weaving_order_id
+SET p.weaving_financial_id = k.keep_id
+WHERE p.weaving_financial_id <> k.keep_id;
+
+DELETE f FROM weaving_financials f
+JOIN weaving_financials o ON o.weaving_order_id = f.weaving_order_id AND o.id > f.id;
+
+ALTER TABLE weaving_financials
+    ADD UNIQUE KEY uk_weaving_financials_order (weaving_order_id);
+
+ALTER TABLE weaving_financials
+    DROP INDEX idx_weaving_order_id;
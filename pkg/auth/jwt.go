@@ -7,6 +7,9 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// StaffAudience is the audience of staff tokens; tokens for other audiences (the customer portal) are rejected
+const StaffAudience = "appsynex-staff"
+
 type Permission struct {
 	Name   string `json:"name"`
 	Module string `json:"module"`
@@ -28,12 +31,16 @@ type JWTService interface {
 type jwtService struct {
 	secretKey     string
 	expirationStr string
+	// startedAt bounds the tokens accepted without an audience: only those issued before this process,
+	// by releases that did not set one yet, and they expire within one token lifetime
+	startedAt time.Time
 }
 
 func NewJWTService(secretKey string, expirationStr string) JWTService {
 	return &jwtService{
 		secretKey:     secretKey,
 		expirationStr: expirationStr,
+		startedAt:     time.Now(),
 	}
 }
 
@@ -50,6 +57,7 @@ func (s *jwtService) GenerateToken(userID uint, username string, roles []string,
 		Roles:       roles,
 		Permissions: permissions,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{StaffAudience},
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -67,16 +75,29 @@ func (s *jwtService) GenerateToken(userID uint, username string, roles []string,
 func (s *jwtService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(s.secretKey), nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil {
 		return nil, err
 	}
 
 	claims, ok := token.Claims.(*JWTClaims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || !s.validAudience(claims) {
 		return nil, errors.New("invalid token")
 	}
 
 	return claims, nil
 }
+
+// validAudience accepts staff tokens, and tokens without an audience issued before the service started
+func (s *jwtService) validAudience(claims *JWTClaims) bool {
+	if len(claims.Audience) == 0 {
+		return claims.IssuedAt != nil && claims.IssuedAt.Time.Before(s.startedAt)
+	}
+	for _, audience := range claims.Audience {
+		if audience == StaffAudience {
+			return true
+		}
+	}
+	return false
+}
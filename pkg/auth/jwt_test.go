@@ -0,0 +1,107 @@
+// File: pkg/auth/jwt_test.go
+// Tạo tại: pkg/auth/jwt_test.go
+// Mục đích: Kiểm thử tách biệt token nhân viên và token portal khách hàng theo audience
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+func signClaims(t *testing.T, method jwt.SigningMethod, claims jwt.Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return token
+}
+
+func TestStaffAndPortalTokensAreSeparate(t *testing.T) {
+	// The same secret on both sides, so only the audience tells the tokens apart
+	staff := NewJWTService(testSecret, "1h")
+	portal := NewCustomerJWTService(testSecret, "1h")
+
+	staffToken, err := staff.GenerateToken(1, "admin", []string{"ADMIN"}, nil)
+	if err != nil {
+		t.Fatalf("staff token: %v", err)
+	}
+	portalToken, _, err := portal.GenerateToken(7, 3, "buyer@example.com")
+	if err != nil {
+		t.Fatalf("portal token: %v", err)
+	}
+
+	if claims, err := staff.ValidateToken(staffToken); err != nil || claims.ID != 1 || claims.Username != "admin" {
+		t.Errorf("staff token on staff routes = %+v, %v", claims, err)
+	}
+	if claims, err := portal.ValidateToken(portalToken); err != nil || claims.AccountID != 7 || claims.CustomerID != 3 {
+		t.Errorf("portal token on portal routes = %+v, %v", claims, err)
+	}
+	if _, err := staff.ValidateToken(portalToken); err == nil {
+		t.Error("staff routes accepted a portal token")
+	}
+	if _, err := portal.ValidateToken(staffToken); err == nil {
+		t.Error("portal routes accepted a staff token")
+	}
+}
+
+func TestStaffTokenAudience(t *testing.T) {
+	now := time.Now()
+	service := &jwtService{secretKey: testSecret, expirationStr: "1h", startedAt: now.Add(-time.Minute)}
+	claims := func(audience []string, issuedAt time.Time) *JWTClaims {
+		c := &JWTClaims{ID: 1, Username: "admin", RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		}}
+		if audience != nil {
+			c.Audience = audience
+		}
+		if !issuedAt.IsZero() {
+			c.IssuedAt = jwt.NewNumericDate(issuedAt)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		wantOK bool
+	}{
+		{"staff audience", signClaims(t, jwt.SigningMethodHS256, claims([]string{StaffAudience}, now)), true},
+		{"issued before the deploy without an audience", signClaims(t, jwt.SigningMethodHS256, claims(nil, now.Add(-time.Hour))), true},
+		{"issued after the deploy without an audience", signClaims(t, jwt.SigningMethodHS256, claims(nil, now)), false},
+		{"no audience and no issue time", signClaims(t, jwt.SigningMethodHS256, claims(nil, time.Time{})), false},
+		{"portal audience", signClaims(t, jwt.SigningMethodHS256, claims([]string{PortalAudience}, now.Add(-time.Hour))), false},
+		{"other signing method", signClaims(t, jwt.SigningMethodHS512, claims([]string{StaffAudience}, now)), false},
+	}
+	for _, tt := range tests {
+		_, err := service.ValidateToken(tt.token)
+		if (err == nil) != tt.wantOK {
+			t.Errorf("%s: error = %v, want ok = %v", tt.name, err, tt.wantOK)
+		}
+	}
+}
+
+func TestTokenSignedWithAnotherSecret(t *testing.T) {
+	token, err := NewJWTService("other-secret", "1h").GenerateToken(1, "admin", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewJWTService(testSecret, "1h").ValidateToken(token); err == nil {
+		t.Error("accepted a token signed with another secret")
+	}
+}
+
+func TestPortalTokenNeedsCustomer(t *testing.T) {
+	token := signClaims(t, jwt.SigningMethodHS256, &CustomerClaims{AccountID: 7, RegisteredClaims: jwt.RegisteredClaims{
+		Audience:  jwt.ClaimStrings{PortalAudience},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}})
+	if _, err := NewCustomerJWTService(testSecret, "1h").ValidateToken(token); err == nil {
+		t.Error("accepted a portal token without a customer")
+	}
+}
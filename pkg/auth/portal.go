@@ -0,0 +1,85 @@
+// File: pkg/auth/portal.go
+// Tạo tại: pkg/auth/portal.go
+// Mục đích: JWT riêng cho tài khoản khách hàng của cổng portal, tách biệt với JWT nhân viên
+
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// PortalAudience is the audience of customer portal tokens; staff routes never accept them
+const PortalAudience = "appsynex-portal"
+
+// CustomerClaims identifies a customer portal account and the customer it belongs to
+type CustomerClaims struct {
+	AccountID  uint   `json:"account_id"`
+	CustomerID uint   `json:"customer_id"`
+	Email      string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+type CustomerJWTService interface {
+	GenerateToken(accountID, customerID uint, email string) (string, time.Time, error)
+	ValidateToken(tokenString string) (*CustomerClaims, error)
+}
+
+type customerJWTService struct {
+	secretKey     string
+	expirationStr string
+}
+
+func NewCustomerJWTService(secretKey string, expirationStr string) CustomerJWTService {
+	return &customerJWTService{
+		secretKey:     secretKey,
+		expirationStr: expirationStr,
+	}
+}
+
+func (s *customerJWTService) GenerateToken(accountID, customerID uint, email string) (string, time.Time, error) {
+	// Parse expiration duration
+	expiration, err := time.ParseDuration(s.expirationStr)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(expiration)
+
+	claims := &CustomerClaims{
+		AccountID:  accountID,
+		CustomerID: customerID,
+		Email:      email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{PortalAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(s.secretKey))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt, nil
+}
+
+func (s *customerJWTService) ValidateToken(tokenString string) (*CustomerClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &CustomerClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(s.secretKey), nil
+	}, jwt.WithAudience(PortalAudience), jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*CustomerClaims)
+	if !ok || !token.Valid || claims.CustomerID == 0 {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,517 @@
+// File: pkg/i18n/catalog.go
+// Tạo tại: pkg/i18n/catalog.go
+// Mục đích: Danh mục thông điệp tiếng Việt cho lỗi API, thông báo validate và tên quyền
+
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// messages maps English source messages to Vietnamese. Keys may contain fmt verbs
+// (%s, %d, %q, %v, %w); the Vietnamese text uses the same verbs in the same order,
+// or indexed verbs such as %[2]s to reorder. Matched arguments found in the catalog
+// (such as wrapped errors) are translated as well.
+var messages = map[string]string{
+	// Common
+	"Invalid ID format":            "Định dạng ID không hợp lệ",
+	"Invalid image ID format":      "Định dạng ID hình ảnh không hợp lệ",
+	"Invalid permission ID format": "Định dạng ID quyền không hợp lệ",
+	"Invalid role ID format":       "Định dạng ID vai trò không hợp lệ",
+	"Invalid user ID format":       "Định dạng ID người dùng không hợp lệ",
+	"Module is required":           "Bắt buộc nhập module",
+	"file is required":             "Bắt buộc tải lên tệp",
+	"EOF":                          "Nội dung yêu cầu trống",
+	"invalid request":              "yêu cầu không hợp lệ",
+	"invalid query":                "truy vấn không hợp lệ",
+
+	// Authentication & authorization
+	"Authorization header is required":                   "Thiếu header Authorization",
+	"Authorization header format must be Bearer {token}": "Header Authorization phải có dạng Bearer {token}",
+	"Invalid or expired token":                           "Token không hợp lệ hoặc đã hết hạn",
+	"Unauthorized":                                       "Chưa xác thực",
+	"Failed to parse user claims":                        "Không đọc được thông tin người dùng",
+	"User not found in context":                          "Không tìm thấy người dùng trong phiên",
+	"Insufficient permissions":                           "Không đủ quyền",
+	"Access denied - insufficient role":                  "Từ chối truy cập - vai trò không đủ quyền",
+	"Permission check failed":                            "Kiểm tra quyền thất bại",
+	"invalid credentials":                                "Sai tên đăng nhập hoặc mật khẩu",
+	"invalid token":                                      "token không hợp lệ",
+
+	// Users, roles & permissions
+	"user not found":                                      "không tìm thấy người dùng",
+	"username already exists":                             "tên đăng nhập đã tồn tại",
+	"role not found":                                      "không tìm thấy vai trò",
+	"one or more roles not found":                         "không tìm thấy một hoặc nhiều vai trò",
+	"source role not found":                               "không tìm thấy vai trò nguồn",
+	"target role not found":                               "không tìm thấy vai trò đích",
+	"permission not found":                                "không tìm thấy quyền",
+	"permission %d not found":                             "không tìm thấy quyền %d",
+	"permission already exists":                           "quyền đã tồn tại",
+	"cannot delete permission that is currently assigned": "không thể xóa quyền đang được gán",
+
+	// Customers
+	"customer not found":                                          "không tìm thấy khách hàng",
+	"customer code already exists":                                "mã khách hàng đã tồn tại",
+	"could not generate a unique customer code":                   "không tạo được mã khách hàng duy nhất",
+	"customer has orders; set account_status to inactive instead": "khách hàng đã có đơn hàng; hãy chuyển account_status sang inactive",
+
+	"set exactly one of product_id and sample_product_id": "chỉ được đặt một trong hai trường product_id và sample_product_id",
+	"item is already saved for this customer":             "mục này đã được lưu cho khách hàng",
+	"saved item not found":                                "không tìm thấy mục đã lưu",
+	"unsupported saved item type: %s":                     "loại mục đã lưu không được hỗ trợ: %s",
+	"date_from must not be after date_to":                 "date_from không được sau date_to",
+	"Invalid item ID format":                              "Định dạng ID mục không hợp lệ",
+	"cannot merge a customer into itself":                 "không thể gộp khách hàng vào chính nó",
+	"duplicate customer not found":                        "không tìm thấy khách hàng trùng lặp",
+	"customer account is blocked":                         "tài khoản khách hàng đang bị khóa",
+	"unsupported activity type %q":                        "loại hoạt động %q không được hỗ trợ",
+
+	// Customer portal
+	"Customer not found in context":                   "Không tìm thấy khách hàng trong phiên",
+	"Account is disabled":                             "Tài khoản đã bị vô hiệu hóa",
+	"account is disabled":                             "tài khoản đã bị vô hiệu hóa",
+	"account not found":                               "không tìm thấy tài khoản",
+	"order not found":                                 "không tìm thấy đơn hàng",
+	"lap dip not found":                               "không tìm thấy lap dip",
+	"lap dip has already been answered":               "lap dip đã được phản hồi",
+	"final_ld_code is not one of the lap dip results": "final_ld_code không nằm trong kết quả lap dip",
+	"portal account not found":                        "không tìm thấy tài khoản portal",
+	"email already in use":                            "email đã được sử dụng",
+	"Invalid account ID format":                       "Định dạng ID tài khoản không hợp lệ",
+
+	// Orders
+	"order code already exists":                                      "mã đơn hàng đã tồn tại",
+	"could not generate a unique order code":                         "không tạo được mã đơn hàng duy nhất",
+	"order status PENDING is missing":                                "thiếu trạng thái đơn hàng PENDING",
+	"order items can only be changed while the order is PENDING":     "chỉ được sửa dòng hàng khi đơn hàng đang PENDING",
+	"only PENDING or CANCELLED orders can be deleted":                "chỉ được xóa đơn hàng PENDING hoặc CANCELLED",
+	"unknown order action %q":                                        "thao tác đơn hàng %q không tồn tại",
+	"unknown order action":                                           "thao tác đơn hàng không tồn tại",
+	"unknown order status %q":                                        "trạng thái đơn hàng %q không tồn tại",
+	"order status not found":                                         "không tìm thấy trạng thái đơn hàng",
+	"illegal order transition":                                       "chuyển trạng thái đơn hàng không hợp lệ",
+	"cannot %s an order that is %s (allowed from %s)":                "không thể %s đơn hàng đang ở trạng thái %s (chỉ được từ %s)",
+	"insufficient permissions":                                       "không đủ quyền",
+	"%s requires permission %s_%s":                                   "thao tác %s cần quyền %s_%s",
+	"order action not possible":                                      "không thể thực hiện thao tác đơn hàng",
+	"a note is required to %s an order":                              "cần nhập ghi chú để %s đơn hàng",
+	"order status %s is not configured":                              "trạng thái đơn hàng %s chưa được cấu hình",
+	"order status was changed by someone else; reload and try again": "trạng thái đơn hàng vừa được người khác thay đổi; hãy tải lại và thử lại",
+	"order has no items":                                             "đơn hàng chưa có dòng hàng",
+	"customer %s is blocked":                                         "khách hàng %s đang bị khóa",
+	"order has no packing list; create one before shipping":          "đơn hàng chưa có phiếu đóng gói; hãy tạo trước khi giao",
+	"requires permission %s":                                         "cần quyền %s",
+	"insufficient stock":                                             "không đủ hàng",
+	"order line %d needs %v m, %v m free":                            "dòng đơn hàng %d cần %v m, chỉ còn %v m trống",
+	"order line %d: %d of %d rolls are not free":                     "dòng đơn hàng %d: %d trong %d cuộn không còn trống",
+	"reservation not found":                                          "không tìm thấy phiếu giữ hàng",
+	"reservation is already %s":                                      "phiếu giữ hàng đã ở trạng thái %s",
+	"cannot reserve stock for an order that is %s":                   "không thể giữ hàng cho đơn hàng đang ở trạng thái %s",
+	"expires_at must be in the future":                               "expires_at phải là thời điểm trong tương lai",
+	"order item %d is not part of the order":                         "dòng hàng %d không thuộc đơn hàng",
+	"order item %d is already fully reserved":                        "dòng hàng %d đã được giữ đủ",
+	"every order line is already fully reserved":                     "mọi dòng hàng đã được giữ đủ",
+	"product %s not found":                                           "không tìm thấy sản phẩm %s",
+	"product %d not found":                                           "không tìm thấy sản phẩm %d",
+	"no files uploaded":                                              "chưa có tệp nào được tải lên",
+	"file %s: unsupported attachment type":                           "tệp %s: loại tệp đính kèm không được hỗ trợ",
+	"attachment not found":                                           "không tìm thấy tệp đính kèm",
+
+	// Price lists and quotations
+	"price list not found": "không tìm thấy bảng giá",
+	"a price list is for a customer or a price tier, not both":           "bảng giá chỉ áp dụng cho một khách hàng hoặc một nhóm giá, không cả hai",
+	"valid_to must not be before valid_from":                             "valid_to không được trước valid_from",
+	"name is required":                                                   "cần nhập tên",
+	"product %d has two prices from quantity %v":                         "sản phẩm %d có hai giá từ cùng số lượng %v",
+	"quotation not found":                                                "không tìm thấy báo giá",
+	"quotation is closed":                                                "báo giá đã đóng",
+	"quotation status was changed by someone else; reload and try again": "trạng thái báo giá vừa được người khác thay đổi; hãy tải lại và thử lại",
+	"unknown quotation status %q":                                        "trạng thái báo giá %q không tồn tại",
+	"valid_until must not be before issue_date":                          "valid_until không được trước issue_date",
+	"quotation code already exists":                                      "mã báo giá đã tồn tại",
+	"could not generate a unique quotation code":                         "không tạo được mã báo giá duy nhất",
+	"a %s quotation cannot be changed":                                   "không thể sửa báo giá đang ở trạng thái %s",
+	"a %s quotation cannot be rejected":                                  "không thể từ chối báo giá đang ở trạng thái %s",
+	"a %s quotation cannot be accepted":                                  "không thể chấp nhận báo giá đang ở trạng thái %s",
+	"an accepted quotation is kept with its order":                       "báo giá đã được chấp nhận được giữ lại cùng đơn hàng",
+	"only a valid draft quotation can be sent (quotation is %s)":         "chỉ gửi được báo giá nháp còn hiệu lực (báo giá đang ở trạng thái %s)",
+
+	// Currencies and exchange rates
+	"exchange rate not found":                   "không tìm thấy tỷ giá",
+	"no exchange rate":                          "chưa có tỷ giá",
+	"no exchange rate from %s to %s on %s":      "chưa có tỷ giá từ %s sang %s vào ngày %s",
+	"from_currency and to_currency must differ": "from_currency và to_currency phải khác nhau",
+	"a %s to %s rate is already set for %s":     "tỷ giá %s sang %s đã được đặt cho ngày %s",
+	"invalid currency code %q":                  "mã tiền tệ %q không hợp lệ",
+	"invalid amount":                            "số tiền không hợp lệ",
+	"empty":                                     "trống",
+	"%q is out of range":                        "%q vượt quá giới hạn cho phép",
+	"to must not be before from":                "to không được trước from",
+
+	// Yarn inventory
+	"yarn box not found": "không tìm thấy thùng sợi",
+	"insufficient yarn":  "không đủ sợi",
+	"yarn box status was changed by someone else; reload and try again": "trạng thái thùng sợi vừa được người khác thay đổi; hãy tải lại và thử lại",
+	"unknown yarn box status %q":                                        "trạng thái thùng sợi %q không tồn tại",
+	"yarn_type is required":                                             "cần nhập yarn_type",
+	"box code already exists":                                           "mã thùng đã tồn tại",
+	"could not generate a unique box code":                              "không tạo được mã thùng duy nhất",
+	"box %s still holds %d cones, %v kg; issue them first":              "thùng %s còn %d cuộn, %v kg; hãy xuất hết trước",
+	"cannot set a yarn box to %s":                                       "không thể chuyển thùng sợi sang trạng thái %s",
+	"a %s yarn box cannot become %s":                                    "thùng sợi đang %s không thể chuyển sang %s",
+	"quantity or weight is required":                                    "cần nhập số cuộn hoặc khối lượng",
+	"box %s holds %d cones, %v kg":                                      "thùng %s chỉ còn %d cuộn, %v kg",
+	"box %s holds %d cones, %v kg not allocated to orders":              "thùng %s chỉ còn %d cuộn, %v kg chưa phân bổ cho đơn hàng",
+	"unknown transaction type %q":                                       "loại giao dịch %q không tồn tại",
+
+	// Yarn allocation
+	"yarn allocation not found":                                         "không tìm thấy phân bổ sợi",
+	"yarn allocation was changed by someone else; reload and try again": "phân bổ sợi vừa được người khác thay đổi; hãy tải lại và thử lại",
+	"yarn type %s is listed twice":                                      "loại sợi %s bị khai báo hai lần",
+	"cannot allocate yarn for an order that is %s":                      "không thể phân bổ sợi cho đơn hàng đang ở trạng thái %s",
+	"the order's products have no yarn bill of materials":               "sản phẩm của đơn hàng chưa có định mức sợi",
+	"the order does not need yarn type %s":                              "đơn hàng không cần loại sợi %s",
+	"the order's yarn is already fully allocated":                       "sợi của đơn hàng đã được phân bổ đủ",
+	"yarn allocation is already %s":                                     "phân bổ sợi đã ở trạng thái %s",
+	"%s needs %v kg more":                                               "%s còn thiếu %v kg",
+
+	// Weaving
+	"weaving facility not found":  "không tìm thấy xưởng dệt",
+	"weaving shift not found":     "không tìm thấy ca dệt",
+	"weaving staff not found":     "không tìm thấy công nhân dệt",
+	"weaving order not found":     "không tìm thấy lệnh dệt",
+	"weaving operation not found": "không tìm thấy công đoạn dệt",
+	"weaving order status was changed by someone else; reload and try again": "trạng thái lệnh dệt vừa được người khác thay đổi; hãy tải lại và thử lại",
+	"facility_name is required": "cần nhập facility_name",
+	"shift_name is required":    "cần nhập shift_name",
+	"staff_name is required":    "cần nhập staff_name",
+	"facility has %d open weaving orders; complete or cancel them first": "xưởng còn %d lệnh dệt đang mở; hãy hoàn thành hoặc hủy trước",
+	"invalid shift time %q; use HH:MM":                                   "giờ ca %q không hợp lệ; hãy dùng HH:MM",
+	"a shift cannot start and end at the same time":                      "ca làm việc không thể bắt đầu và kết thúc cùng một giờ",
+	"shift %s belongs to another facility":                               "ca %s thuộc xưởng khác",
+	"%s works at another facility":                                       "%s làm việc ở xưởng khác",
+	"unknown weaving order status %q":                                    "trạng thái lệnh dệt %q không tồn tại",
+	"cannot weave for an order that is %s":                               "không thể dệt cho đơn hàng đang ở trạng thái %s",
+	"product %d is not on order %s":                                      "sản phẩm %d không có trong đơn hàng %s",
+	"%s of order %s is already fully planned for weaving":                "%s của đơn hàng %s đã được lên kế hoạch dệt đủ",
+	"weaving order code already exists":                                  "mã lệnh dệt đã tồn tại",
+	"could not generate a unique weaving order code":                     "không tạo được mã lệnh dệt duy nhất",
+	"cannot change a %s weaving order":                                   "không thể sửa lệnh dệt đang ở trạng thái %s",
+	"cannot move a started weaving order to another facility":            "không thể chuyển lệnh dệt đã bắt đầu sang xưởng khác",
+	"yarn box %s is depleted":                                            "thùng sợi %s đã hết",
+	"planned_quantity cannot be negative":                                "planned_quantity không được âm",
+	"planned end date is before the start date":                          "ngày kết thúc dự kiến trước ngày bắt đầu",
+	"weaving order is %s; cancel it instead":                             "lệnh dệt đang ở trạng thái %s; hãy hủy thay vì xóa",
+	"weaving order is already %s":                                        "lệnh dệt đã ở trạng thái %s",
+	"cannot complete a weaving order with nothing woven":                 "không thể hoàn thành lệnh dệt chưa dệt được mét nào",
+	"cannot set a weaving order to %s":                                   "không thể chuyển lệnh dệt sang trạng thái %s",
+	"a %s weaving order cannot become %s":                                "lệnh dệt đang %s không thể chuyển sang %s",
+	"cannot log operations on a %s weaving order":                        "không thể ghi công đoạn cho lệnh dệt đang ở trạng thái %s",
+	"end_time is before start_time":                                      "end_time trước start_time",
+
+	// Weaving quality
+	"defect type not found":                                           "không tìm thấy loại lỗi",
+	"weaving inspection not found":                                    "không tìm thấy phiếu kiểm tra chất lượng dệt",
+	"weaving order status change not possible":                        "không thể đổi trạng thái lệnh dệt",
+	"code is required":                                                "cần nhập mã",
+	"defect type code already exists":                                 "mã loại lỗi đã tồn tại",
+	"cannot inspect a %s weaving order":                               "không thể kiểm tra lệnh dệt đang ở trạng thái %s",
+	"quality_inspector is required":                                   "cần nhập quality_inspector",
+	"inspected_length is required when the operation has no quantity": "cần nhập inspected_length khi công đoạn chưa có sản lượng",
+	"fabric_width is required; product %s has no width":               "cần nhập fabric_width; sản phẩm %s chưa có khổ vải",
+	"defect type %s is inactive":                                      "loại lỗi %s đã ngừng sử dụng",
+	"defect at %vm is beyond the inspected length":                    "lỗi tại mét %v nằm ngoài chiều dài đã kiểm tra",
+	"record corrective action before deleting a failed inspection":    "ghi biện pháp khắc phục trước khi xóa phiếu kiểm tra không đạt",
+	"only failed inspections need corrective action":                  "chỉ phiếu kiểm tra không đạt mới cần biện pháp khắc phục",
+	"corrective_action is required":                                   "cần nhập corrective_action",
+	"%d failed inspections need corrective action first":              "còn %d phiếu kiểm tra không đạt cần ghi biện pháp khắc phục trước",
+
+	// Weaving capacity
+	"weaving machine not found":                         "không tìm thấy máy dệt",
+	"weaving roster not found":                          "không tìm thấy lịch phân ca",
+	"unknown machine status %q":                         "trạng thái máy %q không tồn tại",
+	"machine_code is required":                          "cần nhập machine_code",
+	"machine code already exists":                       "mã máy đã tồn tại",
+	"machine %s belongs to another facility":            "máy %s thuộc xưởng khác",
+	"machine %s is retired":                             "máy %s đã ngừng sử dụng",
+	"machine %s is not active":                          "máy %s không ở trạng thái hoạt động",
+	"machine %s is given to more than one staff member": "máy %s được giao cho nhiều hơn một công nhân",
+	"machine %s is already rostered to %s on %s":        "máy %s đã được phân cho %s vào ngày %s",
+	"%s is listed twice":                                "%s bị liệt kê hai lần",
+	"to is before from":                                 "to trước from",
+	"rosters cover at most %d days at a time":           "mỗi lần chỉ phân ca tối đa %d ngày",
+
+	// Subcontracting
+	"subcontract job not found":                                 "không tìm thấy công việc gia công",
+	"job type must be weaving or dyeing":                        "loại công việc phải là weaving hoặc dyeing",
+	"job has no invoice to pay":                                 "công việc chưa có hóa đơn để thanh toán",
+	"payment exceeds the invoice balance":                       "số tiền thanh toán vượt quá số còn nợ của hóa đơn",
+	"invoice total is less than the amount already paid":        "tổng hóa đơn nhỏ hơn số tiền đã thanh toán",
+	"due date is before the invoice date":                       "hạn thanh toán trước ngày hóa đơn",
+	"invoice currency cannot change once payments are recorded": "không thể đổi loại tiền của hóa đơn đã có thanh toán",
+	"payment has no invoice":                                    "khoản thanh toán chưa gắn với hóa đơn",
+
+	// Dyeing
+	"dye house not found":  "không tìm thấy nhà nhuộm",
+	"dyeing job not found": "không tìm thấy lệnh nhuộm",
+	"dyeing lot not found": "không tìm thấy lô nhuộm",
+	"dyeing job status was changed by someone else; reload and try again":      "trạng thái lệnh nhuộm đã bị người khác thay đổi; hãy tải lại và thử lại",
+	"dyeing lot was already accepted or rejected":                              "lô nhuộm đã được nghiệm thu hoặc từ chối",
+	"dyeing job code already exists":                                           "mã lệnh nhuộm đã tồn tại",
+	"dyeing lot code already exists":                                           "mã lô nhuộm đã tồn tại",
+	"could not generate a unique dyeing job code":                              "không thể tạo mã lệnh nhuộm duy nhất",
+	"could not generate a unique dyeing lot code":                              "không thể tạo mã lô nhuộm duy nhất",
+	"target_color is required; give it or a lap dip test":                      "cần nhập target_color hoặc chọn lap dip",
+	"expected return date is before the sent date":                             "ngày dự kiến trả hàng trước ngày gửi nhuộm",
+	"record the returned lots before closing the dyeing job":                   "cần ghi nhận các lô trả về trước khi đóng lệnh nhuộm",
+	"cannot delete a dyeing job with returned lots":                            "không thể xóa lệnh nhuộm đã có lô trả về",
+	"a lot cannot come back before the job was sent":                           "lô không thể trả về trước ngày gửi nhuộm",
+	"only lots of a dyeing job can be accepted":                                "chỉ nghiệm thu được lô thuộc một lệnh nhuộm",
+	"record the shrinkage and color fastness results before accepting the lot": "cần ghi kết quả co rút và độ bền màu trước khi nghiệm thu lô",
+	"dye house has %d open dyeing jobs; close or cancel them first":            "nhà nhuộm còn %d lệnh nhuộm đang mở; hãy đóng hoặc hủy trước",
+	"unknown dyeing job status %q":                                             "trạng thái lệnh nhuộm %q không tồn tại",
+	"weaving order %s is %s; only completed weaving can go to dyeing":          "lệnh dệt %s đang ở trạng thái %s; chỉ lệnh dệt đã hoàn thành mới được gửi nhuộm",
+	"cannot change a %s dyeing job":                                            "không thể sửa lệnh nhuộm đang ở trạng thái %s",
+	"dyeing job is already %s":                                                 "lệnh nhuộm đã ở trạng thái %s",
+	"lot %s is not yet accepted or rejected":                                   "lô %s chưa được nghiệm thu hoặc từ chối",
+	"cannot set a dyeing job to %s":                                            "không thể chuyển lệnh nhuộm sang trạng thái %s",
+	"a %s dyeing job cannot become %s":                                         "lệnh nhuộm đang %s không thể chuyển sang %s",
+	"a %s dyeing job takes no more lots":                                       "lệnh nhuộm đang %s không nhận thêm lô",
+	"set the product of dyeing job %s before accepting its lots":               "cần chọn sản phẩm cho lệnh nhuộm %s trước khi nghiệm thu lô",
+	"warehouse lot %s already exists":                                          "lô kho %s đã tồn tại",
+	"cannot delete a %s dyeing lot":                                            "không thể xóa lô nhuộm đang ở trạng thái %s",
+
+	// Categories
+	"category not found":                                                          "không tìm thấy danh mục",
+	"parent category not found":                                                   "không tìm thấy danh mục cha",
+	"target category not found":                                                   "không tìm thấy danh mục đích",
+	"category cannot be its own parent":                                           "danh mục không thể là cha của chính nó",
+	"cannot move a category under one of its own sub-categories":                  "không thể chuyển danh mục vào danh mục con của chính nó",
+	"category hierarchy already contains a cycle":                                 "cây danh mục đang có vòng lặp",
+	"category is in use":                                                          "danh mục đang được sử dụng",
+	"target_category_id is required for strategy=reassign":                        "strategy=reassign cần target_category_id",
+	"unsupported delete strategy %q":                                              "chiến lược xóa %q không được hỗ trợ",
+	"category must be a category ID":                                              "category phải là ID danh mục",
+	"target category cannot be the deleted category or one of its sub-categories": "danh mục đích không được là danh mục bị xóa hoặc danh mục con của nó",
+	"it has %d sub-categories, %d products and %d samples; use strategy=reassign or strategy=cascade": "danh mục có %d danh mục con, %d sản phẩm và %d mẫu; hãy dùng strategy=reassign hoặc strategy=cascade",
+
+	// Products & samples
+	"product not found":      "không tìm thấy sản phẩm",
+	"product name not found": "không tìm thấy tên sản phẩm",
+	"sample not found":       "không tìm thấy mẫu vải",
+	"SKU already exists":     "SKU đã tồn tại",
+	"search query must contain at least one word of 2 or more characters": "từ khóa tìm kiếm phải có ít nhất một từ dài từ 2 ký tự",
+
+	// Query, sort & pagination
+	"malformed cursor": "cursor không hợp lệ",
+	"cursor does not match the requested sort order": "cursor không khớp với thứ tự sắp xếp được yêu cầu",
+	"cursor pagination supports a single sort field": "phân trang theo cursor chỉ hỗ trợ một trường sắp xếp",
+	"unsupported sort field %q":                      "không hỗ trợ sắp xếp theo trường %q",
+	"unsupported facet %q":                           "không hỗ trợ facet %q",
+	"unsupported filter field: %s":                   "không hỗ trợ lọc theo trường: %s",
+	"unsupported filter operator: %s":                "không hỗ trợ toán tử lọc: %s",
+	"unsupported sort field: %s":                     "không hỗ trợ sắp xếp theo trường: %s",
+	"unsupported facet field: %s":                    "không hỗ trợ facet theo trường: %s",
+
+	// Import & export
+	"import file is empty":                                                   "tệp nhập không có dữ liệu",
+	"import file exceeds %d rows":                                            "tệp nhập vượt quá %d dòng",
+	"failed to read import file: %w":                                         "không đọc được tệp nhập: %w",
+	"failed to import samples: %w":                                           "nhập mẫu vải thất bại: %w",
+	"workbook has no sheets":                                                 "tệp Excel không có sheet nào",
+	"no column mapped to sku":                                                "chưa có cột nào được ánh xạ tới sku",
+	"unknown field %q in mapping for column %q":                              "trường %q không tồn tại trong ánh xạ của cột %q",
+	"field %q is mapped to more than one column":                             "trường %q được ánh xạ tới nhiều hơn một cột",
+	"unsupported file format, use csv or xlsx":                               "định dạng tệp không được hỗ trợ, hãy dùng csv hoặc xlsx",
+	"invalid column mapping, expected JSON object of column header to field": "ánh xạ cột không hợp lệ, cần object JSON từ tiêu đề cột tới trường",
+
+	// Images & storage
+	"image not found":                                 "không tìm thấy hình ảnh",
+	"image %d not found":                              "không tìm thấy hình ảnh %d",
+	"no image files provided":                         "chưa có tệp hình ảnh nào",
+	"unsupported image format":                        "định dạng hình ảnh không được hỗ trợ",
+	"image dimensions are too large":                  "kích thước hình ảnh quá lớn",
+	"image_ids must include every image exactly once": "image_ids phải chứa mỗi hình ảnh đúng một lần",
+	"file %s exceeds maximum size of %d bytes":        "tệp %s vượt quá dung lượng tối đa %d byte",
+	"file %s: %s":                                     "tệp %s: %s",
+	"invalid storage key":                             "khóa lưu trữ không hợp lệ",
+
+	// Permission descriptions
+	"View users":                     "Xem người dùng",
+	"Create new users":               "Tạo người dùng mới",
+	"Update user information":        "Cập nhật thông tin người dùng",
+	"Delete users":                   "Xóa người dùng",
+	"Assign roles to users":          "Gán vai trò cho người dùng",
+	"Reset user passwords":           "Đặt lại mật khẩu người dùng",
+	"View roles":                     "Xem vai trò",
+	"Create new roles":               "Tạo vai trò mới",
+	"Update role information":        "Cập nhật thông tin vai trò",
+	"Delete roles":                   "Xóa vai trò",
+	"Assign permissions to roles":    "Gán quyền cho vai trò",
+	"View products":                  "Xem sản phẩm",
+	"Create new products":            "Tạo sản phẩm mới",
+	"Update product information":     "Cập nhật thông tin sản phẩm",
+	"Delete products":                "Xóa sản phẩm",
+	"Export product data":            "Xuất dữ liệu sản phẩm",
+	"Import product data":            "Nhập dữ liệu sản phẩm",
+	"View product categories":        "Xem danh mục sản phẩm",
+	"Create product categories":      "Tạo danh mục sản phẩm",
+	"Update product categories":      "Cập nhật danh mục sản phẩm",
+	"Delete product categories":      "Xóa danh mục sản phẩm",
+	"View samples":                   "Xem mẫu vải",
+	"Create new samples":             "Tạo mẫu vải mới",
+	"Update sample information":      "Cập nhật thông tin mẫu vải",
+	"Delete samples":                 "Xóa mẫu vải",
+	"Dispatch samples to customers":  "Gửi mẫu vải cho khách hàng",
+	"Track sample status":            "Theo dõi trạng thái mẫu vải",
+	"Import sample data":             "Nhập dữ liệu mẫu vải",
+	"Export sample data":             "Xuất dữ liệu mẫu vải",
+	"View customers":                 "Xem khách hàng",
+	"Create new customers":           "Tạo khách hàng mới",
+	"Update customer information":    "Cập nhật thông tin khách hàng",
+	"Delete customers":               "Xóa khách hàng",
+	"View customer activity logs":    "Xem nhật ký hoạt động khách hàng",
+	"Merge duplicate customers":      "Gộp khách hàng trùng lặp",
+	"View orders":                    "Xem đơn hàng",
+	"Create new orders":              "Tạo đơn hàng mới",
+	"Update order information":       "Cập nhật thông tin đơn hàng",
+	"Delete orders":                  "Xóa đơn hàng",
+	"Approve orders":                 "Duyệt đơn hàng",
+	"Cancel orders":                  "Hủy đơn hàng",
+	"Ship orders":                    "Giao đơn hàng",
+	"Create and manage quotations":   "Tạo và quản lý báo giá",
+	"Manage price lists":             "Quản lý bảng giá",
+	"View warehouse data":            "Xem dữ liệu kho",
+	"Create warehouse entries":       "Tạo phiếu kho",
+	"Update warehouse data":          "Cập nhật dữ liệu kho",
+	"Delete warehouse entries":       "Xóa phiếu kho",
+	"Transfer inventory":             "Chuyển kho",
+	"View financial data":            "Xem dữ liệu tài chính",
+	"Create financial records":       "Tạo chứng từ tài chính",
+	"Update financial data":          "Cập nhật dữ liệu tài chính",
+	"Delete financial records":       "Xóa chứng từ tài chính",
+	"Approve financial transactions": "Duyệt giao dịch tài chính",
+	"Manage exchange rates":          "Quản lý tỷ giá",
+	"View reports":                   "Xem báo cáo",
+	"Create custom reports":          "Tạo báo cáo tùy chỉnh",
+	"Export reports":                 "Xuất báo cáo",
+	"View system logs":               "Xem nhật ký hệ thống",
+	"Manage system settings":         "Quản lý cấu hình hệ thống",
+	"Perform system backup":          "Sao lưu hệ thống",
+	"Restore system from backup":     "Khôi phục hệ thống từ bản sao lưu",
+
+	// Permission groups
+	"User Management":              "Quản lý người dùng",
+	"Role & Permission Management": "Quản lý vai trò & phân quyền",
+	"Product Management":           "Quản lý sản phẩm",
+	"Category Management":          "Quản lý danh mục",
+	"Sample Management":            "Quản lý mẫu vải",
+	"Customer Management":          "Quản lý khách hàng",
+	"Order Management":             "Quản lý đơn hàng",
+	"Warehouse Management":         "Quản lý kho",
+	"Financial Management":         "Quản lý tài chính",
+	"Reports & Analytics":          "Báo cáo & phân tích",
+	"System Administration":        "Quản trị hệ thống",
+}
+
+var verbPattern = regexp.MustCompile(`%(\[\d+\])?[sdqvw]`)
+
+// pattern is a catalog entry with fmt verbs, matched against formatted messages
+type pattern struct {
+	re        *regexp.Regexp
+	translate string
+}
+
+var patterns = compilePatterns()
+
+func compilePatterns() []pattern {
+	var result []pattern
+	for source, translated := range messages {
+		verbs := verbPattern.FindAllStringIndex(source, -1)
+		if len(verbs) == 0 {
+			continue
+		}
+
+		var expr strings.Builder
+		expr.WriteString("^")
+		last := 0
+		for _, verb := range verbs {
+			expr.WriteString(regexp.QuoteMeta(source[last:verb[0]]))
+			expr.WriteString("(.+?)")
+			last = verb[1]
+		}
+		expr.WriteString(regexp.QuoteMeta(source[last:]))
+		expr.WriteString("$")
+
+		result = append(result, pattern{re: regexp.MustCompile(expr.String()), translate: translated})
+	}
+
+	// Longer sources are more specific; try them first
+	sort.Slice(result, func(a, b int) bool {
+		return len(result[a].re.String()) > len(result[b].re.String())
+	})
+	return result
+}
+
+// T translates an English message into lang. Messages missing from the catalog are returned unchanged.
+func T(lang Lang, message string) string {
+	if lang == EN || message == "" {
+		return message
+	}
+	if translated, ok := translate(message); ok {
+		return translated
+	}
+	return message
+}
+
+func translate(message string) (string, bool) {
+	if translated, ok := messages[message]; ok {
+		return translated, true
+	}
+
+	for _, p := range patterns {
+		match := p.re.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		args := match[1:]
+		for i, arg := range args {
+			args[i] = T(VI, arg)
+		}
+		return substitute(p.translate, args), true
+	}
+
+	// Wrapped errors read "outer: inner"; translate each part
+	if outer, inner, ok := strings.Cut(message, ": "); ok {
+		translatedOuter, okOuter := translate(outer)
+		translatedInner, okInner := translate(inner)
+		if okOuter || okInner {
+			return translatedOuter + ": " + translatedInner, true
+		}
+	}
+	return message, false
+}
+
+// substitute replaces fmt verbs in format with args in order, or by index for %[n]s
+func substitute(format string, args []string) string {
+	next := 0
+	return verbPattern.ReplaceAllStringFunc(format, func(verb string) string {
+		index := next
+		if strings.HasPrefix(verb, "%[") {
+			n, err := strconv.Atoi(verb[2:strings.Index(verb, "]")])
+			if err != nil {
+				return verb
+			}
+			index = n - 1
+		}
+		next = index + 1
+		if index < 0 || index >= len(args) {
+			return verb
+		}
+		return args[index]
+	})
+}
@@ -0,0 +1,68 @@
+// File: pkg/i18n/i18n.go
+// Tạo tại: pkg/i18n/i18n.go
+// Mục đích: Xác định ngôn ngữ (vi/en) từ Accept-Language và chọn nội dung song ngữ
+
+package i18n
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Lang is a supported response language
+type Lang string
+
+const (
+	VI Lang = "vi"
+	EN Lang = "en"
+)
+
+// Supported lists the response languages, in matcher preference order
+var Supported = []Lang{EN, VI}
+
+var matcher = language.NewMatcher([]language.Tag{language.English, language.Vietnamese})
+
+// Parse returns the supported language for a language code such as "vi" or "en-US"
+func Parse(code string) (Lang, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	for _, lang := range Supported {
+		if string(lang) == code {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// Match picks the best supported language for an Accept-Language header, or fallback when nothing matches
+func Match(acceptLanguage string, fallback Lang) Lang {
+	if strings.TrimSpace(acceptLanguage) == "" {
+		return fallback
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return fallback
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return fallback
+	}
+	return Supported[index]
+}
+
+// Pick returns the text for lang, falling back to the other language when that translation is empty
+func Pick(lang Lang, vi, en string) string {
+	if lang == EN {
+		if en != "" {
+			return en
+		}
+		return vi
+	}
+	if vi != "" {
+		return vi
+	}
+	return en
+}
@@ -0,0 +1,95 @@
+// File: pkg/i18n/validation.go
+// Tạo tại: pkg/i18n/validation.go
+// Mục đích: Chuyển lỗi binding/validate của Gin thành thông báo dễ đọc theo từng trường (vi/en)
+
+package i18n
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	validationPattern = regexp.MustCompile(`Key: '([^']*)' Error:Field validation for '[^']*' failed on the '([^']*)' tag`)
+	unmarshalPattern  = regexp.MustCompile(`^json: cannot unmarshal \S+ into Go (?:struct field|value) (\S*) of type (\S+)$`)
+)
+
+// validationMessages holds the English and Vietnamese text per validator tag; %s is the field name
+var validationMessages = map[string][2]string{
+	"required": {"%s is required", "%s là bắt buộc"},
+	"oneof":    {"%s must be one of the allowed values", "%s phải là một trong các giá trị cho phép"},
+	"email":    {"%s must be a valid email address", "%s phải là địa chỉ email hợp lệ"},
+	"min":      {"%s is too short or too small", "%s quá ngắn hoặc quá nhỏ"},
+	"gt":       {"%s is too small", "%s quá nhỏ"},
+	"gte":      {"%s is too small", "%s quá nhỏ"},
+	"max":      {"%s is too long or too large", "%s quá dài hoặc quá lớn"},
+	"lt":       {"%s is too large", "%s quá lớn"},
+	"lte":      {"%s is too large", "%s quá lớn"},
+	"len":      {"%s has the wrong length", "%s có độ dài không đúng"},
+	"numeric":  {"%s must be a number", "%s phải là số"},
+	"url":      {"%s must be a valid URL", "%s phải là URL hợp lệ"},
+	"datetime": {"%s must be a valid date", "%s phải là ngày hợp lệ"},
+}
+
+var (
+	invalidFieldMessage = [2]string{"%s is invalid", "%s không hợp lệ"}
+	wrongTypeMessage    = [2]string{"%s has the wrong type, expected %s", "%s sai kiểu dữ liệu, cần %s"}
+)
+
+// TranslateError localizes an error message returned by the API: binding and validation errors
+// become one readable message per field, other messages go through the catalog
+func TranslateError(lang Lang, message string) string {
+	if translated, ok := translateValidation(lang, message); ok {
+		return translated
+	}
+	return T(lang, message)
+}
+
+func translateValidation(lang Lang, message string) (string, bool) {
+	if match := unmarshalPattern.FindStringSubmatch(message); match != nil {
+		return pickMessage(lang, wrongTypeMessage, fieldName(match[1]), match[2]), true
+	}
+
+	matches := validationPattern.FindAllStringSubmatch(message, -1)
+	if len(matches) == 0 {
+		return "", false
+	}
+	parts := make([]string, len(matches))
+	for i, match := range matches {
+		text, ok := validationMessages[match[2]]
+		if !ok {
+			text = invalidFieldMessage
+		}
+		parts[i] = pickMessage(lang, text, fieldName(match[1]))
+	}
+	return strings.Join(parts, "; "), true
+}
+
+func pickMessage(lang Lang, text [2]string, args ...string) string {
+	if lang == VI {
+		return substitute(text[1], args)
+	}
+	return substitute(text[0], args)
+}
+
+// fieldName turns a validator namespace such as "CreateSampleRequest.ProductNameID" into "product_name_id"
+func fieldName(namespace string) string {
+	if i := strings.LastIndex(namespace, "."); i >= 0 {
+		namespace = namespace[i+1:]
+	}
+
+	runes := []rune(namespace)
+	var name strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word at a lower→upper boundary or at the last capital of an acronym ("IDValue")
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				name.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		name.WriteRune(r)
+	}
+	return name.String()
+}
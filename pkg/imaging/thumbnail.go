@@ -0,0 +1,99 @@
+// File: pkg/imaging/thumbnail.go
+// Tạo tại: pkg/imaging/thumbnail.go
+// Mục đích: Decode ảnh upload và tạo thumbnail JPEG
+
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// AllowedContentTypes lists image formats accepted for upload
+var AllowedContentTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// MaxPixels bounds width × height of an upload, so a small file cannot claim dimensions that take
+// gigabytes to decode
+const MaxPixels = 40_000_000
+
+var (
+	// ErrUnsupportedFormat is returned when the upload is not a supported image
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	// ErrImageTooLarge is returned when the image dimensions exceed MaxPixels
+	ErrImageTooLarge = errors.New("image dimensions are too large")
+)
+
+// Thumbnail holds an encoded thumbnail together with the source dimensions
+type Thumbnail struct {
+	Data         []byte
+	Width        int
+	Height       int
+	SourceWidth  int
+	SourceHeight int
+}
+
+// MakeThumbnail decodes an image and scales it down to maxWidth, preserving aspect ratio.
+// Images narrower than maxWidth are re-encoded without upscaling. The dimensions are read from the
+// header first, and images larger than MaxPixels are rejected before decoding.
+func MakeThumbnail(r io.Reader, maxWidth int) (*Thumbnail, error) {
+	var header bytes.Buffer
+	config, _, err := image.DecodeConfig(io.TeeReader(r, &header))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrUnsupportedFormat
+	}
+	if int64(config.Width)*int64(config.Height) > MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(io.MultiReader(&header, r))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, ErrUnsupportedFormat
+	}
+
+	thumbWidth, thumbHeight := width, height
+	if maxWidth > 0 && width > maxWidth {
+		thumbWidth = maxWidth
+		thumbHeight = height * maxWidth / width
+		if thumbHeight == 0 {
+			thumbHeight = 1
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+
+	return &Thumbnail{
+		Data:         buf.Bytes(),
+		Width:        thumbWidth,
+		Height:       thumbHeight,
+		SourceWidth:  width,
+		SourceHeight: height,
+	}, nil
+}
@@ -0,0 +1,76 @@
+// File: pkg/imaging/thumbnail_test.go
+// Tạo tại: pkg/imaging/thumbnail_test.go
+// Mục đích: Kiểm thử tạo thumbnail và từ chối ảnh có kích thước vượt giới hạn trước khi decode
+
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func pngImage(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// gifHeader is only the header and screen descriptor of a GIF, which is all DecodeConfig reads
+func gifHeader(width, height uint16) []byte {
+	data := []byte("GIF89a")
+	data = binary.LittleEndian.AppendUint16(data, width)
+	data = binary.LittleEndian.AppendUint16(data, height)
+	return append(data, 0, 0, 0)
+}
+
+func TestMakeThumbnail(t *testing.T) {
+	tests := []struct {
+		name                string
+		data                []byte
+		maxWidth            int
+		width, height       int
+		srcWidth, srcHeight int
+	}{
+		{name: "scaled down", data: pngImage(t, 400, 200), maxWidth: 100, width: 100, height: 50, srcWidth: 400, srcHeight: 200},
+		{name: "not upscaled", data: pngImage(t, 80, 60), maxWidth: 100, width: 80, height: 60, srcWidth: 80, srcHeight: 60},
+		{name: "thin image keeps a row", data: pngImage(t, 1000, 1), maxWidth: 100, width: 100, height: 1, srcWidth: 1000, srcHeight: 1},
+	}
+	for _, tt := range tests {
+		thumb, err := MakeThumbnail(bytes.NewReader(tt.data), tt.maxWidth)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if thumb.Width != tt.width || thumb.Height != tt.height || thumb.SourceWidth != tt.srcWidth || thumb.SourceHeight != tt.srcHeight {
+			t.Errorf("%s: thumbnail %dx%d of %dx%d, want %dx%d of %dx%d", tt.name, thumb.Width, thumb.Height,
+				thumb.SourceWidth, thumb.SourceHeight, tt.width, tt.height, tt.srcWidth, tt.srcHeight)
+		}
+		if _, format, err := image.Decode(bytes.NewReader(thumb.Data)); err != nil || format != "jpeg" {
+			t.Errorf("%s: thumbnail is %q, %v, want jpeg", tt.name, format, err)
+		}
+	}
+}
+
+func TestMakeThumbnailRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "not an image", data: []byte("hello"), want: ErrUnsupportedFormat},
+		{name: "huge dimensions in a tiny file", data: gifHeader(65535, 65535), want: ErrImageTooLarge},
+		{name: "just over the limit", data: gifHeader(8000, 5001), want: ErrImageTooLarge},
+	}
+	for _, tt := range tests {
+		if _, err := MakeThumbnail(bytes.NewReader(tt.data), 100); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// File: pkg/money/money.go
+// Tạo tại: pkg/money/money.go
+// Mục đích: Kiểu tiền tệ số chấm cố định (đơn vị 1/100) thay cho float64, mã tiền tệ ISO 4217 và quy đổi theo tỷ giá
+
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Common currency codes
+const (
+	VND = "VND"
+	USD = "USD"
+)
+
+// Scale is the number of Amount units in one currency unit, matching the DECIMAL(15,2) columns
+const Scale = 100
+
+// ErrInvalidAmount is returned when a value cannot be read as an amount
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// Amount is a monetary value in hundredths of a currency unit. It reads and writes DECIMAL columns
+// and JSON numbers exactly, so sums never pick up float rounding errors.
+type Amount int64
+
+// New makes an amount from whole units and hundredths, e.g. New(12, 50) is 12.50
+func New(units, hundredths int64) Amount {
+	return Amount(units*Scale + hundredths)
+}
+
+// FromFloat rounds a float to the nearest hundredth, halves away from zero
+func FromFloat(f float64) Amount {
+	return Amount(math.Round(f * Scale))
+}
+
+// Parse reads a decimal string such as "1234.5" or "-0.125". Digits past the hundredths are rounded.
+func Parse(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("%w: empty", ErrInvalidAmount)
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || !digitsOnly(whole) || !digitsOnly(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	var units int64
+	if whole != "" {
+		var err error
+		if units, err = strconv.ParseInt(whole, 10, 64); err != nil || units > math.MaxInt64/Scale-1 {
+			return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+		}
+	}
+
+	// Hundredths, rounded on the third decimal
+	frac += "000"
+	hundredths, _ := strconv.ParseInt(frac[:2], 10, 64)
+	if frac[2] >= '5' {
+		hundredths++
+	}
+
+	a := Amount(units*Scale + hundredths)
+	if negative {
+		a = -a
+	}
+	return a, nil
+}
+
+func digitsOnly(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Float64 returns the amount in currency units, for display math such as percentages
+func (a Amount) Float64() float64 {
+	return float64(a) / Scale
+}
+
+// String formats the amount with two decimals, e.g. "1234.50"
+func (a Amount) String() string {
+	sign := ""
+	value := int64(a)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, value/Scale, value%Scale)
+}
+
+// Mul multiplies the amount by a quantity or rate and rounds to the hundredth
+func (a Amount) Mul(factor float64) Amount {
+	return Amount(math.Round(float64(a) * factor))
+}
+
+// Discount takes percent off the amount, e.g. 100.00 less 12.5% is 87.50
+func (a Amount) Discount(percent float64) Amount {
+	return a.Mul(1 - percent/100)
+}
+
+// Sum adds up amounts
+func Sum(amounts ...Amount) Amount {
+	var total Amount
+	for _, amount := range amounts {
+		total += amount
+	}
+	return total
+}
+
+// Value stores the amount as a decimal string so DECIMAL columns keep it exactly
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan reads DECIMAL, integer, float and NULL (as zero) column values
+func (a *Amount) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = 0
+	case []byte:
+		return a.scanString(string(v))
+	case string:
+		return a.scanString(v)
+	case int64:
+		*a = Amount(v * Scale)
+	case float64:
+		*a = FromFloat(v)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidAmount, src)
+	}
+	return nil
+}
+
+func (a *Amount) scanString(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// GormDataType is the column type used when a model with amounts is auto-migrated
+func (Amount) GormDataType() string {
+	return "decimal(15,2)"
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimals
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		return nil
+	}
+	return a.scanString(s)
+}
+
+// IsCurrencyCode reports whether code looks like an ISO 4217 code: three upper-case letters
+func IsCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeCurrency upper-cases and checks a currency code
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !IsCurrencyCode(code) {
+		return "", fmt.Errorf("invalid currency code %q", code)
+	}
+	return code, nil
+}
+
+// Convert changes an amount into another currency at rate, the price of one unit of its currency
+func Convert(amount Amount, rate float64) Amount {
+	return amount.Mul(rate)
+}
@@ -0,0 +1,146 @@
+// File: pkg/money/money_test.go
+// Tạo tại: pkg/money/money_test.go
+// Mục đích: Kiểm thử đọc số tiền dạng chuỗi, làm tròn đến 1/100 và định dạng/JSON của Amount
+
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Amount
+		wantErr bool
+	}{
+		{in: "0", want: 0},
+		{in: "1234.5", want: New(1234, 50)},
+		{in: " 12.34 ", want: New(12, 34)},
+		{in: "+7", want: New(7, 0)},
+		{in: ".5", want: New(0, 50)},
+		{in: "3.", want: New(3, 0)},
+		{in: "-0.125", want: -13},
+		{in: "0.124", want: 12},
+		{in: "1.995", want: New(2, 0)},
+		{in: "-10.999", want: -New(11, 0)},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "1,000", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "--1", wantErr: true},
+		{in: "99999999999999999999", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidAmount) {
+				t.Errorf("Parse(%q) error = %v, want ErrInvalidAmount", tt.in, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestRounding(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Amount
+		want Amount
+	}{
+		{"FromFloat rounds halves away from zero", FromFloat(0.125), 13},
+		{"FromFloat negative half", FromFloat(-0.125), -13},
+		{"FromFloat float noise", FromFloat(0.1 + 0.2), 30},
+		{"Mul by quantity", New(12, 35).Mul(3.5), New(43, 23)},
+		{"Mul by exchange rate", Convert(New(10, 0), 25450.5), New(254505, 0)},
+		{"Discount", New(100, 0).Discount(12.5), New(87, 50)},
+		{"Discount rounds", New(0, 99).Discount(50), 50},
+		{"Sum is exact", Sum(FromFloat(0.1), FromFloat(0.2), FromFloat(0.3)), New(0, 60)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := map[Amount]string{
+		0:               "0.00",
+		New(1234, 5):    "1234.05",
+		-5:              "-0.05",
+		-New(12, 50):    "-12.50",
+		New(1000000, 0): "1000000.00",
+	}
+	for amount, want := range tests {
+		if got := amount.String(); got != want {
+			t.Errorf("String(%d) = %q, want %q", int64(amount), got, want)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		Price Amount `json:"price"`
+		Cost  Amount `json:"cost"`
+	}
+	if err := json.Unmarshal([]byte(`{"price": 19.999, "cost": "7.5"}`), &v); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if v.Price != New(20, 0) || v.Cost != New(7, 50) {
+		t.Errorf("unmarshal = %v, %v, want 20.00, 7.50", v.Price, v.Cost)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil || string(data) != `{"price":20.00,"cost":7.50}` {
+		t.Errorf("marshal = %s, %v", data, err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		src  interface{}
+		want Amount
+	}{
+		{nil, 0},
+		{[]byte("15.25"), New(15, 25)},
+		{"3.10", New(3, 10)},
+		{int64(4), New(4, 0)},
+		{2.675, New(2, 68)},
+	}
+	for _, tt := range tests {
+		var a Amount = 99
+		if err := a.Scan(tt.src); err != nil || a != tt.want {
+			t.Errorf("Scan(%v) = %v, %v, want %v", tt.src, a, err, tt.want)
+		}
+	}
+
+	var a Amount
+	if err := a.Scan(true); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("Scan(bool) error = %v, want ErrInvalidAmount", err)
+	}
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: " vnd ", want: VND},
+		{in: "USD", want: USD},
+		{in: "US", wantErr: true},
+		{in: "U$D", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeCurrency(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeCurrency(%q) = %q, %v", tt.in, got, err)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// File: pkg/spreadsheet/spreadsheet.go
+// Tạo tại: pkg/spreadsheet/spreadsheet.go
+// Mục đích: Đọc/ghi dữ liệu dạng bảng (CSV, XLSX) cho chức năng import/export
+
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// ErrUnsupportedFormat is returned for file formats other than CSV/XLSX
+var ErrUnsupportedFormat = errors.New("unsupported file format, use csv or xlsx")
+
+// FormatFromFileName detects the format from a file extension
+func FormatFromFileName(name string) (string, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ParseFormat checks a format name such as "CSV" or "xlsx" and returns it as FormatCSV or FormatXLSX
+func ParseFormat(format string) (string, error) {
+	switch format = strings.ToLower(strings.TrimSpace(format)); format {
+	case FormatCSV, FormatXLSX:
+		return format, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ContentType returns the MIME type of a format
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// ReadAll reads every row of the first sheet (XLSX) or the whole file (CSV)
+func ReadAll(r io.Reader, format string) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		// Strip UTF-8 BOM written by Excel
+		if len(rows) > 0 && len(rows[0]) > 0 {
+			rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+		}
+		return rows, nil
+	case FormatXLSX:
+		f, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, errors.New("workbook has no sheets")
+		}
+		return f.GetRows(sheets[0])
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// Writer writes rows to a CSV or XLSX output
+type Writer interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+// NewWriter creates a row writer for the given format.
+// CSV rows are flushed to w as they are written; XLSX rows are streamed into the workbook and written on Close.
+func NewWriter(w io.Writer, format string, sheetName string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		// UTF-8 BOM so Excel opens Vietnamese text correctly
+		if _, err := io.WriteString(w, "\ufeff"); err != nil {
+			return nil, err
+		}
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		f := excelize.NewFile()
+		defaultSheet := f.GetSheetName(0)
+		if sheetName != "" && sheetName != defaultSheet {
+			if err := f.SetSheetName(defaultSheet, sheetName); err != nil {
+				return nil, err
+			}
+		} else {
+			sheetName = defaultSheet
+		}
+
+		stream, err := f.NewStreamWriter(sheetName)
+		if err != nil {
+			return nil, err
+		}
+		return &xlsxWriter{file: f, stream: stream, out: w, row: 1}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	count  int
+}
+
+func (w *csvWriter) WriteRow(values []string) error {
+	if err := w.writer.Write(values); err != nil {
+		return err
+	}
+	w.count++
+	// Flush periodically so large exports stream to the client
+	if w.count%500 == 0 {
+		w.writer.Flush()
+		return w.writer.Error()
+	}
+	return nil
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type xlsxWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	out    io.Writer
+	row    int
+}
+
+func (w *xlsxWriter) WriteRow(values []string) error {
+	cells := make([]interface{}, len(values))
+	for i, v := range values {
+		cells[i] = v
+	}
+
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	if err := w.stream.SetRow(cell, cells); err != nil {
+		return fmt.Errorf("write row %d: %w", w.row, err)
+	}
+	w.row++
+	return nil
+}
+
+func (w *xlsxWriter) Close() error {
+	defer w.file.Close()
+
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+	return w.file.Write(w.out)
+}
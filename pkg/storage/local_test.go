@@ -0,0 +1,78 @@
+// File: pkg/storage/local_test.go
+// Tạo tại: pkg/storage/local_test.go
+// Mục đích: Kiểm thử BlobStore local giữ mọi key bên trong thư mục gốc (chặn path traversal)
+
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStoreResolve(t *testing.T) {
+	root := t.TempDir()
+	s := &localStore{root: root}
+	tests := []struct {
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{key: "samples/12/a.jpg", want: "samples/12/a.jpg"},
+		{key: "/samples/12/a.jpg", want: "samples/12/a.jpg"},
+		{key: "samples/../products/3/b.png", want: "products/3/b.png"},
+		{key: "../../etc/passwd", want: "etc/passwd"},
+		{key: "samples/../../../outside.jpg", want: "outside.jpg"},
+		{key: "", wantErr: true},
+		{key: "/", wantErr: true},
+		{key: "../..", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := s.resolve(tt.key)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("resolve(%q) = %q, want an error", tt.key, got)
+			}
+			continue
+		}
+		if want := filepath.Join(root, filepath.FromSlash(tt.want)); err != nil || got != want {
+			t.Errorf("resolve(%q) = %q, %v, want %q", tt.key, got, err, want)
+		}
+	}
+}
+
+func TestLocalStorePutStaysInsideRoot(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "uploads")
+	store, err := NewLocalStore(root, "http://localhost/uploads/")
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := store.Put(ctx, "../escaped.txt", strings.NewReader("x"), 1, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(parent, "escaped.txt")); !os.IsNotExist(err) {
+		t.Errorf("file was written outside the root: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escaped.txt")); err != nil {
+		t.Errorf("file was not written inside the root: %v", err)
+	}
+
+	if err := store.Delete(ctx, "../escaped.txt"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escaped.txt")); !os.IsNotExist(err) {
+		t.Errorf("file still exists after Delete: %v", err)
+	}
+	if err := store.Delete(ctx, "missing.txt"); err != nil {
+		t.Errorf("Delete of a missing key: %v", err)
+	}
+
+	if got := store.URL("/samples/1/a.jpg"); got != "http://localhost/uploads/samples/1/a.jpg" {
+		t.Errorf("URL = %q", got)
+	}
+}
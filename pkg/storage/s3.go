@@ -0,0 +1,78 @@
+// File: pkg/storage/s3.go
+// Tạo tại: pkg/storage/s3.go
+// Mục đích: BlobStore cho S3-compatible storage (AWS S3, MinIO, ...)
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/godiidev/appsynex/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type s3Store struct {
+	client   *minio.Client
+	bucket   string
+	endpoint string
+	useSSL   bool
+	baseURL  string
+}
+
+// NewS3Store creates a BlobStore backed by an S3-compatible bucket
+func NewS3Store(cfg *config.StorageConfig) (BlobStore, error) {
+	if cfg.S3Endpoint == "" || cfg.S3Bucket == "" {
+		return nil, errors.New("S3_ENDPOINT and S3_BUCKET are required for s3 storage")
+	}
+
+	client, err := minio.New(cfg.S3Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.S3AccessKey, cfg.S3SecretKey, ""),
+		Secure: cfg.S3UseSSL,
+		Region: cfg.S3Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// BaseURL is only used when it points to a CDN/public host, otherwise use the bucket URL
+	baseURL := ""
+	if strings.HasPrefix(cfg.BaseURL, "http://") || strings.HasPrefix(cfg.BaseURL, "https://") {
+		baseURL = strings.TrimRight(cfg.BaseURL, "/")
+	}
+
+	return &s3Store{
+		client:   client,
+		bucket:   cfg.S3Bucket,
+		endpoint: cfg.S3Endpoint,
+		useSSL:   cfg.S3UseSSL,
+		baseURL:  baseURL,
+	}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *s3Store) URL(key string) string {
+	if s.baseURL != "" {
+		return s.baseURL + "/" + key
+	}
+
+	scheme := "http"
+	if s.useSSL {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/%s/%s", scheme, s.endpoint, s.bucket, key)
+}
@@ -0,0 +1,50 @@
+// File: pkg/storage/storage.go
+// Tạo tại: pkg/storage/storage.go
+// Mục đích: BlobStore interface dùng chung cho lưu trữ file (local filesystem hoặc S3-compatible)
+
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/godiidev/appsynex/config"
+)
+
+// BlobStore stores binary objects under a key and exposes them via URL
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// New creates a BlobStore based on the configured driver
+func New(cfg *config.StorageConfig) (BlobStore, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case "", "local":
+		return NewLocalStore(cfg.LocalPath, cfg.BaseURL)
+	case "s3":
+		return NewS3Store(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Driver)
+	}
+}
+
+// NewObjectName returns a random hex name for a new object (without extension)
+func NewObjectName() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Key joins key segments into an object key like "samples/12/3f9a...c1.jpg"
+func Key(segments ...string) string {
+	return path.Join(segments...)
+}
@@ -0,0 +1,330 @@
+// File: pkg/textsearch/textsearch.go
+// Tạo tại: pkg/textsearch/textsearch.go
+// Mục đích: Chuẩn hóa tiếng Việt (bỏ dấu), tách từ, chấm điểm liên quan và tạo đoạn trích có đánh dấu cho tìm kiếm
+
+package textsearch
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MinTokenLength matches the MySQL ngram_token_size used by the FULLTEXT indexes
+const MinTokenLength = 2
+
+const (
+	HighlightOpen  = "<em>"
+	HighlightClose = "</em>"
+)
+
+// Match weights relative to the field weight
+const (
+	exactMatch  = 1.0
+	prefixMatch = 0.8
+	fuzzyMatch  = 0.5
+	phraseBonus = 0.25
+)
+
+// Normalize lowercases s, strips Vietnamese diacritics ("vải" -> "vai", "đỏ" -> "do")
+// and replaces punctuation with single spaces
+func Normalize(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	space := true
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r == 'đ' || r == 'Đ':
+			r = 'd'
+		}
+
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+			space = false
+		} else if !space {
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// Tokenize returns the distinct normalized words of s in order of appearance
+func Tokenize(s string) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	for _, token := range strings.Fields(Normalize(s)) {
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// BooleanQuery builds a MySQL FULLTEXT boolean-mode query for an ngram index.
+// With requireAll every token must appear as a phrase; otherwise every bigram of every token
+// is optional, which yields candidates for fuzzy matching. Tokens shorter than MinTokenLength are skipped.
+func BooleanQuery(tokens []string, requireAll bool) string {
+	var terms []string
+	for _, token := range tokens {
+		if utf8.RuneCountInString(token) < MinTokenLength {
+			continue
+		}
+		if requireAll {
+			terms = append(terms, `+"`+token+`"`)
+			continue
+		}
+		runes := []rune(token)
+		for i := 0; i+MinTokenLength <= len(runes); i++ {
+			terms = append(terms, string(runes[i:i+MinTokenLength]))
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+// Field is a piece of text scored with a weight
+type Field struct {
+	Name   string
+	Text   string
+	Weight float64
+}
+
+// Result is the relevance of a document and the fields that matched
+type Result struct {
+	Relevance     float64
+	MatchedFields []string
+}
+
+// Score ranks fields against the query tokens. Each token contributes its best match over all fields
+// (exact, prefix or fuzzy), the sum is normalized to 0..1 and a bonus is added when a field contains
+// the whole query as a phrase. A zero relevance means at least one token did not match anywhere.
+func Score(tokens []string, fields []Field) Result {
+	if len(tokens) == 0 {
+		return Result{}
+	}
+
+	maxWeight := 0.0
+	normalized := make([][]string, len(fields))
+	for i, field := range fields {
+		normalized[i] = strings.Fields(Normalize(field.Text))
+		maxWeight = math.Max(maxWeight, field.Weight)
+	}
+	if maxWeight == 0 {
+		return Result{}
+	}
+
+	matched := make(map[string]bool)
+	total := 0.0
+	for _, token := range tokens {
+		best := 0.0
+		for i, field := range fields {
+			for _, word := range normalized[i] {
+				if m := matchWord(word, token); m > 0 {
+					if m*field.Weight > best {
+						best = m * field.Weight
+					}
+					matched[field.Name] = true
+				}
+			}
+		}
+		if best == 0 {
+			return Result{}
+		}
+		total += best
+	}
+
+	relevance := total / (float64(len(tokens)) * maxWeight)
+
+	phrase := strings.Join(tokens, " ")
+	for i := range fields {
+		if len(tokens) > 1 && strings.Contains(strings.Join(normalized[i], " "), phrase) {
+			relevance += phraseBonus
+			break
+		}
+	}
+
+	names := make([]string, 0, len(matched))
+	for _, field := range fields {
+		if matched[field.Name] {
+			names = append(names, field.Name)
+		}
+	}
+
+	return Result{
+		Relevance:     math.Round(math.Min(relevance, 1)*10000) / 10000,
+		MatchedFields: names,
+	}
+}
+
+// Highlight wraps the words of text that match any token in HighlightOpen/HighlightClose and
+// trims the result to a snippet of about maxRunes around the first match. The text is HTML-escaped, so
+// the highlight tags are the only markup in the snippet. It returns false when nothing matched.
+func Highlight(text string, tokens []string, maxRunes int) (string, bool) {
+	type span struct{ start, end int }
+
+	runes := []rune(text)
+	var spans []span
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+		word := Normalize(string(runes[i:j]))
+		for _, token := range tokens {
+			if matchWord(word, token) > 0 {
+				spans = append(spans, span{i, j})
+				break
+			}
+		}
+		i = j
+	}
+	if len(spans) == 0 {
+		return "", false
+	}
+
+	// Window around the first match
+	from, to := 0, len(runes)
+	if maxRunes > 0 && len(runes) > maxRunes {
+		from = spans[0].start - maxRunes/4
+		if from < 0 {
+			from = 0
+		}
+		to = from + maxRunes
+		if to > len(runes) {
+			to = len(runes)
+			from = to - maxRunes
+		}
+		// Do not cut words in half
+		for from > 0 && isWordRune(runes[from-1]) && isWordRune(runes[from]) {
+			from--
+		}
+		for to < len(runes) && isWordRune(runes[to-1]) && isWordRune(runes[to]) {
+			to++
+		}
+	}
+
+	sort.Slice(spans, func(a, b int) bool { return spans[a].start < spans[b].start })
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := from
+	for _, s := range spans {
+		if s.end <= from || s.start >= to {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:s.start])))
+		b.WriteString(HighlightOpen)
+		b.WriteString(html.EscapeString(string(runes[s.start:s.end])))
+		b.WriteString(HighlightClose)
+		pos = s.end
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:to])))
+	if to < len(runes) {
+		b.WriteString("…")
+	}
+
+	return strings.TrimSpace(b.String()), true
+}
+
+// Similarity compares two texts after normalization, from 0 (nothing in common) to 1 (equal).
+// Word order is ignored, so "Minh Anh Textile" and "Textile Minh Anh" are equal.
+func Similarity(a, b string) float64 {
+	a, b = sortedWords(a), sortedWords(b)
+	if a == b {
+		return 1
+	}
+	aRunes, bRunes := []rune(a), []rune(b)
+	longest := max(len(aRunes), len(bRunes))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(aRunes, bRunes))/float64(longest)
+}
+
+func sortedWords(s string) string {
+	words := strings.Fields(Normalize(s))
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// matchWord returns how well a normalized word matches a query token (0 when it does not)
+func matchWord(word, token string) float64 {
+	switch {
+	case word == token:
+		return exactMatch
+	case strings.HasPrefix(word, token):
+		return prefixMatch
+	}
+
+	maxDistance := allowedDistance(token)
+	if maxDistance == 0 {
+		return 0
+	}
+	wordRunes, tokenRunes := []rune(word), []rune(token)
+	if abs(len(wordRunes)-len(tokenRunes)) > maxDistance {
+		return 0
+	}
+	if levenshtein(wordRunes, tokenRunes) <= maxDistance {
+		return fuzzyMatch
+	}
+	return 0
+}
+
+// allowedDistance grows the typo tolerance with the token length
+func allowedDistance(token string) int {
+	switch n := utf8.RuneCountInString(token); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,199 @@
+// File: pkg/textsearch/textsearch_test.go
+// Tạo tại: pkg/textsearch/textsearch_test.go
+// Mục đích: Kiểm thử chuẩn hóa tiếng Việt, chấm điểm liên quan và đoạn trích có đánh dấu
+
+package textsearch
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"Vải Đỏ", "vai do"},
+		{"Lụa tơ tằm", "lua to tam"},
+		{"  Cotton,  100% / poly-ester ", "cotton 100 poly ester"},
+		{"NGUYỄN", "nguyen"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Vải vai VẢI đỏ")
+	want := []string{"vai", "do"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize = %v, want %v", got, want)
+	}
+}
+
+func TestBooleanQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		tokens     []string
+		requireAll bool
+		want       string
+	}{
+		{"phrases skip short tokens", []string{"vai", "x", "cotton"}, true, `+"vai" +"cotton"`},
+		{"bigrams", []string{"vai"}, false, "va ai"},
+		{"nothing long enough", []string{"x"}, false, ""},
+	}
+	for _, tt := range tests {
+		if got := BooleanQuery(tt.tokens, tt.requireAll); got != tt.want {
+			t.Errorf("%s: BooleanQuery = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokens    []string
+		fields    []Field
+		relevance float64
+		matched   []string
+	}{
+		{
+			name:      "exact",
+			tokens:    []string{"vai"},
+			fields:    []Field{{"sku", "VAI-01", 1}},
+			relevance: 1,
+			matched:   []string{"sku"},
+		},
+		{
+			name:      "prefix",
+			tokens:    []string{"cot"},
+			fields:    []Field{{"fiber", "Cotton", 1}},
+			relevance: 0.8,
+			matched:   []string{"fiber"},
+		},
+		{
+			name:      "fuzzy",
+			tokens:    []string{"coton"},
+			fields:    []Field{{"fiber", "cotton", 1}},
+			relevance: 0.5,
+			matched:   []string{"fiber"},
+		},
+		{
+			name:      "every token must match",
+			tokens:    []string{"vai", "xyz"},
+			fields:    []Field{{"name", "vải đỏ", 1}},
+			relevance: 0,
+		},
+		{
+			name:      "best field weight counts",
+			tokens:    []string{"do"},
+			fields:    []Field{{"name", "Vải đỏ", 2}, {"description", "đỏ", 1}},
+			relevance: 1,
+			matched:   []string{"name", "description"},
+		},
+		{
+			name:      "without phrase",
+			tokens:    []string{"vai", "do"},
+			fields:    []Field{{"name", "vai", 2}, {"description", "do vai", 1}},
+			relevance: 0.75,
+			matched:   []string{"name", "description"},
+		},
+		{
+			name:      "phrase bonus",
+			tokens:    []string{"vai", "do"},
+			fields:    []Field{{"name", "vai", 2}, {"description", "vải đỏ", 1}},
+			relevance: 1,
+			matched:   []string{"name", "description"},
+		},
+		{
+			name:      "no tokens",
+			fields:    []Field{{"name", "vai", 1}},
+			relevance: 0,
+		},
+	}
+	for _, tt := range tests {
+		got := Score(tt.tokens, tt.fields)
+		if math.Abs(got.Relevance-tt.relevance) > 1e-9 {
+			t.Errorf("%s: relevance = %v, want %v", tt.name, got.Relevance, tt.relevance)
+		}
+		if len(got.MatchedFields) != 0 || len(tt.matched) != 0 {
+			if !reflect.DeepEqual(got.MatchedFields, tt.matched) {
+				t.Errorf("%s: matched fields = %v, want %v", tt.name, got.MatchedFields, tt.matched)
+			}
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		tokens   []string
+		maxRunes int
+		want     string
+		ok       bool
+	}{
+		{
+			name:   "marks matching words with diacritics",
+			text:   "Vải đỏ cotton",
+			tokens: []string{"do"},
+			want:   "Vải <em>đỏ</em> cotton",
+			ok:     true,
+		},
+		{
+			name:   "no match",
+			text:   "Vải đỏ",
+			tokens: []string{"xanh"},
+		},
+		{
+			name:   "escapes markup in the text",
+			text:   `<script>alert("x")</script> vải`,
+			tokens: []string{"vai"},
+			want:   "&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; <em>vải</em>",
+			ok:     true,
+		},
+		{
+			name:   "escapes inside the highlight",
+			text:   "a&b",
+			tokens: []string{"a"},
+			want:   "<em>a</em>&amp;b",
+			ok:     true,
+		},
+		{
+			name:     "snippet around the first match",
+			text:     strings.Repeat("aaa ", 20) + "target" + strings.Repeat(" aaa", 20),
+			tokens:   []string{"target"},
+			maxRunes: 20,
+			want:     "… aaa <em>target</em> aaa aaa …",
+			ok:       true,
+		},
+	}
+	for _, tt := range tests {
+		got, ok := Highlight(tt.text, tt.tokens, tt.maxRunes)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: Highlight = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"Minh Anh Textile", "Textile Minh Anh", 1},
+		{"Dệt May", "det may", 1},
+		{"abc", "abd", 1 - 1.0/3},
+		{"", "", 1},
+	}
+	for _, tt := range tests {
+		if got := Similarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Similarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}